@@ -39,8 +39,14 @@ import (
 	_ "volcano.sh/volcano/pkg/controllers/podgroup"
 	_ "volcano.sh/volcano/pkg/controllers/queue"
 
+	_ "volcano.sh/volcano-global/pkg/controllers/batchjob"
 	_ "volcano.sh/volcano-global/pkg/controllers/deployment"
-	_ "volcano.sh/volcano-global/pkg/dispatcher"
+	_ "volcano.sh/volcano-global/pkg/controllers/namespaceonboarding"
+	_ "volcano.sh/volcano-global/pkg/controllers/namespacequeue"
+	_ "volcano.sh/volcano-global/pkg/controllers/podgroupstatus"
+	_ "volcano.sh/volcano-global/pkg/controllers/propagation"
+	_ "volcano.sh/volcano-global/pkg/controllers/workloadpodgroup"
+	"volcano.sh/volcano-global/pkg/dispatcher"
 )
 
 const componentName = "volcano-global-controller-manager"
@@ -49,25 +55,8 @@ func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
 	klog.InitFlags(nil)
 
-	fs := pflag.CommandLine
 	s := options.NewServerOption()
-
-	var knownControllers = func() []string {
-		var controllerNames []string
-		fn := func(controller framework.Controller) {
-			controllerNames = append(controllerNames, controller.Name())
-		}
-		framework.ForeachController(fn)
-		sort.Strings(controllerNames)
-		return controllerNames
-	}
-
-	s.AddFlags(fs, knownControllers())
-	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
-
-	commonutil.LeaderElectionDefault(&s.LeaderElection)
-	s.LeaderElection.ResourceName = componentName
-	componentbaseoptions.BindLeaderElectionFlags(&s.LeaderElection, fs)
+	addFlags(pflag.CommandLine, s)
 
 	cliflag.InitFlags()
 
@@ -79,6 +68,10 @@ func main() {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
+	if err := dispatcher.CheckOptions(s.LeaderElection.LeaderElect); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 	if s.CaCertFile != "" && s.CertFile != "" && s.KeyFile != "" {
 		if err := s.ParseCAFiles(nil); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to parse CA files: %v\n", err)
@@ -94,3 +87,25 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// addFlags registers the flags of the controllers and the dispatcher onto the flag set, pflag rejects the unknown
+// flags, so all of them must be registered before it's parsed.
+func addFlags(fs *pflag.FlagSet, s *options.ServerOption) {
+	var knownControllers = func() []string {
+		var controllerNames []string
+		fn := func(controller framework.Controller) {
+			controllerNames = append(controllerNames, controller.Name())
+		}
+		framework.ForeachController(fn)
+		sort.Strings(controllerNames)
+		return controllerNames
+	}
+
+	s.AddFlags(fs, knownControllers())
+	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
+	dispatcher.AddFlags(fs, &s.KubeClientOptions)
+
+	commonutil.LeaderElectionDefault(&s.LeaderElection)
+	s.LeaderElection.ResourceName = componentName
+	componentbaseoptions.BindLeaderElectionFlags(&s.LeaderElection, fs)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	goflag "flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+	"volcano.sh/volcano/cmd/controller-manager/app/options"
+
+	"volcano.sh/volcano-global/pkg/dispatcher"
+)
+
+// deployedArgs returns the args of the controller-manager container in the shipped Deployment.
+func deployedArgs(t *testing.T) []string {
+	data, err := os.ReadFile("../../docs/deploy/volcano-global-controller-manager.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read the deployment, err: %v", err)
+	}
+	for _, doc := range strings.Split(string(data), "\n---\n") {
+		deployment := &appsv1.Deployment{}
+		if err := yaml.Unmarshal([]byte(doc), deployment); err != nil {
+			t.Fatalf("Failed to decode the deployment, err: %v", err)
+		}
+		if deployment.Kind == "Deployment" {
+			return deployment.Spec.Template.Spec.Containers[0].Args
+		}
+	}
+	t.Fatalf("The controller-manager Deployment not found")
+	return nil
+}
+
+func TestAddFlags(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		args             []string
+		expectKubeConfig string
+		expectError      bool
+		expectCheckError bool
+	}{
+		{
+			Name:             "Deployed args",
+			args:             deployedArgs(t),
+			expectKubeConfig: "/etc/kubeconfig/karmada.config",
+		},
+		{
+			Name:             "Dispatcher flags",
+			args:             []string{"--kubeconfig=/x", "--dispatch-period=2s", "--shard-group=g", "--leader-elect=false", "--enable-scheduler-estimator"},
+			expectKubeConfig: "/x",
+		},
+		{
+			Name:             "Shard group with leader election",
+			args:             []string{"--kubeconfig=/x", "--shard-group=g"},
+			expectKubeConfig: "/x",
+			expectCheckError: true,
+		},
+		{
+			Name:        "Unknown flag",
+			args:        []string{"--unknown-flag=x"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		goFlags := goflag.NewFlagSet(tc.Name, goflag.ContinueOnError)
+		klog.InitFlags(goFlags)
+		fs := pflag.NewFlagSet(tc.Name, pflag.ContinueOnError)
+		fs.AddGoFlagSet(goFlags)
+		s := options.NewServerOption()
+		addFlags(fs, s)
+
+		err := fs.Parse(tc.args)
+		if (err != nil) != tc.expectError {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectError)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if s.KubeClientOptions.KubeConfig != tc.expectKubeConfig {
+			t.Errorf("Test case %s failed, got kubeconfig: %s expect: %s", tc.Name, s.KubeClientOptions.KubeConfig, tc.expectKubeConfig)
+		}
+		if err := dispatcher.CheckOptions(s.LeaderElection.LeaderElect); (err != nil) != tc.expectCheckError {
+			t.Errorf("Test case %s failed, got check err: %v expect error: %v", tc.Name, err, tc.expectCheckError)
+		}
+	}
+}
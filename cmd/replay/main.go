@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/component-base/cli"
+
+	"volcano.sh/volcano-global/pkg/cli/replay"
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "replay",
+		Short: "replay reproduces a dispatching round of the volcano-global dispatcher offline",
+		Long: "replay rebuilds the cache of the dispatcher from a cache dump and executes the actions and the plugins " +
+			"of the configuration, then compares the decisions with the audit log.",
+		Example: "replay --cache-dump dump.yaml --audit-log audit.log --dispatcher-conf dispatcher.conf --cycle 42",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return replay.ReplayRound()
+		},
+	}
+	replay.InitReplayFlags(rootCmd)
+
+	code := cli.Run(rootCmd)
+	os.Exit(code)
+}
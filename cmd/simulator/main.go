@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/component-base/cli"
+
+	"volcano.sh/volcano-global/pkg/cli/simulator"
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "simulator",
+		Short: "simulator runs the dispatching of the volcano-global dispatcher over the synthetic workloads",
+		Long: "simulator creates the workloads of the trace when they arrive in the simulated time, dispatches them by " +
+			"the actions and the plugins of the configuration and reports the wait times and the fairness of the queues.",
+		Example: "simulator --queues queues.yaml --trace trace.csv --dispatcher-conf dispatcher.conf",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return simulator.RunSimulation()
+		},
+	}
+	simulator.InitSimulatorFlags(rootCmd)
+
+	code := cli.Run(rootCmd)
+	os.Exit(code)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"volcano.sh/volcano/cmd/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/dispatch"
+	"volcano.sh/volcano-global/pkg/cli/explain"
+	"volcano.sh/volcano-global/pkg/cli/queue"
+	"volcano.sh/volcano-global/pkg/cli/resourcebinding"
+)
+
+type command struct {
+	Use         string
+	Short       string
+	Args        cobra.PositionalArgs
+	RunFunction func(cmd *cobra.Command, args []string)
+	InitFlags   func(cmd *cobra.Command)
+}
+
+func buildCmd(use, short string, aliases []string, commands []command) *cobra.Command {
+	parentCmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+	}
+
+	for _, command := range commands {
+		cmd := &cobra.Command{
+			Use:   command.Use,
+			Short: command.Short,
+			Args:  command.Args,
+			Run:   command.RunFunction,
+		}
+		command.InitFlags(cmd)
+		parentCmd.AddCommand(cmd)
+	}
+
+	return parentCmd
+}
+
+func buildQueueCmd() *cobra.Command {
+	return buildCmd("queue", "Queue Operations", nil, []command{
+		{
+			Use:   "list",
+			Short: "lists all the queues with the federation-level backlog",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, queue.ListQueue(cmd.Context()))
+			},
+			InitFlags: queue.InitListFlags,
+		},
+		{
+			Use:   "describe",
+			Short: "describe a queue and its suspended ResourceBindings",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, queue.DescribeQueue(cmd.Context()))
+			},
+			InitFlags: queue.InitDescribeFlags,
+		},
+		{
+			Use:   "usage",
+			Short: "show the deserved, used, borrowed and lent resources of the queues",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, queue.QueueUsage(cmd.Context()))
+			},
+			InitFlags: queue.InitUsageFlags,
+		},
+	})
+}
+
+func buildResourceBindingCmd() *cobra.Command {
+	return buildCmd("resourcebinding", "ResourceBinding Operations", []string{"rb"}, []command{
+		{
+			Use:   "list",
+			Short: "lists the ResourceBindings with the dispatching state",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, resourcebinding.ListResourceBindings(cmd.Context()))
+			},
+			InitFlags: resourcebinding.InitListFlags,
+		},
+		{
+			Use:   "release <namespace>/<name>",
+			Short: "releases a ResourceBinding by force, bypassing the plugins and its hold",
+			Args:  cobra.ExactArgs(1),
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, resourcebinding.ReleaseResourceBinding(cmd.Context(), args))
+			},
+			InitFlags: resourcebinding.InitReleaseFlags,
+		},
+		{
+			Use:   "hold <namespace>/<name>",
+			Short: "holds a ResourceBinding, it's never dispatched until the hold is removed",
+			Args:  cobra.ExactArgs(1),
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, resourcebinding.HoldResourceBinding(cmd.Context(), args))
+			},
+			InitFlags: resourcebinding.InitHoldFlags,
+		},
+		{
+			Use:   "unhold <namespace>/<name>",
+			Short: "removes the hold of a ResourceBinding",
+			Args:  cobra.ExactArgs(1),
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, resourcebinding.UnholdResourceBinding(cmd.Context(), args))
+			},
+			InitFlags: resourcebinding.InitUnholdFlags,
+		},
+	})
+}
+
+func buildDispatchCmd() *cobra.Command {
+	return buildCmd("dispatch", "Dispatch Operations", nil, []command{
+		{
+			Use:   "status",
+			Short: "show the dispatching state and the paused queues",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, dispatch.DispatchStatus(cmd.Context()))
+			},
+			InitFlags: dispatch.InitStatusFlags,
+		},
+	})
+}
+
+func buildExplainCmd() *cobra.Command {
+	explainCmd := &cobra.Command{
+		Use:   "explain <namespace>/<name>",
+		Short: "explain why a ResourceBinding is still suspended",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckError(cmd, explain.ExplainResourceBinding(cmd.Context(), args))
+		},
+	}
+	explain.InitExplainFlags(explainCmd)
+	return explainCmd
+}
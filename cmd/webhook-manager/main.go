@@ -20,16 +20,32 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"syscall"
 	"time"
 
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/klog/v2"
 	"volcano.sh/volcano/cmd/webhook-manager/app"
 	"volcano.sh/volcano/cmd/webhook-manager/app/options"
+	"volcano.sh/volcano/pkg/kube"
 	"volcano.sh/volcano/pkg/version"
 
-	_ "volcano.sh/volcano-global/pkg/webhooks/resourcebinding/mutating"
+	"volcano.sh/volcano-global/pkg/utils"
+	"volcano.sh/volcano-global/pkg/webhooks/certs"
+	_ "volcano.sh/volcano-global/pkg/webhooks/queue/mutating"
+	queuevalidating "volcano.sh/volcano-global/pkg/webhooks/queue/validating"
+	"volcano.sh/volcano-global/pkg/webhooks/resourcebinding/mutating"
+	_ "volcano.sh/volcano-global/pkg/webhooks/resourcebinding/validating"
+	workloadmutating "volcano.sh/volcano-global/pkg/webhooks/workload/mutating"
 )
 
 func main() {
@@ -39,6 +55,27 @@ func main() {
 	config := options.NewConfig()
 	config.AddFlags(pflag.CommandLine)
 
+	utilfeature.DefaultMutableFeatureGate.AddFlag(pflag.CommandLine)
+
+	var extraWorkloads []string
+	pflag.CommandLine.StringSliceVar(&extraWorkloads, "extra-workloads", nil,
+		"The extra workloads which should be suspended, in Kind.version.group format, like TFJob.v1.kubeflow.org")
+	var certSecretName string
+	var certValidity, certRotateBefore time.Duration
+	pflag.CommandLine.StringVar(&certSecretName, "cert-secret-name", "volcano-global-webhook-certs",
+		"The Secret in the webhook namespace to save the self-signed certificates, they are used when the tls cert and key files are not set")
+	pflag.CommandLine.DurationVar(&certValidity, "cert-validity", 365*24*time.Hour, "The validity of the self-signed certificates")
+	pflag.CommandLine.DurationVar(&certRotateBefore, "cert-rotate-before", 30*24*time.Hour,
+		"Rotate the self-signed certificates when they expire within the duration, the webhook manager restarts to serve with the new certificates")
+	var namespaceSelector, objectSelector string
+	pflag.CommandLine.StringVar(&namespaceSelector, "suspension-namespace-selector", "",
+		"The label selector of the namespaces whose workloads will be suspended, like `kubernetes.io/metadata.name notin (kube-system)`, empty means all")
+	pflag.CommandLine.StringVar(&objectSelector, "suspension-object-selector", "",
+		"The label selector of the ResourceBindings which will be suspended, empty means all")
+	var defaultQueue string
+	pflag.CommandLine.StringVar(&defaultQueue, "default-queue", "default",
+		"The default queue name of the workloads which didn't set a queue, the queue annotation of the namespace takes precedence")
+
 	cliflag.InitFlags()
 
 	if config.PrintVersion {
@@ -53,7 +90,79 @@ func main() {
 		klog.Fatalf("Configured port check failed: %v", err)
 	}
 
-	if err := config.ParseCAFiles(nil); err != nil {
+	workloadGVKs := make([]schema.GroupVersionKind, 0, len(extraWorkloads))
+	for _, workload := range extraWorkloads {
+		gvk, err := utils.ParseWorkloadGVK(workload)
+		if err != nil {
+			klog.Fatalf("Failed to parse extra workloads: %v", err)
+		}
+		workloadGVKs = append(workloadGVKs, gvk)
+	}
+	utils.DefaultWorkloadRegistry.SetExtraWorkloads(workloadGVKs)
+	mutating.SetDefaultQueue(defaultQueue)
+
+	restConfig, err := kube.BuildConfig(config.KubeClientOptions)
+	if err != nil {
+		klog.Fatalf("Unable to build k8s config: %v", err)
+	}
+	suspendMode := utils.DetectSuspendMode(restConfig)
+	mutating.SetSuspendMode(suspendMode)
+	workloadmutating.SetSuspendMode(suspendMode)
+	if suspendMode == utils.SuspendModeWorkload {
+		if err := workloadmutating.Register(); err != nil {
+			klog.Fatalf("Failed to register the workload mutating webhook: %v", err)
+		}
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("Failed to init kubeClient: %v", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("Failed to init dynamicClient: %v", err)
+	}
+	mutating.SetWorkloadClient(dynamicClient, restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeClient.Discovery())))
+	karmadaClient, err := karmadaclientset.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("Failed to init karmadaClient: %v", err)
+	}
+	queuevalidating.SetKarmadaClient(karmadaClient)
+
+	if namespaceSelector != "" || objectSelector != "" {
+		nsSelector, err := parseLabelSelector(namespaceSelector)
+		if err != nil {
+			klog.Fatalf("Invalid suspension namespace selector: %v", err)
+		}
+		objSelector, err := parseLabelSelector(objectSelector)
+		if err != nil {
+			klog.Fatalf("Invalid suspension object selector: %v", err)
+		}
+		// The webhook configuration may be created after the webhook manager starts, wait for it in background.
+		go func() {
+			if err := mutating.ApplyWebhookSelectors(kubeClient, nsSelector, objSelector); err != nil {
+				klog.Fatalf("Failed to apply the selectors of the suspension webhook: %v", err)
+			}
+		}()
+	}
+
+	if config.CertFile == "" && config.KeyFile == "" {
+		// Use the self-signed certificates, so the installation doesn't depend on the cert-manager.
+		certManager := certs.NewManager(kubeClient, config.WebhookNamespace, config.WebhookName, certSecretName, certValidity, certRotateBefore)
+		certificates, err := certManager.Bootstrap()
+		if err != nil {
+			klog.Fatalf("Failed to bootstrap the self-signed certificates: %v", err)
+		}
+		config.CaCertData, config.CertData, config.KeyData = certificates.CACert, certificates.Cert, certificates.Key
+
+		// The webhook server can't reload the certificates, restart it gracefully after the rotation.
+		go certManager.WatchExpiry(certificates, make(chan struct{}), func() {
+			klog.Infof("The webhook certificates are rotated, restart to serve with the new certificates.")
+			if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+				klog.Fatalf("Failed to restart after the certificates rotated: %v", err)
+			}
+		})
+	} else if err := config.ParseCAFiles(nil); err != nil {
 		klog.Fatalf("Failed to parse CA files: %v", err)
 	}
 
@@ -62,3 +171,11 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// parseLabelSelector parses the label selector, an empty selector means matching everything.
+func parseLabelSelector(selector string) (*metav1.LabelSelector, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	return metav1.ParseToLabelSelector(selector)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 is the v1alpha1 version of the dispatch.volcano.sh API group of volcano-global.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/strings/slices"
+)
+
+// SchemeGroupVersion is the group version of the API.
+var SchemeGroupVersion = schema.GroupVersion{Group: "dispatch.volcano.sh", Version: "v1alpha1"}
+
+// DispatchPolicyResource is the resource of the DispatchPolicy, it's watched by the dynamic informer.
+var DispatchPolicyResource = SchemeGroupVersion.WithResource("dispatchpolicies")
+
+// DispatchPolicy configures the dispatching of the queues declaratively, it's cluster-scoped. The dispatcher reconciles
+// it into the annotations of the queues in each round, so the settings take effect without restarting the dispatcher.
+type DispatchPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DispatchPolicySpec `json:"spec"`
+}
+
+// DispatchPolicySpec is the dispatch settings of the queues, the unset ones are left to the queues.
+type DispatchPolicySpec struct {
+	// Queues are the names of the queues the policy applies to, it applies to all the queues if it's empty.
+	// +optional
+	Queues []string `json:"queues,omitempty"`
+
+	// Paused pauses the dispatching of the queues.
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+
+	// DispatchWindow declares the dispatch windows of the queues, like `20:00-06:00` or `0 20 * * 1-5 10h`,
+	// multiple windows are separated by `;`.
+	// +optional
+	DispatchWindow string `json:"dispatchWindow,omitempty"`
+
+	// MaxInFlightWorkloads limits how many workloads of each queue can be dispatched but not running yet.
+	// +optional
+	MaxInFlightWorkloads *int32 `json:"maxInFlightWorkloads,omitempty"`
+
+	// NamespaceFairness orders the workloads of the queues by taking turns between the namespaces,
+	// `round-robin` or `weighted`.
+	// +optional
+	NamespaceFairness string `json:"namespaceFairness,omitempty"`
+
+	// DispatchOrder orders the workloads of the queues, `priority` by the order plugins, which is the default,
+	// or `fifo` by their creation time only.
+	// +optional
+	DispatchOrder string `json:"dispatchOrder,omitempty"`
+
+	// DispatchRateLimit limits how many workloads of each queue are dispatched in a period, like `10/1m`.
+	// +optional
+	DispatchRateLimit string `json:"dispatchRateLimit,omitempty"`
+
+	// Preemptable is false to never suspend the dispatched workloads of the queues again to reclaim their resources.
+	// +optional
+	Preemptable *bool `json:"preemptable,omitempty"`
+
+	// PreemptQueues are the queues whose dispatched workloads can be suspended again for the workloads of the
+	// queues by the reclaim action, `*` means all the queues.
+	// +optional
+	PreemptQueues []string `json:"preemptQueues,omitempty"`
+
+	// ResuspendOnGrowth suspends the dispatched workloads of the queues again when they grow, like scaled up.
+	// +optional
+	ResuspendOnGrowth *bool `json:"resuspendOnGrowth,omitempty"`
+
+	// MinResourcesSource decides the min resources of the gangs of the workloads of the queues, `podGroup` takes the
+	// `spec.minResources` of their PodGroups when they set them, and `workload` resolves them from the workloads always.
+	// +optional
+	MinResourcesSource string `json:"minResourcesSource,omitempty"`
+
+	// FirstStageAdmission admits the staged workloads of the queues once the queues can fit their first stage.
+	// +optional
+	FirstStageAdmission *bool `json:"firstStageAdmission,omitempty"`
+
+	// AdmissionChecks are the condition types which must be True on the ResourceBindings of the queues before
+	// they're dispatched, the conditions are set by the external admission check controllers.
+	// +optional
+	AdmissionChecks []string `json:"admissionChecks,omitempty"`
+
+	// ElasticCluster is the cluster the workloads of the queues which no cluster has room for are released toward
+	// speculatively, so its cluster autoscaler can scale up for them.
+	// +optional
+	ElasticCluster string `json:"elasticCluster,omitempty"`
+
+	// MaxSpeculativeReleases limits how many workloads of each queue can be released toward the elastic cluster
+	// speculatively but not running yet.
+	// +optional
+	MaxSpeculativeReleases *int32 `json:"maxSpeculativeReleases,omitempty"`
+
+	// SuspendedTTL is how long the workloads of the queues can wait suspended, like `72h`, the ones waiting longer
+	// are marked Expired and never dispatched unless released by force.
+	// +optional
+	SuspendedTTL string `json:"suspendedTTL,omitempty"`
+
+	// ExpiryPolicy declares what happens to the expired workloads of the queues, `Condition` tells the users by the
+	// condition and the event only, `Annotate` annotates the workloads too and `Delete` deletes them.
+	// +optional
+	ExpiryPolicy string `json:"expiryPolicy,omitempty"`
+}
+
+// Selects checks whether the policy applies to the queue.
+func (spec *DispatchPolicySpec) Selects(queueName string) bool {
+	return len(spec.Queues) == 0 || slices.Contains(spec.Queues, queueName)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+type statusFlags struct {
+	vcutil.CommonFlags
+
+	util.DispatcherTLSFlags
+
+	DispatcherAddress string
+}
+
+var dispatchStatusFlags = &statusFlags{}
+
+// pauseStatus is the response of the `/dispatcher/pause` endpoint of the dispatcher.
+type pauseStatus struct {
+	Global bool     `json:"global"`
+	Queues []string `json:"queues"`
+}
+
+// summary is the dispatching state of the ResourceBindings.
+type summary struct {
+	Total      int
+	Suspended  int
+	Dispatched int
+	OptedOut   int
+	// Blocked[reason] is the number of the suspended ResourceBindings blocked by the reason.
+	Blocked map[string]int
+}
+
+// InitStatusFlags inits all flags.
+func InitStatusFlags(cmd *cobra.Command) {
+	vcutil.InitFlags(cmd, &dispatchStatusFlags.CommonFlags)
+
+	cmd.Flags().StringVar(&dispatchStatusFlags.DispatcherAddress, "dispatcher-address", "",
+		"the address of the dispatcher like "+util.DefaultDispatcherAddress+", used to get the paused queues by the admin endpoint")
+	util.InitDispatcherTLSFlags(cmd, &dispatchStatusFlags.DispatcherTLSFlags)
+}
+
+// DispatchStatus shows the dispatching state of the ResourceBindings and the paused queues.
+func DispatchStatus(ctx context.Context) error {
+	clients, err := util.NewClients(&dispatchStatusFlags.CommonFlags)
+	if err != nil {
+		return err
+	}
+	rbs, err := clients.ListResourceBindings(ctx, "")
+	if err != nil {
+		return err
+	}
+	queues, err := clients.Volcano.SchedulingV1beta1().Queues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var pausedQueues []string
+	for _, queue := range queues.Items {
+		if queue.Annotations[api.QueuePausedAnnotationKey] == "true" || queue.Annotations[api.QueuePausedByAnnotationKey] != "" {
+			pausedQueues = append(pausedQueues, queue.Name)
+		}
+	}
+
+	var pause *pauseStatus
+	if dispatchStatusFlags.DispatcherAddress != "" {
+		pause = &pauseStatus{}
+		if err = util.GetDispatcherJSON(ctx, dispatchStatusFlags.DispatcherAddress, "/dispatcher/pause",
+			util.DispatcherToken(&dispatchStatusFlags.CommonFlags, ""), &dispatchStatusFlags.DispatcherTLSFlags, pause); err != nil {
+			return err
+		}
+	}
+
+	PrintStatus(summarize(rbs), pausedQueues, pause, os.Stdout)
+	return nil
+}
+
+func summarize(rbs []workv1alpha2.ResourceBinding) *summary {
+	s := &summary{Blocked: map[string]int{}}
+	for _, rb := range rbs {
+		s.Total++
+		if utils.IsDispatchDisabled(&rb) {
+			s.OptedOut++
+			continue
+		}
+		if !utils.IsResourceBindingSuspended(&rb) {
+			s.Dispatched++
+			continue
+		}
+		s.Suspended++
+		if condition := util.DispatchedCondition(&rb); condition != nil && condition.Status == metav1.ConditionFalse {
+			s.Blocked[condition.Reason]++
+		}
+	}
+	return s
+}
+
+// PrintStatus prints the summary of the ResourceBindings and the paused queues.
+func PrintStatus(s *summary, pausedQueues []string, pause *pauseStatus, writer io.Writer) {
+	fmt.Fprintf(writer, "ResourceBindings:  %d\n", s.Total)
+	fmt.Fprintf(writer, "  Suspended:       %d\n", s.Suspended)
+	fmt.Fprintf(writer, "  Dispatched:      %d\n", s.Dispatched)
+	fmt.Fprintf(writer, "  Opted out:       %d\n", s.OptedOut)
+
+	if len(s.Blocked) != 0 {
+		reasons := make([]string, 0, len(s.Blocked))
+		for reason := range s.Blocked {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+
+		fmt.Fprintf(writer, "\nBlocked by:\n")
+		for _, reason := range reasons {
+			fmt.Fprintf(writer, "  %-24s%d\n", reason, s.Blocked[reason])
+		}
+	}
+
+	fmt.Fprintf(writer, "\nPaused queues (annotation):  %s\n", joinOrNone(pausedQueues))
+	if pause != nil {
+		fmt.Fprintf(writer, "Paused globally (config):    %t\n", pause.Global)
+		fmt.Fprintf(writer, "Paused queues (endpoint):    %s\n", joinOrNone(pause.Queues))
+	}
+}
+
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+type explainFlags struct {
+	vcutil.CommonFlags
+
+	util.DispatcherTLSFlags
+
+	DispatcherAddress string
+	Token             string
+	ControlPlane      string
+}
+
+var explainResourceBindingFlags = &explainFlags{}
+
+// InitExplainFlags inits all flags.
+func InitExplainFlags(cmd *cobra.Command) {
+	vcutil.InitFlags(cmd, &explainResourceBindingFlags.CommonFlags)
+
+	util.InitDispatcherTLSFlags(cmd, &explainResourceBindingFlags.DispatcherTLSFlags)
+
+	cmd.Flags().StringVar(&explainResourceBindingFlags.DispatcherAddress, "dispatcher-address", util.DefaultDispatcherAddress,
+		"the address of the dispatcher which serves the debug endpoints")
+	cmd.Flags().StringVar(&explainResourceBindingFlags.Token, "token", "",
+		"the bearer token for the debug endpoints, the token of the kubeconfig is used by default")
+	cmd.Flags().StringVar(&explainResourceBindingFlags.ControlPlane, "control-plane", "",
+		"the Karmada control plane of the ResourceBinding, the first one served by the dispatcher is used by default")
+}
+
+// ExplainResourceBinding asks the dispatcher why the ResourceBinding `<namespace>/<name>` is still suspended.
+func ExplainResourceBinding(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expect one ResourceBinding like <namespace>/<name>, got %d", len(args))
+	}
+	key, err := util.ParseKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("namespace", key.Namespace)
+	query.Set("name", key.Name)
+	if explainResourceBindingFlags.ControlPlane != "" {
+		query.Set("controlPlane", explainResourceBindingFlags.ControlPlane)
+	}
+	explanation := &api.Explanation{}
+	token := util.DispatcherToken(&explainResourceBindingFlags.CommonFlags, explainResourceBindingFlags.Token)
+	if err = util.GetDispatcherJSON(ctx, explainResourceBindingFlags.DispatcherAddress, "/debug/explain?"+query.Encode(), token,
+		&explainResourceBindingFlags.DispatcherTLSFlags, explanation); err != nil {
+		return err
+	}
+	PrintExplanation(explanation, os.Stdout)
+	return nil
+}
+
+// PrintExplanation prints the explanation of the ResourceBinding.
+func PrintExplanation(explanation *api.Explanation, writer io.Writer) {
+	fmt.Fprintf(writer, "ResourceBinding:  %s/%s\n", explanation.Namespace, explanation.Name)
+	if explanation.Queue != "" {
+		fmt.Fprintf(writer, "Queue:            %s\n", explanation.Queue)
+	}
+	if explanation.DispatchStatus != "" {
+		fmt.Fprintf(writer, "Status:           %s\n", explanation.DispatchStatus)
+	}
+	fmt.Fprintf(writer, "Dispatchable:     %t\n", explanation.Dispatchable)
+	fmt.Fprintf(writer, "Reason:           %s\n", explanation.Reason)
+	if explanation.Plugin != "" {
+		fmt.Fprintf(writer, "Blocked by:       %s\n", explanation.Plugin)
+	}
+	fmt.Fprintf(writer, "Message:          %s\n", explanation.Message)
+	if explanation.Ahead > 0 {
+		fmt.Fprintf(writer, "Ahead:            %d\n", explanation.Ahead)
+	}
+	for _, note := range explanation.Notes {
+		fmt.Fprintf(writer, "Note:             %s\n", note)
+	}
+
+	// Print the transitions from the earliest one.
+	statuses := make([]string, 0, len(explanation.TransitionTimes))
+	for status := range explanation.TransitionTimes {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return explanation.TransitionTimes[statuses[i]].Before(explanation.TransitionTimes[statuses[j]])
+	})
+	for _, status := range statuses {
+		fmt.Fprintf(writer, "Transition:       %-12s%s\n", status, explanation.TransitionTimes[status].Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// backlog is the federation-level backlog of a queue.
+type backlog struct {
+	// Suspended is the number of the ResourceBindings waiting to be dispatched.
+	Suspended int
+	// Dispatched is the number of the ResourceBindings released to the member clusters.
+	Dispatched int
+}
+
+// countBacklogs counts the backlog of each queue by the ResourceBindings.
+func countBacklogs(rbs []workv1alpha2.ResourceBinding) map[string]*backlog {
+	backlogs := map[string]*backlog{}
+	for i := range rbs {
+		rb := &rbs[i]
+		if utils.IsDispatchDisabled(rb) {
+			continue
+		}
+		queueName := util.ResourceBindingQueue(rb)
+		if backlogs[queueName] == nil {
+			backlogs[queueName] = &backlog{}
+		}
+		if utils.IsResourceBindingSuspended(rb) {
+			backlogs[queueName].Suspended++
+		} else {
+			backlogs[queueName].Dispatched++
+		}
+	}
+	return backlogs
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+func TestCountBacklogs(t *testing.T) {
+	rb := func(queue string, suspended bool, annotations map[string]string) workv1alpha2.ResourceBinding {
+		if queue != "" {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[schedulingv1beta1.QueueNameAnnotationKey] = queue
+		}
+		return workv1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec:       workv1alpha2.ResourceBindingSpec{Suspend: suspended},
+		}
+	}
+
+	rbs := []workv1alpha2.ResourceBinding{
+		rb("q1", true, nil),
+		rb("q1", true, nil),
+		rb("q1", false, nil),
+		rb("", true, nil),
+		rb("q2", true, map[string]string{utils.DispatchAnnotationKey: "false"}),
+	}
+	expect := map[string]*backlog{
+		"q1":      {Suspended: 2, Dispatched: 1},
+		"default": {Suspended: 1},
+	}
+
+	if got := countBacklogs(rbs); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Test case count backlogs failed, got: %v expect: %v", got, expect)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+type describeFlags struct {
+	vcutil.CommonFlags
+
+	Name string
+}
+
+var describeQueueFlags = &describeFlags{}
+
+// dispatchAnnotationKeys are the annotations of the Queue which change the dispatching.
+var dispatchAnnotationKeys = []string{
+	api.QueuePausedAnnotationKey,
+	api.DispatchWindowAnnotationKey,
+	api.MaxInFlightAnnotationKey,
+	api.SuspendedTTLAnnotationKey,
+	api.ExpiryPolicyAnnotationKey,
+}
+
+// InitDescribeFlags inits all flags.
+func InitDescribeFlags(cmd *cobra.Command) {
+	vcutil.InitFlags(cmd, &describeQueueFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&describeQueueFlags.Name, "name", "n", "", "the name of queue")
+}
+
+// DescribeQueue shows the details of the queue and its suspended ResourceBindings.
+func DescribeQueue(ctx context.Context) error {
+	if describeQueueFlags.Name == "" {
+		return fmt.Errorf("name is mandatory to describe a queue")
+	}
+
+	clients, err := util.NewClients(&describeQueueFlags.CommonFlags)
+	if err != nil {
+		return err
+	}
+
+	queue, err := clients.Volcano.SchedulingV1beta1().Queues().Get(ctx, describeQueueFlags.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	rbs, err := clients.ListResourceBindings(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var queueRBs []workv1alpha2.ResourceBinding
+	for _, rb := range rbs {
+		if util.ResourceBindingQueue(&rb) == queue.Name {
+			queueRBs = append(queueRBs, rb)
+		}
+	}
+	PrintQueueDetail(queue, queueRBs, os.Stdout)
+	return nil
+}
+
+// PrintQueueDetail prints the queue, the annotations which change the dispatching and the suspended ResourceBindings
+// from the oldest one.
+func PrintQueueDetail(queue *schedulingv1beta1.Queue, rbs []workv1alpha2.ResourceBinding, writer io.Writer) {
+	b := countBacklogs(rbs)[queue.Name]
+	if b == nil {
+		b = &backlog{}
+	}
+
+	fmt.Fprintf(writer, "Name:        %s\n", queue.Name)
+	fmt.Fprintf(writer, "State:       %s\n", queue.Status.State)
+	fmt.Fprintf(writer, "Priority:    %d\n", queue.Spec.Priority)
+	fmt.Fprintf(writer, "Weight:      %d\n", queue.Spec.Weight)
+	if len(queue.Spec.Capability) != 0 {
+		fmt.Fprintf(writer, "Capability:  %v\n", queue.Spec.Capability)
+	}
+	for _, key := range dispatchAnnotationKeys {
+		if value, found := queue.Annotations[key]; found {
+			fmt.Fprintf(writer, "Annotation:  %s=%s\n", key, value)
+		}
+	}
+	fmt.Fprintf(writer, "Suspended:   %d\n", b.Suspended)
+	fmt.Fprintf(writer, "Dispatched:  %d\n", b.Dispatched)
+
+	var suspended []workv1alpha2.ResourceBinding
+	for _, rb := range rbs {
+		if utils.IsResourceBindingSuspended(&rb) && !utils.IsDispatchDisabled(&rb) {
+			suspended = append(suspended, rb)
+		}
+	}
+	if len(suspended) == 0 {
+		return
+	}
+	sort.Slice(suspended, func(i, j int) bool {
+		return suspended[i].CreationTimestamp.Before(&suspended[j].CreationTimestamp)
+	})
+
+	fmt.Fprintf(writer, "\nSuspended ResourceBindings:\n")
+	fmt.Fprintf(writer, "  %-20s%-40s%-24s%-8s\n", "Namespace", "Name", "Reason", "Age")
+	for _, rb := range suspended {
+		reason := ""
+		if condition := util.DispatchedCondition(&rb); condition != nil {
+			reason = condition.Reason
+		}
+		fmt.Fprintf(writer, "  %-20s%-40s%-24s%-8s\n", rb.Namespace, rb.Name, reason, util.Age(rb.CreationTimestamp))
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+)
+
+type listFlags struct {
+	vcutil.CommonFlags
+}
+
+var listQueueFlags = &listFlags{}
+
+// InitListFlags inits all flags.
+func InitListFlags(cmd *cobra.Command) {
+	vcutil.InitFlags(cmd, &listQueueFlags.CommonFlags)
+}
+
+// ListQueue lists all the queues with their federation-level backlog.
+func ListQueue(ctx context.Context) error {
+	clients, err := util.NewClients(&listQueueFlags.CommonFlags)
+	if err != nil {
+		return err
+	}
+
+	queues, err := clients.Volcano.SchedulingV1beta1().Queues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(queues.Items) == 0 {
+		fmt.Printf("No resources found\n")
+		return nil
+	}
+
+	rbs, err := clients.ListResourceBindings(ctx, "")
+	if err != nil {
+		return err
+	}
+	PrintQueues(queues.Items, countBacklogs(rbs), os.Stdout)
+	return nil
+}
+
+// PrintQueues prints the queues with their backlog.
+func PrintQueues(queues []schedulingv1beta1.Queue, backlogs map[string]*backlog, writer io.Writer) {
+	_, err := fmt.Fprintf(writer, "%-25s%-10s%-10s%-10s%-12s%-8s\n", "Name", "State", "Priority", "Suspended", "Dispatched", "Age")
+	if err != nil {
+		fmt.Printf("Failed to print queue command result: %s.\n", err)
+	}
+	for _, queue := range queues {
+		b := backlogs[queue.Name]
+		if b == nil {
+			b = &backlog{}
+		}
+		_, err = fmt.Fprintf(writer, "%-25s%-10s%-10d%-10d%-12d%-8s\n",
+			queue.Name, queue.Status.State, queue.Spec.Priority, b.Suspended, b.Dispatched, util.Age(queue.CreationTimestamp))
+		if err != nil {
+			fmt.Printf("Failed to print queue command result: %s.\n", err)
+		}
+	}
+}
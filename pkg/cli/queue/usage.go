@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+type usageFlags struct {
+	vcutil.CommonFlags
+
+	util.DispatcherTLSFlags
+
+	DispatcherAddress string
+	Token             string
+	ControlPlane      string
+}
+
+var usageQueueFlags = &usageFlags{}
+
+// InitUsageFlags inits all flags.
+func InitUsageFlags(cmd *cobra.Command) {
+	vcutil.InitFlags(cmd, &usageQueueFlags.CommonFlags)
+
+	util.InitDispatcherTLSFlags(cmd, &usageQueueFlags.DispatcherTLSFlags)
+
+	cmd.Flags().StringVar(&usageQueueFlags.DispatcherAddress, "dispatcher-address", util.DefaultDispatcherAddress,
+		"the address of the dispatcher which serves the debug endpoints")
+	cmd.Flags().StringVar(&usageQueueFlags.Token, "token", "",
+		"the bearer token for the debug endpoints, the token of the kubeconfig is used by default")
+	cmd.Flags().StringVar(&usageQueueFlags.ControlPlane, "control-plane", "",
+		"the Karmada control plane of the queues, the first one served by the dispatcher is used by default")
+}
+
+// QueueUsage asks the dispatcher for the deserved, used, borrowed and lent resources of the queues.
+func QueueUsage(ctx context.Context) error {
+	path := "/debug/queues/usage"
+	if usageQueueFlags.ControlPlane != "" {
+		path += "?" + url.Values{"controlPlane": []string{usageQueueFlags.ControlPlane}}.Encode()
+	}
+	var usages []api.QueueUsage
+	token := util.DispatcherToken(&usageQueueFlags.CommonFlags, usageQueueFlags.Token)
+	if err := util.GetDispatcherJSON(ctx, usageQueueFlags.DispatcherAddress, path, token, &usageQueueFlags.DispatcherTLSFlags, &usages); err != nil {
+		return err
+	}
+	if len(usages) == 0 {
+		fmt.Printf("No resources found\n")
+		return nil
+	}
+	PrintQueueUsages(usages, os.Stdout)
+	return nil
+}
+
+// PrintQueueUsages prints a line for each resource of the queues, the resources of a queue are sorted by the name.
+func PrintQueueUsages(usages []api.QueueUsage, writer io.Writer) {
+	_, err := fmt.Fprintf(writer, "%-25s%-25s%-12s%-12s%-12s%-12s\n", "Name", "Resource", "Deserved", "Used", "Borrowed", "Lent")
+	if err != nil {
+		fmt.Printf("Failed to print queue command result: %s.\n", err)
+	}
+	for _, usage := range usages {
+		names := map[corev1.ResourceName]bool{}
+		for _, resources := range []corev1.ResourceList{usage.Deserved, usage.Used, usage.Borrowed, usage.Lent} {
+			for name := range resources {
+				names[name] = true
+			}
+		}
+		resourceNames := make([]corev1.ResourceName, 0, len(names))
+		for name := range names {
+			resourceNames = append(resourceNames, name)
+		}
+		sort.Slice(resourceNames, func(i, j int) bool {
+			return resourceNames[i] < resourceNames[j]
+		})
+
+		for _, name := range resourceNames {
+			_, err = fmt.Fprintf(writer, "%-25s%-25s%-12s%-12s%-12s%-12s\n", usage.Name, name,
+				quantityOf(usage.Deserved, name), quantityOf(usage.Used, name), quantityOf(usage.Borrowed, name), quantityOf(usage.Lent, name))
+			if err != nil {
+				fmt.Printf("Failed to print queue command result: %s.\n", err)
+			}
+		}
+	}
+}
+
+func quantityOf(resources corev1.ResourceList, name corev1.ResourceName) string {
+	quantity, found := resources[name]
+	if !found {
+		return "0"
+	}
+	return quantity.String()
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"volcano.sh/volcano-global/pkg/dispatcher"
+	"volcano.sh/volcano-global/pkg/dispatcher/audit"
+)
+
+type replayFlags struct {
+	CacheDump      string
+	AuditLog       string
+	DispatcherConf string
+	ControlPlane   string
+	Cycle          uint64
+}
+
+var replayRoundFlags = &replayFlags{}
+
+// InitReplayFlags inits all flags.
+func InitReplayFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&replayRoundFlags.CacheDump, "cache-dump", "",
+		"the YAML or JSON objects in the cache of the dispatcher when the round was made, like the output of `kubectl get -o yaml`")
+	cmd.Flags().StringVar(&replayRoundFlags.AuditLog, "audit-log", "", "the audit log written by the stdout or the file sink of the dispatcher")
+	cmd.Flags().StringVar(&replayRoundFlags.DispatcherConf, "dispatcher-conf", "",
+		"the configuration of the dispatcher when the round was made, the default one is used when it's empty")
+	cmd.Flags().StringVar(&replayRoundFlags.ControlPlane, "control-plane", "",
+		"the Karmada control plane of the round, the events of all the control planes are compared when it's empty")
+	cmd.Flags().Uint64Var(&replayRoundFlags.Cycle, "cycle", 0, "the dispatching round to replay, the earliest one in the audit log by default")
+	_ = cmd.MarkFlagRequired("cache-dump")
+}
+
+// ReplayRound replays the dispatching round offline and prints its decisions, it fails when they differ from
+// the audit log.
+func ReplayRound() error {
+	options := dispatcher.ReplayOptions{ControlPlane: replayRoundFlags.ControlPlane, Cycle: replayRoundFlags.Cycle}
+
+	dump, err := os.Open(replayRoundFlags.CacheDump)
+	if err != nil {
+		return err
+	}
+	defer dump.Close()
+	if options.Objects, err = dispatcher.ReadCacheDump(dump); err != nil {
+		return err
+	}
+	if replayRoundFlags.AuditLog != "" {
+		auditLog, err := os.Open(replayRoundFlags.AuditLog)
+		if err != nil {
+			return err
+		}
+		defer auditLog.Close()
+		if options.Events, err = audit.ReadEvents(auditLog); err != nil {
+			return err
+		}
+	}
+	if replayRoundFlags.DispatcherConf != "" {
+		data, err := os.ReadFile(replayRoundFlags.DispatcherConf)
+		if err != nil {
+			return err
+		}
+		if options.Configuration, err = dispatcher.UnmarshalDispatcherConf(strings.TrimSpace(string(data))); err != nil {
+			return err
+		}
+	}
+
+	result, err := dispatcher.Replay(options)
+	if err != nil {
+		return err
+	}
+	PrintResult(result, os.Stdout)
+	if len(result.Diffs) > 0 {
+		return fmt.Errorf("%d ResourceBindings are decided differently from the audit log", len(result.Diffs))
+	}
+	return nil
+}
+
+// PrintResult prints the decisions of the replayed round in order, then the differences from the audit log.
+func PrintResult(result *dispatcher.ReplayResult, writer io.Writer) {
+	fmt.Fprintf(writer, "Cycle: %d\n\n", result.Cycle)
+	fmt.Fprintf(writer, "%-40s%-20s%-12s%-20s%-30s\n", "ResourceBinding", "Queue", "Action", "Plugin", "Reason")
+	for _, decision := range result.Decisions {
+		fmt.Fprintf(writer, "%-40s%-20s%-12s%-20s%-30s\n", decision.Namespace+"/"+decision.Name, decision.Queue,
+			replayedAction(decision.Dispatched, decision.Evicted), decision.Plugin, decision.Reason)
+	}
+	if len(result.Diffs) == 0 {
+		fmt.Fprintf(writer, "\nThe replayed decisions match the audit log.\n")
+		return
+	}
+
+	fmt.Fprintf(writer, "\n%-40s%-45s%-45s\n", "ResourceBinding", "Audited", "Replayed")
+	for _, diff := range result.Diffs {
+		audited, replayed := "-", "-"
+		if diff.Audited != nil {
+			audited = fmt.Sprintf("%s %s %s", diff.Audited.Action, diff.Audited.Plugin, diff.Audited.Reason)
+		}
+		if diff.Replayed != nil {
+			replayed = fmt.Sprintf("%s %s %s", replayedAction(diff.Replayed.Dispatched, diff.Replayed.Evicted),
+				diff.Replayed.Plugin, diff.Replayed.Reason)
+		}
+		fmt.Fprintf(writer, "%-40s%-45s%-45s\n", diff.Namespace+"/"+diff.Name, strings.Join(strings.Fields(audited), " "),
+			strings.Join(strings.Fields(replayed), " "))
+	}
+}
+
+func replayedAction(dispatched, evicted bool) string {
+	switch {
+	case dispatched:
+		return audit.ActionUnsuspend
+	case evicted:
+		return audit.ActionPreempt
+	default:
+		return audit.ActionSuspend
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcebinding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// dispatcherFlags are the flags of the commands operating the ResourceBindings by the endpoints of the dispatcher.
+type dispatcherFlags struct {
+	vcutil.CommonFlags
+
+	util.DispatcherTLSFlags
+
+	DispatcherAddress string
+	Token             string
+	ControlPlane      string
+}
+
+func initDispatcherFlags(cmd *cobra.Command, flags *dispatcherFlags) {
+	vcutil.InitFlags(cmd, &flags.CommonFlags)
+
+	util.InitDispatcherTLSFlags(cmd, &flags.DispatcherTLSFlags)
+
+	cmd.Flags().StringVar(&flags.DispatcherAddress, "dispatcher-address", util.DefaultDispatcherAddress,
+		"the address of the dispatcher which serves the endpoints")
+	cmd.Flags().StringVar(&flags.Token, "token", "",
+		"the bearer token for the endpoints, the token of the kubeconfig is used by default")
+	cmd.Flags().StringVar(&flags.ControlPlane, "control-plane", "",
+		"the Karmada control plane of the ResourceBinding, the first one served by the dispatcher is used by default")
+}
+
+var holdResourceBindingFlags = &dispatcherFlags{}
+
+// InitHoldFlags inits all flags.
+func InitHoldFlags(cmd *cobra.Command) {
+	initDispatcherFlags(cmd, holdResourceBindingFlags)
+}
+
+// HoldResourceBinding asks the dispatcher to hold the ResourceBinding `<namespace>/<name>`,
+// it's never dispatched until the hold is removed.
+func HoldResourceBinding(ctx context.Context, args []string) error {
+	return holdOrUnhold(ctx, args, holdResourceBindingFlags, http.MethodPost)
+}
+
+var unholdResourceBindingFlags = &dispatcherFlags{}
+
+// InitUnholdFlags inits all flags.
+func InitUnholdFlags(cmd *cobra.Command) {
+	initDispatcherFlags(cmd, unholdResourceBindingFlags)
+}
+
+// UnholdResourceBinding asks the dispatcher to remove the hold of the ResourceBinding `<namespace>/<name>`.
+func UnholdResourceBinding(ctx context.Context, args []string) error {
+	return holdOrUnhold(ctx, args, unholdResourceBindingFlags, http.MethodDelete)
+}
+
+func holdOrUnhold(ctx context.Context, args []string, flags *dispatcherFlags, method string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expect one ResourceBinding like <namespace>/<name>, got %d", len(args))
+	}
+	key, err := util.ParseKey(args[0])
+	if err != nil {
+		return err
+	}
+	return requestManualOperation(ctx, flags, method, "/dispatcher/hold", key)
+}
+
+// requestManualOperation requests the endpoint of the dispatcher for the ResourceBinding and prints the result.
+func requestManualOperation(ctx context.Context, flags *dispatcherFlags, method, path string, key types.NamespacedName) error {
+	query := url.Values{}
+	query.Set("namespace", key.Namespace)
+	query.Set("name", key.Name)
+	if flags.ControlPlane != "" {
+		query.Set("controlPlane", flags.ControlPlane)
+	}
+	result := &api.ManualOperation{}
+	token := util.DispatcherToken(&flags.CommonFlags, flags.Token)
+	if err := util.DoDispatcherJSON(ctx, method, flags.DispatcherAddress, path+"?"+query.Encode(), token, &flags.DispatcherTLSFlags, result); err != nil {
+		return err
+	}
+	fmt.Printf("%s ResourceBinding %s/%s as %s. %s\n", result.Operation, result.Namespace, result.Name, result.User, result.Message)
+	return nil
+}
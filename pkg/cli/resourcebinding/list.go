@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcebinding
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/spf13/cobra"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+type listFlags struct {
+	vcutil.CommonFlags
+
+	Namespace     string
+	AllNamespaces bool
+	Queue         string
+	Suspended     bool
+}
+
+var listResourceBindingFlags = &listFlags{}
+
+// InitListFlags inits all flags.
+func InitListFlags(cmd *cobra.Command) {
+	vcutil.InitFlags(cmd, &listResourceBindingFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&listResourceBindingFlags.Namespace, "namespace", "n", "default", "the namespace of ResourceBindings")
+	cmd.Flags().BoolVarP(&listResourceBindingFlags.AllNamespaces, "all-namespaces", "A", false, "list the ResourceBindings in all namespaces")
+	cmd.Flags().StringVarP(&listResourceBindingFlags.Queue, "queue", "q", "", "list the ResourceBindings in the queue")
+	cmd.Flags().BoolVar(&listResourceBindingFlags.Suspended, "suspended", false, "list the ResourceBindings waiting to be dispatched only")
+}
+
+// ListResourceBindings lists the ResourceBindings with their dispatching state.
+func ListResourceBindings(ctx context.Context) error {
+	clients, err := util.NewClients(&listResourceBindingFlags.CommonFlags)
+	if err != nil {
+		return err
+	}
+
+	namespace := listResourceBindingFlags.Namespace
+	if listResourceBindingFlags.AllNamespaces {
+		namespace = ""
+	}
+	rbs, err := clients.ListResourceBindings(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	rbs = filterResourceBindings(rbs, listResourceBindingFlags.Queue, listResourceBindingFlags.Suspended)
+	if len(rbs) == 0 {
+		fmt.Printf("No resources found\n")
+		return nil
+	}
+	PrintResourceBindings(rbs, os.Stdout)
+	return nil
+}
+
+func filterResourceBindings(rbs []workv1alpha2.ResourceBinding, queue string, suspendedOnly bool) []workv1alpha2.ResourceBinding {
+	var filtered []workv1alpha2.ResourceBinding
+	for _, rb := range rbs {
+		if queue != "" && util.ResourceBindingQueue(&rb) != queue {
+			continue
+		}
+		if suspendedOnly && !utils.IsResourceBindingSuspended(&rb) {
+			continue
+		}
+		filtered = append(filtered, rb)
+	}
+	return filtered
+}
+
+// PrintResourceBindings prints the ResourceBindings, the reason is the one of the condition set by the dispatcher.
+func PrintResourceBindings(rbs []workv1alpha2.ResourceBinding, writer io.Writer) {
+	_, err := fmt.Fprintf(writer, "%-20s%-40s%-20s%-24s%-12s%-24s%-8s\n",
+		"Namespace", "Name", "Queue", "Workload", "Suspended", "Reason", "Age")
+	if err != nil {
+		fmt.Printf("Failed to print ResourceBinding command result: %s.\n", err)
+	}
+	for _, rb := range rbs {
+		reason := ""
+		if condition := util.DispatchedCondition(&rb); condition != nil {
+			reason = condition.Reason
+		}
+		_, err = fmt.Fprintf(writer, "%-20s%-40s%-20s%-24s%-12t%-24s%-8s\n",
+			rb.Namespace, rb.Name, util.ResourceBindingQueue(&rb), rb.Spec.Resource.Kind+"/"+rb.Spec.Resource.Name,
+			utils.IsResourceBindingSuspended(&rb), reason, util.Age(rb.CreationTimestamp))
+		if err != nil {
+			fmt.Printf("Failed to print ResourceBinding command result: %s.\n", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcebinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/cli/util"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+type releaseFlags struct {
+	dispatcherFlags
+
+	Direct bool
+}
+
+var releaseResourceBindingFlags = &releaseFlags{}
+
+// InitReleaseFlags inits all flags.
+func InitReleaseFlags(cmd *cobra.Command) {
+	initDispatcherFlags(cmd, &releaseResourceBindingFlags.dispatcherFlags)
+
+	cmd.Flags().BoolVar(&releaseResourceBindingFlags.Direct, "direct", false,
+		"unsuspend the ResourceBinding by patching it directly instead of asking the dispatcher, the release is not audited")
+}
+
+// ReleaseResourceBinding asks the dispatcher to release the ResourceBinding `<namespace>/<name>` by force in the
+// next round, bypassing the plugins and its hold. It's unsuspended by patching directly with the `--direct` flag.
+func ReleaseResourceBinding(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expect one ResourceBinding like <namespace>/<name>, got %d", len(args))
+	}
+	key, err := util.ParseKey(args[0])
+	if err != nil {
+		return err
+	}
+	if !releaseResourceBindingFlags.Direct {
+		return requestManualOperation(ctx, &releaseResourceBindingFlags.dispatcherFlags, http.MethodPost, "/dispatcher/release", key)
+	}
+
+	clients, err := util.NewClients(&releaseResourceBindingFlags.CommonFlags)
+	if err != nil {
+		return err
+	}
+	rb, err := clients.Karmada.WorkV1alpha2().ResourceBindings(key.Namespace).Get(ctx, key.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !utils.IsResourceBindingSuspended(rb) {
+		fmt.Printf("ResourceBinding %s is not suspended.\n", key)
+		return nil
+	}
+	// Removing the annotation leaves the workload held, only the dispatcher releases both.
+	if utils.IsWorkloadHeld(rb) {
+		return fmt.Errorf("the workload of ResourceBinding %s is held, release it through the dispatcher", key)
+	}
+
+	patchBytes, err := json.Marshal(utils.BuildSuspendPatch(rb, suspendModeOf(rb), false))
+	if err != nil {
+		return err
+	}
+	if _, err = clients.Karmada.WorkV1alpha2().ResourceBindings(key.Namespace).Patch(ctx, key.Name,
+		types.JSONPatchType, patchBytes, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	fmt.Printf("ResourceBinding %s is released.\n", key)
+	return nil
+}
+
+// suspendModeOf returns the mode by the field which suspends the ResourceBinding.
+func suspendModeOf(rb *workv1alpha2.ResourceBinding) utils.SuspendMode {
+	if rb.Spec.Suspend {
+		return utils.SuspendModeSuspend
+	}
+	return utils.SuspendModeSuspension
+}
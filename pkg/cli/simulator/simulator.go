@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"volcano.sh/volcano-global/pkg/dispatcher"
+)
+
+type simulatorFlags struct {
+	Queues         string
+	Trace          string
+	Format         string
+	DispatcherConf string
+	Seed           int64
+	Period         time.Duration
+	MaxDuration    time.Duration
+}
+
+var simulateFlags = &simulatorFlags{}
+
+// InitSimulatorFlags inits all flags.
+func InitSimulatorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&simulateFlags.Queues, "queues", "",
+		"the YAML or JSON Queues, PriorityClasses and the other objects existing when the simulation starts")
+	cmd.Flags().StringVar(&simulateFlags.Trace, "trace", "", "the arrivals of the workloads and their generators")
+	cmd.Flags().StringVar(&simulateFlags.Format, "format", "",
+		"the format of the trace, yaml or csv, it's decided by the extension of the trace when it's empty")
+	cmd.Flags().StringVar(&simulateFlags.DispatcherConf, "dispatcher-conf", "",
+		"the configuration of the simulated dispatcher, the default one is used when it's empty")
+	cmd.Flags().Int64Var(&simulateFlags.Seed, "seed", 1, "the seed of the workload generators")
+	cmd.Flags().DurationVar(&simulateFlags.Period, "period", time.Second,
+		"the interval of the rounds while the dispatched workloads keep the others waiting")
+	cmd.Flags().DurationVar(&simulateFlags.MaxDuration, "max-duration", 24*time.Hour,
+		"the simulated time to stop, the workloads not dispatched by then are reported as pending")
+	_ = cmd.MarkFlagRequired("queues")
+	_ = cmd.MarkFlagRequired("trace")
+}
+
+// RunSimulation simulates the dispatching of the trace and prints the statistics of the queues.
+func RunSimulation() error {
+	options := dispatcher.SimulationOptions{Period: simulateFlags.Period, MaxDuration: simulateFlags.MaxDuration}
+
+	queues, err := os.Open(simulateFlags.Queues)
+	if err != nil {
+		return err
+	}
+	defer queues.Close()
+	if options.Objects, err = dispatcher.ReadCacheDump(queues); err != nil {
+		return err
+	}
+	format := simulateFlags.Format
+	if format == "" {
+		format = "yaml"
+		if strings.EqualFold(filepath.Ext(simulateFlags.Trace), ".csv") {
+			format = "csv"
+		}
+	}
+	traceFile, err := os.Open(simulateFlags.Trace)
+	if err != nil {
+		return err
+	}
+	defer traceFile.Close()
+	trace, err := dispatcher.ReadSimulationTrace(traceFile, format)
+	if err != nil {
+		return err
+	}
+	options.Workloads = trace.Expand(simulateFlags.Seed)
+	if simulateFlags.DispatcherConf != "" {
+		data, err := os.ReadFile(simulateFlags.DispatcherConf)
+		if err != nil {
+			return err
+		}
+		if options.Configuration, err = dispatcher.UnmarshalDispatcherConf(strings.TrimSpace(string(data))); err != nil {
+			return err
+		}
+	}
+
+	report, err := dispatcher.Simulate(options)
+	if err != nil {
+		return err
+	}
+	PrintReport(report, os.Stdout)
+	return nil
+}
+
+// PrintReport prints the statistics of the queues, then the summary of the simulation.
+func PrintReport(report *dispatcher.SimulationReport, writer io.Writer) {
+	fmt.Fprintf(writer, "%-20s%-8s%-11s%-12s%-11s%-12s%-12s%-12s%-12s%-10s%-10s\n", "Queue", "Weight", "Workloads",
+		"Dispatched", "Preempted", "WaitMean", "WaitP50", "WaitP95", "WaitMax", "CPUShare", "Weighted")
+	for _, queue := range report.Queues {
+		fmt.Fprintf(writer, "%-20s%-8d%-11d%-12d%-11d%-12s%-12s%-12s%-12s%-10.3f%-10.3f\n", queue.Queue, queue.Weight,
+			queue.Workloads, queue.Dispatched, queue.Preempted, queue.WaitMean.Round(time.Second),
+			queue.WaitP50.Round(time.Second), queue.WaitP95.Round(time.Second), queue.WaitMax.Round(time.Second), queue.CPUShare, queue.WeightShare)
+	}
+	fmt.Fprintf(writer, "\nRounds: %d\nMakespan: %s\nPending: %d\nFairness: %.3f\n", report.Rounds, report.Makespan.Round(time.Second),
+		report.Pending, report.Fairness)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	vcutil "volcano.sh/volcano/pkg/cli/util"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// DefaultQueue is the queue of the ResourceBindings which didn't set the queue annotation.
+const DefaultQueue = "default"
+
+// Clients are the clients of the Karmada control plane used by the command lines.
+type Clients struct {
+	Karmada karmadaclientset.Interface
+	Volcano vcclientset.Interface
+}
+
+// NewClients builds the clients by the common flags.
+func NewClients(cf *vcutil.CommonFlags) (*Clients, error) {
+	config, err := vcutil.BuildConfig(cf.Master, cf.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	karmadaClient, err := karmadaclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	volcanoClient, err := vcclientset.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Clients{Karmada: karmadaClient, Volcano: volcanoClient}, nil
+}
+
+// ListResourceBindings lists the ResourceBindings in the namespace, empty namespace means all the namespaces.
+func (c *Clients) ListResourceBindings(ctx context.Context, namespace string) ([]workv1alpha2.ResourceBinding, error) {
+	rbs, err := c.Karmada.WorkV1alpha2().ResourceBindings(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return rbs.Items, nil
+}
+
+// ResourceBindingQueue returns the queue of the ResourceBinding, which is set by the webhook.
+func ResourceBindingQueue(rb *workv1alpha2.ResourceBinding) string {
+	if queue := rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey]; queue != "" {
+		return queue
+	}
+	return DefaultQueue
+}
+
+// DispatchedCondition returns the condition set by the dispatcher, it's nil if the ResourceBinding was never blocked.
+func DispatchedCondition(rb *workv1alpha2.ResourceBinding) *metav1.Condition {
+	return meta.FindStatusCondition(rb.Status.Conditions, api.DispatchedCondition)
+}
+
+// Age returns the human-readable age of the object.
+func Age(creationTimestamp metav1.Time) string {
+	if creationTimestamp.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(creationTimestamp.Time))
+}
+
+// ParseKey parses the `<namespace>/<name>` into the NamespacedName.
+func ParseKey(value string) (types.NamespacedName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return types.NamespacedName{}, fmt.Errorf("invalid ResourceBinding %q, expect <namespace>/<name>", value)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+// DispatcherToken returns the bearer token for the debug endpoints of the dispatcher,
+// the token of the kubeconfig is used if the token is not set.
+func DispatcherToken(cf *vcutil.CommonFlags, token string) string {
+	if token != "" {
+		return token
+	}
+	config, err := vcutil.BuildConfig(cf.Master, cf.Kubeconfig)
+	if err != nil {
+		return ""
+	}
+	if config.BearerToken == "" && config.BearerTokenFile != "" {
+		if data, err := os.ReadFile(config.BearerTokenFile); err == nil {
+			return strings.TrimSpace(string(data))
+		}
+	}
+	return config.BearerToken
+}
+
+// DefaultDispatcherAddress is the default address of the admin and the debug endpoints of the dispatcher.
+const DefaultDispatcherAddress = "https://127.0.0.1:8443"
+
+// DispatcherTLSFlags are the flags to verify the serving certificate of the endpoints of the dispatcher.
+type DispatcherTLSFlags struct {
+	CAFile             string
+	InsecureSkipVerify bool
+}
+
+// InitDispatcherTLSFlags inits the flags to verify the serving certificate of the dispatcher.
+func InitDispatcherTLSFlags(cmd *cobra.Command, flags *DispatcherTLSFlags) {
+	cmd.Flags().StringVar(&flags.CAFile, "dispatcher-ca-file", "",
+		"the CA file to verify the serving certificate of the dispatcher, the system roots are used by default")
+	cmd.Flags().BoolVar(&flags.InsecureSkipVerify, "dispatcher-insecure-skip-tls-verify", false,
+		"don't verify the serving certificate of the dispatcher, like the self-signed one when it's not set")
+}
+
+// newDispatcherClient builds the http client verifying the serving certificate of the dispatcher by the flags.
+func newDispatcherClient(tlsFlags *DispatcherTLSFlags) (*http.Client, error) {
+	if tlsFlags == nil || (tlsFlags.CAFile == "" && !tlsFlags.InsecureSkipVerify) {
+		return http.DefaultClient, nil
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: tlsFlags.InsecureSkipVerify}
+	if tlsFlags.CAFile != "" {
+		data, err := os.ReadFile(tlsFlags.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificate found in %s", tlsFlags.CAFile)
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// GetDispatcherJSON gets the path from the endpoints of the dispatcher and decodes the json response into the out,
+// the token is sent as the bearer token if it's not empty.
+func GetDispatcherJSON(ctx context.Context, address, path, token string, tlsFlags *DispatcherTLSFlags, out interface{}) error {
+	return DoDispatcherJSON(ctx, http.MethodGet, address, path, token, tlsFlags, out)
+}
+
+// DoDispatcherJSON requests the path of the endpoints of the dispatcher by the method and decodes the json response
+// into the out, the token is sent as the bearer token if it's not empty.
+func DoDispatcherJSON(ctx context.Context, method, address, path, token string, tlsFlags *DispatcherTLSFlags, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	client, err := newDispatcherClient(tlsFlags)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(address, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("failed to %s %s of the dispatcher, status: %s, %s", strings.ToLower(method), path,
+			response.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(response.Body).Decode(out)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseKey(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		value     string
+		expectKey types.NamespacedName
+		expectErr bool
+	}{
+		{Name: "Valid key", value: "ns/rb", expectKey: types.NamespacedName{Namespace: "ns", Name: "rb"}},
+		{Name: "Without namespace", value: "rb", expectErr: true},
+		{Name: "Empty name", value: "ns/", expectErr: true},
+		{Name: "Too many parts", value: "a/b/c", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		key, err := ParseKey(tc.value)
+		if (err != nil) != tc.expectErr || key != tc.expectKey {
+			t.Errorf("Test case %s failed, got: %v, %v expect: %v, error %v", tc.Name, key, err, tc.expectKey, tc.expectErr)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchjob
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
+	"k8s.io/client-go/kubernetes"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanoclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	schedulinginformer "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+)
+
+func init() {
+	framework.RegisterController(&batchJobController{})
+}
+
+const controllerName = "batch-job-controller"
+
+// batchJobController creates the PodGroups for the batch Jobs lacking one and keeps them in sync with the Jobs,
+// so the Jobs are accounted in the queues by the dispatcher like the other workloads.
+// It can be disabled by `--controllers=*,-batch-job-controller`.
+type batchJobController struct {
+	kubeClient kubernetes.Interface
+	vcClient   volcanoclientset.Interface
+
+	informerFactory        informers.SharedInformerFactory
+	volcanoInformerFactory volcanoinformer.SharedInformerFactory
+
+	jobInformer batchinformers.JobInformer
+	jobLister   batchlisters.JobLister
+
+	podGroupInformer schedulinginformer.PodGroupInformer
+	podGroupLister   schedulinglister.PodGroupLister
+
+	queue       workqueue.RateLimitingInterface
+	pgWorkerNum uint32
+}
+
+func (jc *batchJobController) Name() string {
+	return controllerName
+}
+
+func (jc *batchJobController) Initialize(opt *framework.ControllerOption) error {
+	jc.kubeClient = opt.KubeClient
+	jc.informerFactory = opt.SharedInformerFactory
+	jc.pgWorkerNum = opt.WorkerThreadsForPG
+	jc.vcClient = opt.VolcanoClient
+	jc.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	jc.jobInformer = opt.SharedInformerFactory.Batch().V1().Jobs()
+	jc.jobLister = jc.jobInformer.Lister()
+
+	jc.jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    jc.addJobHandler,
+		UpdateFunc: jc.updateJobHandler,
+	})
+
+	jc.volcanoInformerFactory = informerfactory.NewSharedInformerFactory(jc.vcClient, 0)
+	jc.podGroupInformer = jc.volcanoInformerFactory.Scheduling().V1beta1().PodGroups()
+	jc.podGroupLister = jc.podGroupInformer.Lister()
+	return nil
+}
+
+func (jc *batchJobController) Run(stopCh <-chan struct{}) {
+	// Start the factories, and wait for cache sync
+	jc.informerFactory.Start(stopCh)
+	jc.volcanoInformerFactory.Start(stopCh)
+	for informerType, ok := range jc.informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+	for informerType, ok := range jc.volcanoInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+
+	for i := 1; i <= int(jc.pgWorkerNum); i++ {
+		go wait.Until(jc.worker, 0, stopCh)
+	}
+
+	klog.Infof("%s is running, pgWorkerNum: %d ......", controllerName, jc.pgWorkerNum)
+}
+
+func (jc *batchJobController) worker() {
+	for jc.processNext() {
+	}
+}
+
+func (jc *batchJobController) processNext() bool {
+	obj, shutdown := jc.queue.Get()
+	if shutdown {
+		klog.Errorf("Failed to get request from queue, queue is shutdown.")
+		return false
+	}
+
+	req := obj.(types.NamespacedName)
+	defer jc.queue.Done(req)
+
+	job, err := jc.jobLister.Jobs(req.Namespace).Get(req.Name)
+	if err != nil {
+		klog.Errorf("Failed to get Job by <%s/%s> from cache: %v", req.Namespace, req.Name, err)
+		return true
+	}
+
+	if podGroupName := job.Annotations[schedulingv1beta1.KubeGroupNameAnnotationKey]; podGroupName != "" {
+		klog.V(5).Infof("Job <%s/%s> already had created PodGroup, sync it with the Job.", req.Namespace, req.Name)
+		err = jc.syncPodGroupForJob(job, podGroupName)
+	} else {
+		err = jc.createPodGroupForJob(job)
+	}
+	if err != nil {
+		klog.Errorf("Failed to create or sync PodGroup for Job <%s/%s>, err: %v", req.Namespace, req.Name, err)
+		jc.queue.AddRateLimited(req)
+		return true
+	}
+
+	jc.queue.Forget(req)
+	return true
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchjob
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func (jc *batchJobController) addJobHandler(obj interface{}) {
+	job := obj.(*batchv1.Job)
+
+	jc.queue.Add(types.NamespacedName{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+	})
+}
+
+func (jc *batchJobController) updateJobHandler(oldObj, newObj interface{}) {
+	oldJob := oldObj.(*batchv1.Job)
+	newJob := newObj.(*batchv1.Job)
+
+	// Only the changes of the spec and the queue matter.
+	if oldJob.Generation == newJob.Generation &&
+		oldJob.Annotations[schedulingv1beta1.QueueNameAnnotationKey] == newJob.Annotations[schedulingv1beta1.QueueNameAnnotationKey] {
+		return
+	}
+	jc.addJobHandler(newJob)
+}
+
+func (jc *batchJobController) createPodGroupForJob(job *batchv1.Job) error {
+	podGroupName := generatePodGroupName(job)
+
+	if _, err := jc.podGroupLister.PodGroups(job.Namespace).Get(podGroupName); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to get PodGroup for Job <%s/%s>, err: %v", job.Namespace, job.Name, err)
+			return err
+		}
+
+		podGroup := &schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            podGroupName,
+				Namespace:       job.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(job, batchv1.SchemeGroupVersion.WithKind("Job"))},
+			},
+			Spec: newPodGroupSpec(job),
+			Status: schedulingv1beta1.PodGroupStatus{
+				Phase: schedulingv1beta1.PodGroupPending,
+			},
+		}
+
+		if _, err = jc.vcClient.SchedulingV1beta1().PodGroups(podGroup.Namespace).Create(context.TODO(), podGroup, metav1.CreateOptions{}); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				klog.Errorf("Failed to create PodGroup <%s/%s> for Job <%s/%s>, err: %v",
+					job.Namespace, podGroupName, job.Namespace, job.Name, err)
+				return err
+			}
+		}
+	}
+
+	job = job.DeepCopy()
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[schedulingv1beta1.KubeGroupNameAnnotationKey] = podGroupName
+	if _, err := jc.kubeClient.BatchV1().Jobs(job.Namespace).Update(context.TODO(), job, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update Job <%s/%s>, err: %v", job.Namespace, job.Name, err)
+		return err
+	}
+	return nil
+}
+
+// syncPodGroupForJob keeps the spec of the PodGroup created for the Job in sync with the Job,
+// the PodGroups not created for the Job are left alone.
+func (jc *batchJobController) syncPodGroupForJob(job *batchv1.Job, podGroupName string) error {
+	podGroup, err := jc.podGroupLister.PodGroups(job.Namespace).Get(podGroupName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(podGroup, job) {
+		return nil
+	}
+
+	spec := newPodGroupSpec(job)
+	if podGroup.Spec.MinMember == spec.MinMember && podGroup.Spec.Queue == spec.Queue &&
+		podGroup.Spec.PriorityClassName == spec.PriorityClassName && equality.Semantic.DeepEqual(podGroup.Spec.MinResources, spec.MinResources) {
+		return nil
+	}
+
+	podGroup = podGroup.DeepCopy()
+	podGroup.Spec.MinMember = spec.MinMember
+	podGroup.Spec.Queue = spec.Queue
+	podGroup.Spec.PriorityClassName = spec.PriorityClassName
+	podGroup.Spec.MinResources = spec.MinResources
+	if _, err = jc.vcClient.SchedulingV1beta1().PodGroups(podGroup.Namespace).Update(context.TODO(), podGroup, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update PodGroup <%s/%s> for Job <%s/%s>, err: %v",
+			podGroup.Namespace, podGroup.Name, job.Namespace, job.Name, err)
+		return err
+	}
+	klog.V(4).Infof("Updated PodGroup <%s/%s> for Job <%s/%s>.", podGroup.Namespace, podGroup.Name, job.Namespace, job.Name)
+	return nil
+}
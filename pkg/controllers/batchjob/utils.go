@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchjob
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/util"
+)
+
+// generatePodGroupName generates the PodGroup name of the Job by its own UID, the Jobs of a CronJob share the owner.
+func generatePodGroupName(job *batchv1.Job) string {
+	return vcbatch.PodgroupNamePrefix + string(job.UID)
+}
+
+// newPodGroupSpec derives the spec of the PodGroup from the Job, the MinMember is the pods running in parallel,
+// which is the parallelism limited by the completions, and the MinResources is the usage of them.
+func newPodGroupSpec(job *batchv1.Job) schedulingv1beta1.PodGroupSpec {
+	minMember := int32(1)
+	if job.Spec.Parallelism != nil && *job.Spec.Parallelism > 0 {
+		minMember = *job.Spec.Parallelism
+	}
+	if job.Spec.Completions != nil && *job.Spec.Completions > 0 && *job.Spec.Completions < minMember {
+		minMember = *job.Spec.Completions
+	}
+
+	minResources := corev1.ResourceList{}
+	for name, quantity := range *util.GetPodQuotaUsage(&corev1.Pod{Spec: job.Spec.Template.Spec}) {
+		quantity.Mul(int64(minMember))
+		minResources[name] = quantity
+	}
+
+	return schedulingv1beta1.PodGroupSpec{
+		MinMember: minMember,
+		// Get the queue name from the annotation, it may be empty.
+		Queue:             job.Annotations[schedulingv1beta1.QueueNameAnnotationKey],
+		PriorityClassName: job.Spec.Template.Spec.PriorityClassName,
+		MinResources:      &minResources,
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchjob
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewPodGroupSpec(t *testing.T) {
+	newJob := func(parallelism, completions *int32) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Spec.Parallelism = parallelism
+		job.Spec.Completions = completions
+		job.Spec.Template.Spec.Containers = []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			},
+		}}
+		return job
+	}
+
+	testCases := []struct {
+		Name            string
+		job             *batchv1.Job
+		expectMinMember int32
+		expectCPU       resource.Quantity
+	}{
+		{
+			Name:            "Default parallelism",
+			job:             newJob(nil, nil),
+			expectMinMember: 1,
+			expectCPU:       resource.MustParse("500m"),
+		},
+		{
+			Name:            "Parallel pods",
+			job:             newJob(ptr.To[int32](4), ptr.To[int32](10)),
+			expectMinMember: 4,
+			expectCPU:       resource.MustParse("2"),
+		},
+		{
+			Name:            "Parallelism limited by the completions",
+			job:             newJob(ptr.To[int32](4), ptr.To[int32](2)),
+			expectMinMember: 2,
+			expectCPU:       resource.MustParse("1"),
+		},
+	}
+
+	for _, tc := range testCases {
+		spec := newPodGroupSpec(tc.job)
+		cpu := (*spec.MinResources)[corev1.ResourceCPU]
+		if spec.MinMember != tc.expectMinMember || !equality.Semantic.DeepEqual(cpu, tc.expectCPU) {
+			t.Errorf("Test case %s failed, got: %d/%s expect: %d/%s", tc.Name, spec.MinMember, cpu.String(),
+				tc.expectMinMember, tc.expectCPU.String())
+		}
+	}
+}
@@ -73,7 +73,8 @@ func (dc *deploymentController) Initialize(opt *framework.ControllerOption) erro
 	dc.deploymentLister = dc.deploymentInformer.Lister()
 
 	dc.deploymentInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: dc.addDeploymentHandler,
+		AddFunc:    dc.addDeploymentHandler,
+		UpdateFunc: dc.updateDeploymentHandler,
 	})
 
 	dc.volcanoInformerFactory = informerfactory.NewSharedInformerFactory(dc.vcClient, 0)
@@ -126,8 +127,13 @@ func (dc *deploymentController) processNext() bool {
 		return true
 	}
 
-	if deployment.Annotations != nil && deployment.Annotations[schedulingv1beta1.KubeGroupNameAnnotationKey] != "" {
-		klog.V(5).Infof("Deployment <%s/%s> already had created PodGroup.", req.Namespace, req.Name)
+	if podGroupName := deployment.Annotations[schedulingv1beta1.KubeGroupNameAnnotationKey]; podGroupName != "" {
+		klog.V(5).Infof("Deployment <%s/%s> already had created PodGroup, sync it with the Deployment.", req.Namespace, req.Name)
+		if err = dc.syncPodGroupForDeployment(deployment, podGroupName); err != nil {
+			klog.Errorf("Failed to sync PodGroup for Deployment <%s/%s>, err: %v", req.Namespace, req.Name, err)
+			dc.queue.AddRateLimited(req)
+			return true
+		}
 		dc.queue.Forget(req)
 		return true
 	}
@@ -20,14 +20,13 @@ import (
 	"context"
 
 	v1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
-	"volcano.sh/volcano/pkg/controllers/util"
 )
 
 func (dc *deploymentController) addDeploymentHandler(obj interface{}) {
@@ -39,6 +38,18 @@ func (dc *deploymentController) addDeploymentHandler(obj interface{}) {
 	})
 }
 
+func (dc *deploymentController) updateDeploymentHandler(oldObj, newObj interface{}) {
+	oldDeployment := oldObj.(*v1.Deployment)
+	newDeployment := newObj.(*v1.Deployment)
+
+	// Only the changes of the pod template and the queue matter.
+	if oldDeployment.Generation == newDeployment.Generation &&
+		oldDeployment.Annotations[scheduling.QueueNameAnnotationKey] == newDeployment.Annotations[scheduling.QueueNameAnnotationKey] {
+		return
+	}
+	dc.addDeploymentHandler(newDeployment)
+}
+
 func (dc *deploymentController) createPodGroupForDeployment(deployment *v1.Deployment) error {
 	podGroupName := generatePodGroupName(deployment)
 
@@ -54,13 +65,7 @@ func (dc *deploymentController) createPodGroupForDeployment(deployment *v1.Deplo
 				Namespace:       deployment.Namespace,
 				OwnerReferences: newDeploymentPodGroupOwnerReferences(deployment),
 			},
-			Spec: schedulingv1beta1.PodGroupSpec{
-				MinMember: 1,
-				// Get the queue name from the annotation, it may be empty.
-				Queue:             deployment.Annotations[scheduling.QueueNameAnnotationKey],
-				PriorityClassName: deployment.Spec.Template.Spec.PriorityClassName,
-				MinResources:      util.GetPodQuotaUsage(&corev1.Pod{Spec: deployment.Spec.Template.Spec}),
-			},
+			Spec: newPodGroupSpec(deployment),
 			Status: schedulingv1beta1.PodGroupStatus{
 				Phase: schedulingv1beta1.PodGroupPending,
 			},
@@ -99,6 +104,40 @@ func (dc *deploymentController) updatePodPodGroupAnnotation(deployment *v1.Deplo
 	return nil
 }
 
+// syncPodGroupForDeployment keeps the spec of the PodGroup created for the Deployment in sync with the Deployment,
+// the PodGroups not created for the Deployment are left alone.
+func (dc *deploymentController) syncPodGroupForDeployment(deployment *v1.Deployment, podGroupName string) error {
+	podGroup, err := dc.podGroupLister.PodGroups(deployment.Namespace).Get(podGroupName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(podGroup, deployment) {
+		return nil
+	}
+
+	spec := newPodGroupSpec(deployment)
+	if podGroup.Spec.MinMember == spec.MinMember && podGroup.Spec.Queue == spec.Queue &&
+		podGroup.Spec.PriorityClassName == spec.PriorityClassName && equality.Semantic.DeepEqual(podGroup.Spec.MinResources, spec.MinResources) {
+		return nil
+	}
+
+	podGroup = podGroup.DeepCopy()
+	podGroup.Spec.MinMember = spec.MinMember
+	podGroup.Spec.Queue = spec.Queue
+	podGroup.Spec.PriorityClassName = spec.PriorityClassName
+	podGroup.Spec.MinResources = spec.MinResources
+	if _, err = dc.vcClient.SchedulingV1beta1().PodGroups(podGroup.Namespace).Update(context.TODO(), podGroup, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update PodGroup <%s/%s> for Deployment <%s/%s>, err: %v",
+			podGroup.Namespace, podGroup.Name, deployment.Namespace, deployment.Name, err)
+		return err
+	}
+	klog.V(4).Infof("Updated PodGroup <%s/%s> for Deployment <%s/%s>.", podGroup.Namespace, podGroup.Name, deployment.Namespace, deployment.Name)
+	return nil
+}
+
 func newDeploymentPodGroupOwnerReferences(deployment *v1.Deployment) []metav1.OwnerReference {
 	return []metav1.OwnerReference{*metav1.NewControllerRef(deployment, v1.SchemeGroupVersion.WithKind("Deployment"))}
 }
@@ -18,7 +18,10 @@ package deployment
 
 import (
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/util"
 )
 
 // generate PodGroup name for the deployment if not exists
@@ -36,3 +39,15 @@ func generatePodGroupName(deployment *v1.Deployment) string {
 
 	return podGroupName + string(deployment.UID)
 }
+
+// newPodGroupSpec derives the spec of the PodGroup from the Deployment, the pods of the Deployment are not a gang,
+// so the MinMember is 1 and the MinResources is the usage of one pod.
+func newPodGroupSpec(deployment *v1.Deployment) schedulingv1beta1.PodGroupSpec {
+	return schedulingv1beta1.PodGroupSpec{
+		MinMember: 1,
+		// Get the queue name from the annotation, it may be empty.
+		Queue:             deployment.Annotations[schedulingv1beta1.QueueNameAnnotationKey],
+		PriorityClassName: deployment.Spec.Template.Spec.PriorityClassName,
+		MinResources:      util.GetPodQuotaUsage(&corev1.Pod{Spec: deployment.Spec.Template.Spec}),
+	}
+}
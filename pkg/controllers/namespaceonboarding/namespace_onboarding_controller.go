@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceonboarding
+
+import (
+	"time"
+
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	informerpolicyv1alpha1 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/policy/v1alpha1"
+	listerpolicyv1alpha1 "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	volcanoclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	schedulinginformer "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+)
+
+func init() {
+	framework.RegisterController(&namespaceOnboardingController{})
+}
+
+const controllerName = "namespace-onboarding-controller"
+
+// namespaceOnboardingController onboards the Namespaces labeled by `volcano-global.volcano.sh/onboard: "true"`
+// in one reconcile: it creates their default Queue and FederatedResourceQuota, and binds them to the Queue and sets
+// their dispatch weight by the annotations, so the tenants are rolled out without the hand-made objects.
+// The existing objects and annotations are never changed, so they can be tuned after the onboarding.
+type namespaceOnboardingController struct {
+	kubeClient    kubernetes.Interface
+	vcClient      volcanoclientset.Interface
+	karmadaClient karmadaclientset.Interface
+
+	informerFactory        informers.SharedInformerFactory
+	volcanoInformerFactory volcanoinformer.SharedInformerFactory
+	karmadaInformerFactory karmadainformerfactory.SharedInformerFactory
+
+	namespaceInformer coreinformers.NamespaceInformer
+	namespaceLister   corelisters.NamespaceLister
+
+	queueInformer schedulinginformer.QueueInformer
+	queueLister   schedulinglister.QueueLister
+
+	quotaInformer informerpolicyv1alpha1.FederatedResourceQuotaInformer
+	quotaLister   listerpolicyv1alpha1.FederatedResourceQuotaLister
+
+	queue     workqueue.RateLimitingInterface
+	workerNum uint32
+}
+
+func (oc *namespaceOnboardingController) Name() string {
+	return controllerName
+}
+
+func (oc *namespaceOnboardingController) Initialize(opt *framework.ControllerOption) error {
+	karmadaClient, err := karmadaclientset.NewForConfig(opt.Config)
+	if err != nil {
+		return err
+	}
+
+	oc.kubeClient = opt.KubeClient
+	oc.vcClient = opt.VolcanoClient
+	oc.karmadaClient = karmadaClient
+	oc.informerFactory = opt.SharedInformerFactory
+	oc.workerNum = opt.WorkerNum
+	oc.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	oc.namespaceInformer = opt.SharedInformerFactory.Core().V1().Namespaces()
+	oc.namespaceLister = oc.namespaceInformer.Lister()
+	oc.namespaceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: isOnboarding,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    oc.addNamespaceHandler,
+			UpdateFunc: oc.updateNamespaceHandler,
+		},
+	})
+
+	oc.volcanoInformerFactory = informerfactory.NewSharedInformerFactory(oc.vcClient, 0)
+	oc.queueInformer = oc.volcanoInformerFactory.Scheduling().V1beta1().Queues()
+	oc.queueLister = oc.queueInformer.Lister()
+
+	oc.karmadaInformerFactory = karmadainformerfactory.NewSharedInformerFactory(oc.karmadaClient, 0)
+	oc.quotaInformer = oc.karmadaInformerFactory.Policy().V1alpha1().FederatedResourceQuotas()
+	oc.quotaLister = oc.quotaInformer.Lister()
+	oc.quotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: oc.deleteQuotaHandler,
+	})
+	return nil
+}
+
+func (oc *namespaceOnboardingController) Run(stopCh <-chan struct{}) {
+	// Start the factories, and wait for cache sync
+	oc.informerFactory.Start(stopCh)
+	oc.volcanoInformerFactory.Start(stopCh)
+	oc.karmadaInformerFactory.Start(stopCh)
+	for informerType, ok := range oc.informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+	for informerType, ok := range oc.volcanoInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+	for informerType, ok := range oc.karmadaInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+
+	for i := 1; i <= int(oc.workerNum); i++ {
+		go wait.Until(oc.worker, time.Second, stopCh)
+	}
+
+	klog.Infof("%s is running, workerNum: %d ......", controllerName, oc.workerNum)
+}
+
+func (oc *namespaceOnboardingController) worker() {
+	for oc.processNext() {
+	}
+}
+
+func (oc *namespaceOnboardingController) processNext() bool {
+	obj, shutdown := oc.queue.Get()
+	if shutdown {
+		klog.Errorf("Failed to get request from queue, queue is shutdown.")
+		return false
+	}
+
+	namespace := obj.(string)
+	defer oc.queue.Done(namespace)
+
+	if err := oc.syncNamespace(namespace); err != nil {
+		klog.Errorf("Failed to onboard Namespace <%s>, err: %v", namespace, err)
+		oc.queue.AddRateLimited(namespace)
+		return true
+	}
+
+	oc.queue.Forget(namespace)
+	return true
+}
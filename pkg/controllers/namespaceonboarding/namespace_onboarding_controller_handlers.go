@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceonboarding
+
+import (
+	"context"
+
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func (oc *namespaceOnboardingController) addNamespaceHandler(obj interface{}) {
+	namespace := obj.(*corev1.Namespace)
+	oc.queue.Add(namespace.Name)
+}
+
+func (oc *namespaceOnboardingController) updateNamespaceHandler(oldObj, newObj interface{}) {
+	oldNamespace := oldObj.(*corev1.Namespace)
+	newNamespace := newObj.(*corev1.Namespace)
+
+	// Only the onboarding and the changes of the onboarding annotations matter.
+	if isOnboarding(oldNamespace) &&
+		oldNamespace.Annotations[QueueAnnotationKey] == newNamespace.Annotations[QueueAnnotationKey] &&
+		oldNamespace.Annotations[QuotaAnnotationKey] == newNamespace.Annotations[QuotaAnnotationKey] {
+		return
+	}
+	oc.addNamespaceHandler(newNamespace)
+}
+
+// deleteQuotaHandler syncs the onboarded Namespace again when its default FederatedResourceQuota is deleted,
+// so it's created again while the Namespace is still onboarded.
+func (oc *namespaceOnboardingController) deleteQuotaHandler(obj interface{}) {
+	quota, ok := obj.(*policyv1alpha1.FederatedResourceQuota)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if quota, ok = tombstone.Obj.(*policyv1alpha1.FederatedResourceQuota); !ok {
+			return
+		}
+	}
+	if namespace := quota.Labels[ManagedLabelKey]; namespace != "" {
+		oc.queue.Add(namespace)
+	}
+}
+
+// syncNamespace onboards the Namespace, it creates the missing default Queue and FederatedResourceQuota, and sets the
+// missing dispatch annotations. The retried sync skips the objects created by the failed one.
+func (oc *namespaceOnboardingController) syncNamespace(name string) error {
+	namespace, err := oc.namespaceLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !isOnboarding(namespace) {
+		return nil
+	}
+	queueName, err := queueName(namespace)
+	if err != nil {
+		// Retrying doesn't help until the annotation is fixed, which triggers the sync again.
+		klog.Errorf("Failed to onboard Namespace <%s>, err: %v", name, err)
+		return nil
+	}
+	quota, err := parseQuota(namespace.Annotations[QuotaAnnotationKey])
+	if err != nil {
+		klog.Errorf("Failed to onboard Namespace <%s>, err: %v", name, err)
+		return nil
+	}
+
+	if err = oc.ensureQueue(name, queueName); err != nil {
+		return err
+	}
+	if err = oc.ensureQuota(name, quota); err != nil {
+		return err
+	}
+
+	annotations := missingAnnotations(namespace, queueName)
+	if len(annotations) == 0 {
+		return nil
+	}
+	namespace = namespace.DeepCopy()
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	for key, value := range annotations {
+		namespace.Annotations[key] = value
+	}
+	if _, err = oc.kubeClient.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to annotate Namespace <%s> for onboarding, err: %v", name, err)
+		return err
+	}
+	klog.V(3).Infof("Onboarded Namespace <%s> with Queue <%s>.", name, queueName)
+	return nil
+}
+
+// ensureQueue creates the default Queue of the Namespace if it doesn't exist, the existing one is shared.
+func (oc *namespaceOnboardingController) ensureQueue(namespace, queueName string) error {
+	if _, err := oc.queueLister.Get(queueName); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+	queue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   queueName,
+			Labels: map[string]string{ManagedLabelKey: namespace},
+		},
+		Spec: schedulingv1beta1.QueueSpec{
+			Weight: 1,
+		},
+	}
+	if _, err := oc.vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		klog.Errorf("Failed to create Queue <%s> for Namespace <%s>, err: %v", queueName, namespace, err)
+		return err
+	}
+	klog.V(3).Infof("Created Queue <%s> for Namespace <%s>.", queueName, namespace)
+	return nil
+}
+
+// ensureQuota creates the default FederatedResourceQuota of the Namespace if it doesn't exist.
+func (oc *namespaceOnboardingController) ensureQuota(namespace string, overall corev1.ResourceList) error {
+	if _, err := oc.quotaLister.FederatedResourceQuotas(namespace).Get(quotaName); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+	quota := &policyv1alpha1.FederatedResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      quotaName,
+			Labels:    map[string]string{ManagedLabelKey: namespace},
+		},
+		Spec: policyv1alpha1.FederatedResourceQuotaSpec{
+			Overall: overall,
+		},
+	}
+	if _, err := oc.karmadaClient.PolicyV1alpha1().FederatedResourceQuotas(namespace).Create(context.TODO(), quota, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		klog.Errorf("Failed to create FederatedResourceQuota <%s/%s>, err: %v", namespace, quotaName, err)
+		return err
+	}
+	klog.V(3).Infof("Created FederatedResourceQuota <%s/%s> with overall %v.", namespace, quotaName, overall)
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceonboarding
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+const (
+	// OnboardLabelKey is the label of the Namespaces to onboard, the value is `true`.
+	OnboardLabelKey = "volcano-global.volcano.sh/onboard"
+	// QueueAnnotationKey is the annotation of the onboarded Namespaces naming their default Queue,
+	// the name of the Namespace is used by default.
+	QueueAnnotationKey = "volcano-global.volcano.sh/onboard-queue"
+	// QuotaAnnotationKey is the annotation of the onboarded Namespaces setting the overall of their default
+	// FederatedResourceQuota, like `cpu=100,memory=200Gi`, the defaultQuota is used by default.
+	QuotaAnnotationKey = "volcano-global.volcano.sh/onboard-quota"
+	// ManagedLabelKey is the label of the Queues and the FederatedResourceQuotas created by the controller, the value
+	// is the onboarded Namespace. It's the same label as the namespace-queue-controller, so they agree on the owners.
+	ManagedLabelKey = "volcano-global.volcano.sh/namespace"
+
+	// quotaName is the name of the default FederatedResourceQuota of the onboarded Namespaces.
+	quotaName = "volcano-global-default"
+	// defaultDispatchWeight is the dispatch weight of the onboarded Namespaces.
+	defaultDispatchWeight = "1"
+)
+
+// defaultQuota is the overall of the default FederatedResourceQuota when the Namespace doesn't set one.
+var defaultQuota = corev1.ResourceList{
+	corev1.ResourceCPU:    resource.MustParse("100"),
+	corev1.ResourceMemory: resource.MustParse("200Gi"),
+}
+
+func isOnboarding(obj interface{}) bool {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return false
+	}
+	return namespace.Labels[OnboardLabelKey] == "true"
+}
+
+// queueName returns the name of the default Queue of the Namespace.
+func queueName(namespace *corev1.Namespace) (string, error) {
+	name := strings.TrimSpace(namespace.Annotations[QueueAnnotationKey])
+	if name == "" {
+		return namespace.Name, nil
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid queue name %q: %s", name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// parseQuota parses the `<resource>=<quantity>,...` value of the quota annotation, the defaultQuota is returned
+// when it's empty.
+func parseQuota(value string) (corev1.ResourceList, error) {
+	if strings.TrimSpace(value) == "" {
+		return defaultQuota.DeepCopy(), nil
+	}
+	quota := corev1.ResourceList{}
+	for _, item := range strings.Split(value, ",") {
+		name, quantityValue, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid quota %q, expect <resource>=<quantity>", item)
+		}
+		quantity, err := resource.ParseQuantity(strings.TrimSpace(quantityValue))
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q of resource %s: %v", quantityValue, name, err)
+		}
+		quota[corev1.ResourceName(strings.TrimSpace(name))] = quantity
+	}
+	return quota, nil
+}
+
+// missingAnnotations returns the dispatch annotations the Namespace lacks, they bind its workloads to the Queue
+// and set its weight in the shared queues.
+func missingAnnotations(namespace *corev1.Namespace, queueName string) map[string]string {
+	annotations := map[string]string{}
+	if _, found := namespace.Annotations[schedulingv1beta1.QueueNameAnnotationKey]; !found {
+		annotations[schedulingv1beta1.QueueNameAnnotationKey] = queueName
+	}
+	if _, found := namespace.Annotations[api.DispatchWeightAnnotationKey]; !found {
+		annotations[api.DispatchWeightAnnotationKey] = defaultDispatchWeight
+	}
+	return annotations
+}
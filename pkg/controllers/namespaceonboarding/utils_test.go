@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceonboarding
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestParseQuota(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		value       string
+		expectQuota corev1.ResourceList
+		expectErr   bool
+	}{
+		{
+			Name:        "Default quota",
+			value:       "",
+			expectQuota: defaultQuota,
+		},
+		{
+			Name:  "Quota of the annotation",
+			value: "cpu=10, memory=20Gi",
+			expectQuota: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10"),
+				corev1.ResourceMemory: resource.MustParse("20Gi"),
+			},
+		},
+		{
+			Name:      "Quota without quantity",
+			value:     "cpu",
+			expectErr: true,
+		},
+		{
+			Name:      "Invalid quantity",
+			value:     "cpu=ten",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		quota, err := parseQuota(tc.value)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("Test case %s failed, err: %v expect err: %v", tc.Name, err, tc.expectErr)
+			continue
+		}
+		if !tc.expectErr && !reflect.DeepEqual(quota, tc.expectQuota) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, quota, tc.expectQuota)
+		}
+	}
+}
+
+func TestMissingAnnotations(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		annotations       map[string]string
+		expectQueue       string
+		expectErr         bool
+		expectAnnotations map[string]string
+	}{
+		{
+			Name:        "Namespace without annotations",
+			expectQueue: "team-a",
+			expectAnnotations: map[string]string{
+				schedulingv1beta1.QueueNameAnnotationKey: "team-a",
+				api.DispatchWeightAnnotationKey:          defaultDispatchWeight,
+			},
+		},
+		{
+			Name:        "Queue named by the annotation",
+			annotations: map[string]string{QueueAnnotationKey: "shared"},
+			expectQueue: "shared",
+			expectAnnotations: map[string]string{
+				schedulingv1beta1.QueueNameAnnotationKey: "shared",
+				api.DispatchWeightAnnotationKey:          defaultDispatchWeight,
+			},
+		},
+		{
+			Name: "Existing annotations are kept",
+			annotations: map[string]string{
+				schedulingv1beta1.QueueNameAnnotationKey: "other",
+				api.DispatchWeightAnnotationKey:          "4",
+			},
+			expectQueue:       "team-a",
+			expectAnnotations: map[string]string{},
+		},
+		{
+			Name:        "Invalid queue name",
+			annotations: map[string]string{QueueAnnotationKey: "Shared_Queue"},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Annotations: tc.annotations}}
+		name, err := queueName(namespace)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("Test case %s failed, err: %v expect err: %v", tc.Name, err, tc.expectErr)
+			continue
+		}
+		if tc.expectErr {
+			continue
+		}
+		if annotations := missingAnnotations(namespace, name); name != tc.expectQueue || !reflect.DeepEqual(annotations, tc.expectAnnotations) {
+			t.Errorf("Test case %s failed, got: %s %v expect: %s %v", tc.Name, name, annotations, tc.expectQueue, tc.expectAnnotations)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequeue
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	volcanoclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	schedulinginformer "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+)
+
+func init() {
+	framework.RegisterController(&namespaceQueueController{})
+}
+
+const controllerName = "namespace-queue-controller"
+
+// namespaceQueueController creates and updates the Queues on the control plane by the
+// `volcano-global.volcano.sh/queue: <name>[:<weight>]` annotation of the Namespaces,
+// so the platform teams can manage the Queues declaratively with the Namespaces.
+// The Queues are never deleted by it, because they may still hold the workloads.
+type namespaceQueueController struct {
+	vcClient volcanoclientset.Interface
+
+	informerFactory        informers.SharedInformerFactory
+	volcanoInformerFactory volcanoinformer.SharedInformerFactory
+
+	namespaceInformer coreinformers.NamespaceInformer
+	namespaceLister   corelisters.NamespaceLister
+
+	queueInformer schedulinginformer.QueueInformer
+	queueLister   schedulinglister.QueueLister
+
+	queue     workqueue.RateLimitingInterface
+	workerNum uint32
+}
+
+func (nc *namespaceQueueController) Name() string {
+	return controllerName
+}
+
+func (nc *namespaceQueueController) Initialize(opt *framework.ControllerOption) error {
+	nc.vcClient = opt.VolcanoClient
+	nc.informerFactory = opt.SharedInformerFactory
+	nc.workerNum = opt.WorkerNum
+	nc.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	nc.namespaceInformer = opt.SharedInformerFactory.Core().V1().Namespaces()
+	nc.namespaceLister = nc.namespaceInformer.Lister()
+	nc.namespaceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: hasQueueAnnotation,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    nc.addNamespaceHandler,
+			UpdateFunc: nc.updateNamespaceHandler,
+		},
+	})
+
+	nc.volcanoInformerFactory = informerfactory.NewSharedInformerFactory(nc.vcClient, 0)
+	nc.queueInformer = nc.volcanoInformerFactory.Scheduling().V1beta1().Queues()
+	nc.queueLister = nc.queueInformer.Lister()
+	nc.queueInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: nc.updateQueueHandler,
+		DeleteFunc: nc.deleteQueueHandler,
+	})
+	return nil
+}
+
+func (nc *namespaceQueueController) Run(stopCh <-chan struct{}) {
+	// Start the factories, and wait for cache sync
+	nc.informerFactory.Start(stopCh)
+	nc.volcanoInformerFactory.Start(stopCh)
+	for informerType, ok := range nc.informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+	for informerType, ok := range nc.volcanoInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+
+	for i := 1; i <= int(nc.workerNum); i++ {
+		go wait.Until(nc.worker, time.Second, stopCh)
+	}
+
+	klog.Infof("%s is running, workerNum: %d ......", controllerName, nc.workerNum)
+}
+
+func (nc *namespaceQueueController) worker() {
+	for nc.processNext() {
+	}
+}
+
+func (nc *namespaceQueueController) processNext() bool {
+	obj, shutdown := nc.queue.Get()
+	if shutdown {
+		klog.Errorf("Failed to get request from queue, queue is shutdown.")
+		return false
+	}
+
+	namespace := obj.(string)
+	defer nc.queue.Done(namespace)
+
+	if err := nc.syncNamespaceQueue(namespace); err != nil {
+		klog.Errorf("Failed to sync the Queue of Namespace <%s>, err: %v", namespace, err)
+		nc.queue.AddRateLimited(namespace)
+		return true
+	}
+
+	nc.queue.Forget(namespace)
+	return true
+}
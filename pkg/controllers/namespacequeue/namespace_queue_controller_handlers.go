@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequeue
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func (nc *namespaceQueueController) addNamespaceHandler(obj interface{}) {
+	namespace := obj.(*corev1.Namespace)
+	nc.queue.Add(namespace.Name)
+}
+
+func (nc *namespaceQueueController) updateNamespaceHandler(oldObj, newObj interface{}) {
+	oldNamespace := oldObj.(*corev1.Namespace)
+	newNamespace := newObj.(*corev1.Namespace)
+
+	// Only the changes of the queue annotation matter.
+	if oldNamespace.Annotations[QueueAnnotationKey] == newNamespace.Annotations[QueueAnnotationKey] {
+		return
+	}
+	nc.addNamespaceHandler(newNamespace)
+}
+
+// updateQueueHandler syncs the declaring Namespace of the Queue again when the Queue is changed,
+// so the changes not made through the Namespace are reverted.
+func (nc *namespaceQueueController) updateQueueHandler(_, newObj interface{}) {
+	queue := newObj.(*schedulingv1beta1.Queue)
+	if namespace := queue.Labels[NamespaceLabelKey]; namespace != "" {
+		nc.queue.Add(namespace)
+	}
+}
+
+// deleteQueueHandler syncs the declaring Namespace of the Queue again when the Queue is deleted,
+// so the Queue is created again while the Namespace still declares it.
+func (nc *namespaceQueueController) deleteQueueHandler(obj interface{}) {
+	queue, ok := obj.(*schedulingv1beta1.Queue)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if queue, ok = tombstone.Obj.(*schedulingv1beta1.Queue); !ok {
+			return
+		}
+	}
+	if namespace := queue.Labels[NamespaceLabelKey]; namespace != "" {
+		nc.queue.Add(namespace)
+	}
+}
+
+func (nc *namespaceQueueController) syncNamespaceQueue(name string) error {
+	namespace, err := nc.namespaceLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	value, found := namespace.Annotations[QueueAnnotationKey]
+	if !found {
+		return nil
+	}
+	queueName, weight, err := parseQueueAnnotation(value)
+	if err != nil {
+		// Retrying doesn't help until the annotation is fixed, which triggers the sync again.
+		klog.Errorf("Failed to parse the queue annotation of Namespace <%s>, err: %v", name, err)
+		return nil
+	}
+
+	queue, err := nc.queueLister.Get(queueName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		queue = &schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   queueName,
+				Labels: map[string]string{NamespaceLabelKey: name},
+			},
+			Spec: schedulingv1beta1.QueueSpec{
+				Weight: weight,
+			},
+		}
+		if _, err = nc.vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("Failed to create Queue <%s> for Namespace <%s>, err: %v", queueName, name, err)
+			return err
+		}
+		klog.V(3).Infof("Created Queue <%s> with weight <%d> for Namespace <%s>.", queueName, weight, name)
+		return nil
+	}
+
+	if owner := queue.Labels[NamespaceLabelKey]; owner != name {
+		klog.V(4).Infof("Queue <%s> declared by Namespace <%s> is not created by it, owner: <%s>, skip updating.",
+			queueName, name, owner)
+		return nil
+	}
+	if queue.Spec.Weight == weight {
+		return nil
+	}
+
+	queue = queue.DeepCopy()
+	queue.Spec.Weight = weight
+	if _, err = nc.vcClient.SchedulingV1beta1().Queues().Update(context.TODO(), queue, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update Queue <%s> for Namespace <%s>, err: %v", queueName, name, err)
+		return err
+	}
+	klog.V(3).Infof("Updated the weight of Queue <%s> to <%d> for Namespace <%s>.", queueName, weight, name)
+	return nil
+}
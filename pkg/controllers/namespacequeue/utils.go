@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequeue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// QueueAnnotationKey is the annotation of the Namespaces declaring their Queues, the value is `<name>[:<weight>]`.
+	QueueAnnotationKey = "volcano-global.volcano.sh/queue"
+	// NamespaceLabelKey is the label of the Queues created by the controller, the value is the declaring Namespace.
+	// Only the Queues with it are updated by the controller, so the Queues created by the users are left alone.
+	NamespaceLabelKey = "volcano-global.volcano.sh/namespace"
+
+	// defaultQueueWeight is the weight of the Queues declared without the weight.
+	defaultQueueWeight int32 = 1
+)
+
+func hasQueueAnnotation(obj interface{}) bool {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return false
+	}
+	_, found := namespace.Annotations[QueueAnnotationKey]
+	return found
+}
+
+// parseQueueAnnotation parses the `<name>[:<weight>]` value of the queue annotation.
+func parseQueueAnnotation(value string) (string, int32, error) {
+	name, weightValue, hasWeight := strings.Cut(strings.TrimSpace(value), ":")
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", 0, fmt.Errorf("invalid queue name %q: %s", name, strings.Join(errs, ", "))
+	}
+	if !hasWeight {
+		return name, defaultQueueWeight, nil
+	}
+
+	weight, err := strconv.ParseInt(weightValue, 10, 32)
+	if err != nil || weight <= 0 {
+		return "", 0, fmt.Errorf("invalid weight %q of queue %s, it must be a positive integer", weightValue, name)
+	}
+	return name, int32(weight), nil
+}
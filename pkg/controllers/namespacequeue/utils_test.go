@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequeue
+
+import (
+	"testing"
+)
+
+func TestParseQueueAnnotation(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		value        string
+		expectName   string
+		expectWeight int32
+		expectErr    bool
+	}{
+		{
+			Name:         "Queue without weight",
+			value:        "team-a",
+			expectName:   "team-a",
+			expectWeight: defaultQueueWeight,
+		},
+		{
+			Name:         "Queue with weight",
+			value:        "team-a:4",
+			expectName:   "team-a",
+			expectWeight: 4,
+		},
+		{
+			Name:      "Invalid queue name",
+			value:     "Team_A:4",
+			expectErr: true,
+		},
+		{
+			Name:      "Invalid weight",
+			value:     "team-a:four",
+			expectErr: true,
+		},
+		{
+			Name:      "Zero weight",
+			value:     "team-a:0",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		name, weight, err := parseQueueAnnotation(tc.value)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("Test case %s failed, err: %v expect err: %v", tc.Name, err, tc.expectErr)
+			continue
+		}
+		if name != tc.expectName || weight != tc.expectWeight {
+			t.Errorf("Test case %s failed, got: %s:%d expect: %s:%d", tc.Name, name, weight, tc.expectName, tc.expectWeight)
+		}
+	}
+}
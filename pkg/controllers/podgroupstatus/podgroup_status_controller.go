@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroupstatus
+
+import (
+	"time"
+
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	informerworkv1aplha2 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/work/v1alpha2"
+	listerworkv1alpha2 "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	volcanoclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	schedulinginformer "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+)
+
+func init() {
+	framework.RegisterController(&podGroupStatusController{})
+}
+
+const controllerName = "podgroup-status-controller"
+
+// podGroupStatusController aggregates the status of the PodGroups in the member clusters, which is collected by
+// Karmada into the ResourceBinding of the PodGroup, back onto the PodGroup of the control plane.
+// So the users can get the gang status across the clusters from the control plane.
+type podGroupStatusController struct {
+	vcClient      volcanoclientset.Interface
+	karmadaClient karmadaclientset.Interface
+
+	volcanoInformerFactory volcanoinformer.SharedInformerFactory
+	karmadaInformerFactory karmadainformerfactory.SharedInformerFactory
+
+	resourceBindingInformer informerworkv1aplha2.ResourceBindingInformer
+	resourceBindingLister   listerworkv1alpha2.ResourceBindingLister
+
+	podGroupInformer schedulinginformer.PodGroupInformer
+	podGroupLister   schedulinglister.PodGroupLister
+
+	queue     workqueue.RateLimitingInterface
+	workerNum uint32
+}
+
+func (pc *podGroupStatusController) Name() string {
+	return controllerName
+}
+
+func (pc *podGroupStatusController) Initialize(opt *framework.ControllerOption) error {
+	karmadaClient, err := karmadaclientset.NewForConfig(opt.Config)
+	if err != nil {
+		return err
+	}
+
+	pc.vcClient = opt.VolcanoClient
+	pc.karmadaClient = karmadaClient
+	pc.workerNum = opt.WorkerThreadsForPG
+	pc.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	pc.karmadaInformerFactory = karmadainformerfactory.NewSharedInformerFactory(pc.karmadaClient, 0)
+	pc.resourceBindingInformer = pc.karmadaInformerFactory.Work().V1alpha2().ResourceBindings()
+	pc.resourceBindingLister = pc.resourceBindingInformer.Lister()
+	pc.resourceBindingInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: isPodGroupResourceBinding,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    pc.addResourceBindingHandler,
+			UpdateFunc: pc.updateResourceBindingHandler,
+		},
+	})
+
+	pc.volcanoInformerFactory = informerfactory.NewSharedInformerFactory(pc.vcClient, 0)
+	pc.podGroupInformer = pc.volcanoInformerFactory.Scheduling().V1beta1().PodGroups()
+	pc.podGroupLister = pc.podGroupInformer.Lister()
+	return nil
+}
+
+func (pc *podGroupStatusController) Run(stopCh <-chan struct{}) {
+	// Start the factories, and wait for cache sync
+	pc.karmadaInformerFactory.Start(stopCh)
+	pc.volcanoInformerFactory.Start(stopCh)
+	for informerType, ok := range pc.karmadaInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+	for informerType, ok := range pc.volcanoInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+
+	for i := 1; i <= int(pc.workerNum); i++ {
+		go wait.Until(pc.worker, time.Second, stopCh)
+	}
+
+	klog.Infof("%s is running, workerNum: %d ......", controllerName, pc.workerNum)
+}
+
+func (pc *podGroupStatusController) worker() {
+	for pc.processNext() {
+	}
+}
+
+func (pc *podGroupStatusController) processNext() bool {
+	obj, shutdown := pc.queue.Get()
+	if shutdown {
+		klog.Errorf("Failed to get request from queue, queue is shutdown.")
+		return false
+	}
+
+	req := obj.(types.NamespacedName)
+	defer pc.queue.Done(req)
+
+	if err := pc.syncPodGroupStatus(req); err != nil {
+		klog.Errorf("Failed to sync the status of PodGroup from ResourceBinding <%s/%s>, err: %v", req.Namespace, req.Name, err)
+		pc.queue.AddRateLimited(req)
+		return true
+	}
+
+	pc.queue.Forget(req)
+	return true
+}
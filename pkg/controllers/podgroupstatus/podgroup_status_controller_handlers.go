@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroupstatus
+
+import (
+	"context"
+	"reflect"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+func (pc *podGroupStatusController) addResourceBindingHandler(obj interface{}) {
+	rb := obj.(*workv1alpha2.ResourceBinding)
+
+	pc.queue.Add(types.NamespacedName{
+		Name:      rb.Name,
+		Namespace: rb.Namespace,
+	})
+}
+
+func (pc *podGroupStatusController) updateResourceBindingHandler(oldObj, newObj interface{}) {
+	oldRB := oldObj.(*workv1alpha2.ResourceBinding)
+	newRB := newObj.(*workv1alpha2.ResourceBinding)
+
+	// Only the changes of the aggregated status matter.
+	if reflect.DeepEqual(oldRB.Status.AggregatedStatus, newRB.Status.AggregatedStatus) {
+		return
+	}
+	pc.addResourceBindingHandler(newRB)
+}
+
+func (pc *podGroupStatusController) syncPodGroupStatus(req types.NamespacedName) error {
+	rb, err := pc.resourceBindingLister.ResourceBindings(req.Namespace).Get(req.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// The member clusters didn't report the status yet.
+	if len(rb.Status.AggregatedStatus) == 0 {
+		return nil
+	}
+
+	resource := rb.Spec.Resource
+	podGroup, err := pc.podGroupLister.PodGroups(resource.Namespace).Get(resource.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("PodGroup <%s/%s> of ResourceBinding <%s/%s> not found, skip syncing the status.",
+				resource.Namespace, resource.Name, rb.Namespace, rb.Name)
+			return nil
+		}
+		return err
+	}
+
+	status, err := aggregatePodGroupStatus(rb.Status.AggregatedStatus)
+	if err != nil {
+		return err
+	}
+	// Keep the conditions of the PodGroup in the control plane.
+	status.Conditions = podGroup.Status.Conditions
+	if reflect.DeepEqual(podGroup.Status, *status) {
+		return nil
+	}
+
+	podGroup = podGroup.DeepCopy()
+	podGroup.Status = *status
+	if _, err = pc.vcClient.SchedulingV1beta1().PodGroups(podGroup.Namespace).UpdateStatus(context.TODO(), podGroup, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update the status of PodGroup <%s/%s>, err: %v", podGroup.Namespace, podGroup.Name, err)
+		return err
+	}
+
+	klog.V(4).Infof("Updated the status of PodGroup <%s/%s> to phase <%s>, running <%d>.",
+		podGroup.Namespace, podGroup.Name, status.Phase, status.Running)
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroupstatus
+
+import (
+	"encoding/json"
+	"fmt"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// phaseOrder is the progress of the PodGroup phases, the aggregated phase is the least progressed one,
+// because the gang is only running when it is running in every member cluster.
+var phaseOrder = map[schedulingv1beta1.PodGroupPhase]int{
+	schedulingv1beta1.PodGroupUnknown:   0,
+	schedulingv1beta1.PodGroupPending:   1,
+	schedulingv1beta1.PodGroupInqueue:   2,
+	schedulingv1beta1.PodGroupRunning:   3,
+	schedulingv1beta1.PodGroupCompleted: 4,
+}
+
+func isPodGroupResourceBinding(obj interface{}) bool {
+	rb, ok := obj.(*workv1alpha2.ResourceBinding)
+	if !ok {
+		return false
+	}
+	return rb.Spec.Resource.APIVersion == schedulingv1beta1.SchemeGroupVersion.String() && rb.Spec.Resource.Kind == "PodGroup"
+}
+
+// aggregatePodGroupStatus aggregates the PodGroup status reported by the member clusters,
+// the counts are summed and the phase is the least progressed one.
+func aggregatePodGroupStatus(items []workv1alpha2.AggregatedStatusItem) (*schedulingv1beta1.PodGroupStatus, error) {
+	aggregated := &schedulingv1beta1.PodGroupStatus{}
+
+	for _, item := range items {
+		// The PodGroup may not be created in the member cluster yet, we treat it as pending.
+		if item.Status == nil {
+			aggregated.Phase = lessProgressedPhase(aggregated.Phase, schedulingv1beta1.PodGroupPending)
+			continue
+		}
+
+		status := &schedulingv1beta1.PodGroupStatus{}
+		if err := json.Unmarshal(item.Status.Raw, status); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the PodGroup status of cluster %s: %v", item.ClusterName, err)
+		}
+
+		aggregated.Running += status.Running
+		aggregated.Succeeded += status.Succeeded
+		aggregated.Failed += status.Failed
+		phase := status.Phase
+		if phase == "" {
+			phase = schedulingv1beta1.PodGroupPending
+		}
+		aggregated.Phase = lessProgressedPhase(aggregated.Phase, phase)
+	}
+
+	return aggregated, nil
+}
+
+func lessProgressedPhase(current, phase schedulingv1beta1.PodGroupPhase) schedulingv1beta1.PodGroupPhase {
+	if current == "" {
+		return phase
+	}
+	if phaseOrder[phase] < phaseOrder[current] {
+		return phase
+	}
+	return current
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroupstatus
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestAggregatePodGroupStatus(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		items        []workv1alpha2.AggregatedStatusItem
+		expectStatus *schedulingv1beta1.PodGroupStatus
+	}{
+		{
+			Name: "All clusters are running",
+			items: []workv1alpha2.AggregatedStatusItem{
+				{ClusterName: "member1", Status: &runtime.RawExtension{Raw: []byte(`{"phase":"Running","running":2}`)}},
+				{ClusterName: "member2", Status: &runtime.RawExtension{Raw: []byte(`{"phase":"Running","running":3,"failed":1}`)}},
+			},
+			expectStatus: &schedulingv1beta1.PodGroupStatus{Phase: schedulingv1beta1.PodGroupRunning, Running: 5, Failed: 1},
+		},
+		{
+			Name: "One cluster is inqueue",
+			items: []workv1alpha2.AggregatedStatusItem{
+				{ClusterName: "member1", Status: &runtime.RawExtension{Raw: []byte(`{"phase":"Completed","succeeded":2}`)}},
+				{ClusterName: "member2", Status: &runtime.RawExtension{Raw: []byte(`{"phase":"Inqueue"}`)}},
+			},
+			expectStatus: &schedulingv1beta1.PodGroupStatus{Phase: schedulingv1beta1.PodGroupInqueue, Succeeded: 2},
+		},
+		{
+			Name: "One cluster didn't report the status",
+			items: []workv1alpha2.AggregatedStatusItem{
+				{ClusterName: "member1", Status: &runtime.RawExtension{Raw: []byte(`{"phase":"Running","running":1}`)}},
+				{ClusterName: "member2"},
+			},
+			expectStatus: &schedulingv1beta1.PodGroupStatus{Phase: schedulingv1beta1.PodGroupPending, Running: 1},
+		},
+		{
+			Name: "Unknown has the highest priority",
+			items: []workv1alpha2.AggregatedStatusItem{
+				{ClusterName: "member1", Status: &runtime.RawExtension{Raw: []byte(`{"phase":"Pending"}`)}},
+				{ClusterName: "member2", Status: &runtime.RawExtension{Raw: []byte(`{"phase":"Unknown","running":1}`)}},
+			},
+			expectStatus: &schedulingv1beta1.PodGroupStatus{Phase: schedulingv1beta1.PodGroupUnknown, Running: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		status, err := aggregatePodGroupStatus(tc.items)
+		if err != nil {
+			t.Errorf("Test case %s failed, err: %v", tc.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(status, tc.expectStatus) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, status, tc.expectStatus)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"time"
+
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	informerpolicyv1alpha1 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/policy/v1alpha1"
+	informerworkv1aplha2 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/work/v1alpha2"
+	listerpolicyv1alpha1 "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	listerworkv1alpha2 "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha2"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	volcanoclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	schedulinginformer "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+)
+
+func init() {
+	framework.RegisterController(&propagationController{})
+}
+
+const (
+	controllerName = "propagation-controller"
+
+	// syncKey is the only key of the queue, every change triggers the sync of all the ClusterPropagationPolicies,
+	// so the bursts of the changes are merged into one sync by the queue.
+	syncKey = "propagation"
+)
+
+// propagationController generates the Karmada ClusterPropagationPolicies to propagate the Queues and PriorityClasses
+// used by the ResourceBindings to their scheduled member clusters, so the Volcano schedulers in the member clusters
+// see the same definitions as the control plane.
+type propagationController struct {
+	vcClient      volcanoclientset.Interface
+	karmadaClient karmadaclientset.Interface
+
+	volcanoInformerFactory volcanoinformer.SharedInformerFactory
+	karmadaInformerFactory karmadainformerfactory.SharedInformerFactory
+
+	resourceBindingInformer informerworkv1aplha2.ResourceBindingInformer
+	resourceBindingLister   listerworkv1alpha2.ResourceBindingLister
+
+	policyInformer informerpolicyv1alpha1.ClusterPropagationPolicyInformer
+	policyLister   listerpolicyv1alpha1.ClusterPropagationPolicyLister
+
+	podGroupInformer schedulinginformer.PodGroupInformer
+
+	queue workqueue.RateLimitingInterface
+}
+
+func (pc *propagationController) Name() string {
+	return controllerName
+}
+
+func (pc *propagationController) Initialize(opt *framework.ControllerOption) error {
+	karmadaClient, err := karmadaclientset.NewForConfig(opt.Config)
+	if err != nil {
+		return err
+	}
+
+	pc.vcClient = opt.VolcanoClient
+	pc.karmadaClient = karmadaClient
+	pc.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	pc.karmadaInformerFactory = karmadainformerfactory.NewSharedInformerFactory(pc.karmadaClient, 0)
+	pc.resourceBindingInformer = pc.karmadaInformerFactory.Work().V1alpha2().ResourceBindings()
+	pc.resourceBindingLister = pc.resourceBindingInformer.Lister()
+	pc.resourceBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    pc.addResourceBindingHandler,
+		UpdateFunc: pc.updateResourceBindingHandler,
+		DeleteFunc: pc.enqueue,
+	})
+	pc.policyInformer = pc.karmadaInformerFactory.Policy().V1alpha1().ClusterPropagationPolicies()
+	pc.policyLister = pc.policyInformer.Lister()
+	pc.policyInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: isManagedPolicy,
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, newObj interface{}) { pc.enqueue(newObj) },
+			DeleteFunc: pc.enqueue,
+		},
+	})
+
+	pc.volcanoInformerFactory = informerfactory.NewSharedInformerFactory(pc.vcClient, 0)
+	pc.podGroupInformer = pc.volcanoInformerFactory.Scheduling().V1beta1().PodGroups()
+	if err = pc.podGroupInformer.Informer().AddIndexers(cache.Indexers{podGroupOwnerIndex: podGroupOwnerIndexFunc}); err != nil {
+		return err
+	}
+	pc.podGroupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    pc.enqueue,
+		UpdateFunc: pc.updatePodGroupHandler,
+		DeleteFunc: pc.enqueue,
+	})
+	return nil
+}
+
+func (pc *propagationController) Run(stopCh <-chan struct{}) {
+	// Start the factories, and wait for cache sync
+	pc.karmadaInformerFactory.Start(stopCh)
+	pc.volcanoInformerFactory.Start(stopCh)
+	for informerType, ok := range pc.karmadaInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+	for informerType, ok := range pc.volcanoInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+
+	// The sync covers all the ClusterPropagationPolicies, so one worker is enough.
+	pc.queue.Add(syncKey)
+	go wait.Until(pc.worker, time.Second, stopCh)
+
+	klog.Infof("%s is running ......", controllerName)
+}
+
+func (pc *propagationController) worker() {
+	for pc.processNext() {
+	}
+}
+
+func (pc *propagationController) processNext() bool {
+	obj, shutdown := pc.queue.Get()
+	if shutdown {
+		klog.Errorf("Failed to get request from queue, queue is shutdown.")
+		return false
+	}
+	defer pc.queue.Done(obj)
+
+	if err := pc.syncPropagationPolicies(); err != nil {
+		klog.Errorf("Failed to sync the ClusterPropagationPolicies of the Queues and PriorityClasses, err: %v", err)
+		pc.queue.AddRateLimited(obj)
+		return true
+	}
+
+	pc.queue.Forget(obj)
+	return true
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"reflect"
+
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func (pc *propagationController) enqueue(_ interface{}) {
+	pc.queue.Add(syncKey)
+}
+
+func (pc *propagationController) addResourceBindingHandler(obj interface{}) {
+	rb := obj.(*workv1alpha2.ResourceBinding)
+	if len(rb.Spec.Clusters) == 0 {
+		return
+	}
+	pc.enqueue(rb)
+}
+
+func (pc *propagationController) updateResourceBindingHandler(oldObj, newObj interface{}) {
+	oldRB := oldObj.(*workv1alpha2.ResourceBinding)
+	newRB := newObj.(*workv1alpha2.ResourceBinding)
+
+	// Only the changes of the scheduled clusters and the referenced resources matter.
+	if reflect.DeepEqual(oldRB.Spec.Clusters, newRB.Spec.Clusters) &&
+		reflect.DeepEqual(referencedResources(oldRB, nil), referencedResources(newRB, nil)) {
+		return
+	}
+	pc.enqueue(newRB)
+}
+
+func (pc *propagationController) updatePodGroupHandler(oldObj, newObj interface{}) {
+	oldPG := oldObj.(*schedulingv1beta1.PodGroup)
+	newPG := newObj.(*schedulingv1beta1.PodGroup)
+
+	if oldPG.Spec.Queue == newPG.Spec.Queue && oldPG.Spec.PriorityClassName == newPG.Spec.PriorityClassName {
+		return
+	}
+	pc.enqueue(newPG)
+}
+
+// podGroupOf finds the PodGroup of the ResourceBinding by the owner of the PodGroup, like the dispatcher.
+func (pc *propagationController) podGroupOf(rb *workv1alpha2.ResourceBinding) *schedulingv1beta1.PodGroup {
+	objs, err := pc.podGroupInformer.Informer().GetIndexer().ByIndex(podGroupOwnerIndex, string(rb.Spec.Resource.UID))
+	if err != nil || len(objs) == 0 {
+		return nil
+	}
+	return objs[0].(*schedulingv1beta1.PodGroup)
+}
+
+func (pc *propagationController) syncPropagationPolicies() error {
+	rbs, err := pc.resourceBindingLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	policies, err := pc.policyLister.List(labels.SelectorFromSet(labels.Set{PropagationLabelKey: "true"}))
+	if err != nil {
+		return err
+	}
+	stale := make(map[string]*policyv1alpha1.ClusterPropagationPolicy, len(policies))
+	for _, policy := range policies {
+		stale[policy.Name] = policy
+	}
+
+	var errs []error
+	for resource, clusters := range collectPropagations(rbs, pc.podGroupOf) {
+		expected := newPropagationPolicy(resource, clusters)
+		policy, found := stale[expected.Name]
+		delete(stale, expected.Name)
+
+		if !found {
+			if _, err = pc.karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Create(context.TODO(), expected, metav1.CreateOptions{}); err != nil {
+				klog.Errorf("Failed to create ClusterPropagationPolicy <%s>, err: %v", expected.Name, err)
+				errs = append(errs, err)
+				continue
+			}
+			klog.V(3).Infof("Created ClusterPropagationPolicy <%s> propagating %s <%s> to clusters %v.",
+				expected.Name, resource.Kind, resource.Name, expected.Spec.Placement.ClusterAffinity.ClusterNames)
+			continue
+		}
+		if !isPolicyOutdated(policy, expected) {
+			continue
+		}
+
+		policy = policy.DeepCopy()
+		policy.Spec.ResourceSelectors = expected.Spec.ResourceSelectors
+		policy.Spec.Placement.ClusterAffinity = expected.Spec.Placement.ClusterAffinity
+		policy.Spec.ConflictResolution = expected.Spec.ConflictResolution
+		policy.Spec.PreserveResourcesOnDeletion = expected.Spec.PreserveResourcesOnDeletion
+		if _, err = pc.karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Update(context.TODO(), policy, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Failed to update ClusterPropagationPolicy <%s>, err: %v", policy.Name, err)
+			errs = append(errs, err)
+			continue
+		}
+		klog.V(3).Infof("Updated ClusterPropagationPolicy <%s> propagating %s <%s> to clusters %v.",
+			policy.Name, resource.Kind, resource.Name, expected.Spec.Placement.ClusterAffinity.ClusterNames)
+	}
+
+	// No ResourceBinding uses the resources of the rest ones anymore.
+	for name := range stale {
+		err = pc.karmadaClient.PolicyV1alpha1().ClusterPropagationPolicies().Delete(context.TODO(), name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to delete ClusterPropagationPolicy <%s>, err: %v", name, err)
+			errs = append(errs, err)
+			continue
+		}
+		klog.V(3).Infof("Deleted ClusterPropagationPolicy <%s>, no ResourceBinding uses its resource.", name)
+	}
+	return utilerrors.NewAggregate(errs)
+}
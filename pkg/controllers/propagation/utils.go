@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+const (
+	// PropagationLabelKey is the label of the ClusterPropagationPolicies generated by the controller,
+	// only the ClusterPropagationPolicies with it are updated and deleted by the controller.
+	PropagationLabelKey = "volcano-global.volcano.sh/propagation"
+
+	podGroupOwnerIndex = "owner"
+	// systemPriorityClassPrefix is the prefix of the built-in PriorityClasses, they exist in every cluster.
+	systemPriorityClassPrefix = "system-"
+)
+
+// propagatedResource is the Queue or PriorityClass propagated to the member clusters.
+type propagatedResource struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+func newQueueResource(name string) propagatedResource {
+	return propagatedResource{APIVersion: schedulingv1beta1.SchemeGroupVersion.String(), Kind: "Queue", Name: name}
+}
+
+func newPriorityClassResource(name string) propagatedResource {
+	return propagatedResource{APIVersion: schedulingv1.SchemeGroupVersion.String(), Kind: "PriorityClass", Name: name}
+}
+
+func (r propagatedResource) policyName() string {
+	return fmt.Sprintf("volcano-global-%s-%s", strings.ToLower(r.Kind), r.Name)
+}
+
+func isManagedPolicy(obj interface{}) bool {
+	policy, ok := obj.(*policyv1alpha1.ClusterPropagationPolicy)
+	if !ok {
+		return false
+	}
+	return policy.Labels[PropagationLabelKey] == "true"
+}
+
+func podGroupOwnerIndexFunc(obj interface{}) ([]string, error) {
+	pg, ok := obj.(*schedulingv1beta1.PodGroup)
+	if !ok {
+		return nil, nil
+	}
+	owners := make([]string, 0, len(pg.OwnerReferences))
+	for _, ownerRef := range pg.OwnerReferences {
+		owners = append(owners, string(ownerRef.UID))
+	}
+	return owners, nil
+}
+
+// referencedResources returns the Queue and PriorityClass used by the ResourceBinding, they are resolved the same as
+// the dispatcher, the ones of the PodGroup take precedence. The default ones are not returned, because the dispatcher
+// configures them, and the member clusters have their own.
+func referencedResources(rb *workv1alpha2.ResourceBinding, pg *schedulingv1beta1.PodGroup) []propagatedResource {
+	queueName := rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey]
+	priorityClassName := ""
+	if rb.Spec.ReplicaRequirements != nil {
+		priorityClassName = rb.Spec.ReplicaRequirements.PriorityClassName
+	}
+	if pg != nil {
+		if pg.Spec.Queue != "" {
+			queueName = pg.Spec.Queue
+		}
+		if pg.Spec.PriorityClassName != "" {
+			priorityClassName = pg.Spec.PriorityClassName
+		}
+	}
+
+	var resources []propagatedResource
+	if queueName != "" {
+		resources = append(resources, newQueueResource(queueName))
+	}
+	if priorityClassName != "" && !strings.HasPrefix(priorityClassName, systemPriorityClassPrefix) {
+		resources = append(resources, newPriorityClassResource(priorityClassName))
+	}
+	return resources
+}
+
+// collectPropagations collects the scheduled member clusters of the ResourceBindings by the Queues and PriorityClasses
+// they use. The suspended and the opted out ResourceBindings are collected too, so the definitions are ready before
+// the workloads are dispatched.
+func collectPropagations(rbs []*workv1alpha2.ResourceBinding,
+	podGroupOf func(rb *workv1alpha2.ResourceBinding) *schedulingv1beta1.PodGroup) map[propagatedResource]sets.Set[string] {
+	propagations := map[propagatedResource]sets.Set[string]{}
+	for _, rb := range rbs {
+		if len(rb.Spec.Clusters) == 0 {
+			continue
+		}
+		for _, resource := range referencedResources(rb, podGroupOf(rb)) {
+			clusters, found := propagations[resource]
+			if !found {
+				clusters = sets.New[string]()
+				propagations[resource] = clusters
+			}
+			for _, target := range rb.Spec.Clusters {
+				clusters.Insert(target.Name)
+			}
+		}
+	}
+	return propagations
+}
+
+// newPropagationPolicy builds the ClusterPropagationPolicy propagating the resource to the clusters. The resource
+// overwrites the one with the same name in the member clusters to keep the definitions consistent, and is preserved
+// in the member clusters when the ClusterPropagationPolicy is deleted, because the workloads may still use it.
+func newPropagationPolicy(resource propagatedResource, clusters sets.Set[string]) *policyv1alpha1.ClusterPropagationPolicy {
+	return &policyv1alpha1.ClusterPropagationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   resource.policyName(),
+			Labels: map[string]string{PropagationLabelKey: "true"},
+		},
+		Spec: policyv1alpha1.PropagationSpec{
+			ResourceSelectors: []policyv1alpha1.ResourceSelector{{
+				APIVersion: resource.APIVersion,
+				Kind:       resource.Kind,
+				Name:       resource.Name,
+			}},
+			Placement: policyv1alpha1.Placement{
+				ClusterAffinity: &policyv1alpha1.ClusterAffinity{ClusterNames: sets.List(clusters)},
+			},
+			ConflictResolution:          policyv1alpha1.ConflictOverwrite,
+			PreserveResourcesOnDeletion: utils.ToPointer(true),
+		},
+	}
+}
+
+// isPolicyOutdated compares the fields set by newPropagationPolicy only, the others are defaulted by Karmada.
+func isPolicyOutdated(policy, expected *policyv1alpha1.ClusterPropagationPolicy) bool {
+	return !reflect.DeepEqual(policy.Spec.ResourceSelectors, expected.Spec.ResourceSelectors) ||
+		!reflect.DeepEqual(policy.Spec.Placement.ClusterAffinity, expected.Spec.Placement.ClusterAffinity) ||
+		policy.Spec.ConflictResolution != expected.Spec.ConflictResolution ||
+		!reflect.DeepEqual(policy.Spec.PreserveResourcesOnDeletion, expected.Spec.PreserveResourcesOnDeletion)
+}
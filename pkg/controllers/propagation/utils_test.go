@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestCollectPropagations(t *testing.T) {
+	newRB := func(uid types.UID, queue, priorityClass string, clusters ...string) *workv1alpha2.ResourceBinding {
+		rb := &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: string(uid)}}
+		rb.Spec.Resource.UID = uid
+		if queue != "" {
+			rb.Annotations = map[string]string{schedulingv1beta1.QueueNameAnnotationKey: queue}
+		}
+		if priorityClass != "" {
+			rb.Spec.ReplicaRequirements = &workv1alpha2.ReplicaRequirements{PriorityClassName: priorityClass}
+		}
+		for _, cluster := range clusters {
+			rb.Spec.Clusters = append(rb.Spec.Clusters, workv1alpha2.TargetCluster{Name: cluster})
+		}
+		return rb
+	}
+
+	testCases := []struct {
+		Name              string
+		rbs               []*workv1alpha2.ResourceBinding
+		podGroups         map[types.UID]*schedulingv1beta1.PodGroup
+		expectPropagation map[propagatedResource]sets.Set[string]
+	}{
+		{
+			Name: "Clusters are merged by the resources",
+			rbs: []*workv1alpha2.ResourceBinding{
+				newRB("rb1", "q1", "high", "member1"),
+				newRB("rb2", "q1", "", "member2", "member3"),
+			},
+			expectPropagation: map[propagatedResource]sets.Set[string]{
+				newQueueResource("q1"):           sets.New("member1", "member2", "member3"),
+				newPriorityClassResource("high"): sets.New("member1"),
+			},
+		},
+		{
+			Name: "PodGroup takes precedence",
+			rbs:  []*workv1alpha2.ResourceBinding{newRB("rb1", "q1", "high", "member1")},
+			podGroups: map[types.UID]*schedulingv1beta1.PodGroup{
+				"rb1": {Spec: schedulingv1beta1.PodGroupSpec{Queue: "q2", PriorityClassName: "low"}},
+			},
+			expectPropagation: map[propagatedResource]sets.Set[string]{
+				newQueueResource("q2"):          sets.New("member1"),
+				newPriorityClassResource("low"): sets.New("member1"),
+			},
+		},
+		{
+			Name: "Unscheduled ResourceBinding and system PriorityClass are skipped",
+			rbs: []*workv1alpha2.ResourceBinding{
+				newRB("rb1", "q1", ""),
+				newRB("rb2", "", "system-cluster-critical", "member1"),
+			},
+			expectPropagation: map[propagatedResource]sets.Set[string]{},
+		},
+	}
+
+	for _, tc := range testCases {
+		podGroupOf := func(rb *workv1alpha2.ResourceBinding) *schedulingv1beta1.PodGroup {
+			return tc.podGroups[rb.Spec.Resource.UID]
+		}
+		propagations := collectPropagations(tc.rbs, podGroupOf)
+		if !reflect.DeepEqual(propagations, tc.expectPropagation) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, propagations, tc.expectPropagation)
+		}
+	}
+}
+
+func TestIsPolicyOutdated(t *testing.T) {
+	expected := newPropagationPolicy(newQueueResource("q1"), sets.New("member1", "member2"))
+
+	defaulted := expected.DeepCopy()
+	defaulted.Spec.SchedulerName = "default-scheduler"
+	defaulted.Spec.Placement.ClusterTolerations = []corev1.Toleration{{Key: "cluster.karmada.io/not-ready", Operator: corev1.TolerationOpExists}}
+	if isPolicyOutdated(defaulted, expected) {
+		t.Errorf("Test case %s failed, got: %v expect: %v", "Defaulted by Karmada", true, false)
+	}
+
+	shrunk := newPropagationPolicy(newQueueResource("q1"), sets.New("member1"))
+	if !isPolicyOutdated(shrunk, expected) {
+		t.Errorf("Test case %s failed, got: %v expect: %v", "Clusters changed", false, true)
+	}
+}
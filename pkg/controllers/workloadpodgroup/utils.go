@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadpodgroup
+
+import (
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// podGroupControlledWorkloads are the workloads whose PodGroups are created by their own controllers,
+// like the Volcano job controller, the deployment controller, the batch job controller and the podgroup controller.
+var podGroupControlledWorkloads = map[schema.GroupVersionKind]struct{}{
+	vcbatch.SchemeGroupVersion.WithKind("Job"):       {},
+	appsv1.SchemeGroupVersion.WithKind("Deployment"): {},
+	batchv1.SchemeGroupVersion.WithKind("Job"):       {},
+	corev1.SchemeGroupVersion.WithKind("Pod"):        {},
+}
+
+// needsPodGroup returns whether the workload of the ResourceBinding is in the registry but no other controller
+// creates the PodGroup for it.
+func needsPodGroup(rb *workv1alpha2.ResourceBinding) bool {
+	ref := rb.Spec.Resource
+	if ref.UID == "" {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false
+	}
+	gvk := gv.WithKind(ref.Kind)
+	if _, found := podGroupControlledWorkloads[gvk]; found {
+		return false
+	}
+	return utils.DefaultWorkloadRegistry.IsWorkloadGVK(gvk)
+}
+
+// newPodGroup creates the PodGroup of the workload owned by it. The queue is left empty, so it's the one of the
+// ResourceBinding, and the min resources are left empty, so they're resolved from the workload by the dispatcher.
+func newPodGroup(ref workv1alpha2.ObjectReference) *schedulingv1beta1.PodGroup {
+	return &schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vcbatch.PodgroupNamePrefix + string(ref.UID),
+			Namespace: ref.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: ref.APIVersion,
+				Kind:       ref.Kind,
+				Name:       ref.Name,
+				UID:        ref.UID,
+			}},
+		},
+		Spec: schedulingv1beta1.PodGroupSpec{
+			MinMember: 1,
+		},
+		Status: schedulingv1beta1.PodGroupStatus{
+			Phase: schedulingv1beta1.PodGroupPending,
+		},
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadpodgroup
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNeedsPodGroup(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		apiVersion   string
+		kind         string
+		uid          string
+		expectResult bool
+	}{
+		{Name: "StatefulSet", apiVersion: "apps/v1", kind: "StatefulSet", uid: "sts", expectResult: true},
+		{Name: "TFJob", apiVersion: "kubeflow.org/v1", kind: "TFJob", uid: "tfjob", expectResult: true},
+		{Name: "RayJob", apiVersion: "ray.io/v1", kind: "RayJob", uid: "rayjob", expectResult: true},
+		{Name: "Deployment created by the deployment controller", apiVersion: "apps/v1", kind: "Deployment", uid: "deploy", expectResult: false},
+		{Name: "Volcano Job created by the job controller", apiVersion: "batch.volcano.sh/v1alpha1", kind: "Job", uid: "vcjob", expectResult: false},
+		{Name: "Not a workload", apiVersion: "v1", kind: "ConfigMap", uid: "cm", expectResult: false},
+		{Name: "Workload without UID", apiVersion: "apps/v1", kind: "StatefulSet", expectResult: false},
+	}
+
+	for _, tc := range testCases {
+		rb := &workv1alpha2.ResourceBinding{Spec: workv1alpha2.ResourceBindingSpec{Resource: workv1alpha2.ObjectReference{
+			APIVersion: tc.apiVersion, Kind: tc.kind, Namespace: "ns", Name: "workload", UID: types.UID(tc.uid),
+		}}}
+		if result := needsPodGroup(rb); result != tc.expectResult {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, result, tc.expectResult)
+		}
+	}
+}
+
+func TestNewPodGroup(t *testing.T) {
+	ref := workv1alpha2.ObjectReference{APIVersion: "apps/v1", Kind: "StatefulSet", Namespace: "ns", Name: "sts", UID: "uid"}
+	podGroup := newPodGroup(ref)
+	if podGroup.Name != "podgroup-uid" || podGroup.Namespace != "ns" || len(podGroup.OwnerReferences) != 1 ||
+		podGroup.OwnerReferences[0].UID != "uid" || podGroup.Spec.MinResources != nil {
+		t.Errorf("Test case NewPodGroup failed, got: %v", podGroup)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadpodgroup
+
+import (
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	informerworkv1aplha2 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/work/v1alpha2"
+	listerworkv1alpha2 "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	volcanoclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	schedulinginformer "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+)
+
+func init() {
+	framework.RegisterController(&workloadPodGroupController{})
+}
+
+const controllerName = "workload-podgroup-controller"
+
+// workloadPodGroupController creates the PodGroups for the workloads no other controller creates them for, like the
+// StatefulSets, the Kubeflow jobs and the custom CRDs in the workload registry, so the dispatcher can dispatch them.
+// The PodGroups don't set the min resources, the dispatcher resolves them from the workloads.
+type workloadPodGroupController struct {
+	vcClient      volcanoclientset.Interface
+	karmadaClient karmadaclientset.Interface
+
+	volcanoInformerFactory volcanoinformer.SharedInformerFactory
+	karmadaInformerFactory karmadainformerfactory.SharedInformerFactory
+
+	resourceBindingInformer informerworkv1aplha2.ResourceBindingInformer
+	resourceBindingLister   listerworkv1alpha2.ResourceBindingLister
+
+	podGroupInformer schedulinginformer.PodGroupInformer
+	podGroupLister   schedulinglister.PodGroupLister
+
+	queue       workqueue.RateLimitingInterface
+	pgWorkerNum uint32
+}
+
+func (wc *workloadPodGroupController) Name() string {
+	return controllerName
+}
+
+func (wc *workloadPodGroupController) Initialize(opt *framework.ControllerOption) error {
+	karmadaClient, err := karmadaclientset.NewForConfig(opt.Config)
+	if err != nil {
+		return err
+	}
+
+	wc.vcClient = opt.VolcanoClient
+	wc.karmadaClient = karmadaClient
+	wc.pgWorkerNum = opt.WorkerThreadsForPG
+	wc.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	wc.karmadaInformerFactory = karmadainformerfactory.NewSharedInformerFactory(wc.karmadaClient, 0)
+	wc.resourceBindingInformer = wc.karmadaInformerFactory.Work().V1alpha2().ResourceBindings()
+	wc.resourceBindingLister = wc.resourceBindingInformer.Lister()
+	wc.resourceBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    wc.addResourceBindingHandler,
+		UpdateFunc: wc.updateResourceBindingHandler,
+	})
+
+	wc.volcanoInformerFactory = informerfactory.NewSharedInformerFactory(wc.vcClient, 0)
+	wc.podGroupInformer = wc.volcanoInformerFactory.Scheduling().V1beta1().PodGroups()
+	wc.podGroupLister = wc.podGroupInformer.Lister()
+	return nil
+}
+
+func (wc *workloadPodGroupController) Run(stopCh <-chan struct{}) {
+	// Start the factories, and wait for cache sync
+	wc.karmadaInformerFactory.Start(stopCh)
+	wc.volcanoInformerFactory.Start(stopCh)
+	for informerType, ok := range wc.karmadaInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+	for informerType, ok := range wc.volcanoInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", informerType)
+		}
+	}
+
+	for i := 1; i <= int(wc.pgWorkerNum); i++ {
+		go wait.Until(wc.worker, 0, stopCh)
+	}
+
+	klog.Infof("%s is running, pgWorkerNum: %d ......", controllerName, wc.pgWorkerNum)
+}
+
+func (wc *workloadPodGroupController) worker() {
+	for wc.processNext() {
+	}
+}
+
+func (wc *workloadPodGroupController) processNext() bool {
+	obj, shutdown := wc.queue.Get()
+	if shutdown {
+		klog.Errorf("Failed to get request from queue, queue is shutdown.")
+		return false
+	}
+
+	req := obj.(types.NamespacedName)
+	defer wc.queue.Done(req)
+
+	rb, err := wc.resourceBindingLister.ResourceBindings(req.Namespace).Get(req.Name)
+	if err != nil {
+		klog.Errorf("Failed to get ResourceBinding by <%s/%s> from cache: %v", req.Namespace, req.Name, err)
+		wc.queue.Forget(req)
+		return true
+	}
+
+	if err = wc.createPodGroupForWorkload(rb); err != nil {
+		klog.Errorf("Failed to create PodGroup for ResourceBinding <%s/%s>, err: %v", req.Namespace, req.Name, err)
+		wc.queue.AddRateLimited(req)
+		return true
+	}
+
+	wc.queue.Forget(req)
+	return true
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadpodgroup
+
+import (
+	"context"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+func (wc *workloadPodGroupController) addResourceBindingHandler(obj interface{}) {
+	rb := obj.(*workv1alpha2.ResourceBinding)
+	if !needsPodGroup(rb) {
+		return
+	}
+
+	wc.queue.Add(types.NamespacedName{
+		Name:      rb.Name,
+		Namespace: rb.Namespace,
+	})
+}
+
+func (wc *workloadPodGroupController) updateResourceBindingHandler(oldObj, newObj interface{}) {
+	oldRB := oldObj.(*workv1alpha2.ResourceBinding)
+	newRB := newObj.(*workv1alpha2.ResourceBinding)
+
+	// Only the change of the workload matters, like the one recreated with the same name.
+	if oldRB.Spec.Resource.UID == newRB.Spec.Resource.UID {
+		return
+	}
+	wc.addResourceBindingHandler(newRB)
+}
+
+func (wc *workloadPodGroupController) createPodGroupForWorkload(rb *workv1alpha2.ResourceBinding) error {
+	if !needsPodGroup(rb) {
+		return nil
+	}
+	ref := rb.Spec.Resource
+	podGroup := newPodGroup(ref)
+
+	if _, err := wc.podGroupLister.PodGroups(podGroup.Namespace).Get(podGroup.Name); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if _, err := wc.vcClient.SchedulingV1beta1().PodGroups(podGroup.Namespace).Create(context.TODO(), podGroup, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		klog.Errorf("Failed to create PodGroup <%s/%s> for %s <%s/%s>, err: %v",
+			podGroup.Namespace, podGroup.Name, ref.Kind, ref.Namespace, ref.Name, err)
+		return err
+	}
+	klog.V(4).Infof("Created PodGroup <%s/%s> for %s <%s/%s>.", podGroup.Namespace, podGroup.Name, ref.Kind, ref.Namespace, ref.Name)
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocate
+
+import (
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const ActionName = "allocate"
+
+// Action pops the suspended ResourceBindings from the queues in the order of the plugins and dispatches the ones
+// allowed by the plugins, the others are told why by the conditions.
+type Action struct{}
+
+func New() *Action {
+	return &Action{}
+}
+
+func (alloc *Action) Name() string {
+	return ActionName
+}
+
+func (alloc *Action) Execute(ssn *framework.Session) {
+	klog.V(5).Infof("Allocate start running...")
+	defer klog.V(5).Infof("Allocate end running...")
+
+	ops := ssn.Operations()
+	ops.Enqueue()
+	// The ResourceBindings reserved by the two-phase dispatching are released after the loop, even if it's
+	// stopped by the rate limit.
+	defer ops.Commit()
+
+	queues, resourceBindingMap := ssn.BuildQueues(ops.OwnsQueue)
+	for !queues.Empty() {
+		queue := queues.Pop().(*schedulingapi.QueueInfo)
+		resourceBindingsQueue := resourceBindingMap[queue.Name]
+
+		// The dispatching of the queue is paused, tell the users by the condition and leave them suspended.
+		if ops.IsQueuePaused(queue) {
+			message := api.PausedMessage(queue.Name, ops.QueuePausedBy(queue))
+			for !resourceBindingsQueue.Empty() {
+				ops.Block(queue, resourceBindingsQueue.Pop().(*api.ResourceBindingInfo), &api.DispatchBlocker{
+					Reason:  api.DispatchPausedReason,
+					Message: message,
+				})
+			}
+			continue
+		}
+
+		for !resourceBindingsQueue.Empty() {
+			rbi := resourceBindingsQueue.Pop().(*api.ResourceBindingInfo)
+			// The expired ResourceBinding is never dispatched unless released by force.
+			if rbi.IsExpired() {
+				continue
+			}
+			// The ResourceBinding held manually is never dispatched until the hold is removed.
+			if user, held := ops.HeldBy(rbi); held {
+				ops.Hold(queue, rbi, user)
+				continue
+			}
+			blocker, speculativeCluster := ops.Dispatchable(queue, rbi)
+			if blocker != nil {
+				ops.Block(queue, rbi, blocker)
+				continue
+			}
+			// Stop dispatching when the rate limit is reached, the rest will be dispatched in the next round.
+			if !ops.Allocate(queue, rbi, speculativeCluster) {
+				return
+			}
+		}
+	}
+}
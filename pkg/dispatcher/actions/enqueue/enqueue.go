@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enqueue
+
+import (
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const ActionName = "enqueue"
+
+// Action prepares the suspended ResourceBindings for the round, the ones released manually are released before
+// all the others, and the metrics of the queues are updated. The allocate action enqueues them itself when the
+// action isn't configured, so it only decides whether it's done before the other actions.
+type Action struct{}
+
+func New() *Action {
+	return &Action{}
+}
+
+func (enqueue *Action) Name() string {
+	return ActionName
+}
+
+func (enqueue *Action) Execute(ssn *framework.Session) {
+	klog.V(5).Infof("Enqueue start running...")
+	defer klog.V(5).Infof("Enqueue end running...")
+
+	ssn.Operations().Enqueue()
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"volcano.sh/volcano-global/pkg/dispatcher/actions/allocate"
+	"volcano.sh/volcano-global/pkg/dispatcher/actions/enqueue"
+	"volcano.sh/volcano-global/pkg/dispatcher/actions/preempt"
+	"volcano.sh/volcano-global/pkg/dispatcher/actions/reclaim"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// Register the actions, they are executed in the order of the `actions` of the configuration.
+func init() {
+	framework.RegisterAction(enqueue.New())
+	framework.RegisterAction(allocate.New())
+	framework.RegisterAction(preempt.New())
+	framework.RegisterAction(reclaim.New())
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preempt
+
+import (
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const ActionName = "preempt"
+
+// Action suspends the dispatched ResourceBindings with the lower priority in the same queue, so the first
+// ResourceBinding of a queue which is blocked by the usage of the queue can be dispatched. Like reclaim, it preempts
+// for one ResourceBinding in a round at most, otherwise the released resources may be taken by the others before it.
+type Action struct{}
+
+func New() *Action {
+	return &Action{}
+}
+
+func (pa *Action) Name() string {
+	return ActionName
+}
+
+func (pa *Action) Execute(ssn *framework.Session) {
+	klog.V(5).Infof("Preempt start running...")
+	defer klog.V(5).Infof("Preempt end running...")
+
+	ops := ssn.Operations()
+	// The ones of the namespaces owned by the other replicas are preempted by them.
+	candidates := ssn.EvictionCandidates(ops.OwnsQueue)
+	if len(candidates) == 0 {
+		return
+	}
+
+	queues, resourceBindingMap := ssn.BuildQueues(ops.OwnsQueue)
+	for !queues.Empty() {
+		queue := queues.Pop().(*schedulingapi.QueueInfo)
+		if ops.IsQueuePaused(queue) {
+			continue
+		}
+
+		resourceBindingsQueue := resourceBindingMap[queue.Name]
+		for !resourceBindingsQueue.Empty() {
+			preemptor := resourceBindingsQueue.Pop().(*api.ResourceBindingInfo)
+			if !preemptor.CanPreempt() || preemptor.IsExpired() {
+				continue
+			}
+			// The ResourceBinding held manually is never dispatched, nothing is preempted for it.
+			if _, held := ops.HeldBy(preemptor); held {
+				continue
+			}
+			if ssn.Dispatchable(preemptor) == nil {
+				continue
+			}
+
+			victims := selectVictims(ssn, queue.Name, preemptor, candidates)
+			if len(victims) == 0 {
+				continue
+			}
+			for _, victim := range victims {
+				ops.Evict(victim, preemptor, api.PreemptedReason)
+			}
+			return
+		}
+	}
+}
+
+// selectVictims evicts the candidates of the queue with the lower priority than the preemptor in the session one by
+// one, until the plugins allow the preemptor to be dispatched. The evictions are reverted in the session, the victims
+// are returned to be evicted for real, or nil when evicting all of them is not enough.
+func selectVictims(ssn *framework.Session, queueName string, preemptor *api.ResourceBindingInfo,
+	candidates []*api.ResourceBindingInfo) []*api.ResourceBindingInfo {
+	var victims []*api.ResourceBindingInfo
+	dispatchable := false
+	for _, candidate := range candidates {
+		if candidate.Priority >= preemptor.Priority || ssn.GetResourceBindingInfoQueue(candidate) != queueName {
+			continue
+		}
+		ssn.Evict(candidate)
+		victims = append(victims, candidate)
+		if ssn.Dispatchable(preemptor) == nil {
+			dispatchable = true
+			break
+		}
+	}
+
+	for _, victim := range victims {
+		ssn.Unevict(victim)
+	}
+	if !dispatchable {
+		return nil
+	}
+	return victims
+}
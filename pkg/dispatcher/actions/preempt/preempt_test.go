@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preempt
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+	_ "volcano.sh/volcano-global/pkg/dispatcher/plugins"
+)
+
+// fakeCache returns the snapshot only, the other operations do nothing.
+type fakeCache struct {
+	snapshot *cache.DispatcherCacheSnapshot
+}
+
+func (fc *fakeCache) Run(_ <-chan struct{}) {}
+
+func (fc *fakeCache) Snapshot() *cache.DispatcherCacheSnapshot {
+	return fc.snapshot
+}
+
+func (fc *fakeCache) LookupResourceBinding(_ types.NamespacedName) (string, api.DispatchStatus, bool) {
+	return "", 0, false
+}
+
+func (fc *fakeCache) UnSuspendResourceBinding(_ types.NamespacedName) {}
+
+func (fc *fakeCache) UnSuspendResourceBindingToCluster(_ types.NamespacedName, _ string) {}
+
+func (fc *fakeCache) SuspendResourceBinding(_ types.NamespacedName) {}
+
+func (fc *fakeCache) SuspendResourceBindingOnFailure(_ types.NamespacedName, _ []string) {}
+
+func (fc *fakeCache) FailResourceBinding(_ types.NamespacedName) {}
+
+func (fc *fakeCache) UpdateResourceBindingCondition(_ types.NamespacedName, _ metav1.Condition) {}
+
+func (fc *fakeCache) AnnotateResourceBinding(_ types.NamespacedName, _, _ string) {}
+
+func (fc *fakeCache) ExpireWorkload(_ types.NamespacedName, _ string) {}
+
+func (fc *fakeCache) CheckConsistency(_ context.Context, _ bool) (*api.ConsistencyReport, error) {
+	return &api.ConsistencyReport{}, nil
+}
+
+func (fc *fakeCache) CollectGarbage() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) WakeUp() <-chan struct{} {
+	return nil
+}
+
+func (fc *fakeCache) WorkQueueDepths() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) TakeCoalescedStatusUpdates() int {
+	return 0
+}
+
+func (fc *fakeCache) TakeEventErrors() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) Resync(_ string) int {
+	return 0
+}
+
+func (fc *fakeCache) SetDefaultQueue(_ string) {}
+
+func (fc *fakeCache) SetMinResourcesSource(_ string) {}
+
+func (fc *fakeCache) SetQueuePausedBy(_, _ string) error { return nil }
+
+// fakeOperations records the evicted ResourceBindings, the other operations do nothing.
+type fakeOperations struct {
+	evicted []string
+}
+
+func (fo *fakeOperations) OwnsQueue(_ string) bool { return true }
+
+func (fo *fakeOperations) IsQueuePaused(_ *schedulingapi.QueueInfo) bool { return false }
+
+func (fo *fakeOperations) QueuePausedBy(_ *schedulingapi.QueueInfo) string { return "" }
+
+func (fo *fakeOperations) HeldBy(_ *api.ResourceBindingInfo) (string, bool) { return "", false }
+
+func (fo *fakeOperations) Enqueue() {}
+
+func (fo *fakeOperations) Dispatchable(_ *schedulingapi.QueueInfo, _ *api.ResourceBindingInfo) (*api.DispatchBlocker, string) {
+	return nil, ""
+}
+
+func (fo *fakeOperations) Block(_ *schedulingapi.QueueInfo, _ *api.ResourceBindingInfo, _ *api.DispatchBlocker) {
+}
+
+func (fo *fakeOperations) Hold(_ *schedulingapi.QueueInfo, _ *api.ResourceBindingInfo, _ string) {}
+
+func (fo *fakeOperations) Allocate(_ *schedulingapi.QueueInfo, _ *api.ResourceBindingInfo, _ string) bool {
+	return true
+}
+
+func (fo *fakeOperations) Commit() {}
+
+func (fo *fakeOperations) Evict(victim, _ *api.ResourceBindingInfo, reason string) {
+	if reason == api.PreemptedReason {
+		fo.evicted = append(fo.evicted, victim.ResourceBinding.Name)
+	}
+}
+
+func TestPreempt(t *testing.T) {
+	cpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+	newQueue := func(name string) *schedulingapi.QueueInfo {
+		return &schedulingapi.QueueInfo{Name: name, Queue: &scheduling.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       scheduling.QueueSpec{Capability: cpu("4")},
+		}}
+	}
+	newRBI := func(name, queue string, priority int32, status api.DispatchStatus) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				UID:       types.UID(name),
+			}},
+			Queue:          queue,
+			Priority:       priority,
+			DispatchStatus: status,
+			PodGroup:       &schedulingv1beta1.PodGroup{},
+			MinResources:   cpu("3"),
+		}
+	}
+
+	testCases := []struct {
+		Name          string
+		dispatched    []*api.ResourceBindingInfo
+		expectEvicted []string
+	}{
+		{
+			Name:          "Preempt the lower priority one of the queue",
+			dispatched:    []*api.ResourceBindingInfo{newRBI("low", "q1", 0, api.Dispatched)},
+			expectEvicted: []string{"low"},
+		},
+		{
+			Name:       "Same priority one is not preempted",
+			dispatched: []*api.ResourceBindingInfo{newRBI("same", "q1", 1, api.Dispatched)},
+		},
+		{
+			Name: "Lower priority one of the other queue is not preempted",
+			dispatched: []*api.ResourceBindingInfo{
+				newRBI("same", "q1", 1, api.Dispatched),
+				newRBI("other", "q2", 0, api.Dispatched),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue: "q1",
+			QueueInfos: map[string]*schedulingapi.QueueInfo{
+				"q1": newQueue("q1"),
+				"q2": newQueue("q2"),
+			},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+			Clusters: map[string]*clusterv1alpha1.Cluster{"member": {
+				Status: clusterv1alpha1.ClusterStatus{
+					Conditions:      []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: metav1.ConditionTrue}},
+					ResourceSummary: &clusterv1alpha1.ResourceSummary{Allocatable: cpu("10")},
+				},
+			}},
+		}
+		for _, rbi := range append(tc.dispatched, newRBI("preemptor", "q1", 1, api.Pending)) {
+			snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+		}
+
+		ops := &fakeOperations{}
+		ssn := framework.OpenSession(&fakeCache{snapshot: snapshot}, []conf.PluginOption{{Name: "priority"}, {Name: "capacity"}})
+		ssn.SetOperations(ops)
+		New().Execute(ssn)
+		ssn.CloseSession()
+
+		if !reflect.DeepEqual(ops.evicted, tc.expectEvicted) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, ops.evicted, tc.expectEvicted)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reclaim
+
+import (
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const ActionName = "reclaim"
+
+// Action suspends the dispatched ResourceBindings of the queues which borrowed the resources, so the first
+// ResourceBinding which is blocked by the reclaimable blockers only can be dispatched. It reclaims for one
+// ResourceBinding in a round at most, otherwise the released resources may be taken by the others before it.
+type Action struct{}
+
+func New() *Action {
+	return &Action{}
+}
+
+func (ra *Action) Name() string {
+	return ActionName
+}
+
+func (ra *Action) Execute(ssn *framework.Session) {
+	klog.V(5).Infof("Reclaim start running...")
+	defer klog.V(5).Infof("Reclaim end running...")
+
+	ops := ssn.Operations()
+	// The ones of the namespaces owned by the other replicas are reclaimed by them.
+	candidates := ssn.EvictionCandidates(ops.OwnsQueue)
+	if len(candidates) == 0 {
+		return
+	}
+
+	queues, resourceBindingMap := ssn.BuildQueues(ops.OwnsQueue)
+	for !queues.Empty() {
+		queue := queues.Pop().(*schedulingapi.QueueInfo)
+		if ops.IsQueuePaused(queue) {
+			continue
+		}
+
+		resourceBindingsQueue := resourceBindingMap[queue.Name]
+		for !resourceBindingsQueue.Empty() {
+			reclaimer := resourceBindingsQueue.Pop().(*api.ResourceBindingInfo)
+			if !reclaimer.CanPreempt() || !isReclaimable(ssn.DispatchBlockers(reclaimer)) {
+				continue
+			}
+			// The ResourceBinding held manually or expired is never dispatched, nothing is reclaimed for it.
+			if _, held := ops.HeldBy(reclaimer); held || reclaimer.IsExpired() {
+				continue
+			}
+
+			// The workloads with the higher priority than the reclaimer, or of the queues its queue may not preempt,
+			// are never reclaimed for it.
+			allowedCandidates := make([]*api.ResourceBindingInfo, 0, len(candidates))
+			for _, candidate := range candidates {
+				if candidate.Priority <= reclaimer.Priority && ssn.MayPreempt(queue.Name, ssn.GetResourceBindingInfoQueue(candidate)) {
+					allowedCandidates = append(allowedCandidates, candidate)
+				}
+			}
+			victims := ssn.Reclaimable(reclaimer, allowedCandidates)
+			if len(victims) == 0 {
+				continue
+			}
+
+			for _, victim := range victims {
+				ops.Evict(victim, reclaimer, api.ReclaimedReason)
+			}
+			return
+		}
+	}
+}
+
+// isReclaimable checks whether the ResourceBinding is blocked, and all the blockers can be resolved by reclaiming.
+func isReclaimable(blockers []*api.DispatchBlocker) bool {
+	for _, blocker := range blockers {
+		if !blocker.Reclaimable {
+			return false
+		}
+	}
+	return len(blockers) > 0
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DispatchCheckpoint is the state of the ResourceBindingInfo owned by the dispatcher, which can't be observed from
+// the ResourceBinding after the dispatcher restarts. The times are in seconds.
+type DispatchCheckpoint struct {
+	FirstSeenTime     metav1.Time         `json:"firstSeenTime"`
+	EnqueueTime       metav1.Time         `json:"enqueueTime"`
+	UnSuspendTime     metav1.Time         `json:"unSuspendTime"`
+	AdmittedResources corev1.ResourceList `json:"admittedResources,omitempty"`
+	// ResourceUID is the UID of the workload checkpointed, the checkpoint of a workload deleted and created again
+	// with the same name is not restored.
+	ResourceUID types.UID `json:"resourceUID,omitempty"`
+	Preemptions int32     `json:"preemptions,omitempty"`
+	Failures    int32     `json:"failures,omitempty"`
+}
+
+// Checkpoint returns the DispatchCheckpoint of the ResourceBindingInfo in JSON.
+func (rbi *ResourceBindingInfo) Checkpoint() string {
+	value, _ := json.Marshal(&DispatchCheckpoint{
+		FirstSeenTime:     metav1.NewTime(rbi.FirstSeenTime.Truncate(time.Second)),
+		EnqueueTime:       metav1.NewTime(rbi.EnqueueTime.Truncate(time.Second)),
+		UnSuspendTime:     metav1.NewTime(rbi.UnSuspendTime.Truncate(time.Second)),
+		AdmittedResources: rbi.AdmittedResources,
+		ResourceUID:       rbi.ResourceUID,
+		Preemptions:       rbi.Preemptions,
+		Failures:          rbi.Failures,
+	})
+	return string(value)
+}
+
+// RestoreCheckpoint restores the state of the ResourceBindingInfo seen the first time from the DispatchCheckpoint
+// annotation of its ResourceBinding. The state is only restored when it matches the observed status, like the
+// ResourceBinding suspended after it was checkpointed as dispatched starts waiting in the queue again.
+func (rbi *ResourceBindingInfo) RestoreCheckpoint() bool {
+	value, found := rbi.ResourceBinding.Annotations[DispatchCheckpointAnnotationKey]
+	if !found {
+		return false
+	}
+	checkpoint := &DispatchCheckpoint{}
+	if err := json.Unmarshal([]byte(value), checkpoint); err != nil {
+		return false
+	}
+	if checkpoint.ResourceUID != "" && rbi.ResourceUID != "" && checkpoint.ResourceUID != rbi.ResourceUID {
+		return false
+	}
+
+	if !checkpoint.FirstSeenTime.IsZero() {
+		rbi.FirstSeenTime = checkpoint.FirstSeenTime.Time
+	}
+	rbi.Preemptions, rbi.Failures = checkpoint.Preemptions, checkpoint.Failures
+	dispatched := !checkpoint.UnSuspendTime.IsZero()
+	if rbi.DispatchStatus.IsDispatched() != dispatched {
+		return true
+	}
+	if !checkpoint.EnqueueTime.IsZero() {
+		rbi.EnqueueTime = checkpoint.EnqueueTime.Time
+	}
+	if dispatched {
+		rbi.UnSuspendTime = checkpoint.UnSuspendTime.Time
+		if checkpoint.AdmittedResources != nil {
+			rbi.AdmittedResources = checkpoint.AdmittedResources
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DebugQueue is the Queue in the dispatcher cache, served by the debug endpoints.
+type DebugQueue struct {
+	Name        string              `json:"name"`
+	State       string              `json:"state"`
+	Priority    int32               `json:"priority"`
+	Weight      int32               `json:"weight"`
+	Guarantee   corev1.ResourceList `json:"guarantee,omitempty"`
+	Deserved    corev1.ResourceList `json:"deserved,omitempty"`
+	Capability  corev1.ResourceList `json:"capability,omitempty"`
+	Annotations map[string]string   `json:"annotations,omitempty"`
+}
+
+// DebugResourceBinding is the ResourceBindingInfo in the dispatcher cache, served by the debug endpoints.
+type DebugResourceBinding struct {
+	Namespace         string              `json:"namespace"`
+	Name              string              `json:"name"`
+	Queue             string              `json:"queue"`
+	Priority          int32               `json:"priority"`
+	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	DispatchStatus    string              `json:"dispatchStatus"`
+	PodGroup          string              `json:"podGroup,omitempty"`
+	PodGroupPhase     string              `json:"podGroupPhase,omitempty"`
+	MinResources      corev1.ResourceList `json:"minResources,omitempty"`
+	FirstSeenTime     time.Time           `json:"firstSeenTime,omitempty"`
+	EnqueueTime       time.Time           `json:"enqueueTime,omitempty"`
+	UnSuspendTime     time.Time           `json:"unSuspendTime,omitempty"`
+	// TransitionTimes[status] is the last time when the ResourceBinding transitioned to the status.
+	TransitionTimes map[string]time.Time `json:"transitionTimes,omitempty"`
+}
+
+// SnapshotStreamVersion is the version of the SnapshotUpdate, it's bumped on the incompatible changes of the messages.
+const SnapshotStreamVersion = "v1"
+
+// SnapshotUpdate is the state of a control plane sent by the snapshot stream after each dispatching round.
+type SnapshotUpdate struct {
+	Version      string `json:"version"`
+	ControlPlane string `json:"controlPlane"`
+	// Cycle is the last dispatching round finished before the update, zero means no round is finished yet.
+	Cycle            uint64                 `json:"cycle"`
+	Time             time.Time              `json:"time"`
+	Queues           []DebugQueue           `json:"queues"`
+	ResourceBindings []DebugResourceBinding `json:"resourceBindings"`
+	// Decisions are the ones made since the last update of the stream, the first update carries all the recorded ones.
+	Decisions []Decision `json:"decisions,omitempty"`
+}
+
+// Decision is a decision made by the dispatcher for a ResourceBinding in a round.
+type Decision struct {
+	Time       time.Time `json:"time"`
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	Queue      string    `json:"queue"`
+	Dispatched bool      `json:"dispatched"`
+	// Evicted is true when the dispatched ResourceBinding is suspended again.
+	Evicted bool   `json:"evicted,omitempty"`
+	Plugin  string `json:"plugin,omitempty"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+	// Cycle is the dispatching round of the control plane which made the decision.
+	Cycle uint64 `json:"cycle,omitempty"`
+	// User is who made the decision manually by the endpoints, like releasing the ResourceBinding by force.
+	User string `json:"user,omitempty"`
+}
+
+const (
+	DecisionDispatched = "Dispatched"
+	DecisionEvicted    = "Evicted"
+	DecisionBlocked    = "Blocked"
+)
+
+// DecisionAnnotation is the compact decision in the LastDispatchDecisionAnnotationKey annotation of the ResourceBinding.
+type DecisionAnnotation struct {
+	// Decision is one of Dispatched, Evicted and Blocked.
+	Decision string    `json:"decision"`
+	Cycle    uint64    `json:"cycle"`
+	Time     time.Time `json:"time"`
+	Plugin   string    `json:"plugin,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// Annotation returns the compact form of the decision, the message is left in the condition of the ResourceBinding.
+func (d *Decision) Annotation() DecisionAnnotation {
+	annotation := DecisionAnnotation{
+		Decision: DecisionBlocked,
+		Cycle:    d.Cycle,
+		Time:     d.Time.UTC().Truncate(time.Second),
+		Plugin:   d.Plugin,
+		Reason:   d.Reason,
+	}
+	if d.Dispatched {
+		annotation.Decision = DecisionDispatched
+	} else if d.Evicted {
+		annotation.Decision = DecisionEvicted
+	}
+	return annotation
+}
+
+// ManualOperation is the response of the endpoints holding and releasing a ResourceBinding manually.
+type ManualOperation struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Operation is one of Hold, Unhold and Release.
+	Operation string `json:"operation"`
+	User      string `json:"user"`
+	Message   string `json:"message,omitempty"`
+}
+
+const (
+	OperationHold    = "Hold"
+	OperationUnhold  = "Unhold"
+	OperationRelease = "Release"
+)
+
+// QueueUsage is the usage of the elastic quota of a queue computed from the latest snapshot, for the chargeback.
+type QueueUsage struct {
+	Name string `json:"name"`
+	// Deserved is the resources the queue can use without borrowing, limited by its guarantee and capability.
+	Deserved corev1.ResourceList `json:"deserved,omitempty"`
+	// Used is the resources of the dispatched but not completed ResourceBindings in the queue.
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// Borrowed is the resources used beyond the deserved ones.
+	Borrowed corev1.ResourceList `json:"borrowed,omitempty"`
+	// Lent is the unused deserved resources used by the borrowing queues, the borrowed resources are attributed to
+	// the queues in proportion to their unused deserved resources.
+	Lent corev1.ResourceList `json:"lent,omitempty"`
+}
+
+// ControlPlaneHealth is the health of a Karmada control plane served by the dispatcher.
+type ControlPlaneHealth struct {
+	Name string `json:"name"`
+	// Healthy is false when the dispatching rounds are stuck.
+	Healthy bool `json:"healthy"`
+	// Ready is true after the informers of the control plane are synced.
+	Ready            bool      `json:"ready"`
+	Synced           bool      `json:"synced"`
+	LastDispatchTime time.Time `json:"lastDispatchTime,omitempty"`
+	Message          string    `json:"message,omitempty"`
+}
+
+// ConsistencyReport is the differences between the dispatcher cache and the apiserver.
+type ConsistencyReport struct {
+	Time             time.Time       `json:"time"`
+	ResourceBindings ConsistencyDiff `json:"resourceBindings"`
+	Queues           ConsistencyDiff `json:"queues"`
+	// Healed is true when the differences are fixed by resyncing the objects from the apiserver.
+	Healed bool `json:"healed"`
+}
+
+// ConsistencyDiff is the keys of the objects which are different between the dispatcher cache and the apiserver.
+type ConsistencyDiff struct {
+	// Missing objects are in the apiserver but not in the cache.
+	Missing []string `json:"missing,omitempty"`
+	// Stale objects are in the cache but deleted from the apiserver.
+	Stale []string `json:"stale,omitempty"`
+	// Outdated objects are in both of them, but the spec of the cached one is older.
+	Outdated []string `json:"outdated,omitempty"`
+}
+
+// Consistent checks whether there is no difference.
+func (report *ConsistencyReport) Consistent() bool {
+	return report.ResourceBindings.empty() && report.Queues.empty()
+}
+
+func (diff *ConsistencyDiff) empty() bool {
+	return len(diff.Missing) == 0 && len(diff.Stale) == 0 && len(diff.Outdated) == 0
+}
@@ -17,37 +17,297 @@ limitations under the License.
 package api
 
 import (
+	"slices"
+	"sort"
+	"time"
+
 	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
+// DispatchStatus is the lifecycle state of the ResourceBinding in the dispatcher.
 type DispatchStatus int16
 
 const (
-	Suspended DispatchStatus = 1 << iota
-	UnSuspending
-	UnSuspended
+	// Pending means the ResourceBinding is suspended and waits in the queue for the admission.
+	Pending DispatchStatus = 1 << iota
+	// Admitted means the ResourceBinding is admitted by the dispatcher, the unsuspending task is queued.
+	Admitted
+	// Dispatching means the ResourceBinding is being unsuspended, the dispatcher waits for the patch to be observed.
+	Dispatching
+	// Dispatched means the ResourceBinding is unsuspended and released to Karmada.
+	Dispatched
+	// Failed means the dispatcher failed to unsuspend the ResourceBinding, it waits in the queue for the next round.
+	Failed
+	// Preempted means the ResourceBinding is suspended again by the dispatcher, it waits in the queue to be admitted again.
+	Preempted
 )
 
+func (ds DispatchStatus) String() string {
+	switch ds {
+	case Pending:
+		return "Pending"
+	case Admitted:
+		return "Admitted"
+	case Dispatching:
+		return "Dispatching"
+	case Dispatched:
+		return "Dispatched"
+	case Failed:
+		return "Failed"
+	case Preempted:
+		return "Preempted"
+	default:
+		return "Unknown"
+	}
+}
+
+// IsDispatched checks whether the ResourceBinding is admitted by the dispatcher, including the ones being unsuspended,
+// its resources are counted as allocated. The Pending, Failed and Preempted ones wait in the queues.
+func (ds DispatchStatus) IsDispatched() bool {
+	return ds == Admitted || ds == Dispatching || ds == Dispatched
+}
+
 type ResourceBindingInfo struct {
 	ResourceBinding *workv1alpha2.ResourceBinding
 
 	ResourceUID types.UID
 	Queue       string
-	Priority    int32
-	PodGroup    *schedulingv1beta1.PodGroup
+	// WorkloadQueue is the queue annotation of the workload, it's captured when the cache resolves the workload.
+	WorkloadQueue string
+	// Priority is resolved from the PriorityClass of the workload, the default PriorityClass is used if it didn't set one.
+	Priority          int32
+	PriorityClassName string
+	// PriorityOverridden is true when the Priority is overridden by the `volcano.sh/priority-override` annotation.
+	PriorityOverridden bool
+	// PreemptionPolicy is inherited from the PriorityClass, the workload with `Never` can't preempt the others.
+	PreemptionPolicy corev1.PreemptionPolicy
+	PodGroup         *schedulingv1beta1.PodGroup
+	// MinResources is the minimum resources of the workload's gang, computed by the WorkloadResourceResolver.
+	MinResources corev1.ResourceList
+	// FirstStageResources is the resources of the first stage of the staged workload, like the first parallel step set
+	// of the Argo Workflow, it's nil for the other workloads.
+	FirstStageResources corev1.ResourceList
+	// AdmittedResources is the MinResources when the ResourceBinding was dispatched, it's nil if it's not dispatched.
+	AdmittedResources corev1.ResourceList
+	// AdmittedClusters are the sorted clusters Karmada scheduled the ResourceBinding to first after it was dispatched,
+	// it's nil if it's not dispatched or not scheduled yet.
+	AdmittedClusters []string
+	// FailedPlacement are the sorted clusters the ResourceBinding was scheduled to when it was suspended again as some
+	// of them were not ready, it's not dispatched again until it's scheduled to the other clusters or they're ready.
+	FailedPlacement []string
+	// ClusterReplicaRequirements[cluster] is the ReplicaRequirements overridden by the OverridePolicies in the
+	// target cluster, it's nil when the overrides are not evaluated or no override changes the resource requests.
+	ClusterReplicaRequirements map[string]*workv1alpha2.ReplicaRequirements
 
 	DispatchStatus DispatchStatus
+	// TransitionTimes[status] is the last time when the ResourceBinding transitioned to the status.
+	TransitionTimes map[DispatchStatus]time.Time
+
+	// FirstSeenTime is the time when the dispatcher first saw the ResourceBinding.
+	FirstSeenTime time.Time
+	// EnqueueTime is the time when the ResourceBinding is suspended and starts waiting in the queue.
+	EnqueueTime time.Time
+	// UnSuspendTime is the time when the dispatcher unsuspends the ResourceBinding.
+	UnSuspendTime time.Time
+	// Preemptions is the number of the times the dispatched ResourceBinding is suspended again by the preemption.
+	Preemptions int32
+	// Failures is the number of the times the dispatching failed, like the unsuspending patch failed or the workload
+	// was not applied to the member clusters in time, and the ResourceBinding waits in the queue again.
+	Failures int32
+	// SpeculativeCluster is the elastic cluster the ResourceBinding is released toward speculatively, it's empty
+	// when the ResourceBinding is released as scheduled.
+	SpeculativeCluster string
+	// Unadmitted is true when the ResourceBinding was created unsuspended and never admitted by the queues, like its
+	// suspension was skipped by the webhook installed with the `Ignore` failurePolicy.
+	Unadmitted bool
+	// ClusterCost is the relative cost of the clusters the workload is expected to land on, it's set by the cost
+	// plugin in the session, and nil when the plugin is disabled.
+	ClusterCost *float64
 }
 
 func (rbi *ResourceBindingInfo) DeepCopy() *ResourceBindingInfo {
 	return &ResourceBindingInfo{
-		ResourceBinding: rbi.ResourceBinding.DeepCopy(),
-		ResourceUID:     rbi.ResourceUID,
-		Queue:           rbi.Queue,
-		Priority:        rbi.Priority,
-		PodGroup:        rbi.PodGroup.DeepCopy(),
-		DispatchStatus:  rbi.DispatchStatus,
+		ResourceBinding:     rbi.ResourceBinding.DeepCopy(),
+		ResourceUID:         rbi.ResourceUID,
+		Queue:               rbi.Queue,
+		WorkloadQueue:       rbi.WorkloadQueue,
+		Priority:            rbi.Priority,
+		PriorityClassName:   rbi.PriorityClassName,
+		PriorityOverridden:  rbi.PriorityOverridden,
+		PreemptionPolicy:    rbi.PreemptionPolicy,
+		PodGroup:            rbi.PodGroup.DeepCopy(),
+		MinResources:        rbi.MinResources.DeepCopy(),
+		FirstStageResources: rbi.FirstStageResources.DeepCopy(),
+		AdmittedResources:   rbi.AdmittedResources.DeepCopy(),
+		AdmittedClusters:    slices.Clone(rbi.AdmittedClusters),
+		FailedPlacement:     slices.Clone(rbi.FailedPlacement),
+
+		ClusterReplicaRequirements: copyClusterReplicaRequirements(rbi.ClusterReplicaRequirements),
+		DispatchStatus:             rbi.DispatchStatus,
+		TransitionTimes:            copyTransitionTimes(rbi.TransitionTimes),
+		FirstSeenTime:              rbi.FirstSeenTime,
+		EnqueueTime:                rbi.EnqueueTime,
+		UnSuspendTime:              rbi.UnSuspendTime,
+		Preemptions:                rbi.Preemptions,
+		Failures:                   rbi.Failures,
+		SpeculativeCluster:         rbi.SpeculativeCluster,
+		Unadmitted:                 rbi.Unadmitted,
+		ClusterCost:                rbi.ClusterCost,
+	}
+}
+
+// SetDispatchStatus transitions the ResourceBindingInfo to the status, the transition time is recorded
+// only when the status changes.
+func (rbi *ResourceBindingInfo) SetDispatchStatus(status DispatchStatus, now time.Time) {
+	if rbi.DispatchStatus == status && !rbi.TransitionTimes[status].IsZero() {
+		return
+	}
+	rbi.DispatchStatus = status
+	if rbi.TransitionTimes == nil {
+		rbi.TransitionTimes = map[DispatchStatus]time.Time{}
+	}
+	rbi.TransitionTimes[status] = now
+}
+
+// TransitionTimesByName returns the transition times by the name of the status, for the debug endpoints.
+func (rbi *ResourceBindingInfo) TransitionTimesByName() map[string]time.Time {
+	transitionTimes := make(map[string]time.Time, len(rbi.TransitionTimes))
+	for status, transitionTime := range rbi.TransitionTimes {
+		transitionTimes[status.String()] = transitionTime
+	}
+	return transitionTimes
+}
+
+func copyTransitionTimes(transitionTimes map[DispatchStatus]time.Time) map[DispatchStatus]time.Time {
+	if transitionTimes == nil {
+		return nil
+	}
+	copied := make(map[DispatchStatus]time.Time, len(transitionTimes))
+	for status, transitionTime := range transitionTimes {
+		copied[status] = transitionTime
+	}
+	return copied
+}
+
+func copyClusterReplicaRequirements(requirements map[string]*workv1alpha2.ReplicaRequirements) map[string]*workv1alpha2.ReplicaRequirements {
+	if requirements == nil {
+		return nil
+	}
+	copied := make(map[string]*workv1alpha2.ReplicaRequirements, len(requirements))
+	for cluster, requirement := range requirements {
+		copied[cluster] = requirement.DeepCopy()
+	}
+	return copied
+}
+
+// Retries returns the number of the times the ResourceBinding waits in the queue again after it was dispatched.
+func (rbi *ResourceBindingInfo) Retries() int32 {
+	return rbi.Preemptions + rbi.Failures
+}
+
+// CanPreempt checks whether the ResourceBindingInfo is allowed to preempt the lower priority ones.
+func (rbi *ResourceBindingInfo) CanPreempt() bool {
+	return rbi.PreemptionPolicy != corev1.PreemptNever
+}
+
+// IsSpeculative checks whether the ResourceBinding is released toward the elastic cluster speculatively, by the
+// dispatcher in this run or in the previous runs.
+func (rbi *ResourceBindingInfo) IsSpeculative() bool {
+	if rbi.SpeculativeCluster != "" {
+		return true
+	}
+	_, found := rbi.ResourceBinding.Annotations[SpeculativeReleaseAnnotationKey]
+	return found
+}
+
+// IsRunning checks whether the dispatched workload is running in the member clusters.
+// The phase of the PodGroup is used if it has one, otherwise the workload is running when it's healthy in all the target clusters.
+func (rbi *ResourceBindingInfo) IsRunning() bool {
+	if rbi.PodGroup != nil {
+		return rbi.PodGroup.Status.Phase == schedulingv1beta1.PodGroupRunning ||
+			rbi.PodGroup.Status.Phase == schedulingv1beta1.PodGroupCompleted
+	}
+
+	aggregatedStatus := rbi.ResourceBinding.Status.AggregatedStatus
+	if len(aggregatedStatus) == 0 {
+		return false
+	}
+	for _, item := range aggregatedStatus {
+		if item.Health != workv1alpha2.ResourceHealthy {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPreemptable checks whether the dispatched workload can be suspended again, it's preemptable
+// unless the ResourceBinding or its PodGroup is annotated with `volcano.sh/preemptable: "false"`.
+func (rbi *ResourceBindingInfo) IsPreemptable() bool {
+	if rbi.ResourceBinding.Annotations[PreemptableAnnotationKey] == "false" {
+		return false
+	}
+	return rbi.PodGroup == nil || rbi.PodGroup.Annotations[PreemptableAnnotationKey] != "false"
+}
+
+// IsExpired checks whether the ResourceBinding is expired after waiting suspended longer than the TTL of its queue.
+func (rbi *ResourceBindingInfo) IsExpired() bool {
+	return rbi.ResourceBinding.Annotations[ExpiredAnnotationKey] != ""
+}
+
+// IsCompleted checks whether the dispatched workload is completed, its resources are released in the member clusters.
+func (rbi *ResourceBindingInfo) IsCompleted() bool {
+	return rbi.PodGroup != nil && rbi.PodGroup.Status.Phase == schedulingv1beta1.PodGroupCompleted
+}
+
+// Deadline returns the deadline of the workload, the annotation of the ResourceBinding takes precedence over
+// the one of the PodGroup, it returns false if neither of them sets a valid deadline.
+func (rbi *ResourceBindingInfo) Deadline() (time.Time, bool) {
+	value, found := rbi.ResourceBinding.Annotations[DeadlineAnnotationKey]
+	if !found && rbi.PodGroup != nil {
+		value, found = rbi.PodGroup.Annotations[DeadlineAnnotationKey]
+	}
+	if !found {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// ScheduledClusters returns the sorted clusters in the scheduling result of the ResourceBinding.
+func (rbi *ResourceBindingInfo) ScheduledClusters() []string {
+	clusters := make([]string, 0, len(rbi.ResourceBinding.Spec.Clusters))
+	for _, cluster := range rbi.ResourceBinding.Spec.Clusters {
+		clusters = append(clusters, cluster.Name)
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// IsRescheduled checks whether Karmada reschedules the dispatched workload to the different clusters from the ones
+// it was scheduled to first after it was dispatched.
+func (rbi *ResourceBindingInfo) IsRescheduled() bool {
+	if rbi.AdmittedClusters == nil || len(rbi.ResourceBinding.Spec.Clusters) == 0 {
+		return false
+	}
+	return !slices.Equal(rbi.AdmittedClusters, rbi.ScheduledClusters())
+}
+
+// IsGrown checks whether the dispatched workload requests more resources than the ones admitted when it was dispatched.
+func (rbi *ResourceBindingInfo) IsGrown() bool {
+	if rbi.AdmittedResources == nil {
+		return false
+	}
+	for name, quantity := range rbi.MinResources {
+		admitted := rbi.AdmittedResources[name]
+		if quantity.Cmp(admitted) > 0 {
+			return true
+		}
 	}
+	return false
 }
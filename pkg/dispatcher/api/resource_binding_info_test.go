@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestResourceBindingInfoDeepCopy(t *testing.T) {
+	now := time.Now()
+	resources := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+	rbi := &ResourceBindingInfo{
+		ResourceBinding:     &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb"}},
+		ResourceUID:         "uid",
+		Queue:               "queue",
+		WorkloadQueue:       "workload-queue",
+		Priority:            100,
+		PriorityClassName:   "high",
+		PriorityOverridden:  true,
+		PreemptionPolicy:    corev1.PreemptNever,
+		PodGroup:            &schedulingv1beta1.PodGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pg"}},
+		MinResources:        resources,
+		FirstStageResources: resources,
+		AdmittedResources:   resources,
+		AdmittedClusters:    []string{"member1"},
+		FailedPlacement:     []string{"member2"},
+
+		ClusterReplicaRequirements: map[string]*workv1alpha2.ReplicaRequirements{"member1": {ResourceRequest: resources}},
+		DispatchStatus:             Dispatched,
+		TransitionTimes:            map[DispatchStatus]time.Time{Dispatched: now},
+		FirstSeenTime:              now.Add(-3 * time.Minute),
+		EnqueueTime:                now.Add(-2 * time.Minute),
+		UnSuspendTime:              now.Add(-time.Minute),
+		Preemptions:                1,
+		Failures:                   2,
+		SpeculativeCluster:         "member3",
+		Unadmitted:                 true,
+		ClusterCost:                ptr.To(1.5),
+	}
+	// Every field is set, so the ones not copied are caught.
+	value := reflect.ValueOf(rbi).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if value.Field(i).IsZero() {
+			t.Fatalf("Test case DeepCopy failed, field %s is not set", value.Type().Field(i).Name)
+		}
+	}
+
+	copied := rbi.DeepCopy()
+	if !reflect.DeepEqual(copied, rbi) {
+		t.Errorf("Test case DeepCopy failed, got: %+v expect: %+v", copied, rbi)
+	}
+	copied.AdmittedClusters[0] = "changed"
+	copied.TransitionTimes[Failed] = now
+	if rbi.AdmittedClusters[0] != "member1" || len(rbi.TransitionTimes) != 1 {
+		t.Errorf("Test case DeepCopy failed, the copy shares the slices or the maps with the original")
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SizeClass is the class of the workload by the resources it requests.
+type SizeClass string
+
+const (
+	// SizeSmall is the class of the workloads requesting no more than the small thresholds of all the resources.
+	SizeSmall SizeClass = "small"
+	// SizeMedium is the class of the workloads which are neither small nor large.
+	SizeMedium SizeClass = "medium"
+	// SizeLarge is the class of the workloads requesting the large thresholds of any resource at least.
+	SizeLarge SizeClass = "large"
+)
+
+// SizeThresholds are the thresholds of the resources classifying the workloads, the resources without the
+// thresholds are not compared.
+type SizeThresholds struct {
+	Small corev1.ResourceList
+	Large corev1.ResourceList
+}
+
+// Classify returns the class of the workload requesting the resources, the large thresholds take precedence.
+func (thresholds SizeThresholds) Classify(resources corev1.ResourceList) SizeClass {
+	for name, threshold := range thresholds.Large {
+		if quantity, found := resources[name]; found && quantity.Cmp(threshold) >= 0 {
+			return SizeLarge
+		}
+	}
+	for name, threshold := range thresholds.Small {
+		if quantity, found := resources[name]; found && quantity.Cmp(threshold) > 0 {
+			return SizeMedium
+		}
+	}
+	return SizeSmall
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DispatchedCondition is the condition type set on the ResourceBinding by the dispatcher,
+// it is False with the blocking reason when the ResourceBinding can't be dispatched.
+const DispatchedCondition = "Dispatched"
+
+// DispatchedReason is the reason of the DispatchedCondition when the ResourceBinding is dispatched.
+const DispatchedReason = "Dispatched"
+
+// DispatchPausedReason is the reason of the DispatchedCondition when the dispatching of the queue is paused.
+const DispatchPausedReason = "DispatchPaused"
+
+// DispatchHoldAnnotationKey is the annotation on the ResourceBinding holding it manually, its value is the user who
+// holds it. The ResourceBinding held is never released until the annotation is removed or empty.
+const DispatchHoldAnnotationKey = "volcano.sh/dispatch-hold"
+
+// DispatchHeldReason is the reason of the DispatchedCondition when the ResourceBinding is held manually.
+const DispatchHeldReason = "DispatchHeld"
+
+// ForceReleasedReason is the reason of the DispatchedCondition when the ResourceBinding is released by force,
+// bypassing the plugins.
+const ForceReleasedReason = "ForceReleased"
+
+// QueuePausedAnnotationKey is the annotation on the Queue to pause dispatching the ResourceBindings in it.
+const QueuePausedAnnotationKey = "volcano.sh/dispatch-paused"
+
+// QueuePausedByAnnotationKey is the annotation on the Queue written by the pause endpoint of the dispatcher, its
+// value is the user who paused the queue. It's apart from QueuePausedAnnotationKey, which is owned by the
+// DispatchPolicies.
+const QueuePausedByAnnotationKey = "volcano.sh/dispatch-paused-by"
+
+// PausedMessage tells the users the dispatching of the queue is paused, and who paused it by the pause endpoint.
+func PausedMessage(queueName, user string) string {
+	if user == "" {
+		return fmt.Sprintf("The dispatching of queue %s is paused.", queueName)
+	}
+	return fmt.Sprintf("The dispatching of queue %s is paused by %s.", queueName, user)
+}
+
+// ResuspendOnClusterFailureAnnotationKey is the annotation on the Queue, the dispatched workloads of the queue with
+// `volcano.sh/resuspend-on-cluster-failure: "true"` are suspended again when a cluster they are scheduled to is not
+// ready, like unreachable, so they wait in the queue and are dispatched again after Karmada reschedules them.
+const ResuspendOnClusterFailureAnnotationKey = "volcano.sh/resuspend-on-cluster-failure"
+
+// ClusterNotReadyReason is the reason of the DispatchedCondition when the ResourceBinding is suspended again
+// because a cluster it's scheduled to is not ready.
+const ClusterNotReadyReason = "ClusterNotReady"
+
+// DispatchWindowAnnotationKey is the annotation on the Queue to declare its dispatch windows,
+// like `20:00-06:00` or `0 20 * * 1-5 10h`, multiple windows are separated by `;`.
+const DispatchWindowAnnotationKey = "volcano.sh/dispatch-window"
+
+// MaxInFlightAnnotationKey is the annotation on the Queue to limit how many workloads of it
+// can be dispatched but not running yet, it overrides the `inflight.maxInFlight` argument.
+// It limits the workloads of the PriorityClass in all the queues when it's on the PriorityClass.
+const MaxInFlightAnnotationKey = "volcano.sh/max-inflight-workloads"
+
+// PreemptableAnnotationKey is the annotation on the ResourceBinding, the PodGroup or the Queue, the workload with
+// `volcano.sh/preemptable: "false"`, or in the queue with it, will never be suspended again to reclaim its resources.
+const PreemptableAnnotationKey = "volcano.sh/preemptable"
+
+// PreemptQueuesAnnotationKey is the annotation on the Queue to declare the queues whose dispatched workloads can be
+// suspended again for the workloads of the queue by the reclaim action, like `best-effort,batch`, `*` means all the
+// queues. It overrides the `preemptionMatrix` of the configuration, the queues in neither of them can reclaim from
+// all the queues.
+const PreemptQueuesAnnotationKey = "volcano.sh/preempt-queues"
+
+// PriorityOverrideAnnotationKey is the annotation on the ResourceBinding to override the priority resolved from its
+// PriorityClass, like `1000000` for the emergency jobs. Only the users allowed to `override-priority` the
+// ResourceBindings can set it, which is checked by the webhook.
+const PriorityOverrideAnnotationKey = "volcano.sh/priority-override"
+
+// OverridePriorityVerb is the RBAC verb on the ResourceBindings required to set the PriorityOverrideAnnotationKey.
+const OverridePriorityVerb = "override-priority"
+
+// ParsePriorityOverride parses the PriorityOverrideAnnotationKey, it returns false when it's not set.
+func ParsePriorityOverride(annotations map[string]string) (int32, bool, error) {
+	value, found := annotations[PriorityOverrideAnnotationKey]
+	if !found {
+		return 0, false, nil
+	}
+	priority, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid annotation %s: `%s`, expect a 32-bit integer", PriorityOverrideAnnotationKey, value)
+	}
+	return int32(priority), true, nil
+}
+
+// DispatchDependsOnAnnotationKey is the annotation on the ResourceBinding to declare the workloads it depends on,
+// like `<namespace>/<workload>`, multiple workloads are separated by `,`. The ResourceBinding is dispatched only
+// after all the workloads it depends on are running or succeeded.
+const DispatchDependsOnAnnotationKey = "volcano.sh/dispatch-depends-on"
+
+// JobArrayAnnotationKey is the annotation on the ResourceBinding to put it into a job array of its namespace, its
+// value is the name of the array. The members of the array, like the many similar Jobs created at once, are released
+// in the order they are created, and at most JobArrayChunkSizeAnnotationKey of them are in flight at the same time.
+const JobArrayAnnotationKey = "volcano.sh/job-array"
+
+// JobArrayChunkSizeAnnotationKey is the annotation on the ResourceBinding in a job array to declare how many members
+// of the array can be dispatched but not completed at the same time, like `10`, the next members are released as
+// their predecessors complete. It overrides the `jobarray.chunkSize` argument.
+const JobArrayChunkSizeAnnotationKey = "volcano.sh/job-array-chunk-size"
+
+// ResuspendOnGrowthAnnotationKey is the annotation on the Queue, the dispatched workloads of the queue with
+// `volcano.sh/resuspend-on-growth: "true"` are suspended again when they grow, like scaled up, so the growth is
+// subject to the queue capacity again.
+const ResuspendOnGrowthAnnotationKey = "volcano.sh/resuspend-on-growth"
+
+// FirstStageAdmissionAnnotationKey is the annotation on the Queue, the staged workloads of the queue with
+// `volcano.sh/first-stage-admission: "true"`, like the Argo Workflow, are admitted once the queue can fit their
+// first stage instead of their largest one.
+const FirstStageAdmissionAnnotationKey = "volcano.sh/first-stage-admission"
+
+// NamespaceFairnessAnnotationKey is the annotation on the Queue to share it fairly between the namespaces, the
+// namespaces take turns to dispatch their workloads with `round-robin`, and the turns are in proportion to their
+// DispatchWeightAnnotationKey with `weighted`. The workloads are ordered strictly by the plugins without it.
+const NamespaceFairnessAnnotationKey = "volcano.sh/namespace-fairness"
+
+// DispatchOrderAnnotationKey is the annotation on the Queue to declare how its workloads are ordered, one of the
+// DispatchOrders, `priority` by default.
+const DispatchOrderAnnotationKey = "volcano.sh/dispatch-order"
+
+const (
+	// DispatchOrderPriority orders the workloads by the order plugins, like by their priorities.
+	DispatchOrderPriority = "priority"
+	// DispatchOrderFIFO orders the workloads by their creation time only, the order plugins are skipped.
+	DispatchOrderFIFO = "fifo"
+)
+
+// DispatchRateLimitAnnotationKey is the annotation on the Queue to limit how many of its workloads are dispatched
+// in a period, like `10/1m`, the workloads over the limit wait for the next rounds.
+const DispatchRateLimitAnnotationKey = "volcano.sh/dispatch-rate-limit"
+
+// MinResourcesSourceAnnotationKey is the annotation on the Queue to decide the min resources of the gangs of its
+// workloads with PodGroups, `podGroup` or `workload`, it overrides the `minResourcesSource` of the configuration.
+const MinResourcesSourceAnnotationKey = "volcano.sh/min-resources-source"
+
+// DispatchWeightAnnotationKey is the annotation on the Namespace to declare its weight in the queues which are
+// shared by the `weighted` NamespaceFairnessAnnotationKey, it's a positive integer and 1 by default.
+const DispatchWeightAnnotationKey = "volcano.sh/dispatch-weight"
+
+// AdmissionChecksAnnotationKey is the annotation on the Queue to declare the admission checks of its workloads,
+// multiple checks are separated by `,`. Each check is a condition type set on the ResourceBinding by an external
+// controller, like the budget approval, and the workload is dispatched only after all the conditions are True.
+const AdmissionChecksAnnotationKey = "volcano.sh/admission-checks"
+
+// RejectedAdmissionCheckReason is the reason of the False admission check condition set by the external controller
+// when it rejects the workload, the workload waits until the condition is set again.
+const RejectedAdmissionCheckReason = "Rejected"
+
+// ElasticClusterAnnotationKey is the annotation on the Queue to name its elastic cluster, the workloads of the queue
+// which no cluster has room for are released toward the elastic cluster speculatively instead of waiting, so the
+// cluster autoscaler of the elastic cluster can scale up for them.
+const ElasticClusterAnnotationKey = "volcano.sh/elastic-cluster"
+
+// MaxSpeculativeReleasesAnnotationKey is the annotation on the Queue to limit how many of its workloads can be
+// released toward the elastic cluster speculatively but not running yet, it's 1 by default.
+const MaxSpeculativeReleasesAnnotationKey = "volcano.sh/max-speculative-releases"
+
+// SpeculativeReleaseAnnotationKey is the annotation patched on the ResourceBinding released toward the elastic cluster
+// speculatively, its value is the cluster, so the cluster autoscalers can scale up for it.
+const SpeculativeReleaseAnnotationKey = "volcano.sh/speculative-release"
+
+// SpeculativeReleaseReason is the reason of the event when the ResourceBinding is released toward the elastic cluster
+// speculatively.
+const SpeculativeReleaseReason = "SpeculativeRelease"
+
+// ClusterCostAnnotationKey is the annotation on the member Cluster to declare its relative cost, like `1.5`, the cost
+// plugin prefers dispatching the workloads which land on the cheaper clusters.
+const ClusterCostAnnotationKey = "volcano.sh/cluster-cost"
+
+// WorkloadGrownReason is the reason of the DispatchedCondition when the ResourceBinding is suspended again
+// because its workload grows after it was dispatched.
+const WorkloadGrownReason = "WorkloadGrown"
+
+// RescheduledReason is the reason of the DispatchedCondition when the ResourceBinding is suspended again
+// because Karmada reschedules it to the different clusters after it was dispatched.
+const RescheduledReason = "Rescheduled"
+
+// UnadmittedReason is the reason of the DispatchedCondition when the ResourceBinding created unsuspended without
+// the admission of its queue is suspended, so it waits for the queue to admit it.
+const UnadmittedReason = "Unadmitted"
+
+// DeadlineAnnotationKey is the annotation on the ResourceBinding or the PodGroup to declare the deadline of the
+// workload in RFC3339 format, like `2024-12-31T08:00:00Z`.
+const DeadlineAnnotationKey = "volcano.sh/deadline"
+
+// DeadlineMissedReason is the reason of the event when the workload is dispatched after its deadline.
+const DeadlineMissedReason = "DeadlineMissed"
+
+// NotAppliedReason is the reason of the DispatchedCondition and the event when the workload of the dispatched
+// ResourceBinding isn't applied to the member clusters in time, it waits in the queue to be admitted again.
+const NotAppliedReason = "NotApplied"
+
+// DispatchCycleAnnotationKey is the annotation of the events recorded by the dispatcher, its value is the cycle of
+// the dispatching round which recorded them, so they can be correlated with the logs, the decisions and the audit
+// events of the round.
+const DispatchCycleAnnotationKey = "volcano.sh/dispatch-cycle"
+
+// LastDispatchDecisionAnnotationKey is the annotation patched on the ResourceBinding by the dispatcher, its value is
+// the DecisionAnnotation in JSON of the last decision, so the tools can show the dispatcher state without the debug API.
+const LastDispatchDecisionAnnotationKey = "volcano.sh/last-dispatch-decision"
+
+// DispatchCheckpointAnnotationKey is the annotation patched on the ResourceBinding by the dispatcher when the
+// checkpointing is enabled, its value is the DispatchCheckpoint in JSON, so the state owned by the dispatcher
+// survives the restarts.
+const DispatchCheckpointAnnotationKey = "volcano.sh/dispatch-checkpoint"
+
+// ReclaimedReason is the reason of the DispatchedCondition when the ResourceBinding is suspended again
+// to give back the resources borrowed by its queue.
+const ReclaimedReason = "Reclaimed"
+
+// PreemptedReason is the reason of the DispatchedCondition when the ResourceBinding is suspended again
+// to make room for a higher priority one of its queue.
+const PreemptedReason = "Preempted"
+
+// SuspendedTTLAnnotationKey is the annotation on the Queue to declare how long its ResourceBindings can wait
+// suspended, like `72h`, the ones waiting longer are marked Expired and never dispatched unless released by force.
+const SuspendedTTLAnnotationKey = "volcano.sh/suspended-ttl"
+
+// ExpiryPolicyAnnotationKey is the annotation on the Queue to declare what happens to the workloads whose
+// ResourceBindings are expired, one of the ExpiryPolicies, `Condition` by default.
+const ExpiryPolicyAnnotationKey = "volcano.sh/expiry-policy"
+
+const (
+	// ExpiryPolicyCondition tells the users by the condition and the event only.
+	ExpiryPolicyCondition = "Condition"
+	// ExpiryPolicyAnnotate annotates the workload with the ExpiredAnnotationKey too, for the external cleanup.
+	ExpiryPolicyAnnotate = "Annotate"
+	// ExpiryPolicyDelete deletes the workload.
+	ExpiryPolicyDelete = "Delete"
+)
+
+// ExpiredAnnotationKey is the annotation patched on the expired ResourceBinding by the dispatcher, and on its
+// workload by the `Annotate` policy, its value is the time when it's expired in RFC3339.
+const ExpiredAnnotationKey = "volcano.sh/dispatch-expired"
+
+// ExpiredReason is the reason of the DispatchedCondition and the event when the ResourceBinding is expired.
+const ExpiredReason = "Expired"
+
+// ParseExpiry parses the SuspendedTTLAnnotationKey and the ExpiryPolicyAnnotationKey of the Queue, the zero TTL
+// means the ResourceBindings of the queue never expire.
+func ParseExpiry(annotations map[string]string) (time.Duration, string, error) {
+	var ttl time.Duration
+	if value, found := annotations[SuspendedTTLAnnotationKey]; found {
+		var err error
+		if ttl, err = time.ParseDuration(value); err != nil || ttl <= 0 {
+			return 0, "", fmt.Errorf("invalid annotation %s: `%s`, expect a positive duration like `72h`", SuspendedTTLAnnotationKey, value)
+		}
+	}
+	policy, found := annotations[ExpiryPolicyAnnotationKey]
+	switch {
+	case !found:
+		policy = ExpiryPolicyCondition
+	case policy != ExpiryPolicyCondition && policy != ExpiryPolicyAnnotate && policy != ExpiryPolicyDelete:
+		return 0, "", fmt.Errorf("invalid annotation %s: `%s`, expect `%s`, `%s` or `%s`", ExpiryPolicyAnnotationKey, policy,
+			ExpiryPolicyCondition, ExpiryPolicyAnnotate, ExpiryPolicyDelete)
+	}
+	return ttl, policy, nil
+}
+
+// DispatchBlocker describes why a ResourceBindingInfo can't be dispatched now.
+type DispatchBlocker struct {
+	// Plugin is the name of the plugin which blocks the dispatching.
+	Plugin string
+	// Reason is a brief CamelCase reason, it will be set as the reason of the DispatchedCondition.
+	Reason string
+	// Message is a human-readable message indicating details about the blocker.
+	Message string
+	// Reclaimable is true when the blocker can be resolved by reclaiming the resources borrowed by the other queues.
+	Reclaimable bool
+}
+
+// DispatchableFn checks whether the ResourceBindingInfo can be dispatched now, it returns nil if it can.
+type DispatchableFn func(rbi *ResourceBindingInfo) *DispatchBlocker
+
+// ReclaimableFn selects the victims from the candidates in order, which should be suspended again to make room
+// for the reclaimer, it returns nil if the reclaimer can't be dispatched even if all the candidates are suspended.
+type ReclaimableFn func(reclaimer *ResourceBindingInfo, candidates []*ResourceBindingInfo) []*ResourceBindingInfo
+
+// The reasons of the Explanation besides the ones of the DispatchedCondition and the plugins.
+const (
+	// NotManagedReason means the ResourceBinding is not in the dispatcher cache.
+	NotManagedReason = "NotManaged"
+	// NotOwnedShardReason means the queue of the ResourceBinding is dispatched by another replica of the shard group.
+	NotOwnedShardReason = "NotOwnedShard"
+	// GangIncompleteReason means the PodGroup of the workload is not created yet.
+	GangIncompleteReason = "GangIncomplete"
+	// QueueNotFoundReason means the queue of the ResourceBinding doesn't exist.
+	QueueNotFoundReason = "QueueNotFound"
+	// LowerPriorityThanBacklogReason means the ResourceBinding is dispatchable, but the ones ahead of it use up the rate limit.
+	LowerPriorityThanBacklogReason = "LowerPriorityThanBacklog"
+	// DispatchableReason means the ResourceBinding will be dispatched in the next round.
+	DispatchableReason = "Dispatchable"
+)
+
+// Explanation describes why a ResourceBinding is still suspended, by replaying the dispatching for it.
+type Explanation struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Queue     string `json:"queue,omitempty"`
+	// DispatchStatus is the lifecycle state of the ResourceBinding in the dispatcher.
+	DispatchStatus string `json:"dispatchStatus,omitempty"`
+	// TransitionTimes[status] is the last time when the ResourceBinding transitioned to the status.
+	TransitionTimes map[string]time.Time `json:"transitionTimes,omitempty"`
+	// Dispatchable is true when the ResourceBinding is dispatched or will be dispatched in the next round.
+	Dispatchable bool `json:"dispatchable"`
+	// Plugin is the plugin which blocks the ResourceBinding.
+	Plugin  string `json:"plugin,omitempty"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	// Ahead is the number of the ResourceBindings which will be dispatched before it in the round.
+	Ahead int `json:"ahead"`
+	// Notes are the things worth knowing which don't block the dispatching, like the queue is closed.
+	Notes []string `json:"notes,omitempty"`
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ActionSuspend means the ResourceBinding is kept suspended by the dispatcher.
+	ActionSuspend = "Suspend"
+	// ActionUnsuspend means the ResourceBinding is dispatched to the member clusters.
+	ActionUnsuspend = "Unsuspend"
+	// ActionPreempt means the ResourceBinding is suspended again to give way to the higher priority ones.
+	ActionPreempt = "Preempt"
+	// ActionHold means the ResourceBinding is held manually, it will not be dispatched until the hold is removed.
+	ActionHold = "Hold"
+	// ActionUnhold means the manual hold of the ResourceBinding is removed.
+	ActionUnhold = "Unhold"
+	// ActionRelease means the ResourceBinding released by force manually is dispatched already, the one which is
+	// dispatched by the release is audited as Unsuspend.
+	ActionRelease = "Release"
+	// ActionPause means the dispatching of the queue, or all the queues when it's empty, is paused manually.
+	ActionPause = "Pause"
+	// ActionResume means the dispatching of the queue, or all the queues when it's empty, is resumed manually.
+	ActionResume = "Resume"
+
+	defaultBufferSize = 1024
+	// maxEventLineSize is the max size of an event read from the audit log.
+	maxEventLineSize = 1024 * 1024
+)
+
+// Event is a line of the audit log, it records a decision of the dispatcher with its inputs,
+// so the order of the dispatched workloads can be reconstructed later.
+type Event struct {
+	Time              time.Time `json:"time"`
+	ControlPlane      string    `json:"controlPlane,omitempty"`
+	Action            string    `json:"action"`
+	Namespace         string    `json:"namespace"`
+	Name              string    `json:"name"`
+	Queue             string    `json:"queue"`
+	Priority          int32     `json:"priority"`
+	PriorityClassName string    `json:"priorityClassName,omitempty"`
+	// PriorityOverridden is true when the Priority is overridden by the `volcano.sh/priority-override` annotation.
+	PriorityOverridden bool                `json:"priorityOverridden,omitempty"`
+	Request            corev1.ResourceList `json:"request,omitempty"`
+	// QueueAllocated is the resources of the dispatched ResourceBindings in the queue when the decision is made.
+	QueueAllocated  corev1.ResourceList `json:"queueAllocated,omitempty"`
+	QueueCapability corev1.ResourceList `json:"queueCapability,omitempty"`
+	// ClusterCost is the relative cost of the clusters the workload is expected to land on, by the cost plugin.
+	ClusterCost *float64 `json:"clusterCost,omitempty"`
+	Plugin      string   `json:"plugin,omitempty"`
+	Reason      string   `json:"reason"`
+	Message     string   `json:"message,omitempty"`
+	// User is who made the decision manually by the endpoints of the dispatcher.
+	User string `json:"user,omitempty"`
+	// Cycle is the dispatching round of the control plane which made the decision.
+	Cycle uint64 `json:"cycle,omitempty"`
+}
+
+// Sink delivers the audit events to somewhere.
+type Sink interface {
+	// Name is the name of the sink for logs.
+	Name() string
+	Write(event *Event) error
+	Close() error
+}
+
+// Logger delivers the audit events to the sinks asynchronously, so the dispatching will not be slowed down
+// by the sinks. The events are dropped when the buffer is full.
+type Logger struct {
+	sinks  []Sink
+	events chan *Event
+}
+
+// NewLogger creates the Logger, it returns nil when there is no sink, a nil Logger drops all the events.
+func NewLogger(sinks []Sink, bufferSize int) *Logger {
+	if len(sinks) == 0 {
+		return nil
+	}
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Logger{
+		sinks:  sinks,
+		events: make(chan *Event, bufferSize),
+	}
+}
+
+// Log adds the event to the buffer, it never blocks.
+func (l *Logger) Log(event *Event) {
+	if l == nil {
+		return
+	}
+	select {
+	case l.events <- event:
+	default:
+		klog.Warningf("The audit buffer is full, drop the %s event of ResourceBinding <%s/%s>.",
+			event.Action, event.Namespace, event.Name)
+	}
+}
+
+// Run writes the events to the sinks until the stopCh is closed, the sinks are closed after that.
+func (l *Logger) Run(stopCh <-chan struct{}) {
+	if l == nil {
+		return
+	}
+	defer func() {
+		for _, sink := range l.sinks {
+			if err := sink.Close(); err != nil {
+				klog.Errorf("Failed to close audit sink %s, err: %v", sink.Name(), err)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case event := <-l.events:
+			l.write(event)
+		case <-stopCh:
+			// Flush the buffered events before exiting.
+			for {
+				select {
+				case event := <-l.events:
+					l.write(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l *Logger) write(event *Event) {
+	for _, sink := range l.sinks {
+		if err := sink.Write(event); err != nil {
+			klog.Errorf("Failed to write the audit event of ResourceBinding <%s/%s> to sink %s, err: %v",
+				event.Namespace, event.Name, sink.Name(), err)
+		}
+	}
+}
+
+// ReadEvents reads the events written as JSON lines by the stdout and the file sinks, the blank lines are skipped.
+func ReadEvents(reader io.Reader) ([]*Event, error) {
+	var events []*Event
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		event := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			return nil, fmt.Errorf("failed to decode the audit event at line %d: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
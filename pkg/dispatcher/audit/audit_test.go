@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNewSinks(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		Name        string
+		specs       []string
+		expectNames []string
+		expectErr   bool
+	}{
+		{
+			Name:        "No sink",
+			specs:       []string{""},
+			expectNames: []string{},
+		},
+		{
+			Name:        "All kinds of sinks",
+			specs:       []string{"stdout", " file:" + filepath.Join(dir, "audit.log"), "webhook:http://127.0.0.1/audit"},
+			expectNames: []string{"stdout", "file:" + filepath.Join(dir, "audit.log"), "webhook:http://127.0.0.1/audit"},
+		},
+		{
+			Name:      "Unknown sink",
+			specs:     []string{"stdout", "syslog"},
+			expectErr: true,
+		},
+		{
+			Name:      "File sink without path",
+			specs:     []string{"file:"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		sinks, err := NewSinks(tc.specs, time.Second)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("Test case %s failed, got err: %v expect err: %v", tc.Name, err, tc.expectErr)
+			continue
+		}
+		if tc.expectErr {
+			continue
+		}
+		names := make([]string, 0, len(sinks))
+		for _, sink := range sinks {
+			names = append(names, sink.Name())
+		}
+		closeSinks(sinks)
+		if !reflect.DeepEqual(names, tc.expectNames) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, names, tc.expectNames)
+		}
+	}
+}
+
+func TestLogger(t *testing.T) {
+	received := make(chan Event, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := Event{}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	buffer := &bytes.Buffer{}
+	logger := NewLogger([]Sink{NewWriterSink("buffer", buffer), NewWebhookSink(server.URL, time.Second)}, 0)
+	events := []*Event{
+		{Time: time.Unix(0, 0).UTC(), Action: ActionSuspend, Namespace: "ns", Name: "rb1", Queue: "q", Reason: "QuotaExceeded"},
+		{Time: time.Unix(1, 0).UTC(), Action: ActionUnsuspend, Namespace: "ns", Name: "rb2", Queue: "q", Reason: "Dispatched",
+			Request: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+	}
+	for _, event := range events {
+		logger.Log(event)
+	}
+	// Stop the logger at once, the buffered events should still be written.
+	stopCh := make(chan struct{})
+	close(stopCh)
+	logger.Run(stopCh)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != len(events) {
+		t.Fatalf("Test case writer sink failed, got: %d lines expect: %d lines", len(lines), len(events))
+	}
+	for i, line := range lines {
+		event := Event{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Errorf("Test case writer sink failed, line %q is not a json: %v", line, err)
+			continue
+		}
+		if event.Name != events[i].Name || event.Action != events[i].Action {
+			t.Errorf("Test case writer sink failed, got: %v expect: %v", event, *events[i])
+		}
+	}
+	for i := range events {
+		event := <-received
+		if event.Name != events[i].Name || event.Action != events[i].Action {
+			t.Errorf("Test case webhook sink failed, got: %v expect: %v", event, *events[i])
+		}
+	}
+
+	// A nil logger drops the events.
+	var nilLogger *Logger
+	nilLogger.Log(events[0])
+	nilLogger.Run(stopCh)
+}
+
+func TestReadEvents(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		log         string
+		expectNames []string
+		expectErr   bool
+	}{
+		{
+			Name:        "JSON lines with blank lines",
+			log:         "{\"action\":\"Unsuspend\",\"namespace\":\"ns\",\"name\":\"a\",\"cycle\":1}\n\n{\"action\":\"Suspend\",\"namespace\":\"ns\",\"name\":\"b\"}\n",
+			expectNames: []string{"a", "b"},
+		},
+		{
+			Name:      "Invalid line",
+			log:       "{\"action\":\"Unsuspend\"}\nnot json\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		events, err := ReadEvents(strings.NewReader(tc.log))
+		if (err != nil) != tc.expectErr {
+			t.Errorf("Test case %s failed, got err: %v expect err: %v", tc.Name, err, tc.expectErr)
+			continue
+		}
+		var names []string
+		for _, event := range events {
+			names = append(names, event.Name)
+		}
+		if !reflect.DeepEqual(names, tc.expectNames) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, names, tc.expectNames)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fileSinkPrefix    = "file:"
+	webhookSinkPrefix = "webhook:"
+	stdoutSink        = "stdout"
+)
+
+// NewSinks creates the sinks by the specs, which are `stdout`, `file:<path>` or `webhook:<url>`.
+func NewSinks(specs []string, webhookTimeout time.Duration) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		var sink Sink
+		switch {
+		case spec == "":
+			continue
+		case spec == stdoutSink:
+			sink = NewWriterSink(stdoutSink, os.Stdout)
+		case strings.HasPrefix(spec, fileSinkPrefix):
+			fileSink, err := NewFileSink(strings.TrimPrefix(spec, fileSinkPrefix))
+			if err != nil {
+				closeSinks(sinks)
+				return nil, err
+			}
+			sink = fileSink
+		case strings.HasPrefix(spec, webhookSinkPrefix):
+			sink = NewWebhookSink(strings.TrimPrefix(spec, webhookSinkPrefix), webhookTimeout)
+		default:
+			closeSinks(sinks)
+			return nil, fmt.Errorf("unknown audit sink %q, expect stdout, file:<path> or webhook:<url>", spec)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+func closeSinks(sinks []Sink) {
+	for _, sink := range sinks {
+		_ = sink.Close()
+	}
+}
+
+// writerSink writes the events as JSON lines.
+type writerSink struct {
+	name    string
+	mutex   sync.Mutex
+	writer  io.Writer
+	encoder *json.Encoder
+}
+
+// NewWriterSink creates a Sink writing the events as JSON lines to the writer, the writer is closed
+// with the sink if it's an io.Closer other than the stdout.
+func NewWriterSink(name string, writer io.Writer) Sink {
+	return &writerSink{
+		name:    name,
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// NewFileSink creates a Sink appending the events as JSON lines to the file.
+func NewFileSink(path string) (Sink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the path of the audit file sink is empty")
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file %s: %v", path, err)
+	}
+	return NewWriterSink(fileSinkPrefix+path, file), nil
+}
+
+func (ws *writerSink) Name() string {
+	return ws.name
+}
+
+func (ws *writerSink) Write(event *Event) error {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	return ws.encoder.Encode(event)
+}
+
+func (ws *writerSink) Close() error {
+	if closer, ok := ws.writer.(io.Closer); ok && ws.writer != os.Stdout {
+		return closer.Close()
+	}
+	return nil
+}
+
+// webhookSink posts each event as a JSON object to the URL.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a Sink posting the events to the URL, a response without 2xx status is an error.
+func NewWebhookSink(url string, timeout time.Duration) Sink {
+	return &webhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (whs *webhookSink) Name() string {
+	return webhookSinkPrefix + whs.url
+}
+
+func (whs *webhookSink) Write(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, whs.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := whs.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (whs *webhookSink) Close() error {
+	whs.client.CloseIdleConnections()
+	return nil
+}
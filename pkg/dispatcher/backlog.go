@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+)
+
+// queueBacklogs collects the ResourceBindings waiting in each queue of the snapshot, so the autoscaling and the
+// alerting can react to the building backlogs. The queues without waiting ones are reported as empty.
+func queueBacklogs(ssn *dispatcherframework.Session, now time.Time) map[string]*metrics.QueueBacklog {
+	backlogs := make(map[string]*metrics.QueueBacklog, len(ssn.Snapshot.QueueInfos))
+	for queueName := range ssn.Snapshot.QueueInfos {
+		backlogs[queueName] = &metrics.QueueBacklog{Resources: corev1.ResourceList{}}
+	}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		// The expired ones don't wait in the queue anymore.
+		if rbi.DispatchStatus.IsDispatched() || rbi.IsExpired() {
+			continue
+		}
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		backlog, found := backlogs[queueName]
+		if !found {
+			backlog = &metrics.QueueBacklog{Resources: corev1.ResourceList{}}
+			backlogs[queueName] = backlog
+		}
+		backlog.Count++
+		enqueueTime := rbi.EnqueueTime
+		if enqueueTime.IsZero() {
+			enqueueTime = rbi.FirstSeenTime
+		}
+		if !enqueueTime.IsZero() && now.Sub(enqueueTime) > backlog.HeadOfLineAge {
+			backlog.HeadOfLineAge = now.Sub(enqueueTime)
+		}
+		for name, quantity := range rbi.MinResources {
+			value := backlog.Resources[name]
+			value.Add(quantity)
+			backlog.Resources[name] = value
+		}
+	}
+	return backlogs
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+)
+
+func TestQueueBacklogs(t *testing.T) {
+	now := time.Now()
+	cpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+	newRBI := func(name, queue string, status api.DispatchStatus, enqueueTime time.Time, resources corev1.ResourceList) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, UID: types.UID(name)}},
+			Queue:           queue,
+			DispatchStatus:  status,
+			EnqueueTime:     enqueueTime,
+			MinResources:    resources,
+		}
+	}
+
+	testCases := []struct {
+		Name   string
+		rbis   []*api.ResourceBindingInfo
+		expect map[string]*metrics.QueueBacklog
+	}{
+		{
+			Name: "Empty queues",
+			rbis: []*api.ResourceBindingInfo{newRBI("a", "q1", api.Dispatched, now.Add(-time.Hour), cpu("4"))},
+			expect: map[string]*metrics.QueueBacklog{
+				"q1": {Resources: corev1.ResourceList{}},
+				"q2": {Resources: corev1.ResourceList{}},
+			},
+		},
+		{
+			Name: "Waiting ResourceBindings",
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "q1", api.Pending, now.Add(-time.Minute), cpu("1")),
+				newRBI("b", "q1", api.Failed, now.Add(-time.Hour), cpu("2")),
+				newRBI("c", "q1", api.Dispatched, now.Add(-2*time.Hour), cpu("4")),
+				newRBI("d", "q2", api.Pending, time.Time{}, cpu("8")),
+			},
+			expect: map[string]*metrics.QueueBacklog{
+				"q1": {Count: 2, HeadOfLineAge: time.Hour, Resources: cpu("3")},
+				"q2": {Count: 1, Resources: cpu("8")},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		snapshot := &cache.DispatcherCacheSnapshot{
+			QueueInfos: map[string]*schedulingapi.QueueInfo{
+				"q1": {Name: "q1"},
+				"q2": {Name: "q2"},
+			},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+		}
+		for _, rbi := range tc.rbis {
+			snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+		}
+		ssn := dispatcherframework.OpenSession(&fakeCache{snapshot: snapshot}, nil)
+		got := queueBacklogs(ssn, now)
+		ssn.CloseSession()
+
+		for queueName, backlog := range got {
+			// Compare the quantities by the values, their formats are different after adding.
+			for name, quantity := range backlog.Resources {
+				backlog.Resources[name] = resource.MustParse(quantity.String())
+			}
+			if !reflect.DeepEqual(backlog, tc.expect[queueName]) {
+				t.Errorf("Test case %s failed, queue %s got: %+v expect: %+v", tc.Name, queueName, backlog, tc.expect[queueName])
+			}
+		}
+		if len(got) != len(tc.expect) {
+			t.Errorf("Test case %s failed, got %d queues expect: %d", tc.Name, len(got), len(tc.expect))
+		}
+	}
+}
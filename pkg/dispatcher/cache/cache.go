@@ -17,75 +17,213 @@ limitations under the License.
 package cache
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
 	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
 	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	informerclusterv1alpha1 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/cluster/v1alpha1"
+	informerpolicyv1alpha1 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/policy/v1alpha1"
 	informerworkv1aplha2 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	schedv1 "k8s.io/client-go/informers/scheduling/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	volcanoclientset "volcano.sh/apis/pkg/client/clientset/versioned"
 	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
-	volcanoinformerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
 	schedulinginformer "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/kube"
 	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 
+	dispatchv1alpha1 "volcano.sh/volcano-global/pkg/apis/dispatch/v1alpha1"
 	"volcano.sh/volcano-global/pkg/dispatcher/api"
-	"volcano.sh/volcano-global/pkg/dispatcher/cache/utils"
+	cacheutils "volcano.sh/volcano-global/pkg/dispatcher/cache/utils"
+	"volcano.sh/volcano-global/pkg/dispatcher/clustercapacity"
+	"volcano.sh/volcano-global/pkg/utils"
 )
 
 type DispatcherCacheOption struct {
-	WorkerNum         uint32
-	DefaultQueueName  string
-	KubeClientOptions kube.ClientOptions
+	WorkerNum uint32
+	// UnSuspendParallelism is the number of the ResourceBindings unsuspended concurrently.
+	UnSuspendParallelism uint32
+	DefaultQueueName     string
+	KubeClientOptions    kube.ClientOptions
+	// FaultInjection injects the faults into the cache for the resilience tests, it's nil when disabled.
+	FaultInjection *FaultInjection
+	// OverridePolicyAware evaluates the OverridePolicies applicable to the workloads on resolving their resources.
+	OverridePolicyAware bool
+	// PolicyQueueAware resolves the queues of the workloads by the labels of the PropagationPolicies and the
+	// ClusterPropagationPolicies claiming them.
+	PolicyQueueAware bool
+	// StripInformerObjects drops the fields never read by the dispatcher from the objects of the informers.
+	StripInformerObjects bool
+	// StatusWriteWindow is the window to coalesce the conditions and the annotations written back to a
+	// ResourceBinding, zero means writing them at once.
+	StatusWriteWindow time.Duration
+	// StatusWriterNum is the number of the workers writing the conditions and the annotations, at least WorkerNum.
+	StatusWriterNum uint32
+	// CapacityProvider provides the capacity of the member clusters, it's the status of the Clusters when it's nil.
+	CapacityProvider clustercapacity.CapacityProvider
 }
 
+// DispatcherCache protects each collection by its own lock, so the churn of the ResourceBindings doesn't block
+// the reads of the Queues and PriorityClasses. Don't acquire two locks at the same time, except that the
+// mutex of the statusWriter can be acquired with the resourceBindingMutex held.
 type DispatcherCache struct {
-	mutex     sync.Mutex
-	workerNum uint32
+	workerNum            uint32
+	unSuspendParallelism uint32
 
 	kubeClient    kubernetes.Interface
 	vcClient      volcanoclientset.Interface
 	karmadaClient karmadaclientset.Interface
+	// The dynamicClient and restMapper are used to get the workloads for resolving their min resources and opt-outs.
+	dynamicClient dynamic.Interface
+	restMapper    meta.RESTMapper
+	// suspendMode is the field used to suspend the ResourceBindings, it depends on the Karmada version.
+	suspendMode utils.SuspendMode
 
 	informerFactory        informers.SharedInformerFactory
 	volcanoInformerFactory volcanoinformer.SharedInformerFactory
 	karmadaInformerFactor  karmadainformerfactory.SharedInformerFactory
 
 	queueInformer schedulinginformer.QueueInformer
+	queueMutex    sync.RWMutex
 	queues        map[string]*schedulingapi.QueueInfo
 	defaultQueue  string
 
 	podGroupInformer schedulinginformer.PodGroupInformer
-	// podGroups[namespace][name] = target RodGroup.
-	podGroups map[string]map[string]*schedulingv1beta1.PodGroup
+	podGroupMutex    sync.RWMutex
+	// podGroups[namespace/name] = target PodGroup.
+	podGroups map[types.NamespacedName]*schedulingv1beta1.PodGroup
+	// podGroupsByOwner[owner UID] = the PodGroup of the workload, like Deployment, Pod and volcano-job.
+	podGroupsByOwner map[types.UID]*schedulingv1beta1.PodGroup
 
 	priorityClassInformer schedv1.PriorityClassInformer
+	priorityClassMutex    sync.RWMutex
 	priorityClasses       map[string]*schedulingv1.PriorityClass
 	defaultPriorityClass  *schedulingv1.PriorityClass
 
 	resourceBindingInformer informerworkv1aplha2.ResourceBindingInformer
-	// resourceBindings[namespace][name] = target ResourceBinding.
-	resourceBindings map[string]map[string]*workv1alpha2.ResourceBinding
+	// resourceBindingMutex protects both the resourceBindings and the resourceBindingInfos.
+	resourceBindingMutex sync.RWMutex
+	// resourceBindings[namespace/name] = target ResourceBinding.
+	resourceBindings map[types.NamespacedName]*workv1alpha2.ResourceBinding
 
 	// The infos only save basic information like RB, ResourceUID, Status in the cache, the PodGroup,
 	// Queue, and Priority will update when Snapshot.
-	// resourceBindingInfos[namespace][name] = target ResourceBindingInfo.
-	resourceBindingInfos map[string]map[string]*api.ResourceBindingInfo
+	// resourceBindingInfos[namespace/name] = target ResourceBindingInfo.
+	resourceBindingInfos map[types.NamespacedName]*api.ResourceBindingInfo
+
+	federatedResourceQuotaInformer informerpolicyv1alpha1.FederatedResourceQuotaInformer
+	federatedResourceQuotaMutex    sync.RWMutex
+	// federatedResourceQuotas[namespace/name] = target FederatedResourceQuota.
+	federatedResourceQuotas map[types.NamespacedName]*policyv1alpha1.FederatedResourceQuota
+
+	clusterInformer informerclusterv1alpha1.ClusterInformer
+	clusterMutex    sync.RWMutex
+	// clusters[name] = target member Cluster, their allocatable resources are the total resources of the federation.
+	clusters map[string]*clusterv1alpha1.Cluster
+
+	namespaceInformer coreinformers.NamespaceInformer
+	namespaceMutex    sync.RWMutex
+	// namespaces[name] = target Namespace, their annotations tune the dispatching of their workloads.
+	namespaces map[string]*corev1.Namespace
+
+	dispatchPolicyMutex sync.RWMutex
+	// dispatchPolicyInformer watches the DispatchPolicies, it's nil until their CRD is installed.
+	dispatchPolicyInformer cache.SharedIndexInformer
+	// dispatchPolicies[name] = target DispatchPolicy, they are reconciled into the annotations of the queues.
+	dispatchPolicies map[string]*dispatchv1alpha1.DispatchPolicy
+
+	// Its queue for processing the ResourceBinding events by the workers instead of the informer goroutine.
+	resourceBindingTaskQueue workqueue.RateLimitingInterface
 
 	// Its queue for unsuspend the ResourceBinding, when a ResourceBinding finish dispatch,
 	// The Dispatcher will add a task to here, and update the ResourceBinding.spec.Suspend = false.
 	unSuspendRBTaskQueue workqueue.Interface
+
+	// Its queue for suspending the dispatched ResourceBindings again, when their resources are reclaimed.
+	suspendRBTaskQueue workqueue.Interface
+
+	// Its queue for expiring the workloads of the expired ResourceBindings by their expiry policies,
+	// the failed tasks are retried by the rate limiter.
+	expireTaskQueue workqueue.RateLimitingInterface
+
+	// statusWriter writes the conditions and the annotations back to the ResourceBindings asynchronously, the
+	// updates of a ResourceBinding in a window are coalesced into one write.
+	statusWriter *statusWriter
+	// statusWriterNum is the number of the workers of the statusWriter.
+	statusWriterNum uint32
+	// capacityProvider provides the capacity of the clusters in the snapshots.
+	capacityProvider clustercapacity.CapacityProvider
+
+	// ignorePodGroupMinResources takes the min resources resolved from the workloads even if their PodGroups set them.
+	ignorePodGroupMinResources atomic.Bool
+	minResourcesSourceMutex    sync.RWMutex
+	// queueMinResourcesSources[queue] = the source of the min resources declared by the queue in the last snapshot,
+	// it overrides the one of the configuration when the workloads of the queue are admitted.
+	queueMinResourcesSources map[string]string
+
+	eventErrorMutex sync.Mutex
+	// eventErrors[kind] = the number of the events of the kind failed to be converted or processed since they were
+	// taken last time.
+	eventErrors map[string]int
+
+	// lastSnapshotSize is the cardinality of the last snapshot for pre-sizing the next one.
+	lastSnapshotSize snapshotSize
+
+	// wakeUp is signaled when the capability or the weight of a queue, or the taints or the readiness of a cluster
+	// is changed, its buffer coalesces the signals which are not received by the dispatcher yet.
+	wakeUp chan struct{}
+
+	// faults are injected into the cache for the resilience tests, it's nil when disabled.
+	faults *FaultInjection
+
+	// overrides list the OverridePolicies evaluated on resolving the resources, it's nil when disabled.
+	overrides *overrideListers
+
+	// policyQueues get the policies claiming the ResourceBindings for their queue labels, it's nil when disabled.
+	policyQueues *policyQueueListers
+
+	// startTime is the time when the cache starts to run, the ResourceBindings created after it are observed
+	// when they're created.
+	startTime time.Time
+
+	// stripObjects is true when the objects of the informers are stripped by stripObject, the ones listed
+	// from the apiserver for healing the cache are stripped too.
+	stripObjects bool
+}
+
+// DispatcherCacheClients are the clients the DispatcherCache lists, watches and patches the objects by, they're
+// injected by NewDispatcherCacheWithClients, like the fake ones built by the FakeDispatcherCacheBuilder.
+type DispatcherCacheClients struct {
+	KubeClient    kubernetes.Interface
+	VolcanoClient volcanoclientset.Interface
+	KarmadaClient karmadaclientset.Interface
+	DynamicClient dynamic.Interface
+	RESTMapper    meta.RESTMapper
+	// SuspendMode is the field used to suspend the ResourceBindings, it depends on the Karmada version.
+	SuspendMode utils.SuspendMode
 }
 
 func NewDispatcherCache(option *DispatcherCacheOption) DispatcherCacheInterface {
@@ -105,29 +243,68 @@ func NewDispatcherCache(option *DispatcherCacheOption) DispatcherCacheInterface
 	if err != nil {
 		panic(fmt.Sprintf("failed to init karmadaClient, with err: %v", err))
 	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		panic(fmt.Sprintf("failed to init dynamicClient, with err: %v", err))
+	}
 
 	// Create the default queue
-	utils.CreateDefaultQueue(volcanoClient, option.DefaultQueueName)
+	cacheutils.CreateDefaultQueue(volcanoClient, option.DefaultQueueName)
+	return NewDispatcherCacheWithClients(option, &DispatcherCacheClients{
+		KubeClient:    kubeClient,
+		VolcanoClient: volcanoClient,
+		KarmadaClient: karmadaClient,
+		DynamicClient: dynamicClient,
+		RESTMapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeClient.Discovery())),
+		SuspendMode:   utils.DetectSuspendMode(config),
+	})
+}
 
-	sc := &DispatcherCache{
-		kubeClient:    kubeClient,
-		workerNum:     option.WorkerNum,
-		vcClient:      volcanoClient,
-		karmadaClient: karmadaClient,
+// NewDispatcherCacheWithClients creates the DispatcherCache by the injected clients, the informers and the event
+// handlers are set up as NewDispatcherCache does, but the default queue is not created.
+func NewDispatcherCacheWithClients(option *DispatcherCacheOption, clients *DispatcherCacheClients) *DispatcherCache {
+	informerFactory, volcanoInformerFactory, karmadaInformerFactory := newInformerFactories(clients.KubeClient,
+		clients.VolcanoClient, clients.KarmadaClient, option.StripInformerObjects)
 
-		informerFactory:        informers.NewSharedInformerFactory(kubeClient, 0),
-		volcanoInformerFactory: volcanoinformerfactory.NewSharedInformerFactory(volcanoClient, 0),
-		karmadaInformerFactor:  karmadainformerfactory.NewSharedInformerFactory(karmadaClient, 0),
+	sc := &DispatcherCache{
+		kubeClient:           clients.KubeClient,
+		workerNum:            option.WorkerNum,
+		unSuspendParallelism: option.UnSuspendParallelism,
+		vcClient:             clients.VolcanoClient,
+		karmadaClient:        clients.KarmadaClient,
+		dynamicClient:        clients.DynamicClient,
+		restMapper:           clients.RESTMapper,
+		suspendMode:          clients.SuspendMode,
+
+		informerFactory:        informerFactory,
+		volcanoInformerFactory: volcanoInformerFactory,
+		karmadaInformerFactor:  karmadaInformerFactory,
+		stripObjects:           option.StripInformerObjects,
 
 		queues:           map[string]*schedulingapi.QueueInfo{},
 		defaultQueue:     option.DefaultQueueName,
-		podGroups:        map[string]map[string]*schedulingv1beta1.PodGroup{},
+		podGroups:        map[types.NamespacedName]*schedulingv1beta1.PodGroup{},
+		podGroupsByOwner: map[types.UID]*schedulingv1beta1.PodGroup{},
 		priorityClasses:  map[string]*schedulingv1.PriorityClass{},
-		resourceBindings: map[string]map[string]*workv1alpha2.ResourceBinding{},
-
-		resourceBindingInfos: map[string]map[string]*api.ResourceBindingInfo{},
-
-		unSuspendRBTaskQueue: workqueue.New(),
+		resourceBindings: map[types.NamespacedName]*workv1alpha2.ResourceBinding{},
+
+		resourceBindingInfos: map[types.NamespacedName]*api.ResourceBindingInfo{},
+
+		federatedResourceQuotas: map[types.NamespacedName]*policyv1alpha1.FederatedResourceQuota{},
+		clusters:                map[string]*clusterv1alpha1.Cluster{},
+		namespaces:              map[string]*corev1.Namespace{},
+
+		resourceBindingTaskQueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		unSuspendRBTaskQueue:     workqueue.New(),
+		suspendRBTaskQueue:       workqueue.New(),
+		expireTaskQueue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		statusWriter:             newStatusWriter(option.StatusWriteWindow),
+		statusWriterNum:          option.StatusWriterNum,
+		capacityProvider:         option.CapacityProvider,
+		dispatchPolicies:         map[string]*dispatchv1alpha1.DispatchPolicy{},
+		eventErrors:              map[string]int{},
+		wakeUp:                   make(chan struct{}, 1),
+		faults:                   option.FaultInjection,
 	}
 
 	sc.queueInformer = sc.volcanoInformerFactory.Scheduling().V1beta1().Queues()
@@ -158,10 +335,39 @@ func NewDispatcherCache(option *DispatcherCacheOption) DispatcherCacheInterface
 		DeleteFunc: sc.deleteResourceBinding,
 	})
 
+	sc.federatedResourceQuotaInformer = sc.karmadaInformerFactor.Policy().V1alpha1().FederatedResourceQuotas()
+	sc.federatedResourceQuotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    sc.addFederatedResourceQuota,
+		UpdateFunc: sc.updateFederatedResourceQuota,
+		DeleteFunc: sc.deleteFederatedResourceQuota,
+	})
+
+	sc.clusterInformer = sc.karmadaInformerFactor.Cluster().V1alpha1().Clusters()
+	sc.clusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    sc.addCluster,
+		UpdateFunc: sc.updateCluster,
+		DeleteFunc: sc.deleteCluster,
+	})
+
+	sc.namespaceInformer = sc.informerFactory.Core().V1().Namespaces()
+	sc.namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    sc.addNamespace,
+		UpdateFunc: sc.updateNamespace,
+		DeleteFunc: sc.deleteNamespace,
+	})
+
+	if option.OverridePolicyAware {
+		sc.watchOverridePolicies()
+	}
+	if option.PolicyQueueAware {
+		sc.watchPropagationPolicies()
+	}
+
 	return sc
 }
 
 func (dc *DispatcherCache) Run(stopCh <-chan struct{}) {
+	dc.startTime = time.Now()
 	// Start the factories, and wait for cache sync
 	dc.informerFactory.Start(stopCh)
 	dc.volcanoInformerFactory.Start(stopCh)
@@ -181,10 +387,73 @@ func (dc *DispatcherCache) Run(stopCh <-chan struct{}) {
 			klog.Errorf("Caches failed to sync: %v", informerType)
 		}
 	}
+	dc.watchDispatchPolicies(stopCh)
 
 	for i := uint32(1); i <= dc.workerNum; i++ {
+		go wait.Until(dc.resourceBindingTaskWorker, 0, stopCh)
+		go wait.Until(dc.suspendResourceBindingTaskWorker, 0, stopCh)
+		go wait.Until(dc.expireWorkloadTaskWorker, time.Second, stopCh)
+	}
+	go func() {
+		<-stopCh
+		dc.expireTaskQueue.ShutDown()
+	}()
+	for i := uint32(1); i <= max(dc.statusWriterNum, dc.workerNum); i++ {
+		go wait.Until(dc.statusWriterWorker, 0, stopCh)
+	}
+	// The unsuspending workers patch synchronously, one for each worker at least.
+	for i := uint32(1); i <= max(dc.unSuspendParallelism, dc.workerNum); i++ {
 		go wait.Until(dc.unSuspendResourceBindingTaskWorker, 0, stopCh)
 	}
 
+	// Wait the ResourceBindings listed by the informer to be processed, so the first dispatching can see all of them.
+	if err := wait.PollUntilContextCancel(wait.ContextForChannel(stopCh), 100*time.Millisecond, true, func(_ context.Context) (bool, error) {
+		return dc.resourceBindingTaskQueue.Len() == 0, nil
+	}); err != nil {
+		klog.Errorf("Failed to wait the ResourceBindings to be processed, err: %v", err)
+	}
+
 	klog.V(2).Infof("DispatcherCache completes initialization and start to run.")
 }
+
+func (dc *DispatcherCache) WakeUp() <-chan struct{} {
+	return dc.wakeUp
+}
+
+func (dc *DispatcherCache) WorkQueueDepths() map[string]int {
+	return map[string]int{
+		"resourceBinding": dc.resourceBindingTaskQueue.Len(),
+		"unSuspend":       dc.unSuspendRBTaskQueue.Len(),
+		"suspend":         dc.suspendRBTaskQueue.Len(),
+		"expire":          dc.expireTaskQueue.Len(),
+		"status":          dc.statusWriter.queue.Len(),
+	}
+}
+
+func (dc *DispatcherCache) SetDefaultQueue(queueName string) {
+	dc.queueMutex.Lock()
+	defer dc.queueMutex.Unlock()
+
+	if dc.defaultQueue != queueName {
+		klog.V(3).Infof("Update the default queue from <%s> to <%s>.", dc.defaultQueue, queueName)
+		dc.defaultQueue = queueName
+	}
+}
+
+func (dc *DispatcherCache) SetQueuePausedBy(queueName, user string) error {
+	// The null value of the merge patch removes the annotation.
+	var value interface{}
+	if user != "" {
+		value = user
+	}
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": map[string]interface{}{
+			api.QueuePausedByAnnotationKey: value,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = dc.vcClient.SchedulingV1beta1().Queues().Patch(context.TODO(), queueName, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,1223 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	informerworkv1aplha2 "github.com/karmada-io/karmada/pkg/generated/informers/externalversions/work/v1alpha2"
+	worklisterv1alpha2 "github.com/karmada-io/karmada/pkg/generated/listers/work/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	dispatchv1alpha1 "volcano.sh/volcano-global/pkg/apis/dispatch/v1alpha1"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+func newTestDispatcherCache() *DispatcherCache {
+	return &DispatcherCache{
+		resourceBindingTaskQueue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+
+		queues:                  map[string]*schedulingapi.QueueInfo{},
+		defaultQueue:            "default",
+		podGroups:               map[types.NamespacedName]*schedulingv1beta1.PodGroup{},
+		podGroupsByOwner:        map[types.UID]*schedulingv1beta1.PodGroup{},
+		priorityClasses:         map[string]*schedulingv1.PriorityClass{},
+		resourceBindings:        map[types.NamespacedName]*workv1alpha2.ResourceBinding{},
+		resourceBindingInfos:    map[types.NamespacedName]*api.ResourceBindingInfo{},
+		federatedResourceQuotas: map[types.NamespacedName]*policyv1alpha1.FederatedResourceQuota{},
+		clusters:                map[string]*clusterv1alpha1.Cluster{},
+		namespaces:              map[string]*corev1.Namespace{},
+		statusWriter:            newStatusWriter(0),
+		dispatchPolicies:        map[string]*dispatchv1alpha1.DispatchPolicy{},
+		eventErrors:             map[string]int{},
+	}
+}
+
+func newTestResourceBinding(namespace, name string) *workv1alpha2.ResourceBinding {
+	return &workv1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			UID:         types.UID(namespace + "/" + name),
+			Generation:  1,
+			Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "default"},
+		},
+		Spec: workv1alpha2.ResourceBindingSpec{
+			Resource: workv1alpha2.ObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Namespace:  namespace,
+				Name:       name,
+				UID:        types.UID(name),
+			},
+			Suspend: true,
+		},
+	}
+}
+
+func TestResourceBindingTaskWorker(t *testing.T) {
+	dc := newTestDispatcherCache()
+	dc.resourceBindingInformer = karmadainformerfactory.NewSharedInformerFactory(karmadafake.NewSimpleClientset(), 0).Work().V1alpha2().ResourceBindings()
+	indexer := dc.resourceBindingInformer.Informer().GetIndexer()
+	go dc.resourceBindingTaskWorker()
+	defer dc.resourceBindingTaskQueue.ShutDown()
+
+	key := types.NamespacedName{Namespace: "ns", Name: "rb"}
+	rb := newTestResourceBinding(key.Namespace, key.Name)
+	// Set the min resources first, so the worker reuses them instead of resolving by the workload.
+	dc.resourceBindingInfos[key] = &api.ResourceBindingInfo{
+		ResourceBinding: rb,
+		ResourceUID:     rb.Spec.Resource.UID,
+		MinResources:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		DispatchStatus:  api.Pending,
+	}
+
+	getStatus := func() (api.DispatchStatus, bool) {
+		dc.resourceBindingMutex.RLock()
+		defer dc.resourceBindingMutex.RUnlock()
+		rbi, found := dc.resourceBindingInfos[key]
+		if !found {
+			return 0, false
+		}
+		return rbi.DispatchStatus, true
+	}
+	waitFor := func(name string, expectFound bool, expectStatus api.DispatchStatus) {
+		if err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+			status, found := getStatus()
+			return found == expectFound && (!found || status == expectStatus), nil
+		}); err != nil {
+			status, found := getStatus()
+			t.Errorf("Test case %s failed, got: %v %v expect: %v %v", name, found, status, expectFound, expectStatus)
+		}
+	}
+
+	if err := indexer.Add(rb); err != nil {
+		t.Fatalf("Failed to add ResourceBinding to the indexer, err: %v", err)
+	}
+	dc.addResourceBinding(rb)
+	waitFor("Add suspended ResourceBinding", true, api.Pending)
+
+	unsuspended := rb.DeepCopy()
+	unsuspended.Spec.Suspend = false
+	if err := indexer.Update(unsuspended); err != nil {
+		t.Fatalf("Failed to update ResourceBinding in the indexer, err: %v", err)
+	}
+	dc.updateResourceBinding(rb, unsuspended)
+	waitFor("Unsuspend ResourceBinding", true, api.Dispatched)
+
+	if err := indexer.Delete(unsuspended); err != nil {
+		t.Fatalf("Failed to delete ResourceBinding from the indexer, err: %v", err)
+	}
+	dc.deleteResourceBinding(unsuspended)
+	waitFor("Delete ResourceBinding", false, 0)
+}
+
+// failingResourceBindingInformer fails the first gets of its lister.
+type failingResourceBindingInformer struct {
+	informerworkv1aplha2.ResourceBindingInformer
+	failures int
+}
+
+func (fi *failingResourceBindingInformer) Lister() worklisterv1alpha2.ResourceBindingLister {
+	return &failingResourceBindingLister{ResourceBindingLister: fi.ResourceBindingInformer.Lister(), informer: fi}
+}
+
+type failingResourceBindingLister struct {
+	worklisterv1alpha2.ResourceBindingLister
+	informer *failingResourceBindingInformer
+}
+
+func (fl *failingResourceBindingLister) ResourceBindings(namespace string) worklisterv1alpha2.ResourceBindingNamespaceLister {
+	return &failingResourceBindingNamespaceLister{ResourceBindingNamespaceLister: fl.ResourceBindingLister.ResourceBindings(namespace),
+		informer: fl.informer}
+}
+
+type failingResourceBindingNamespaceLister struct {
+	worklisterv1alpha2.ResourceBindingNamespaceLister
+	informer *failingResourceBindingInformer
+}
+
+func (fl *failingResourceBindingNamespaceLister) Get(name string) (*workv1alpha2.ResourceBinding, error) {
+	if fl.informer.failures > 0 {
+		fl.informer.failures--
+		return nil, fmt.Errorf("injected failure")
+	}
+	return fl.ResourceBindingNamespaceLister.Get(name)
+}
+
+func TestResourceBindingTaskWorkerRetry(t *testing.T) {
+	dc := newTestDispatcherCache()
+	dc.resourceBindingTaskQueue = workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Millisecond))
+	informer := &failingResourceBindingInformer{failures: 2,
+		ResourceBindingInformer: karmadainformerfactory.NewSharedInformerFactory(karmadafake.NewSimpleClientset(), 0).Work().V1alpha2().ResourceBindings()}
+	dc.resourceBindingInformer = informer
+
+	key := types.NamespacedName{Namespace: "ns", Name: "rb"}
+	rb := newTestResourceBinding(key.Namespace, key.Name)
+	dc.resourceBindingInfos[key] = &api.ResourceBindingInfo{
+		ResourceBinding: rb,
+		ResourceUID:     rb.Spec.Resource.UID,
+		MinResources:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		DispatchStatus:  api.Pending,
+	}
+	unsuspended := rb.DeepCopy()
+	unsuspended.Spec.Suspend = false
+	if err := informer.Informer().GetIndexer().Add(unsuspended); err != nil {
+		t.Fatalf("Failed to add ResourceBinding to the indexer, err: %v", err)
+	}
+	go dc.resourceBindingTaskWorker()
+	defer dc.resourceBindingTaskQueue.ShutDown()
+	dc.updateResourceBinding(rb, unsuspended)
+
+	if err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+		dc.resourceBindingMutex.RLock()
+		defer dc.resourceBindingMutex.RUnlock()
+		// The key is forgotten by the rate limiter after it's processed.
+		return dc.resourceBindingInfos[key].DispatchStatus == api.Dispatched && dc.resourceBindingTaskQueue.NumRequeues(key) == 0, nil
+	}); err != nil {
+		t.Errorf("Test case retry failed, the ResourceBinding is not updated after the failed gets")
+	}
+	if eventErrors := dc.TakeEventErrors(); eventErrors["ResourceBinding"] != 2 {
+		t.Errorf("Test case retry failed, got event errors: %v expect: 2", eventErrors)
+	}
+}
+
+func TestPatchUnSuspendResourceBinding(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		suspendMode   utils.SuspendMode
+		failures      int
+		expectPatches int
+		expectError   bool
+	}{
+		{Name: "Apply the suspend field", suspendMode: utils.SuspendModeSuspend, expectPatches: 1},
+		{Name: "Patch the suspension field", suspendMode: utils.SuspendModeSuspension, expectPatches: 1},
+		{Name: "Remove the workload held annotation", suspendMode: utils.SuspendModeWorkload, expectPatches: 1},
+		{Name: "Retry the transient errors", suspendMode: utils.SuspendModeSuspend, failures: 2, expectPatches: 3},
+		{Name: "Give up after the retries", suspendMode: utils.SuspendModeSuspend, failures: 10, expectPatches: 4, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		rb := newTestResourceBinding("ns", "rb")
+		if tc.suspendMode == utils.SuspendModeSuspension {
+			rb.Spec.Suspend = false
+			rb.Spec.Suspension = &policyv1alpha1.Suspension{Dispatching: ptr.To(true)}
+		}
+		if tc.suspendMode == utils.SuspendModeWorkload {
+			rb.Spec.Suspend = false
+			rb.Annotations[utils.WorkloadHeldAnnotationKey] = "true"
+		}
+		client := karmadafake.NewSimpleClientset(rb)
+		patches := 0
+		client.PrependReactor("patch", "resourcebindings", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			patches++
+			if patches <= tc.failures {
+				return true, nil, apierrors.NewTooManyRequests("busy", 0)
+			}
+			return false, nil, nil
+		})
+		dc := newTestDispatcherCache()
+		dc.karmadaClient = client
+		dc.suspendMode = tc.suspendMode
+
+		err := dc.patchUnSuspendResourceBinding(rb)
+		if (err != nil) != tc.expectError || patches != tc.expectPatches {
+			t.Errorf("Test case %s failed, got err: %v patches: %d expect error: %v patches: %d",
+				tc.Name, err, patches, tc.expectError, tc.expectPatches)
+			continue
+		}
+		if tc.expectError {
+			continue
+		}
+		patched, _ := client.WorkV1alpha2().ResourceBindings("ns").Get(context.TODO(), "rb", metav1.GetOptions{})
+		if utils.IsResourceBindingSuspended(patched) {
+			t.Errorf("Test case %s failed, got suspended ResourceBinding: %+v", tc.Name, patched.Spec)
+		}
+	}
+}
+
+func TestPatchWorkloadHold(t *testing.T) {
+	newDeployment := func(paused bool, annotations map[string]string) *unstructured.Unstructured {
+		deployment := &unstructured.Unstructured{}
+		deployment.SetAPIVersion("apps/v1")
+		deployment.SetKind("Deployment")
+		deployment.SetNamespace("ns")
+		deployment.SetName("rb")
+		deployment.SetAnnotations(annotations)
+		_ = unstructured.SetNestedField(deployment.Object, paused, "spec", "paused")
+		return deployment
+	}
+
+	testCases := []struct {
+		Name         string
+		workload     *unstructured.Unstructured
+		hold         bool
+		expectPaused bool
+		expectHeld   bool
+	}{
+		{
+			Name:         "Release the held workload",
+			workload:     newDeployment(true, map[string]string{utils.WorkloadHeldAnnotationKey: "true"}),
+			expectPaused: false,
+			expectHeld:   false,
+		},
+		{
+			Name:         "Hold the released workload again",
+			workload:     newDeployment(false, nil),
+			hold:         true,
+			expectPaused: true,
+			expectHeld:   true,
+		},
+		{
+			Name:         "Keep the workload paused by the user",
+			workload:     newDeployment(true, nil),
+			expectPaused: true,
+			expectHeld:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+		dc := newTestDispatcherCache()
+		dc.dynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), tc.workload)
+		dc.restMapper = restMapper
+
+		if err := dc.patchWorkloadHold(newTestResourceBinding("ns", "rb"), tc.hold); err != nil {
+			t.Errorf("Test case %s failed, got err: %v", tc.Name, err)
+			continue
+		}
+		workload := dc.getWorkload(newTestResourceBinding("ns", "rb"))
+		paused, _, _ := unstructured.NestedBool(workload.Object, "spec", "paused")
+		if paused != tc.expectPaused || utils.IsWorkloadHeld(workload) != tc.expectHeld {
+			t.Errorf("Test case %s failed, got paused: %v held: %v expect paused: %v held: %v",
+				tc.Name, paused, utils.IsWorkloadHeld(workload), tc.expectPaused, tc.expectHeld)
+		}
+	}
+}
+
+func TestAnnotateResourceBinding(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		existing      string
+		value         string
+		expectPatches int
+	}{
+		{Name: "Patch the new annotation", value: "a", expectPatches: 1},
+		{Name: "Patch the changed annotation", existing: "a", value: "b", expectPatches: 1},
+		{Name: "Skip the same annotation", existing: "a", value: "a", expectPatches: 0},
+	}
+
+	for _, tc := range testCases {
+		rb := newTestResourceBinding("ns", "rb")
+		if tc.existing != "" {
+			rb.Annotations["key"] = tc.existing
+		}
+		client := karmadafake.NewSimpleClientset(rb)
+		patches := 0
+		client.PrependReactor("patch", "resourcebindings", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			patches++
+			return false, nil, nil
+		})
+		dc := newTestDispatcherCache()
+		dc.karmadaClient = client
+		dc.resourceBindingInfos[types.NamespacedName{Namespace: "ns", Name: "rb"}] = &api.ResourceBindingInfo{ResourceBinding: rb}
+
+		dc.AnnotateResourceBinding(types.NamespacedName{Namespace: "ns", Name: "rb"}, "key", tc.value)
+		dc.statusWriter.queue.ShutDown()
+		dc.statusWriterWorker()
+
+		patched, _ := client.WorkV1alpha2().ResourceBindings("ns").Get(context.TODO(), "rb", metav1.GetOptions{})
+		if patches != tc.expectPatches || patched.Annotations["key"] != tc.value {
+			t.Errorf("Test case %s failed, got patches: %d annotation: %s expect patches: %d annotation: %s",
+				tc.Name, patches, patched.Annotations["key"], tc.expectPatches, tc.value)
+		}
+	}
+}
+
+func TestObservedDispatchStatus(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		suspended    bool
+		applied      bool
+		oldStatus    api.DispatchStatus
+		expectStatus api.DispatchStatus
+	}{
+		{
+			Name:         "New suspended ResourceBinding",
+			suspended:    true,
+			expectStatus: api.Pending,
+		},
+		{
+			Name:         "New unsuspended ResourceBinding",
+			expectStatus: api.Dispatched,
+		},
+		{
+			Name:         "Unsuspending patch is not observed yet",
+			suspended:    true,
+			oldStatus:    api.Dispatching,
+			expectStatus: api.Dispatching,
+		},
+		{
+			Name:         "Unsuspending patch is observed",
+			oldStatus:    api.Dispatching,
+			expectStatus: api.Dispatched,
+		},
+		{
+			Name:         "Suspended by the others",
+			suspended:    true,
+			oldStatus:    api.Dispatched,
+			expectStatus: api.Pending,
+		},
+		{
+			Name:         "Suspending patch is not observed yet",
+			oldStatus:    api.Preempted,
+			expectStatus: api.Preempted,
+		},
+		{
+			Name:         "Unsuspended but not applied workload",
+			oldStatus:    api.Failed,
+			expectStatus: api.Failed,
+		},
+		{
+			Name:         "Failed workload is applied",
+			applied:      true,
+			oldStatus:    api.Failed,
+			expectStatus: api.Dispatched,
+		},
+	}
+
+	for _, tc := range testCases {
+		var oldRbi *api.ResourceBindingInfo
+		if tc.oldStatus != 0 {
+			oldRbi = &api.ResourceBindingInfo{DispatchStatus: tc.oldStatus}
+		}
+		if status := observedDispatchStatus(tc.suspended, tc.applied, oldRbi); status != tc.expectStatus {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, status, tc.expectStatus)
+		}
+	}
+}
+
+func TestDefaultPriorityClass(t *testing.T) {
+	newPriorityClass := func(name string, value int32, globalDefault bool) *schedulingv1.PriorityClass {
+		return &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Value: value, GlobalDefault: globalDefault}
+	}
+
+	testCases := []struct {
+		Name          string
+		add           []*schedulingv1.PriorityClass
+		delete        []*schedulingv1.PriorityClass
+		expectDefault string
+	}{
+		{
+			Name:          "No GlobalDefault PriorityClass",
+			add:           []*schedulingv1.PriorityClass{newPriorityClass("high", 100, false)},
+			expectDefault: "",
+		},
+		{
+			Name: "Highest value wins",
+			add: []*schedulingv1.PriorityClass{
+				newPriorityClass("low", 10, true),
+				newPriorityClass("high", 100, true),
+				newPriorityClass("middle", 50, true),
+			},
+			expectDefault: "high",
+		},
+		{
+			Name: "Lexically smallest name breaks the tie",
+			add: []*schedulingv1.PriorityClass{
+				newPriorityClass("b", 100, true),
+				newPriorityClass("a", 100, true),
+				newPriorityClass("c", 100, true),
+			},
+			expectDefault: "a",
+		},
+		{
+			Name: "Delete the default falls back to the other GlobalDefault",
+			add: []*schedulingv1.PriorityClass{
+				newPriorityClass("low", 10, true),
+				newPriorityClass("high", 100, true),
+			},
+			delete:        []*schedulingv1.PriorityClass{newPriorityClass("high", 100, true)},
+			expectDefault: "low",
+		},
+		{
+			Name: "Delete the non-default keeps the default",
+			add: []*schedulingv1.PriorityClass{
+				newPriorityClass("low", 10, true),
+				newPriorityClass("high", 100, true),
+			},
+			delete:        []*schedulingv1.PriorityClass{newPriorityClass("low", 10, true)},
+			expectDefault: "high",
+		},
+		{
+			Name:          "Delete the only default",
+			add:           []*schedulingv1.PriorityClass{newPriorityClass("high", 100, true)},
+			delete:        []*schedulingv1.PriorityClass{newPriorityClass("high", 100, true)},
+			expectDefault: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		dc := newTestDispatcherCache()
+		for _, pc := range tc.add {
+			dc.addPriorityClass(pc)
+		}
+		for _, pc := range tc.delete {
+			dc.deletePriorityClass(pc)
+		}
+		got := ""
+		if dc.defaultPriorityClass != nil {
+			got = dc.defaultPriorityClass.Name
+		}
+		if got != tc.expectDefault {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, got, tc.expectDefault)
+		}
+	}
+
+	// The GlobalDefault is removed by an update, the other GlobalDefault takes over.
+	dc := newTestDispatcherCache()
+	dc.addPriorityClass(newPriorityClass("low", 10, true))
+	dc.addPriorityClass(newPriorityClass("high", 100, true))
+	dc.updatePriorityClass(newPriorityClass("high", 100, true), newPriorityClass("high", 100, false))
+	if dc.defaultPriorityClass == nil || dc.defaultPriorityClass.Name != "low" {
+		t.Errorf("Test case update GlobalDefault failed, got: %v expect: low", dc.defaultPriorityClass)
+	}
+}
+
+// BenchmarkCacheUnderResourceBindingChurn measures the queue and PriorityClass updates, while the ResourceBindings
+// are updated and the cache is snapshotted in the background, like a busy dispatcher.
+func BenchmarkCacheUnderResourceBindingChurn(b *testing.B) {
+	const namespaces, resourceBindingsPerNamespace = 10, 500
+
+	dc := newTestDispatcherCache()
+	var resourceBindings []*workv1alpha2.ResourceBinding
+	for i := 0; i < namespaces; i++ {
+		for j := 0; j < resourceBindingsPerNamespace; j++ {
+			rb := newTestResourceBinding(fmt.Sprintf("ns-%d", i), fmt.Sprintf("rb-%d", j))
+			resourceBindings = append(resourceBindings, rb)
+			// Set the min resources first, so the updates reuse them instead of resolving by the workload.
+			dc.resourceBindingInfos[types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}] = &api.ResourceBindingInfo{
+				ResourceBinding: rb,
+				ResourceUID:     rb.Spec.Resource.UID,
+				MinResources:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				DispatchStatus:  api.Pending,
+			}
+		}
+	}
+	queue := &schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	priorityClass := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Value: 100}
+
+	stopCh := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			rb := resourceBindings[i%len(resourceBindings)]
+			dc.setResourceBinding(rb)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(10 * time.Millisecond):
+				dc.Snapshot()
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			dc.updateQueue(queue, queue)
+			dc.updatePriorityClass(priorityClass, priorityClass)
+		}
+	})
+	b.StopTimer()
+
+	close(stopCh)
+	wg.Wait()
+}
+
+// newSnapshotTestCache builds the cache with the ResourceBindings of the namespaces, their min resources are set,
+// so they are not resolved by the workloads.
+func newSnapshotTestCache(namespaces, resourceBindingsPerNamespace int) *DispatcherCache {
+	dc := newTestDispatcherCache()
+	dc.queues["default"] = &schedulingapi.QueueInfo{Name: "default", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default"}}}
+	for i := 0; i < namespaces; i++ {
+		namespace := fmt.Sprintf("ns-%d", i)
+		for j := 0; j < resourceBindingsPerNamespace; j++ {
+			rb := newTestResourceBinding(namespace, fmt.Sprintf("rb-%d", j))
+			dc.resourceBindingInfos[types.NamespacedName{Namespace: namespace, Name: rb.Name}] = &api.ResourceBindingInfo{
+				ResourceBinding: rb,
+				ResourceUID:     rb.Spec.Resource.UID,
+				MinResources:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				DispatchStatus:  api.Pending,
+			}
+			dc.addPodGroup(&schedulingv1beta1.PodGroup{ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            rb.Name,
+				OwnerReferences: []metav1.OwnerReference{{UID: rb.Spec.Resource.UID}},
+			}})
+		}
+	}
+	return dc
+}
+
+func TestSnapshotRelease(t *testing.T) {
+	dc := newSnapshotTestCache(2, 3)
+	dc.Snapshot().Release()
+
+	delete(dc.resourceBindingInfos, types.NamespacedName{Namespace: "ns-1", Name: "rb-0"})
+	snapshot := dc.Snapshot()
+	if len(snapshot.ResourceBindingInfos) != 5 || len(snapshot.QueueInfos) != 1 || snapshot.DefaultQueue != "default" {
+		t.Errorf("Test case %s failed, got ResourceBindingInfos: %d QueueInfos: %d DefaultQueue: %s expect: 5 1 default",
+			"Reuse the released snapshot", len(snapshot.ResourceBindingInfos), len(snapshot.QueueInfos), snapshot.DefaultQueue)
+	}
+	if _, found := snapshot.ResourceBindingInfos[types.UID("ns-1/rb-0")]; found {
+		t.Errorf("Test case %s failed, got the deleted ResourceBinding in the reused snapshot", "Reuse the released snapshot")
+	}
+}
+
+func BenchmarkSnapshot(b *testing.B) {
+	dc := newSnapshotTestCache(10, 500)
+
+	b.Run("Released", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dc.Snapshot().Release()
+		}
+	})
+	b.Run("NotReleased", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dc.Snapshot()
+		}
+	})
+}
+
+func BenchmarkCacheHandlers(b *testing.B) {
+	const namespaces, objectsPerNamespace = 10, 500
+
+	dc := newSnapshotTestCache(namespaces, objectsPerNamespace)
+	var resourceBindings []*workv1alpha2.ResourceBinding
+	var podGroups []*schedulingv1beta1.PodGroup
+	for i := 0; i < namespaces; i++ {
+		for j := 0; j < objectsPerNamespace; j++ {
+			rb := newTestResourceBinding(fmt.Sprintf("ns-%d", i), fmt.Sprintf("rb-%d", j))
+			resourceBindings = append(resourceBindings, rb)
+			podGroups = append(podGroups, &schedulingv1beta1.PodGroup{ObjectMeta: metav1.ObjectMeta{
+				Namespace:       rb.Namespace,
+				Name:            rb.Name,
+				OwnerReferences: []metav1.OwnerReference{{UID: rb.Spec.Resource.UID}},
+			}})
+		}
+	}
+
+	b.Run("ResourceBinding", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, rb := range resourceBindings {
+				dc.setResourceBinding(rb)
+			}
+		}
+	})
+	b.Run("PodGroup", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, pg := range podGroups {
+				dc.addPodGroup(pg)
+			}
+			for _, pg := range podGroups {
+				dc.deletePodGroup(pg)
+			}
+		}
+	})
+}
+
+func TestPodGroupsByOwner(t *testing.T) {
+	newPodGroup := func(name string, owners ...string) *schedulingv1beta1.PodGroup {
+		pg := &schedulingv1beta1.PodGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}}
+		for _, owner := range owners {
+			pg.OwnerReferences = append(pg.OwnerReferences, metav1.OwnerReference{UID: types.UID(owner)})
+		}
+		return pg
+	}
+
+	dc := newTestDispatcherCache()
+	dc.addPodGroup(newPodGroup("pg-1", "job-1"))
+	dc.addPodGroup(newPodGroup("pg-2", "job-2"))
+	// The owner taken by the new PodGroup is kept when the old one is deleted.
+	dc.addPodGroup(newPodGroup("pg-3", "job-2"))
+	dc.deletePodGroup(newPodGroup("pg-2", "job-2"))
+	// The old owner is removed when the owner of the PodGroup changes.
+	dc.updatePodGroup(newPodGroup("pg-1", "job-1"), newPodGroup("pg-1", "job-3"))
+
+	got := map[string]string{}
+	for owner, pg := range dc.podGroupsByOwner {
+		got[string(owner)] = pg.Name
+	}
+	expect := map[string]string{"job-2": "pg-3", "job-3": "pg-1"}
+	if !reflect.DeepEqual(got, expect) || len(dc.podGroups) != 2 {
+		t.Errorf("Test case %s failed, got: %v %d PodGroups expect: %v 2 PodGroups", "Index the PodGroups by owner", got, len(dc.podGroups), expect)
+	}
+}
+
+func TestExpireWorkloadTaskWorker(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		err           error
+		failures      int
+		expectDeletes int
+	}{
+		{
+			Name:          "Expire the workload",
+			expectDeletes: 1,
+		},
+		{
+			Name:          "Retry the transient errors",
+			err:           apierrors.NewServiceUnavailable("unavailable"),
+			failures:      2,
+			expectDeletes: 3,
+		},
+		{
+			Name:          "Drop the task after the max retries",
+			err:           apierrors.NewTooManyRequests("throttled", 0),
+			failures:      maxExpireRetries + 2,
+			expectDeletes: maxExpireRetries + 1,
+		},
+		{
+			Name:          "Drop the task failed by the other errors",
+			err:           apierrors.NewForbidden(appsv1.Resource("deployments"), "rb", fmt.Errorf("forbidden")),
+			failures:      1,
+			expectDeletes: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+		dc := newTestDispatcherCache()
+		client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		dc.dynamicClient = client
+		dc.restMapper = restMapper
+		dc.expireTaskQueue = workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Millisecond))
+		dc.resourceBindingInfos[types.NamespacedName{Namespace: "ns", Name: "rb"}] = &api.ResourceBindingInfo{ResourceBinding: newTestResourceBinding("ns", "rb")}
+
+		var mu sync.Mutex
+		deletes := 0
+		client.PrependReactor("delete", "deployments", func(_ clienttesting.Action) (bool, runtime.Object, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			deletes++
+			if deletes <= tc.failures {
+				return true, nil, tc.err
+			}
+			return false, nil, nil
+		})
+
+		go dc.expireWorkloadTaskWorker()
+		dc.ExpireWorkload(types.NamespacedName{Namespace: "ns", Name: "rb"}, api.ExpiryPolicyDelete)
+		if err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return deletes >= tc.expectDeletes && dc.expireTaskQueue.Len() == 0, nil
+		}); err != nil {
+			t.Errorf("Test case %s failed, got err: %v", tc.Name, err)
+		}
+		// Let the worker finish the last task before the queue is shut down.
+		time.Sleep(50 * time.Millisecond)
+		dc.expireTaskQueue.ShutDown()
+
+		mu.Lock()
+		if deletes != tc.expectDeletes {
+			t.Errorf("Test case %s failed, got deletes: %d expect: %d", tc.Name, deletes, tc.expectDeletes)
+		}
+		mu.Unlock()
+	}
+}
+
+func TestLookupResourceBinding(t *testing.T) {
+	dc := newTestDispatcherCache()
+	dc.defaultQueue = "fallback"
+	for _, name := range []string{"annotated", "grouped", "unset"} {
+		rb := newTestResourceBinding("ns", name)
+		if name != "annotated" {
+			rb.Annotations = nil
+		}
+		dc.resourceBindingInfos[types.NamespacedName{Namespace: "ns", Name: name}] = &api.ResourceBindingInfo{
+			ResourceBinding: rb,
+			DispatchStatus:  api.Dispatched,
+		}
+	}
+	dc.podGroupsByOwner["grouped"] = &schedulingv1beta1.PodGroup{Spec: schedulingv1beta1.PodGroupSpec{Queue: "podgroup-queue"}}
+
+	testCases := []struct {
+		Name        string
+		name        string
+		expectQueue string
+		expectFound bool
+	}{
+		{Name: "Queue of the annotation", name: "annotated", expectQueue: "default", expectFound: true},
+		{Name: "Queue of the PodGroup", name: "grouped", expectQueue: "podgroup-queue", expectFound: true},
+		{Name: "Default queue", name: "unset", expectQueue: "fallback", expectFound: true},
+		{Name: "Not found", name: "not-found"},
+	}
+
+	for _, tc := range testCases {
+		queueName, status, found := dc.LookupResourceBinding(types.NamespacedName{Namespace: "ns", Name: tc.name})
+		if queueName != tc.expectQueue || found != tc.expectFound || (found && status != api.Dispatched) {
+			t.Errorf("Test case %s failed, got: %s %v %v expect: %s %v", tc.Name, queueName, status, found, tc.expectQueue, tc.expectFound)
+		}
+	}
+}
+
+func TestSuspendResourceBinding(t *testing.T) {
+	key := types.NamespacedName{Namespace: "ns", Name: "rb"}
+
+	testCases := []struct {
+		Name                  string
+		suspendMode           utils.SuspendMode
+		failedClusters        []string
+		expectPreemptions     int32
+		expectFailedPlacement []string
+	}{
+		{
+			Name:              "Suspended by the preemption",
+			suspendMode:       utils.SuspendModeSuspension,
+			expectPreemptions: 1,
+		},
+		{
+			Name:                  "Suspended on the cluster failure",
+			suspendMode:           utils.SuspendModeSuspension,
+			failedClusters:        []string{"member1"},
+			expectFailedPlacement: []string{"member1"},
+		},
+		{
+			Name:           "Suspended on the cluster failure without scheduling",
+			suspendMode:    utils.SuspendModeSuspend,
+			failedClusters: []string{"member1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		dc := newTestDispatcherCache()
+		dc.suspendRBTaskQueue = workqueue.New()
+		dc.suspendMode = tc.suspendMode
+		dc.resourceBindingInfos[key] = &api.ResourceBindingInfo{
+			ResourceBinding: newTestResourceBinding(key.Namespace, key.Name),
+			DispatchStatus:  api.Dispatched,
+		}
+		if tc.failedClusters == nil {
+			dc.SuspendResourceBinding(key)
+		} else {
+			dc.SuspendResourceBindingOnFailure(key, tc.failedClusters)
+		}
+
+		rbi := dc.resourceBindingInfos[key]
+		if rbi.DispatchStatus != api.Preempted || rbi.Preemptions != tc.expectPreemptions ||
+			!reflect.DeepEqual(rbi.FailedPlacement, tc.expectFailedPlacement) {
+			t.Errorf("Test case %s failed, got: %v %d %v expect: %v %d %v", tc.Name, rbi.DispatchStatus, rbi.Preemptions,
+				rbi.FailedPlacement, api.Preempted, tc.expectPreemptions, tc.expectFailedPlacement)
+		}
+		if dc.suspendRBTaskQueue.Len() != 1 {
+			t.Errorf("Test case %s failed, got suspend tasks: %d expect: 1", tc.Name, dc.suspendRBTaskQueue.Len())
+		}
+	}
+}
+
+func TestSetFirstStageAdmission(t *testing.T) {
+	cpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+	newQueue := func(name string, firstStage bool) *schedulingapi.QueueInfo {
+		queue := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if firstStage {
+			queue.Annotations = map[string]string{api.FirstStageAdmissionAnnotationKey: "true"}
+		}
+		return &schedulingapi.QueueInfo{Name: name, Queue: queue}
+	}
+	snapshot := &DispatcherCacheSnapshot{
+		DefaultQueue: "staged",
+		QueueInfos: map[string]*schedulingapi.QueueInfo{
+			"staged":  newQueue("staged", true),
+			"default": newQueue("default", false),
+		},
+	}
+
+	testCases := []struct {
+		Name                string
+		queue               string
+		firstStageResources corev1.ResourceList
+		expectMinResources  corev1.ResourceList
+	}{
+		{
+			Name:                "Staged workload in the opted in queue",
+			queue:               "staged",
+			firstStageResources: cpu("1"),
+			expectMinResources:  cpu("1"),
+		},
+		{
+			Name:                "Staged workload joins the opted in default queue",
+			firstStageResources: cpu("1"),
+			expectMinResources:  cpu("1"),
+		},
+		{
+			Name:                "Staged workload in the queue not opted in",
+			queue:               "default",
+			firstStageResources: cpu("1"),
+			expectMinResources:  cpu("4"),
+		},
+		{
+			Name:               "Not staged workload",
+			queue:              "staged",
+			expectMinResources: cpu("4"),
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{
+			Queue:               tc.queue,
+			MinResources:        cpu("4"),
+			FirstStageResources: tc.firstStageResources,
+		}
+		setFirstStageAdmission(rbi, snapshot)
+		if !reflect.DeepEqual(rbi.MinResources, tc.expectMinResources) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, rbi.MinResources, tc.expectMinResources)
+		}
+	}
+}
+
+func TestOptOutOnUpdate(t *testing.T) {
+	withLabels := func(rb *workv1alpha2.ResourceBinding, labels map[string]string) *workv1alpha2.ResourceBinding {
+		rb.Labels = labels
+		return rb
+	}
+	withGeneration := func(rb *workv1alpha2.ResourceBinding, generation int64) *workv1alpha2.ResourceBinding {
+		rb.Generation = generation
+		return rb
+	}
+	unSuspended := func(rb *workv1alpha2.ResourceBinding) *workv1alpha2.ResourceBinding {
+		rb.Spec.Suspend = false
+		return rb
+	}
+
+	testCases := []struct {
+		Name                string
+		oldRb               *workv1alpha2.ResourceBinding
+		newRb               *workv1alpha2.ResourceBinding
+		workloadAnnotations map[string]string
+		expectCached        bool
+		expectSuspended     bool
+	}{
+		{
+			Name:            "Suspended ResourceBinding opts out by the label",
+			oldRb:           newTestResourceBinding("ns", "rb"),
+			newRb:           withLabels(newTestResourceBinding("ns", "rb"), map[string]string{utils.IgnoreKey: "true"}),
+			expectSuspended: false,
+		},
+		{
+			Name:                "Suspended ResourceBinding whose workload opts out by the annotation",
+			oldRb:               newTestResourceBinding("ns", "rb"),
+			newRb:               withGeneration(newTestResourceBinding("ns", "rb"), 2),
+			workloadAnnotations: map[string]string{utils.IgnoreKey: "true"},
+			expectSuspended:     false,
+		},
+		{
+			Name:                "The opt-out of the workload is checked when the spec changes",
+			oldRb:               newTestResourceBinding("ns", "rb"),
+			newRb:               newTestResourceBinding("ns", "rb"),
+			workloadAnnotations: map[string]string{utils.IgnoreKey: "true"},
+			expectCached:        true,
+			expectSuspended:     true,
+		},
+		{
+			Name:            "Dispatched ResourceBinding opts out",
+			oldRb:           unSuspended(newTestResourceBinding("ns", "rb")),
+			newRb:           unSuspended(withLabels(newTestResourceBinding("ns", "rb"), map[string]string{utils.IgnoreKey: "true"})),
+			expectSuspended: false,
+		},
+		{
+			Name:            "The opt-out is removed after the ResourceBinding is released",
+			oldRb:           withLabels(newTestResourceBinding("ns", "rb"), map[string]string{utils.IgnoreKey: "true"}),
+			newRb:           withGeneration(newTestResourceBinding("ns", "rb"), 2),
+			expectCached:    true,
+			expectSuspended: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		deployment := &unstructured.Unstructured{}
+		deployment.SetAPIVersion("apps/v1")
+		deployment.SetKind("Deployment")
+		deployment.SetNamespace("ns")
+		deployment.SetName("rb")
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+
+		dc := newTestDispatcherCache()
+		dc.karmadaClient = karmadafake.NewSimpleClientset(tc.newRb)
+		dc.suspendMode = utils.SuspendModeSuspend
+		dc.dynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment)
+		dc.restMapper = restMapper
+		dc.setResourceBinding(tc.oldRb)
+
+		// The workload opts out after the ResourceBinding is cached.
+		deployment.SetAnnotations(tc.workloadAnnotations)
+		if _, err := dc.dynamicClient.Resource(appsv1.SchemeGroupVersion.WithResource("deployments")).Namespace("ns").
+			Update(context.TODO(), deployment, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("Test case %s failed, update the workload err: %v", tc.Name, err)
+		}
+		dc.setResourceBinding(tc.newRb)
+
+		_, cached := dc.resourceBindingInfos[types.NamespacedName{Namespace: "ns", Name: "rb"}]
+		rb, _ := dc.karmadaClient.WorkV1alpha2().ResourceBindings("ns").Get(context.TODO(), "rb", metav1.GetOptions{})
+		if cached != tc.expectCached || utils.IsResourceBindingSuspended(rb) != tc.expectSuspended {
+			t.Errorf("Test case %s failed, got cached: %v suspended: %v expect cached: %v suspended: %v",
+				tc.Name, cached, utils.IsResourceBindingSuspended(rb), tc.expectCached, tc.expectSuspended)
+		}
+	}
+}
+
+func TestIsUnadmitted(t *testing.T) {
+	startTime := time.Now()
+	newRbi := func(created time.Time, status api.DispatchStatus, unadmitted bool) *api.ResourceBindingInfo {
+		rb := newTestResourceBinding("ns", "rb")
+		rb.CreationTimestamp = metav1.NewTime(created)
+		return &api.ResourceBindingInfo{ResourceBinding: rb, DispatchStatus: status, Unadmitted: unadmitted}
+	}
+
+	testCases := []struct {
+		Name   string
+		rbi    *api.ResourceBindingInfo
+		oldRbi *api.ResourceBindingInfo
+		expect bool
+	}{
+		{
+			Name:   "Created unsuspended after the cache started",
+			rbi:    newRbi(startTime.Add(time.Minute), api.Dispatched, false),
+			expect: true,
+		},
+		{
+			Name:   "Listed unsuspended when the cache started",
+			rbi:    newRbi(startTime.Add(-time.Hour), api.Dispatched, false),
+			expect: false,
+		},
+		{
+			Name:   "Created suspended",
+			rbi:    newRbi(startTime.Add(time.Minute), api.Pending, false),
+			expect: false,
+		},
+		{
+			Name:   "Unadmitted one updated",
+			rbi:    newRbi(startTime.Add(time.Minute), api.Dispatched, false),
+			oldRbi: newRbi(startTime.Add(time.Minute), api.Dispatched, true),
+			expect: true,
+		},
+		{
+			Name:   "Admitted by the dispatcher",
+			rbi:    newRbi(startTime.Add(time.Minute), api.Dispatched, false),
+			oldRbi: newRbi(startTime.Add(time.Minute), api.Dispatching, false),
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		dc := newTestDispatcherCache()
+		dc.startTime = startTime
+		if got := dc.isUnadmitted(tc.rbi, tc.oldRbi); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expect)
+		}
+	}
+}
+
+func TestWorkloadRecreated(t *testing.T) {
+	recreated := func(rb *workv1alpha2.ResourceBinding) *workv1alpha2.ResourceBinding {
+		rb.Spec.Resource.UID = "recreated"
+		return rb
+	}
+
+	testCases := []struct {
+		Name           string
+		newRb          *workv1alpha2.ResourceBinding
+		expectReset    bool
+		expectStatus   api.DispatchStatus
+		expectFailures int32
+	}{
+		{
+			Name:           "Same workload keeps the dispatch state",
+			newRb:          newTestResourceBinding("ns", "rb"),
+			expectReset:    false,
+			expectStatus:   api.Failed,
+			expectFailures: 2,
+		},
+		{
+			Name:           "Recreated workload resets the dispatch state",
+			newRb:          recreated(newTestResourceBinding("ns", "rb")),
+			expectReset:    true,
+			expectStatus:   api.Pending,
+			expectFailures: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		key := types.NamespacedName{Namespace: "ns", Name: "rb"}
+		firstSeen := time.Now().Add(-time.Hour)
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+		dc := newTestDispatcherCache()
+		dc.dynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		dc.restMapper = restMapper
+		dc.setResourceBinding(newTestResourceBinding("ns", "rb"))
+		dc.resourceBindingInfos[key].FirstSeenTime = firstSeen
+		dc.resourceBindingInfos[key].DispatchStatus = api.Failed
+		dc.resourceBindingInfos[key].Failures = 2
+
+		dc.setResourceBinding(tc.newRb)
+		rbi := dc.resourceBindingInfos[key]
+		reset := !rbi.FirstSeenTime.Equal(firstSeen)
+		if reset != tc.expectReset || rbi.DispatchStatus != tc.expectStatus || rbi.ResourceUID != tc.newRb.Spec.Resource.UID ||
+			rbi.Failures != tc.expectFailures {
+			t.Errorf("Test case %s failed, got reset: %v status: %v uid: %s failures: %d expect reset: %v status: %v uid: %s failures: %d",
+				tc.Name, reset, rbi.DispatchStatus, rbi.ResourceUID, rbi.Failures, tc.expectReset, tc.expectStatus,
+				tc.newRb.Spec.Resource.UID, tc.expectFailures)
+		}
+	}
+}
+
+func TestWakeUpOnQueueUpdate(t *testing.T) {
+	newQueue := func(weight int32, capability string, description string) *schedulingv1beta1.Queue {
+		return &schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: map[string]string{"description": description}},
+			Spec: schedulingv1beta1.QueueSpec{
+				Weight:     weight,
+				Capability: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(capability)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name         string
+		newQueue     *schedulingv1beta1.Queue
+		expectWakeUp bool
+	}{
+		{Name: "Weight is changed", newQueue: newQueue(2, "10", ""), expectWakeUp: true},
+		{Name: "Capability is changed", newQueue: newQueue(1, "20", ""), expectWakeUp: true},
+		{Name: "Capability is the same in another format", newQueue: newQueue(1, "10000m", ""), expectWakeUp: false},
+		{Name: "Other fields are changed", newQueue: newQueue(1, "10", "updated"), expectWakeUp: false},
+	}
+
+	for _, tc := range testCases {
+		dc := newTestDispatcherCache()
+		dc.wakeUp = make(chan struct{}, 1)
+		dc.updateQueue(newQueue(1, "10", ""), tc.newQueue)
+
+		wokenUp := false
+		select {
+		case <-dc.WakeUp():
+			wokenUp = true
+		default:
+		}
+		if wokenUp != tc.expectWakeUp {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, wokenUp, tc.expectWakeUp)
+		}
+	}
+}
+
+func TestWakeUpOnClusterUpdate(t *testing.T) {
+	newCluster := func(ready metav1.ConditionStatus, effect corev1.TaintEffect, labels map[string]string) *clusterv1alpha1.Cluster {
+		cluster := &clusterv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "member1", Labels: labels},
+			Status: clusterv1alpha1.ClusterStatus{
+				Conditions:      []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: ready}},
+				ResourceSummary: &clusterv1alpha1.ResourceSummary{Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}},
+			},
+		}
+		if effect != "" {
+			cluster.Spec.Taints = []corev1.Taint{{Key: "cluster.karmada.io/unschedulable", Effect: effect}}
+		}
+		return cluster
+	}
+
+	testCases := []struct {
+		Name         string
+		newCluster   *clusterv1alpha1.Cluster
+		expectWakeUp bool
+		expectTotal  string
+	}{
+		{Name: "Cluster is cordoned", newCluster: newCluster(metav1.ConditionTrue, corev1.TaintEffectNoSchedule, nil), expectWakeUp: true, expectTotal: "0"},
+		{Name: "Cluster is drained", newCluster: newCluster(metav1.ConditionTrue, corev1.TaintEffectNoExecute, nil), expectWakeUp: true, expectTotal: "0"},
+		{Name: "Cluster is tainted PreferNoSchedule", newCluster: newCluster(metav1.ConditionTrue, corev1.TaintEffectPreferNoSchedule, nil), expectWakeUp: true, expectTotal: "10"},
+		{Name: "Cluster is not ready", newCluster: newCluster(metav1.ConditionFalse, "", nil), expectWakeUp: true, expectTotal: "0"},
+		{Name: "Other fields are changed", newCluster: newCluster(metav1.ConditionTrue, "", map[string]string{"zone": "a"}), expectWakeUp: false, expectTotal: "10"},
+	}
+
+	for _, tc := range testCases {
+		dc := newTestDispatcherCache()
+		dc.wakeUp = make(chan struct{}, 1)
+		dc.updateCluster(newCluster(metav1.ConditionTrue, "", nil), tc.newCluster)
+
+		wokenUp := false
+		select {
+		case <-dc.WakeUp():
+			wokenUp = true
+		default:
+		}
+		total := dc.Snapshot().TotalResources()[corev1.ResourceCPU]
+		if wokenUp != tc.expectWakeUp || total.Cmp(resource.MustParse(tc.expectTotal)) != 0 {
+			t.Errorf("Test case %s failed, got wake up: %v total: %s expect wake up: %v total: %s", tc.Name,
+				wokenUp, total.String(), tc.expectWakeUp, tc.expectTotal)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// CheckConsistency lists the ResourceBindings and the Queues from the apiserver and compares them with the cache,
+// to catch the missed events. The differences are fixed by resyncing the listed objects if heal is true.
+func (dc *DispatcherCache) CheckConsistency(ctx context.Context, heal bool) (*api.ConsistencyReport, error) {
+	// List from the watch cache of the apiserver to reduce the load, the stale objects are confirmed by getting them.
+	rbList, err := dc.karmadaClient.WorkV1alpha2().ResourceBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+	if err != nil {
+		return nil, err
+	}
+	queueList, err := dc.vcClient.SchedulingV1beta1().Queues().List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &api.ConsistencyReport{Time: time.Now()}
+	missingRBs, staleRBs := dc.diffResourceBindings(ctx, rbList.Items, &report.ResourceBindings)
+	missingQueues, staleQueues := dc.diffQueues(ctx, queueList.Items, &report.Queues)
+	if report.Consistent() {
+		return report, nil
+	}
+
+	klog.Warningf("DispatcherCache is inconsistent with the apiserver, ResourceBindings: %+v, Queues: %+v.",
+		report.ResourceBindings, report.Queues)
+	if heal {
+		for _, rb := range missingRBs {
+			if dc.stripObjects {
+				stripObject(rb)
+			}
+			dc.setResourceBinding(rb)
+		}
+		for _, key := range staleRBs {
+			dc.removeResourceBinding(key)
+		}
+		for _, queue := range missingQueues {
+			if dc.stripObjects {
+				stripObject(queue)
+			}
+			dc.addQueue(queue)
+		}
+		for _, name := range staleQueues {
+			dc.queueMutex.Lock()
+			delete(dc.queues, name)
+			dc.queueMutex.Unlock()
+		}
+		report.Healed = true
+		klog.V(2).Infof("DispatcherCache is resynced from the apiserver.")
+	}
+	return report, nil
+}
+
+// diffResourceBindings compares the listed ResourceBindings managed by the dispatcher with the cached ones, it returns
+// the listed ones which are missing or outdated in the cache, and the keys of the stale ones.
+func (dc *DispatcherCache) diffResourceBindings(ctx context.Context, listed []workv1alpha2.ResourceBinding,
+	diff *api.ConsistencyDiff) ([]*workv1alpha2.ResourceBinding, []types.NamespacedName) {
+	listedRBs := map[types.NamespacedName]*workv1alpha2.ResourceBinding{}
+	for i := range listed {
+		rb := &listed[i]
+		if isWorkload, err := utils.DefaultWorkloadRegistry.IsWorkload(rb.Spec.Resource); err != nil || !isWorkload || utils.IsDispatchDisabled(rb) {
+			continue
+		}
+		listedRBs[types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}] = rb
+	}
+
+	cachedGenerations := map[types.NamespacedName]int64{}
+	dc.resourceBindingMutex.RLock()
+	for key, rbi := range dc.resourceBindingInfos {
+		cachedGenerations[key] = rbi.ResourceBinding.Generation
+	}
+	dc.resourceBindingMutex.RUnlock()
+
+	var resyncRBs []*workv1alpha2.ResourceBinding
+	var staleRBs []types.NamespacedName
+	for key, rb := range listedRBs {
+		generation, found := cachedGenerations[key]
+		switch {
+		case !found:
+			diff.Missing = append(diff.Missing, key.String())
+			resyncRBs = append(resyncRBs, rb)
+		case generation < rb.Generation:
+			diff.Outdated = append(diff.Outdated, key.String())
+			resyncRBs = append(resyncRBs, rb)
+		}
+	}
+	for key := range cachedGenerations {
+		if _, found := listedRBs[key]; found {
+			continue
+		}
+		// The watch cache may be behind the informer, confirm it's deleted.
+		if _, err := dc.karmadaClient.WorkV1alpha2().ResourceBindings(key.Namespace).Get(ctx, key.Name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+			diff.Stale = append(diff.Stale, key.String())
+			staleRBs = append(staleRBs, key)
+		}
+	}
+	sortDiff(diff)
+	return resyncRBs, staleRBs
+}
+
+// diffQueues compares the listed Queues with the cached ones, it returns the listed ones which are missing
+// or outdated in the cache, and the names of the stale ones.
+func (dc *DispatcherCache) diffQueues(ctx context.Context, listed []schedulingv1beta1.Queue,
+	diff *api.ConsistencyDiff) ([]*schedulingv1beta1.Queue, []string) {
+	cachedGenerations := map[string]int64{}
+	dc.queueMutex.RLock()
+	for name, queue := range dc.queues {
+		if queue.Queue != nil {
+			cachedGenerations[name] = queue.Queue.Generation
+		}
+	}
+	dc.queueMutex.RUnlock()
+
+	listedQueues := map[string]bool{}
+	var resyncQueues []*schedulingv1beta1.Queue
+	for i := range listed {
+		queue := &listed[i]
+		listedQueues[queue.Name] = true
+		generation, found := cachedGenerations[queue.Name]
+		switch {
+		case !found:
+			diff.Missing = append(diff.Missing, queue.Name)
+			resyncQueues = append(resyncQueues, queue)
+		case generation < queue.Generation:
+			diff.Outdated = append(diff.Outdated, queue.Name)
+			resyncQueues = append(resyncQueues, queue)
+		}
+	}
+
+	var staleQueues []string
+	for name := range cachedGenerations {
+		if listedQueues[name] {
+			continue
+		}
+		if _, err := dc.vcClient.SchedulingV1beta1().Queues().Get(ctx, name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+			diff.Stale = append(diff.Stale, name)
+			staleQueues = append(staleQueues, name)
+		}
+	}
+	sortDiff(diff)
+	return resyncQueues, staleQueues
+}
+
+func sortDiff(diff *api.ConsistencyDiff) {
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Stale)
+	sort.Strings(diff.Outdated)
+}
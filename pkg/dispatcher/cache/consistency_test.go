@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestCheckConsistency(t *testing.T) {
+	newQueue := func(name string, generation int64) *schedulingv1beta1.Queue {
+		return &schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: name, Generation: generation}}
+	}
+	withGeneration := func(rb *workv1alpha2.ResourceBinding, generation int64) *workv1alpha2.ResourceBinding {
+		rb.Generation = generation
+		return rb
+	}
+
+	dc := newTestDispatcherCache()
+	// The workloads are not found by the empty RESTMapper, their min resources are resolved by the ReplicaRequirements.
+	dc.restMapper = meta.NewDefaultRESTMapper(nil)
+	dc.karmadaClient = karmadafake.NewSimpleClientset(
+		newTestResourceBinding("ns", "consistent"),
+		newTestResourceBinding("ns", "missing"),
+		withGeneration(newTestResourceBinding("ns", "outdated"), 2),
+	)
+	dc.vcClient = volcanofake.NewSimpleClientset(newQueue("default", 1), newQueue("missing", 1))
+	for _, rb := range []*workv1alpha2.ResourceBinding{
+		newTestResourceBinding("ns", "consistent"),
+		newTestResourceBinding("ns", "outdated"),
+		newTestResourceBinding("ns", "stale"),
+	} {
+		dc.setResourceBinding(rb)
+	}
+	for _, queue := range []*schedulingv1beta1.Queue{newQueue("default", 1), newQueue("stale", 1)} {
+		dc.queues[queue.Name] = schedulingapi.NewQueueInfo(&scheduling.Queue{ObjectMeta: queue.ObjectMeta})
+	}
+
+	report, err := dc.CheckConsistency(context.TODO(), false)
+	if err != nil {
+		t.Fatalf("Failed to check the consistency, err: %v", err)
+	}
+	expectRBs := api.ConsistencyDiff{Missing: []string{"ns/missing"}, Stale: []string{"ns/stale"}, Outdated: []string{"ns/outdated"}}
+	expectQueues := api.ConsistencyDiff{Missing: []string{"missing"}, Stale: []string{"stale"}}
+	if !reflect.DeepEqual(report.ResourceBindings, expectRBs) || !reflect.DeepEqual(report.Queues, expectQueues) || report.Healed {
+		t.Errorf("Test case inconsistent cache failed, got: %+v expect: %+v %+v", report, expectRBs, expectQueues)
+	}
+
+	if report, err = dc.CheckConsistency(context.TODO(), true); err != nil || !report.Healed {
+		t.Fatalf("Test case heal failed, got: %+v err: %v", report, err)
+	}
+	if report, err = dc.CheckConsistency(context.TODO(), false); err != nil || !report.Consistent() {
+		t.Errorf("Test case healed cache failed, got: %+v err: %v", report, err)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	dispatchv1alpha1 "volcano.sh/volcano-global/pkg/apis/dispatch/v1alpha1"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// dispatchPolicyDiscoveryPeriod is the period of discovering the CRD of the DispatchPolicies before it's installed.
+const dispatchPolicyDiscoveryPeriod = time.Minute
+
+// watchDispatchPolicies watches the DispatchPolicies by the dynamic informer once their CRD is installed, the
+// dispatcher works without them before. The policies are synced before it returns if the CRD is installed already,
+// otherwise the CRD is discovered periodically, so the policies take effect without restarting the dispatcher.
+func (dc *DispatcherCache) watchDispatchPolicies(stopCh <-chan struct{}) {
+	if dc.startDispatchPolicyInformer(stopCh) {
+		return
+	}
+	go func() {
+		err := wait.PollUntilContextCancel(wait.ContextForChannel(stopCh), dispatchPolicyDiscoveryPeriod, false, func(_ context.Context) (bool, error) {
+			return dc.startDispatchPolicyInformer(stopCh), nil
+		})
+		if err != nil {
+			klog.V(4).Infof("Stop discovering the CRD of the DispatchPolicies, err: %v", err)
+		}
+	}()
+}
+
+// startDispatchPolicyInformer starts the informer of the DispatchPolicies and waits for it to sync,
+// it returns false when their CRD is not installed.
+func (dc *DispatcherCache) startDispatchPolicyInformer(stopCh <-chan struct{}) bool {
+	if _, err := dc.kubeClient.Discovery().ServerResourcesForGroupVersion(dispatchv1alpha1.SchemeGroupVersion.String()); err != nil {
+		klog.V(4).Infof("DispatchPolicy is not watched, the CRD may not be installed, err: %v", err)
+		return false
+	}
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(dc.dynamicClient, 0)
+	informer := factory.ForResource(dispatchv1alpha1.DispatchPolicyResource).Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    dc.setDispatchPolicy,
+		UpdateFunc: func(_, newObj interface{}) { dc.setDispatchPolicy(newObj) },
+		DeleteFunc: dc.deleteDispatchPolicy,
+	})
+	factory.Start(stopCh)
+	for resource, ok := range factory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("Caches failed to sync: %v", resource)
+		}
+	}
+
+	dc.dispatchPolicyMutex.Lock()
+	dc.dispatchPolicyInformer = informer
+	dc.dispatchPolicyMutex.Unlock()
+	klog.V(2).Infof("Start watching the DispatchPolicies.")
+	return true
+}
+
+func convertToDispatchPolicy(obj interface{}) *dispatchv1alpha1.DispatchPolicy {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		klog.Errorf("Failed to convert object to *unstructured.Unstructured, obj: %v", obj)
+		return nil
+	}
+	policy := &dispatchv1alpha1.DispatchPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, policy); err != nil {
+		klog.Errorf("Failed to convert DispatchPolicy <%s>, err: %v", u.GetName(), err)
+		return nil
+	}
+	return policy
+}
+
+func (dc *DispatcherCache) setDispatchPolicy(obj interface{}) {
+	policy := convertToDispatchPolicy(obj)
+	if policy == nil {
+		dc.recordEventError("DispatchPolicy")
+		return
+	}
+	dc.dispatchPolicyMutex.Lock()
+	defer dc.dispatchPolicyMutex.Unlock()
+
+	dc.dispatchPolicies[policy.Name] = policy
+}
+
+func (dc *DispatcherCache) deleteDispatchPolicy(obj interface{}) {
+	policy := convertToDispatchPolicy(obj)
+	if policy == nil {
+		dc.recordEventError("DispatchPolicy")
+		return
+	}
+	dc.dispatchPolicyMutex.Lock()
+	defer dc.dispatchPolicyMutex.Unlock()
+
+	delete(dc.dispatchPolicies, policy.Name)
+}
+
+// applyDispatchPolicies reconciles the DispatchPolicies into the annotations of the queues in the snapshot. The
+// policies naming the queues take precedence over the ones applying to all the queues, and the annotations set on
+// the queues by the users take precedence over the policies. The queues are copied before they're changed.
+func applyDispatchPolicies(queues map[string]*schedulingapi.QueueInfo, policies map[string]*dispatchv1alpha1.DispatchPolicy) {
+	if len(policies) == 0 {
+		return
+	}
+	ordered := make([]*dispatchv1alpha1.DispatchPolicy, 0, len(policies))
+	for _, policy := range policies {
+		ordered = append(ordered, policy)
+	}
+	// The later ones override the earlier ones.
+	sort.Slice(ordered, func(i, j int) bool {
+		if (len(ordered[i].Spec.Queues) == 0) != (len(ordered[j].Spec.Queues) == 0) {
+			return len(ordered[i].Spec.Queues) == 0
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	for name, queue := range queues {
+		if queue.Queue == nil {
+			continue
+		}
+		annotations := map[string]string{}
+		for _, policy := range ordered {
+			if policy.Spec.Selects(name) {
+				setPolicyAnnotations(annotations, &policy.Spec)
+			}
+		}
+		if len(annotations) == 0 {
+			continue
+		}
+
+		queue.Queue = queue.Queue.DeepCopy()
+		if queue.Queue.Annotations == nil {
+			queue.Queue.Annotations = map[string]string{}
+		}
+		for key, value := range annotations {
+			if _, found := queue.Queue.Annotations[key]; !found {
+				queue.Queue.Annotations[key] = value
+			}
+		}
+	}
+}
+
+// setPolicyAnnotations sets the annotations of the queue by the settings of the policy.
+func setPolicyAnnotations(annotations map[string]string, spec *dispatchv1alpha1.DispatchPolicySpec) {
+	if spec.Paused != nil {
+		annotations[api.QueuePausedAnnotationKey] = strconv.FormatBool(*spec.Paused)
+	}
+	if spec.DispatchWindow != "" {
+		annotations[api.DispatchWindowAnnotationKey] = spec.DispatchWindow
+	}
+	if spec.MaxInFlightWorkloads != nil {
+		annotations[api.MaxInFlightAnnotationKey] = strconv.Itoa(int(*spec.MaxInFlightWorkloads))
+	}
+	if spec.NamespaceFairness != "" {
+		annotations[api.NamespaceFairnessAnnotationKey] = spec.NamespaceFairness
+	}
+	if spec.DispatchOrder != "" {
+		annotations[api.DispatchOrderAnnotationKey] = spec.DispatchOrder
+	}
+	if spec.DispatchRateLimit != "" {
+		annotations[api.DispatchRateLimitAnnotationKey] = spec.DispatchRateLimit
+	}
+	if spec.Preemptable != nil {
+		annotations[api.PreemptableAnnotationKey] = strconv.FormatBool(*spec.Preemptable)
+	}
+	if len(spec.PreemptQueues) != 0 {
+		annotations[api.PreemptQueuesAnnotationKey] = strings.Join(spec.PreemptQueues, ",")
+	}
+	if spec.ResuspendOnGrowth != nil {
+		annotations[api.ResuspendOnGrowthAnnotationKey] = strconv.FormatBool(*spec.ResuspendOnGrowth)
+	}
+	if spec.MinResourcesSource != "" {
+		annotations[api.MinResourcesSourceAnnotationKey] = spec.MinResourcesSource
+	}
+	if spec.FirstStageAdmission != nil {
+		annotations[api.FirstStageAdmissionAnnotationKey] = strconv.FormatBool(*spec.FirstStageAdmission)
+	}
+	if len(spec.AdmissionChecks) != 0 {
+		annotations[api.AdmissionChecksAnnotationKey] = strings.Join(spec.AdmissionChecks, ",")
+	}
+	if spec.ElasticCluster != "" {
+		annotations[api.ElasticClusterAnnotationKey] = spec.ElasticCluster
+	}
+	if spec.MaxSpeculativeReleases != nil {
+		annotations[api.MaxSpeculativeReleasesAnnotationKey] = strconv.Itoa(int(*spec.MaxSpeculativeReleases))
+	}
+	if spec.SuspendedTTL != "" {
+		annotations[api.SuspendedTTLAnnotationKey] = spec.SuspendedTTL
+	}
+	if spec.ExpiryPolicy != "" {
+		annotations[api.ExpiryPolicyAnnotationKey] = spec.ExpiryPolicy
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	dispatchv1alpha1 "volcano.sh/volcano-global/pkg/apis/dispatch/v1alpha1"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestApplyDispatchPolicies(t *testing.T) {
+	newPolicy := func(name string, spec dispatchv1alpha1.DispatchPolicySpec) *dispatchv1alpha1.DispatchPolicy {
+		return &dispatchv1alpha1.DispatchPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+	policies := map[string]*dispatchv1alpha1.DispatchPolicy{
+		"all": newPolicy("all", dispatchv1alpha1.DispatchPolicySpec{
+			MaxInFlightWorkloads: ptr.To[int32](10),
+			Preemptable:          ptr.To(true),
+		}),
+		"batch": newPolicy("batch", dispatchv1alpha1.DispatchPolicySpec{
+			Queues:             []string{"batch"},
+			Preemptable:        ptr.To(false),
+			DispatchWindow:     "20:00-06:00",
+			NamespaceFairness:  "round-robin",
+			DispatchOrder:      "fifo",
+			DispatchRateLimit:  "10/1m",
+			MinResourcesSource: "workload",
+		}),
+	}
+
+	testCases := []struct {
+		Name              string
+		queue             string
+		annotations       map[string]string
+		expectAnnotations map[string]string
+	}{
+		{
+			Name:  "Policy applying to all the queues",
+			queue: "default",
+			expectAnnotations: map[string]string{
+				api.MaxInFlightAnnotationKey: "10",
+				api.PreemptableAnnotationKey: "true",
+			},
+		},
+		{
+			Name:  "Policy naming the queue takes precedence",
+			queue: "batch",
+			expectAnnotations: map[string]string{
+				api.MaxInFlightAnnotationKey:        "10",
+				api.PreemptableAnnotationKey:        "false",
+				api.DispatchWindowAnnotationKey:     "20:00-06:00",
+				api.NamespaceFairnessAnnotationKey:  "round-robin",
+				api.DispatchOrderAnnotationKey:      "fifo",
+				api.DispatchRateLimitAnnotationKey:  "10/1m",
+				api.MinResourcesSourceAnnotationKey: "workload",
+			},
+		},
+		{
+			Name:        "Annotation of the queue takes precedence",
+			queue:       "default",
+			annotations: map[string]string{api.MaxInFlightAnnotationKey: "3"},
+			expectAnnotations: map[string]string{
+				api.MaxInFlightAnnotationKey: "3",
+				api.PreemptableAnnotationKey: "true",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		queue := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: tc.queue, Annotations: tc.annotations}}
+		queues := map[string]*schedulingapi.QueueInfo{tc.queue: {Name: tc.queue, Queue: queue}}
+		applyDispatchPolicies(queues, policies)
+
+		if got := queues[tc.queue].Queue.Annotations; !reflect.DeepEqual(got, tc.expectAnnotations) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expectAnnotations)
+		}
+		// The cached queue is copied before it's changed.
+		if !reflect.DeepEqual(queue.Annotations, tc.annotations) {
+			t.Errorf("Test case %s failed, the cached queue is changed: %v", tc.Name, queue.Annotations)
+		}
+	}
+}
+
+func TestStartDispatchPolicyInformer(t *testing.T) {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": dispatchv1alpha1.SchemeGroupVersion.String(),
+		"kind":       "DispatchPolicy",
+		"metadata":   map[string]interface{}{"name": "all"},
+		"spec":       map[string]interface{}{"paused": true},
+	}}
+	kubeClient := kubefake.NewSimpleClientset()
+	dc := newTestDispatcherCache()
+	dc.kubeClient = kubeClient
+	dc.dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{dispatchv1alpha1.DispatchPolicyResource: "DispatchPolicyList"}, policy)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// The CRD is not installed yet.
+	if dc.startDispatchPolicyInformer(stopCh) || dc.dispatchPolicyInformer != nil {
+		t.Errorf("Test case CRD not installed failed, got: watched expect: not watched")
+	}
+
+	// The CRD is installed after the dispatcher starts.
+	kubeClient.Resources = []*metav1.APIResourceList{{
+		GroupVersion: dispatchv1alpha1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: dispatchv1alpha1.DispatchPolicyResource.Resource, Kind: "DispatchPolicy"}},
+	}}
+	if !dc.startDispatchPolicyInformer(stopCh) || dc.dispatchPolicyInformer == nil {
+		t.Errorf("Test case CRD installed failed, got: not watched expect: watched")
+	}
+	if _, found := dc.dispatchPolicies["all"]; !found {
+		t.Errorf("Test case CRD installed failed, got: %v expect: policy all synced", dc.dispatchPolicies)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// recordEventError counts the event of the kind which failed to be converted or processed, a burst of them tells
+// the cache may have drifted from the informer, the dispatcher resyncs the kind when they exceed the budget.
+func (dc *DispatcherCache) recordEventError(kind string) {
+	dc.eventErrorMutex.Lock()
+	defer dc.eventErrorMutex.Unlock()
+
+	dc.eventErrors[kind]++
+}
+
+// TakeEventErrors returns the number of the failed events by the kind since the last call, and resets them.
+func (dc *DispatcherCache) TakeEventErrors() map[string]int {
+	dc.eventErrorMutex.Lock()
+	defer dc.eventErrorMutex.Unlock()
+
+	eventErrors := dc.eventErrors
+	dc.eventErrors = map[string]int{}
+	return eventErrors
+}
+
+// Resync replays the objects of the kind in the store of its informer through the add handler, so the ones whose
+// events were failed or missed are cached again. The stale ones are left to CollectGarbage.
+// It returns the number of the replayed objects.
+func (dc *DispatcherCache) Resync(kind string) int {
+	var store cache.Store
+	var add func(obj interface{})
+	switch kind {
+	case "Queue":
+		store, add = dc.queueInformer.Informer().GetStore(), dc.addQueue
+	case "PodGroup":
+		store, add = dc.podGroupInformer.Informer().GetStore(), dc.addPodGroup
+	case "PriorityClass":
+		store, add = dc.priorityClassInformer.Informer().GetStore(), dc.addPriorityClass
+	case "ResourceBinding":
+		store, add = dc.resourceBindingInformer.Informer().GetStore(), dc.addResourceBinding
+	case "FederatedResourceQuota":
+		store, add = dc.federatedResourceQuotaInformer.Informer().GetStore(), dc.addFederatedResourceQuota
+	case "Cluster":
+		store, add = dc.clusterInformer.Informer().GetStore(), dc.addCluster
+	case "Namespace":
+		store, add = dc.namespaceInformer.Informer().GetStore(), dc.addNamespace
+	case "DispatchPolicy":
+		dc.dispatchPolicyMutex.RLock()
+		informer := dc.dispatchPolicyInformer
+		dc.dispatchPolicyMutex.RUnlock()
+		if informer == nil {
+			return 0
+		}
+		store, add = informer.GetStore(), dc.setDispatchPolicy
+	default:
+		klog.Warningf("DispatcherCache can't resync the unknown kind <%s>.", kind)
+		return 0
+	}
+
+	objs := store.List()
+	for _, obj := range objs {
+		add(obj)
+	}
+	klog.V(2).Infof("DispatcherCache resynced <%d> %s objects from the informer.", len(objs), kind)
+	return len(objs)
+}
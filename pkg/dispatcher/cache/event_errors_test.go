@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventErrors(t *testing.T) {
+	karmadaInformerFactory := karmadainformerfactory.NewSharedInformerFactory(karmadafake.NewSimpleClientset(), 0)
+
+	dc := newTestDispatcherCache()
+	dc.clusterInformer = karmadaInformerFactory.Cluster().V1alpha1().Clusters()
+
+	// The events of the unexpected objects fail to be converted.
+	dc.addCluster("not a cluster")
+	dc.deleteCluster(nil)
+	dc.addNamespace(&clusterv1alpha1.Cluster{})
+
+	eventErrors := dc.TakeEventErrors()
+	expect := map[string]int{"Cluster": 2, "Namespace": 1}
+	if !reflect.DeepEqual(eventErrors, expect) {
+		t.Errorf("Test case %s failed, got: %v expect: %v", "Count the failed events", eventErrors, expect)
+	}
+	if eventErrors = dc.TakeEventErrors(); len(eventErrors) != 0 {
+		t.Errorf("Test case %s failed, got: %v expect: %v", "Reset after taken", eventErrors, map[string]int{})
+	}
+
+	// The cluster whose add event was missed is cached again by the resync.
+	_ = dc.clusterInformer.Informer().GetStore().Add(&clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "member1"}})
+	if count := dc.Resync("Cluster"); count != 1 || dc.clusters["member1"] == nil {
+		t.Errorf("Test case %s failed, got: %d resynced, cached %v expect: %d resynced, cached %v",
+			"Resync the missed cluster", count, dc.clusters["member1"] != nil, 1, true)
+	}
+	if count := dc.Resync("Unknown"); count != 0 {
+		t.Errorf("Test case %s failed, got: %d expect: %d", "Resync the unknown kind", count, 0)
+	}
+}
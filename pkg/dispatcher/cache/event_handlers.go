@@ -17,7 +17,14 @@ limitations under the License.
 package cache
 
 import (
+	"time"
+
 	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/apis/pkg/apis/scheduling/scheme"
@@ -31,6 +38,7 @@ import (
 func (dc *DispatcherCache) addQueue(obj interface{}) {
 	queue := convertToQueue(obj)
 	if queue == nil {
+		dc.recordEventError("Queue")
 		return
 	}
 
@@ -38,11 +46,12 @@ func (dc *DispatcherCache) addQueue(obj interface{}) {
 	v1queue := &scheduling.Queue{}
 	if err := scheme.Scheme.Convert(queue, v1queue, nil); err != nil {
 		klog.Errorf("Failed to convert queue from %T to %T", queue, v1queue)
+		dc.recordEventError("Queue")
 		return
 	}
 
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
+	dc.queueMutex.Lock()
+	defer dc.queueMutex.Unlock()
 
 	dc.queues[queue.Name] = schedulingapi.NewQueueInfo(v1queue)
 }
@@ -50,10 +59,11 @@ func (dc *DispatcherCache) addQueue(obj interface{}) {
 func (dc *DispatcherCache) deleteQueue(obj interface{}) {
 	queue := convertToQueue(obj)
 	if queue == nil {
+		dc.recordEventError("Queue")
 		return
 	}
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
+	dc.queueMutex.Lock()
+	defer dc.queueMutex.Unlock()
 
 	delete(dc.queues, queue.Name)
 }
@@ -62,44 +72,57 @@ func (dc *DispatcherCache) updateQueue(oldObj, newObj interface{}) {
 	oldQueue := convertToQueue(oldObj)
 	newQueue := convertToQueue(newObj)
 	if oldQueue == nil || newQueue == nil {
+		dc.recordEventError("Queue")
 		return
 	}
 
 	dc.deleteQueue(oldQueue)
 	dc.addQueue(newQueue)
+
+	if oldQueue.Spec.Weight != newQueue.Spec.Weight || !equality.Semantic.DeepEqual(oldQueue.Spec.Capability, newQueue.Spec.Capability) {
+		klog.V(3).Infof("The capability or the weight of Queue <%s> is changed, wake up the dispatcher.", newQueue.Name)
+		dc.wakeUpDispatcher()
+	}
+}
+
+// wakeUpDispatcher signals the dispatcher without blocking, the signal is dropped when one is pending already.
+func (dc *DispatcherCache) wakeUpDispatcher() {
+	select {
+	case dc.wakeUp <- struct{}{}:
+	default:
+	}
 }
 
 func (dc *DispatcherCache) addPodGroup(obj interface{}) {
 	pg := convertToPodGroup(obj)
 	if pg == nil {
+		dc.recordEventError("PodGroup")
 		return
 	}
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
+	dc.podGroupMutex.Lock()
+	defer dc.podGroupMutex.Unlock()
 
-	if dc.podGroups[pg.Namespace] == nil {
-		dc.podGroups[pg.Namespace] = map[string]*schedulingv1beta1.PodGroup{
-			pg.Name: pg,
-		}
-	} else {
-		dc.podGroups[pg.Namespace][pg.Name] = pg
+	dc.podGroups[types.NamespacedName{Namespace: pg.Namespace, Name: pg.Name}] = pg
+	for _, ownerRef := range pg.OwnerReferences {
+		dc.podGroupsByOwner[ownerRef.UID] = pg
 	}
 }
 
 func (dc *DispatcherCache) deletePodGroup(obj interface{}) {
 	pg := convertToPodGroup(obj)
 	if pg == nil {
+		dc.recordEventError("PodGroup")
 		return
 	}
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
-
-	if dc.podGroups[pg.Namespace] == nil {
-		klog.Errorf("Failed to delete PodGroup <%s/%s>, the PodGroup's "+
-			"Namespace should is not in the cache.", pg.Namespace, pg.Name)
-		return
-	} else {
-		delete(dc.podGroups[pg.Namespace], pg.Name)
+	dc.podGroupMutex.Lock()
+	defer dc.podGroupMutex.Unlock()
+
+	delete(dc.podGroups, types.NamespacedName{Namespace: pg.Namespace, Name: pg.Name})
+	// The owner may be taken by another PodGroup, keep the index of it.
+	for _, ownerRef := range pg.OwnerReferences {
+		if indexed, found := dc.podGroupsByOwner[ownerRef.UID]; found && indexed.Namespace == pg.Namespace && indexed.Name == pg.Name {
+			delete(dc.podGroupsByOwner, ownerRef.UID)
+		}
 	}
 }
 
@@ -107,6 +130,7 @@ func (dc *DispatcherCache) updatePodGroup(oldObj, newObj interface{}) {
 	oldPg := convertToPodGroup(oldObj)
 	newPg := convertToPodGroup(newObj)
 	if oldPg == nil || newPg == nil {
+		dc.recordEventError("PodGroup")
 		return
 	}
 
@@ -117,38 +141,64 @@ func (dc *DispatcherCache) updatePodGroup(oldObj, newObj interface{}) {
 func (dc *DispatcherCache) addPriorityClass(obj interface{}) {
 	pc := convertToPriorityClass(obj)
 	if pc == nil {
+		dc.recordEventError("PriorityClass")
 		return
 	}
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
+	dc.priorityClassMutex.Lock()
+	defer dc.priorityClassMutex.Unlock()
 
+	dc.priorityClasses[pc.Name] = pc
 	if pc.GlobalDefault {
-		klog.V(3).Infof("Set default PriorityClass to <%s>, Priority <%d>.", pc.Name, pc.Value)
-		dc.defaultPriorityClass = pc
+		dc.resetDefaultPriorityClass()
 	}
-
-	dc.priorityClasses[pc.Name] = pc
 }
 
 func (dc *DispatcherCache) deletePriorityClass(obj interface{}) {
 	pc := convertToPriorityClass(obj)
 	if pc == nil {
+		dc.recordEventError("PriorityClass")
 		return
 	}
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
+	dc.priorityClassMutex.Lock()
+	defer dc.priorityClassMutex.Unlock()
 
+	delete(dc.priorityClasses, pc.Name)
 	if pc.GlobalDefault {
 		klog.V(5).Infof("Delete default PriorityClass <%s>, Priority <%d>.", pc.Name, pc.Value)
-		dc.defaultPriorityClass = nil
+		dc.resetDefaultPriorityClass()
 	}
-	delete(dc.priorityClasses, pc.Name)
+}
+
+// resetDefaultPriorityClass picks the default PriorityClass from all the GlobalDefault ones, the apiserver
+// doesn't prevent the conflicts, so the highest value wins and the lexically smallest name breaks the tie.
+// The caller must hold the priorityClassMutex.
+func (dc *DispatcherCache) resetDefaultPriorityClass() {
+	var defaultPriorityClass *schedulingv1.PriorityClass
+	globalDefaults := 0
+	for _, pc := range dc.priorityClasses {
+		if !pc.GlobalDefault {
+			continue
+		}
+		globalDefaults++
+		if defaultPriorityClass == nil || pc.Value > defaultPriorityClass.Value ||
+			(pc.Value == defaultPriorityClass.Value && pc.Name < defaultPriorityClass.Name) {
+			defaultPriorityClass = pc
+		}
+	}
+	if globalDefaults > 1 {
+		klog.Warningf("Found %d GlobalDefault PriorityClasses, use <%s> as the default.", globalDefaults, defaultPriorityClass.Name)
+	}
+	if defaultPriorityClass != nil && defaultPriorityClass != dc.defaultPriorityClass {
+		klog.V(3).Infof("Set default PriorityClass to <%s>, Priority <%d>.", defaultPriorityClass.Name, defaultPriorityClass.Value)
+	}
+	dc.defaultPriorityClass = defaultPriorityClass
 }
 
 func (dc *DispatcherCache) updatePriorityClass(oldObj, newObj interface{}) {
 	oldPc := convertToPriorityClass(oldObj)
 	newPc := convertToPriorityClass(newObj)
 	if oldPc == nil || newPc == nil {
+		dc.recordEventError("PriorityClass")
 		return
 	}
 	dc.deletePriorityClass(oldPc)
@@ -158,14 +208,77 @@ func (dc *DispatcherCache) updatePriorityClass(oldObj, newObj interface{}) {
 func (dc *DispatcherCache) addResourceBinding(obj interface{}) {
 	rb := convertToResourceBinding(obj)
 	if rb == nil {
+		dc.recordEventError("ResourceBinding")
+		return
+	}
+	if dc.faults.dropEvent() {
+		klog.Warningf("Drop the add event of ResourceBinding <%s/%s> by the fault injection.", rb.Namespace, rb.Name)
 		return
 	}
+	dc.resourceBindingTaskQueue.Add(types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name})
+}
 
+func (dc *DispatcherCache) updateResourceBinding(oldObj, newObj interface{}) {
+	oldRb := convertToResourceBinding(oldObj)
+	newRb := convertToResourceBinding(newObj)
+	if oldRb == nil || newRb == nil {
+		dc.recordEventError("ResourceBinding")
+		return
+	}
+	if dc.faults.dropEvent() {
+		klog.Warningf("Drop the update event of ResourceBinding <%s/%s> by the fault injection.", newRb.Namespace, newRb.Name)
+		return
+	}
+	dc.resourceBindingTaskQueue.Add(types.NamespacedName{Namespace: newRb.Namespace, Name: newRb.Name})
+}
+
+func (dc *DispatcherCache) deleteResourceBinding(obj interface{}) {
+	rb := convertToResourceBinding(obj)
+	if rb == nil {
+		dc.recordEventError("ResourceBinding")
+		return
+	}
+	dc.resourceBindingTaskQueue.Add(types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name})
+}
+
+// Its worker for processing the ResourceBinding events out of the informer goroutine, because getting the workload
+// and resolving its min resources may be slow. The keys are deduplicated by the queue, the latest ResourceBinding
+// is got from the lister when processing, and the keys failed to get are retried by the rate limiter.
+func (dc *DispatcherCache) resourceBindingTaskWorker() {
+	for {
+		obj, shutdown := dc.resourceBindingTaskQueue.Get()
+		if shutdown {
+			return
+		}
+
+		key := obj.(types.NamespacedName)
+		rb, err := dc.resourceBindingInformer.Lister().ResourceBindings(key.Namespace).Get(key.Name)
+		switch {
+		case apierrors.IsNotFound(err):
+			dc.removeResourceBinding(key)
+		case err != nil:
+			klog.Errorf("Failed to get ResourceBinding <%s/%s> from the lister, retry it, err: %v", key.Namespace, key.Name, err)
+			dc.recordEventError("ResourceBinding")
+			dc.resourceBindingTaskQueue.AddRateLimited(key)
+			dc.resourceBindingTaskQueue.Done(key)
+			continue
+		default:
+			dc.setResourceBinding(rb)
+		}
+		dc.resourceBindingTaskQueue.Forget(key)
+		dc.resourceBindingTaskQueue.Done(key)
+	}
+}
+
+// setResourceBinding adds or updates the ResourceBinding in the cache, the min resources of the old one
+// are reused if the spec of the ResourceBinding and the workload didn't change.
+func (dc *DispatcherCache) setResourceBinding(rb *workv1alpha2.ResourceBinding) {
 	// Check if its workload, skip add to cache if not.
-	isWorkload, err := utils.IsWorkload(rb.Spec.Resource)
+	isWorkload, err := utils.DefaultWorkloadRegistry.IsWorkload(rb.Spec.Resource)
 	if err != nil {
 		klog.Errorf("Failed to check ResourceBinding <%s/%s> if workload, stop add it to cache, err: %v",
 			rb.Namespace, rb.Name, err)
+		dc.recordEventError("ResourceBinding")
 		return
 	}
 	if !isWorkload {
@@ -173,65 +286,319 @@ func (dc *DispatcherCache) addResourceBinding(obj interface{}) {
 			rb.Namespace, rb.Name)
 		return
 	}
+	// The ResourceBinding opted out of the dispatcher, remove it from the cache in case the opt-out is added later.
+	if utils.IsDispatchDisabled(rb) {
+		klog.V(3).Infof("ResourceBinding <%s/%s> opted out of the dispatcher, skip add it to cache.",
+			rb.Namespace, rb.Name)
+		dc.releaseResourceBinding(rb)
+		return
+	}
 
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
-
-	// Add the ResourceBinding to cache.
-	if dc.resourceBindings[rb.Namespace] == nil {
-		dc.resourceBindings[rb.Namespace] = map[string]*workv1alpha2.ResourceBinding{
-			rb.Name: rb,
+	// Resolve the min resources out of the lock, it may need to get the workload from the apiserver.
+	// The result can be reused if the spec of the ResourceBinding and the workload didn't change.
+	key := types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}
+	dc.resourceBindingMutex.RLock()
+	oldResourceBindingInfo := dc.resourceBindingInfos[key]
+	dc.resourceBindingMutex.RUnlock()
+	// The state of the dead workload doesn't apply to the new one, the ResourceBinding is handled like it's new.
+	recreated := isWorkloadRecreated(oldResourceBindingInfo, rb)
+	if recreated {
+		klog.V(3).Infof("The workload of ResourceBinding <%s/%s> is recreated, UID <%s> -> <%s>, reset its dispatch state.",
+			rb.Namespace, rb.Name, oldResourceBindingInfo.ResourceUID, rb.Spec.Resource.UID)
+		if rb.Annotations[api.ExpiredAnnotationKey] != "" {
+			dc.AnnotateResourceBinding(key, api.ExpiredAnnotationKey, "")
 		}
+		oldResourceBindingInfo = nil
+	}
+	var minResources, firstStageResources corev1.ResourceList
+	var clusterRequirements map[string]*workv1alpha2.ReplicaRequirements
+	var workloadQueue string
+	if oldResourceBindingInfo != nil && oldResourceBindingInfo.MinResources != nil &&
+		oldResourceBindingInfo.ResourceUID == rb.Spec.Resource.UID &&
+		oldResourceBindingInfo.ResourceBinding.Generation == rb.Generation {
+		minResources = oldResourceBindingInfo.MinResources
+		firstStageResources = oldResourceBindingInfo.FirstStageResources
+		clusterRequirements = oldResourceBindingInfo.ClusterReplicaRequirements
+		workloadQueue = oldResourceBindingInfo.WorkloadQueue
 	} else {
-		dc.resourceBindings[rb.Namespace][rb.Name] = rb
+		// The change of the workload, like the opt-out is added, is synced to the ResourceBinding by Karmada,
+		// so the workload is checked again when the spec of the ResourceBinding changes.
+		workload := dc.getWorkload(rb)
+		if workload != nil && utils.IsDispatchDisabled(workload) {
+			klog.V(3).Infof("The workload of ResourceBinding <%s/%s> opted out of the dispatcher, skip add it to cache.",
+				rb.Namespace, rb.Name)
+			dc.releaseResourceBinding(rb)
+			return
+		}
+		minResources, firstStageResources, clusterRequirements = dc.resolveMinResources(rb, workload)
+		if workload != nil {
+			workloadQueue = workload.GetAnnotations()[schedulingv1beta1.QueueNameAnnotationKey]
+		}
 	}
 
+	dc.resourceBindingMutex.Lock()
+	defer dc.resourceBindingMutex.Unlock()
+
+	// Add the ResourceBinding to cache.
+	dc.resourceBindings[key] = rb
+
 	// Build the ResourceBindingInfo, the other elements will set when Snapshot.
+	now := time.Now()
 	newResourceBindingInfo := &api.ResourceBindingInfo{
-		ResourceBinding: rb,
-		ResourceUID:     rb.Spec.Resource.UID,
-		DispatchStatus:  api.UnSuspended,
+		ResourceBinding:     rb,
+		ResourceUID:         rb.Spec.Resource.UID,
+		WorkloadQueue:       workloadQueue,
+		MinResources:        minResources,
+		FirstStageResources: firstStageResources,
+
+		ClusterReplicaRequirements: clusterRequirements,
+	}
+	if oldResourceBindingInfo != nil {
+		newResourceBindingInfo.DispatchStatus = oldResourceBindingInfo.DispatchStatus
+		newResourceBindingInfo.TransitionTimes = oldResourceBindingInfo.DeepCopy().TransitionTimes
+		newResourceBindingInfo.Preemptions = oldResourceBindingInfo.Preemptions
+		newResourceBindingInfo.Failures = oldResourceBindingInfo.Failures
+		newResourceBindingInfo.FailedPlacement = oldResourceBindingInfo.FailedPlacement
+	}
+	// The webhook with the `Fail` failurePolicy suspends each ResourceBinding when it's created, but the one with
+	// `Ignore` may be skipped, the unsuspended ResourceBindings are marked unadmitted, see isUnadmitted.
+	newResourceBindingInfo.SetDispatchStatus(observedDispatchStatus(utils.IsResourceBindingSuspended(rb),
+		utils.IsResourceBindingApplied(rb), oldResourceBindingInfo), now)
+	newResourceBindingInfo.Unadmitted = dc.isUnadmitted(newResourceBindingInfo, oldResourceBindingInfo)
+	setDispatchTimestamps(newResourceBindingInfo, oldResourceBindingInfo, now)
+	setAdmittedResources(newResourceBindingInfo, oldResourceBindingInfo)
+	setAdmittedClusters(newResourceBindingInfo, oldResourceBindingInfo)
+	// The dispatcher restarted or the ResourceBinding was taken over from another replica.
+	if oldResourceBindingInfo == nil && !recreated && newResourceBindingInfo.RestoreCheckpoint() {
+		klog.V(4).Infof("Restored the dispatch checkpoint of ResourceBinding <%s/%s>.", rb.Namespace, rb.Name)
+	}
+
+	dc.resourceBindingInfos[key] = newResourceBindingInfo
+}
+
+// isUnadmitted checks whether the dispatched ResourceBinding was never admitted by its queue. The one created
+// unsuspended after the cache started is unadmitted, the ones listed when the cache starts can't be told from the
+// ones dispatched before the restart, they're taken as admitted.
+func (dc *DispatcherCache) isUnadmitted(rbi, oldRbi *api.ResourceBindingInfo) bool {
+	if rbi.DispatchStatus != api.Dispatched {
+		return false
+	}
+	if oldRbi != nil {
+		return oldRbi.Unadmitted
+	}
+	// The creation timestamp is truncated to the seconds.
+	return !dc.startTime.IsZero() && !rbi.ResourceBinding.CreationTimestamp.Time.Before(dc.startTime.Truncate(time.Second))
+}
+
+// isWorkloadRecreated checks whether the workload of the ResourceBinding is deleted and created again with the same
+// name, the ResourceBinding is kept by Karmada and refers to the new workload by its UID then.
+func isWorkloadRecreated(oldRbi *api.ResourceBindingInfo, rb *workv1alpha2.ResourceBinding) bool {
+	return oldRbi != nil && oldRbi.ResourceUID != "" && rb.Spec.Resource.UID != "" && oldRbi.ResourceUID != rb.Spec.Resource.UID
+}
+
+// observedDispatchStatus returns the status of the ResourceBinding by whether it's suspended. The status set by
+// the dispatcher is kept until the patch of the dispatcher is observed, or the patch fails. The unsuspended
+// ResourceBinding which failed as its workload isn't applied is kept Failed until the workload is applied.
+func observedDispatchStatus(suspended, applied bool, oldRbi *api.ResourceBindingInfo) api.DispatchStatus {
+	if oldRbi == nil {
+		if suspended {
+			return api.Pending
+		}
+		return api.Dispatched
 	}
-	// Currently, our failurePolicy is set to Fail, which ensures that no unexpected ResourceBindings will exist.
-	// When a ResourceBinding is created, it will definitely be updated to Suspend, so we don't need to check the Status.
-	if rb.Spec.Suspend {
-		newResourceBindingInfo.DispatchStatus = api.Suspended
+
+	switch {
+	case suspended && oldRbi.DispatchStatus == api.Dispatched:
+		// Suspended by the others, like the users.
+		return api.Pending
+	case suspended:
+		return oldRbi.DispatchStatus
+	case oldRbi.DispatchStatus == api.Preempted:
+		// The patch suspending it again is not observed yet.
+		return api.Preempted
+	case oldRbi.DispatchStatus == api.Failed && !applied:
+		return api.Failed
+	default:
+		return api.Dispatched
 	}
+}
 
-	if dc.resourceBindingInfos[rb.Namespace] == nil {
-		dc.resourceBindingInfos[rb.Namespace] = map[string]*api.ResourceBindingInfo{
-			rb.Name: newResourceBindingInfo,
+// setDispatchTimestamps inherits the timestamps from the old ResourceBindingInfo, the EnqueueTime is reset
+// when the ResourceBinding is suspended again after it was unsuspended.
+func setDispatchTimestamps(rbi, oldRbi *api.ResourceBindingInfo, now time.Time) {
+	if oldRbi == nil {
+		rbi.FirstSeenTime = now
+		if !rbi.DispatchStatus.IsDispatched() {
+			rbi.EnqueueTime = now
 		}
-	} else {
-		dc.resourceBindingInfos[rb.Namespace][rb.Name] = newResourceBindingInfo
+		return
+	}
+
+	rbi.FirstSeenTime = oldRbi.FirstSeenTime
+	rbi.EnqueueTime = oldRbi.EnqueueTime
+	rbi.UnSuspendTime = oldRbi.UnSuspendTime
+	if !rbi.DispatchStatus.IsDispatched() && (oldRbi.DispatchStatus == api.Dispatched || rbi.EnqueueTime.IsZero()) {
+		rbi.EnqueueTime = now
+		rbi.UnSuspendTime = time.Time{}
 	}
 }
 
-func (dc *DispatcherCache) deleteResourceBinding(obj interface{}) {
-	rb := convertToResourceBinding(obj)
-	if rb == nil {
+// setAdmittedResources inherits the admitted resources from the old ResourceBindingInfo, the dispatched ResourceBinding
+// which is not admitted by this dispatcher, like dispatched before it restarts, admits its current MinResources.
+func setAdmittedResources(rbi, oldRbi *api.ResourceBindingInfo) {
+	if !rbi.DispatchStatus.IsDispatched() {
 		return
 	}
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
+	if oldRbi != nil && oldRbi.AdmittedResources != nil {
+		rbi.AdmittedResources = oldRbi.AdmittedResources
+		return
+	}
+	rbi.AdmittedResources = rbi.MinResources
+}
 
-	if dc.resourceBindings[rb.Namespace] == nil {
-		klog.Errorf("Failed to delete ResourceBinding <%s/%s>, the Resourcebinding's "+
-			"Namespace is not in the cache.", rb.Namespace, rb.Name)
+// setAdmittedClusters inherits the admitted clusters from the old ResourceBindingInfo, the dispatched ResourceBinding
+// which is not scheduled since it was dispatched admits the clusters of its first scheduling result.
+func setAdmittedClusters(rbi, oldRbi *api.ResourceBindingInfo) {
+	if !rbi.DispatchStatus.IsDispatched() {
 		return
-	} else {
-		delete(dc.resourceBindings[rb.Namespace], rb.Name)
-		delete(dc.resourceBindingInfos[rb.Namespace], rb.Name)
+	}
+	if oldRbi != nil && oldRbi.AdmittedClusters != nil {
+		rbi.AdmittedClusters = oldRbi.AdmittedClusters
+		return
+	}
+	if len(rbi.ResourceBinding.Spec.Clusters) != 0 {
+		rbi.AdmittedClusters = rbi.ScheduledClusters()
 	}
 }
 
-func (dc *DispatcherCache) updateResourceBinding(oldObj, newObj interface{}) {
-	oldRb := convertToResourceBinding(oldObj)
-	newRb := convertToResourceBinding(newObj)
-	if oldRb == nil || newRb == nil {
+// releaseResourceBinding removes the ResourceBinding opted out of the dispatcher from the cache, and unsuspends it
+// if it's still suspended, like the opt-out is added after it was suspended, so it's not stuck.
+func (dc *DispatcherCache) releaseResourceBinding(rb *workv1alpha2.ResourceBinding) {
+	key := types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}
+	dc.resourceBindingMutex.Lock()
+	delete(dc.resourceBindings, key)
+	delete(dc.resourceBindingInfos, key)
+	dc.resourceBindingMutex.Unlock()
+
+	if !utils.IsResourceBindingSuspended(rb) {
+		return
+	}
+	klog.V(3).Infof("ResourceBinding <%s/%s> opted out of the dispatcher is suspended, unsuspend it.", rb.Namespace, rb.Name)
+	if err := dc.patchUnSuspendResourceBinding(rb); err != nil {
+		// Retry by the next event of the ResourceBinding, like the resync.
+		klog.Errorf("Failed to unsuspend ResourceBinding <%s/%s> opted out of the dispatcher, err: %v", rb.Namespace, rb.Name, err)
+	}
+}
+
+func (dc *DispatcherCache) removeResourceBinding(key types.NamespacedName) {
+	dc.resourceBindingMutex.Lock()
+	defer dc.resourceBindingMutex.Unlock()
+
+	delete(dc.resourceBindings, key)
+	delete(dc.resourceBindingInfos, key)
+}
+
+func (dc *DispatcherCache) addFederatedResourceQuota(obj interface{}) {
+	frq := convertToFederatedResourceQuota(obj)
+	if frq == nil {
+		dc.recordEventError("FederatedResourceQuota")
 		return
 	}
+	dc.federatedResourceQuotaMutex.Lock()
+	defer dc.federatedResourceQuotaMutex.Unlock()
+
+	dc.federatedResourceQuotas[types.NamespacedName{Namespace: frq.Namespace, Name: frq.Name}] = frq
+}
+
+func (dc *DispatcherCache) deleteFederatedResourceQuota(obj interface{}) {
+	frq := convertToFederatedResourceQuota(obj)
+	if frq == nil {
+		dc.recordEventError("FederatedResourceQuota")
+		return
+	}
+	dc.federatedResourceQuotaMutex.Lock()
+	defer dc.federatedResourceQuotaMutex.Unlock()
+
+	delete(dc.federatedResourceQuotas, types.NamespacedName{Namespace: frq.Namespace, Name: frq.Name})
+}
+
+func (dc *DispatcherCache) updateFederatedResourceQuota(oldObj, newObj interface{}) {
+	oldFrq := convertToFederatedResourceQuota(oldObj)
+	newFrq := convertToFederatedResourceQuota(newObj)
+	if oldFrq == nil || newFrq == nil {
+		dc.recordEventError("FederatedResourceQuota")
+		return
+	}
+
+	dc.deleteFederatedResourceQuota(oldFrq)
+	dc.addFederatedResourceQuota(newFrq)
+}
+
+func (dc *DispatcherCache) addCluster(obj interface{}) {
+	cluster := convertToCluster(obj)
+	if cluster == nil {
+		dc.recordEventError("Cluster")
+		return
+	}
+	dc.clusterMutex.Lock()
+	defer dc.clusterMutex.Unlock()
+
+	dc.clusters[cluster.Name] = cluster
+}
+
+func (dc *DispatcherCache) deleteCluster(obj interface{}) {
+	cluster := convertToCluster(obj)
+	if cluster == nil {
+		dc.recordEventError("Cluster")
+		return
+	}
+	dc.clusterMutex.Lock()
+	defer dc.clusterMutex.Unlock()
+
+	delete(dc.clusters, cluster.Name)
+}
+
+func (dc *DispatcherCache) updateCluster(oldObj, newObj interface{}) {
+	oldCluster := convertToCluster(oldObj)
+	newCluster := convertToCluster(newObj)
+	if oldCluster == nil || newCluster == nil {
+		dc.recordEventError("Cluster")
+		return
+	}
+	dc.addCluster(newCluster)
+
+	// The capacity of the federation and the clusters the workloads can land on are changed with the taints, like the
+	// cluster is cordoned, drained or back.
+	if !equality.Semantic.DeepEqual(oldCluster.Spec.Taints, newCluster.Spec.Taints) || ClusterSchedulable(oldCluster) != ClusterSchedulable(newCluster) {
+		klog.V(3).Infof("The taints or the readiness of Cluster <%s> is changed, wake up the dispatcher.", newCluster.Name)
+		dc.wakeUpDispatcher()
+	}
+}
+
+func (dc *DispatcherCache) addNamespace(obj interface{}) {
+	namespace := convertToNamespace(obj)
+	if namespace == nil {
+		dc.recordEventError("Namespace")
+		return
+	}
+	dc.namespaceMutex.Lock()
+	defer dc.namespaceMutex.Unlock()
+
+	dc.namespaces[namespace.Name] = namespace
+}
+
+func (dc *DispatcherCache) deleteNamespace(obj interface{}) {
+	namespace := convertToNamespace(obj)
+	if namespace == nil {
+		dc.recordEventError("Namespace")
+		return
+	}
+	dc.namespaceMutex.Lock()
+	defer dc.namespaceMutex.Unlock()
+
+	delete(dc.namespaces, namespace.Name)
+}
 
-	dc.deleteResourceBinding(oldRb)
-	dc.addResourceBinding(newRb)
+func (dc *DispatcherCache) updateNamespace(_, newObj interface{}) {
+	dc.addNamespace(newObj)
 }
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// FakeClients are the fake clients backing the DispatcherCache built by the FakeDispatcherCacheBuilder, the tests
+// check the patches and inject the failures by their actions and reactors.
+type FakeClients struct {
+	KubeClient    *kubefake.Clientset
+	VolcanoClient *volcanofake.Clientset
+	KarmadaClient *karmadafake.Clientset
+	DynamicClient *dynamicfake.FakeDynamicClient
+}
+
+// FakeDispatcherCacheBuilder builds the DispatcherCache backed by the fake clients, so the event handlers, the
+// snapshots and the plugins can be covered by the unit tests without a live control plane. The objects are
+// listed by the informers after the cache runs, and the workloads are got by the dynamic client.
+type FakeDispatcherCacheBuilder struct {
+	option         DispatcherCacheOption
+	suspendMode    utils.SuspendMode
+	kubeObjects    []runtime.Object
+	volcanoObjects []runtime.Object
+	karmadaObjects []runtime.Object
+	workloads      []runtime.Object
+}
+
+// NewFakeDispatcherCacheBuilder creates the builder with one worker and the `default` queue as the default one,
+// the queue itself is not created unless it's added by WithVolcanoObjects.
+func NewFakeDispatcherCacheBuilder() *FakeDispatcherCacheBuilder {
+	return &FakeDispatcherCacheBuilder{
+		option: DispatcherCacheOption{
+			WorkerNum:            1,
+			UnSuspendParallelism: 1,
+			DefaultQueueName:     "default",
+		},
+		suspendMode: utils.SuspendModeSuspend,
+	}
+}
+
+// WithOption tunes the option of the cache, like the OverridePolicyAware and the FaultInjection.
+func (b *FakeDispatcherCacheBuilder) WithOption(tune func(option *DispatcherCacheOption)) *FakeDispatcherCacheBuilder {
+	tune(&b.option)
+	return b
+}
+
+// WithSuspendMode sets the field used to suspend the ResourceBindings.
+func (b *FakeDispatcherCacheBuilder) WithSuspendMode(mode utils.SuspendMode) *FakeDispatcherCacheBuilder {
+	b.suspendMode = mode
+	return b
+}
+
+// WithKubeObjects adds the objects served by the kube client, like the Namespaces and the PriorityClasses.
+func (b *FakeDispatcherCacheBuilder) WithKubeObjects(objects ...runtime.Object) *FakeDispatcherCacheBuilder {
+	b.kubeObjects = append(b.kubeObjects, objects...)
+	return b
+}
+
+// WithVolcanoObjects adds the objects served by the volcano client, like the Queues and the PodGroups.
+func (b *FakeDispatcherCacheBuilder) WithVolcanoObjects(objects ...runtime.Object) *FakeDispatcherCacheBuilder {
+	b.volcanoObjects = append(b.volcanoObjects, objects...)
+	return b
+}
+
+// WithKarmadaObjects adds the objects served by the karmada client, like the ResourceBindings and the Clusters.
+func (b *FakeDispatcherCacheBuilder) WithKarmadaObjects(objects ...runtime.Object) *FakeDispatcherCacheBuilder {
+	b.karmadaObjects = append(b.karmadaObjects, objects...)
+	return b
+}
+
+// WithWorkloads adds the workloads served by the dynamic client, they're typed objects of the kube scheme or
+// the unstructured ones.
+func (b *FakeDispatcherCacheBuilder) WithWorkloads(objects ...runtime.Object) *FakeDispatcherCacheBuilder {
+	b.workloads = append(b.workloads, objects...)
+	return b
+}
+
+// Build creates the DispatcherCache and its fake clients, the kinds of the registered workloads are mapped to
+// the namespaced resources. Run the cache to list the objects into it.
+func (b *FakeDispatcherCacheBuilder) Build() (*DispatcherCache, *FakeClients) {
+	clients := &FakeClients{
+		KubeClient:    kubefake.NewSimpleClientset(b.kubeObjects...),
+		VolcanoClient: volcanofake.NewSimpleClientset(b.volcanoObjects...),
+		KarmadaClient: karmadafake.NewSimpleClientset(b.karmadaObjects...),
+		DynamicClient: dynamicfake.NewSimpleDynamicClient(scheme.Scheme, b.workloads...),
+	}
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range utils.DefaultWorkloadRegistry.List() {
+		restMapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+
+	option := b.option
+	dc := NewDispatcherCacheWithClients(&option, &DispatcherCacheClients{
+		KubeClient:    clients.KubeClient,
+		VolcanoClient: clients.VolcanoClient,
+		KarmadaClient: clients.KarmadaClient,
+		DynamicClient: clients.DynamicClient,
+		RESTMapper:    restMapper,
+		SuspendMode:   b.suspendMode,
+	})
+	return dc, clients
+}
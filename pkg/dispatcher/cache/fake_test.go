@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+func TestFakeDispatcherCacheBuilder(t *testing.T) {
+	newQueue := func(name string) *schedulingv1beta1.Queue {
+		return &schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen}}
+	}
+	notWorkload := newTestResourceBinding("ns", "cm")
+	notWorkload.Spec.Resource.APIVersion, notWorkload.Spec.Resource.Kind = "v1", "ConfigMap"
+	optedOut := newTestResourceBinding("ns", "opted-out")
+	optedOut.Annotations[utils.DispatchAnnotationKey] = "false"
+	dispatched := newTestResourceBinding("ns", "dispatched")
+	dispatched.Spec.Suspend = false
+
+	testCases := []struct {
+		Name         string
+		rb           *workv1alpha2.ResourceBinding
+		expectCached bool
+		expectStatus api.DispatchStatus
+	}{
+		{
+			Name:         "Suspended workload",
+			rb:           newTestResourceBinding("ns", "rb"),
+			expectCached: true,
+			expectStatus: api.Pending,
+		},
+		{
+			Name:         "Dispatched workload",
+			rb:           dispatched,
+			expectCached: true,
+			expectStatus: api.Dispatched,
+		},
+		{
+			Name: "Not a workload",
+			rb:   notWorkload,
+		},
+		{
+			Name: "Opted out of the dispatcher",
+			rb:   optedOut,
+		},
+	}
+
+	for _, tc := range testCases {
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: tc.rb.Namespace, Name: tc.rb.Name,
+			UID: tc.rb.Spec.Resource.UID}}
+		dc, _ := NewFakeDispatcherCacheBuilder().
+			WithVolcanoObjects(newQueue("default")).
+			WithKarmadaObjects(tc.rb).
+			WithWorkloads(deployment).
+			Build()
+		stopCh := make(chan struct{})
+		dc.Run(stopCh)
+
+		snapshot := dc.Snapshot()
+		rbi, cached := snapshot.ResourceBindingInfos[tc.rb.UID]
+		_, queueCached := snapshot.QueueInfos["default"]
+		if cached != tc.expectCached || !queueCached {
+			t.Errorf("Test case %s failed, got cached: %v queue cached: %v expect cached: %v",
+				tc.Name, cached, queueCached, tc.expectCached)
+		} else if cached && rbi.DispatchStatus != tc.expectStatus {
+			t.Errorf("Test case %s failed, got status: %v expect: %v", tc.Name, rbi.DispatchStatus, tc.expectStatus)
+		}
+		snapshot.Release()
+		close(stopCh)
+	}
+}
+
+func TestFakeDispatcherCacheUnSuspend(t *testing.T) {
+	rb := newTestResourceBinding("ns", "rb")
+	dc, clients := NewFakeDispatcherCacheBuilder().WithKarmadaObjects(rb).Build()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	dc.Run(stopCh)
+
+	key := types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}
+	dc.UnSuspendResourceBinding(key)
+	if err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, wait.ForeverTestTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			patched, err := clients.KarmadaClient.WorkV1alpha2().ResourceBindings(rb.Namespace).Get(ctx, rb.Name, metav1.GetOptions{})
+			return err == nil && !utils.IsResourceBindingSuspended(patched), nil
+		}); err != nil {
+		t.Errorf("Test case unsuspend failed, got ResourceBinding suspended: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// FaultInjection injects the faults into the cache to exercise the resilience of the dispatcher, like the retries of
+// the patches and the consistency checks, in the e2e tests and the staging environments. Never enable it in production.
+type FaultInjection struct {
+	// UnSuspendDelay delays each attempt of the unsuspend patches.
+	UnSuspendDelay time.Duration
+	// UnSuspendFailureRate is the probability an attempt of the unsuspend patches fails with a retriable error.
+	UnSuspendFailureRate float64
+	// EventDropRate is the probability an add or update event of the ResourceBindings is dropped.
+	EventDropRate float64
+	// SnapshotStall stalls each snapshot, like the cache is under heavy lock contention.
+	SnapshotStall time.Duration
+
+	// random returns a number in [0, 1), it's replaced by the tests.
+	random func() float64
+}
+
+// ParseFaultInjection parses the faults by the `--fault-injection` flag like `<fault>=<value>,...`, the faults are
+// unsuspend-delay, unsuspend-failure-rate, event-drop-rate and snapshot-stall. It returns nil when the flag is empty.
+func ParseFaultInjection(spec string) (*FaultInjection, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	fi := &FaultInjection{random: rand.Float64}
+	for _, item := range strings.Split(spec, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found {
+			return nil, fmt.Errorf("invalid fault %q, expect <fault>=<value>", item)
+		}
+		var err error
+		switch strings.TrimSpace(name) {
+		case "unsuspend-delay":
+			fi.UnSuspendDelay, err = time.ParseDuration(strings.TrimSpace(value))
+		case "unsuspend-failure-rate":
+			fi.UnSuspendFailureRate, err = parseRate(value)
+		case "event-drop-rate":
+			fi.EventDropRate, err = parseRate(value)
+		case "snapshot-stall":
+			fi.SnapshotStall, err = time.ParseDuration(strings.TrimSpace(value))
+		default:
+			return nil, fmt.Errorf("unknown fault %q", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid fault %q: %v", item, err)
+		}
+	}
+	return fi, nil
+}
+
+func parseRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("the rate must be between 0 and 1")
+	}
+	return rate, nil
+}
+
+// unSuspendFault delays the attempt of the unsuspend patch, and fails it with a retriable error by the rate.
+func (fi *FaultInjection) unSuspendFault() error {
+	if fi == nil {
+		return nil
+	}
+	if fi.UnSuspendDelay > 0 {
+		time.Sleep(fi.UnSuspendDelay)
+	}
+	if fi.random() < fi.UnSuspendFailureRate {
+		return apierrors.NewServiceUnavailable("the unsuspend patch fails by the fault injection")
+	}
+	return nil
+}
+
+// dropEvent checks whether the event of the ResourceBinding should be dropped by the rate.
+func (fi *FaultInjection) dropEvent() bool {
+	return fi != nil && fi.random() < fi.EventDropRate
+}
+
+// stallSnapshot stalls the snapshot.
+func (fi *FaultInjection) stallSnapshot() {
+	if fi != nil && fi.SnapshotStall > 0 {
+		time.Sleep(fi.SnapshotStall)
+	}
+}
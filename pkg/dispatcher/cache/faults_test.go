@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestParseFaultInjection(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		spec        string
+		expect      *FaultInjection
+		expectError bool
+	}{
+		{Name: "Disabled", spec: ""},
+		{
+			Name: "All the faults",
+			spec: "unsuspend-delay=1s, unsuspend-failure-rate=0.5,event-drop-rate=0.1,snapshot-stall=2s",
+			expect: &FaultInjection{UnSuspendDelay: time.Second, UnSuspendFailureRate: 0.5, EventDropRate: 0.1,
+				SnapshotStall: 2 * time.Second},
+		},
+		{Name: "Unknown fault", spec: "network-partition=1", expectError: true},
+		{Name: "Rate out of range", spec: "event-drop-rate=2", expectError: true},
+		{Name: "Missing value", spec: "snapshot-stall", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		fi, err := ParseFaultInjection(tc.spec)
+		if (err != nil) != tc.expectError {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectError)
+			continue
+		}
+		if tc.expect == nil {
+			if fi != nil {
+				t.Errorf("Test case %s failed, got: %+v expect: nil", tc.Name, *fi)
+			}
+			continue
+		}
+		if fi.UnSuspendDelay != tc.expect.UnSuspendDelay || fi.UnSuspendFailureRate != tc.expect.UnSuspendFailureRate ||
+			fi.EventDropRate != tc.expect.EventDropRate || fi.SnapshotStall != tc.expect.SnapshotStall {
+			t.Errorf("Test case %s failed, got: %+v expect: %+v", tc.Name, *fi, *tc.expect)
+		}
+	}
+}
+
+func TestInjectFaults(t *testing.T) {
+	var disabled *FaultInjection
+	if disabled.dropEvent() || disabled.unSuspendFault() != nil {
+		t.Errorf("Test case disabled failed, expect no fault injected")
+	}
+
+	fi := &FaultInjection{UnSuspendFailureRate: 0.5, EventDropRate: 0.2, random: func() float64 { return 0.3 }}
+	if err := fi.unSuspendFault(); !apierrors.IsServiceUnavailable(err) || !isRetriablePatchError(err) {
+		t.Errorf("Test case unsuspend failure failed, got err: %v expect a retriable error", err)
+	}
+	if fi.dropEvent() {
+		t.Errorf("Test case event drop failed, got dropped: %v expect: %v", true, false)
+	}
+}
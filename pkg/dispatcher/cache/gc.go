@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// CollectGarbage removes the cached objects which are not in the informers anymore, like the ones whose delete
+// events were missed, and the ResourceBindingInfos of them. The ResourceBindingInfos of the deleted workloads are
+// removed too, because Karmada deletes their ResourceBindings by the owner references.
+// It returns the number of the removed objects by the kind.
+func (dc *DispatcherCache) CollectGarbage() map[string]int {
+	collected := map[string]int{}
+
+	resourceBindingStore := dc.resourceBindingInformer.Informer().GetStore()
+	dc.resourceBindingMutex.Lock()
+	collected["ResourceBinding"] = collectGarbage(dc.resourceBindings, func(key types.NamespacedName) bool {
+		return inStore(resourceBindingStore, key.String())
+	})
+	// The infos are removed with their ResourceBindings, the ones left alone are stale too.
+	collected["ResourceBindingInfo"] = collectGarbage(dc.resourceBindingInfos, func(key types.NamespacedName) bool {
+		_, found := dc.resourceBindings[key]
+		return found
+	})
+	dc.resourceBindingMutex.Unlock()
+
+	podGroupStore := dc.podGroupInformer.Informer().GetStore()
+	dc.podGroupMutex.Lock()
+	collected["PodGroup"] = collectGarbage(dc.podGroups, func(key types.NamespacedName) bool {
+		return inStore(podGroupStore, key.String())
+	})
+	collectGarbage(dc.podGroupsByOwner, func(owner types.UID) bool {
+		pg := dc.podGroupsByOwner[owner]
+		_, found := dc.podGroups[types.NamespacedName{Namespace: pg.Namespace, Name: pg.Name}]
+		return found
+	})
+	dc.podGroupMutex.Unlock()
+
+	federatedResourceQuotaStore := dc.federatedResourceQuotaInformer.Informer().GetStore()
+	dc.federatedResourceQuotaMutex.Lock()
+	collected["FederatedResourceQuota"] = collectGarbage(dc.federatedResourceQuotas, func(key types.NamespacedName) bool {
+		return inStore(federatedResourceQuotaStore, key.String())
+	})
+	dc.federatedResourceQuotaMutex.Unlock()
+
+	clusterStore := dc.clusterInformer.Informer().GetStore()
+	dc.clusterMutex.Lock()
+	collected["Cluster"] = collectGarbage(dc.clusters, func(name string) bool {
+		return inStore(clusterStore, name)
+	})
+	dc.clusterMutex.Unlock()
+
+	namespaceStore := dc.namespaceInformer.Informer().GetStore()
+	dc.namespaceMutex.Lock()
+	collected["Namespace"] = collectGarbage(dc.namespaces, func(name string) bool {
+		return inStore(namespaceStore, name)
+	})
+	dc.namespaceMutex.Unlock()
+
+	for kind, count := range collected {
+		if count > 0 {
+			klog.V(2).Infof("DispatcherCache collected <%d> stale %s objects.", count, kind)
+		}
+	}
+	return collected
+}
+
+// collectGarbage deletes the entries not alive from the map, it returns the number of the deleted ones.
+func collectGarbage[K comparable, V any](entries map[K]V, alive func(K) bool) int {
+	count := 0
+	for key := range entries {
+		if !alive(key) {
+			delete(entries, key)
+			count++
+		}
+	}
+	return count
+}
+
+// inStore checks the object is in the store of the informer. The store is updated before the event handlers are
+// called, so the objects in the cache are always in it, except the ones whose delete events were missed.
+func inStore(store cache.Store, key string) bool {
+	_, found, err := store.GetByKey(key)
+	return err != nil || found
+}
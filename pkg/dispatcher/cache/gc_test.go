@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	karmadainformerfactory "github.com/karmada-io/karmada/pkg/generated/informers/externalversions"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	volcanoinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	karmadaInformerFactory := karmadainformerfactory.NewSharedInformerFactory(karmadafake.NewSimpleClientset(), 0)
+	volcanoInformerFactory := volcanoinformer.NewSharedInformerFactory(volcanofake.NewSimpleClientset(), 0)
+	informerFactory := informers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0)
+
+	dc := newTestDispatcherCache()
+	dc.resourceBindingInformer = karmadaInformerFactory.Work().V1alpha2().ResourceBindings()
+	dc.federatedResourceQuotaInformer = karmadaInformerFactory.Policy().V1alpha1().FederatedResourceQuotas()
+	dc.clusterInformer = karmadaInformerFactory.Cluster().V1alpha1().Clusters()
+	dc.podGroupInformer = volcanoInformerFactory.Scheduling().V1beta1().PodGroups()
+	dc.namespaceInformer = informerFactory.Core().V1().Namespaces()
+
+	// The alive objects are in both the informers and the cache, the stale ones are in the cache only.
+	for _, name := range []string{"alive", "stale"} {
+		key := types.NamespacedName{Namespace: "ns", Name: name}
+		rb := newTestResourceBinding("ns", name)
+		pg := &schedulingv1beta1.PodGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name,
+			OwnerReferences: []metav1.OwnerReference{{UID: types.UID(name)}}}}
+		frq := &policyv1alpha1.FederatedResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}}
+		cluster := &clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+
+		dc.resourceBindings[key] = rb
+		dc.resourceBindingInfos[key] = &api.ResourceBindingInfo{ResourceBinding: rb}
+		dc.addPodGroup(pg)
+		dc.federatedResourceQuotas[key] = frq
+		dc.clusters[name] = cluster
+		dc.namespaces[name] = namespace
+		if name == "stale" {
+			continue
+		}
+		_ = dc.resourceBindingInformer.Informer().GetStore().Add(rb)
+		_ = dc.podGroupInformer.Informer().GetStore().Add(pg)
+		_ = dc.federatedResourceQuotaInformer.Informer().GetStore().Add(frq)
+		_ = dc.clusterInformer.Informer().GetStore().Add(cluster)
+		_ = dc.namespaceInformer.Informer().GetStore().Add(namespace)
+	}
+	// The info left alone by its ResourceBinding is stale too.
+	dc.resourceBindingInfos[types.NamespacedName{Namespace: "ns", Name: "orphan"}] = &api.ResourceBindingInfo{}
+
+	collected := dc.CollectGarbage()
+	expect := map[string]int{"ResourceBinding": 1, "ResourceBindingInfo": 2, "PodGroup": 1,
+		"FederatedResourceQuota": 1, "Cluster": 1, "Namespace": 1}
+	if !reflect.DeepEqual(collected, expect) {
+		t.Errorf("Test case %s failed, got: %v expect: %v", "Collected counts", collected, expect)
+	}
+
+	alive := types.NamespacedName{Namespace: "ns", Name: "alive"}
+	if len(dc.resourceBindings) != 1 || dc.resourceBindingInfos[alive] == nil || len(dc.resourceBindingInfos) != 1 ||
+		len(dc.podGroups) != 1 || len(dc.podGroupsByOwner) != 1 || dc.podGroupsByOwner["alive"] == nil ||
+		len(dc.federatedResourceQuotas) != 1 || dc.clusters["alive"] == nil || len(dc.clusters) != 1 ||
+		dc.namespaces["alive"] == nil || len(dc.namespaces) != 1 {
+		t.Errorf("Test case %s failed, got: %d ResourceBindings %d infos %d PodGroups %d owners %d quotas %d clusters %d namespaces expect: 1 of each",
+			"Only the alive objects are kept", len(dc.resourceBindings), len(dc.resourceBindingInfos), len(dc.podGroups),
+			len(dc.podGroupsByOwner), len(dc.federatedResourceQuotas), len(dc.clusters), len(dc.namespaces))
+	}
+}
@@ -17,7 +17,12 @@ limitations under the License.
 package cache
 
 import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
 )
 
 type DispatcherCacheInterface interface {
@@ -27,7 +32,79 @@ type DispatcherCacheInterface interface {
 	// Snapshot the cache's resource.
 	Snapshot() *DispatcherCacheSnapshot
 
+	// LookupResourceBinding returns the queue and the dispatch status of the cached ResourceBinding without building
+	// a snapshot, the queue is the default one when the ResourceBinding doesn't set it.
+	LookupResourceBinding(resourceBindingKey types.NamespacedName) (string, api.DispatchStatus, bool)
+
 	// UnSuspendResourceBinding means update the ResourceBinding.spec.suspend = false,
 	// deliver it to karmada-scheduler, so finish dispatching for the ResourceBinding.
 	UnSuspendResourceBinding(resourceBindingKey types.NamespacedName)
+
+	// UnSuspendResourceBindingToCluster unsuspends the ResourceBinding toward the cluster speculatively, its scheduling
+	// result is replaced by the cluster and it's annotated, so the cluster autoscaler of the cluster can scale up for it.
+	UnSuspendResourceBindingToCluster(resourceBindingKey types.NamespacedName, cluster string)
+
+	// SuspendResourceBinding suspends the dispatched ResourceBinding again and removes its scheduling result,
+	// so Karmada removes the workload from the member clusters, it will be dispatched again later.
+	SuspendResourceBinding(resourceBindingKey types.NamespacedName)
+
+	// SuspendResourceBindingOnFailure suspends the dispatched ResourceBinding again like SuspendResourceBinding when
+	// some of the clusters it's scheduled to fail, it's not counted as a preemption. The failed clusters are recorded
+	// until it's admitted again, when Karmada reschedules the suspended ResourceBindings in the suspend mode.
+	SuspendResourceBindingOnFailure(resourceBindingKey types.NamespacedName, failedClusters []string)
+
+	// FailResourceBinding marks the dispatched ResourceBinding Failed when its workload isn't applied to the member
+	// clusters, its resources are given back and it waits in the queue to be admitted again.
+	FailResourceBinding(resourceBindingKey types.NamespacedName)
+
+	// UpdateResourceBindingCondition set the condition to the ResourceBinding's status asynchronously,
+	// it will be skipped if the condition didn't change.
+	UpdateResourceBindingCondition(resourceBindingKey types.NamespacedName, condition metav1.Condition)
+
+	// AnnotateResourceBinding sets the annotation of the ResourceBinding asynchronously,
+	// it will be skipped if the annotation didn't change.
+	AnnotateResourceBinding(resourceBindingKey types.NamespacedName, key, value string)
+
+	// ExpireWorkload handles the workload of the expired ResourceBinding by the expiry policy asynchronously,
+	// it's annotated by the `Annotate` policy and deleted by the `Delete` policy.
+	ExpireWorkload(resourceBindingKey types.NamespacedName, policy string)
+
+	// CheckConsistency compares the cache with the ResourceBindings and Queues listed from the apiserver,
+	// the differences are fixed by resyncing them from the apiserver if heal is true.
+	CheckConsistency(ctx context.Context, heal bool) (*api.ConsistencyReport, error)
+
+	// CollectGarbage removes the cached objects which are not in the informers anymore,
+	// it returns the number of the removed objects by the kind.
+	CollectGarbage() map[string]int
+
+	// WakeUp returns the channel signaled when the suspended ResourceBindings should be evaluated again
+	// without waiting for the next period, like the capability or the weight of a queue, or the taints of a cluster
+	// is changed.
+	WakeUp() <-chan struct{}
+
+	// WorkQueueDepths returns the number of the items waiting in each work queue of the cache, like the events of
+	// the ResourceBindings and the pending patches, the growing ones tell the workers can't catch up.
+	WorkQueueDepths() map[string]int
+
+	// TakeCoalescedStatusUpdates returns the number of the condition and annotation updates merged into the other
+	// ones of the same ResourceBindings since the last call, and resets it.
+	TakeCoalescedStatusUpdates() int
+
+	// TakeEventErrors returns the number of the events failed to be converted or processed by the kind since
+	// the last call, and resets them.
+	TakeEventErrors() map[string]int
+
+	// Resync replays the objects of the kind in its informer through the event handler, it returns the number of them.
+	Resync(kind string) int
+
+	// SetDefaultQueue update the queue which the workloads without queue will join.
+	SetDefaultQueue(queueName string)
+
+	// SetMinResourcesSource updates whether the min resources of the workloads are taken from their PodGroups when
+	// they set them, or resolved from the workloads always.
+	SetMinResourcesSource(source string)
+
+	// SetQueuePausedBy pauses the dispatching of the queue by the user on the annotation of the Queue, so all the
+	// replicas see it and it survives the restarts. An empty user resumes the queue.
+	SetQueuePausedBy(queueName, user string) error
 }
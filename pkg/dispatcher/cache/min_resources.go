@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+const (
+	// MinResourcesSourcePodGroup takes the `spec.minResources` of the PodGroup of the workload when it's set, instead
+	// of the ones resolved from the workload, it's the default.
+	MinResourcesSourcePodGroup = "podGroup"
+	// MinResourcesSourceWorkload always takes the min resources resolved from the workload.
+	MinResourcesSourceWorkload = "workload"
+)
+
+// SetMinResourcesSource updates the source of the min resources of the workloads with PodGroups.
+func (dc *DispatcherCache) SetMinResourcesSource(source string) {
+	ignore := source == MinResourcesSourceWorkload
+	if dc.ignorePodGroupMinResources.Swap(ignore) != ignore {
+		klog.V(3).Infof("Update the source of the min resources to <%s>.", source)
+	}
+}
+
+// setQueueMinResourcesSources remembers the sources of the min resources declared by the queues of the snapshot,
+// so the workloads are admitted with the same min resources as they are accounted in the snapshot.
+func (dc *DispatcherCache) setQueueMinResourcesSources(queues map[string]*schedulingapi.QueueInfo) {
+	sources := map[string]string{}
+	for name, queue := range queues {
+		if queue.Queue != nil && queue.Queue.Annotations[api.MinResourcesSourceAnnotationKey] != "" {
+			sources[name] = queue.Queue.Annotations[api.MinResourcesSourceAnnotationKey]
+		}
+	}
+	dc.minResourcesSourceMutex.Lock()
+	dc.queueMinResourcesSources = sources
+	dc.minResourcesSourceMutex.Unlock()
+}
+
+// preferPodGroupMinResources checks whether the min resources of the PodGroups are preferred for the workloads of the
+// queue, the source declared by the queue overrides the one of the configuration.
+func (dc *DispatcherCache) preferPodGroupMinResources(queueName string) bool {
+	dc.minResourcesSourceMutex.RLock()
+	source := dc.queueMinResourcesSources[queueName]
+	dc.minResourcesSourceMutex.RUnlock()
+	switch source {
+	case MinResourcesSourcePodGroup:
+		return true
+	case MinResourcesSourceWorkload:
+		return false
+	}
+	return !dc.ignorePodGroupMinResources.Load()
+}
+
+// setPodGroupMinResources takes the min resources of the PodGroup of the ResourceBindingInfo when they are set and
+// preferred, it returns whether they disagree with the ones resolved from the workload.
+func setPodGroupMinResources(rbi *api.ResourceBindingInfo, preferPodGroup bool) bool {
+	if rbi.PodGroup == nil || rbi.PodGroup.Spec.MinResources == nil {
+		return false
+	}
+	podGroupMinResources := *rbi.PodGroup.Spec.MinResources
+	disagreed := !equalResources(podGroupMinResources, rbi.MinResources)
+	if preferPodGroup {
+		rbi.MinResources = podGroupMinResources.DeepCopy()
+	}
+	return disagreed
+}
+
+// podGroupMinResourcesOf returns the min resources of the PodGroup of the ResourceBinding when they are set and
+// preferred by its queue, or nil. It acquires the locks of the ResourceBindings, the PodGroups and the queues one by one.
+func (dc *DispatcherCache) podGroupMinResourcesOf(key types.NamespacedName) corev1.ResourceList {
+	dc.resourceBindingMutex.RLock()
+	cached, found := dc.resourceBindingInfos[key]
+	if !found {
+		dc.resourceBindingMutex.RUnlock()
+		return nil
+	}
+	// The queue is resolved on a copy with the PodGroup, like the snapshot.
+	rbi := &api.ResourceBindingInfo{ResourceBinding: cached.ResourceBinding, WorkloadQueue: cached.WorkloadQueue}
+	dc.resourceBindingMutex.RUnlock()
+
+	dc.podGroupMutex.RLock()
+	pg, found := dc.podGroupsByOwner[rbi.ResourceBinding.Spec.Resource.UID]
+	dc.podGroupMutex.RUnlock()
+	if !found || pg.Spec.MinResources == nil {
+		return nil
+	}
+	rbi.PodGroup = pg
+
+	queueName := dc.resolveQueue(rbi)
+	if queueName == "" {
+		dc.queueMutex.RLock()
+		queueName = dc.defaultQueue
+		dc.queueMutex.RUnlock()
+	}
+	if !dc.preferPodGroupMinResources(queueName) {
+		return nil
+	}
+	return pg.Spec.MinResources.DeepCopy()
+}
+
+// equalResources compares the quantities of the resources, the missing ones are zero.
+func equalResources(a, b corev1.ResourceList) bool {
+	for name, quantity := range a {
+		if other := b[name]; quantity.Cmp(other) != 0 {
+			return false
+		}
+	}
+	for name, quantity := range b {
+		if other := a[name]; quantity.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestSetPodGroupMinResources(t *testing.T) {
+	cpu := func(value string) *corev1.ResourceList {
+		return &corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+
+	testCases := []struct {
+		Name               string
+		podGroup           *schedulingv1beta1.PodGroup
+		preferPodGroup     bool
+		expectMinResources corev1.ResourceList
+		expectDisagreed    bool
+	}{
+		{
+			Name:               "Without PodGroup",
+			preferPodGroup:     true,
+			expectMinResources: *cpu("4"),
+		},
+		{
+			Name:               "PodGroup without min resources",
+			podGroup:           &schedulingv1beta1.PodGroup{},
+			preferPodGroup:     true,
+			expectMinResources: *cpu("4"),
+		},
+		{
+			Name:               "PodGroup min resources are preferred",
+			podGroup:           &schedulingv1beta1.PodGroup{Spec: schedulingv1beta1.PodGroupSpec{MinResources: cpu("2")}},
+			preferPodGroup:     true,
+			expectMinResources: *cpu("2"),
+			expectDisagreed:    true,
+		},
+		{
+			Name:               "Workload min resources are preferred",
+			podGroup:           &schedulingv1beta1.PodGroup{Spec: schedulingv1beta1.PodGroupSpec{MinResources: cpu("2")}},
+			expectMinResources: *cpu("4"),
+			expectDisagreed:    true,
+		},
+		{
+			Name:               "Both sources agree",
+			podGroup:           &schedulingv1beta1.PodGroup{Spec: schedulingv1beta1.PodGroupSpec{MinResources: cpu("4000m")}},
+			preferPodGroup:     true,
+			expectMinResources: *cpu("4000m"),
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{PodGroup: tc.podGroup, MinResources: *cpu("4")}
+		disagreed := setPodGroupMinResources(rbi, tc.preferPodGroup)
+		if !reflect.DeepEqual(rbi.MinResources, tc.expectMinResources) || disagreed != tc.expectDisagreed {
+			t.Errorf("Test case %s failed, got: %v disagreed %v expect: %v disagreed %v",
+				tc.Name, rbi.MinResources, disagreed, tc.expectMinResources, tc.expectDisagreed)
+		}
+	}
+}
+
+func TestAdmitPodGroupMinResources(t *testing.T) {
+	dc := newTestDispatcherCache()
+	dc.unSuspendRBTaskQueue = workqueue.New()
+	key := types.NamespacedName{Namespace: "ns", Name: "rb"}
+	rb := newTestResourceBinding(key.Namespace, key.Name)
+	rb.Spec.Resource = workv1alpha2.ObjectReference{UID: "job"}
+	dc.resourceBindings[key] = rb
+	dc.resourceBindingInfos[key] = &api.ResourceBindingInfo{ResourceBinding: rb,
+		MinResources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}}
+	minResources := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}
+	dc.addPodGroup(&schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pg", OwnerReferences: []metav1.OwnerReference{{UID: "job"}}},
+		Spec:       schedulingv1beta1.PodGroupSpec{MinResources: &minResources},
+	})
+
+	testCases := []struct {
+		Name           string
+		source         string
+		queueSource    string
+		expectAdmitted string
+	}{
+		{Name: "PodGroup min resources are admitted", source: MinResourcesSourcePodGroup, expectAdmitted: "2"},
+		{Name: "Workload min resources are admitted", source: MinResourcesSourceWorkload, expectAdmitted: "4"},
+		{Name: "Queue prefers the workload min resources", source: MinResourcesSourcePodGroup, queueSource: MinResourcesSourceWorkload, expectAdmitted: "4"},
+		{Name: "Queue prefers the PodGroup min resources", source: MinResourcesSourceWorkload, queueSource: MinResourcesSourcePodGroup, expectAdmitted: "2"},
+	}
+	for _, tc := range testCases {
+		dc.SetMinResourcesSource(tc.source)
+		dc.queueMinResourcesSources = map[string]string{dc.defaultQueue: tc.queueSource}
+		dc.UnSuspendResourceBinding(key)
+		admitted := dc.resourceBindingInfos[key].AdmittedResources[corev1.ResourceCPU]
+		if admitted.String() != tc.expectAdmitted {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, admitted.String(), tc.expectAdmitted)
+		}
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package cache
 
 import (
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
 	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -75,3 +78,42 @@ func convertToResourceBinding(obj interface{}) *workv1alpha2.ResourceBinding {
 	}
 	return resourceBinding
 }
+
+func convertToFederatedResourceQuota(obj interface{}) *policyv1alpha1.FederatedResourceQuota {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	federatedResourceQuota, ok := obj.(*policyv1alpha1.FederatedResourceQuota)
+	if !ok {
+		klog.Errorf("Cant Convert obj to *policyv1alpha1.FederatedResourceQuota, obj: %v", obj)
+		return nil
+	}
+	return federatedResourceQuota
+}
+
+func convertToCluster(obj interface{}) *clusterv1alpha1.Cluster {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	cluster, ok := obj.(*clusterv1alpha1.Cluster)
+	if !ok {
+		klog.Errorf("Cant Convert obj to *clusterv1alpha1.Cluster, obj: %v", obj)
+		return nil
+	}
+	return cluster
+}
+
+func convertToNamespace(obj interface{}) *corev1.Namespace {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		klog.Errorf("Failed to convert object to *corev1.Namespace, obj: %v", obj)
+		return nil
+	}
+	return namespace
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"sort"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	policylister "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/resolver"
+)
+
+// overridePolicy is the name and the spec of an OverridePolicy or a ClusterOverridePolicy.
+type overridePolicy struct {
+	name string
+	spec *policyv1alpha1.OverrideSpec
+}
+
+// overrideListers list the OverridePolicies and the ClusterOverridePolicies, they are nil when the dispatcher
+// is not aware of the overrides.
+type overrideListers struct {
+	overridePolicyLister        policylister.OverridePolicyLister
+	clusterOverridePolicyLister policylister.ClusterOverridePolicyLister
+}
+
+// watchOverridePolicies starts watching the OverridePolicies and the ClusterOverridePolicies, they are evaluated
+// on resolving the resources of the ResourceBindings. The changes of the policies take effect when the
+// ResourceBindings are updated, like they are rescheduled.
+func (dc *DispatcherCache) watchOverridePolicies() {
+	policyInformers := dc.karmadaInformerFactor.Policy().V1alpha1()
+	// Get the informers before the factory starts, so the factory starts and syncs them.
+	policyInformers.OverridePolicies().Informer()
+	policyInformers.ClusterOverridePolicies().Informer()
+	dc.overrides = &overrideListers{
+		overridePolicyLister:        policyInformers.OverridePolicies().Lister(),
+		clusterOverridePolicyLister: policyInformers.ClusterOverridePolicies().Lister(),
+	}
+}
+
+// policies returns the ClusterOverridePolicies and then the OverridePolicies in the namespace, like the
+// karmada-controller-manager applies the cluster-scoped ones first.
+func (ol *overrideListers) policies(namespace string) ([]overridePolicy, []overridePolicy) {
+	var clusterPolicies, namespacedPolicies []overridePolicy
+	clusterOverridePolicies, err := ol.clusterOverridePolicyLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list the ClusterOverridePolicies, err: %v", err)
+	}
+	for _, policy := range clusterOverridePolicies {
+		clusterPolicies = append(clusterPolicies, overridePolicy{name: policy.Name, spec: &policy.Spec})
+	}
+	if namespace == "" {
+		return clusterPolicies, nil
+	}
+	overridePolicies, err := ol.overridePolicyLister.OverridePolicies(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list the OverridePolicies in namespace <%s>, err: %v", namespace, err)
+	}
+	for _, policy := range overridePolicies {
+		namespacedPolicies = append(namespacedPolicies, overridePolicy{name: policy.Name, spec: &policy.Spec})
+	}
+	return clusterPolicies, namespacedPolicies
+}
+
+// plaintextOverriders returns the plaintext overriders of the policies applicable to the workload in the cluster,
+// in the order of the karmada-controller-manager, the policies with the more implicit priority and then the name
+// are applied later. The other overriders never change the replicas or the resource requests, they are ignored.
+func plaintextOverriders(policies []overridePolicy, workload *unstructured.Unstructured, cluster *clusterv1alpha1.Cluster) []policyv1alpha1.PlaintextOverrider {
+	matched := make([]overridePolicy, 0, len(policies))
+	priorities := make(map[string]karmadautil.ImplicitPriority, len(policies))
+	for _, policy := range policies {
+		if len(policy.spec.ResourceSelectors) == 0 {
+			priorities[policy.name] = karmadautil.PriorityMatchAll
+		} else if karmadautil.ResourceMatchSelectors(workload, policy.spec.ResourceSelectors...) {
+			priorities[policy.name] = karmadautil.ResourceMatchSelectorsPriority(workload, policy.spec.ResourceSelectors...)
+		} else {
+			continue
+		}
+		matched = append(matched, policy)
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if priorities[matched[i].name] != priorities[matched[j].name] {
+			return priorities[matched[i].name] < priorities[matched[j].name]
+		}
+		return matched[i].name < matched[j].name
+	})
+
+	var overriders []policyv1alpha1.PlaintextOverrider
+	for _, policy := range matched {
+		rules := policy.spec.OverrideRules
+		if len(rules) == 0 {
+			//nolint:staticcheck // The deprecated fields are still served for the backward compatibility.
+			rules = []policyv1alpha1.RuleWithCluster{{TargetCluster: policy.spec.TargetCluster, Overriders: policy.spec.Overriders}}
+		}
+		for _, rule := range rules {
+			if rule.TargetCluster == nil || karmadautil.ClusterMatches(cluster, *rule.TargetCluster) {
+				overriders = append(overriders, rule.Overriders.Plaintext...)
+			}
+		}
+	}
+	return overriders
+}
+
+// applyPlaintextOverriders returns a copy of the workload applied the overriders as the JSON patches.
+func applyPlaintextOverriders(workload *unstructured.Unstructured, overriders []policyv1alpha1.PlaintextOverrider) (*unstructured.Unstructured, error) {
+	type operation struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+	operations := make([]operation, 0, len(overriders))
+	for _, overrider := range overriders {
+		operations = append(operations, operation{Op: string(overrider.Operator), Path: overrider.Path, Value: overrider.Value.Raw})
+	}
+	patchBytes, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatch.DecodePatch(patchBytes)
+	if err != nil {
+		return nil, err
+	}
+	workloadBytes, err := workload.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	patchedBytes, err := patch.Apply(workloadBytes)
+	if err != nil {
+		return nil, err
+	}
+	overridden := &unstructured.Unstructured{}
+	if err = overridden.UnmarshalJSON(patchedBytes); err != nil {
+		return nil, err
+	}
+	return overridden, nil
+}
+
+// resolveOverrides evaluates the overrides of the workload in each target cluster of the ResourceBinding. The min
+// resources are the average of the ones resolved in the target clusters weighted by their replicas, they are the
+// same as the ones without the overrides when no override applies. The replica requirements are overridden for the
+// target clusters whose overrides change the resource requests of a replica.
+func (dc *DispatcherCache) resolveOverrides(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured,
+	workloadResolver resolver.WorkloadResourceResolver, minResources corev1.ResourceList) (corev1.ResourceList, map[string]*workv1alpha2.ReplicaRequirements) {
+	if dc.overrides == nil || workload == nil || len(rb.Spec.Clusters) == 0 {
+		return minResources, nil
+	}
+
+	clusterPolicies, namespacedPolicies := dc.overrides.policies(workload.GetNamespace())
+	if len(clusterPolicies) == 0 && len(namespacedPolicies) == 0 {
+		return minResources, nil
+	}
+
+	totalReplicas := int32(0)
+	for _, target := range rb.Spec.Clusters {
+		totalReplicas += target.Replicas
+	}
+	replicaRequests, _ := resolver.ReplicaRequests(workload)
+
+	overridden := false
+	weightedResources := corev1.ResourceList{}
+	var clusterRequirements map[string]*workv1alpha2.ReplicaRequirements
+	for _, target := range rb.Spec.Clusters {
+		weight := 1 / float64(len(rb.Spec.Clusters))
+		if totalReplicas > 0 {
+			weight = float64(target.Replicas) / float64(totalReplicas)
+		}
+
+		resources := minResources
+		dc.clusterMutex.RLock()
+		cluster, found := dc.clusters[target.Name]
+		dc.clusterMutex.RUnlock()
+		if found {
+			overriders := append(plaintextOverriders(clusterPolicies, workload, cluster),
+				plaintextOverriders(namespacedPolicies, workload, cluster)...)
+			if len(overriders) > 0 {
+				clusterWorkload, err := applyPlaintextOverriders(workload, overriders)
+				if err != nil {
+					klog.Errorf("Failed to apply the overrides of cluster <%s> to ResourceBinding <%s/%s>, err: %v",
+						target.Name, rb.Namespace, rb.Name, err)
+				} else if resolved, err := workloadResolver.MinResources(rb, clusterWorkload); err != nil {
+					klog.Errorf("Failed to resolve the overridden min resources of ResourceBinding <%s/%s> in cluster <%s>, err: %v",
+						rb.Namespace, rb.Name, target.Name, err)
+				} else {
+					overridden = overridden || !equality.Semantic.DeepEqual(resolved, minResources)
+					resources = resolved
+					if requests, ok := resolver.ReplicaRequests(clusterWorkload); ok && rb.Spec.ReplicaRequirements != nil &&
+						!equality.Semantic.DeepEqual(requests, replicaRequests) {
+						if clusterRequirements == nil {
+							clusterRequirements = map[string]*workv1alpha2.ReplicaRequirements{}
+						}
+						requirements := rb.Spec.ReplicaRequirements.DeepCopy()
+						requirements.ResourceRequest = requests
+						clusterRequirements[target.Name] = requirements
+					}
+				}
+			}
+		}
+		addWeightedResources(weightedResources, resources, weight)
+	}
+	if !overridden {
+		return minResources, clusterRequirements
+	}
+	klog.V(4).Infof("The min resources of ResourceBinding <%s/%s> are overridden from %v to %v.",
+		rb.Namespace, rb.Name, minResources, weightedResources)
+	return weightedResources, clusterRequirements
+}
+
+// addWeightedResources adds the resources multiplied by the weight in [0, 1] to the list.
+func addWeightedResources(list, resources corev1.ResourceList, weight float64) {
+	for name, quantity := range resources {
+		value := list[name]
+		switch {
+		case weight >= 1:
+			value.Add(quantity)
+		// The milli value of the large quantities like the memory in bytes may overflow.
+		case quantity.Value() < 1<<40:
+			value.Add(*resource.NewMilliQuantity(int64(float64(quantity.MilliValue())*weight), quantity.Format))
+		default:
+			value.Add(*resource.NewQuantity(int64(float64(quantity.Value())*weight), quantity.Format))
+		}
+		list[name] = value
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	policylister "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/resolver"
+)
+
+func TestResolveOverrides(t *testing.T) {
+	workload := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"namespace": "ns", "name": "deploy"},
+		"spec": map[string]interface{}{
+			"replicas": int64(4),
+			"template": map[string]interface{}{"spec": map[string]interface{}{
+				"containers": []interface{}{map[string]interface{}{
+					"name":      "main",
+					"resources": map[string]interface{}{"requests": map[string]interface{}{"cpu": "1"}},
+				}},
+			}},
+		},
+	}}
+	cpuOverride := func(name, namespace string, clusters ...string) *policyv1alpha1.OverridePolicy {
+		return &policyv1alpha1.OverridePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: policyv1alpha1.OverrideSpec{
+				OverrideRules: []policyv1alpha1.RuleWithCluster{{
+					TargetCluster: &policyv1alpha1.ClusterAffinity{ClusterNames: clusters},
+					Overriders: policyv1alpha1.Overriders{Plaintext: []policyv1alpha1.PlaintextOverrider{{
+						Path:     "/spec/template/spec/containers/0/resources/requests/cpu",
+						Operator: policyv1alpha1.OverriderOpReplace,
+						Value:    apiextensionsv1.JSON{Raw: []byte(`"2"`)},
+					}}},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name                string
+		policies            []*policyv1alpha1.OverridePolicy
+		expectMinCPU        string
+		expectOverriddenCPU map[string]string
+	}{
+		{
+			Name:         "No override policy",
+			expectMinCPU: "4",
+		},
+		{
+			Name:         "Override policy in the other namespace",
+			policies:     []*policyv1alpha1.OverridePolicy{cpuOverride("op", "other", "m2")},
+			expectMinCPU: "4",
+		},
+		{
+			Name:                "Override the requests in one of the clusters",
+			policies:            []*policyv1alpha1.OverridePolicy{cpuOverride("op", "ns", "m2")},
+			expectMinCPU:        "6",
+			expectOverriddenCPU: map[string]string{"m2": "2"},
+		},
+		{
+			Name:                "Override the requests in all the clusters",
+			policies:            []*policyv1alpha1.OverridePolicy{cpuOverride("op", "ns", "m1", "m2")},
+			expectMinCPU:        "8",
+			expectOverriddenCPU: map[string]string{"m1": "2", "m2": "2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		dc := newTestDispatcherCache()
+		for _, name := range []string{"m1", "m2"} {
+			dc.clusters[name] = &clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		}
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for _, policy := range tc.policies {
+			if err := indexer.Add(policy); err != nil {
+				t.Fatalf("Test case %s failed, add policy err: %v", tc.Name, err)
+			}
+		}
+		dc.overrides = &overrideListers{
+			overridePolicyLister:        policylister.NewOverridePolicyLister(indexer),
+			clusterOverridePolicyLister: policylister.NewClusterOverridePolicyLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+		}
+
+		rb := newTestResourceBinding("ns", "deploy")
+		rb.Spec.ReplicaRequirements = &workv1alpha2.ReplicaRequirements{ResourceRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}}
+		rb.Spec.Clusters = []workv1alpha2.TargetCluster{{Name: "m1", Replicas: 2}, {Name: "m2", Replicas: 2}}
+		workloadResolver := resolver.GetResolver(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+		minResources, _ := workloadResolver.MinResources(rb, workload)
+
+		minResources, clusterRequirements := dc.resolveOverrides(rb, workload, workloadResolver, minResources)
+		if cpu := minResources[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse(tc.expectMinCPU)) != 0 {
+			t.Errorf("Test case %s failed, got min cpu: %s expect: %s", tc.Name, cpu.String(), tc.expectMinCPU)
+		}
+		if len(clusterRequirements) != len(tc.expectOverriddenCPU) {
+			t.Errorf("Test case %s failed, got overridden requirements: %v expect: %v", tc.Name, clusterRequirements, tc.expectOverriddenCPU)
+			continue
+		}
+		for cluster, expect := range tc.expectOverriddenCPU {
+			requirements, found := clusterRequirements[cluster]
+			if !found {
+				t.Errorf("Test case %s failed, the requirements of cluster %s are not overridden", tc.Name, cluster)
+				continue
+			}
+			if cpu := requirements.ResourceRequest[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse(expect)) != 0 {
+				t.Errorf("Test case %s failed, got cpu of cluster %s: %s expect: %s", tc.Name, cluster, cpu.String(), expect)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sort"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// PlacementClusters returns the ready member clusters which the placement allows the workload to land on, and whether
+// the placement excludes any ready cluster.
+func (snapshot *DispatcherCacheSnapshot) PlacementClusters(placement *policyv1alpha1.Placement) ([]*clusterv1alpha1.Cluster, bool) {
+	clusters := make([]*clusterv1alpha1.Cluster, 0, len(snapshot.Clusters))
+	excluded := false
+	for _, cluster := range snapshot.Clusters {
+		if !meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1alpha1.ClusterConditionReady) {
+			continue
+		}
+		if !PlacementMatches(placement, cluster) {
+			excluded = true
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, excluded
+}
+
+// PlacementClusterNames returns the sorted names of the PlacementClusters, they're the candidate clusters of the
+// workload before the karmada-scheduler schedules it.
+func (snapshot *DispatcherCacheSnapshot) PlacementClusterNames(placement *policyv1alpha1.Placement) []string {
+	clusters, _ := snapshot.PlacementClusters(placement)
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClusterSchedulable checks whether the capacity of the member cluster counts for the federation, the cluster which is
+// not ready, or tainted NoSchedule or NoExecute like being cordoned or drained, takes no new workloads of the queues.
+func ClusterSchedulable(cluster *clusterv1alpha1.Cluster) bool {
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1alpha1.ClusterConditionReady) {
+		return false
+	}
+	for i := range cluster.Spec.Taints {
+		if effect := cluster.Spec.Taints[i].Effect; effect == corev1.TaintEffectNoSchedule || effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+	return true
+}
+
+// PlacementMatches checks whether the workload with the placement can land on the cluster, like the karmada-scheduler
+// filters the clusters by the cluster affinity and the taints of the clusters. The nil placement matches all the
+// clusters without the NoSchedule and NoExecute taints.
+func PlacementMatches(placement *policyv1alpha1.Placement, cluster *clusterv1alpha1.Cluster) bool {
+	if placement == nil {
+		placement = &policyv1alpha1.Placement{}
+	}
+
+	// The karmada-scheduler tries the cluster affinities one by one, the workload can land on any of them.
+	switch {
+	case len(placement.ClusterAffinities) > 0:
+		matched := false
+		for _, term := range placement.ClusterAffinities {
+			if karmadautil.ClusterMatches(cluster, term.ClusterAffinity) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	case placement.ClusterAffinity != nil:
+		if !karmadautil.ClusterMatches(cluster, *placement.ClusterAffinity) {
+			return false
+		}
+	}
+
+	for i := range cluster.Spec.Taints {
+		taint := &cluster.Spec.Taints[i]
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		if !tolerates(placement.ClusterTolerations, taint) {
+			return false
+		}
+	}
+	return true
+}
+
+func tolerates(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// FreeResources sums the free resources of the clusters, which are their allocatable resources minus the allocated
+// and allocating ones. It's empty when the capacity of no cluster is known.
+func (snapshot *DispatcherCacheSnapshot) FreeResources(clusters []*clusterv1alpha1.Cluster) corev1.ResourceList {
+	free := corev1.ResourceList{}
+	for _, cluster := range clusters {
+		capacity, found := snapshot.ClusterCapacity(cluster)
+		if !found {
+			continue
+		}
+		for name, quantity := range capacity.Free() {
+			value := free[name]
+			value.Add(quantity)
+			free[name] = value
+		}
+	}
+	return free
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	policylister "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// policyQueueListers get the PropagationPolicies and the ClusterPropagationPolicies claiming the ResourceBindings,
+// for the queues declared by their `scheduling.volcano.sh/queue-name` labels. They are nil when the dispatcher is
+// not aware of the policy queues.
+type policyQueueListers struct {
+	propagationPolicyLister        policylister.PropagationPolicyLister
+	clusterPropagationPolicyLister policylister.ClusterPropagationPolicyLister
+}
+
+// watchPropagationPolicies starts watching the PropagationPolicies and the ClusterPropagationPolicies, the changes
+// of their queue labels take effect in the next snapshot.
+func (dc *DispatcherCache) watchPropagationPolicies() {
+	policyInformers := dc.karmadaInformerFactor.Policy().V1alpha1()
+	// Get the informers before the factory starts, so the factory starts and syncs them.
+	policyInformers.PropagationPolicies().Informer()
+	policyInformers.ClusterPropagationPolicies().Informer()
+	dc.policyQueues = &policyQueueListers{
+		propagationPolicyLister:        policyInformers.PropagationPolicies().Lister(),
+		clusterPropagationPolicyLister: policyInformers.ClusterPropagationPolicies().Lister(),
+	}
+}
+
+// queue returns the queue label of the policy claiming the ResourceBinding, Karmada annotates the ResourceBinding
+// with the policy. It's empty when the policy doesn't declare a queue or it's not found.
+func (pl *policyQueueListers) queue(rb *workv1alpha2.ResourceBinding) string {
+	if name := rb.Annotations[policyv1alpha1.PropagationPolicyNameAnnotation]; name != "" {
+		namespace := rb.Annotations[policyv1alpha1.PropagationPolicyNamespaceAnnotation]
+		if namespace == "" {
+			namespace = rb.Namespace
+		}
+		policy, err := pl.propagationPolicyLister.PropagationPolicies(namespace).Get(name)
+		if err != nil {
+			klog.V(4).Infof("Failed to get PropagationPolicy <%s/%s> of ResourceBinding <%s/%s>, err: %v",
+				namespace, name, rb.Namespace, rb.Name, err)
+			return ""
+		}
+		return policy.Labels[schedulingv1beta1.QueueNameAnnotationKey]
+	}
+
+	if name := rb.Annotations[policyv1alpha1.ClusterPropagationPolicyAnnotation]; name != "" {
+		policy, err := pl.clusterPropagationPolicyLister.Get(name)
+		if err != nil {
+			klog.V(4).Infof("Failed to get ClusterPropagationPolicy <%s> of ResourceBinding <%s/%s>, err: %v",
+				name, rb.Namespace, rb.Name, err)
+			return ""
+		}
+		return policy.Labels[schedulingv1beta1.QueueNameAnnotationKey]
+	}
+	return ""
+}
+
+// resolveQueue resolves the queue of the ResourceBindingInfo by the chain, the first one set is used:
+//   - The queue annotation of the workload.
+//   - The queue of its PodGroup.
+//   - The queue label of the policy claiming it, when the cache is aware of the policy queues.
+//   - The queue annotation of the ResourceBinding, which is set by the webhook to the queue of its namespace or
+//     the default queue.
+//
+// It's empty when none is set, the workload joins the default queue of the snapshot then.
+func (dc *DispatcherCache) resolveQueue(rbi *api.ResourceBindingInfo) string {
+	if rbi.WorkloadQueue != "" {
+		return rbi.WorkloadQueue
+	}
+	if rbi.PodGroup != nil && rbi.PodGroup.Spec.Queue != "" {
+		return rbi.PodGroup.Spec.Queue
+	}
+	if dc.policyQueues != nil {
+		if queueName := dc.policyQueues.queue(rbi.ResourceBinding); queueName != "" {
+			return queueName
+		}
+	}
+	return rbi.ResourceBinding.Annotations[schedulingv1beta1.QueueNameAnnotationKey]
+}
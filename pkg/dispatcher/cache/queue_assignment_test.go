@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	policylister "github.com/karmada-io/karmada/pkg/generated/listers/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestResolveQueue(t *testing.T) {
+	queueLabel := map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "policy-queue"}
+	claimedBy := map[string]string{
+		policyv1alpha1.PropagationPolicyNamespaceAnnotation: "ns",
+		policyv1alpha1.PropagationPolicyNameAnnotation:      "pp",
+	}
+	claimedByCluster := map[string]string{policyv1alpha1.ClusterPropagationPolicyAnnotation: "cpp"}
+
+	testCases := []struct {
+		Name          string
+		workloadQueue string
+		podGroupQueue string
+		annotations   map[string]string
+		policyAware   bool
+		expect        string
+	}{
+		{
+			Name:          "Workload annotation takes precedence",
+			workloadQueue: "workload-queue",
+			podGroupQueue: "podgroup-queue",
+			annotations:   claimedBy,
+			policyAware:   true,
+			expect:        "workload-queue",
+		},
+		{
+			Name:          "PodGroup takes precedence over the policy",
+			podGroupQueue: "podgroup-queue",
+			annotations:   claimedBy,
+			policyAware:   true,
+			expect:        "podgroup-queue",
+		},
+		{
+			Name:        "PropagationPolicy label",
+			annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "namespace-queue", policyv1alpha1.PropagationPolicyNamespaceAnnotation: "ns", policyv1alpha1.PropagationPolicyNameAnnotation: "pp"},
+			policyAware: true,
+			expect:      "policy-queue",
+		},
+		{
+			Name:        "ClusterPropagationPolicy label",
+			annotations: claimedByCluster,
+			policyAware: true,
+			expect:      "policy-queue",
+		},
+		{
+			Name:        "Not aware of the policy queues",
+			annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "namespace-queue", policyv1alpha1.PropagationPolicyNamespaceAnnotation: "ns", policyv1alpha1.PropagationPolicyNameAnnotation: "pp"},
+			expect:      "namespace-queue",
+		},
+		{
+			Name:        "Policy not found",
+			annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "namespace-queue", policyv1alpha1.PropagationPolicyNamespaceAnnotation: "ns", policyv1alpha1.PropagationPolicyNameAnnotation: "missing"},
+			policyAware: true,
+			expect:      "namespace-queue",
+		},
+	}
+
+	for _, tc := range testCases {
+		dc := newTestDispatcherCache()
+		if tc.policyAware {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			clusterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if err := indexer.Add(&policyv1alpha1.PropagationPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pp", Labels: queueLabel}}); err != nil {
+				t.Fatalf("Test case %s failed, add policy err: %v", tc.Name, err)
+			}
+			if err := clusterIndexer.Add(&policyv1alpha1.ClusterPropagationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cpp", Labels: queueLabel}}); err != nil {
+				t.Fatalf("Test case %s failed, add policy err: %v", tc.Name, err)
+			}
+			dc.policyQueues = &policyQueueListers{
+				propagationPolicyLister:        policylister.NewPropagationPolicyLister(indexer),
+				clusterPropagationPolicyLister: policylister.NewClusterPropagationPolicyLister(clusterIndexer),
+			}
+		}
+
+		rb := newTestResourceBinding("ns", "rb")
+		rb.Annotations = tc.annotations
+		rbi := &api.ResourceBindingInfo{ResourceBinding: rb, WorkloadQueue: tc.workloadQueue}
+		if tc.podGroupQueue != "" {
+			rbi.PodGroup = &schedulingv1beta1.PodGroup{Spec: schedulingv1beta1.PodGroupSpec{Queue: tc.podGroupQueue}}
+		}
+
+		if got := dc.resolveQueue(rbi); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, got, tc.expect)
+		}
+	}
+}
@@ -19,31 +19,89 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"time"
 
 	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
 	"gomodules.xyz/jsonpatch/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/utils"
 )
 
+// unSuspendFieldManager is the field manager of the dispatcher unsuspending the ResourceBindings.
+const unSuspendFieldManager = "volcano-global-dispatcher"
+
+func (dc *DispatcherCache) LookupResourceBinding(key types.NamespacedName) (string, api.DispatchStatus, bool) {
+	dc.resourceBindingMutex.RLock()
+	cached, ok := dc.resourceBindingInfos[key]
+	if !ok {
+		dc.resourceBindingMutex.RUnlock()
+		return "", 0, false
+	}
+	// The queue is resolved on a copy with the PodGroup, like the snapshot.
+	rbi := &api.ResourceBindingInfo{ResourceBinding: cached.ResourceBinding, WorkloadQueue: cached.WorkloadQueue}
+	status := cached.DispatchStatus
+	dc.resourceBindingMutex.RUnlock()
+
+	dc.podGroupMutex.RLock()
+	rbi.PodGroup = dc.podGroupsByOwner[rbi.ResourceBinding.Spec.Resource.UID]
+	dc.podGroupMutex.RUnlock()
+
+	queueName := dc.resolveQueue(rbi)
+	if queueName == "" {
+		dc.queueMutex.RLock()
+		queueName = dc.defaultQueue
+		dc.queueMutex.RUnlock()
+	}
+	return queueName, status, true
+}
+
 func (dc *DispatcherCache) UnSuspendResourceBinding(key types.NamespacedName) {
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
-	rbi, ok := dc.resourceBindingInfos[key.Namespace][key.Name]
+	dc.admitResourceBinding(key, "")
+}
+
+func (dc *DispatcherCache) UnSuspendResourceBindingToCluster(key types.NamespacedName, cluster string) {
+	dc.admitResourceBinding(key, cluster)
+}
+
+// admitResourceBinding queues the unsuspending task of the ResourceBinding, it's released toward the speculative
+// cluster when it's set.
+func (dc *DispatcherCache) admitResourceBinding(key types.NamespacedName, speculativeCluster string) {
+	// The min resources of the PodGroup are admitted if they are preferred, like the ones in the snapshot.
+	podGroupMinResources := dc.podGroupMinResourcesOf(key)
+
+	dc.resourceBindingMutex.Lock()
+	defer dc.resourceBindingMutex.Unlock()
+	rbi, ok := dc.resourceBindingInfos[key]
 	if !ok {
 		klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
 		return
 	}
-	// Update the ResourceBindingInfo status to UnSuspending.
-	rbi.DispatchStatus = api.UnSuspending
+	// Update the ResourceBindingInfo status to Admitted.
+	now := time.Now()
+	rbi.SetDispatchStatus(api.Admitted, now)
+	rbi.UnSuspendTime = now
+	rbi.AdmittedResources = rbi.MinResources
+	if podGroupMinResources != nil {
+		rbi.AdmittedResources = podGroupMinResources
+	}
+	rbi.SpeculativeCluster = speculativeCluster
+	rbi.FailedPlacement = nil
 	dc.unSuspendRBTaskQueue.Add(key)
 	klog.V(3).Infof("Add unsuspend ResourceBinding(%s) task to the unSuspendRBTaskQueue queue.", key)
 }
 
-// Its worker for update ResourceBinding.spec.suspend = false.
+// Its worker for update ResourceBinding.spec.suspend = false, there are unSuspendParallelism workers patching
+// concurrently, and the workqueue coalesces the tasks of the same ResourceBinding.
 func (dc *DispatcherCache) unSuspendResourceBindingTaskWorker() {
 	for {
 		// Wait the queue receive a task, convert to NamespacedName.
@@ -52,61 +110,410 @@ func (dc *DispatcherCache) unSuspendResourceBindingTaskWorker() {
 			return
 		}
 
-		dc.mutex.Lock()
+		dc.resourceBindingMutex.Lock()
 		key := obj.(types.NamespacedName)
-		rbi, ok := dc.resourceBindingInfos[key.Namespace][key.Name]
+		rbi, ok := dc.resourceBindingInfos[key]
 		if !ok {
 			klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
-			dc.mutex.Unlock()
-			break
+			dc.resourceBindingMutex.Unlock()
+			dc.unSuspendRBTaskQueue.Done(key)
+			continue
 		}
-		dc.mutex.Unlock()
+		rb := rbi.ResourceBinding
+		speculativeCluster := rbi.SpeculativeCluster
+		rbi.SetDispatchStatus(api.Dispatching, time.Now())
+		dc.resourceBindingMutex.Unlock()
 
 		klog.V(5).Infof("Start to patch ResourceBinding <%s/%s>.", key.Namespace, key.Name)
-		go dc.unSuspendResourceBinding(rbi.ResourceBinding)
+		dc.unSuspendResourceBinding(rb, speculativeCluster)
 	}
 }
 
 // Try to update the suspend field, if failed add to err task queue.
-func (dc *DispatcherCache) unSuspendResourceBinding(rb *workv1alpha2.ResourceBinding) {
+func (dc *DispatcherCache) unSuspendResourceBinding(rb *workv1alpha2.ResourceBinding, speculativeCluster string) {
 	key := types.NamespacedName{
 		Namespace: rb.Namespace,
 		Name:      rb.Name,
 	}
 
-	if err := dc.patchUnSuspendResourceBinding(rb); err != nil {
-		klog.Errorf("Failed to patch ResourceBinding <%s/%s>, update to Suspended status for next dispath round, err: %v",
+	var err error
+	// Move the ResourceBinding to the speculative cluster before it's released.
+	if speculativeCluster != "" {
+		err = dc.patchSpeculativeCluster(rb, speculativeCluster)
+	}
+	// Release the workload before the ResourceBinding, so the ResourceBinding stays suspended when it fails.
+	if err == nil && dc.suspendMode == utils.SuspendModeWorkload {
+		err = dc.patchWorkloadHold(rb, false)
+	}
+	if err == nil {
+		err = dc.patchUnSuspendResourceBinding(rb)
+	}
+	if err != nil {
+		klog.Errorf("Failed to patch ResourceBinding <%s/%s>, update to Failed status for next dispath round, err: %v",
 			key.Namespace, key.Name, err)
+	}
 
-		dc.mutex.Lock()
-		rbi, ok := dc.resourceBindingInfos[key.Namespace][key.Name]
-		if !ok {
-			klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
-		} else {
-			// Recover the ResourceBindingInfo status to Suspended, wait for the next dispatch.
-			rbi.DispatchStatus = api.Suspended
-		}
-		dc.mutex.Unlock()
+	dc.resourceBindingMutex.Lock()
+	rbi, ok := dc.resourceBindingInfos[key]
+	switch {
+	case !ok:
+		klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
+	case err != nil:
+		// Update the ResourceBindingInfo status to Failed, wait for the next dispatch.
+		rbi.SetDispatchStatus(api.Failed, time.Now())
+		rbi.Failures++
+	case !utils.IsResourceBindingSuspended(rbi.ResourceBinding):
+		// The unsuspended ResourceBinding is observed already, or it didn't need patching.
+		rbi.SetDispatchStatus(api.Dispatched, time.Now())
 	}
+	dc.resourceBindingMutex.Unlock()
 
 	dc.unSuspendRBTaskQueue.Done(key)
 }
 
-func (dc *DispatcherCache) patchUnSuspendResourceBinding(rb *workv1alpha2.ResourceBinding) error {
-	patchBytes, _ := json.Marshal([]jsonpatch.Operation{
-		{Operation: "replace", Path: "/spec/suspend", Value: false},
-	})
+// patchSpeculativeCluster replaces the scheduling result of the ResourceBinding by all the replicas in the cluster,
+// and annotates it with the cluster for the cluster autoscalers.
+func (dc *DispatcherCache) patchSpeculativeCluster(rb *workv1alpha2.ResourceBinding, cluster string) error {
+	patch := []jsonpatch.Operation{
+		{Operation: "add", Path: "/spec/clusters", Value: []workv1alpha2.TargetCluster{{Name: cluster, Replicas: rb.Spec.Replicas}}},
+	}
+	if rb.Annotations == nil {
+		patch = append(patch, jsonpatch.Operation{Operation: "add", Path: "/metadata/annotations",
+			Value: map[string]string{api.SpeculativeReleaseAnnotationKey: cluster}})
+	} else {
+		patch = append(patch, jsonpatch.Operation{Operation: "add",
+			Path: "/metadata/annotations/" + strings.ReplaceAll(api.SpeculativeReleaseAnnotationKey, "/", "~1"), Value: cluster})
+	}
+	patchBytes, _ := json.Marshal(patch)
 
-	// Patch the ResourceBinding.spec.suspend = false.
 	_, err := dc.karmadaClient.WorkV1alpha2().ResourceBindings(rb.Namespace).Patch(context.TODO(),
 		rb.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	if err != nil {
+		klog.Errorf("Failed to patch the speculative cluster %s of ResourceBinding <%s/%s>, err: %v", cluster, rb.Namespace, rb.Name, err)
+	} else {
+		klog.V(3).Infof("Success patch the speculative cluster %s of ResourceBinding <%s/%s>.", cluster, rb.Namespace, rb.Name)
+	}
+	return err
+}
+
+func (dc *DispatcherCache) patchUnSuspendResourceBinding(rb *workv1alpha2.ResourceBinding) error {
+	// Apply the suspend field by the dedicated field manager, so it doesn't conflict with the updates of the others.
+	patchType, patchOptions := types.ApplyPatchType, metav1.PatchOptions{FieldManager: unSuspendFieldManager, Force: ptr.To(true)}
+	patchBytes := utils.BuildUnSuspendApplyPatch(rb, dc.suspendMode)
+	if patchBytes == nil {
+		patch := utils.BuildSuspendPatch(rb, dc.suspendMode, false)
+		if len(patch) == 0 {
+			klog.V(3).Infof("ResourceBinding <%s/%s> is not suspended by %s, skip patching.", rb.Namespace, rb.Name, dc.suspendMode)
+			return nil
+		}
+		patchType, patchOptions = types.JSONPatchType, metav1.PatchOptions{FieldManager: unSuspendFieldManager}
+		patchBytes, _ = json.Marshal(patch)
+	}
+
+	// Retry the transient errors in place, instead of waiting for the next dispatching round to admit it again.
+	err := retry.OnError(retry.DefaultBackoff, isRetriablePatchError, func() error {
+		if err := dc.faults.unSuspendFault(); err != nil {
+			return err
+		}
+		_, err := dc.karmadaClient.WorkV1alpha2().ResourceBindings(rb.Namespace).Patch(context.TODO(),
+			rb.Name, patchType, patchBytes, patchOptions)
+		return err
+	})
 
 	if err != nil {
 		klog.Errorf("Failed to patch/continue ResourceBinding <%s/%s>, err: %v",
-			rb.Namespace, rb.Namespace, err)
+			rb.Namespace, rb.Name, err)
 	} else {
 		klog.V(3).Infof("Success patch/continue ResourceBinding <%s/%s>.",
-			rb.Namespace, rb.Namespace)
+			rb.Namespace, rb.Name)
+	}
+	return err
+}
+
+// patchWorkloadHold holds or releases the workload of the ResourceBinding by its own field in the
+// SuspendModeWorkload, the workloads of the kinds which can't be held are skipped.
+func (dc *DispatcherCache) patchWorkloadHold(rb *workv1alpha2.ResourceBinding, hold bool) error {
+	ref := rb.Spec.Resource
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return err
+	}
+	path, found := utils.WorkloadHoldPath(schema.GroupKind{Group: gv.Group, Kind: ref.Kind})
+	if !found {
+		return nil
+	}
+	mapping, err := dc.restMapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return err
+	}
+	workloadClient := dc.dynamicClient.Resource(mapping.Resource).Namespace(ref.Namespace)
+	workload, err := workloadClient.Get(context.TODO(), ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// The workload released already is not held again, and the one held by the user is never released.
+	if utils.IsWorkloadHeld(workload) == hold {
+		return nil
+	}
+	patchBytes, _ := json.Marshal(utils.BuildWorkloadHoldPatch(workload, path, hold))
+
+	_, err = workloadClient.Patch(context.TODO(), ref.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	if err != nil {
+		klog.Errorf("Failed to patch the hold of %s <%s/%s> to %v, err: %v", ref.Kind, ref.Namespace, ref.Name, hold, err)
+	} else {
+		klog.V(3).Infof("Success patch the hold of %s <%s/%s> to %v.", ref.Kind, ref.Namespace, ref.Name, hold)
 	}
 	return err
 }
+
+// isRetriablePatchError returns whether the patch failed by a transient error of the apiserver.
+func isRetriablePatchError(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err)
+}
+
+func (dc *DispatcherCache) SuspendResourceBinding(key types.NamespacedName) {
+	dc.resuspendResourceBinding(key, true, nil)
+}
+
+func (dc *DispatcherCache) SuspendResourceBindingOnFailure(key types.NamespacedName, failedClusters []string) {
+	// Karmada doesn't schedule the ResourceBindings suspended by `spec.suspend`, the removed scheduling result is
+	// only given again after it's dispatched, so there is no placement to wait for.
+	if dc.suspendMode == utils.SuspendModeSuspend {
+		failedClusters = nil
+	}
+	dc.resuspendResourceBinding(key, false, failedClusters)
+}
+
+// resuspendResourceBinding queues the suspending task of the dispatched ResourceBinding, it's counted as a preemption
+// when preempted is set.
+func (dc *DispatcherCache) resuspendResourceBinding(key types.NamespacedName, preempted bool, failedPlacement []string) {
+	dc.resourceBindingMutex.Lock()
+	defer dc.resourceBindingMutex.Unlock()
+	rbi, ok := dc.resourceBindingInfos[key]
+	if !ok {
+		klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
+		return
+	}
+	// Update the ResourceBindingInfo status to Preempted, so it waits in the queue again.
+	now := time.Now()
+	rbi.SetDispatchStatus(api.Preempted, now)
+	if preempted {
+		rbi.Preemptions++
+	}
+	rbi.FailedPlacement = failedPlacement
+	rbi.EnqueueTime = now
+	rbi.UnSuspendTime = time.Time{}
+	rbi.AdmittedResources = nil
+	rbi.AdmittedClusters = nil
+	rbi.SpeculativeCluster = ""
+	rbi.Unadmitted = false
+	dc.suspendRBTaskQueue.Add(key)
+	klog.V(3).Infof("Add suspend ResourceBinding(%s) task to the suspendRBTaskQueue queue.", key)
+}
+
+func (dc *DispatcherCache) FailResourceBinding(key types.NamespacedName) {
+	dc.resourceBindingMutex.Lock()
+	defer dc.resourceBindingMutex.Unlock()
+	rbi, ok := dc.resourceBindingInfos[key]
+	if !ok {
+		klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
+		return
+	}
+	if rbi.DispatchStatus != api.Dispatched {
+		return
+	}
+	// The ResourceBinding is kept Failed until its workload is applied, or it's admitted again.
+	now := time.Now()
+	rbi.SetDispatchStatus(api.Failed, now)
+	rbi.Failures++
+	rbi.EnqueueTime = now
+	rbi.UnSuspendTime = time.Time{}
+	rbi.AdmittedResources = nil
+	rbi.AdmittedClusters = nil
+	rbi.SpeculativeCluster = ""
+	klog.V(3).Infof("ResourceBinding <%s/%s> is not applied to the member clusters, update to Failed status.", key.Namespace, key.Name)
+}
+
+// Its worker for suspending the ResourceBindings again.
+func (dc *DispatcherCache) suspendResourceBindingTaskWorker() {
+	for {
+		obj, shutdown := dc.suspendRBTaskQueue.Get()
+		if shutdown {
+			return
+		}
+		key := obj.(types.NamespacedName)
+
+		dc.resourceBindingMutex.RLock()
+		rbi, ok := dc.resourceBindingInfos[key]
+		var rb *workv1alpha2.ResourceBinding
+		if ok {
+			rb = rbi.ResourceBinding
+		}
+		dc.resourceBindingMutex.RUnlock()
+
+		if !ok {
+			klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
+		} else if err := dc.patchSuspendResourceBinding(rb); err != nil {
+			dc.resourceBindingMutex.Lock()
+			if rbi, ok := dc.resourceBindingInfos[key]; ok {
+				// Recover the ResourceBindingInfo status, it's still running in the member clusters.
+				rbi.SetDispatchStatus(api.Dispatched, time.Now())
+			}
+			dc.resourceBindingMutex.Unlock()
+		}
+		dc.suspendRBTaskQueue.Done(key)
+	}
+}
+
+func (dc *DispatcherCache) patchSuspendResourceBinding(rb *workv1alpha2.ResourceBinding) error {
+	if dc.suspendMode == utils.SuspendModeWorkload {
+		if err := dc.patchWorkloadHold(rb, true); err != nil {
+			return err
+		}
+	}
+	patch := utils.BuildSuspendPatch(rb, dc.suspendMode, true)
+	// Remove the scheduling result, Karmada removes the Works of the clusters which are not in it.
+	if len(rb.Spec.Clusters) > 0 {
+		patch = append(patch, jsonpatch.Operation{Operation: "remove", Path: "/spec/clusters"})
+	}
+	// The speculation ends with the scheduling result.
+	if _, found := rb.Annotations[api.SpeculativeReleaseAnnotationKey]; found {
+		patch = append(patch, jsonpatch.Operation{Operation: "remove",
+			Path: "/metadata/annotations/" + strings.ReplaceAll(api.SpeculativeReleaseAnnotationKey, "/", "~1")})
+	}
+	patchBytes, _ := json.Marshal(patch)
+
+	_, err := dc.karmadaClient.WorkV1alpha2().ResourceBindings(rb.Namespace).Patch(context.TODO(),
+		rb.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	if err != nil {
+		klog.Errorf("Failed to patch/suspend ResourceBinding <%s/%s>, err: %v", rb.Namespace, rb.Name, err)
+	} else {
+		klog.V(3).Infof("Success patch/suspend ResourceBinding <%s/%s>.", rb.Namespace, rb.Name)
+	}
+	return err
+}
+
+func (dc *DispatcherCache) UpdateResourceBindingCondition(key types.NamespacedName, condition metav1.Condition) {
+	dc.resourceBindingMutex.RLock()
+	defer dc.resourceBindingMutex.RUnlock()
+	rbi, ok := dc.resourceBindingInfos[key]
+	if !ok {
+		klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
+		return
+	}
+
+	existingCondition := meta.FindStatusCondition(rbi.ResourceBinding.Status.Conditions, condition.Type)
+	if existingCondition == nil && condition.Status == metav1.ConditionTrue {
+		// The ResourceBinding has never been blocked, no need to tell it's dispatched.
+		return
+	}
+	if existingCondition != nil && existingCondition.Status == condition.Status &&
+		existingCondition.Reason == condition.Reason && existingCondition.Message == condition.Message {
+		return
+	}
+
+	dc.statusWriter.updateCondition(key, condition)
+}
+
+func (dc *DispatcherCache) AnnotateResourceBinding(key types.NamespacedName, annotationKey, value string) {
+	dc.resourceBindingMutex.RLock()
+	defer dc.resourceBindingMutex.RUnlock()
+	rbi, ok := dc.resourceBindingInfos[key]
+	if !ok {
+		klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
+		return
+	}
+	if existing, found := rbi.ResourceBinding.Annotations[annotationKey]; found && existing == value {
+		return
+	}
+
+	dc.statusWriter.annotate(key, annotationKey, value)
+}
+
+// maxExpireRetries is the max times to retry expiring a workload before the task is dropped.
+const maxExpireRetries = 5
+
+// expireTask is the task of expiring the workload of a ResourceBinding by the expiry policy.
+type expireTask struct {
+	key    types.NamespacedName
+	ref    workv1alpha2.ObjectReference
+	policy string
+}
+
+func (dc *DispatcherCache) ExpireWorkload(key types.NamespacedName, policy string) {
+	dc.resourceBindingMutex.RLock()
+	rbi, ok := dc.resourceBindingInfos[key]
+	if !ok {
+		dc.resourceBindingMutex.RUnlock()
+		klog.Errorf("ResourceBindingInfo <%s/%s> not found in cache.", key.Namespace, key.Name)
+		return
+	}
+	ref := rbi.ResourceBinding.Spec.Resource
+	dc.resourceBindingMutex.RUnlock()
+
+	dc.expireTaskQueue.Add(expireTask{key: key, ref: ref, policy: policy})
+	klog.V(3).Infof("Add expire workload %s <%s/%s> task to the expireTaskQueue queue.", ref.Kind, ref.Namespace, ref.Name)
+}
+
+// Its worker for expiring the workloads, the tasks failed by the transient errors are retried by the rate limiter.
+func (dc *DispatcherCache) expireWorkloadTaskWorker() {
+	for {
+		obj, shutdown := dc.expireTaskQueue.Get()
+		if shutdown {
+			return
+		}
+
+		task := obj.(expireTask)
+		err := dc.expireWorkload(task.ref, task.policy)
+		switch {
+		case err == nil:
+			klog.V(3).Infof("Success expire workload %s <%s/%s> by policy %s.", task.ref.Kind, task.ref.Namespace, task.ref.Name, task.policy)
+			dc.expireTaskQueue.Forget(obj)
+		case isRetriablePatchError(err) && dc.expireTaskQueue.NumRequeues(obj) < maxExpireRetries:
+			klog.V(3).Infof("Failed to expire workload %s <%s/%s>, retry it later, err: %v", task.ref.Kind, task.ref.Namespace, task.ref.Name, err)
+			dc.expireTaskQueue.AddRateLimited(obj)
+		default:
+			klog.Errorf("Failed to expire workload %s <%s/%s> of ResourceBinding <%s/%s> by policy %s, err: %v",
+				task.ref.Kind, task.ref.Namespace, task.ref.Name, task.key.Namespace, task.key.Name, task.policy, err)
+			dc.expireTaskQueue.Forget(obj)
+		}
+		dc.expireTaskQueue.Done(obj)
+	}
+}
+
+func (dc *DispatcherCache) expireWorkload(ref workv1alpha2.ObjectReference, policy string) error {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return err
+	}
+	mapping, err := dc.restMapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return err
+	}
+	client := dc.dynamicClient.Resource(mapping.Resource).Namespace(ref.Namespace)
+
+	switch policy {
+	case api.ExpiryPolicyAnnotate:
+		patchBytes, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"annotations": map[string]string{
+				api.ExpiredAnnotationKey: time.Now().Format(time.RFC3339),
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = client.Patch(context.TODO(), ref.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	case api.ExpiryPolicyDelete:
+		err := client.Delete(context.TODO(), ref.Name, metav1.DeleteOptions{PropagationPolicy: ptr.To(metav1.DeletePropagationBackground)})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
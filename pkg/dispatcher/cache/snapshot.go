@@ -17,12 +17,20 @@ limitations under the License.
 package cache
 
 import (
+	"sync"
+	"sync/atomic"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 
 	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/clustercapacity"
 )
 
 type DispatcherCacheSnapshot struct {
@@ -31,65 +39,280 @@ type DispatcherCacheSnapshot struct {
 	QueueInfos   map[string]*schedulingapi.QueueInfo
 
 	ResourceBindingInfos map[types.UID]*api.ResourceBindingInfo
+
+	// The map of the namespace to its FederatedResourceQuotas.
+	FederatedResourceQuotas map[string][]*policyv1alpha1.FederatedResourceQuota
+
+	// The map of the member Cluster name to Cluster.
+	Clusters map[string]*clusterv1alpha1.Cluster
+
+	// CapacityProvider provides the capacity of the Clusters, it's the status of the Clusters when it's nil.
+	CapacityProvider clustercapacity.CapacityProvider
+
+	// The map of the Namespace name to Namespace, they are shared with the cache and must not be changed.
+	Namespaces map[string]*corev1.Namespace
+
+	// The map of the PriorityClass name to PriorityClass, they are shared with the cache and must not be changed.
+	PriorityClasses map[string]*schedulingv1.PriorityClass
+
+	// MinResourcesDisagreements is the number of the workloads whose PodGroups set the min resources different
+	// from the ones resolved from the workloads.
+	MinResourcesDisagreements int
 }
 
-func (dc *DispatcherCache) Snapshot() *DispatcherCacheSnapshot {
-	dc.mutex.Lock()
-	defer dc.mutex.Unlock()
+// snapshotPool reuses the containers of the released snapshots, the cleared maps keep their buckets,
+// so the snapshots of the next rounds don't grow them again.
+var snapshotPool = sync.Pool{New: func() interface{} { return &DispatcherCacheSnapshot{} }}
+
+// podGroupMapPool reuses the maps of the PodGroups by their owners, which only live in a snapshotting.
+var podGroupMapPool = sync.Pool{New: func() interface{} { return map[types.UID]*schedulingv1beta1.PodGroup(nil) }}
 
-	snapshot := &DispatcherCacheSnapshot{
-		DefaultQueue:         dc.defaultQueue,
-		QueueInfos:           make(map[string]*schedulingapi.QueueInfo, len(dc.queues)),
-		ResourceBindingInfos: make(map[types.UID]*api.ResourceBindingInfo),
+// newSnapshot gets the snapshot from the pool, the maps which are not reused are pre-sized by the last snapshot.
+func (dc *DispatcherCache) newSnapshot() *DispatcherCacheSnapshot {
+	snapshot := snapshotPool.Get().(*DispatcherCacheSnapshot)
+	if snapshot.QueueInfos == nil {
+		snapshot.QueueInfos = make(map[string]*schedulingapi.QueueInfo, dc.lastSnapshotSize.queues.Load())
 	}
+	if snapshot.ResourceBindingInfos == nil {
+		snapshot.ResourceBindingInfos = make(map[types.UID]*api.ResourceBindingInfo, dc.lastSnapshotSize.resourceBindings.Load())
+	}
+	if snapshot.FederatedResourceQuotas == nil {
+		snapshot.FederatedResourceQuotas = make(map[string][]*policyv1alpha1.FederatedResourceQuota, dc.lastSnapshotSize.federatedResourceQuotas.Load())
+	}
+	if snapshot.Clusters == nil {
+		snapshot.Clusters = make(map[string]*clusterv1alpha1.Cluster, dc.lastSnapshotSize.clusters.Load())
+	}
+	if snapshot.Namespaces == nil {
+		snapshot.Namespaces = make(map[string]*corev1.Namespace, dc.lastSnapshotSize.namespaces.Load())
+	}
+	return snapshot
+}
+
+// Release clears the snapshot and puts its containers back to the pool for the next snapshots. The snapshot must
+// not be used after releasing, but the objects in it are not affected. The snapshot which is not released is
+// collected by the GC as usual.
+func (snapshot *DispatcherCacheSnapshot) Release() {
+	snapshot.DefaultQueue = ""
+	snapshot.MinResourcesDisagreements = 0
+	snapshot.PriorityClasses = nil
+	snapshot.CapacityProvider = nil
+	clear(snapshot.QueueInfos)
+	clear(snapshot.ResourceBindingInfos)
+	clear(snapshot.FederatedResourceQuotas)
+	clear(snapshot.Clusters)
+	clear(snapshot.Namespaces)
+	snapshotPool.Put(snapshot)
+}
 
+func (dc *DispatcherCache) Snapshot() *DispatcherCacheSnapshot {
+	dc.faults.stallSnapshot()
+	snapshot := dc.newSnapshot()
+
+	dc.queueMutex.RLock()
+	snapshot.DefaultQueue = dc.defaultQueue
 	for _, queue := range dc.queues {
 		snapshot.QueueInfos[queue.Name] = queue.Clone()
 	}
+	dc.queueMutex.RUnlock()
 
-	// Collect the PodGroups for ResourceBindingInfo.
-	// The map key was the PodGroup source resource's UID (like Deployment, Pod, volcano-job).
-	// A PodGroup may have multiple OwnerReference, we need to find the binding ResourceBinding by the map.
-	podGroupMap := map[types.UID]*schedulingv1beta1.PodGroup{}
-	for _, podGroups := range dc.podGroups {
-		for _, podGroup := range podGroups {
-			for _, ownerRef := range podGroup.OwnerReferences {
-				podGroupMap[ownerRef.UID] = podGroup
-			}
-		}
+	dc.dispatchPolicyMutex.RLock()
+	applyDispatchPolicies(snapshot.QueueInfos, dc.dispatchPolicies)
+	dc.dispatchPolicyMutex.RUnlock()
+	dc.setQueueMinResourcesSources(snapshot.QueueInfos)
+
+	// Collect the PodGroups for ResourceBindingInfo by the UID of their source resources (like Deployment, Pod,
+	// volcano-job), which is the owner of the PodGroup.
+	podGroupMap := podGroupMapPool.Get().(map[types.UID]*schedulingv1beta1.PodGroup)
+	if podGroupMap == nil {
+		podGroupMap = make(map[types.UID]*schedulingv1beta1.PodGroup, dc.lastSnapshotSize.podGroupOwners.Load())
+	}
+	defer func() {
+		clear(podGroupMap)
+		podGroupMapPool.Put(podGroupMap)
+	}()
+	dc.podGroupMutex.RLock()
+	for ownerUID, podGroup := range dc.podGroupsByOwner {
+		podGroupMap[ownerUID] = podGroup
+	}
+	dc.podGroupMutex.RUnlock()
+
+	dc.priorityClassMutex.RLock()
+	priorityClasses := make(map[string]*schedulingv1.PriorityClass, len(dc.priorityClasses))
+	for name, priorityClass := range dc.priorityClasses {
+		priorityClasses[name] = priorityClass
 	}
+	defaultPriorityClass := dc.defaultPriorityClass
+	dc.priorityClassMutex.RUnlock()
+	snapshot.PriorityClasses = priorityClasses
 
 	// Collect the ResourceBindingInfos.
-	// First, we should update the elements of the ResourceBindingInfo,
-	// because we only set some elements of them when creating the ResourceBindingInfo.
-	for _, resourceBindingInfoMap := range dc.resourceBindingInfos {
-		for _, rbi := range resourceBindingInfoMap {
-			// Collect the priority and PodGroup, only the Deployment, Pod and volcano-job will create PodGroup,
-			// So the PodGroup field may be nil.
-			rbi.Priority = 0
-			if dc.defaultPriorityClass != nil {
-				rbi.Priority = dc.defaultPriorityClass.Value
-			}
+	// The cache only saves some elements of the ResourceBindingInfo, we should set the others on the copy,
+	// the ResourceBindingInfos in the cache can't be changed by the snapshots under the read lock.
+	dc.resourceBindingMutex.RLock()
+	for _, cached := range dc.resourceBindingInfos {
+		rbi := cached.DeepCopy()
+		// Collect the priority and PodGroup, only the Deployment, Pod and volcano-job will create PodGroup,
+		// So the PodGroup field may be nil.
+		// The priority is resolved from the PriorityClass of the PodGroup, or the ResourceBinding's ReplicaRequirements.
+		// The queue is resolved by resolveQueue with the PodGroup.
+		// The min resources are taken from the PodGroup if it sets them and they are preferred by the queue.
+		rbi.PodGroup = nil
 
-			// Try find the binding PodGroup.
-			if pg, ok := podGroupMap[rbi.ResourceBinding.Spec.Resource.UID]; ok {
-				if pcName := pg.Spec.PriorityClassName; pcName != "" {
-					if dc.priorityClasses[pcName] == nil {
-						// It shouldn't happen. All the PriorityClass should in the cache.
-						klog.Errorf("PriorityClass <%s> not found in the cache when execute PodGroup <%s/%s>.",
-							pcName, pg.Namespace, pg.Name)
-					} else {
-						rbi.Priority = dc.priorityClasses[pcName].Value
-					}
-				}
-				rbi.PodGroup = pg
-				rbi.Queue = pg.Spec.Queue
+		// Try find the binding PodGroup.
+		priorityClassName := ""
+		if rbi.ResourceBinding.Spec.ReplicaRequirements != nil {
+			priorityClassName = rbi.ResourceBinding.Spec.ReplicaRequirements.PriorityClassName
+		}
+		if pg, ok := podGroupMap[rbi.ResourceBinding.Spec.Resource.UID]; ok {
+			if pg.Spec.PriorityClassName != "" {
+				priorityClassName = pg.Spec.PriorityClassName
 			}
-
-			// On the end, we need to copy it.
-			snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi.DeepCopy()
+			rbi.PodGroup = pg.DeepCopy()
+		}
+		rbi.Queue = dc.resolveQueue(rbi)
+		setPriority(rbi, priorityClassName, priorityClasses, defaultPriorityClass)
+		queueName := rbi.Queue
+		if queueName == "" {
+			queueName = snapshot.DefaultQueue
 		}
+		if setPodGroupMinResources(rbi, dc.preferPodGroupMinResources(queueName)) {
+			snapshot.MinResourcesDisagreements++
+		}
+		setFirstStageAdmission(rbi, snapshot)
+
+		snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+	}
+	dc.resourceBindingMutex.RUnlock()
+
+	dc.federatedResourceQuotaMutex.RLock()
+	for key, frq := range dc.federatedResourceQuotas {
+		snapshot.FederatedResourceQuotas[key.Namespace] = append(snapshot.FederatedResourceQuotas[key.Namespace], frq.DeepCopy())
 	}
+	dc.federatedResourceQuotaMutex.RUnlock()
 
+	dc.clusterMutex.RLock()
+	for name, cluster := range dc.clusters {
+		snapshot.Clusters[name] = cluster.DeepCopy()
+	}
+	dc.clusterMutex.RUnlock()
+	snapshot.CapacityProvider = dc.capacityProvider
+
+	dc.namespaceMutex.RLock()
+	for name, namespace := range dc.namespaces {
+		snapshot.Namespaces[name] = namespace
+	}
+	dc.namespaceMutex.RUnlock()
+
+	dc.lastSnapshotSize.record(snapshot, len(podGroupMap))
 	return snapshot
 }
+
+// snapshotSize is the cardinality of the last snapshot, the new maps of the next snapshot are pre-sized by it.
+type snapshotSize struct {
+	queues                  atomic.Int64
+	resourceBindings        atomic.Int64
+	podGroupOwners          atomic.Int64
+	federatedResourceQuotas atomic.Int64
+	clusters                atomic.Int64
+	namespaces              atomic.Int64
+}
+
+func (size *snapshotSize) record(snapshot *DispatcherCacheSnapshot, podGroupOwners int) {
+	size.queues.Store(int64(len(snapshot.QueueInfos)))
+	size.resourceBindings.Store(int64(len(snapshot.ResourceBindingInfos)))
+	size.podGroupOwners.Store(int64(podGroupOwners))
+	size.federatedResourceQuotas.Store(int64(len(snapshot.FederatedResourceQuotas)))
+	size.clusters.Store(int64(len(snapshot.Clusters)))
+	size.namespaces.Store(int64(len(snapshot.Namespaces)))
+}
+
+// defaultCapacityProvider provides the capacity of the Clusters of the snapshots without their own provider.
+var defaultCapacityProvider = clustercapacity.NewClusterStatusProvider()
+
+// ClusterCapacity returns the capacity of the member cluster by the CapacityProvider of the snapshot, it returns false
+// when the capacity is unknown.
+func (snapshot *DispatcherCacheSnapshot) ClusterCapacity(cluster *clusterv1alpha1.Cluster) (*clustercapacity.Capacity, bool) {
+	provider := snapshot.CapacityProvider
+	if provider == nil {
+		provider = defaultCapacityProvider
+	}
+	return provider.Capacity(cluster)
+}
+
+// TotalResources sums the allocatable resources of the schedulable member clusters, the tainted ones are excluded
+// even if some workloads tolerate them. It's empty when the capacity of no cluster is known.
+func (snapshot *DispatcherCacheSnapshot) TotalResources() corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, cluster := range snapshot.Clusters {
+		if !ClusterSchedulable(cluster) {
+			continue
+		}
+		capacity, found := snapshot.ClusterCapacity(cluster)
+		if !found {
+			continue
+		}
+		for name, quantity := range capacity.Allocatable {
+			value := total[name]
+			value.Add(quantity)
+			total[name] = value
+		}
+	}
+	return total
+}
+
+// setFirstStageAdmission makes the staged workload be admitted by its first stage if its queue opts in,
+// the resources of the dispatched one are counted as its first stage too.
+func setFirstStageAdmission(rbi *api.ResourceBindingInfo, snapshot *DispatcherCacheSnapshot) {
+	if rbi.FirstStageResources == nil {
+		return
+	}
+	queueName := rbi.Queue
+	if queueName == "" {
+		queueName = snapshot.DefaultQueue
+	}
+	queue, found := snapshot.QueueInfos[queueName]
+	if !found || queue.Queue == nil || queue.Queue.Annotations[api.FirstStageAdmissionAnnotationKey] != "true" {
+		return
+	}
+	rbi.MinResources = rbi.FirstStageResources.DeepCopy()
+}
+
+// setPriority resolves the PriorityClass of the workload into the priority of the ResourceBindingInfo,
+// the default PriorityClass is used when the workload didn't set one.
+func setPriority(rbi *api.ResourceBindingInfo, priorityClassName string,
+	priorityClasses map[string]*schedulingv1.PriorityClass, defaultPriorityClass *schedulingv1.PriorityClass) {
+	priorityClass := defaultPriorityClass
+	if priorityClassName != "" {
+		if pc, found := priorityClasses[priorityClassName]; found {
+			priorityClass = pc
+		} else {
+			// It shouldn't happen. All the PriorityClass should in the cache.
+			klog.Errorf("PriorityClass <%s> not found in the cache when execute ResourceBinding <%s/%s>, use the default one.",
+				priorityClassName, rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name)
+		}
+	}
+
+	rbi.Priority = 0
+	rbi.PriorityClassName = ""
+	rbi.PriorityOverridden = false
+	rbi.PreemptionPolicy = corev1.PreemptLowerPriority
+	if priorityClass != nil {
+		rbi.Priority = priorityClass.Value
+		rbi.PriorityClassName = priorityClass.Name
+		if priorityClass.PreemptionPolicy != nil {
+			rbi.PreemptionPolicy = *priorityClass.PreemptionPolicy
+		}
+	}
+
+	// The override is validated and authorized by the webhook, the PreemptionPolicy is still the PriorityClass's.
+	priority, overridden, err := api.ParsePriorityOverride(rbi.ResourceBinding.Annotations)
+	if err != nil {
+		klog.Errorf("Ignore the priority override of ResourceBinding <%s/%s>: %v",
+			rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, err)
+		return
+	}
+	if overridden {
+		rbi.Priority = priority
+		rbi.PriorityOverridden = true
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// pendingStatus is the conditions and the annotations waiting to be written back to a ResourceBinding, only the
+// latest condition of each type and the latest value of each annotation are kept.
+type pendingStatus struct {
+	conditions  map[string]metav1.Condition
+	annotations map[string]string
+	// updates is the number of the updates coalesced into the pending status.
+	updates int
+}
+
+// statusWriter coalesces the conditions and the annotations written back to the ResourceBindings. The first update
+// of a ResourceBinding is written after the window, and the later ones in the window are merged into it, so a
+// ResourceBinding blocked and annotated in several rounds costs one annotation patch and one status update only.
+type statusWriter struct {
+	queue  workqueue.DelayingInterface
+	window time.Duration
+
+	mutex   sync.Mutex
+	pending map[types.NamespacedName]*pendingStatus
+	// coalesced is the number of the updates merged into the others since it was taken last time.
+	coalesced int
+}
+
+func newStatusWriter(window time.Duration) *statusWriter {
+	return &statusWriter{
+		queue:   workqueue.NewDelayingQueue(),
+		window:  window,
+		pending: map[types.NamespacedName]*pendingStatus{},
+	}
+}
+
+func (sw *statusWriter) updateCondition(key types.NamespacedName, condition metav1.Condition) {
+	sw.update(key, func(status *pendingStatus) {
+		status.conditions[condition.Type] = condition
+	})
+}
+
+func (sw *statusWriter) annotate(key types.NamespacedName, annotationKey, value string) {
+	sw.update(key, func(status *pendingStatus) {
+		status.annotations[annotationKey] = value
+	})
+}
+
+func (sw *statusWriter) update(key types.NamespacedName, merge func(status *pendingStatus)) {
+	sw.mutex.Lock()
+	status, found := sw.pending[key]
+	if !found {
+		status = &pendingStatus{conditions: map[string]metav1.Condition{}, annotations: map[string]string{}}
+		sw.pending[key] = status
+	} else {
+		sw.coalesced++
+	}
+	status.updates++
+	merge(status)
+	sw.mutex.Unlock()
+
+	if !found {
+		sw.queue.AddAfter(key, sw.window)
+	}
+}
+
+// take removes the pending status of the ResourceBinding, the updates after it are written in the next window.
+func (sw *statusWriter) take(key types.NamespacedName) (*pendingStatus, bool) {
+	sw.mutex.Lock()
+	defer sw.mutex.Unlock()
+	status, found := sw.pending[key]
+	delete(sw.pending, key)
+	return status, found
+}
+
+// TakeCoalescedStatusUpdates returns the number of the condition and annotation updates of the ResourceBindings
+// merged into the others since the last call, they are written back without their own requests.
+func (dc *DispatcherCache) TakeCoalescedStatusUpdates() int {
+	dc.statusWriter.mutex.Lock()
+	defer dc.statusWriter.mutex.Unlock()
+	coalesced := dc.statusWriter.coalesced
+	dc.statusWriter.coalesced = 0
+	return coalesced
+}
+
+// Its worker for writing the coalesced conditions and annotations back to the ResourceBindings.
+func (dc *DispatcherCache) statusWriterWorker() {
+	for {
+		obj, shutdown := dc.statusWriter.queue.Get()
+		if shutdown {
+			return
+		}
+
+		key := obj.(types.NamespacedName)
+		if status, ok := dc.statusWriter.take(key); ok {
+			if len(status.annotations) > 0 {
+				if err := dc.patchResourceBindingAnnotations(key, status.annotations); err != nil {
+					klog.Errorf("Failed to patch annotations of ResourceBinding <%s/%s>, err: %v", key.Namespace, key.Name, err)
+				}
+			}
+			if len(status.conditions) > 0 {
+				if err := dc.updateResourceBindingConditions(key, status.conditions); err != nil {
+					klog.Errorf("Failed to update conditions of ResourceBinding <%s/%s>, err: %v", key.Namespace, key.Name, err)
+				}
+			}
+			klog.V(5).Infof("Write %d coalesced status updates of ResourceBinding <%s/%s>.", status.updates, key.Namespace, key.Name)
+		}
+		dc.statusWriter.queue.Done(key)
+	}
+}
+
+func (dc *DispatcherCache) updateResourceBindingConditions(key types.NamespacedName, conditions map[string]metav1.Condition) error {
+	// Set the conditions in a stable order, so the last transition times are deterministic.
+	conditionTypes := make([]string, 0, len(conditions))
+	for conditionType := range conditions {
+		conditionTypes = append(conditionTypes, conditionType)
+	}
+	sort.Strings(conditionTypes)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		rb, err := dc.karmadaClient.WorkV1alpha2().ResourceBindings(key.Namespace).Get(context.TODO(), key.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		changed := false
+		for _, conditionType := range conditionTypes {
+			if meta.SetStatusCondition(&rb.Status.Conditions, conditions[conditionType]) {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		_, err = dc.karmadaClient.WorkV1alpha2().ResourceBindings(key.Namespace).UpdateStatus(context.TODO(), rb, metav1.UpdateOptions{})
+		if err == nil {
+			klog.V(4).Infof("Success update conditions %v of ResourceBinding <%s/%s>.", conditionTypes, key.Namespace, key.Name)
+		}
+		return err
+	})
+}
+
+func (dc *DispatcherCache) patchResourceBindingAnnotations(key types.NamespacedName, annotations map[string]string) error {
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = dc.karmadaClient.WorkV1alpha2().ResourceBindings(key.Namespace).Patch(context.TODO(),
+		key.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err == nil {
+		klog.V(4).Infof("Success patch annotations of ResourceBinding <%s/%s>.", key.Namespace, key.Name)
+	}
+	return err
+}
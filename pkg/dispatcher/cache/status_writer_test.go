@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestStatusWriter(t *testing.T) {
+	blocked := func(reason string) metav1.Condition {
+		return metav1.Condition{Type: api.DispatchedCondition, Status: metav1.ConditionFalse, Reason: reason, Message: reason}
+	}
+	testCases := []struct {
+		Name             string
+		conditions       []metav1.Condition
+		annotations      map[string]string
+		expectPatches    int
+		expectUpdates    int
+		expectCoalesced  int
+		expectReason     string
+		expectAnnotation string
+	}{
+		{
+			Name:             "Coalesce the conditions and the annotations",
+			conditions:       []metav1.Condition{blocked("QueueFull"), blocked("OutsideDispatchWindow")},
+			annotations:      map[string]string{"a": "1", "b": "2"},
+			expectPatches:    1,
+			expectUpdates:    1,
+			expectCoalesced:  3,
+			expectReason:     "OutsideDispatchWindow",
+			expectAnnotation: "2",
+		},
+		{
+			Name:            "Only the conditions",
+			conditions:      []metav1.Condition{blocked("QueueFull")},
+			expectUpdates:   1,
+			expectCoalesced: 0,
+			expectReason:    "QueueFull",
+		},
+		{
+			Name:             "Only the annotations",
+			annotations:      map[string]string{"b": "2"},
+			expectPatches:    1,
+			expectAnnotation: "2",
+		},
+	}
+
+	for _, tc := range testCases {
+		rb := newTestResourceBinding("ns", "rb")
+		client := karmadafake.NewSimpleClientset(rb)
+		patches, updates := 0, 0
+		client.PrependReactor("patch", "resourcebindings", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			patches++
+			return false, nil, nil
+		})
+		client.PrependReactor("update", "resourcebindings", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			updates++
+			return false, nil, nil
+		})
+		dc := newTestDispatcherCache()
+		dc.karmadaClient = client
+		key := types.NamespacedName{Namespace: "ns", Name: "rb"}
+		dc.resourceBindingInfos[key] = &api.ResourceBindingInfo{ResourceBinding: rb}
+
+		for _, condition := range tc.conditions {
+			dc.UpdateResourceBindingCondition(key, condition)
+		}
+		for _, annotationKey := range []string{"a", "b"} {
+			if value, found := tc.annotations[annotationKey]; found {
+				dc.AnnotateResourceBinding(key, annotationKey, value)
+			}
+		}
+		coalesced := dc.TakeCoalescedStatusUpdates()
+		dc.statusWriter.queue.ShutDown()
+		dc.statusWriterWorker()
+
+		written, _ := client.WorkV1alpha2().ResourceBindings("ns").Get(context.TODO(), "rb", metav1.GetOptions{})
+		reason := ""
+		if condition := meta.FindStatusCondition(written.Status.Conditions, api.DispatchedCondition); condition != nil {
+			reason = condition.Reason
+		}
+		if patches != tc.expectPatches || updates != tc.expectUpdates || coalesced != tc.expectCoalesced ||
+			reason != tc.expectReason || written.Annotations["b"] != tc.expectAnnotation {
+			t.Errorf("Test case %s failed, got patches: %d updates: %d coalesced: %d reason: %s annotation: %s "+
+				"expect: %d %d %d %s %s", tc.Name, patches, updates, coalesced, reason, written.Annotations["b"],
+				tc.expectPatches, tc.expectUpdates, tc.expectCoalesced, tc.expectReason, tc.expectAnnotation)
+		}
+	}
+}
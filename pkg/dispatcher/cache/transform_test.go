@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestStripObject(t *testing.T) {
+	objectMeta := func() metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:          "obj",
+			Annotations:   map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "volcano.sh/queue-name": "q1"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		}
+	}
+	strippedMeta := metav1.ObjectMeta{Name: "obj", Annotations: map[string]string{"volcano.sh/queue-name": "q1"}}
+
+	testCases := []struct {
+		Name   string
+		obj    interface{}
+		expect interface{}
+	}{
+		{
+			Name: "ResourceBinding",
+			obj: &workv1alpha2.ResourceBinding{ObjectMeta: objectMeta(), Status: workv1alpha2.ResourceBindingStatus{
+				Conditions: []metav1.Condition{{Type: workv1alpha2.FullyApplied, Status: metav1.ConditionTrue}},
+				AggregatedStatus: []workv1alpha2.AggregatedStatusItem{{ClusterName: "member1", Health: workv1alpha2.ResourceHealthy,
+					Status: &runtime.RawExtension{Raw: []byte(`{"replicas":1}`)}}},
+			}},
+			expect: &workv1alpha2.ResourceBinding{ObjectMeta: strippedMeta, Status: workv1alpha2.ResourceBindingStatus{
+				Conditions:       []metav1.Condition{{Type: workv1alpha2.FullyApplied, Status: metav1.ConditionTrue}},
+				AggregatedStatus: []workv1alpha2.AggregatedStatusItem{{ClusterName: "member1", Health: workv1alpha2.ResourceHealthy}},
+			}},
+		},
+		{
+			Name: "PodGroup",
+			obj: &schedulingv1beta1.PodGroup{ObjectMeta: objectMeta(), Status: schedulingv1beta1.PodGroupStatus{
+				Phase: schedulingv1beta1.PodGroupRunning, Running: 2,
+				Conditions: []schedulingv1beta1.PodGroupCondition{{Type: schedulingv1beta1.PodGroupScheduled}},
+			}},
+			expect: &schedulingv1beta1.PodGroup{ObjectMeta: strippedMeta, Status: schedulingv1beta1.PodGroupStatus{
+				Phase: schedulingv1beta1.PodGroupRunning,
+			}},
+		},
+		{
+			Name:   "Other object",
+			obj:    &corev1.Namespace{ObjectMeta: objectMeta(), Status: corev1.NamespaceStatus{Phase: corev1.NamespaceActive}},
+			expect: &corev1.Namespace{ObjectMeta: strippedMeta, Status: corev1.NamespaceStatus{Phase: corev1.NamespaceActive}},
+		},
+		{
+			Name:   "Tombstone",
+			obj:    cache.DeletedFinalStateUnknown{Key: "obj"},
+			expect: cache.DeletedFinalStateUnknown{Key: "obj"},
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := stripObject(tc.obj)
+		if err != nil {
+			t.Errorf("Test case %s failed, got err: %v", tc.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.expect) {
+			t.Errorf("Test case %s failed, got: %+v expect: %+v", tc.Name, got, tc.expect)
+		}
+	}
+}
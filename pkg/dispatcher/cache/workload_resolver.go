@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/resolver"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// getWorkload gets the workload of the ResourceBinding, it returns nil if the workload can't be found.
+func (dc *DispatcherCache) getWorkload(rb *workv1alpha2.ResourceBinding) *unstructured.Unstructured {
+	ref := rb.Spec.Resource
+	workload, err := utils.GetWorkload(dc.dynamicClient, dc.restMapper, ref)
+	if err != nil {
+		klog.V(3).Infof("Failed to get the workload %s <%s/%s> of ResourceBinding <%s/%s>, err: %v",
+			ref.Kind, ref.Namespace, ref.Name, rb.Namespace, rb.Name, err)
+		return nil
+	}
+	return workload
+}
+
+// resolveMinResources computes the minimum resources of the ResourceBinding's workload, the resources of its
+// first stage if it's a staged workload, and the replica requirements overridden for the target clusters.
+// It will fall back to the ResourceBinding's ReplicaRequirements if the workload is nil.
+func (dc *DispatcherCache) resolveMinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (
+	corev1.ResourceList, corev1.ResourceList, map[string]*workv1alpha2.ReplicaRequirements) {
+	ref := rb.Spec.Resource
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		klog.Errorf("Failed to parse APIVersion of ResourceBinding <%s/%s>, err: %v", rb.Namespace, rb.Name, err)
+		return nil, nil, nil
+	}
+	workloadResolver := resolver.GetResolver(gv.WithKind(ref.Kind))
+
+	var clusterRequirements map[string]*workv1alpha2.ReplicaRequirements
+	minResources, err := workloadResolver.MinResources(rb, workload)
+	if err != nil {
+		klog.Errorf("Failed to resolve the min resources of ResourceBinding <%s/%s>, resolve by ReplicaRequirements, err: %v",
+			rb.Namespace, rb.Name, err)
+		minResources, _ = workloadResolver.MinResources(rb, nil)
+	} else {
+		minResources, clusterRequirements = dc.resolveOverrides(rb, workload, workloadResolver, minResources)
+	}
+
+	stagedResolver, ok := workloadResolver.(resolver.StagedWorkloadResourceResolver)
+	if !ok {
+		return minResources, nil, clusterRequirements
+	}
+	firstStageResources, err := stagedResolver.FirstStageResources(rb, workload)
+	if err != nil {
+		// Admit it by the min resources.
+		klog.Errorf("Failed to resolve the first stage resources of ResourceBinding <%s/%s>, err: %v",
+			rb.Namespace, rb.Name, err)
+		return minResources, nil, clusterRequirements
+	}
+	return minResources, firstStageResources, clusterRequirements
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// checkpoint persists the state owned by the dispatcher onto the ResourceBindings of the queues this replica owns,
+// only the changed ones are patched. The state changed in this round is persisted in the next round.
+func (dispatcher *Dispatcher) checkpoint(cp *controlPlane, ssn *dispatcherframework.Session) {
+	if !dispatcher.checkpointDispatchState {
+		return
+	}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if !dispatcher.ownsResourceBinding(ssn, rbi) {
+			continue
+		}
+		value := rbi.Checkpoint()
+		if rbi.ResourceBinding.Annotations[api.DispatchCheckpointAnnotationKey] == value {
+			continue
+		}
+		cp.cache.AnnotateResourceBinding(types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name},
+			api.DispatchCheckpointAnnotationKey, value)
+	}
+}
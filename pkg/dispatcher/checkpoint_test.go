@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestRestoreCheckpoint(t *testing.T) {
+	firstSeen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	enqueued := firstSeen.Add(time.Minute)
+	unSuspended := firstSeen.Add(time.Hour)
+	admitted := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}
+	restarted := firstSeen.Add(24 * time.Hour)
+
+	testCases := []struct {
+		Name            string
+		checkpointed    *api.ResourceBindingInfo
+		status          api.DispatchStatus
+		resourceUID     types.UID
+		expectRestored  bool
+		expectEnqueue   time.Time
+		expectUnSuspend time.Time
+		expectAdmitted  corev1.ResourceList
+	}{
+		{
+			Name:           "Waiting in the queue",
+			checkpointed:   &api.ResourceBindingInfo{FirstSeenTime: firstSeen, EnqueueTime: enqueued},
+			status:         api.Pending,
+			expectRestored: true,
+			expectEnqueue:  enqueued,
+		},
+		{
+			Name: "Dispatched",
+			checkpointed: &api.ResourceBindingInfo{FirstSeenTime: firstSeen, EnqueueTime: enqueued,
+				UnSuspendTime: unSuspended, AdmittedResources: admitted},
+			status:          api.Dispatched,
+			expectRestored:  true,
+			expectEnqueue:   enqueued,
+			expectUnSuspend: unSuspended,
+			expectAdmitted:  admitted,
+		},
+		{
+			Name: "Suspended after it was dispatched",
+			checkpointed: &api.ResourceBindingInfo{FirstSeenTime: firstSeen, EnqueueTime: enqueued,
+				UnSuspendTime: unSuspended, AdmittedResources: admitted},
+			status:         api.Pending,
+			expectRestored: true,
+			expectEnqueue:  restarted,
+		},
+		{
+			Name:          "Workload recreated after it was checkpointed",
+			checkpointed:  &api.ResourceBindingInfo{ResourceUID: "deleted", FirstSeenTime: firstSeen, EnqueueTime: enqueued},
+			status:        api.Pending,
+			resourceUID:   "recreated",
+			expectEnqueue: restarted,
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{api.DispatchCheckpointAnnotationKey: tc.checkpointed.Checkpoint()},
+			}},
+			ResourceUID:    tc.resourceUID,
+			DispatchStatus: tc.status,
+			FirstSeenTime:  restarted,
+			EnqueueTime:    restarted,
+		}
+		if restored := rbi.RestoreCheckpoint(); restored != tc.expectRestored {
+			t.Errorf("Test case %s failed, got restored: %v expect: %v", tc.Name, restored, tc.expectRestored)
+			continue
+		}
+		if !tc.expectRestored {
+			if !rbi.FirstSeenTime.Equal(restarted) || !rbi.EnqueueTime.Equal(tc.expectEnqueue) {
+				t.Errorf("Test case %s failed, the state of the recreated workload is restored", tc.Name)
+			}
+			continue
+		}
+		if !rbi.FirstSeenTime.Equal(firstSeen) || !rbi.EnqueueTime.Equal(tc.expectEnqueue) ||
+			!rbi.UnSuspendTime.Equal(tc.expectUnSuspend) || !equality.Semantic.DeepEqual(rbi.AdmittedResources, tc.expectAdmitted) {
+			t.Errorf("Test case %s failed, got: %v/%v/%v/%v expect: %v/%v/%v/%v", tc.Name, rbi.FirstSeenTime, rbi.EnqueueTime,
+				rbi.UnSuspendTime, rbi.AdmittedResources, firstSeen, tc.expectEnqueue, tc.expectUnSuspend, tc.expectAdmitted)
+		}
+	}
+}
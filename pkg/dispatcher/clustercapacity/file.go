@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercapacity
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// fileCheckInterval limits how often the file is checked for changes.
+const fileCheckInterval = 10 * time.Second
+
+// capacityFile is the content of the file, like:
+//
+//	clusters:
+//	  member1:
+//	    allocatable: {cpu: "100", memory: 400Gi}
+//	    allocated: {cpu: "20", memory: 80Gi}
+type capacityFile struct {
+	Clusters map[string]*Capacity `json:"clusters"`
+}
+
+type fileProvider struct {
+	path     string
+	fallback CapacityProvider
+
+	mutex     sync.Mutex
+	checkTime time.Time
+	modTime   time.Time
+	clusters  map[string]*Capacity
+}
+
+// NewFileProvider returns a CapacityProvider which reads the capacities of the clusters from the file, it's written
+// by any source out of the control plane and reloaded when it changes. The clusters not in the file are provided by
+// the fallback, which can be nil.
+func NewFileProvider(path string, fallback CapacityProvider) (CapacityProvider, error) {
+	p := &fileProvider{path: path, fallback: fallback}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *fileProvider) Name() string {
+	return FileProviderName
+}
+
+func (p *fileProvider) Capacity(cluster *clusterv1alpha1.Cluster) (*Capacity, bool) {
+	p.mutex.Lock()
+	if now := time.Now(); now.Sub(p.checkTime) >= fileCheckInterval {
+		p.checkTime = now
+		// Keep the last capacities if the file is broken, the dispatcher shouldn't stop for it.
+		if err := p.loadLocked(); err != nil {
+			klog.Errorf("Failed to reload the capacities of the clusters from %s, using the previous ones: %v", p.path, err)
+		}
+	}
+	capacity, found := p.clusters[cluster.Name]
+	p.mutex.Unlock()
+
+	if found {
+		return capacity, true
+	}
+	if p.fallback != nil {
+		return p.fallback.Capacity(cluster)
+	}
+	return nil, false
+}
+
+func (p *fileProvider) load() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.checkTime = time.Now()
+	return p.loadLocked()
+}
+
+func (p *fileProvider) loadLocked() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return err
+	}
+	if p.clusters != nil && info.ModTime().Equal(p.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return err
+	}
+	file := &capacityFile{}
+	if err := yaml.UnmarshalStrict(data, file); err != nil {
+		return fmt.Errorf("invalid capacity file: %v", err)
+	}
+	if file.Clusters == nil {
+		file.Clusters = map[string]*Capacity{}
+	}
+	p.clusters, p.modTime = file.Clusters, info.ModTime()
+	klog.V(3).Infof("Loaded the capacities of %d clusters from %s.", len(p.clusters), p.path)
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercapacity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func cpu(quantity string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(quantity)}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capacity.yaml")
+	if err := os.WriteFile(path, []byte(`
+clusters:
+  member1:
+    allocatable: {cpu: "100"}
+    allocated: {cpu: "120"}
+  member2:
+    allocatable: {cpu: "10"}
+`), 0600); err != nil {
+		t.Fatalf("Failed to write the capacity file: %v", err)
+	}
+	provider, err := NewCapacityProvider(FileProviderName, path)
+	if err != nil {
+		t.Fatalf("Failed to create the file provider: %v", err)
+	}
+
+	withSummary := func(name string) *clusterv1alpha1.Cluster {
+		return &clusterv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: clusterv1alpha1.ClusterStatus{ResourceSummary: &clusterv1alpha1.ResourceSummary{
+				Allocatable: cpu("8"), Allocated: cpu("2"), Allocating: cpu("1"),
+			}},
+		}
+	}
+	testCases := []struct {
+		Name        string
+		Cluster     *clusterv1alpha1.Cluster
+		ExpectFound bool
+		ExpectFree  string
+	}{
+		{
+			Name:        "Over allocated cluster in the file has nothing free",
+			Cluster:     withSummary("member1"),
+			ExpectFound: true,
+			ExpectFree:  "0",
+		},
+		{
+			Name:        "Cluster in the file overrides its status",
+			Cluster:     withSummary("member2"),
+			ExpectFound: true,
+			ExpectFree:  "10",
+		},
+		{
+			Name:        "Cluster not in the file falls back to its status",
+			Cluster:     withSummary("member3"),
+			ExpectFound: true,
+			ExpectFree:  "5",
+		},
+		{
+			Name:    "Cluster without the status is unknown",
+			Cluster: &clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "member4"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		capacity, found := provider.Capacity(tc.Cluster)
+		if found != tc.ExpectFound {
+			t.Errorf("Test case %s failed, got found: %v, expect: %v", tc.Name, found, tc.ExpectFound)
+			continue
+		}
+		if !found {
+			continue
+		}
+		free := capacity.Free()[corev1.ResourceCPU]
+		if free.Cmp(resource.MustParse(tc.ExpectFree)) != 0 {
+			t.Errorf("Test case %s failed, got free cpu: %s, expect: %s", tc.Name, free.String(), tc.ExpectFree)
+		}
+	}
+
+	if _, err := NewCapacityProvider(FileProviderName, ""); err == nil {
+		t.Errorf("Expect the file provider without the path to fail")
+	}
+	if _, err := NewCapacityProvider("estimator", ""); err == nil {
+		t.Errorf("Expect the unknown provider to fail")
+	}
+}
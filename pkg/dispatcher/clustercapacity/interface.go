@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustercapacity
+
+import (
+	"fmt"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Capacity is the resources of a member cluster.
+type Capacity struct {
+	Allocatable corev1.ResourceList `json:"allocatable"`
+	// Allocated is the resources taken by the workloads running or being scheduled in the cluster.
+	Allocated corev1.ResourceList `json:"allocated,omitempty"`
+}
+
+// Free returns the allocatable resources minus the allocated ones, the over allocated resources have nothing free.
+func (c *Capacity) Free() corev1.ResourceList {
+	free := make(corev1.ResourceList, len(c.Allocatable))
+	for name, quantity := range c.Allocatable {
+		value := quantity.DeepCopy()
+		value.Sub(c.Allocated[name])
+		if value.Sign() < 0 {
+			value.Set(0)
+		}
+		free[name] = value
+	}
+	return free
+}
+
+// CapacityProvider provides the capacity of the member clusters to the dispatcher, like the Karmada Cluster status
+// or a source out of the control plane, the air-gapped environments can plug in their own one.
+type CapacityProvider interface {
+	// Name returns the name of the provider.
+	Name() string
+	// Capacity returns the capacity of the cluster, it returns false when the capacity of the cluster is unknown.
+	Capacity(cluster *clusterv1alpha1.Cluster) (*Capacity, bool)
+}
+
+type clusterStatusProvider struct{}
+
+// NewClusterStatusProvider returns a CapacityProvider which takes the resource summary reported in the status of the
+// Cluster, the allocating resources are counted as allocated.
+func NewClusterStatusProvider() CapacityProvider {
+	return &clusterStatusProvider{}
+}
+
+func (p *clusterStatusProvider) Name() string {
+	return ClusterStatusProviderName
+}
+
+func (p *clusterStatusProvider) Capacity(cluster *clusterv1alpha1.Cluster) (*Capacity, bool) {
+	summary := cluster.Status.ResourceSummary
+	if summary == nil {
+		return nil, false
+	}
+	allocated := make(corev1.ResourceList, len(summary.Allocated))
+	for name, quantity := range summary.Allocated {
+		allocated[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range summary.Allocating {
+		value := allocated[name]
+		value.Add(quantity)
+		allocated[name] = value
+	}
+	return &Capacity{Allocatable: summary.Allocatable, Allocated: allocated}, true
+}
+
+const (
+	// ClusterStatusProviderName is the name of the provider taking the status of the Clusters.
+	ClusterStatusProviderName = "cluster-status"
+	// FileProviderName is the name of the provider reading the file.
+	FileProviderName = "file"
+)
+
+// NewCapacityProvider returns the CapacityProvider by its name, the file provider reads the path and falls back to
+// the status of the Clusters for the clusters not in the file.
+func NewCapacityProvider(name, path string) (CapacityProvider, error) {
+	switch name {
+	case "", ClusterStatusProviderName:
+		return NewClusterStatusProvider(), nil
+	case FileProviderName:
+		if path == "" {
+			return nil, fmt.Errorf("the path of the capacity file is required by the %s capacity provider", FileProviderName)
+		}
+		return NewFileProvider(path, NewClusterStatusProvider())
+	default:
+		return nil, fmt.Errorf("unknown capacity provider %q, expect %s or %s", name, ClusterStatusProviderName, FileProviderName)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/features"
+)
+
+// resuspendOnClusterFailure suspends the dispatched ResourceBindings again when a cluster they are scheduled to is not
+// ready, like unreachable, and their queues opt in by the `volcano.sh/resuspend-on-cluster-failure` annotation, so
+// they wait in the queues and are dispatched again after Karmada reschedules them to the healthy clusters. Their
+// scheduling results are removed, and the failovers are not counted as the preemptions.
+func (dispatcher *Dispatcher) resuspendOnClusterFailure(cp *controlPlane, ssn *dispatcherframework.Session) {
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus != api.Dispatched || rbi.IsCompleted() || !dispatcher.ownsResourceBinding(ssn, rbi) {
+			continue
+		}
+		// It's suspended again by resuspendGrown or resuspendRescheduled in the round already.
+		if resuspendedInRound(ssn, rbi) {
+			continue
+		}
+		failedClusters := notReadyClusters(ssn, rbi)
+		if len(failedClusters) == 0 {
+			continue
+		}
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+
+		key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+		message := fmt.Sprintf("The clusters [%s] the workload is scheduled to are not ready, wait for queue %s to "+
+			"admit it again after Karmada reschedules it.", strings.Join(failedClusters, ","), queueName)
+		cp.logger().Info(3, queueName, key, "Resuspend ResourceBinding on not ready clusters", "message", message)
+
+		ssn.Evict(rbi)
+		cp.cache.SuspendResourceBindingOnFailure(key, rbi.ScheduledClusters())
+		cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+			Type:    api.DispatchedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  api.ClusterNotReadyReason,
+			Message: message,
+		})
+		dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+			Queue: queueName, Evicted: true, Reason: api.ClusterNotReadyReason, Message: message},
+			rbi, ssn.Snapshot.QueueInfos[queueName], nil)
+	}
+}
+
+// notReadyClusters returns the sorted clusters the ResourceBindingInfo is scheduled to which are not ready, when its
+// queue opts in to suspending the workloads on the failed clusters again.
+func notReadyClusters(ssn *dispatcherframework.Session, rbi *api.ResourceBindingInfo) []string {
+	queue, found := ssn.Snapshot.QueueInfos[ssn.GetResourceBindingInfoQueue(rbi)]
+	if !found || queue.Queue == nil || queue.Queue.Annotations[api.ResuspendOnClusterFailureAnnotationKey] != "true" {
+		return nil
+	}
+	return notReadyOf(ssn, rbi.ScheduledClusters())
+}
+
+// clusterFailureBlocker blocks the ResourceBindingInfo suspended again by the failure of its clusters while it's still
+// scheduled to them and some of them are not ready, otherwise it's dispatched and suspended again in each round until
+// Karmada reschedules it. The failed placement isn't recorded when Karmada doesn't schedule the suspended
+// ResourceBindings, they're rescheduled after dispatched instead.
+func clusterFailureBlocker(ssn *dispatcherframework.Session, rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	if rbi.FailedPlacement == nil || !slices.Equal(rbi.FailedPlacement, rbi.ScheduledClusters()) {
+		return nil
+	}
+	failedClusters := notReadyOf(ssn, rbi.FailedPlacement)
+	if len(failedClusters) == 0 {
+		return nil
+	}
+	return &api.DispatchBlocker{
+		Reason: api.ClusterNotReadyReason,
+		Message: fmt.Sprintf("The clusters [%s] the workload is scheduled to are still not ready, wait for Karmada to "+
+			"reschedule it or them to be ready again.", strings.Join(failedClusters, ",")),
+	}
+}
+
+// notReadyOf returns the clusters which are not ready in the sorted ones, the clusters not in the snapshot are removed
+// from the federation, they're left to Karmada.
+func notReadyOf(ssn *dispatcherframework.Session, names []string) []string {
+	var clusters []string
+	for _, name := range names {
+		cluster, found := ssn.Snapshot.Clusters[name]
+		if found && !meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1alpha1.ClusterConditionReady) {
+			clusters = append(clusters, name)
+		}
+	}
+	return clusters
+}
+
+// resuspendedInRound checks whether the dispatched ResourceBindingInfo is suspended again by resuspendGrown or
+// resuspendRescheduled in the round.
+func resuspendedInRound(ssn *dispatcherframework.Session, rbi *api.ResourceBindingInfo) bool {
+	return (rbi.IsGrown() && resuspendsOnGrowth(ssn, rbi)) || (!rbi.IsSpeculative() && rbi.IsRescheduled() &&
+		utilfeature.DefaultFeatureGate.Enabled(features.RedispatchOnReschedule))
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/actions/allocate"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	"volcano.sh/volcano-global/pkg/dispatcher/feasibility"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func newTestCluster(name string, ready metav1.ConditionStatus) *clusterv1alpha1.Cluster {
+	return &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: clusterv1alpha1.ClusterStatus{
+			Conditions: []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: ready}},
+		},
+	}
+}
+
+func TestNotReadyClusters(t *testing.T) {
+	newQueue := func(name string, resuspend bool) *schedulingapi.QueueInfo {
+		queue := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if resuspend {
+			queue.Annotations = map[string]string{api.ResuspendOnClusterFailureAnnotationKey: "true"}
+		}
+		return &schedulingapi.QueueInfo{Name: name, Queue: queue}
+	}
+	ssn := &dispatcherframework.Session{Snapshot: &cache.DispatcherCacheSnapshot{
+		DefaultQueue: "default",
+		QueueInfos: map[string]*schedulingapi.QueueInfo{
+			"default":   newQueue("default", false),
+			"resuspend": newQueue("resuspend", true),
+		},
+		Clusters: map[string]*clusterv1alpha1.Cluster{
+			"member1": newTestCluster("member1", metav1.ConditionTrue),
+			"member2": newTestCluster("member2", metav1.ConditionFalse),
+			"member3": newTestCluster("member3", metav1.ConditionUnknown),
+		},
+	}}
+
+	testCases := []struct {
+		Name      string
+		queue     string
+		scheduled []string
+		expect    []string
+	}{
+		{
+			Name:      "Scheduled to the not ready clusters in the opted in queue",
+			queue:     "resuspend",
+			scheduled: []string{"member1", "member2", "member3"},
+			expect:    []string{"member2", "member3"},
+		},
+		{
+			Name:      "Scheduled to the not ready cluster in the other queue",
+			queue:     "default",
+			scheduled: []string{"member2"},
+		},
+		{
+			Name:      "Scheduled to the not ready cluster in the default queue",
+			scheduled: []string{"member2"},
+		},
+		{
+			Name:      "Scheduled to the ready cluster",
+			queue:     "resuspend",
+			scheduled: []string{"member1"},
+		},
+		{
+			Name:      "Scheduled to the removed cluster",
+			queue:     "resuspend",
+			scheduled: []string{"member4"},
+		},
+		{
+			Name:  "Not scheduled yet",
+			queue: "resuspend",
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb"}},
+			Queue:           tc.queue,
+		}
+		for _, name := range tc.scheduled {
+			rbi.ResourceBinding.Spec.Clusters = append(rbi.ResourceBinding.Spec.Clusters, workv1alpha2.TargetCluster{Name: name, Replicas: 1})
+		}
+
+		if got := notReadyClusters(ssn, rbi); !reflect.DeepEqual(got, tc.expect) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expect)
+		}
+	}
+}
+
+func TestResuspendOnClusterFailure(t *testing.T) {
+	key := types.NamespacedName{Namespace: "ns", Name: "rb"}
+
+	testCases := []struct {
+		Name string
+		// ready and scheduled are the readiness of member2 and the clusters the workload is scheduled to in the
+		// second round, it's scheduled to member2 and suspended again in the first round.
+		ready             metav1.ConditionStatus
+		scheduled         string
+		expectUnsuspended []types.NamespacedName
+	}{
+		{
+			Name:      "Blocked while scheduled to the not ready cluster",
+			ready:     metav1.ConditionFalse,
+			scheduled: "member2",
+		},
+		{
+			Name:              "Dispatched after the cluster is ready again",
+			ready:             metav1.ConditionTrue,
+			scheduled:         "member2",
+			expectUnsuspended: []types.NamespacedName{key},
+		},
+		{
+			Name:              "Dispatched after rescheduled to the other cluster",
+			ready:             metav1.ConditionFalse,
+			scheduled:         "member1",
+			expectUnsuspended: []types.NamespacedName{key},
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name, UID: "rb"},
+				Spec:       workv1alpha2.ResourceBindingSpec{Clusters: []workv1alpha2.TargetCluster{{Name: "member2", Replicas: 1}}},
+			},
+			Queue:          "resuspend",
+			DispatchStatus: api.Dispatched,
+			PodGroup:       &schedulingv1beta1.PodGroup{},
+		}
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue: "resuspend",
+			QueueInfos: map[string]*schedulingapi.QueueInfo{"resuspend": {Name: "resuspend", Queue: &scheduling.Queue{
+				ObjectMeta: metav1.ObjectMeta{Name: "resuspend",
+					Annotations: map[string]string{api.ResuspendOnClusterFailureAnnotationKey: "true"}},
+			}}},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{rbi.ResourceBinding.UID: rbi},
+			Clusters: map[string]*clusterv1alpha1.Cluster{
+				"member1": newTestCluster("member1", metav1.ConditionTrue),
+				"member2": newTestCluster("member2", metav1.ConditionFalse),
+			},
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10),
+			feasibilityChecker: feasibility.NewNoopChecker()}
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		dispatcher.resuspendOnClusterFailure(cp, ssn)
+		ssn.CloseSession()
+		if !reflect.DeepEqual(fc.suspended, []types.NamespacedName{key}) {
+			t.Errorf("Test case %s failed, got suspended: %v expect: %v", tc.Name, fc.suspended, key)
+		}
+
+		// The cache suspends it again with the failed placement, then Karmada or the cluster changes.
+		rbi.DispatchStatus = api.Preempted
+		rbi.FailedPlacement = fc.failedPlacements[key]
+		rbi.ResourceBinding.Spec.Clusters = []workv1alpha2.TargetCluster{{Name: tc.scheduled, Replicas: 1}}
+		snapshot.Clusters["member2"] = newTestCluster("member2", tc.ready)
+
+		ssn = dispatcherframework.OpenSession(fc, nil)
+		ssn.SetOperations(dispatcher.newRoundOperations(cp, ssn, &conf.DispatcherConfiguration{}, nil, false))
+		allocate.New().Execute(ssn)
+		ssn.CloseSession()
+
+		if !reflect.DeepEqual(fc.unsuspended, tc.expectUnsuspended) {
+			t.Errorf("Test case %s failed, got unsuspended: %v expect: %v", tc.Name, fc.unsuspended, tc.expectUnsuspended)
+		}
+	}
+}
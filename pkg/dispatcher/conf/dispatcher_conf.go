@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conf
+
+// DispatcherConfiguration defines the configuration of the dispatcher.
+type DispatcherConfiguration struct {
+	// Actions defines the actions list of dispatcher in order.
+	Actions string `yaml:"actions"`
+	// Plugins defines the enabled plugins, a plugin which is not in the list will not be opened in the session.
+	Plugins []PluginOption `yaml:"plugins"`
+	// RateLimit defines how many ResourceBindings can be unsuspended per second.
+	RateLimit RateLimitConfiguration `yaml:"rateLimit"`
+	// Workloads defines the extra GroupVersionKinds that the dispatcher treats as workloads,
+	// like custom CRDs, the built-in workloads are always enabled.
+	Workloads []WorkloadOption `yaml:"workloads"`
+	// QueueDefaults defines the defaults applied to the workloads which didn't set a queue.
+	QueueDefaults QueueDefaults `yaml:"queueDefaults"`
+	// Paused pauses the dispatching of all the queues, the suspended ResourceBindings will wait until it's resumed.
+	Paused bool `yaml:"paused"`
+	// TwoPhaseDispatch reserves the capacity of the selected ResourceBindings in the round first, and releases them
+	// only after each of them is verified again against the whole batch, to avoid over-releasing the capacity
+	// when several large ResourceBindings are selected in the same round.
+	TwoPhaseDispatch bool `yaml:"twoPhaseDispatch"`
+	// EquivalenceCache reuses the blockers of the ResourceBindings evaluated in the previous rounds.
+	EquivalenceCache EquivalenceCacheConfiguration `yaml:"equivalenceCache"`
+	// QueueLogVerbosity raises the verbosity of the decision logs of the queues over the `-v` flag by the queue name,
+	// like debugging the problem of one tenant without flooding the logs of the others.
+	QueueLogVerbosity map[string]int `yaml:"queueLogVerbosity"`
+	// TieBreaker breaks the ties between the ResourceBindings with the same priority and age.
+	TieBreaker TieBreakerConfiguration `yaml:"tieBreaker"`
+	// PreemptionMatrix declares the queues whose dispatched workloads can be suspended again for the workloads of
+	// each queue by the reclaim action, by the name of the reclaiming queue, like `prod: [best-effort]`, `*` means all
+	// the queues. The queues not in it can reclaim from all the queues, and the `volcano.sh/preempt-queues`
+	// annotation of the queue overrides it.
+	PreemptionMatrix map[string][]string `yaml:"preemptionMatrix"`
+	// UnadmittedPolicy handles the ResourceBindings created unsuspended and never admitted, like the suspension
+	// webhook is installed with the `Ignore` failurePolicy and skipped. It's `account`, which is the default, to count
+	// them into the usage of their queues and the metrics as they are, or `resuspend` to suspend them, so they wait
+	// for their queues to admit them.
+	UnadmittedPolicy string `yaml:"unadmittedPolicy"`
+	// MinResourcesSource decides the min resources of the workloads with PodGroups for the queue accounting. It's
+	// `podGroup`, which is the default, to take the `spec.minResources` of the PodGroups when they set them, or
+	// `workload` to resolve them from the workloads always.
+	MinResourcesSource string `yaml:"minResourcesSource"`
+}
+
+// PluginOption defines the options of plugin.
+type PluginOption struct {
+	// The name of Plugin.
+	Name string `yaml:"name"`
+	// Arguments defines the different arguments that can be given to different plugins.
+	Arguments map[string]interface{} `yaml:"arguments"`
+}
+
+// RateLimitConfiguration defines the token bucket used when unsuspending ResourceBindings.
+type RateLimitConfiguration struct {
+	// QPS is the number of ResourceBindings unsuspended per second, zero or negative means no limit.
+	QPS float32 `yaml:"qps"`
+	// Burst is the maximum burst of unsuspend operations.
+	Burst int `yaml:"burst"`
+}
+
+// EquivalenceCacheConfiguration defines the cache of the blockers, a ResourceBinding blocked by the plugins is not
+// evaluated again until it's updated, or the usage or the capacity of the queues changes.
+type EquivalenceCacheConfiguration struct {
+	// Enabled enables the equivalence cache.
+	Enabled bool `yaml:"enabled"`
+	// TTLSeconds is how long a cached blocker is reused at most, so the blockers depending on the state out of the
+	// queues, like the admission checks and the dispatch windows, are evaluated again. It's 30 seconds by default.
+	TTLSeconds int `yaml:"ttlSeconds"`
+}
+
+// WorkloadOption defines a GroupVersionKind which should be handled by the dispatcher.
+type TieBreakerConfiguration struct {
+	// Policy is `uid` to order the ties by the UIDs, which is the default, or `weightedRandom` to order them
+	// randomly weighted by their namespaces.
+	Policy string `yaml:"policy"`
+	// Seed is combined with the cycle of the round, so the random order is reproducible in a round.
+	Seed int64 `yaml:"seed"`
+	// NamespaceWeights are the weights of the namespaces in the weighted random order, 1 by default.
+	NamespaceWeights map[string]float64 `yaml:"namespaceWeights"`
+}
+
+type WorkloadOption struct {
+	Group   string `yaml:"group"`
+	Version string `yaml:"version"`
+	Kind    string `yaml:"kind"`
+}
+
+// QueueDefaults defines the defaults of the Queue.
+type QueueDefaults struct {
+	// Name is the default queue name of the workload, it overrides the `--default-queue` flag when set.
+	Name string `yaml:"name"`
+}
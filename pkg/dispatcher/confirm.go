@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// confirmDispatched marks the dispatched ResourceBindings Failed when Karmada doesn't apply their workloads
+// to the member clusters in the dispatchConfirmTimeout, like no cluster fits them, so their admitted resources
+// are given back to the queues and they wait for the admission again.
+func (dispatcher *Dispatcher) confirmDispatched(cp *controlPlane, ssn *dispatcherframework.Session, now time.Time) {
+	if dispatcher.dispatchConfirmTimeout <= 0 {
+		return
+	}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus != api.Dispatched || rbi.IsCompleted() || rbi.UnSuspendTime.IsZero() ||
+			now.Sub(rbi.UnSuspendTime) < dispatcher.dispatchConfirmTimeout ||
+			utils.IsResourceBindingApplied(rbi.ResourceBinding) ||
+			!dispatcher.ownsResourceBinding(ssn, rbi) {
+			continue
+		}
+
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+		message := fmt.Sprintf("The workload is not applied to the member clusters in %s after it was dispatched.",
+			dispatcher.dispatchConfirmTimeout)
+		if scheduled := meta.FindStatusCondition(rbi.ResourceBinding.Status.Conditions, workv1alpha2.Scheduled); scheduled != nil &&
+			scheduled.Status == metav1.ConditionFalse {
+			message = fmt.Sprintf("%s Scheduled: %s", message, scheduled.Message)
+		}
+		cp.logger().Info(3, queueName, key, "Dispatched ResourceBinding is not applied", "message", message)
+
+		cp.cache.FailResourceBinding(key)
+		cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+			Type:    api.DispatchedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  api.NotAppliedReason,
+			Message: message,
+		})
+		cp.eventf(rbi.ResourceBinding, corev1.EventTypeWarning, api.NotAppliedReason, "%s", message)
+		dispatcher.recordDecision(cp, api.Decision{Time: now, Namespace: key.Namespace, Name: key.Name,
+			Queue: queueName, Evicted: true, Reason: api.NotAppliedReason, Message: message},
+			rbi, ssn.Snapshot.QueueInfos[queueName], nil)
+	}
+}
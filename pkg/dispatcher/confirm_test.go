@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func TestConfirmDispatched(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		Name         string
+		timeout      time.Duration
+		status       api.DispatchStatus
+		unSuspended  time.Duration
+		applied      bool
+		expectFailed []types.NamespacedName
+	}{
+		{
+			Name:         "Not applied after the timeout",
+			timeout:      5 * time.Minute,
+			status:       api.Dispatched,
+			unSuspended:  10 * time.Minute,
+			expectFailed: []types.NamespacedName{{Namespace: "ns", Name: "rb"}},
+		},
+		{
+			Name:        "Not applied in the timeout",
+			timeout:     5 * time.Minute,
+			status:      api.Dispatched,
+			unSuspended: time.Minute,
+		},
+		{
+			Name:        "Applied after the timeout",
+			timeout:     5 * time.Minute,
+			status:      api.Dispatched,
+			unSuspended: 10 * time.Minute,
+			applied:     true,
+		},
+		{
+			Name:        "Suspended workload",
+			timeout:     5 * time.Minute,
+			status:      api.Pending,
+			unSuspended: 10 * time.Minute,
+		},
+		{
+			Name:        "Confirmation disabled",
+			status:      api.Dispatched,
+			unSuspended: 10 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		rb := &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb", UID: "rb"}}
+		if tc.applied {
+			rb.Status.Conditions = []metav1.Condition{{Type: workv1alpha2.FullyApplied, Status: metav1.ConditionTrue}}
+		}
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: rb,
+			Queue:           "default",
+			DispatchStatus:  tc.status,
+			UnSuspendTime:   now.Add(-tc.unSuspended),
+		}
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue:         "default",
+			QueueInfos:           map[string]*schedulingapi.QueueInfo{"default": {Name: "default"}},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{rb.UID: rbi},
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{dispatchConfirmTimeout: tc.timeout}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10),
+			recorder: record.NewFakeRecorder(10)}
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		dispatcher.confirmDispatched(cp, ssn, now)
+		ssn.CloseSession()
+
+		if !reflect.DeepEqual(fc.failed, tc.expectFailed) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, fc.failed, tc.expectFailed)
+		}
+	}
+}
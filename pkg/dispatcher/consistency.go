@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+)
+
+// checkConsistency compares the cache of the control plane with the apiserver, and records the differences by the metrics.
+func (dispatcher *Dispatcher) checkConsistency(ctx context.Context, cp *controlPlane, heal bool) (*api.ConsistencyReport, error) {
+	report, err := cp.cache.CheckConsistency(ctx, heal)
+	if err != nil {
+		klog.Errorf("Failed to check the consistency of the cache of control plane <%s>, err: %v", cp.name, err)
+		return nil, err
+	}
+	metrics.UpdateCacheInconsistencies(cp.name, "ResourceBinding", len(report.ResourceBindings.Missing),
+		len(report.ResourceBindings.Stale), len(report.ResourceBindings.Outdated))
+	metrics.UpdateCacheInconsistencies(cp.name, "Queue", len(report.Queues.Missing),
+		len(report.Queues.Stale), len(report.Queues.Outdated))
+	return report, nil
+}
+
+// collectGarbage removes the stale objects from the cache of the control plane, and records them by the metrics.
+func (dispatcher *Dispatcher) collectGarbage(cp *controlPlane) {
+	for kind, count := range cp.cache.CollectGarbage() {
+		metrics.UpdateCacheGarbageCollected(cp.name, kind, count)
+	}
+}
+
+// checkEventErrors resyncs the kinds of the cache of the control plane whose failed events exceeded the budget
+// since the last check, and collects the garbage left by the missed delete events. It returns the resynced kinds.
+func (dispatcher *Dispatcher) checkEventErrors(cp *controlPlane) []string {
+	var resynced []string
+	for kind, count := range cp.cache.TakeEventErrors() {
+		exceeded := count > dispatcher.eventErrorBudget
+		metrics.UpdateCacheEventErrors(cp.name, kind, count, exceeded)
+		if !exceeded {
+			continue
+		}
+		klog.Warningf("Failed <%d> %s events of control plane <%s> exceeded the budget <%d>, resync them.",
+			count, kind, cp.name, dispatcher.eventErrorBudget)
+		cp.cache.Resync(kind)
+		metrics.UpdateCacheResyncs(cp.name, kind)
+		resynced = append(resynced, kind)
+	}
+	if len(resynced) > 0 {
+		dispatcher.collectGarbage(cp)
+	}
+	sort.Strings(resynced)
+	return resynced
+}
+
+// consistencyHandler checks the consistency of the cache by `GET /debug/cache/consistency?controlPlane=<name>`,
+// `POST` heals the differences by resyncing the objects from the apiserver as well.
+func (dispatcher *Dispatcher) consistencyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cp, found := dispatcher.controlPlaneOf(r)
+	if !found {
+		http.Error(w, "control plane not found", http.StatusNotFound)
+		return
+	}
+
+	report, err := dispatcher.checkConsistency(r.Context(), cp, r.Method == http.MethodPost)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, report)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCheckEventErrors(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		budget         int
+		eventErrors    map[string]int
+		expectResynced []string
+	}{
+		{
+			Name:        "No event errors",
+			budget:      10,
+			eventErrors: nil,
+		},
+		{
+			Name:        "Event errors in the budget",
+			budget:      10,
+			eventErrors: map[string]int{"ResourceBinding": 10, "Queue": 1},
+		},
+		{
+			Name:           "Event errors exceeding the budget",
+			budget:         10,
+			eventErrors:    map[string]int{"ResourceBinding": 11, "Queue": 1, "Cluster": 20},
+			expectResynced: []string{"Cluster", "ResourceBinding"},
+		},
+		{
+			Name:           "Zero budget",
+			budget:         0,
+			eventErrors:    map[string]int{"Namespace": 1},
+			expectResynced: []string{"Namespace"},
+		},
+	}
+
+	for _, tc := range testCases {
+		fc := &fakeCache{eventErrors: tc.eventErrors}
+		dispatcher := &Dispatcher{eventErrorBudget: tc.budget}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc}
+
+		resynced := dispatcher.checkEventErrors(cp)
+		sort.Strings(fc.resynced)
+		if !reflect.DeepEqual(resynced, tc.expectResynced) || !reflect.DeepEqual(fc.resynced, tc.expectResynced) {
+			t.Errorf("Test case %s failed, got: %v resynced %v expect: %v", tc.Name, resynced, fc.resynced, tc.expectResynced)
+		}
+		if fc.eventErrors != nil {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, fc.eventErrors, "event errors taken")
+		}
+	}
+}
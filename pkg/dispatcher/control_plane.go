@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/feasibility"
+)
+
+const (
+	// defaultControlPlane is the name of the Karmada control plane set by the `--kubeconfig` flag.
+	defaultControlPlane = "default"
+
+	// minUnhealthyTimeout is the minimum duration without a finished round before a control plane is unhealthy.
+	minUnhealthyTimeout = time.Minute
+)
+
+// controlPlane is a Karmada control plane served by the dispatcher, each one has its own cache and dispatching loop,
+// so a slow or unreachable control plane doesn't block the others.
+type controlPlane struct {
+	name string
+	// kubeConfig is the kubeconfig of the control plane, empty means the one set by the `--kubeconfig` flag.
+	kubeConfig string
+
+	cache cache.DispatcherCacheInterface
+	// feasibilityChecker checks whether the replicas can fit in the clusters of the control plane before unsuspending.
+	feasibilityChecker feasibility.FeasibilityChecker
+	// recorder records the events of the ResourceBindings in the control plane.
+	recorder record.EventRecorder
+	// decisions records the last decisions served by the debug endpoint.
+	decisions *decisionRecorder
+	// rateLimiter limits the unsuspend operations, it is nil when the rate limit is disabled,
+	// it's protected by the mutex of the dispatcher.
+	rateLimiter flowcontrol.RateLimiter
+	// cycle is the number of the dispatching rounds started, and queueLogVerbosity is the one of the configuration
+	// in the current round, they are only accessed by the dispatching goroutine.
+	cycle             uint64
+	queueLogVerbosity map[string]int
+	// equivalence caches the blockers of the ResourceBindings between the rounds, it's only accessed by the
+	// dispatching goroutine.
+	equivalence equivalenceCache
+	// manual records the ResourceBindings held and released manually by the endpoints.
+	manual manualOperations
+	// expired records the ResourceBindings expired until their expired annotations are observed, it's only accessed
+	// by the dispatching goroutine.
+	expired map[types.UID]bool
+
+	mutex sync.Mutex
+	// synced is true after the cache of the control plane is synced.
+	synced bool
+	// syncedTime is the time when the cache of the control plane is synced.
+	syncedTime time.Time
+	// lastDispatchTime is the finish time of the last dispatching round.
+	lastDispatchTime time.Time
+	// lastCycle is the cycle of the last dispatching round.
+	lastCycle uint64
+	// dispatched is closed when a dispatching round is finished to notify the snapshot streams, it's created again
+	// by the next stream waiting for the round.
+	dispatched chan struct{}
+}
+
+// parseControlPlanes parses the control planes by the `--karmada-kubeconfigs` flag like `<name>=<kubeconfig>,...`,
+// only the default control plane is served when the flag is empty.
+func parseControlPlanes(spec string) ([]*controlPlane, error) {
+	if strings.TrimSpace(spec) == "" {
+		return []*controlPlane{{name: defaultControlPlane}}, nil
+	}
+
+	controlPlanes := []*controlPlane{}
+	names := map[string]bool{}
+	for _, item := range strings.Split(spec, ",") {
+		name, kubeConfig, found := strings.Cut(strings.TrimSpace(item), "=")
+		name, kubeConfig = strings.TrimSpace(name), strings.TrimSpace(kubeConfig)
+		if !found || name == "" || kubeConfig == "" {
+			return nil, fmt.Errorf("invalid control plane %q, expect <name>=<kubeconfig>", item)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicated control plane %s", name)
+		}
+		names[name] = true
+		controlPlanes = append(controlPlanes, &controlPlane{name: name, kubeConfig: kubeConfig})
+	}
+	return controlPlanes, nil
+}
+
+func (cp *controlPlane) markSynced(now time.Time) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	cp.synced = true
+	cp.syncedTime = now
+}
+
+func (cp *controlPlane) markDispatched(now time.Time) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	cp.lastDispatchTime = now
+	cp.lastCycle = cp.cycle
+	if cp.dispatched != nil {
+		close(cp.dispatched)
+		cp.dispatched = nil
+	}
+}
+
+// dispatchedSignal returns the channel closed when the next dispatching round is finished, and the cycle of the last one.
+func (cp *controlPlane) dispatchedSignal() (<-chan struct{}, uint64) {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	if cp.dispatched == nil {
+		cp.dispatched = make(chan struct{})
+	}
+	return cp.dispatched, cp.lastCycle
+}
+
+// nextCycle returns the cycle of the next dispatching round, for replaying it out of the dispatching goroutine.
+func (cp *controlPlane) nextCycle() uint64 {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+	return cp.lastCycle + 1
+}
+
+// eventf records the event of the current round, it's annotated by the cycle of the round, so it can be correlated
+// with the logs, the decisions and the audit events of the round.
+func (cp *controlPlane) eventf(object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	cp.recorder.AnnotatedEventf(object, map[string]string{api.DispatchCycleAnnotationKey: strconv.FormatUint(cp.cycle, 10)},
+		eventType, reason, messageFmt, args...)
+}
+
+// health checks whether the control plane is synced and dispatched in time. The control plane which is not synced
+// yet is healthy but not ready, the synced one is unhealthy when no dispatching round is finished in the timeout.
+func (cp *controlPlane) health(now time.Time, timeout time.Duration) api.ControlPlaneHealth {
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	health := api.ControlPlaneHealth{Name: cp.name, Synced: cp.synced, Ready: cp.synced, LastDispatchTime: cp.lastDispatchTime}
+	lastProgress := cp.lastDispatchTime
+	if lastProgress.Before(cp.syncedTime) {
+		lastProgress = cp.syncedTime
+	}
+	switch {
+	case !cp.synced:
+		health.Healthy = true
+		health.Message = "The cache is not synced yet."
+	case now.Sub(lastProgress) > timeout:
+		health.Message = fmt.Sprintf("No dispatching round is finished in %v.", timeout)
+	default:
+		health.Healthy = true
+	}
+	return health
+}
+
+// controlPlaneOf gets the control plane by the `controlPlane` query parameter, the first one is used by default.
+func (dispatcher *Dispatcher) controlPlaneOf(r *http.Request) (*controlPlane, bool) {
+	return dispatcher.controlPlaneByName(r.URL.Query().Get("controlPlane"))
+}
+
+// controlPlaneByName gets the control plane by the name, the first one is used when it's empty.
+func (dispatcher *Dispatcher) controlPlaneByName(name string) (*controlPlane, bool) {
+	if name == "" {
+		return dispatcher.controlPlanes[0], true
+	}
+	for _, cp := range dispatcher.controlPlanes {
+		if cp.name == name {
+			return cp, true
+		}
+	}
+	return nil, false
+}
+
+// healthHandler serves the health of the control planes by `GET /healthz` for the liveness probe,
+// it responds 503 when the dispatching rounds of any of them are stuck.
+func (dispatcher *Dispatcher) healthHandler(w http.ResponseWriter, r *http.Request) {
+	dispatcher.probeHandler(w, r, func(health api.ControlPlaneHealth) bool { return health.Healthy })
+}
+
+// readyHandler serves the readiness of the control planes by `GET /readyz` for the readiness probe, it responds 503
+// until the informers of all of them are synced. The endpoints are served after the dispatcher is started, which
+// happens after the leadership is held when the leader election is enabled, so the standby one is never ready.
+func (dispatcher *Dispatcher) readyHandler(w http.ResponseWriter, r *http.Request) {
+	dispatcher.probeHandler(w, r, func(health api.ControlPlaneHealth) bool { return health.Ready })
+}
+
+func (dispatcher *Dispatcher) probeHandler(w http.ResponseWriter, r *http.Request, passed func(health api.ControlPlaneHealth) bool) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	timeout := dispatcher.unhealthyTimeout
+	if timeout <= 0 {
+		timeout = max(10*dispatcher.dispatchPeriod, minUnhealthyTimeout)
+	}
+	now := time.Now()
+	healths := make([]api.ControlPlaneHealth, 0, len(dispatcher.controlPlanes))
+	allPassed := true
+	for _, cp := range dispatcher.controlPlanes {
+		health := cp.health(now, timeout)
+		allPassed = allPassed && passed(health)
+		healths = append(healths, health)
+	}
+	if !allPassed {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	writeJSON(w, healths)
+}
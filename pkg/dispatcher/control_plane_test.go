@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestParseControlPlanes(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		spec         string
+		expectPlanes map[string]string
+		expectErr    bool
+	}{
+		{
+			Name:         "Default control plane",
+			spec:         "",
+			expectPlanes: map[string]string{defaultControlPlane: ""},
+		},
+		{
+			Name:         "Multiple control planes",
+			spec:         "us=/etc/karmada/us.config, eu = /etc/karmada/eu.config",
+			expectPlanes: map[string]string{"us": "/etc/karmada/us.config", "eu": "/etc/karmada/eu.config"},
+		},
+		{
+			Name:      "Without kubeconfig",
+			spec:      "us=/etc/karmada/us.config,eu",
+			expectErr: true,
+		},
+		{
+			Name:      "Duplicated name",
+			spec:      "us=/etc/karmada/us.config,us=/etc/karmada/eu.config",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		controlPlanes, err := parseControlPlanes(tc.spec)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("Test case %s failed, got err: %v expect err: %v", tc.Name, err, tc.expectErr)
+			continue
+		}
+		if tc.expectErr {
+			continue
+		}
+		planes := map[string]string{}
+		for _, cp := range controlPlanes {
+			planes[cp.name] = cp.kubeConfig
+		}
+		if !reflect.DeepEqual(planes, tc.expectPlanes) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, planes, tc.expectPlanes)
+		}
+	}
+}
+
+func TestControlPlaneHealth(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		Name          string
+		synced        bool
+		syncedTime    time.Time
+		lastDispatch  time.Time
+		expectHealthy bool
+		expectReady   bool
+	}{
+		{Name: "Not synced", expectHealthy: true, expectReady: false},
+		{Name: "Synced recently", synced: true, syncedTime: now.Add(-time.Second), expectHealthy: true, expectReady: true},
+		{Name: "Dispatched recently", synced: true, syncedTime: now.Add(-time.Hour), lastDispatch: now.Add(-time.Second), expectHealthy: true, expectReady: true},
+		{Name: "Not dispatched for a long time", synced: true, syncedTime: now.Add(-2 * time.Hour), lastDispatch: now.Add(-time.Hour), expectHealthy: false, expectReady: true},
+	}
+
+	for _, tc := range testCases {
+		cp := &controlPlane{name: "test", synced: tc.synced, syncedTime: tc.syncedTime, lastDispatchTime: tc.lastDispatch}
+		if health := cp.health(now, minUnhealthyTimeout); health.Healthy != tc.expectHealthy || health.Ready != tc.expectReady {
+			t.Errorf("Test case %s failed, got: %v expect: %v %v", tc.Name, health, tc.expectHealthy, tc.expectReady)
+		}
+	}
+}
+
+func TestControlPlaneEventf(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	cp := &controlPlane{name: defaultControlPlane, recorder: recorder, cycle: 7}
+	cp.eventf(&workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb"}},
+		corev1.EventTypeWarning, api.ExpiredReason, "expired after %s", "1h")
+
+	expect := "Warning Expired expired after 1h map[" + api.DispatchCycleAnnotationKey + ":7]"
+	if got := <-recorder.Events; got != expect {
+		t.Errorf("Test case cycle annotation failed, got: %s expect: %s", got, expect)
+	}
+}
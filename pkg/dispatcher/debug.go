@@ -0,0 +1,339 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/capacity"
+)
+
+// debugAuthCacheTTL is how long an allowed token is cached, to avoid reviewing every request.
+const debugAuthCacheTTL = 10 * time.Second
+
+var (
+	errUnauthenticated = errors.New("the token is not authenticated")
+	errForbidden       = errors.New("the user is not allowed to access the debug endpoint")
+)
+
+// anonymousUser is the user of the requests when the authentication of the endpoints is disabled.
+const anonymousUser = "system:anonymous"
+
+// debugAuthenticator authenticates the requests of the debug endpoints by the TokenReview, and authorizes them by
+// the SubjectAccessReview of the non-resource url, the user needs the permission of the http method to the path,
+// like `get` for the GET requests of `/debug/*` and `post` for the POST requests of `/dispatcher/hold`.
+type debugAuthenticator struct {
+	kubeClient kubernetes.Interface
+
+	mutex sync.Mutex
+	// allowed[hash of token + verb + path] is the user of the token and the time when the allowed result expires,
+	// the tokens are hashed so they're not kept in the memory.
+	allowed map[string]allowedUser
+}
+
+type allowedUser struct {
+	name   string
+	expiry time.Time
+}
+
+// userContextKey is the key of the authenticated user in the context of the request.
+type userContextKey struct{}
+
+func newDebugAuthenticator(kubeClient kubernetes.Interface) *debugAuthenticator {
+	return &debugAuthenticator{
+		kubeClient: kubeClient,
+		allowed:    map[string]allowedUser{},
+	}
+}
+
+// requestUser returns the user authenticated for the request, it's anonymous when the authentication is disabled.
+func requestUser(r *http.Request) string {
+	if user, ok := r.Context().Value(userContextKey{}).(string); ok && user != "" {
+		return user
+	}
+	return anonymousUser
+}
+
+// wrap authenticates and authorizes the request before the handler, the authenticator nil means no authentication.
+func (da *debugAuthenticator) wrap(handler http.HandlerFunc) http.HandlerFunc {
+	if da == nil {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if token == "" || token == r.Header.Get("Authorization") {
+			http.Error(w, "bearer token is required", http.StatusUnauthorized)
+			return
+		}
+		user, code, err := da.review(r.Context(), token, strings.ToLower(r.Method), r.URL.Path)
+		if err != nil {
+			klog.V(3).Infof("Request %s %s to the debug endpoint is refused, err: %v", r.Method, r.URL.Path, err)
+			http.Error(w, err.Error(), code)
+			return
+		}
+		handler(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, user)))
+	}
+}
+
+// review returns the user of the token, the http status code and the error if the token is not allowed to
+// access the path by the verb.
+func (da *debugAuthenticator) review(ctx context.Context, token, verb, path string) (string, int, error) {
+	tokenHash := sha256.Sum256([]byte(token))
+	cacheKey := hex.EncodeToString(tokenHash[:]) + " " + verb + " " + path
+	da.mutex.Lock()
+	allowed, found := da.allowed[cacheKey]
+	da.mutex.Unlock()
+	if found && time.Now().Before(allowed.expiry) {
+		return allowed.name, http.StatusOK, nil
+	}
+
+	tokenReview, err := da.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	if !tokenReview.Status.Authenticated {
+		return "", http.StatusUnauthorized, errUnauthenticated
+	}
+
+	user := tokenReview.Status.User
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := da.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+				Path: path,
+				Verb: verb,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	if !accessReview.Status.Allowed {
+		return "", http.StatusForbidden, errForbidden
+	}
+
+	da.mutex.Lock()
+	da.allowed[cacheKey] = allowedUser{name: user.Username, expiry: time.Now().Add(debugAuthCacheTTL)}
+	for key, allowed := range da.allowed {
+		if time.Now().After(allowed.expiry) {
+			delete(da.allowed, key)
+		}
+	}
+	da.mutex.Unlock()
+	return user.Username, http.StatusOK, nil
+}
+
+// newDebugTLSConfig loads the serving certificate of the debug endpoints, a self-signed one is generated when the
+// files are not set.
+func newDebugTLSConfig(certFile, keyFile string) (*tls.Config, error) {
+	var certificate tls.Certificate
+	var err error
+	if certFile != "" || keyFile != "" {
+		certificate, err = tls.LoadX509KeyPair(certFile, keyFile)
+	} else {
+		var certPEM, keyPEM []byte
+		if certPEM, keyPEM, err = certutil.GenerateSelfSignedCertKey(dispatcherName, nil, nil); err != nil {
+			return nil, err
+		}
+		klog.Warningf("The debug endpoints are served by a self-signed certificate, set --debug-cert-file and --debug-key-file to verify it.")
+		certificate, err = tls.X509KeyPair(certPEM, keyPEM)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}, nil
+}
+
+// serveDebugEndpoints serves the admin endpoints, which change the dispatching, and the debug endpoints over TLS
+// on their own address, apart from the metrics served in plain http.
+func (dispatcher *Dispatcher) serveDebugEndpoints(address string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dispatcher/pause", dispatcher.debugAuthenticator.wrap(dispatcher.pauseHandler))
+	mux.HandleFunc("/dispatcher/resume", dispatcher.debugAuthenticator.wrap(dispatcher.resumeHandler))
+	mux.HandleFunc("/dispatcher/hold", dispatcher.debugAuthenticator.wrap(dispatcher.holdHandler))
+	mux.HandleFunc("/dispatcher/release", dispatcher.debugAuthenticator.wrap(dispatcher.releaseHandler))
+	mux.HandleFunc("/debug/explain", dispatcher.debugAuthenticator.wrap(dispatcher.explainHandler))
+	mux.HandleFunc("/debug/cache/queues", dispatcher.debugAuthenticator.wrap(dispatcher.queuesHandler))
+	mux.HandleFunc("/debug/queues/usage", dispatcher.debugAuthenticator.wrap(dispatcher.queueUsagesHandler))
+	mux.HandleFunc("/debug/cache/resourcebindings", dispatcher.debugAuthenticator.wrap(dispatcher.resourceBindingsHandler))
+	mux.HandleFunc("/debug/decisions", dispatcher.debugAuthenticator.wrap(dispatcher.decisionsHandler))
+	mux.HandleFunc("/debug/cache/consistency", dispatcher.debugAuthenticator.wrap(dispatcher.consistencyHandler))
+	if dispatcher.enableProfiling {
+		dispatcher.registerProfilingHandlers(mux)
+	}
+
+	server := &http.Server{Addr: address, Handler: mux, TLSConfig: dispatcher.debugTLSConfig}
+	klog.Fatalf("Debug Https Server failed %s", server.ListenAndServeTLS("", ""))
+}
+
+// queuesHandler serves the queues in the cache by `GET /debug/cache/queues?controlPlane=<name>`.
+func (dispatcher *Dispatcher) queuesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cp, found := dispatcher.controlPlaneOf(r)
+	if !found {
+		http.Error(w, "control plane not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, debugQueues(cp.cache.Snapshot()))
+}
+
+// debugQueues returns the queues in the snapshot sorted by the name.
+func debugQueues(snapshot *cache.DispatcherCacheSnapshot) []api.DebugQueue {
+	queues := make([]api.DebugQueue, 0, len(snapshot.QueueInfos))
+	for _, queue := range snapshot.QueueInfos {
+		if queue.Queue == nil {
+			continue
+		}
+		queues = append(queues, api.DebugQueue{
+			Name:        queue.Name,
+			State:       string(queue.Queue.Status.State),
+			Priority:    queue.Queue.Spec.Priority,
+			Weight:      queue.Queue.Spec.Weight,
+			Guarantee:   queue.Queue.Spec.Guarantee.Resource,
+			Deserved:    queue.Queue.Spec.Deserved,
+			Capability:  queue.Queue.Spec.Capability,
+			Annotations: queue.Queue.Annotations,
+		})
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].Name < queues[j].Name
+	})
+	return queues
+}
+
+// queueUsagesHandler serves the deserved, used, borrowed and lent resources of the queues computed from the latest
+// snapshot by `GET /debug/queues/usage?controlPlane=<name>`, for the chargeback dashboards.
+func (dispatcher *Dispatcher) queueUsagesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cp, found := dispatcher.controlPlaneOf(r)
+	if !found {
+		http.Error(w, "control plane not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, capacity.QueueUsages(cp.cache.Snapshot()))
+}
+
+// resourceBindingsHandler serves the ResourceBindings in the cache by
+// `GET /debug/cache/resourcebindings?namespace=<ns>&queue=<name>&controlPlane=<name>`, the filters are optional.
+func (dispatcher *Dispatcher) resourceBindingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cp, found := dispatcher.controlPlaneOf(r)
+	if !found {
+		http.Error(w, "control plane not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, debugResourceBindings(cp.cache.Snapshot(), r.URL.Query().Get("namespace"), r.URL.Query().Get("queue")))
+}
+
+// debugResourceBindings returns the ResourceBindings in the snapshot sorted by the namespace and the name,
+// the empty namespace or queue matches all.
+func debugResourceBindings(snapshot *cache.DispatcherCacheSnapshot, namespace, queueName string) []api.DebugResourceBinding {
+	resourceBindings := make([]api.DebugResourceBinding, 0, len(snapshot.ResourceBindingInfos))
+	for _, rbi := range snapshot.ResourceBindingInfos {
+		rbQueue := rbi.Queue
+		if rbQueue == "" {
+			rbQueue = snapshot.DefaultQueue
+		}
+		if (namespace != "" && rbi.ResourceBinding.Namespace != namespace) || (queueName != "" && rbQueue != queueName) {
+			continue
+		}
+
+		resourceBinding := api.DebugResourceBinding{
+			Namespace:         rbi.ResourceBinding.Namespace,
+			Name:              rbi.ResourceBinding.Name,
+			Queue:             rbQueue,
+			Priority:          rbi.Priority,
+			PriorityClassName: rbi.PriorityClassName,
+			DispatchStatus:    rbi.DispatchStatus.String(),
+			MinResources:      rbi.MinResources,
+			FirstSeenTime:     rbi.FirstSeenTime,
+			EnqueueTime:       rbi.EnqueueTime,
+			UnSuspendTime:     rbi.UnSuspendTime,
+			TransitionTimes:   rbi.TransitionTimesByName(),
+		}
+		if rbi.PodGroup != nil {
+			resourceBinding.PodGroup = rbi.PodGroup.Name
+			resourceBinding.PodGroupPhase = string(rbi.PodGroup.Status.Phase)
+		}
+		resourceBindings = append(resourceBindings, resourceBinding)
+	}
+	sort.Slice(resourceBindings, func(i, j int) bool {
+		if resourceBindings[i].Namespace != resourceBindings[j].Namespace {
+			return resourceBindings[i].Namespace < resourceBindings[j].Namespace
+		}
+		return resourceBindings[i].Name < resourceBindings[j].Name
+	})
+	return resourceBindings
+}
+
+// decisionsHandler serves the last decisions of the dispatcher by `GET /debug/decisions?controlPlane=<name>`, from the oldest one.
+func (dispatcher *Dispatcher) decisionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cp, found := dispatcher.controlPlaneOf(r)
+	if !found {
+		http.Error(w, "control plane not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, cp.decisions.list())
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		klog.Errorf("Failed to write the response of the debug endpoint, err: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func TestDebugAuthenticatorReview(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status = authenticationv1.TokenReviewStatus{Authenticated: review.Spec.Token == "secret-token",
+			User: authenticationv1.UserInfo{Username: "admin"}}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.NonResourceAttributes.Verb == "get"
+		return true, review, nil
+	})
+	da := newDebugAuthenticator(kubeClient)
+
+	testCases := []struct {
+		Name       string
+		token      string
+		verb       string
+		expectUser string
+		expectCode int
+	}{
+		{Name: "Allowed", token: "secret-token", verb: "get", expectUser: "admin", expectCode: http.StatusOK},
+		{Name: "Allowed by the cache", token: "secret-token", verb: "get", expectUser: "admin", expectCode: http.StatusOK},
+		{Name: "Forbidden", token: "secret-token", verb: "post", expectCode: http.StatusForbidden},
+		{Name: "Unauthenticated", token: "other-token", verb: "get", expectCode: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		user, code, _ := da.review(context.TODO(), tc.token, tc.verb, "/debug/decisions")
+		if user != tc.expectUser || code != tc.expectCode {
+			t.Errorf("Test case %s failed, got: %s %d expect: %s %d", tc.Name, user, code, tc.expectUser, tc.expectCode)
+		}
+	}
+	if reviews := len(kubeClient.Actions()); reviews != 5 {
+		t.Errorf("Test case cache failed, got reviews: %d expect: 5", reviews)
+	}
+	for key := range da.allowed {
+		if strings.Contains(key, "secret-token") {
+			t.Errorf("Test case cache failed, got the raw token in the key: %s", key)
+		}
+	}
+}
+
+func TestNewDebugTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("localhost", nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to generate the certificate, err: %v", err)
+	}
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if err = os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name      string
+		certFile  string
+		keyFile   string
+		expectErr bool
+	}{
+		{Name: "Self-signed certificate"},
+		{Name: "Certificate files", certFile: certFile, keyFile: keyFile},
+		{Name: "Key file missing", certFile: certFile, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		config, err := newDebugTLSConfig(tc.certFile, tc.keyFile)
+		if (err != nil) != tc.expectErr || (err == nil && len(config.Certificates) != 1) {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectErr)
+		}
+	}
+}
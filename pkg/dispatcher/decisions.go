@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/audit"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+// decisionRecorder keeps the last decisions of the dispatcher in a ring buffer. A ResourceBinding is blocked
+// by the same reason in every round usually, so the decision is recorded only when its reason changed.
+type decisionRecorder struct {
+	mutex     sync.Mutex
+	decisions []api.Decision
+	// next is the index of the ring buffer to write, the buffer is full when the count reaches its size.
+	next  int
+	count int
+	// lastReasons records the reason of the last decision of each ResourceBinding.
+	lastReasons map[types.NamespacedName]string
+}
+
+func newDecisionRecorder(size int) *decisionRecorder {
+	if size < 0 {
+		size = 0
+	}
+	return &decisionRecorder{
+		decisions:   make([]api.Decision, size),
+		lastReasons: map[types.NamespacedName]string{},
+	}
+}
+
+// record records the decision, it returns false when the reason of the ResourceBinding is not changed.
+func (dr *decisionRecorder) record(decision api.Decision) bool {
+	dr.mutex.Lock()
+	defer dr.mutex.Unlock()
+
+	key := types.NamespacedName{Namespace: decision.Namespace, Name: decision.Name}
+	if reason, found := dr.lastReasons[key]; found && reason == decision.Reason {
+		return false
+	}
+	if decision.Dispatched {
+		delete(dr.lastReasons, key)
+	} else {
+		dr.lastReasons[key] = decision.Reason
+	}
+
+	if len(dr.decisions) == 0 {
+		return true
+	}
+	dr.decisions[dr.next] = decision
+	dr.next = (dr.next + 1) % len(dr.decisions)
+	if dr.count < len(dr.decisions) {
+		dr.count++
+	}
+	return true
+}
+
+// recordDecision records the decision of the current round, and writes it to the audit log with the inputs and
+// patches it onto the ResourceBinding when it's changed.
+func (dispatcher *Dispatcher) recordDecision(cp *controlPlane, decision api.Decision, rbi *api.ResourceBindingInfo, queue *schedulingapi.QueueInfo, queueAllocated corev1.ResourceList) {
+	decision.Cycle = cp.cycle
+	if !cp.decisions.record(decision) {
+		return
+	}
+	if dispatcher.annotateDecisions {
+		if value, err := json.Marshal(decision.Annotation()); err == nil {
+			cp.cache.AnnotateResourceBinding(types.NamespacedName{Namespace: decision.Namespace, Name: decision.Name},
+				api.LastDispatchDecisionAnnotationKey, string(value))
+		}
+	}
+
+	action := audit.ActionSuspend
+	if decision.Dispatched {
+		action = audit.ActionUnsuspend
+	} else if decision.Evicted {
+		action = audit.ActionPreempt
+	}
+	event := &audit.Event{
+		Time:               decision.Time,
+		ControlPlane:       cp.name,
+		Action:             action,
+		Namespace:          decision.Namespace,
+		Name:               decision.Name,
+		Queue:              decision.Queue,
+		Priority:           rbi.Priority,
+		PriorityClassName:  rbi.PriorityClassName,
+		PriorityOverridden: rbi.PriorityOverridden,
+		Request:            rbi.MinResources.DeepCopy(),
+		QueueAllocated:     queueAllocated.DeepCopy(),
+		Plugin:             decision.Plugin,
+		Reason:             decision.Reason,
+		Message:            decision.Message,
+		ClusterCost:        rbi.ClusterCost,
+		User:               decision.User,
+		Cycle:              decision.Cycle,
+	}
+	if queue != nil && queue.Queue != nil {
+		event.QueueCapability = queue.Queue.Spec.Capability.DeepCopy()
+	}
+	dispatcher.auditLogger.Log(event)
+}
+
+// retain forgets the last reasons of the ResourceBindings which are not suspended in the snapshot.
+func (dr *decisionRecorder) retain(snapshot *cache.DispatcherCacheSnapshot) {
+	suspended := map[types.NamespacedName]struct{}{}
+	for _, rbi := range snapshot.ResourceBindingInfos {
+		if !rbi.DispatchStatus.IsDispatched() {
+			suspended[types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}] = struct{}{}
+		}
+	}
+
+	dr.mutex.Lock()
+	defer dr.mutex.Unlock()
+	for key := range dr.lastReasons {
+		if _, found := suspended[key]; !found {
+			delete(dr.lastReasons, key)
+		}
+	}
+}
+
+// list returns the decisions from the oldest one.
+func (dr *decisionRecorder) list() []api.Decision {
+	dr.mutex.Lock()
+	defer dr.mutex.Unlock()
+
+	decisions := make([]api.Decision, 0, dr.count)
+	start := (dr.next - dr.count + len(dr.decisions)) % max(len(dr.decisions), 1)
+	for i := 0; i < dr.count; i++ {
+		decisions = append(decisions, dr.decisions[(start+i)%len(dr.decisions)])
+	}
+	return decisions
+}
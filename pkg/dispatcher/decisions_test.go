@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func TestDecisionRecorder(t *testing.T) {
+	blocked := func(name, reason string) api.Decision {
+		return api.Decision{Namespace: "ns", Name: name, Reason: reason}
+	}
+	dispatched := func(name string) api.Decision {
+		return api.Decision{Namespace: "ns", Name: name, Dispatched: true, Reason: api.DispatchedReason}
+	}
+
+	testCases := []struct {
+		Name      string
+		size      int
+		decisions []api.Decision
+		// retain is the suspended ResourceBindings retained before the last decision, nil means not retain.
+		retain []string
+		expect []api.Decision
+	}{
+		{
+			Name:      "Skip the same reason",
+			size:      10,
+			decisions: []api.Decision{blocked("a", "QuotaExceeded"), blocked("a", "QuotaExceeded"), blocked("a", "Infeasible"), dispatched("a")},
+			expect:    []api.Decision{blocked("a", "QuotaExceeded"), blocked("a", "Infeasible"), dispatched("a")},
+		},
+		{
+			Name:      "Keep the last decisions",
+			size:      2,
+			decisions: []api.Decision{blocked("a", "QuotaExceeded"), blocked("b", "QuotaExceeded"), blocked("c", "QuotaExceeded")},
+			expect:    []api.Decision{blocked("b", "QuotaExceeded"), blocked("c", "QuotaExceeded")},
+		},
+		{
+			Name:      "Record again after the ResourceBinding is forgotten",
+			size:      10,
+			decisions: []api.Decision{blocked("a", "QuotaExceeded"), blocked("a", "QuotaExceeded")},
+			retain:    []string{},
+			expect:    []api.Decision{blocked("a", "QuotaExceeded"), blocked("a", "QuotaExceeded")},
+		},
+		{
+			Name:      "Zero size",
+			size:      0,
+			decisions: []api.Decision{blocked("a", "QuotaExceeded")},
+			expect:    []api.Decision{},
+		},
+	}
+
+	for _, tc := range testCases {
+		dr := newDecisionRecorder(tc.size)
+		for i, decision := range tc.decisions {
+			if i == len(tc.decisions)-1 && tc.retain != nil {
+				snapshot := &cache.DispatcherCacheSnapshot{ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{}}
+				for _, name := range tc.retain {
+					snapshot.ResourceBindingInfos[types.UID(name)] = &api.ResourceBindingInfo{
+						ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}},
+						DispatchStatus:  api.Pending,
+					}
+				}
+				dr.retain(snapshot)
+			}
+			dr.record(decision)
+		}
+		if got := dr.list(); !reflect.DeepEqual(got, tc.expect) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expect)
+		}
+	}
+}
+
+func TestDecisionAnnotation(t *testing.T) {
+	now := time.Date(2024, 12, 1, 8, 0, 0, 500, time.UTC)
+	testCases := []struct {
+		Name     string
+		decision api.Decision
+		expect   api.DecisionAnnotation
+	}{
+		{
+			Name:     "Blocked by plugin",
+			decision: api.Decision{Time: now, Cycle: 3, Plugin: "capacity", Reason: "QueueCapabilityExceeded", Message: "long message"},
+			expect:   api.DecisionAnnotation{Decision: api.DecisionBlocked, Cycle: 3, Time: now.Truncate(time.Second), Plugin: "capacity", Reason: "QueueCapabilityExceeded"},
+		},
+		{
+			Name:     "Dispatched",
+			decision: api.Decision{Time: now, Cycle: 4, Dispatched: true, Reason: api.DispatchedReason},
+			expect:   api.DecisionAnnotation{Decision: api.DecisionDispatched, Cycle: 4, Time: now.Truncate(time.Second), Reason: api.DispatchedReason},
+		},
+		{
+			Name:     "Evicted",
+			decision: api.Decision{Time: now, Cycle: 5, Evicted: true, Reason: "Reclaimed"},
+			expect:   api.DecisionAnnotation{Decision: api.DecisionEvicted, Cycle: 5, Time: now.Truncate(time.Second), Reason: "Reclaimed"},
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.decision.Annotation(); !reflect.DeepEqual(got, tc.expect) {
+			t.Errorf("Test case %s failed, got: %+v expect: %+v", tc.Name, got, tc.expect)
+		}
+	}
+}
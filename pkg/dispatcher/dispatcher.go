@@ -17,25 +17,53 @@ limitations under the License.
 package dispatcher
 
 import (
-	"flag"
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/filewatcher"
+	"volcano.sh/volcano/pkg/kube"
 	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
-	"volcano.sh/volcano/pkg/scheduler/util"
 
+	_ "volcano.sh/volcano-global/pkg/dispatcher/actions"
 	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/audit"
 	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/clustercapacity"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	"volcano.sh/volcano-global/pkg/dispatcher/feasibility"
 	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+	_ "volcano.sh/volcano-global/pkg/dispatcher/plugins"
+	"volcano.sh/volcano-global/pkg/utils"
 )
 
+// dispatcherController is the registered Dispatcher, its flags are registered by AddFlags.
+var dispatcherController = &Dispatcher{}
+
 func init() {
-	framework.RegisterController(&Dispatcher{})
+	framework.RegisterController(dispatcherController)
 }
 
 const (
@@ -43,11 +71,106 @@ const (
 
 	defaultDispatchPeriod = time.Second
 	defaultQueue          = "default"
+	defaultListenAddress  = ":8080"
+	defaultDebugAddress   = ":8443"
+	defaultDebugDecisions = 1000
+
+	// defaultUnSuspendParallelism is the default number of the ResourceBindings unsuspended concurrently.
+	defaultUnSuspendParallelism = 16
+
+	// defaultStatusWriteWindow is the default window to coalesce the status updates of a ResourceBinding, and
+	// defaultStatusWriters is the default number of the workers writing them.
+	defaultStatusWriteWindow = time.Second
+	defaultStatusWriters     = 4
+
+	defaultAuditWebhookTimeout = 5 * time.Second
+
+	// defaultConsistencyCheckPeriod is the default period of checking the consistency of the caches.
+	defaultConsistencyCheckPeriod = 10 * time.Minute
+
+	// defaultCacheGCPeriod is the default period of collecting the stale objects in the caches.
+	defaultCacheGCPeriod = 5 * time.Minute
+
+	// defaultEventErrorCheckPeriod is the default period of checking the failed events of the caches.
+	defaultEventErrorCheckPeriod = time.Minute
+
+	// defaultEventErrorBudget is the default number of the failed events of a kind tolerated in a check period.
+	defaultEventErrorBudget = 10
+
+	// defaultSelfReportPeriod is the default period of reporting the heap, the goroutines and the work queue depths.
+	defaultSelfReportPeriod = 30 * time.Second
+
+	// defaultWakeUpDebounce is the default quiet period after the last edit of the queues before a round is triggered.
+	defaultWakeUpDebounce = 500 * time.Millisecond
+
+	// defaultDispatchConfirmTimeout is the default time for Karmada to apply the workloads after they are dispatched.
+	defaultDispatchConfirmTimeout = 5 * time.Minute
+
+	defaultSchedulerEstimatorTimeout   = 3 * time.Second
+	defaultSchedulerEstimatorNamespace = "karmada-system"
+	defaultSchedulerEstimatorPrefix    = "karmada-scheduler-estimator"
+	defaultSchedulerEstimatorPort      = 10352
 )
 
 type Dispatcher struct {
-	cache          cache.DispatcherCacheInterface
+	// options are the command line flags of the dispatcher, they're nil when the flags are not registered.
+	options *options
+	// controlPlanes are the Karmada control planes served by the dispatcher, there is one at least.
+	controlPlanes  []*controlPlane
 	dispatchPeriod time.Duration
+	// consistencyCheckPeriod is the period of checking the consistency of the caches, zero means disabled.
+	consistencyCheckPeriod time.Duration
+	// consistencyHeal resyncs the objects from the apiserver when the periodic check finds the differences.
+	consistencyHeal bool
+	// checkpointDispatchState persists the state owned by the dispatcher onto the ResourceBindings,
+	// so it's restored after the restarts.
+	checkpointDispatchState bool
+	// cacheGCPeriod is the period of collecting the stale objects in the caches, zero means disabled.
+	cacheGCPeriod time.Duration
+	// eventErrorCheckPeriod is the period of checking the failed events of the caches, zero means disabled.
+	eventErrorCheckPeriod time.Duration
+	// eventErrorBudget is the number of the failed events of a kind tolerated in a check period before it's resynced.
+	eventErrorBudget int
+	// unhealthyTimeout is the duration without a finished round before a control plane is unhealthy,
+	// zero means 10 dispatch periods and minUnhealthyTimeout at least.
+	unhealthyTimeout time.Duration
+	// The default queue set by the `--default-queue` flag, it will be used when the configuration didn't set one.
+	defaultQueue string
+
+	// The configuration file path and its watcher, the configuration will be reloaded when the file changed.
+	dispatcherConf string
+	fileWatcher    filewatcher.FileWatcher
+
+	mutex         sync.Mutex
+	configuration *conf.DispatcherConfiguration
+	once          sync.Once
+
+	// listenAddress is the address to serve the metrics and the health checks.
+	listenAddress string
+	// debugAddress is the address to serve the admin and the debug endpoints over TLS by the debugTLSConfig, empty
+	// means disabled.
+	debugAddress   string
+	debugTLSConfig *tls.Config
+	// snapshotStreamAddress is the address to serve the gRPC snapshot stream, empty means disabled.
+	snapshotStreamAddress string
+	// auditLogger writes the decisions to the audit sinks, it is nil when no sink is set.
+	auditLogger *audit.Logger
+	// debugAuthenticator authenticates the requests of the debug endpoints, it is nil when the authentication is disabled.
+	debugAuthenticator *debugAuthenticator
+	// shard divides the namespaces among the dispatcher replicas, it is nil when the sharding is disabled.
+	shard *shardMembership
+	// annotateDecisions patches the last decision onto each ResourceBinding when it's changed.
+	annotateDecisions bool
+	// dispatchConfirmTimeout is the time for Karmada to apply the workloads to the member clusters after they are
+	// dispatched, the ones not applied in it are marked Failed, zero means disabled.
+	dispatchConfirmTimeout time.Duration
+	// wakeUpDebounce is the quiet period after the last edit of the capability or the weight of the queues before
+	// a round is triggered without waiting for the next period.
+	wakeUpDebounce time.Duration
+	// enableProfiling serves the pprof endpoints and reports the heap, the goroutines and the depths of the work
+	// queues every selfReportPeriod, zero period means no report.
+	enableProfiling  bool
+	selfReportPeriod time.Duration
 }
 
 func (dispatcher *Dispatcher) Name() string {
@@ -55,134 +178,410 @@ func (dispatcher *Dispatcher) Name() string {
 }
 
 func (dispatcher *Dispatcher) Initialize(opt *framework.ControllerOption) error {
-	cacheOption := &cache.DispatcherCacheOption{
-		WorkerNum: opt.WorkerNum,
+	if dispatcher.options == nil {
+		// The flags are not registered, take their defaults.
+		dispatcher.addFlags(pflag.NewFlagSet(dispatcherName, pflag.ContinueOnError), &kube.ClientOptions{})
+	}
+	o := dispatcher.options
+	cacheOption := o.cacheOption
+	cacheOption.WorkerNum = opt.WorkerNum
+	cacheOption.KubeClientOptions = *o.kubeClientOptions
+
+	cacheOption.UnSuspendParallelism = uint32(o.unSuspendParallelism)
+	cacheOption.StatusWriterNum = uint32(o.statusWriters)
+	faults, err := cache.ParseFaultInjection(o.faultInjection)
+	if err != nil {
+		return err
+	}
+	if faults != nil {
+		klog.Warningf("Inject the faults %q into the dispatcher cache, never enable it in production.", o.faultInjection)
+	}
+	cacheOption.FaultInjection = faults
+	if cacheOption.CapacityProvider, err = clustercapacity.NewCapacityProvider(o.capacityProvider, o.capacityFile); err != nil {
+		return err
 	}
 
-	{
-		// We need to get some additional parameters from the command line.The dispatcher is actually a controller, but it also has functions similar to the volcano-scheduler.
-		// However, adding a new component seems to be a heavy-handed approach at the moment. Therefore, while getting the controllerOption,
-		// we need to get some additional parameters here, even though this approach isn't very elegant :)
-		fs := flag.NewFlagSet(fmt.Sprintf("%s-%s", dispatcherName, "flags"), flag.ContinueOnError)
-		fs.StringVar(&cacheOption.KubeClientOptions.Master, "master", cacheOption.KubeClientOptions.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
-		fs.StringVar(&cacheOption.KubeClientOptions.KubeConfig, "kubeconfig", cacheOption.KubeClientOptions.KubeConfig, "Path to kubeconfig file with authorization and master location information")
-		fs.StringVar(&cacheOption.DefaultQueueName, "default-queue", defaultQueue, "The default queue name of the workload")
+	if dispatcher.dispatcherConf != "" {
+		// Watch the directory instead of the file, the ConfigMap volume updates the file by replacing a symlink.
+		watcher, err := filewatcher.NewFileWatcher(filepath.Dir(dispatcher.dispatcherConf))
+		if err != nil {
+			return fmt.Errorf("failed creating filewatcher for %s: %v", dispatcher.dispatcherConf, err)
+		}
+		dispatcher.fileWatcher = watcher
+	}
 
-		fs.DurationVar(&dispatcher.dispatchPeriod, "dispatch-period", defaultDispatchPeriod, "The period between each scheduling cycle")
+	controlPlanes, err := parseControlPlanes(o.karmadaKubeConfigs)
+	if err != nil {
+		return err
+	}
+	for _, cp := range controlPlanes {
+		cpCacheOption := cacheOption
+		if cp.kubeConfig != "" {
+			cpCacheOption.KubeClientOptions.KubeConfig = cp.kubeConfig
+			cpCacheOption.KubeClientOptions.Master = ""
+		}
 
-		if err := fs.Parse(os.Args[1:]); err != nil {
-			klog.Errorf("Parse flags err: %v", err)
+		cp.feasibilityChecker = feasibility.NewNoopChecker()
+		if o.enableSchedulerEstimator {
+			kubeClient, err := newKubeClient(cpCacheOption.KubeClientOptions)
+			if err != nil {
+				return fmt.Errorf("failed to init kubeClient for control plane %s: %v", cp.name, err)
+			}
+			cp.feasibilityChecker = feasibility.NewEstimatorChecker(kubeClient, o.estimatorOption)
+		}
+		recorder, err := newEventRecorder(cpCacheOption.KubeClientOptions)
+		if err != nil {
+			return fmt.Errorf("failed to init event recorder for control plane %s: %v", cp.name, err)
 		}
+		cp.recorder = recorder
+		cp.decisions = newDecisionRecorder(o.maxDebugDecisions)
+		cp.cache = cache.NewDispatcherCache(&cpCacheOption)
 	}
+	dispatcher.controlPlanes = controlPlanes
 
-	dispatcher.cache = cache.NewDispatcherCache(cacheOption)
+	if o.enableDebugAuth {
+		kubeClient, err := newKubeClient(cacheOption.KubeClientOptions)
+		if err != nil {
+			return fmt.Errorf("failed to init kubeClient for dispatcher: %v", err)
+		}
+		dispatcher.debugAuthenticator = newDebugAuthenticator(kubeClient)
+	}
+	if dispatcher.debugAddress != "" {
+		if dispatcher.debugTLSConfig, err = newDebugTLSConfig(o.debugCertFile, o.debugKeyFile); err != nil {
+			return fmt.Errorf("failed to load the serving certificate of the debug endpoints: %v", err)
+		}
+	}
+
+	if o.shardGroup != "" {
+		shardIdentity := o.shardIdentity
+		if shardIdentity == "" {
+			if shardIdentity, err = os.Hostname(); err != nil {
+				return fmt.Errorf("failed to get the shard identity: %v", err)
+			}
+		}
+		kubeClient, err := newKubeClient(cacheOption.KubeClientOptions)
+		if err != nil {
+			return fmt.Errorf("failed to init kubeClient for the shard membership: %v", err)
+		}
+		dispatcher.shard = newShardMembership(kubeClient, o.shardLeaseNamespace, o.shardGroup, shardIdentity, o.shardLeaseDuration)
+	}
+
+	sinks, err := audit.NewSinks(strings.Split(o.auditSinks, ","), o.auditWebhookTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to create audit sinks: %v", err)
+	}
+	dispatcher.auditLogger = audit.NewLogger(sinks, 0)
+
+	dispatcher.defaultQueue = cacheOption.DefaultQueueName
 	return nil
 }
 
+func newKubeClient(options kube.ClientOptions) (kubernetes.Interface, error) {
+	config, err := kube.BuildConfig(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubeConfig: %v", err)
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// newEventRecorder creates the recorder of the events on the ResourceBindings in the Karmada control plane.
+func newEventRecorder(options kube.ClientOptions) (record.EventRecorder, error) {
+	kubeClient, err := newKubeClient(options)
+	if err != nil {
+		return nil, err
+	}
+	eventScheme := runtime.NewScheme()
+	if err := workv1alpha2.Install(eventScheme); err != nil {
+		return nil, err
+	}
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(eventScheme, corev1.EventSource{Component: dispatcherName}), nil
+}
+
 func (dispatcher *Dispatcher) Run(stopCh <-chan struct{}) {
-	// Run the dispatcher cache.
-	dispatcher.cache.Run(stopCh)
+	dispatcher.loadDispatcherConf()
+	go dispatcher.watchDispatcherConf(stopCh)
+
+	go dispatcher.auditLogger.Run(stopCh)
+	if dispatcher.shard != nil {
+		go dispatcher.shard.run(stopCh)
+	}
+
+	if dispatcher.listenAddress != "" {
+		go func() {
+			// The own mux keeps the handlers registered onto the default one by the imported packages, like pprof, unserved.
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			mux.HandleFunc("/healthz", dispatcher.healthHandler)
+			mux.HandleFunc("/readyz", dispatcher.readyHandler)
+			klog.Fatalf("Prometheus Http Server failed %s", http.ListenAndServe(dispatcher.listenAddress, mux))
+		}()
+	}
+
+	if dispatcher.debugAddress != "" {
+		go dispatcher.serveDebugEndpoints(dispatcher.debugAddress)
+	}
+
+	if dispatcher.snapshotStreamAddress != "" {
+		go dispatcher.serveSnapshotStream(dispatcher.snapshotStreamAddress)
+	}
 
-	go wait.Until(dispatcher.runOnce, dispatcher.dispatchPeriod, stopCh)
-	klog.V(2).Infof("Dispatcher completes initialization and start to run, period <%v> seconds...", dispatcher.dispatchPeriod.Seconds())
+	if dispatcher.enableProfiling && dispatcher.selfReportPeriod > 0 {
+		go wait.Until(dispatcher.selfReport, dispatcher.selfReportPeriod, stopCh)
+	}
+
+	// Run the control planes separately, so an unreachable one doesn't block the others.
+	for _, cp := range dispatcher.controlPlanes {
+		go dispatcher.runControlPlane(cp, stopCh)
+	}
 }
 
-func (dispatcher *Dispatcher) runOnce() {
-	klog.V(4).Infof("Start dispatching...")
-	defer klog.V(4).Infof("End dispatching...")
+func (dispatcher *Dispatcher) runControlPlane(cp *controlPlane, stopCh <-chan struct{}) {
+	// Run the dispatcher cache.
+	cp.cache.Run(stopCh)
+	cp.markSynced(time.Now())
+
+	klog.V(2).Infof("Dispatcher completes initialization of control plane <%s> and start to run, period <%v> seconds...",
+		cp.name, dispatcher.dispatchPeriod.Seconds())
+	if dispatcher.consistencyCheckPeriod > 0 {
+		go wait.Until(func() {
+			_, _ = dispatcher.checkConsistency(wait.ContextForChannel(stopCh), cp, dispatcher.consistencyHeal)
+		}, dispatcher.consistencyCheckPeriod, stopCh)
+	}
+	if dispatcher.cacheGCPeriod > 0 {
+		go wait.Until(func() { dispatcher.collectGarbage(cp) }, dispatcher.cacheGCPeriod, stopCh)
+	}
+	if dispatcher.eventErrorCheckPeriod > 0 {
+		go wait.Until(func() { dispatcher.checkEventErrors(cp) }, dispatcher.eventErrorCheckPeriod, stopCh)
+	}
+	dispatcher.runLoop(cp, stopCh)
+}
 
-	ssn := dispatcherframework.OpenSession(dispatcher.cache)
-	dispatcher.dispatch(ssn)
+func (dispatcher *Dispatcher) runOnce(cp *controlPlane) {
+	klog.V(4).Infof("Start dispatching control plane <%s>...", cp.name)
+	defer klog.V(4).Infof("End dispatching control plane <%s>...", cp.name)
+
+	dispatcher.mutex.Lock()
+	configuration := dispatcher.configuration
+	rateLimiter := cp.rateLimiter
+	dispatcher.mutex.Unlock()
+
+	globalPaused := configuration.Paused
+	cp.cycle++
+	cp.queueLogVerbosity = configuration.QueueLogVerbosity
+	ssn := dispatcherframework.OpenSession(cp.cache, configuration.Plugins)
+	ssn.SetTieBreaker(configuration.TieBreaker, cp.cycle)
+	ssn.SetPreemptionMatrix(configuration.PreemptionMatrix)
+	metrics.UpdateMinResourcesDisagreements(cp.name, ssn.Snapshot.MinResourcesDisagreements)
+	dispatcher.resuspendGrown(cp, ssn)
+	dispatcher.resuspendRescheduled(cp, ssn)
+	dispatcher.resuspendOnClusterFailure(cp, ssn)
+	dispatcher.handleUnadmitted(cp, ssn, configuration.UnadmittedPolicy)
+	dispatcher.confirmDispatched(cp, ssn, time.Now())
+	dispatcher.expireSuspended(cp, ssn, time.Now())
+	dispatcher.checkpoint(cp, ssn)
+	ssn.SetOperations(dispatcher.newRoundOperations(cp, ssn, configuration, rateLimiter, globalPaused))
+	for _, name := range strings.Split(configuration.Actions, ",") {
+		if action, found := dispatcherframework.GetAction(strings.TrimSpace(name)); found {
+			action.Execute(ssn)
+		}
+	}
 	ssn.CloseSession()
+	// Nothing refers to the snapshot after the round, reuse its containers in the next rounds.
+	ssn.Snapshot.Release()
+
+	now := time.Now()
+	cp.markDispatched(now)
+	metrics.UpdateControlPlaneLastDispatchTime(cp.name, now)
+	metrics.UpdateControlPlaneDispatchCycle(cp.name, cp.cycle)
+	metrics.UpdateCoalescedStatusUpdates(cp.name, cp.cache.TakeCoalescedStatusUpdates())
 }
 
-// Dispatch is the main behavior of the Dispatcher.
-// Its primary responsibilities are: sorting the queues and the pending RBs (Resource Bundles) within the queues,
-// and then, according to the queue priority, sequentially retrieving all RBs from the queues.
-// If each RB meets certain conditions,it will be placed in the queue
-// and subsequently updated with their Suspend set to false.
-func (dispatcher *Dispatcher) dispatch(ssn *dispatcherframework.Session) {
-	klog.V(5).Infof("Dispatcher start running...")
-	defer klog.V(5).Infof("Dispatcher end running...")
-
-	ss := ssn.Snapshot
-	queues := util.NewPriorityQueue(ssn.QueueInfoOrderFn)
-	resourceBindingMap := map[string]*util.PriorityQueue{}
-	// The counts for logs.
-	enqueueResourceBindingCount := 0
-	dispatchResourceBindingCount := 0
-
-	// Collect the workloads to the queue map.
-	// For now, the `workload` includes Deployment, volcano-job and Pod only.
-	// Because only the three resources will create PodGroup by controllers.
-	for _, rbi := range ss.ResourceBindingInfos {
-		rb := rbi.ResourceBinding
-
-		// Check if its Suspended, dispatcher cares the suspended rbi only.
-		if rbi.DispatchStatus != api.Suspended {
-			continue
-		}
+func (dispatcher *Dispatcher) loadDispatcherConf() {
+	klog.V(4).Infof("Start loadDispatcherConf ...")
 
-		// If its workload but without PodGroup, skip it.
-		// Only workload ResourceBinding will be suspend and add to the dispatcher cache.
-		if rbi.PodGroup == nil {
-			klog.Errorf("ResourceBinding <%s/%s> is a workload but has no PodGroup, stop dispatching and enqueue.",
-				rb.Namespace, rb.Name)
-			continue
+	var err error
+	dispatcher.once.Do(func() {
+		dispatcher.configuration, err = UnmarshalDispatcherConf(DefaultDispatcherConf)
+		if err != nil {
+			klog.Errorf("Unmarshal Dispatcher config %s failed: %v", DefaultDispatcherConf, err)
+			panic("invalid default configuration")
 		}
+		dispatcher.applyDispatcherConf(dispatcher.configuration)
+	})
 
-		// Get the workload's queue name, it may be a nil.
-		rbiQueueName := ssn.GetResourceBindingInfoQueue(rbi)
-		resource := rb.Spec.Resource
-
-		// Check if the queue set in the map.
-		if rbiPriorityQueue, found := resourceBindingMap[rbiQueueName]; found {
-			// Add this workload to the queue.
-			rbiPriorityQueue.Push(rbi)
-		} else {
-			// This queue didn't set in the map, we should check if the queue exists first, then add it to the map.
-			if queue, found := ss.QueueInfos[rbiQueueName]; found {
-				klog.V(5).Infof("Added Queue <%s> for ResourceBinding <%s/%s>.",
-					rbiQueueName, rb.Namespace, rb.Name)
-				// Create the priority queue for ResourceBindings, and push it.
-				resourceBindingMap[rbiQueueName] = util.NewPriorityQueue(ssn.ResourceBindingInfoOrderFn)
-				resourceBindingMap[rbiQueueName].Push(rbi)
-
-				queues.Push(queue)
-				enqueueResourceBindingCount++
-			} else {
-				// We cant find this queue in the cache snapshot, skip it.
-				klog.V(3).Infof("Resource %s <%s/%s> Queue <%s> not found, skip dispatching.",
-					resource.Kind, resource.Namespace, resource.Name, rbiQueueName)
-				continue
-			}
-		}
+	if len(dispatcher.dispatcherConf) == 0 {
+		return
 	}
 
-	klog.V(5).Infof("Success enqueue <%d> ResourceBindingInfos and <%d> Queues, start dispatching now...",
-		enqueueResourceBindingCount, len(resourceBindingMap))
+	confData, err := os.ReadFile(dispatcher.dispatcherConf)
+	if err != nil {
+		klog.Errorf("Failed to read the Dispatcher config in '%s', using previous configuration: %v",
+			dispatcher.dispatcherConf, err)
+		return
+	}
+	config := strings.TrimSpace(string(confData))
+
+	configuration, err := UnmarshalDispatcherConf(config)
+	if err != nil {
+		klog.Errorf("Dispatcher config %s is invalid, using previous configuration: %v", config, err)
+		return
+	}
+	dispatcher.applyDispatcherConf(configuration)
+
+	klog.V(2).Infof("Successfully loaded Dispatcher conf, actions: %s, plugins: %v", configuration.Actions, configuration.Plugins)
+}
+
+// applyDispatcherConf make the configuration take effect, the running session will not be affected.
+func (dispatcher *Dispatcher) applyDispatcherConf(configuration *conf.DispatcherConfiguration) {
+	workloadGVKs := make([]schema.GroupVersionKind, 0, len(configuration.Workloads))
+	for _, workload := range configuration.Workloads {
+		workloadGVKs = append(workloadGVKs, schema.GroupVersionKind{
+			Group:   workload.Group,
+			Version: workload.Version,
+			Kind:    workload.Kind,
+		})
+	}
+	utils.DefaultWorkloadRegistry.SetExtraWorkloads(workloadGVKs)
+
+	defaultQueueName := dispatcher.defaultQueue
+	if configuration.QueueDefaults.Name != "" {
+		defaultQueueName = configuration.QueueDefaults.Name
+	}
+	for _, cp := range dispatcher.controlPlanes {
+		cp.cache.SetDefaultQueue(defaultQueueName)
+		cp.cache.SetMinResourcesSource(configuration.MinResourcesSource)
+	}
 
+	dispatcher.mutex.Lock()
+	dispatcher.configuration = configuration
+	// Each control plane has its own rate limiter, the rate limit applies to them separately.
+	for _, cp := range dispatcher.controlPlanes {
+		cp.rateLimiter = newRateLimiter(configuration.RateLimit)
+	}
+	dispatcher.mutex.Unlock()
+}
+
+// newRateLimiter creates the rate limiter of unsuspending, it returns nil when the rate limit is disabled.
+func newRateLimiter(rateLimit conf.RateLimitConfiguration) flowcontrol.RateLimiter {
+	if rateLimit.QPS <= 0 {
+		return nil
+	}
+	burst := rateLimit.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	return flowcontrol.NewTokenBucketRateLimiter(rateLimit.QPS, burst)
+}
+
+func (dispatcher *Dispatcher) watchDispatcherConf(stopCh <-chan struct{}) {
+	if dispatcher.fileWatcher == nil {
+		return
+	}
+	defer dispatcher.fileWatcher.Close()
+
+	eventCh := dispatcher.fileWatcher.Events()
+	errCh := dispatcher.fileWatcher.Errors()
 	for {
-		// Finish dispatching when all the queues dispatch done.
-		if queues.Empty() {
-			break
+		select {
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			klog.V(4).Infof("Watch %s event: %v", dispatcher.dispatcherConf, event)
+			if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
+				dispatcher.loadDispatcherConf()
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				return
+			}
+			klog.Infof("Watch %s error: %v", dispatcher.dispatcherConf, err)
+		case <-stopCh:
+			return
 		}
+	}
+}
 
-		queue := queues.Pop().(*schedulingapi.QueueInfo)
-		resourceBindingsQueue := resourceBindingMap[queue.Name]
+// dispatchable checks the ResourceBindingInfo by the plugins, the blocker cached in the same usage epoch
+// is reused instead when the equivalence cache is enabled.
+func (dispatcher *Dispatcher) dispatchable(cp *controlPlane, ssn *dispatcherframework.Session, rbi *api.ResourceBindingInfo,
+	fingerprint uint64, ttl time.Duration) *api.DispatchBlocker {
+	if cp.equivalence == nil {
+		return ssn.Dispatchable(rbi)
+	}
 
-		// Get all the ResourceBindingInfos from the priority queue.
-		for !resourceBindingsQueue.Empty() {
-			rbi := resourceBindingsQueue.Pop().(*api.ResourceBindingInfo)
+	now := time.Now()
+	epoch := usageEpoch{snapshot: fingerprint, session: ssn.UsageVersion()}
+	if blocker, found := cp.equivalence.get(rbi, epoch, now, ttl); found {
+		klog.V(5).Infof("ResourceBinding <%s/%s> is blocked by the cached blocker of plugin <%s>, skip evaluating it.",
+			rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, blocker.Plugin)
+		metrics.UpdateEquivalenceCacheHits(cp.name)
+		return blocker
+	}
+	blocker := ssn.Dispatchable(rbi)
+	cp.equivalence.set(rbi, epoch, now, blocker)
+	return blocker
+}
 
-			rbi.DispatchStatus = api.UnSuspending
-			dispatcher.cache.UnSuspendResourceBinding(types.NamespacedName{
-				Namespace: rbi.ResourceBinding.Namespace,
-				Name:      rbi.ResourceBinding.Name,
-			})
-			dispatchResourceBindingCount++
-		}
+// release unsuspends the ResourceBindingInfo admitted in the round, the plugins have been notified by the session.
+// It's released toward the speculative cluster when it's set.
+func (dispatcher *Dispatcher) release(cp *controlPlane, queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo,
+	speculativeCluster string, queueAllocated map[string]corev1.ResourceList) {
+	key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+	logger := cp.logger()
+
+	rbi.SetDispatchStatus(api.Admitted, time.Now())
+	reason, message := api.DispatchedReason, "The ResourceBinding is dispatched by volcano-global dispatcher."
+	if speculativeCluster == "" {
+		cp.cache.UnSuspendResourceBinding(key)
+	} else {
+		rbi.SpeculativeCluster = speculativeCluster
+		cp.cache.UnSuspendResourceBindingToCluster(key, speculativeCluster)
+		reason = api.SpeculativeReleaseReason
+		message = fmt.Sprintf("No cluster has room for the workload, it's released toward the elastic cluster %s to scale it up.", speculativeCluster)
+		cp.eventf(rbi.ResourceBinding, corev1.EventTypeNormal, api.SpeculativeReleaseReason, "%s", message)
+	}
+	if !rbi.EnqueueTime.IsZero() {
+		metrics.UpdateQueueWaitDuration(cp.name, queue.Name, rbi.PriorityClassName, time.Since(rbi.EnqueueTime))
+	}
+	if rbi.PriorityOverridden {
+		metrics.UpdatePriorityOverriddenResourceBindings(cp.name, queue.Name)
 	}
+	logger.Info(3, queue.Name, key, "ResourceBinding is dispatched")
+	cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+		Type:    api.DispatchedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	if deadline, found := rbi.Deadline(); found && time.Now().After(deadline) {
+		logger.Info(3, queue.Name, key, "ResourceBinding is dispatched after its deadline", "deadline", deadline.Format(time.RFC3339))
+		metrics.UpdateDeadlineMissedResourceBindings(cp.name, queue.Name)
+		cp.eventf(rbi.ResourceBinding, corev1.EventTypeWarning, api.DeadlineMissedReason,
+			"The workload is dispatched after its deadline %s", deadline.Format(time.RFC3339))
+	}
+	dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+		Queue: queue.Name, Dispatched: true, Reason: reason}, rbi, queue, queueAllocated[queue.Name])
+	addResources(queueAllocated, queue.Name, rbi.MinResources)
+}
+
+// addResources adds the resources to the allocated resources of the queue.
+func addResources(allocated map[string]corev1.ResourceList, queueName string, resources corev1.ResourceList) {
+	if allocated[queueName] == nil {
+		allocated[queueName] = corev1.ResourceList{}
+	}
+	for name, quantity := range resources {
+		value := allocated[queueName][name]
+		value.Add(quantity)
+		allocated[queueName][name] = value
+	}
+}
 
-	klog.V(2).Infof("Success dispatch <%d> ResourceBindingInfos.", dispatchResourceBindingCount)
+// isQueuePaused checks whether the dispatching of the queue is paused, by the configuration, the admin endpoint
+// or the annotation of the queue.
+func (dispatcher *Dispatcher) isQueuePaused(queue *schedulingapi.QueueInfo, globalPaused bool) bool {
+	return globalPaused || pausedBy(queue) != "" ||
+		(queue.Queue != nil && queue.Queue.Annotations[api.QueuePausedAnnotationKey] == "true")
 }
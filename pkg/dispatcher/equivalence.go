@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// defaultEquivalenceCacheTTL is the default duration a cached blocker is reused for.
+const defaultEquivalenceCacheTTL = 30 * time.Second
+
+// equivalenceEntry is the blocker of a ResourceBinding evaluated in the usage epoch at the time.
+type equivalenceEntry struct {
+	generation int64
+	epoch      usageEpoch
+	time       time.Time
+	blocker    *api.DispatchBlocker
+}
+
+// equivalenceCache records the blockers of the ResourceBindings by their UIDs, a ResourceBinding blocked by the
+// plugins is usually blocked again until the usage or the capacity of the queues changes, so its blocker is reused
+// while neither the ResourceBinding nor the usage epoch changes instead of evaluating the plugins again.
+type equivalenceCache map[types.UID]*equivalenceEntry
+
+// get returns the cached blocker of the ResourceBindingInfo when it was evaluated in the same epoch within the ttl.
+func (ec equivalenceCache) get(rbi *api.ResourceBindingInfo, epoch usageEpoch, now time.Time, ttl time.Duration) (*api.DispatchBlocker, bool) {
+	entry, found := ec[rbi.ResourceBinding.UID]
+	if !found || entry.generation != rbi.ResourceBinding.Generation || entry.epoch != epoch || now.Sub(entry.time) > ttl {
+		return nil, false
+	}
+	return entry.blocker, true
+}
+
+// set records the blocker of the ResourceBindingInfo evaluated in the epoch, the one which is not blocked
+// is removed, so it's always evaluated again.
+func (ec equivalenceCache) set(rbi *api.ResourceBindingInfo, epoch usageEpoch, now time.Time, blocker *api.DispatchBlocker) {
+	if blocker == nil {
+		delete(ec, rbi.ResourceBinding.UID)
+		return
+	}
+	ec[rbi.ResourceBinding.UID] = &equivalenceEntry{generation: rbi.ResourceBinding.Generation, epoch: epoch, time: now, blocker: blocker}
+}
+
+// retain removes the entries of the ResourceBindings which are not in the snapshot anymore.
+func (ec equivalenceCache) retain(ssn *dispatcherframework.Session) {
+	for uid := range ec {
+		if _, found := ssn.Snapshot.ResourceBindingInfos[uid]; !found {
+			delete(ec, uid)
+		}
+	}
+}
+
+// usageEpoch identifies the state the plugins evaluate the ResourceBindings against, the snapshot is the fingerprint of
+// the queues, the clusters, the quotas and the dispatch status of the ResourceBindings in the round, and the session is
+// the usage version of the session counting the dispatching and the evictions in the round.
+type usageEpoch struct {
+	snapshot uint64
+	session  uint64
+}
+
+// snapshotFingerprint fingerprints the snapshot, it's the same as long as neither the usage nor the capacity of the
+// queues changes between the rounds.
+func snapshotFingerprint(snapshot *cache.DispatcherCacheSnapshot) uint64 {
+	keys := make([]string, 0, len(snapshot.QueueInfos)+len(snapshot.Clusters)+len(snapshot.ResourceBindingInfos)+1)
+	keys = append(keys, "default/"+snapshot.DefaultQueue)
+	for name, queue := range snapshot.QueueInfos {
+		key := "queue/" + name
+		if queue.Queue != nil {
+			// The annotations are counted as well, the DispatchPolicies are applied to them without a new version.
+			annotations := make([]string, 0, len(queue.Queue.Annotations))
+			for annotation, value := range queue.Queue.Annotations {
+				annotations = append(annotations, annotation+"="+value)
+			}
+			sort.Strings(annotations)
+			key += "/" + queue.Queue.ResourceVersion + "/" + strings.Join(annotations, ",")
+		}
+		keys = append(keys, key)
+	}
+	for name, cluster := range snapshot.Clusters {
+		keys = append(keys, "cluster/"+name+"/"+cluster.ResourceVersion)
+	}
+	for namespace, quotas := range snapshot.FederatedResourceQuotas {
+		for _, quota := range quotas {
+			keys = append(keys, "quota/"+namespace+"/"+quota.Name+"/"+quota.ResourceVersion)
+		}
+	}
+	for uid, rbi := range snapshot.ResourceBindingInfos {
+		keys = append(keys, "rb/"+string(uid)+"/"+rbi.Queue+"/"+rbi.DispatchStatus.String()+
+			"/"+strconv.FormatBool(rbi.IsRunning())+"/"+strconv.FormatBool(rbi.IsCompleted()))
+	}
+	sort.Strings(keys)
+
+	hash := fnv.New64a()
+	for _, key := range keys {
+		_, _ = hash.Write([]byte(key))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hash.Sum64()
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func TestEquivalenceCache(t *testing.T) {
+	newRBI := func(generation int64) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{ResourceBinding: &workv1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb", UID: "rb", Generation: generation},
+		}}
+	}
+	now := time.Now()
+	epoch := usageEpoch{snapshot: 1}
+	blocker := &api.DispatchBlocker{Plugin: "capacity", Reason: "InsufficientIdleResource"}
+
+	testCases := []struct {
+		Name        string
+		rbi         *api.ResourceBindingInfo
+		epoch       usageEpoch
+		elapsed     time.Duration
+		expectFound bool
+	}{
+		{Name: "Same binding in the same epoch", rbi: newRBI(1), epoch: epoch, expectFound: true},
+		{Name: "Binding is updated", rbi: newRBI(2), epoch: epoch},
+		{Name: "Snapshot changes", rbi: newRBI(1), epoch: usageEpoch{snapshot: 2}},
+		{Name: "Usage changes in the session", rbi: newRBI(1), epoch: usageEpoch{snapshot: 1, session: 1}},
+		{Name: "Blocker expires", rbi: newRBI(1), epoch: epoch, elapsed: time.Minute},
+	}
+
+	for _, tc := range testCases {
+		ec := equivalenceCache{}
+		ec.set(newRBI(1), epoch, now, blocker)
+		_, found := ec.get(tc.rbi, tc.epoch, now.Add(tc.elapsed), defaultEquivalenceCacheTTL)
+		if found != tc.expectFound {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, found, tc.expectFound)
+		}
+	}
+
+	// The binding which is not blocked anymore is evaluated again.
+	ec := equivalenceCache{}
+	ec.set(newRBI(1), epoch, now, blocker)
+	ec.set(newRBI(1), epoch, now, nil)
+	if _, found := ec.get(newRBI(1), epoch, now, defaultEquivalenceCacheTTL); found {
+		t.Errorf("Test case unblocked binding failed, got: %v expect: %v", found, false)
+	}
+}
+
+func TestSnapshotFingerprint(t *testing.T) {
+	newSnapshot := func() *cache.DispatcherCacheSnapshot {
+		return &cache.DispatcherCacheSnapshot{
+			Clusters: map[string]*clusterv1alpha1.Cluster{
+				"member1": {ObjectMeta: metav1.ObjectMeta{Name: "member1", ResourceVersion: "1"}},
+			},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{
+				"rb": {ResourceBinding: &workv1alpha2.ResourceBinding{}, Queue: "q1", DispatchStatus: api.Pending},
+			},
+		}
+	}
+	base := snapshotFingerprint(newSnapshot())
+
+	testCases := []struct {
+		Name        string
+		update      func(snapshot *cache.DispatcherCacheSnapshot)
+		expectEqual bool
+	}{
+		{Name: "Nothing changes", update: func(_ *cache.DispatcherCacheSnapshot) {}, expectEqual: true},
+		{
+			Name:   "Cluster is updated",
+			update: func(snapshot *cache.DispatcherCacheSnapshot) { snapshot.Clusters["member1"].ResourceVersion = "2" },
+		},
+		{
+			Name: "Binding is dispatched",
+			update: func(snapshot *cache.DispatcherCacheSnapshot) {
+				snapshot.ResourceBindingInfos["rb"].DispatchStatus = api.Dispatched
+			},
+		},
+		{
+			Name:   "Default queue changes",
+			update: func(snapshot *cache.DispatcherCacheSnapshot) { snapshot.DefaultQueue = "q2" },
+		},
+	}
+
+	for _, tc := range testCases {
+		snapshot := newSnapshot()
+		tc.update(snapshot)
+		if equal := snapshotFingerprint(snapshot) == base; equal != tc.expectEqual {
+			t.Errorf("Test case %s failed, got equal: %v expect equal: %v", tc.Name, equal, tc.expectEqual)
+		}
+	}
+}
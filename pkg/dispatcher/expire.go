@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// expireSuspended marks the ResourceBindings Expired when they wait suspended longer than the TTL declared by the
+// `volcano.sh/suspended-ttl` annotation of their queues, so the dead jobs don't clog the backlogs of the queues.
+// The expired ones are never dispatched unless released by force, and their workloads are annotated or deleted
+// by the `volcano.sh/expiry-policy` annotation of the queue.
+func (dispatcher *Dispatcher) expireSuspended(cp *controlPlane, ssn *dispatcherframework.Session, now time.Time) {
+	// The expired annotation is patched asynchronously, the ones expired in the previous rounds are remembered
+	// until the annotation is observed, so they are neither expired again nor dispatched.
+	for uid := range cp.expired {
+		rbi, found := ssn.Snapshot.ResourceBindingInfos[uid]
+		if !found || rbi.IsExpired() || rbi.DispatchStatus.IsDispatched() {
+			delete(cp.expired, uid)
+			continue
+		}
+		markExpired(rbi, now)
+	}
+
+	for uid, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() || rbi.IsExpired() || !dispatcher.ownsResourceBinding(ssn, rbi) {
+			continue
+		}
+		if _, held := cp.manual.heldBy(rbi); held {
+			continue
+		}
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		queue, found := ssn.Snapshot.QueueInfos[queueName]
+		if !found || queue.Queue == nil {
+			continue
+		}
+		ttl, policy, err := api.ParseExpiry(queue.Queue.Annotations)
+		if err != nil {
+			cp.logger().QueueInfo(3, queueName, "Skip expiring the ResourceBinding of the queue", "err", err)
+			continue
+		}
+		enqueueTime := rbi.EnqueueTime
+		if enqueueTime.IsZero() {
+			enqueueTime = rbi.FirstSeenTime
+		}
+		if ttl <= 0 || enqueueTime.IsZero() || now.Sub(enqueueTime) < ttl {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+		message := fmt.Sprintf("The ResourceBinding is suspended longer than the TTL %s of queue %s.", ttl, queueName)
+		if policy != api.ExpiryPolicyCondition {
+			message = fmt.Sprintf("%s The workload is handled by the %s expiry policy.", message, policy)
+		}
+		cp.logger().Info(3, queueName, key, "ResourceBinding is expired", "policy", policy)
+
+		if cp.expired == nil {
+			cp.expired = map[types.UID]bool{}
+		}
+		cp.expired[uid] = true
+		markExpired(rbi, now)
+		cp.cache.AnnotateResourceBinding(key, api.ExpiredAnnotationKey, now.Format(time.RFC3339))
+		cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+			Type:    api.DispatchedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  api.ExpiredReason,
+			Message: message,
+		})
+		cp.eventf(rbi.ResourceBinding, corev1.EventTypeWarning, api.ExpiredReason, "%s", message)
+		if policy != api.ExpiryPolicyCondition {
+			cp.cache.ExpireWorkload(key, policy)
+		}
+		dispatcher.recordDecision(cp, api.Decision{Time: now, Namespace: key.Namespace, Name: key.Name,
+			Queue: queueName, Reason: api.ExpiredReason, Message: message}, rbi, queue, nil)
+	}
+}
+
+// markExpired annotates the ResourceBindingInfo of the snapshot, so it's skipped by the actions in the round.
+func markExpired(rbi *api.ResourceBindingInfo, now time.Time) {
+	if rbi.ResourceBinding.Annotations == nil {
+		rbi.ResourceBinding.Annotations = map[string]string{}
+	}
+	rbi.ResourceBinding.Annotations[api.ExpiredAnnotationKey] = now.Format(time.RFC3339)
+}
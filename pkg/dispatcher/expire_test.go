@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func TestExpireSuspended(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		Name             string
+		queueAnnotations map[string]string
+		rbAnnotations    map[string]string
+		status           api.DispatchStatus
+		enqueued         time.Duration
+		expectExpired    bool
+	}{
+		{
+			Name:             "Suspended longer than the TTL",
+			queueAnnotations: map[string]string{api.SuspendedTTLAnnotationKey: "1h"},
+			status:           api.Pending,
+			enqueued:         2 * time.Hour,
+			expectExpired:    true,
+		},
+		{
+			Name:             "Suspended in the TTL",
+			queueAnnotations: map[string]string{api.SuspendedTTLAnnotationKey: "1h"},
+			status:           api.Pending,
+			enqueued:         time.Minute,
+		},
+		{
+			Name:     "Queue without the TTL",
+			status:   api.Pending,
+			enqueued: 2 * time.Hour,
+		},
+		{
+			Name:             "Invalid TTL",
+			queueAnnotations: map[string]string{api.SuspendedTTLAnnotationKey: "forever"},
+			status:           api.Pending,
+			enqueued:         2 * time.Hour,
+		},
+		{
+			Name:             "Dispatched workload",
+			queueAnnotations: map[string]string{api.SuspendedTTLAnnotationKey: "1h"},
+			status:           api.Dispatched,
+			enqueued:         2 * time.Hour,
+		},
+		{
+			Name:             "Held workload",
+			queueAnnotations: map[string]string{api.SuspendedTTLAnnotationKey: "1h"},
+			rbAnnotations:    map[string]string{api.DispatchHoldAnnotationKey: "admin"},
+			status:           api.Pending,
+			enqueued:         2 * time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		rb := &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb", UID: "rb",
+			Annotations: tc.rbAnnotations}}
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: rb,
+			Queue:           "default",
+			DispatchStatus:  tc.status,
+			EnqueueTime:     now.Add(-tc.enqueued),
+		}
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue: "default",
+			QueueInfos: map[string]*schedulingapi.QueueInfo{"default": {Name: "default",
+				Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default", Annotations: tc.queueAnnotations}}}},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{rb.UID: rbi},
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10),
+			recorder: record.NewFakeRecorder(10)}
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		dispatcher.expireSuspended(cp, ssn, now)
+		ssn.CloseSession()
+
+		if rbi.IsExpired() != tc.expectExpired {
+			t.Errorf("Test case %s failed, got expired: %v expect: %v", tc.Name, rbi.IsExpired(), tc.expectExpired)
+		}
+		if cp.expired[rb.UID] != tc.expectExpired {
+			t.Errorf("Test case %s failed, got remembered: %v expect: %v", tc.Name, cp.expired[rb.UID], tc.expectExpired)
+		}
+	}
+}
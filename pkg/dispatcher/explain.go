@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/feasibility"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// explain replays the dispatching in the session for the ResourceBinding without unsuspending anything,
+// and tells which check blocks it. The burst is the number of the ResourceBindings can be dispatched in a round,
+// zero or negative means no limit.
+func (dispatcher *Dispatcher) explain(cp *controlPlane, ssn *dispatcherframework.Session, key types.NamespacedName, globalPaused bool, burst int) *api.Explanation {
+	explanation := &api.Explanation{Namespace: key.Namespace, Name: key.Name}
+
+	var target *api.ResourceBindingInfo
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.ResourceBinding.Namespace == key.Namespace && rbi.ResourceBinding.Name == key.Name {
+			target = rbi
+			break
+		}
+	}
+	if target == nil {
+		explanation.Reason = api.NotManagedReason
+		explanation.Message = "The ResourceBinding is not managed by the dispatcher, it's not a workload or opted out of the dispatcher."
+		return explanation
+	}
+
+	explanation.Queue = ssn.GetResourceBindingInfoQueue(target)
+	explanation.DispatchStatus = target.DispatchStatus.String()
+	explanation.TransitionTimes = target.TransitionTimesByName()
+	if target.DispatchStatus.IsDispatched() {
+		explanation.Dispatchable = true
+		explanation.Reason = api.DispatchedReason
+		explanation.Message = "The ResourceBinding is already dispatched."
+		return explanation
+	}
+	if !dispatcher.ownsQueue(explanation.Queue) {
+		explanation.Reason = api.NotOwnedShardReason
+		explanation.Message = "The queue is dispatched by another replica of the shard group, explain it on that replica."
+		return explanation
+	}
+	if target.PodGroup == nil {
+		explanation.Reason = api.GangIncompleteReason
+		explanation.Message = "The PodGroup of the workload is not created yet, it will be dispatched after the PodGroup is ready."
+		return explanation
+	}
+
+	queue, found := ssn.Snapshot.QueueInfos[explanation.Queue]
+	if !found {
+		explanation.Reason = api.QueueNotFoundReason
+		explanation.Message = fmt.Sprintf("Queue %s is not found.", explanation.Queue)
+		return explanation
+	}
+	if queue.Queue != nil && queue.Queue.Status.State != "" && queue.Queue.Status.State != scheduling.QueueStateOpen {
+		explanation.Notes = append(explanation.Notes, fmt.Sprintf("Queue %s is %s.", queue.Name, queue.Queue.Status.State))
+	}
+	if dispatcher.isQueuePaused(queue, globalPaused) {
+		explanation.Reason = api.DispatchPausedReason
+		explanation.Message = api.PausedMessage(queue.Name, pausedBy(queue))
+		return explanation
+	}
+	if target.IsExpired() {
+		explanation.Reason = api.ExpiredReason
+		explanation.Message = fmt.Sprintf("The ResourceBinding is expired at %s, it's only dispatched by force.",
+			target.ResourceBinding.Annotations[api.ExpiredAnnotationKey])
+		return explanation
+	}
+	if user, held := cp.manual.heldBy(target); held {
+		explanation.Reason = api.DispatchHeldReason
+		explanation.Message = fmt.Sprintf("The ResourceBinding is held by %s manually.", user)
+		return explanation
+	}
+
+	// Replay the dispatching in order, the ResourceBindings ahead of the target are dispatched in the session only,
+	// so the plugins can count them like a real round.
+	queues, resourceBindingMap := ssn.BuildQueues(dispatcher.ownsQueue)
+	for !queues.Empty() {
+		q := queues.Pop().(*schedulingapi.QueueInfo)
+		if dispatcher.isQueuePaused(q, globalPaused) {
+			continue
+		}
+
+		resourceBindingsQueue := resourceBindingMap[q.Name]
+		for !resourceBindingsQueue.Empty() {
+			rbi := resourceBindingsQueue.Pop().(*api.ResourceBindingInfo)
+			if _, held := cp.manual.heldBy(rbi); held || rbi.IsExpired() {
+				continue
+			}
+			blocker := clusterFailureBlocker(ssn, rbi)
+			if blocker == nil {
+				blocker = ssn.Dispatchable(rbi)
+			}
+			if rbi != target {
+				if blocker == nil {
+					ssn.Dispatch(rbi)
+					explanation.Ahead++
+				}
+				continue
+			}
+
+			if blocker != nil {
+				explanation.Plugin = blocker.Plugin
+				explanation.Reason = blocker.Reason
+				explanation.Message = blocker.Message
+				return explanation
+			}
+			if feasible, message := cp.feasibilityChecker.Feasible(context.TODO(), rbi.ResourceBinding,
+				ssn.Snapshot.PlacementClusterNames(rbi.ResourceBinding.Spec.Placement), rbi.ClusterReplicaRequirements); !feasible {
+				explanation.Reason = feasibility.InfeasibleReason
+				explanation.Message = message
+				return explanation
+			}
+			if burst > 0 && explanation.Ahead >= burst {
+				explanation.Reason = api.LowerPriorityThanBacklogReason
+				explanation.Message = fmt.Sprintf("%d ResourceBindings are dispatched before it, but only %d can be dispatched in a round.",
+					explanation.Ahead, burst)
+				return explanation
+			}
+			explanation.Dispatchable = true
+			explanation.Reason = api.DispatchableReason
+			explanation.Message = "The ResourceBinding will be dispatched in the next round."
+			return explanation
+		}
+	}
+
+	// It shouldn't happen, the target is suspended and its queue exists.
+	explanation.Reason = api.NotManagedReason
+	explanation.Message = "The ResourceBinding is not found in the queues."
+	return explanation
+}
+
+// explainHandler explains why the ResourceBinding is still suspended by
+// `GET /debug/explain?namespace=<ns>&name=<name>&controlPlane=<name>`, the control plane is optional.
+func (dispatcher *Dispatcher) explainHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	key := types.NamespacedName{Namespace: r.URL.Query().Get("namespace"), Name: r.URL.Query().Get("name")}
+	if key.Namespace == "" || key.Name == "" {
+		http.Error(w, "namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	cp, found := dispatcher.controlPlaneOf(r)
+	if !found {
+		http.Error(w, "control plane not found", http.StatusNotFound)
+		return
+	}
+
+	dispatcher.mutex.Lock()
+	configuration := dispatcher.configuration
+	dispatcher.mutex.Unlock()
+
+	burst := 0
+	if configuration.RateLimit.QPS > 0 {
+		burst = configuration.RateLimit.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+	}
+
+	ssn := dispatcherframework.OpenSession(cp.cache, configuration.Plugins)
+	ssn.SetTieBreaker(configuration.TieBreaker, cp.nextCycle())
+	ssn.SetPreemptionMatrix(configuration.PreemptionMatrix)
+	explanation := dispatcher.explain(cp, ssn, key, configuration.Paused, burst)
+	ssn.CloseSession()
+
+	writeJSON(w, explanation)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	"volcano.sh/volcano-global/pkg/dispatcher/feasibility"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// fakeCache returns the snapshot and the event errors, and records the suspended and failed ResourceBindings, their
+// failed placements and the resynced kinds only, the other operations do nothing.
+type fakeCache struct {
+	snapshot         *cache.DispatcherCacheSnapshot
+	unsuspended      []types.NamespacedName
+	suspended        []types.NamespacedName
+	failed           []types.NamespacedName
+	failedPlacements map[types.NamespacedName][]string
+	eventErrors      map[string]int
+	resynced         []string
+}
+
+func (fc *fakeCache) Run(_ <-chan struct{}) {}
+
+func (fc *fakeCache) Snapshot() *cache.DispatcherCacheSnapshot {
+	return fc.snapshot
+}
+
+func (fc *fakeCache) LookupResourceBinding(key types.NamespacedName) (string, api.DispatchStatus, bool) {
+	for _, rbi := range fc.snapshot.ResourceBindingInfos {
+		if rbi.ResourceBinding.Namespace == key.Namespace && rbi.ResourceBinding.Name == key.Name {
+			queueName := rbi.Queue
+			if queueName == "" {
+				queueName = fc.snapshot.DefaultQueue
+			}
+			return queueName, rbi.DispatchStatus, true
+		}
+	}
+	return "", 0, false
+}
+
+func (fc *fakeCache) UnSuspendResourceBinding(key types.NamespacedName) {
+	fc.unsuspended = append(fc.unsuspended, key)
+}
+
+func (fc *fakeCache) UnSuspendResourceBindingToCluster(key types.NamespacedName, _ string) {
+	fc.unsuspended = append(fc.unsuspended, key)
+}
+
+func (fc *fakeCache) SuspendResourceBinding(key types.NamespacedName) {
+	fc.suspended = append(fc.suspended, key)
+}
+
+func (fc *fakeCache) SuspendResourceBindingOnFailure(key types.NamespacedName, clusters []string) {
+	fc.suspended = append(fc.suspended, key)
+	if fc.failedPlacements == nil {
+		fc.failedPlacements = map[types.NamespacedName][]string{}
+	}
+	fc.failedPlacements[key] = clusters
+}
+
+func (fc *fakeCache) FailResourceBinding(key types.NamespacedName) {
+	fc.failed = append(fc.failed, key)
+}
+
+func (fc *fakeCache) UpdateResourceBindingCondition(_ types.NamespacedName, _ metav1.Condition) {}
+
+func (fc *fakeCache) AnnotateResourceBinding(_ types.NamespacedName, _, _ string) {}
+
+func (fc *fakeCache) ExpireWorkload(_ types.NamespacedName, _ string) {}
+
+func (fc *fakeCache) CheckConsistency(_ context.Context, _ bool) (*api.ConsistencyReport, error) {
+	return &api.ConsistencyReport{}, nil
+}
+
+func (fc *fakeCache) CollectGarbage() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) WakeUp() <-chan struct{} {
+	return nil
+}
+
+func (fc *fakeCache) WorkQueueDepths() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) TakeCoalescedStatusUpdates() int {
+	return 0
+}
+
+func (fc *fakeCache) TakeEventErrors() map[string]int {
+	eventErrors := fc.eventErrors
+	fc.eventErrors = nil
+	return eventErrors
+}
+
+func (fc *fakeCache) Resync(kind string) int {
+	fc.resynced = append(fc.resynced, kind)
+	return 0
+}
+
+func (fc *fakeCache) SetDefaultQueue(_ string) {}
+
+func (fc *fakeCache) SetMinResourcesSource(_ string) {}
+
+// SetQueuePausedBy sets the annotation on the queue in the snapshot, like the informer of the queues would do.
+func (fc *fakeCache) SetQueuePausedBy(queueName, user string) error {
+	queue, found := fc.snapshot.QueueInfos[queueName]
+	if !found || queue.Queue == nil {
+		return fmt.Errorf("queue %s is not found", queueName)
+	}
+	if user == "" {
+		delete(queue.Queue.Annotations, api.QueuePausedByAnnotationKey)
+		return nil
+	}
+	if queue.Queue.Annotations == nil {
+		queue.Queue.Annotations = map[string]string{}
+	}
+	queue.Queue.Annotations[api.QueuePausedByAnnotationKey] = user
+	return nil
+}
+
+func TestExplain(t *testing.T) {
+	newRBI := func(name, queue string, priority int32, status api.DispatchStatus, withPodGroup bool) *api.ResourceBindingInfo {
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				UID:       types.UID(name),
+			}},
+			Queue:          queue,
+			Priority:       priority,
+			DispatchStatus: status,
+		}
+		if withPodGroup {
+			rbi.PodGroup = &schedulingv1beta1.PodGroup{}
+		}
+		return rbi
+	}
+	queue := func(name string, annotations map[string]string) *schedulingapi.QueueInfo {
+		return &schedulingapi.QueueInfo{
+			UID:  schedulingapi.QueueID(name),
+			Name: name,
+			Queue: &scheduling.Queue{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+			},
+		}
+	}
+
+	snapshot := &cache.DispatcherCacheSnapshot{
+		DefaultQueue: "default",
+		QueueInfos: map[string]*schedulingapi.QueueInfo{
+			"default": queue("default", nil),
+			"paused":  queue("paused", map[string]string{api.QueuePausedAnnotationKey: "true"}),
+		},
+		ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+	}
+	for _, rbi := range []*api.ResourceBindingInfo{
+		newRBI("high", "", 100, api.Pending, true),
+		newRBI("low", "", 1, api.Pending, true),
+		newRBI("dispatched", "", 1, api.Dispatched, true),
+		newRBI("no-podgroup", "", 1, api.Pending, false),
+		newRBI("no-queue", "not-found", 1, api.Pending, true),
+		newRBI("in-paused", "paused", 1, api.Pending, true),
+	} {
+		snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+	}
+
+	dispatcher := &Dispatcher{}
+	cp := &controlPlane{name: defaultControlPlane, feasibilityChecker: feasibility.NewNoopChecker()}
+
+	testCases := []struct {
+		Name         string
+		rbName       string
+		burst        int
+		expectReason string
+		expectAhead  int
+	}{
+		{Name: "Not managed", rbName: "not-found", expectReason: api.NotManagedReason},
+		{Name: "Already dispatched", rbName: "dispatched", expectReason: api.DispatchedReason},
+		{Name: "Without PodGroup", rbName: "no-podgroup", expectReason: api.GangIncompleteReason},
+		{Name: "Queue not found", rbName: "no-queue", expectReason: api.QueueNotFoundReason},
+		{Name: "Queue paused", rbName: "in-paused", expectReason: api.DispatchPausedReason},
+		{Name: "Highest priority", rbName: "high", burst: 1, expectReason: api.DispatchableReason},
+		{Name: "Rate limited by the backlog", rbName: "low", burst: 1, expectReason: api.LowerPriorityThanBacklogReason, expectAhead: 1},
+		{Name: "No rate limit", rbName: "low", expectReason: api.DispatchableReason, expectAhead: 1},
+	}
+
+	for _, tc := range testCases {
+		ssn := dispatcherframework.OpenSession(&fakeCache{snapshot: snapshot}, []conf.PluginOption{{Name: "priority"}})
+		explanation := dispatcher.explain(cp, ssn, types.NamespacedName{Namespace: "ns", Name: tc.rbName}, false, tc.burst)
+		ssn.CloseSession()
+
+		if explanation.Reason != tc.expectReason || explanation.Ahead != tc.expectAhead {
+			t.Errorf("Test case %s failed, got: %s ahead %d expect: %s ahead %d",
+				tc.Name, explanation.Reason, explanation.Ahead, tc.expectReason, tc.expectAhead)
+		}
+	}
+}
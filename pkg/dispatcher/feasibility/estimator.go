@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feasibility
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	estimatorclient "github.com/karmada-io/karmada/pkg/estimator/client"
+	"github.com/karmada-io/karmada/pkg/util/grpcconnection"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// EstimatorOption is the option of the karmada-scheduler-estimator, it follows the flags of the karmada-scheduler.
+type EstimatorOption struct {
+	Timeout          time.Duration
+	ServiceNamespace string
+	ServicePrefix    string
+	GRPCConfig       grpcconnection.ClientConfig
+}
+
+type estimatorChecker struct {
+	kubeClient kubernetes.Interface
+	option     EstimatorOption
+	cache      *estimatorclient.SchedulerEstimatorCache
+	estimator  *estimatorclient.SchedulerEstimator
+}
+
+// NewEstimatorChecker returns a FeasibilityChecker which calls the karmada-scheduler-estimator of each
+// target cluster to check whether the replicas assigned to the cluster can fit in it.
+func NewEstimatorChecker(kubeClient kubernetes.Interface, option EstimatorOption) FeasibilityChecker {
+	cache := estimatorclient.NewSchedulerEstimatorCache()
+	return &estimatorChecker{
+		kubeClient: kubeClient,
+		option:     option,
+		cache:      cache,
+		estimator:  estimatorclient.NewSchedulerEstimator(cache, option.Timeout),
+	}
+}
+
+func (c *estimatorChecker) Feasible(ctx context.Context, rb *workv1alpha2.ResourceBinding, candidateClusters []string,
+	clusterRequirements map[string]*workv1alpha2.ReplicaRequirements) (bool, string) {
+	// The estimator needs the requirements of a replica, and the clusters to estimate.
+	clusterNames := estimatedClusters(rb, candidateClusters)
+	if rb.Spec.ReplicaRequirements == nil || len(clusterNames) == 0 {
+		return true, ""
+	}
+
+	clusters := make([]*clusterv1alpha1.Cluster, 0, len(clusterNames))
+	for _, name := range clusterNames {
+		if err := estimatorclient.EstablishConnection(c.kubeClient, estimatorclient.SchedulerEstimatorServiceInfo{
+			Name:       name,
+			NamePrefix: c.option.ServicePrefix,
+			Namespace:  c.option.ServiceNamespace,
+		}, c.cache, &c.option.GRPCConfig); err != nil {
+			// We can't verify the cluster without its estimator, don't block the ResourceBinding because of it.
+			klog.Warningf("Failed to establish connection with the scheduler estimator of cluster <%s>, err: %v", name, err)
+			return true, ""
+		}
+		clusters = append(clusters, &clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+
+	// The clusters whose requirements are overridden are estimated one by one, the others together.
+	var availableReplicas []workv1alpha2.TargetCluster
+	groups := map[*workv1alpha2.ReplicaRequirements][]*clusterv1alpha1.Cluster{}
+	for _, cluster := range clusters {
+		requirements, found := clusterRequirements[cluster.Name]
+		if !found {
+			requirements = rb.Spec.ReplicaRequirements
+		}
+		groups[requirements] = append(groups[requirements], cluster)
+	}
+	for requirements, group := range groups {
+		available, err := c.estimator.MaxAvailableReplicas(ctx, group, requirements)
+		if err != nil {
+			klog.Warningf("Failed to estimate the available replicas of ResourceBinding <%s/%s>, err: %v",
+				rb.Namespace, rb.Name, err)
+			return true, ""
+		}
+		availableReplicas = append(availableReplicas, available...)
+	}
+
+	if len(rb.Spec.Clusters) == 0 {
+		return checkCandidateClusters(rb, availableReplicas)
+	}
+	return checkTargetClusters(rb.Spec.Clusters, availableReplicas)
+}
+
+// estimatedClusters returns the clusters to estimate, they're the target clusters scheduled by the karmada-scheduler,
+// or the candidate clusters when it's not scheduled yet, like the ResourceBinding suspended by spec.suspend.
+func estimatedClusters(rb *workv1alpha2.ResourceBinding, candidateClusters []string) []string {
+	if len(rb.Spec.Clusters) == 0 {
+		return candidateClusters
+	}
+	clusters := make([]string, 0, len(rb.Spec.Clusters))
+	for _, target := range rb.Spec.Clusters {
+		clusters = append(clusters, target.Name)
+	}
+	return clusters
+}
+
+// checkCandidateClusters checks whether the candidate clusters can hold the replicas before they're scheduled, the
+// divided replicas can be spread among them, and the duplicated ones need a cluster holding all of them at least.
+func checkCandidateClusters(rb *workv1alpha2.ResourceBinding, availableReplicas []workv1alpha2.TargetCluster) (bool, string) {
+	if rb.Spec.Replicas <= 0 {
+		return true, ""
+	}
+	divided := rb.Spec.Placement != nil && rb.Spec.Placement.ReplicaSchedulingType() == policyv1alpha1.ReplicaSchedulingTypeDivided
+
+	var total, most int32
+	for _, cluster := range availableReplicas {
+		// UnauthenticReplica means the estimator can't give an exact result.
+		if cluster.Replicas == estimatorclient.UnauthenticReplica {
+			return true, ""
+		}
+		total += cluster.Replicas
+		most = max(most, cluster.Replicas)
+	}
+	if divided && total < rb.Spec.Replicas {
+		return false, fmt.Sprintf("the candidate clusters can only hold %d replicas, but %d replicas are required",
+			total, rb.Spec.Replicas)
+	}
+	if !divided && most < rb.Spec.Replicas {
+		return false, fmt.Sprintf("no candidate cluster can hold %d replicas, %d replicas at most",
+			rb.Spec.Replicas, most)
+	}
+	return true, ""
+}
+
+// checkTargetClusters checks whether every target cluster has enough available replicas for its assignment.
+func checkTargetClusters(targets, availableReplicas []workv1alpha2.TargetCluster) (bool, string) {
+	available := make(map[string]int32, len(availableReplicas))
+	for _, cluster := range availableReplicas {
+		available[cluster.Name] = cluster.Replicas
+	}
+
+	for _, target := range targets {
+		replicas, found := available[target.Name]
+		// UnauthenticReplica means the estimator can't give an exact result.
+		if !found || replicas == estimatorclient.UnauthenticReplica {
+			continue
+		}
+		if replicas < target.Replicas {
+			return false, fmt.Sprintf("cluster %s can only hold %d replicas, but %d replicas are assigned to it",
+				target.Name, replicas, target.Replicas)
+		}
+	}
+	return true, ""
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feasibility
+
+import (
+	"reflect"
+	"testing"
+
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	estimatorclient "github.com/karmada-io/karmada/pkg/estimator/client"
+)
+
+func TestCheckTargetClusters(t *testing.T) {
+	targets := []workv1alpha2.TargetCluster{
+		{Name: "member1", Replicas: 2},
+		{Name: "member2", Replicas: 3},
+	}
+
+	testCases := []struct {
+		Name              string
+		availableReplicas []workv1alpha2.TargetCluster
+		expectFeasible    bool
+	}{
+		{
+			Name: "All clusters have enough replicas",
+			availableReplicas: []workv1alpha2.TargetCluster{
+				{Name: "member1", Replicas: 2},
+				{Name: "member2", Replicas: 10},
+			},
+			expectFeasible: true,
+		},
+		{
+			Name: "One cluster doesn't have enough replicas",
+			availableReplicas: []workv1alpha2.TargetCluster{
+				{Name: "member1", Replicas: 2},
+				{Name: "member2", Replicas: 1},
+			},
+			expectFeasible: false,
+		},
+		{
+			Name: "Unauthentic replicas should be ignored",
+			availableReplicas: []workv1alpha2.TargetCluster{
+				{Name: "member1", Replicas: estimatorclient.UnauthenticReplica},
+				{Name: "member2", Replicas: 3},
+			},
+			expectFeasible: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		feasible, message := checkTargetClusters(targets, tc.availableReplicas)
+		if feasible != tc.expectFeasible {
+			t.Errorf("Test case %s failed, got: %v (%s) expect: %v", tc.Name, feasible, message, tc.expectFeasible)
+		}
+	}
+}
+
+func TestEstimatedClusters(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		rb             *workv1alpha2.ResourceBinding
+		expectClusters []string
+	}{
+		{
+			Name: "Suspended ResourceBinding not scheduled yet",
+			rb: &workv1alpha2.ResourceBinding{Spec: workv1alpha2.ResourceBindingSpec{
+				Suspend: true,
+			}},
+			expectClusters: []string{"member1", "member2"},
+		},
+		{
+			Name: "Scheduled ResourceBinding",
+			rb: &workv1alpha2.ResourceBinding{Spec: workv1alpha2.ResourceBindingSpec{
+				Clusters: []workv1alpha2.TargetCluster{{Name: "member2", Replicas: 1}},
+			}},
+			expectClusters: []string{"member2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		if clusters := estimatedClusters(tc.rb, []string{"member1", "member2"}); !reflect.DeepEqual(clusters, tc.expectClusters) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, clusters, tc.expectClusters)
+		}
+	}
+}
+
+func TestCheckCandidateClusters(t *testing.T) {
+	newSuspendedRB := func(replicaSchedulingType policyv1alpha1.ReplicaSchedulingType) *workv1alpha2.ResourceBinding {
+		return &workv1alpha2.ResourceBinding{Spec: workv1alpha2.ResourceBindingSpec{
+			Suspend:  true,
+			Replicas: 4,
+			Placement: &policyv1alpha1.Placement{ReplicaScheduling: &policyv1alpha1.ReplicaSchedulingStrategy{
+				ReplicaSchedulingType: replicaSchedulingType,
+			}},
+		}}
+	}
+	availableReplicas := []workv1alpha2.TargetCluster{
+		{Name: "member1", Replicas: 2},
+		{Name: "member2", Replicas: 3},
+	}
+
+	testCases := []struct {
+		Name              string
+		rb                *workv1alpha2.ResourceBinding
+		availableReplicas []workv1alpha2.TargetCluster
+		expectFeasible    bool
+	}{
+		{
+			Name:              "Divided replicas spread among the candidate clusters",
+			rb:                newSuspendedRB(policyv1alpha1.ReplicaSchedulingTypeDivided),
+			availableReplicas: availableReplicas,
+			expectFeasible:    true,
+		},
+		{
+			Name:              "Duplicated replicas don't fit in any candidate cluster",
+			rb:                newSuspendedRB(policyv1alpha1.ReplicaSchedulingTypeDuplicated),
+			availableReplicas: availableReplicas,
+			expectFeasible:    false,
+		},
+		{
+			Name:              "Divided replicas exceed the candidate clusters",
+			rb:                newSuspendedRB(policyv1alpha1.ReplicaSchedulingTypeDivided),
+			availableReplicas: availableReplicas[:1],
+			expectFeasible:    false,
+		},
+		{
+			Name: "Unauthentic replicas should be ignored",
+			rb:   newSuspendedRB(policyv1alpha1.ReplicaSchedulingTypeDuplicated),
+			availableReplicas: []workv1alpha2.TargetCluster{
+				{Name: "member1", Replicas: estimatorclient.UnauthenticReplica},
+			},
+			expectFeasible: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		feasible, message := checkCandidateClusters(tc.rb, tc.availableReplicas)
+		if feasible != tc.expectFeasible {
+			t.Errorf("Test case %s failed, got: %v (%s) expect: %v", tc.Name, feasible, message, tc.expectFeasible)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feasibility
+
+import (
+	"context"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+)
+
+// InfeasibleReason is the reason of the DispatchedCondition when the replicas can't fit in the clusters.
+const InfeasibleReason = "Infeasible"
+
+// FeasibilityChecker checks whether the replicas of a ResourceBinding can actually fit in the member clusters
+// before the dispatcher unsuspends it.
+type FeasibilityChecker interface {
+	// Feasible returns false with a message when the replicas can't fit in the target clusters, or in the candidate
+	// clusters allowed by the placement when the ResourceBinding is not scheduled yet. The clusterRequirements
+	// override the ReplicaRequirements of the ResourceBinding in the clusters, like by the OverridePolicies.
+	Feasible(ctx context.Context, rb *workv1alpha2.ResourceBinding, candidateClusters []string,
+		clusterRequirements map[string]*workv1alpha2.ReplicaRequirements) (bool, string)
+}
+
+type noopChecker struct{}
+
+// NewNoopChecker returns a FeasibilityChecker which treats every ResourceBinding as feasible.
+func NewNoopChecker() FeasibilityChecker {
+	return &noopChecker{}
+}
+
+func (c *noopChecker) Feasible(_ context.Context, _ *workv1alpha2.ResourceBinding, _ []string, _ map[string]*workv1alpha2.ReplicaRequirements) (bool, string) {
+	return true, ""
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"sync"
+
+	"k8s.io/klog/v2"
+	volcanoapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// Action is a step of the dispatching round, the actions set by the `actions` of the configuration are executed
+// in order in the session, like the actions of the volcano-scheduler.
+type Action interface {
+	// Name The unique name of Action.
+	Name() string
+
+	// Execute runs the action in the session, the ResourceBindings are changed by the Operations of the session.
+	Execute(ssn *Session)
+}
+
+// Operations are the operations of the dispatcher called by the actions, they change the ResourceBindings of the
+// control plane of the session, tell the users why by the conditions and record the decisions.
+type Operations interface {
+	// OwnsQueue checks whether the ResourceBindings of the queue are dispatched by this replica.
+	OwnsQueue(queue string) bool
+	// IsQueuePaused checks whether the dispatching of the queue is paused.
+	IsQueuePaused(queue *volcanoapi.QueueInfo) bool
+	// QueuePausedBy returns the user who paused the queue by the admin endpoint, it's empty when the queue isn't
+	// paused by the endpoint.
+	QueuePausedBy(queue *volcanoapi.QueueInfo) string
+	// HeldBy returns the user who holds the ResourceBinding manually.
+	HeldBy(rbi *api.ResourceBindingInfo) (string, bool)
+
+	// Enqueue prepares the suspended ResourceBindings for the round, the ones released manually are released, and
+	// the metrics of the queues are updated. It's done once in a round, the later calls do nothing.
+	Enqueue()
+	// Dispatchable checks the ResourceBinding by the plugins and whether its replicas fit the clusters, it returns
+	// the blocker, or the elastic cluster to release it toward speculatively when it doesn't fit.
+	Dispatchable(queue *volcanoapi.QueueInfo, rbi *api.ResourceBindingInfo) (blocker *api.DispatchBlocker, speculativeCluster string)
+	// Block tells the user why the ResourceBinding is not dispatched in the round.
+	Block(queue *volcanoapi.QueueInfo, rbi *api.ResourceBindingInfo, blocker *api.DispatchBlocker)
+	// Hold tells the user the ResourceBinding is held manually by the user.
+	Hold(queue *volcanoapi.QueueInfo, rbi *api.ResourceBindingInfo, user string)
+	// Allocate dispatches the ResourceBinding, it returns false without dispatching it when the rate limit is reached.
+	Allocate(queue *volcanoapi.QueueInfo, rbi *api.ResourceBindingInfo, speculativeCluster string) bool
+	// Commit releases the ResourceBindings reserved by the two-phase dispatching after verifying them again.
+	Commit()
+	// Evict suspends the dispatched victim again to make room for the preemptor, the reason tells how.
+	Evict(victim, preemptor *api.ResourceBindingInfo, reason string)
+}
+
+var (
+	actionMutex sync.Mutex
+	actionMap   = map[string]Action{}
+)
+
+// RegisterAction registers the action by its name.
+func RegisterAction(action Action) {
+	actionMutex.Lock()
+	defer actionMutex.Unlock()
+
+	actionMap[action.Name()] = action
+	klog.V(3).Infof("Register action <%s> done.", action.Name())
+}
+
+// GetAction returns the action registered by the name.
+func GetAction(name string) (Action, bool) {
+	actionMutex.Lock()
+	defer actionMutex.Unlock()
+
+	action, found := actionMap[name]
+	return action, found
+}
+
+// SetOperations sets the operations of the dispatcher called by the actions in the session.
+func (ssn *Session) SetOperations(operations Operations) {
+	ssn.operations = operations
+}
+
+// Operations returns the operations of the dispatcher called by the actions in the session.
+func (ssn *Session) Operations() Operations {
+	return ssn.operations
+}
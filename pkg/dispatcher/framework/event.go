@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// Event The event of the ResourceBindingInfo in the session.
+type Event struct {
+	ResourceBindingInfo *api.ResourceBindingInfo
+}
+
+// EventHandler The plugins can register the handler to update their state in the session,
+// like the resources used by the ResourceBindingInfos dispatched in the same session.
+type EventHandler struct {
+	DispatchFunc func(event *Event)
+	// EvictFunc is called when the dispatched ResourceBindingInfo is suspended again in the session.
+	EvictFunc func(event *Event)
+	// UnreserveFunc is called when the ResourceBindingInfo dispatched in the session is not released in the end,
+	// like it's rejected by the verification of the two-phase dispatching. The EvictFunc is called when it's not set.
+	UnreserveFunc func(event *Event)
+}
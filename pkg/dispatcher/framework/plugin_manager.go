@@ -24,8 +24,8 @@ import (
 
 var PluginManagerInstance *PluginManager
 
-// PluginBuilder the builder of a plugin.
-type PluginBuilder func() Plugin
+// PluginBuilder the builder of a plugin, the arguments come from the dispatcher configuration.
+type PluginBuilder func(arguments Arguments) Plugin
 
 // PluginManager The manager of plugins.
 type PluginManager struct {
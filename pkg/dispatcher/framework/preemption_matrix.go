@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"strings"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// preemptAllQueues in the queues a queue can reclaim from means all the queues.
+const preemptAllQueues = "*"
+
+// SetPreemptionMatrix sets the queues the workloads of each queue can reclaim from by the configuration, the
+// `volcano.sh/preempt-queues` annotations of the queues override it.
+func (ssn *Session) SetPreemptionMatrix(matrix map[string][]string) {
+	ssn.preemptionMatrix = matrix
+}
+
+// MayPreempt checks whether the dispatched workloads of the victim queue can be suspended again for the workloads of
+// the preemptor queue. A queue can always preempt its own workloads, and the queues without the rule can preempt
+// the workloads of all the queues.
+func (ssn *Session) MayPreempt(preemptorQueue, victimQueue string) bool {
+	if preemptorQueue == victimQueue {
+		return true
+	}
+	victimQueues, found := ssn.preemptQueues(preemptorQueue)
+	if !found {
+		return true
+	}
+	for _, queueName := range victimQueues {
+		if queueName == preemptAllQueues || queueName == victimQueue {
+			return true
+		}
+	}
+	return false
+}
+
+// preemptQueues returns the queues the workloads of the queue can preempt, the annotation of the queue takes
+// precedence over the configuration. It returns false when neither of them sets the rule of the queue.
+func (ssn *Session) preemptQueues(queueName string) ([]string, bool) {
+	if queue, found := ssn.Snapshot.QueueInfos[queueName]; found && queue.Queue != nil {
+		if value, found := queue.Queue.Annotations[api.PreemptQueuesAnnotationKey]; found {
+			var victimQueues []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					victimQueues = append(victimQueues, item)
+				}
+			}
+			return victimQueues, true
+		}
+	}
+	victimQueues, found := ssn.preemptionMatrix[queueName]
+	return victimQueues, found
+}
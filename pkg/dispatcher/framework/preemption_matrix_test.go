@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatchercache "volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func TestMayPreempt(t *testing.T) {
+	newQueue := func(name string, annotations map[string]string) *schedulingapi.QueueInfo {
+		return &schedulingapi.QueueInfo{Name: name, Queue: &scheduling.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}}
+	}
+	matrix := map[string][]string{"prod": {"best-effort"}, "ops": {"*"}}
+
+	testCases := []struct {
+		Name           string
+		annotations    map[string]string
+		preemptorQueue string
+		victimQueue    string
+		expect         bool
+	}{
+		{
+			Name:           "Own queue",
+			preemptorQueue: "prod",
+			victimQueue:    "prod",
+			expect:         true,
+		},
+		{
+			Name:           "Allowed by the matrix",
+			preemptorQueue: "prod",
+			victimQueue:    "best-effort",
+			expect:         true,
+		},
+		{
+			Name:           "Not allowed by the matrix",
+			preemptorQueue: "prod",
+			victimQueue:    "research",
+			expect:         false,
+		},
+		{
+			Name:           "All the queues",
+			preemptorQueue: "ops",
+			victimQueue:    "research",
+			expect:         true,
+		},
+		{
+			Name:           "No rule",
+			preemptorQueue: "research",
+			victimQueue:    "prod",
+			expect:         true,
+		},
+		{
+			Name:           "Annotation overrides the matrix",
+			annotations:    map[string]string{api.PreemptQueuesAnnotationKey: "research, batch"},
+			preemptorQueue: "prod",
+			victimQueue:    "research",
+			expect:         true,
+		},
+		{
+			Name:           "Empty annotation preempts no other queue",
+			annotations:    map[string]string{api.PreemptQueuesAnnotationKey: ""},
+			preemptorQueue: "prod",
+			victimQueue:    "best-effort",
+			expect:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		ssn := &Session{Snapshot: &dispatchercache.DispatcherCacheSnapshot{
+			QueueInfos: map[string]*schedulingapi.QueueInfo{"prod": newQueue("prod", tc.annotations)},
+		}}
+		ssn.SetPreemptionMatrix(matrix)
+		if got := ssn.MayPreempt(tc.preemptorQueue, tc.victimQueue); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expect)
+		}
+	}
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package framework
 
 import (
+	"sort"
+
 	"k8s.io/klog/v2"
 	"k8s.io/kube-openapi/pkg/util/sets"
 	volcanoapi "volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
 
 	"volcano.sh/volcano-global/pkg/dispatcher/api"
 	dispatchercache "volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
 )
 
 // Session The session stores the information needed by the dispatcher during each dispatch operation.
@@ -33,12 +37,27 @@ type Session struct {
 	cache    dispatchercache.DispatcherCacheInterface
 	Snapshot *dispatchercache.DispatcherCacheSnapshot
 
-	plugins                     map[string]Plugin
+	plugins map[string]Plugin
+	// pluginNames are the names of the enabled plugins in the order of the configuration,
+	// the order functions, the dispatchable and the reclaimable functions of the plugins are called in this order.
+	pluginNames                 []string
 	queueInfoOrderFns           map[string]volcanoapi.CompareFn
 	resourceBindingInfoOrderFns map[string]volcanoapi.CompareFn
+	dispatchableFns             map[string]api.DispatchableFn
+	reclaimableFns              map[string]api.ReclaimableFn
+	eventHandlers               []*EventHandler
+	// tieBreaker orders the ResourceBindingInfos with the same priority and age, they're ordered by the UIDs
+	// when it's nil.
+	tieBreaker *weightedRandomTieBreaker
+	// preemptionMatrix[queue] are the queues the workloads of the queue can reclaim from by the configuration.
+	preemptionMatrix map[string][]string
+	// usageVersion counts the dispatching, the evictions and the unreservations notified in the session.
+	usageVersion uint64
+	// operations are the operations of the dispatcher called by the actions.
+	operations Operations
 }
 
-func OpenSession(cache dispatchercache.DispatcherCacheInterface) *Session {
+func OpenSession(cache dispatchercache.DispatcherCacheInterface, pluginOptions []conf.PluginOption) *Session {
 	session := &Session{
 		cache:    cache,
 		Snapshot: cache.Snapshot(),
@@ -46,12 +65,21 @@ func OpenSession(cache dispatchercache.DispatcherCacheInterface) *Session {
 		plugins:                     map[string]Plugin{},
 		queueInfoOrderFns:           map[string]volcanoapi.CompareFn{},
 		resourceBindingInfoOrderFns: map[string]volcanoapi.CompareFn{},
+		dispatchableFns:             map[string]api.DispatchableFn{},
+		reclaimableFns:              map[string]api.ReclaimableFn{},
 	}
 
-	// Register all the plugins to session.
-	for pluginName, pluginBuilder := range PluginManagerInstance.GetPluginBuilders() {
-		session.plugins[pluginName] = pluginBuilder()
-		session.plugins[pluginName].OnSessionOpen(session)
+	// Register the enabled plugins to session.
+	pluginBuilders := PluginManagerInstance.GetPluginBuilders()
+	for _, pluginOption := range pluginOptions {
+		pluginBuilder, found := pluginBuilders[pluginOption.Name]
+		if !found {
+			klog.Errorf("Failed to get plugin <%s>, skip it.", pluginOption.Name)
+			continue
+		}
+		session.plugins[pluginOption.Name] = pluginBuilder(pluginOption.Arguments)
+		session.pluginNames = append(session.pluginNames, pluginOption.Name)
+		session.plugins[pluginOption.Name].OnSessionOpen(session)
 	}
 
 	klog.V(5).Infof("OpenSession done, QueueCount <%d>, ResourceBindingCount <%d> ...",
@@ -72,6 +100,12 @@ func (ssn *Session) CloseSession() {
 	klog.V(5).Infof("CloseSession done, Registered plugins <%v> ...", pluginNameSet.List())
 }
 
+// UsageVersion returns the number of the dispatching, the evictions and the unreservations notified in the session,
+// the usage of the queues in the session changes when it changes.
+func (ssn *Session) UsageVersion() uint64 {
+	return ssn.usageVersion
+}
+
 // GetResourceBindingInfoQueue Get the workload's queue name, it may be empty when DefaultQueue is empty.
 func (ssn *Session) GetResourceBindingInfoQueue(rbi *api.ResourceBindingInfo) string {
 	name := rbi.Queue
@@ -83,3 +117,97 @@ func (ssn *Session) GetResourceBindingInfoQueue(rbi *api.ResourceBindingInfo) st
 	}
 	return name
 }
+
+// BuildQueues collects the suspended ResourceBindingInfos of the owned queues into the priority queues of their
+// queues, it returns the priority queue of the queues and the priority queues of the ResourceBindingInfos by the queue name.
+func (ssn *Session) BuildQueues(ownsQueue func(queue string) bool) (*util.PriorityQueue, map[string]*util.PriorityQueue) {
+	ss := ssn.Snapshot
+	queues := util.NewPriorityQueue(ssn.QueueInfoOrderFn)
+	resourceBindingMap := map[string]*util.PriorityQueue{}
+	// The count for logs.
+	enqueueResourceBindingCount := 0
+
+	// Collect the workloads to the queue map.
+	// The workloads are the ones in the workload registry, the controllers create the PodGroups for them.
+	for _, rbi := range ss.ResourceBindingInfos {
+		rb := rbi.ResourceBinding
+
+		// Check if its Suspended, dispatcher cares the suspended rbi only.
+		if rbi.DispatchStatus.IsDispatched() {
+			continue
+		}
+		// The queue is dispatched by another replica of the shard group.
+		if !ownsQueue(ssn.GetResourceBindingInfoQueue(rbi)) {
+			continue
+		}
+
+		// If its workload but without PodGroup, skip it.
+		// Only workload ResourceBinding will be suspend and add to the dispatcher cache.
+		if rbi.PodGroup == nil {
+			klog.Errorf("ResourceBinding <%s/%s> is a workload but has no PodGroup, stop dispatching and enqueue.",
+				rb.Namespace, rb.Name)
+			continue
+		}
+
+		// Get the workload's queue name, it may be a nil.
+		rbiQueueName := ssn.GetResourceBindingInfoQueue(rbi)
+		resource := rb.Spec.Resource
+
+		// Check if the queue set in the map.
+		if rbiPriorityQueue, found := resourceBindingMap[rbiQueueName]; found {
+			// Add this workload to the queue.
+			rbiPriorityQueue.Push(rbi)
+		} else {
+			// This queue didn't set in the map, we should check if the queue exists first, then add it to the map.
+			if queue, found := ss.QueueInfos[rbiQueueName]; found {
+				klog.V(5).Infof("Added Queue <%s> for ResourceBinding <%s/%s>.",
+					rbiQueueName, rb.Namespace, rb.Name)
+				// Create the priority queue for ResourceBindings, and push it.
+				resourceBindingMap[rbiQueueName] = util.NewPriorityQueue(ssn.resourceBindingInfoOrderFnOf(queue))
+				resourceBindingMap[rbiQueueName].Push(rbi)
+
+				queues.Push(queue)
+				enqueueResourceBindingCount++
+			} else {
+				// We cant find this queue in the cache snapshot, skip it.
+				klog.V(3).Infof("Resource %s <%s/%s> Queue <%s> not found, skip dispatching.",
+					resource.Kind, resource.Namespace, resource.Name, rbiQueueName)
+				continue
+			}
+		}
+	}
+
+	klog.V(5).Infof("Success enqueue <%d> ResourceBindingInfos and <%d> Queues, start dispatching now...",
+		enqueueResourceBindingCount, len(resourceBindingMap))
+
+	return queues, resourceBindingMap
+}
+
+// EvictionCandidates returns the dispatched and preemptable ResourceBindingInfos of the owned queues, the lower
+// priority first, then the later dispatched first, so the evictions waste the least work.
+func (ssn *Session) EvictionCandidates(ownsQueue func(queue string) bool) []*api.ResourceBindingInfo {
+	candidates := []*api.ResourceBindingInfo{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus == api.Dispatched && !rbi.IsCompleted() && rbi.IsPreemptable() &&
+			ssn.isQueuePreemptable(rbi) && ownsQueue(ssn.GetResourceBindingInfoQueue(rbi)) {
+			candidates = append(candidates, rbi)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority < candidates[j].Priority
+		}
+		if !candidates[i].UnSuspendTime.Equal(candidates[j].UnSuspendTime) {
+			return candidates[i].UnSuspendTime.After(candidates[j].UnSuspendTime)
+		}
+		return candidates[i].ResourceBinding.UID < candidates[j].ResourceBinding.UID
+	})
+	return candidates
+}
+
+// isQueuePreemptable checks whether the dispatched workloads of the queue can be suspended again, it's preemptable
+// unless the queue is annotated with `volcano.sh/preemptable: "false"`.
+func (ssn *Session) isQueuePreemptable(rbi *api.ResourceBindingInfo) bool {
+	queue, found := ssn.Snapshot.QueueInfos[ssn.GetResourceBindingInfoQueue(rbi)]
+	return !found || queue.Queue == nil || queue.Queue.Annotations[api.PreemptableAnnotationKey] != "false"
+}
@@ -32,8 +32,147 @@ func (ssn *Session) AddQueueInfoOrderFn(name string, compareFn volcanoapi.Compar
 	ssn.queueInfoOrderFns[name] = compareFn
 }
 
+// AddDispatchableFn add the function which checks whether the ResourceBindingInfo can be dispatched to the session.
+func (ssn *Session) AddDispatchableFn(name string, dispatchableFn api.DispatchableFn) {
+	ssn.dispatchableFns[name] = dispatchableFn
+	ssn.addPluginName(name)
+}
+
+// AddReclaimableFn add the function which selects the victims to reclaim the resources for the ResourceBindingInfo.
+func (ssn *Session) AddReclaimableFn(name string, reclaimableFn api.ReclaimableFn) {
+	ssn.reclaimableFns[name] = reclaimableFn
+	ssn.addPluginName(name)
+}
+
+// addPluginName appends the name which is not a configured plugin, like the functions added by the tests,
+// so its functions are called after the configured plugins.
+func (ssn *Session) addPluginName(name string) {
+	for _, pluginName := range ssn.pluginNames {
+		if pluginName == name {
+			return
+		}
+	}
+	ssn.pluginNames = append(ssn.pluginNames, name)
+}
+
+// AddEventHandler add the event handler to the session.
+func (ssn *Session) AddEventHandler(eh *EventHandler) {
+	ssn.eventHandlers = append(ssn.eventHandlers, eh)
+}
+
+// Dispatchable returns the first blocker of the ResourceBindingInfo by the plugin configured first, or nil if all the
+// plugins allow it to be dispatched.
+func (ssn *Session) Dispatchable(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	for _, name := range ssn.pluginNames {
+		dispatchableFn, found := ssn.dispatchableFns[name]
+		if !found {
+			continue
+		}
+		if blocker := dispatchableFn(rbi); blocker != nil {
+			blocker.Plugin = name
+			return blocker
+		}
+	}
+	return nil
+}
+
+// DispatchBlockers returns all the blockers of the ResourceBindingInfo in the order of the plugins, or nil if all the
+// plugins allow it to be dispatched.
+func (ssn *Session) DispatchBlockers(rbi *api.ResourceBindingInfo) []*api.DispatchBlocker {
+	var blockers []*api.DispatchBlocker
+	for _, name := range ssn.pluginNames {
+		dispatchableFn, found := ssn.dispatchableFns[name]
+		if !found {
+			continue
+		}
+		if blocker := dispatchableFn(rbi); blocker != nil {
+			blocker.Plugin = name
+			blockers = append(blockers, blocker)
+		}
+	}
+	return blockers
+}
+
+// Reclaimable returns the victims selected by all the plugins in the order of the configuration, each plugin selects
+// from the victims of the previous one.
+// It returns nil if any plugin refuses to reclaim for the reclaimer, or no plugin registers the ReclaimableFn.
+func (ssn *Session) Reclaimable(reclaimer *api.ResourceBindingInfo, candidates []*api.ResourceBindingInfo) []*api.ResourceBindingInfo {
+	if len(ssn.reclaimableFns) == 0 {
+		return nil
+	}
+	victims := candidates
+	for _, name := range ssn.pluginNames {
+		reclaimableFn, found := ssn.reclaimableFns[name]
+		if !found {
+			continue
+		}
+		if victims = reclaimableFn(reclaimer, victims); len(victims) == 0 {
+			return nil
+		}
+	}
+	return victims
+}
+
+// Evict notify the plugins that the dispatched ResourceBindingInfo is suspended again in this session.
+func (ssn *Session) Evict(rbi *api.ResourceBindingInfo) {
+	ssn.usageVersion++
+	for _, eh := range ssn.eventHandlers {
+		if eh.EvictFunc != nil {
+			eh.EvictFunc(&Event{
+				ResourceBindingInfo: rbi,
+			})
+		}
+	}
+}
+
+// Unevict notify the plugins that the ResourceBindingInfo evicted in this session is dispatched again, it reverts
+// Evict, so only the plugins handling the evictions are notified.
+func (ssn *Session) Unevict(rbi *api.ResourceBindingInfo) {
+	ssn.usageVersion++
+	for _, eh := range ssn.eventHandlers {
+		if eh.EvictFunc != nil && eh.DispatchFunc != nil {
+			eh.DispatchFunc(&Event{
+				ResourceBindingInfo: rbi,
+			})
+		}
+	}
+}
+
+// Dispatch notify the plugins that the ResourceBindingInfo is dispatched in this session.
+func (ssn *Session) Dispatch(rbi *api.ResourceBindingInfo) {
+	ssn.usageVersion++
+	for _, eh := range ssn.eventHandlers {
+		if eh.DispatchFunc != nil {
+			eh.DispatchFunc(&Event{
+				ResourceBindingInfo: rbi,
+			})
+		}
+	}
+}
+
+// Unreserve notify the plugins that the ResourceBindingInfo dispatched in this session is not released.
+func (ssn *Session) Unreserve(rbi *api.ResourceBindingInfo) {
+	ssn.usageVersion++
+	for _, eh := range ssn.eventHandlers {
+		unreserveFunc := eh.UnreserveFunc
+		if unreserveFunc == nil {
+			unreserveFunc = eh.EvictFunc
+		}
+		if unreserveFunc != nil {
+			unreserveFunc(&Event{
+				ResourceBindingInfo: rbi,
+			})
+		}
+	}
+}
+
+// QueueInfoOrderFn orders the queues by the order functions of the plugins, the plugin configured first takes precedence.
 func (ssn *Session) QueueInfoOrderFn(l, r interface{}) bool {
-	for _, orderFn := range ssn.queueInfoOrderFns {
+	for _, name := range ssn.pluginNames {
+		orderFn, found := ssn.queueInfoOrderFns[name]
+		if !found {
+			continue
+		}
 		if result := orderFn(l, r); result != 0 {
 			return result < 0
 		}
@@ -50,18 +189,43 @@ func (ssn *Session) QueueInfoOrderFn(l, r interface{}) bool {
 	return lv.Queue.CreationTimestamp.Before(&rv.Queue.CreationTimestamp)
 }
 
+// ResourceBindingInfoOrderFn orders the ResourceBindingInfos by the order functions of the plugins,
+// the plugin configured first takes precedence.
 func (ssn *Session) ResourceBindingInfoOrderFn(l, r interface{}) bool {
-	for _, orderFn := range ssn.resourceBindingInfoOrderFns {
+	for _, name := range ssn.pluginNames {
+		orderFn, found := ssn.resourceBindingInfoOrderFns[name]
+		if !found {
+			continue
+		}
 		if result := orderFn(l, r); result != 0 {
 			return result < 0
 		}
 	}
 
-	// If there is no ResourceBindingInfo order func, order by CreationTimestamp first, then by UID.
+	// If there is no ResourceBindingInfo order func, order by CreationTimestamp first, then by the tie breaker.
+	return ssn.creationOrderFn(l, r)
+}
+
+// resourceBindingInfoOrderFnOf returns the order function of the ResourceBindingInfos of the queue by its
+// DispatchOrderAnnotationKey, the order functions of the plugins are skipped in the `fifo` order.
+func (ssn *Session) resourceBindingInfoOrderFnOf(queue *volcanoapi.QueueInfo) func(l, r interface{}) bool {
+	if queue.Queue != nil && queue.Queue.Annotations[api.DispatchOrderAnnotationKey] == api.DispatchOrderFIFO {
+		return ssn.creationOrderFn
+	}
+	return ssn.ResourceBindingInfoOrderFn
+}
+
+// creationOrderFn orders the ResourceBindingInfos by CreationTimestamp, then by the tie breaker.
+func (ssn *Session) creationOrderFn(l, r interface{}) bool {
 	lv := l.(*api.ResourceBindingInfo)
 	rv := r.(*api.ResourceBindingInfo)
 
 	if lv.ResourceBinding.CreationTimestamp.Equal(&rv.ResourceBinding.CreationTimestamp) {
+		if ssn.tieBreaker != nil {
+			if lk, rk := ssn.tieBreaker.key(lv), ssn.tieBreaker.key(rv); lk != rk {
+				return lk > rk
+			}
+		}
 		return lv.ResourceBinding.UID < rv.ResourceBinding.UID
 	}
 
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	volcanoapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestDispatchBlockers(t *testing.T) {
+	blockedBy := func(reason string) api.DispatchableFn {
+		return func(_ *api.ResourceBindingInfo) *api.DispatchBlocker {
+			return &api.DispatchBlocker{Reason: reason}
+		}
+	}
+
+	testCases := []struct {
+		Name          string
+		pluginNames   []string
+		expectFirst   string
+		expectPlugins []string
+	}{
+		{
+			Name:          "Plugins in the order of the configuration",
+			pluginNames:   []string{"capacity", "quota", "tenant"},
+			expectFirst:   "capacity",
+			expectPlugins: []string{"capacity", "quota", "tenant", "test"},
+		},
+		{
+			Name:          "Plugins in the reversed order of the configuration",
+			pluginNames:   []string{"tenant", "quota", "capacity"},
+			expectFirst:   "tenant",
+			expectPlugins: []string{"tenant", "quota", "capacity", "test"},
+		},
+	}
+
+	for _, tc := range testCases {
+		ssn := &Session{pluginNames: tc.pluginNames, dispatchableFns: map[string]api.DispatchableFn{}}
+		// The function added out of the configuration is called after the configured plugins.
+		ssn.AddDispatchableFn("test", blockedBy("Test"))
+		for _, name := range []string{"quota", "capacity", "tenant"} {
+			ssn.AddDispatchableFn(name, blockedBy(name))
+		}
+
+		rbi := &api.ResourceBindingInfo{}
+		if blocker := ssn.Dispatchable(rbi); blocker == nil || blocker.Plugin != tc.expectFirst {
+			t.Errorf("Test case %s failed, got: %v expect plugin: %s", tc.Name, blocker, tc.expectFirst)
+		}
+		var plugins []string
+		for _, blocker := range ssn.DispatchBlockers(rbi) {
+			plugins = append(plugins, blocker.Plugin)
+		}
+		if !reflect.DeepEqual(plugins, tc.expectPlugins) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, plugins, tc.expectPlugins)
+		}
+	}
+}
+
+func TestResourceBindingInfoOrderFnOf(t *testing.T) {
+	newResourceBindingInfo := func(name string, priority int32, created time.Time) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Name: name, UID: types.UID(name), CreationTimestamp: metav1.NewTime(created)}},
+			Priority: priority,
+		}
+	}
+	now := time.Now()
+	older := newResourceBindingInfo("older", 1, now.Add(-time.Minute))
+	higher := newResourceBindingInfo("higher", 10, now)
+
+	testCases := []struct {
+		Name        string
+		annotations map[string]string
+		expectFirst string
+	}{
+		{
+			Name:        "Ordered by the plugins by default",
+			expectFirst: "higher",
+		},
+		{
+			Name:        "Ordered by the creation time in the fifo order",
+			annotations: map[string]string{api.DispatchOrderAnnotationKey: api.DispatchOrderFIFO},
+			expectFirst: "older",
+		},
+	}
+
+	for _, tc := range testCases {
+		ssn := &Session{
+			pluginNames: []string{"priority"},
+			resourceBindingInfoOrderFns: map[string]volcanoapi.CompareFn{"priority": func(l, r interface{}) int {
+				return int(r.(*api.ResourceBindingInfo).Priority - l.(*api.ResourceBindingInfo).Priority)
+			}},
+		}
+		queue := &volcanoapi.QueueInfo{Name: "q", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q", Annotations: tc.annotations}}}
+		first := older
+		if !ssn.resourceBindingInfoOrderFnOf(queue)(older, higher) {
+			first = higher
+		}
+		if first.ResourceBinding.Name != tc.expectFirst {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, first.ResourceBinding.Name, tc.expectFirst)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+)
+
+const (
+	// TieBreakerUID orders the ResourceBindings with the same priority and age by their UIDs, it's the default.
+	TieBreakerUID = "uid"
+	// TieBreakerWeightedRandom orders them randomly, weighted by their namespaces, so the releases are spread
+	// between the namespaces fairly instead of favoring the ones whose UIDs sort first.
+	TieBreakerWeightedRandom = "weightedRandom"
+)
+
+// weightedRandomTieBreaker draws a key of each ResourceBinding by the weighted random sampling of Efraimidis and
+// Spirakis, the one with the larger key goes first, so a namespace with the double weight wins the ties twice
+// as often. The random numbers are hashed from the seed, the cycle and the UID, so the order is reproducible
+// in a round and changes between the rounds.
+type weightedRandomTieBreaker struct {
+	seed             int64
+	cycle            uint64
+	namespaceWeights map[string]float64
+}
+
+func (tb *weightedRandomTieBreaker) key(rbi *api.ResourceBindingInfo) float64 {
+	hash := fnv.New64a()
+	var buf [16]byte
+	binary.LittleEndian.PutUint64(buf[:8], uint64(tb.seed))
+	binary.LittleEndian.PutUint64(buf[8:], tb.cycle)
+	hash.Write(buf[:])
+	hash.Write([]byte(rbi.ResourceBinding.UID))
+	// The uniform random number in (0, 1) by the top 53 bits of the mixed hash.
+	u := (float64(mix(hash.Sum64())>>11) + 0.5) / (1 << 53)
+
+	weight, found := tb.namespaceWeights[rbi.ResourceBinding.Namespace]
+	if !found || weight <= 0 {
+		weight = 1
+	}
+	return math.Log(u) / weight
+}
+
+// mix is the finalizer of splitmix64, the high bits of the FNV hashes of the similar UIDs are correlated without it.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// SetTieBreaker sets how the session orders the ResourceBindings with the same priority and age in the cycle,
+// they're ordered by the UIDs unless the weighted random one is configured.
+func (ssn *Session) SetTieBreaker(configuration conf.TieBreakerConfiguration, cycle uint64) {
+	if configuration.Policy != TieBreakerWeightedRandom {
+		ssn.tieBreaker = nil
+		return
+	}
+	ssn.tieBreaker = &weightedRandomTieBreaker{
+		seed:             configuration.Seed,
+		cycle:            cycle,
+		namespaceWeights: configuration.NamespaceWeights,
+	}
+}
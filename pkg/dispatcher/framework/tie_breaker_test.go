@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+)
+
+func TestTieBreaker(t *testing.T) {
+	newRBI := func(namespace string, uid types.UID) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{ResourceBinding: &workv1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "rb", UID: uid}}}
+	}
+	// ns2 loses every tie to ns1 by the UIDs.
+	l, r := newRBI("ns1", "a"), newRBI("ns2", "b")
+
+	testCases := []struct {
+		Name          string
+		configuration conf.TieBreakerConfiguration
+		expectMin     float64
+		expectMax     float64
+	}{
+		{
+			Name:      "Ordered by the UIDs",
+			expectMin: 1,
+			expectMax: 1,
+		},
+		{
+			Name:          "Weighted random with the same weights",
+			configuration: conf.TieBreakerConfiguration{Policy: TieBreakerWeightedRandom, Seed: 1},
+			expectMin:     0.45,
+			expectMax:     0.55,
+		},
+		{
+			Name: "Weighted random with the triple weight",
+			configuration: conf.TieBreakerConfiguration{Policy: TieBreakerWeightedRandom, Seed: 1,
+				NamespaceWeights: map[string]float64{"ns1": 3}},
+			expectMin: 0.7,
+			expectMax: 0.8,
+		},
+	}
+
+	const cycles = 2000
+	for _, tc := range testCases {
+		ssn := &Session{}
+		wins := 0
+		for cycle := uint64(1); cycle <= cycles; cycle++ {
+			ssn.SetTieBreaker(tc.configuration, cycle)
+			first := ssn.ResourceBindingInfoOrderFn(l, r)
+			if first == ssn.ResourceBindingInfoOrderFn(r, l) {
+				t.Errorf("Test case %s failed, the order of cycle %d is not consistent", tc.Name, cycle)
+			}
+			if first != ssn.ResourceBindingInfoOrderFn(l, r) {
+				t.Errorf("Test case %s failed, the order of cycle %d is not reproducible", tc.Name, cycle)
+			}
+			if first {
+				wins++
+			}
+		}
+		if ratio := float64(wins) / cycles; ratio < tc.expectMin || ratio > tc.expectMax {
+			t.Errorf("Test case %s failed, got ratio of ns1 first: %v expect: [%v, %v]", tc.Name, ratio, tc.expectMin, tc.expectMax)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/audit"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// manualOperations records the ResourceBindings held and released manually by the endpoints. The holds are persisted
+// by the DispatchHoldAnnotationKey annotation as well, they are kept in memory too, so the ResourceBinding is held
+// before the annotation is observed. The releases are served in the next round.
+type manualOperations struct {
+	mutex sync.Mutex
+	// holds[key] is the user who holds the ResourceBinding.
+	holds map[types.NamespacedName]string
+	// releases[key] is the user who releases the ResourceBinding by force.
+	releases map[types.NamespacedName]string
+}
+
+func (mo *manualOperations) hold(key types.NamespacedName, user string) {
+	mo.mutex.Lock()
+	defer mo.mutex.Unlock()
+
+	if mo.holds == nil {
+		mo.holds = map[types.NamespacedName]string{}
+	}
+	mo.holds[key] = user
+}
+
+func (mo *manualOperations) unhold(key types.NamespacedName) {
+	mo.mutex.Lock()
+	defer mo.mutex.Unlock()
+
+	delete(mo.holds, key)
+}
+
+func (mo *manualOperations) release(key types.NamespacedName, user string) {
+	mo.mutex.Lock()
+	defer mo.mutex.Unlock()
+
+	delete(mo.holds, key)
+	if mo.releases == nil {
+		mo.releases = map[types.NamespacedName]string{}
+	}
+	mo.releases[key] = user
+}
+
+// takeReleases returns the ResourceBindings released by force since the last round and forgets them.
+func (mo *manualOperations) takeReleases() map[types.NamespacedName]string {
+	mo.mutex.Lock()
+	defer mo.mutex.Unlock()
+
+	releases := mo.releases
+	mo.releases = nil
+	return releases
+}
+
+// heldBy returns the user who holds the ResourceBindingInfo, by the endpoint or the annotation.
+func (mo *manualOperations) heldBy(rbi *api.ResourceBindingInfo) (string, bool) {
+	key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+	mo.mutex.Lock()
+	user, found := mo.holds[key]
+	mo.mutex.Unlock()
+	if found {
+		return user, true
+	}
+	user = rbi.ResourceBinding.Annotations[api.DispatchHoldAnnotationKey]
+	return user, user != ""
+}
+
+// forceRelease releases the ResourceBindings released by force since the last round, bypassing the plugins,
+// the ones which are dispatched already are skipped.
+func (dispatcher *Dispatcher) forceRelease(cp *controlPlane, ssn *dispatcherframework.Session) {
+	releases := cp.manual.takeReleases()
+	if len(releases) == 0 {
+		return
+	}
+
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+		user, found := releases[key]
+		if !found {
+			continue
+		}
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		if rbi.DispatchStatus.IsDispatched() {
+			cp.logger().Info(3, queueName, key, "ResourceBinding released by force is dispatched already", "user", user)
+			dispatcher.auditLogger.Log(&audit.Event{
+				Time:         time.Now(),
+				ControlPlane: cp.name,
+				Action:       audit.ActionRelease,
+				Namespace:    key.Namespace,
+				Name:         key.Name,
+				Queue:        queueName,
+				Reason:       api.ForceReleasedReason,
+				Message:      fmt.Sprintf("The ResourceBinding released by %s by force is dispatched already.", user),
+				User:         user,
+				Cycle:        cp.cycle,
+			})
+			continue
+		}
+
+		message := fmt.Sprintf("The ResourceBinding is released by %s by force.", user)
+		cp.logger().Info(3, queueName, key, "ResourceBinding is released by force", "user", user)
+		ssn.Dispatch(rbi)
+		rbi.SetDispatchStatus(api.Admitted, time.Now())
+		cp.cache.UnSuspendResourceBinding(key)
+		cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+			Type:    api.DispatchedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  api.ForceReleasedReason,
+			Message: message,
+		})
+		dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name, Queue: queueName,
+			Dispatched: true, Reason: api.ForceReleasedReason, Message: message, User: user}, rbi, ssn.Snapshot.QueueInfos[queueName], nil)
+	}
+}
+
+// holdHandler holds the ResourceBinding by `POST /dispatcher/hold?namespace=<ns>&name=<name>&controlPlane=<name>`,
+// it's never released until the hold is removed by the `DELETE` method. The control plane is optional.
+func (dispatcher *Dispatcher) holdHandler(w http.ResponseWriter, r *http.Request) {
+	var operation string
+	switch r.Method {
+	case http.MethodPost:
+		operation = api.OperationHold
+	case http.MethodDelete:
+		operation = api.OperationUnhold
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	dispatcher.serveManualOperation(w, r, operation)
+}
+
+// releaseHandler releases the ResourceBinding by force in the next round by
+// `POST /dispatcher/release?namespace=<ns>&name=<name>&controlPlane=<name>`, bypassing the plugins and its hold.
+func (dispatcher *Dispatcher) releaseHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	dispatcher.serveManualOperation(w, r, api.OperationRelease)
+}
+
+func (dispatcher *Dispatcher) serveManualOperation(w http.ResponseWriter, r *http.Request, operation string) {
+	key := types.NamespacedName{Namespace: r.URL.Query().Get("namespace"), Name: r.URL.Query().Get("name")}
+	if key.Namespace == "" || key.Name == "" {
+		http.Error(w, "namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	cp, found := dispatcher.controlPlaneOf(r)
+	if !found {
+		http.Error(w, "control plane not found", http.StatusNotFound)
+		return
+	}
+	queueName, status, found := cp.cache.LookupResourceBinding(key)
+	if !found {
+		http.Error(w, "the ResourceBinding is not managed by the dispatcher", http.StatusNotFound)
+		return
+	}
+	if !dispatcher.ownsQueue(queueName) {
+		http.Error(w, "the queue is dispatched by another replica of the shard group", http.StatusConflict)
+		return
+	}
+
+	result := &api.ManualOperation{Namespace: key.Namespace, Name: key.Name, Operation: operation, User: requestUser(r)}
+	action := audit.ActionHold
+	switch operation {
+	case api.OperationHold:
+		cp.manual.hold(key, result.User)
+		cp.cache.AnnotateResourceBinding(key, api.DispatchHoldAnnotationKey, result.User)
+		result.Message = "The ResourceBinding is held, it will not be dispatched until the hold is removed."
+		if status.IsDispatched() {
+			result.Message = "The ResourceBinding is dispatched already, the hold takes effect after it's suspended again."
+		}
+	case api.OperationUnhold:
+		cp.manual.unhold(key)
+		cp.cache.AnnotateResourceBinding(key, api.DispatchHoldAnnotationKey, "")
+		action = audit.ActionUnhold
+		result.Message = "The hold of the ResourceBinding is removed."
+	case api.OperationRelease:
+		cp.manual.release(key, result.User)
+		cp.cache.AnnotateResourceBinding(key, api.DispatchHoldAnnotationKey, "")
+		cp.cache.AnnotateResourceBinding(key, api.ExpiredAnnotationKey, "")
+		result.Message = "The ResourceBinding will be released in the next round."
+		if status.IsDispatched() {
+			result.Message = "The ResourceBinding is dispatched already."
+		}
+	}
+	klog.Infof("%s ResourceBinding <%s/%s> by %s manually.", operation, key.Namespace, key.Name, result.User)
+
+	// The release is audited when it's served in the round, even if the ResourceBinding is dispatched already.
+	if operation != api.OperationRelease {
+		dispatcher.auditLogger.Log(&audit.Event{
+			Time:         time.Now(),
+			ControlPlane: cp.name,
+			Action:       action,
+			Namespace:    key.Namespace,
+			Name:         key.Name,
+			Queue:        queueName,
+			Reason:       api.DispatchHeldReason,
+			Message:      result.Message,
+			User:         result.User,
+		})
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func TestManualOperations(t *testing.T) {
+	newRBI := func(name string, status api.DispatchStatus, annotations map[string]string) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns",
+				Name:        name,
+				UID:         types.UID(name),
+				Annotations: annotations,
+			}},
+			DispatchStatus: status,
+		}
+	}
+	snapshot := &cache.DispatcherCacheSnapshot{
+		DefaultQueue: "default",
+		QueueInfos: map[string]*schedulingapi.QueueInfo{
+			"default": {UID: "default", Name: "default"},
+		},
+		ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+	}
+	for _, rbi := range []*api.ResourceBindingInfo{
+		newRBI("rb1", api.Pending, nil),
+		newRBI("rb2", api.Pending, map[string]string{api.DispatchHoldAnnotationKey: "admin"}),
+		newRBI("rb3", api.Pending, map[string]string{api.DispatchHoldAnnotationKey: ""}),
+	} {
+		snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+	}
+	fc := &fakeCache{snapshot: snapshot}
+	cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10)}
+	dispatcher := &Dispatcher{controlPlanes: []*controlPlane{cp}}
+
+	testCases := []struct {
+		Name         string
+		handler      http.HandlerFunc
+		method       string
+		url          string
+		expectCode   int
+		expectHeld   map[string]bool
+		expectForced []string
+	}{
+		{
+			Name:       "Hold the ResourceBinding",
+			handler:    dispatcher.holdHandler,
+			method:     http.MethodPost,
+			url:        "/dispatcher/hold?namespace=ns&name=rb1",
+			expectCode: http.StatusOK,
+			expectHeld: map[string]bool{"rb1": true, "rb2": true},
+		},
+		{
+			Name:       "Hold the ResourceBinding not managed",
+			handler:    dispatcher.holdHandler,
+			method:     http.MethodPost,
+			url:        "/dispatcher/hold?namespace=ns&name=not-found",
+			expectCode: http.StatusNotFound,
+			expectHeld: map[string]bool{"rb1": true, "rb2": true},
+		},
+		{
+			Name:       "Hold without the name",
+			handler:    dispatcher.holdHandler,
+			method:     http.MethodPost,
+			url:        "/dispatcher/hold?namespace=ns",
+			expectCode: http.StatusBadRequest,
+			expectHeld: map[string]bool{"rb1": true, "rb2": true},
+		},
+		{
+			Name:       "Hold with invalid method",
+			handler:    dispatcher.holdHandler,
+			method:     http.MethodGet,
+			url:        "/dispatcher/hold?namespace=ns&name=rb3",
+			expectCode: http.StatusMethodNotAllowed,
+			expectHeld: map[string]bool{"rb1": true, "rb2": true},
+		},
+		{
+			Name:       "Unhold the ResourceBinding",
+			handler:    dispatcher.holdHandler,
+			method:     http.MethodDelete,
+			url:        "/dispatcher/hold?namespace=ns&name=rb1",
+			expectCode: http.StatusOK,
+			expectHeld: map[string]bool{"rb2": true},
+		},
+		{
+			Name:         "Release the ResourceBinding held by the annotation",
+			handler:      dispatcher.releaseHandler,
+			method:       http.MethodPost,
+			url:          "/dispatcher/release?namespace=ns&name=rb2",
+			expectCode:   http.StatusOK,
+			expectHeld:   map[string]bool{"rb2": true},
+			expectForced: []string{"rb2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		recorder := httptest.NewRecorder()
+		tc.handler(recorder, httptest.NewRequest(tc.method, tc.url, nil))
+		if recorder.Code != tc.expectCode {
+			t.Errorf("Test case %s failed, got code: %d expect: %d", tc.Name, recorder.Code, tc.expectCode)
+		}
+		for _, rbi := range snapshot.ResourceBindingInfos {
+			if _, held := cp.manual.heldBy(rbi); held != tc.expectHeld[rbi.ResourceBinding.Name] {
+				t.Errorf("Test case %s failed, got held of %s: %v expect: %v", tc.Name,
+					rbi.ResourceBinding.Name, held, tc.expectHeld[rbi.ResourceBinding.Name])
+			}
+		}
+
+		// The released ResourceBindings are dispatched in the round even if they are held by the annotation,
+		// which is removed asynchronously.
+		fc.unsuspended = nil
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		dispatcher.forceRelease(cp, ssn)
+		ssn.CloseSession()
+		if len(fc.unsuspended) != len(tc.expectForced) {
+			t.Errorf("Test case %s failed, got released: %v expect: %v", tc.Name, fc.unsuspended, tc.expectForced)
+			continue
+		}
+		for i, key := range fc.unsuspended {
+			if key.Name != tc.expectForced[i] {
+				t.Errorf("Test case %s failed, got released: %v expect: %v", tc.Name, fc.unsuspended, tc.expectForced)
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// decisionLogger writes the structured decision logs of a dispatching round, each line is tagged with the control
+// plane, the cycle and the queue, so the logs of one queue or ResourceBinding can be filtered out easily.
+type decisionLogger struct {
+	controlPlane string
+	cycle        uint64
+	// queueVerbosity[queue] is the verbosity of the queue, the `-v` flag is used when it's lower.
+	queueVerbosity map[string]int
+}
+
+// logger returns the decision logger of the current round of the control plane.
+func (cp *controlPlane) logger() decisionLogger {
+	return decisionLogger{controlPlane: cp.name, cycle: cp.cycle, queueVerbosity: cp.queueLogVerbosity}
+}
+
+// v returns the verbose logger of the queue, it's enabled when the level is within the `-v` flag or the verbosity
+// of the queue.
+func (l decisionLogger) v(queue string, level klog.Level) klog.Verbose {
+	if verbosity, found := l.queueVerbosity[queue]; found && klog.Level(verbosity) >= level {
+		return klog.V(0)
+	}
+	return klog.V(level)
+}
+
+// QueueInfo logs the message of the queue at the level, with the extra key-value pairs.
+func (l decisionLogger) QueueInfo(level klog.Level, queue, msg string, keysAndValues ...interface{}) {
+	v := l.v(queue, level)
+	if !v.Enabled() {
+		return
+	}
+	v.InfoSDepth(1, msg, append([]interface{}{"controlPlane", l.controlPlane, "cycle", l.cycle, "queue", queue}, keysAndValues...)...)
+}
+
+// Info logs the message of the ResourceBinding in the queue at the level, with the extra key-value pairs.
+func (l decisionLogger) Info(level klog.Level, queue string, key types.NamespacedName, msg string, keysAndValues ...interface{}) {
+	v := l.v(queue, level)
+	if !v.Enabled() {
+		return
+	}
+	v.InfoSDepth(1, msg, append([]interface{}{"controlPlane", l.controlPlane, "cycle", l.cycle, "queue", queue,
+		"resourceBinding", klog.KRef(key.Namespace, key.Name)}, keysAndValues...)...)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+func TestDecisionLoggerVerbosity(t *testing.T) {
+	logger := decisionLogger{controlPlane: "default", cycle: 1, queueVerbosity: map[string]int{"debug": 4}}
+	testCases := []struct {
+		Name          string
+		queue         string
+		level         klog.Level
+		expectEnabled bool
+	}{
+		{Name: "Within the verbosity of the queue", queue: "debug", level: 4, expectEnabled: true},
+		{Name: "Beyond the verbosity of the queue", queue: "debug", level: 5, expectEnabled: false},
+		{Name: "Queue without verbosity follows the flag", queue: "other", level: 4, expectEnabled: false},
+		{Name: "Default level is always enabled", queue: "other", level: 0, expectEnabled: true},
+	}
+
+	for _, tc := range testCases {
+		if got := logger.v(tc.queue, tc.level).Enabled(); got != tc.expectEnabled {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expectEnabled)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// VolcanoGlobalNamespace - namespace in prometheus used by volcano-global
+	VolcanoGlobalNamespace = "volcano_global"
+)
+
+var (
+	queueWaitDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "queue_wait_duration_seconds",
+			Help:      "The duration between the ResourceBinding enqueued and unsuspended by the dispatcher in seconds",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{"control_plane", "queue", "priority_class"},
+	)
+
+	dispatchedResourceBindings = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "dispatched_resource_bindings_total",
+			Help:      "The number of ResourceBindings unsuspended by the dispatcher",
+		},
+		[]string{"control_plane", "queue", "priority_class"},
+	)
+
+	priorityOverriddenResourceBindings = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "priority_overridden_resource_bindings_total",
+			Help:      "The number of ResourceBindings unsuspended by the dispatcher with the priority overridden by the annotation",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	reclaimedResourceBindings = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "reclaimed_resource_bindings_total",
+			Help:      "The number of ResourceBindings suspended again by the dispatcher to reclaim the resources of their queues",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	preemptedResourceBindings = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "preempted_resource_bindings_total",
+			Help:      "The number of ResourceBindings suspended again by the dispatcher for the higher priority ones of their queues",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	deadlineMissedResourceBindings = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "deadline_missed_resource_bindings_total",
+			Help:      "The number of ResourceBindings dispatched after the deadline of their workloads",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	resourceBindings = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "resource_bindings",
+			Help:      "The number of ResourceBindings in each dispatch status when the dispatching round starts",
+		},
+		[]string{"control_plane", "queue", "status"},
+	)
+
+	queueMismatchedResourceBindings = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "queue_mismatched_resource_bindings",
+			Help:      "The number of ResourceBindings whose queue annotation doesn't match the queue of the PodGroup of their workloads",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	cacheInconsistencies = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "cache_inconsistencies",
+			Help:      "The number of objects different between the dispatcher cache and the apiserver in the last consistency check",
+		},
+		[]string{"control_plane", "kind", "type"},
+	)
+
+	unadmittedResourceBindings = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "unadmitted_resource_bindings",
+			Help:      "The number of ResourceBindings created unsuspended and running without the admission of their queues",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	cacheWorkQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "cache_work_queue_depth",
+			Help:      "The number of the items waiting in the work queues of the dispatcher cache",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	cacheGarbageCollected = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "cache_garbage_collected_total",
+			Help:      "The number of the stale objects removed from the dispatcher cache by the garbage collection",
+		},
+		[]string{"control_plane", "kind"},
+	)
+
+	cacheEventErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "cache_event_errors_total",
+			Help:      "The number of the informer events failed to be converted or processed by the dispatcher cache",
+		},
+		[]string{"control_plane", "kind"},
+	)
+
+	cacheResyncs = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "cache_resyncs_total",
+			Help:      "The number of the resyncs of the dispatcher cache triggered by the event errors exceeding the budget",
+		},
+		[]string{"control_plane", "kind"},
+	)
+
+	cacheDriftSuspected = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "cache_drift_suspected",
+			Help:      "Whether the event errors of the kind exceeded the budget in the last check, 1 means the cache may have drifted from the informer",
+		},
+		[]string{"control_plane", "kind"},
+	)
+
+	minResourcesDisagreements = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "min_resources_disagreements",
+			Help:      "The number of the workloads whose PodGroups set the min resources different from the ones resolved from the workloads in the last round",
+		},
+		[]string{"control_plane"},
+	)
+
+	coalescedStatusUpdates = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "coalesced_status_updates_total",
+			Help:      "The number of the condition and annotation updates of the ResourceBindings merged into the other ones in the same window without their own requests",
+		},
+		[]string{"control_plane"},
+	)
+
+	equivalenceCacheHits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "equivalence_cache_hits_total",
+			Help:      "The number of the ResourceBindings blocked by the cached blockers without evaluating the plugins again",
+		},
+		[]string{"control_plane"},
+	)
+
+	queueBacklog = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "queue_backlog_resource_bindings",
+			Help:      "The number of ResourceBindings waiting in the queue when the dispatching round starts",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	queueHeadOfLineAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "queue_head_of_line_age_seconds",
+			Help:      "How long the oldest ResourceBinding waiting in the queue has waited in seconds, 0 when nothing waits",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	queueBacklogResources = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "queue_backlog_resources",
+			Help:      "The total resources requested by the ResourceBindings waiting in the queue, the cpu is in cores",
+		},
+		[]string{"control_plane", "queue", "resource"},
+	)
+
+	queueDispatchPaused = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "queue_dispatch_paused",
+			Help:      "Whether the dispatching of the queue is paused, 1 means paused",
+		},
+		[]string{"control_plane", "queue"},
+	)
+
+	controlPlaneDispatchCycle = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "control_plane_dispatch_cycle",
+			Help:      "The cycle of the last dispatching round of the Karmada control plane, it's in the logs, the events, the decisions and the audit events of the round",
+		},
+		[]string{"control_plane"},
+	)
+
+	controlPlaneLastDispatchTime = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "control_plane_last_dispatch_timestamp_seconds",
+			Help:      "The unix timestamp when the last dispatching round of the Karmada control plane finished",
+		},
+		[]string{"control_plane"},
+	)
+
+	shardMembers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: VolcanoGlobalNamespace,
+			Name:      "dispatcher_shard_members",
+			Help:      "The number of the dispatcher replicas sharing the queues in the shard group",
+		},
+		[]string{"group"},
+	)
+)
+
+// UpdateQueueWaitDuration records the queue wait duration of a dispatched ResourceBinding.
+func UpdateQueueWaitDuration(controlPlane, queueName, priorityClassName string, duration time.Duration) {
+	queueWaitDuration.WithLabelValues(controlPlane, queueName, priorityClassName).Observe(duration.Seconds())
+	dispatchedResourceBindings.WithLabelValues(controlPlane, queueName, priorityClassName).Inc()
+}
+
+// UpdatePriorityOverriddenResourceBindings records a ResourceBinding of the queue with the priority overridden by
+// the annotation is unsuspended.
+func UpdatePriorityOverriddenResourceBindings(controlPlane, queueName string) {
+	priorityOverriddenResourceBindings.WithLabelValues(controlPlane, queueName).Inc()
+}
+
+// UpdateReclaimedResourceBindings records a ResourceBinding of the queue is suspended again to reclaim its resources.
+func UpdateReclaimedResourceBindings(controlPlane, queueName string) {
+	reclaimedResourceBindings.WithLabelValues(controlPlane, queueName).Inc()
+}
+
+// UpdatePreemptedResourceBindings records a ResourceBinding of the queue is suspended again for a higher priority one.
+func UpdatePreemptedResourceBindings(controlPlane, queueName string) {
+	preemptedResourceBindings.WithLabelValues(controlPlane, queueName).Inc()
+}
+
+// UpdateDeadlineMissedResourceBindings records a ResourceBinding of the queue is dispatched after its deadline.
+func UpdateDeadlineMissedResourceBindings(controlPlane, queueName string) {
+	deadlineMissedResourceBindings.WithLabelValues(controlPlane, queueName).Inc()
+}
+
+// UpdateResourceBindings records the number of the ResourceBindings by the queue and the dispatch status,
+// counts[queue][status] is the number, the queues and statuses not in it are removed.
+func UpdateResourceBindings(controlPlane string, counts map[string]map[string]int) {
+	resourceBindings.DeletePartialMatch(prometheus.Labels{"control_plane": controlPlane})
+	for queueName, statuses := range counts {
+		for status, count := range statuses {
+			resourceBindings.WithLabelValues(controlPlane, queueName, status).Set(float64(count))
+		}
+	}
+}
+
+// UpdateQueueMismatchedResourceBindings records the number of the ResourceBindings whose queue annotation doesn't
+// match the queue of their PodGroups by the queue of the PodGroups, the queues not in it are removed.
+func UpdateQueueMismatchedResourceBindings(controlPlane string, counts map[string]int) {
+	queueMismatchedResourceBindings.DeletePartialMatch(prometheus.Labels{"control_plane": controlPlane})
+	for queueName, count := range counts {
+		queueMismatchedResourceBindings.WithLabelValues(controlPlane, queueName).Set(float64(count))
+	}
+}
+
+// UpdateUnadmittedResourceBindings records the number of the ResourceBindings created unsuspended and never admitted
+// by their queues, the queues not in it are removed.
+func UpdateUnadmittedResourceBindings(controlPlane string, counts map[string]int) {
+	unadmittedResourceBindings.DeletePartialMatch(prometheus.Labels{"control_plane": controlPlane})
+	for queueName, count := range counts {
+		unadmittedResourceBindings.WithLabelValues(controlPlane, queueName).Set(float64(count))
+	}
+}
+
+// QueueBacklog is the ResourceBindings waiting in a queue.
+type QueueBacklog struct {
+	// Count is the number of the waiting ResourceBindings.
+	Count int
+	// HeadOfLineAge is how long the oldest one has waited.
+	HeadOfLineAge time.Duration
+	// Resources is the total resources requested by them.
+	Resources corev1.ResourceList
+}
+
+// UpdateQueueBacklogs records the backlogs by the queue, the queues and the resources not in it are removed.
+func UpdateQueueBacklogs(controlPlane string, backlogs map[string]*QueueBacklog) {
+	queueBacklog.DeletePartialMatch(prometheus.Labels{"control_plane": controlPlane})
+	queueHeadOfLineAge.DeletePartialMatch(prometheus.Labels{"control_plane": controlPlane})
+	queueBacklogResources.DeletePartialMatch(prometheus.Labels{"control_plane": controlPlane})
+	for queueName, backlog := range backlogs {
+		queueBacklog.WithLabelValues(controlPlane, queueName).Set(float64(backlog.Count))
+		queueHeadOfLineAge.WithLabelValues(controlPlane, queueName).Set(backlog.HeadOfLineAge.Seconds())
+		for name, quantity := range backlog.Resources {
+			queueBacklogResources.WithLabelValues(controlPlane, queueName, string(name)).Set(quantity.AsApproximateFloat64())
+		}
+	}
+}
+
+// UpdateCacheInconsistencies records the numbers of the missing, stale and outdated objects of the kind in the cache.
+func UpdateCacheInconsistencies(controlPlane, kind string, missing, stale, outdated int) {
+	cacheInconsistencies.WithLabelValues(controlPlane, kind, "missing").Set(float64(missing))
+	cacheInconsistencies.WithLabelValues(controlPlane, kind, "stale").Set(float64(stale))
+	cacheInconsistencies.WithLabelValues(controlPlane, kind, "outdated").Set(float64(outdated))
+}
+
+// UpdateCacheWorkQueueDepths records the number of the items waiting in each work queue of the dispatcher cache.
+func UpdateCacheWorkQueueDepths(controlPlane string, depths map[string]int) {
+	for queue, depth := range depths {
+		cacheWorkQueueDepth.WithLabelValues(controlPlane, queue).Set(float64(depth))
+	}
+}
+
+// UpdateCacheGarbageCollected records the number of the stale objects of the kind removed from the cache.
+func UpdateCacheGarbageCollected(controlPlane, kind string, count int) {
+	cacheGarbageCollected.WithLabelValues(controlPlane, kind).Add(float64(count))
+}
+
+// UpdateCacheEventErrors records the number of the failed events of the kind, and whether they exceeded the budget.
+func UpdateCacheEventErrors(controlPlane, kind string, count int, exceeded bool) {
+	cacheEventErrors.WithLabelValues(controlPlane, kind).Add(float64(count))
+	value := 0.0
+	if exceeded {
+		value = 1
+	}
+	cacheDriftSuspected.WithLabelValues(controlPlane, kind).Set(value)
+}
+
+// UpdateCacheResyncs records a resync of the kind in the cache.
+func UpdateCacheResyncs(controlPlane, kind string) {
+	cacheResyncs.WithLabelValues(controlPlane, kind).Inc()
+}
+
+// UpdateMinResourcesDisagreements records the number of the workloads whose min resources disagree between
+// their PodGroups and the workloads.
+func UpdateMinResourcesDisagreements(controlPlane string, count int) {
+	minResourcesDisagreements.WithLabelValues(controlPlane).Set(float64(count))
+}
+
+// UpdateCoalescedStatusUpdates records the number of the status updates coalesced in the last round.
+func UpdateCoalescedStatusUpdates(controlPlane string, count int) {
+	coalescedStatusUpdates.WithLabelValues(controlPlane).Add(float64(count))
+}
+
+// UpdateEquivalenceCacheHits records a ResourceBinding is blocked by its cached blocker.
+func UpdateEquivalenceCacheHits(controlPlane string) {
+	equivalenceCacheHits.WithLabelValues(controlPlane).Inc()
+}
+
+// UpdateQueueDispatchPaused records whether the dispatching of the queue is paused.
+func UpdateQueueDispatchPaused(controlPlane, queueName string, paused bool) {
+	value := 0.0
+	if paused {
+		value = 1
+	}
+	queueDispatchPaused.WithLabelValues(controlPlane, queueName).Set(value)
+}
+
+// UpdateControlPlaneLastDispatchTime records the finish time of the last dispatching round of the control plane.
+func UpdateControlPlaneLastDispatchTime(controlPlane string, now time.Time) {
+	controlPlaneLastDispatchTime.WithLabelValues(controlPlane).Set(float64(now.Unix()))
+}
+
+// UpdateControlPlaneDispatchCycle records the cycle of the last dispatching round of the control plane.
+func UpdateControlPlaneDispatchCycle(controlPlane string, cycle uint64) {
+	controlPlaneDispatchCycle.WithLabelValues(controlPlane).Set(float64(cycle))
+}
+
+// UpdateShardMembers records the number of the dispatcher replicas in the shard group.
+func UpdateShardMembers(group string, members int) {
+	shardMembers.WithLabelValues(group).Set(float64(members))
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	"volcano.sh/volcano-global/pkg/dispatcher/feasibility"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// roundOperations implements the operations called by the actions in a round of the control plane.
+type roundOperations struct {
+	dispatcher    *Dispatcher
+	cp            *controlPlane
+	ssn           *dispatcherframework.Session
+	configuration *conf.DispatcherConfiguration
+	rateLimiter   flowcontrol.RateLimiter
+	globalPaused  bool
+
+	// enqueued is true after the suspended ResourceBindings are prepared for the round.
+	enqueued bool
+	// queueAllocated records the resources of the dispatched ResourceBindings in each queue for the audit log.
+	queueAllocated map[string]corev1.ResourceList
+	// reserved are the ResourceBindingInfos selected in the round by the two-phase dispatching, in order.
+	reserved []reservation
+	// speculative[queue] is the number of the ResourceBindings released toward the elastic cluster of the queue
+	// speculatively but not running yet.
+	speculative map[string]int
+	// fingerprint and equivalenceTTL decide whether the blockers cached in the previous rounds are reused.
+	fingerprint    uint64
+	equivalenceTTL time.Duration
+	// dispatched is the count of the dispatched ResourceBindings for logs.
+	dispatched int
+}
+
+var _ dispatcherframework.Operations = &roundOperations{}
+
+func (dispatcher *Dispatcher) newRoundOperations(cp *controlPlane, ssn *dispatcherframework.Session,
+	configuration *conf.DispatcherConfiguration, rateLimiter flowcontrol.RateLimiter, globalPaused bool) *roundOperations {
+	return &roundOperations{
+		dispatcher:     dispatcher,
+		cp:             cp,
+		ssn:            ssn,
+		configuration:  configuration,
+		rateLimiter:    rateLimiter,
+		globalPaused:   globalPaused,
+		queueAllocated: map[string]corev1.ResourceList{},
+	}
+}
+
+func (ops *roundOperations) OwnsQueue(queue string) bool {
+	return ops.dispatcher.ownsQueue(queue)
+}
+
+func (ops *roundOperations) IsQueuePaused(queue *schedulingapi.QueueInfo) bool {
+	return ops.dispatcher.isQueuePaused(queue, ops.globalPaused)
+}
+
+func (ops *roundOperations) QueuePausedBy(queue *schedulingapi.QueueInfo) string {
+	return pausedBy(queue)
+}
+
+func (ops *roundOperations) HeldBy(rbi *api.ResourceBindingInfo) (string, bool) {
+	return ops.cp.manual.heldBy(rbi)
+}
+
+func (ops *roundOperations) Enqueue() {
+	if ops.enqueued {
+		return
+	}
+	ops.enqueued = true
+
+	cp, ssn, ss := ops.cp, ops.ssn, ops.ssn.Snapshot
+	// The ResourceBindings released manually are released first, bypassing the plugins and the pause.
+	ops.dispatcher.forceRelease(cp, ssn)
+	cp.decisions.retain(ss)
+
+	for queueName, queue := range ss.QueueInfos {
+		metrics.UpdateQueueDispatchPaused(cp.name, queueName, ops.IsQueuePaused(queue))
+	}
+	ops.speculative = speculativeReleases(ssn)
+	// statusCounts[queue][status] is the number of the ResourceBindings in the status for the metrics.
+	statusCounts := map[string]map[string]int{}
+	// mismatches[queue] is the number of the ResourceBindings whose queue annotation doesn't match the queue of
+	// their PodGroups, the queue of the PodGroups is used.
+	mismatches := map[string]int{}
+	for _, rbi := range ss.ResourceBindingInfos {
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		if rbi.DispatchStatus.IsDispatched() {
+			addResources(ops.queueAllocated, queueName, rbi.MinResources)
+		}
+		if statusCounts[queueName] == nil {
+			statusCounts[queueName] = map[string]int{}
+		}
+		statusCounts[queueName][rbi.DispatchStatus.String()]++
+		if err := utils.ValidatePodGroupQueue(rbi.ResourceBinding, rbi.PodGroup); err != nil {
+			mismatches[queueName]++
+			cp.logger().Info(3, queueName, types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace,
+				Name: rbi.ResourceBinding.Name}, "ResourceBinding is dispatched by the queue of its PodGroup", "err", err)
+		}
+	}
+	metrics.UpdateResourceBindings(cp.name, statusCounts)
+	metrics.UpdateQueueMismatchedResourceBindings(cp.name, mismatches)
+	metrics.UpdateQueueBacklogs(cp.name, queueBacklogs(ssn, time.Now()))
+
+	// The blockers evaluated in the previous rounds are reused when the equivalence cache is enabled.
+	ops.equivalenceTTL = time.Duration(ops.configuration.EquivalenceCache.TTLSeconds) * time.Second
+	if ops.equivalenceTTL <= 0 {
+		ops.equivalenceTTL = defaultEquivalenceCacheTTL
+	}
+	if !ops.configuration.EquivalenceCache.Enabled {
+		cp.equivalence = nil
+	} else {
+		if cp.equivalence == nil {
+			cp.equivalence = equivalenceCache{}
+		}
+		cp.equivalence.retain(ssn)
+		ops.fingerprint = snapshotFingerprint(ss)
+	}
+}
+
+func (ops *roundOperations) Dispatchable(queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo) (*api.DispatchBlocker, string) {
+	if blocker := clusterFailureBlocker(ops.ssn, rbi); blocker != nil {
+		return blocker, ""
+	}
+	if blocker := ops.dispatcher.dispatchable(ops.cp, ops.ssn, rbi, ops.fingerprint, ops.equivalenceTTL); blocker != nil {
+		return blocker, ""
+	}
+
+	// Check if the replicas can fit in the target clusters, the queue with an elastic cluster releases the
+	// infeasible ones toward it speculatively instead, so its cluster autoscaler scales up for them.
+	feasible, message := ops.cp.feasibilityChecker.Feasible(context.TODO(), rbi.ResourceBinding,
+		ops.ssn.Snapshot.PlacementClusterNames(rbi.ResourceBinding.Spec.Placement), rbi.ClusterReplicaRequirements)
+	if feasible {
+		return nil, ""
+	}
+	cluster, found := speculativeCluster(ops.ssn.Snapshot, queue, rbi, ops.speculative[queue.Name])
+	if !found {
+		return &api.DispatchBlocker{Reason: feasibility.InfeasibleReason, Message: message}, ""
+	}
+	ops.cp.logger().Info(3, queue.Name, types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name},
+		"ResourceBinding is infeasible, release it toward the elastic cluster", "cluster", cluster, "message", message)
+	return nil, cluster
+}
+
+func (ops *roundOperations) Block(queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo, blocker *api.DispatchBlocker) {
+	key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+	ops.cp.logger().Info(3, queue.Name, key, "ResourceBinding is blocked",
+		"plugin", blocker.Plugin, "reason", blocker.Reason, "message", blocker.Message)
+	ops.cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+		Type:    api.DispatchedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  blocker.Reason,
+		Message: blocker.Message,
+	})
+	ops.dispatcher.recordDecision(ops.cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+		Queue: queue.Name, Plugin: blocker.Plugin, Reason: blocker.Reason, Message: blocker.Message}, rbi, queue, ops.queueAllocated[queue.Name])
+}
+
+func (ops *roundOperations) Hold(queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo, user string) {
+	key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+	ops.cp.logger().Info(3, queue.Name, key, "ResourceBinding is held manually", "user", user)
+	message := fmt.Sprintf("The ResourceBinding is held by %s manually.", user)
+	ops.cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+		Type:    api.DispatchedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  api.DispatchHeldReason,
+		Message: message,
+	})
+	ops.dispatcher.recordDecision(ops.cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+		Queue: queue.Name, Reason: api.DispatchHeldReason, Message: message, User: user}, rbi, queue, ops.queueAllocated[queue.Name])
+}
+
+func (ops *roundOperations) Allocate(queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo, speculativeCluster string) bool {
+	if ops.rateLimiter != nil && !ops.rateLimiter.TryAccept() {
+		ops.cp.logger().QueueInfo(3, queue.Name, "Reach the dispatch rate limit, the rest ResourceBindings will be dispatched in the next round")
+		return false
+	}
+	if speculativeCluster != "" {
+		ops.speculative[queue.Name]++
+	}
+
+	ops.ssn.Dispatch(rbi)
+	// The capacity is reserved in the session, the ResourceBinding is released after the whole batch is verified.
+	if ops.configuration.TwoPhaseDispatch {
+		ops.cp.logger().Info(4, queue.Name, types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name},
+			"ResourceBinding is reserved")
+		ops.reserved = append(ops.reserved, reservation{queue: queue, rbi: rbi, speculativeCluster: speculativeCluster})
+		return true
+	}
+	ops.dispatcher.release(ops.cp, queue, rbi, speculativeCluster, ops.queueAllocated)
+	ops.dispatched++
+	return true
+}
+
+func (ops *roundOperations) Commit() {
+	ops.dispatched += ops.dispatcher.verifyReservations(ops.cp, ops.ssn, ops.reserved, ops.queueAllocated)
+	ops.reserved = nil
+
+	klog.V(2).Infof("Success dispatch <%d> ResourceBindingInfos of control plane <%s>.", ops.dispatched, ops.cp.name)
+	ops.dispatched = 0
+}
+
+// Evict suspends the victim again for the preemptor, the victim keeps its status in the session,
+// so it will not be dispatched again in the same round.
+func (ops *roundOperations) Evict(victim, preemptor *api.ResourceBindingInfo, reason string) {
+	cp, ssn := ops.cp, ops.ssn
+	key := types.NamespacedName{Namespace: victim.ResourceBinding.Namespace, Name: victim.ResourceBinding.Name}
+	victimQueue := ssn.GetResourceBindingInfoQueue(victim)
+	preemptorQueue := ssn.GetResourceBindingInfoQueue(preemptor)
+	message := fmt.Sprintf("The resources borrowed by queue %s are reclaimed by queue %s for ResourceBinding %s/%s.",
+		victimQueue, preemptorQueue, preemptor.ResourceBinding.Namespace, preemptor.ResourceBinding.Name)
+	if reason == api.PreemptedReason {
+		message = fmt.Sprintf("The ResourceBinding is preempted by ResourceBinding %s/%s with the higher priority in queue %s.",
+			preemptor.ResourceBinding.Namespace, preemptor.ResourceBinding.Name, victimQueue)
+	}
+	cp.logger().Info(3, victimQueue, key, "Evict ResourceBinding", "reason", reason, "preemptorQueue", preemptorQueue,
+		"preemptor", klog.KObj(preemptor.ResourceBinding))
+
+	ssn.Evict(victim)
+	cp.cache.SuspendResourceBinding(key)
+	cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+		Type:    api.DispatchedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	if reason == api.PreemptedReason {
+		metrics.UpdatePreemptedResourceBindings(cp.name, victimQueue)
+	} else {
+		metrics.UpdateReclaimedResourceBindings(cp.name, victimQueue)
+	}
+
+	queueInfo, found := ssn.Snapshot.QueueInfos[victimQueue]
+	if !found {
+		queueInfo = &schedulingapi.QueueInfo{Name: victimQueue}
+	}
+	ops.dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+		Queue: victimQueue, Evicted: true, Reason: reason, Message: message}, victim, queueInfo, nil)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/actions/reclaim"
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func TestReclaim(t *testing.T) {
+	cpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+	newQueue := func(name string, deserved corev1.ResourceList) *schedulingapi.QueueInfo {
+		return &schedulingapi.QueueInfo{Name: name, Queue: &scheduling.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       scheduling.QueueSpec{Deserved: deserved},
+		}}
+	}
+	newRBI := func(name, queue string, priority int32, status api.DispatchStatus, minResources corev1.ResourceList) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				UID:       types.UID(name),
+			}},
+			Queue:          queue,
+			Priority:       priority,
+			DispatchStatus: status,
+			PodGroup:       &schedulingv1beta1.PodGroup{},
+			MinResources:   minResources,
+		}
+	}
+
+	testCases := []struct {
+		Name string
+		// borrower is the queue of the dispatched ResourceBindings.
+		borrower        string
+		reclaimer       *api.ResourceBindingInfo
+		expectSuspended []types.NamespacedName
+	}{
+		{
+			Name:            "Reclaim the lowest priority one",
+			borrower:        "q2",
+			reclaimer:       newRBI("reclaimer", "q1", 1, api.Pending, cpu("3")),
+			expectSuspended: []types.NamespacedName{{Namespace: "ns", Name: "low"}},
+		},
+		{
+			Name:      "Higher priority and non-preemptable ones are not enough",
+			borrower:  "q2",
+			reclaimer: newRBI("reclaimer", "q1", 0, api.Pending, cpu("4")),
+		},
+		{
+			Name:            "Reclaim from the queue without the deserved resources",
+			borrower:        "q3",
+			reclaimer:       newRBI("reclaimer", "q1", 1, api.Pending, cpu("3")),
+			expectSuspended: []types.NamespacedName{{Namespace: "ns", Name: "low"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue: "q1",
+			QueueInfos: map[string]*schedulingapi.QueueInfo{
+				"q1": newQueue("q1", cpu("4")),
+				"q2": newQueue("q2", cpu("6")),
+				"q3": newQueue("q3", nil),
+			},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+			Clusters: map[string]*clusterv1alpha1.Cluster{"member": {
+				Status: clusterv1alpha1.ClusterStatus{
+					Conditions:      []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: metav1.ConditionTrue}},
+					ResourceSummary: &clusterv1alpha1.ResourceSummary{Allocatable: cpu("10")},
+				},
+			}},
+		}
+		// The borrower uses all the resources, q2 borrows 4 of them from q1, and q3 borrows all of them.
+		nonPreemptable := newRBI("non-preemptable", tc.borrower, 0, api.Dispatched, cpu("3"))
+		nonPreemptable.ResourceBinding.Annotations = map[string]string{api.PreemptableAnnotationKey: "false"}
+		for _, rbi := range []*api.ResourceBindingInfo{
+			newRBI("high", tc.borrower, 1, api.Dispatched, cpu("4")),
+			newRBI("low", tc.borrower, 0, api.Dispatched, cpu("3")),
+			nonPreemptable,
+			tc.reclaimer,
+		} {
+			snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10)}
+		ssn := dispatcherframework.OpenSession(fc, []conf.PluginOption{{Name: "priority"}, {Name: "capacity"}})
+		ssn.SetOperations(dispatcher.newRoundOperations(cp, ssn, &conf.DispatcherConfiguration{}, nil, false))
+		reclaim.New().Execute(ssn)
+		ssn.CloseSession()
+
+		if !reflect.DeepEqual(fc.suspended, tc.expectSuspended) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, fc.suspended, tc.expectSuspended)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"volcano.sh/volcano/pkg/kube"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/clustercapacity"
+	"volcano.sh/volcano-global/pkg/dispatcher/feasibility"
+)
+
+// options are the command line flags of the dispatcher not kept by the Dispatcher itself.
+type options struct {
+	// kubeClientOptions are the ones of the controller-manager, set by its `--master` and `--kubeconfig` flags.
+	kubeClientOptions *kube.ClientOptions
+	cacheOption       cache.DispatcherCacheOption
+
+	enableSchedulerEstimator bool
+	estimatorOption          feasibility.EstimatorOption
+	enableDebugAuth          bool
+	debugCertFile            string
+	debugKeyFile             string
+	maxDebugDecisions        int
+	auditSinks               string
+	auditWebhookTimeout      time.Duration
+	karmadaKubeConfigs       string
+	unSuspendParallelism     uint
+	statusWriters            uint
+	faultInjection           string
+	capacityProvider         string
+	capacityFile             string
+	shardGroup               string
+	shardIdentity            string
+	shardLeaseNamespace      string
+	shardLeaseDuration       time.Duration
+}
+
+// AddFlags registers the flags of the dispatcher onto the flag set of the controller-manager before it's parsed.
+// The dispatcher is actually a controller, but it also has the flags similar to the volcano-scheduler, and the
+// controller-manager rejects the flags it doesn't know. The dispatcher connects to the apiserver by the kube
+// client options of the controller-manager.
+func AddFlags(fs *pflag.FlagSet, kubeClientOptions *kube.ClientOptions) {
+	dispatcherController.addFlags(fs, kubeClientOptions)
+}
+
+// CheckOptions checks the flags of the dispatcher against the ones of the controller-manager after they're parsed.
+func CheckOptions(leaderElect bool) error {
+	return dispatcherController.checkOptions(leaderElect)
+}
+
+func (dispatcher *Dispatcher) checkOptions(leaderElect bool) error {
+	// Only the leader runs the controllers, the other replicas of the shard group would never dispatch their queues.
+	if dispatcher.options.shardGroup != "" && leaderElect {
+		return fmt.Errorf("--shard-group requires --leader-elect=false")
+	}
+	return nil
+}
+
+func (dispatcher *Dispatcher) addFlags(fs *pflag.FlagSet, kubeClientOptions *kube.ClientOptions) {
+	o := &options{kubeClientOptions: kubeClientOptions}
+	dispatcher.options = o
+
+	fs.StringVar(&o.cacheOption.DefaultQueueName, "default-queue", defaultQueue, "The default queue name of the workload")
+	fs.StringVar(&o.karmadaKubeConfigs, "karmada-kubeconfigs", "", "The comma separated Karmada control planes like <name>=<kubeconfig> to dispatch, the one of --kubeconfig is used when it's empty")
+	fs.StringVar(&dispatcher.dispatcherConf, "dispatcher-conf", "", "The absolute path of dispatcher configuration file")
+
+	fs.UintVar(&o.unSuspendParallelism, "unsuspend-parallelism", defaultUnSuspendParallelism, "The number of the ResourceBindings unsuspended concurrently when a round releases many of them")
+	fs.DurationVar(&o.cacheOption.StatusWriteWindow, "status-write-window", defaultStatusWriteWindow, "The window to coalesce the conditions and the annotations written back to a ResourceBinding into one status update and one annotation patch, zero means writing them at once")
+	fs.UintVar(&o.statusWriters, "status-writers", defaultStatusWriters, "The number of the workers writing the conditions and the annotations back to the ResourceBindings, at least the number of the workers")
+	fs.DurationVar(&dispatcher.dispatchPeriod, "dispatch-period", defaultDispatchPeriod, "The period between each scheduling cycle")
+	fs.DurationVar(&dispatcher.wakeUpDebounce, "wake-up-debounce", defaultWakeUpDebounce, "The quiet period after the last edit of the capability or the weight of the queues before a dispatching round is triggered without waiting for the next period, the rapid successive edits trigger one round only")
+	fs.DurationVar(&dispatcher.unhealthyTimeout, "unhealthy-timeout", 0, "The duration without a finished dispatching round before the dispatcher is unhealthy, 10 dispatch periods and at least 1m by default")
+	fs.DurationVar(&dispatcher.consistencyCheckPeriod, "consistency-check-period", defaultConsistencyCheckPeriod, "The period of comparing the dispatcher cache with the apiserver to catch the missed events, zero means disabled")
+	fs.BoolVar(&dispatcher.consistencyHeal, "consistency-heal", false, "Resync the objects from the apiserver when the periodic consistency check finds the differences")
+	fs.BoolVar(&dispatcher.checkpointDispatchState, "checkpoint-dispatch-state", false, "Persist the dispatch state owned by the dispatcher, like the enqueue time and the admitted resources, onto the ResourceBindings by the `volcano.sh/dispatch-checkpoint` annotation, so it's restored after the dispatcher restarts")
+	fs.DurationVar(&dispatcher.cacheGCPeriod, "cache-gc-period", defaultCacheGCPeriod, "The period of removing the objects not in the informers anymore from the dispatcher cache, like the ones whose delete events were missed, zero means disabled")
+	fs.DurationVar(&dispatcher.eventErrorCheckPeriod, "event-error-check-period", defaultEventErrorCheckPeriod, "The period of checking the informer events failed to be converted or processed by the dispatcher cache, the kinds whose failed events exceed the budget are resynced from the informers, zero means disabled")
+	fs.IntVar(&dispatcher.eventErrorBudget, "event-error-budget", defaultEventErrorBudget, "The number of the failed informer events of a kind tolerated in an event error check period before the kind is resynced")
+	fs.DurationVar(&dispatcher.dispatchConfirmTimeout, "dispatch-confirm-timeout", defaultDispatchConfirmTimeout, "The time for Karmada to apply the workloads to the member clusters after they are dispatched, the ones not applied in it are marked Failed and wait in the queues again, zero means disabled")
+	fs.BoolVar(&o.cacheOption.OverridePolicyAware, "override-policy-aware", false, "Evaluate the OverridePolicies and the ClusterOverridePolicies applicable to the workloads in their target clusters on resolving their resources for the queue accounting and the feasibility check, like the ones overriding the replicas or the resource requests per cluster")
+	fs.BoolVar(&o.cacheOption.PolicyQueueAware, "policy-queue-aware", false, "Resolve the queues of the workloads by the `scheduling.volcano.sh/queue-name` labels of the PropagationPolicies and the ClusterPropagationPolicies claiming them, after the queue annotations of the workloads and the queues of their PodGroups, and before the queues of their namespaces")
+	fs.BoolVar(&o.cacheOption.StripInformerObjects, "strip-informer-objects", true, "Drop the fields never read by the dispatcher from the watched objects before they are cached, like the managedFields, the per-cluster status of the ResourceBindings and the status of the PodGroups except the phase, to cut the memory on the big federations")
+	fs.StringVar(&o.capacityProvider, "capacity-provider", clustercapacity.ClusterStatusProviderName, "The source of the allocatable and allocated resources of the member clusters, cluster-status takes the resource summary in the status of the Clusters, file reads --capacity-file for the air-gapped environments and takes the status of the Clusters not in it")
+	fs.StringVar(&o.capacityFile, "capacity-file", "", "The YAML file of the capacities of the member clusters read by the file capacity provider, it's reloaded when it changes")
+	fs.StringVar(&o.faultInjection, "fault-injection", "", "The comma separated faults injected into the dispatcher cache for the resilience tests like <fault>=<value>, the faults are unsuspend-delay, unsuspend-failure-rate, event-drop-rate and snapshot-stall, empty means disabled. Never set it in production")
+	fs.StringVar(&dispatcher.listenAddress, "listen-address", defaultListenAddress, "The address to listen on for the metrics and the health checks")
+	fs.StringVar(&dispatcher.debugAddress, "debug-address", defaultDebugAddress, "The address to serve the admin endpoints like /dispatcher/pause and the debug endpoints under /debug/ over TLS, empty means disabled")
+	fs.StringVar(&o.debugCertFile, "debug-cert-file", "", "The serving certificate of the debug endpoints, a self-signed one is generated when it's empty")
+	fs.StringVar(&o.debugKeyFile, "debug-key-file", "", "The private key of the serving certificate of the debug endpoints")
+	fs.StringVar(&dispatcher.snapshotStreamAddress, "snapshot-stream-address", "", "The address to serve the read-only gRPC stream of the queues, the ResourceBindings and the decisions of the control planes after each dispatching round, authenticated like the debug endpoints, empty means disabled")
+	fs.BoolVar(&o.enableDebugAuth, "debug-auth", true, "Authenticate and authorize the requests of the debug endpoints by the TokenReview and SubjectAccessReview")
+	fs.IntVar(&o.maxDebugDecisions, "max-debug-decisions", defaultDebugDecisions, "The number of the last dispatch decisions served by the debug endpoint")
+	fs.BoolVar(&dispatcher.enableProfiling, "enable-profiling", false, "Serve the pprof endpoints under /debug/pprof/, authenticated like the other debug endpoints, and report the heap in use, the goroutines and the depths of the cache work queues periodically to diagnose the leaks")
+	fs.DurationVar(&dispatcher.selfReportPeriod, "self-report-period", defaultSelfReportPeriod, "The period of reporting the heap in use, the goroutines and the depths of the cache work queues when the profiling is enabled, zero means disabled")
+	fs.BoolVar(&dispatcher.annotateDecisions, "annotate-decisions", true, "Patch the last dispatch decision onto each ResourceBinding by the volcano.sh/last-dispatch-decision annotation when it's changed")
+
+	fs.StringVar(&o.shardGroup, "shard-group", "", "The group of the dispatcher replicas sharing the queues by consistent hashing, each replica dispatches the ResourceBindings of its own queues only, empty means disabled. It requires --leader-elect=false, so all the replicas dispatch")
+	fs.StringVar(&o.shardIdentity, "shard-identity", "", "The identity of the replica in the shard group, the hostname by default")
+	fs.StringVar(&o.shardLeaseNamespace, "shard-lease-namespace", defaultShardLeaseNamespace, "The namespace of the Leases of the shard group members")
+	fs.DurationVar(&o.shardLeaseDuration, "shard-lease-duration", defaultShardLeaseDuration, "The duration of the Leases of the shard group members, a member is removed when its Lease is not renewed in it")
+
+	fs.StringVar(&o.auditSinks, "audit-sinks", "", "The comma separated sinks of the dispatch audit log, each one is stdout, file:<path> or webhook:<url>")
+	fs.DurationVar(&o.auditWebhookTimeout, "audit-webhook-timeout", defaultAuditWebhookTimeout, "The timeout of posting an audit event to the webhook sink")
+
+	// The scheduler estimator flags follow the karmada-scheduler.
+	fs.BoolVar(&o.enableSchedulerEstimator, "enable-scheduler-estimator", false, "Enable calling cluster scheduler estimator to check whether the replicas can fit before dispatching")
+	fs.DurationVar(&o.estimatorOption.Timeout, "scheduler-estimator-timeout", defaultSchedulerEstimatorTimeout, "Specifies the timeout period of calling the scheduler estimator service")
+	fs.StringVar(&o.estimatorOption.ServiceNamespace, "scheduler-estimator-service-namespace", defaultSchedulerEstimatorNamespace, "The namespace to be used for discovering scheduler estimator services")
+	fs.StringVar(&o.estimatorOption.ServicePrefix, "scheduler-estimator-service-prefix", defaultSchedulerEstimatorPrefix, "The prefix of scheduler estimator service name")
+	fs.IntVar(&o.estimatorOption.GRPCConfig.TargetPort, "scheduler-estimator-port", defaultSchedulerEstimatorPort, "The secure port on which to connect the accurate scheduler estimator")
+	fs.StringVar(&o.estimatorOption.GRPCConfig.CertFile, "scheduler-estimator-cert-file", "", "SSL certification file used to secure scheduler estimator communication")
+	fs.StringVar(&o.estimatorOption.GRPCConfig.KeyFile, "scheduler-estimator-key-file", "", "SSL key file used to secure scheduler estimator communication")
+	fs.StringVar(&o.estimatorOption.GRPCConfig.ServerAuthCAFile, "scheduler-estimator-ca-file", "", "SSL Certificate Authority file used to secure scheduler estimator communication")
+	fs.BoolVar(&o.estimatorOption.GRPCConfig.InsecureSkipServerVerify, "insecure-skip-estimator-verify", false, "Controls whether verifies the scheduler estimator's certificate chain and host name")
+}
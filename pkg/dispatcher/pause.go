@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/audit"
+)
+
+type pauseStatus struct {
+	// Global is whether all the queues are paused by the configuration.
+	Global bool     `json:"global"`
+	Queues []string `json:"queues"`
+}
+
+// pausedBy returns the user who paused the queue by the pause endpoint, it's empty when the queue isn't paused by
+// the endpoint.
+func pausedBy(queue *schedulingapi.QueueInfo) string {
+	if queue.Queue == nil {
+		return ""
+	}
+	return queue.Queue.Annotations[api.QueuePausedByAnnotationKey]
+}
+
+// pauseHandler pauses the dispatching by `POST /dispatcher/pause?queue=<name>`, all the existing queues will be
+// paused if the queue is not set. The pauses are kept on the annotations of the queues, so all the replicas see
+// them and they survive the restarts. The `GET` method returns the queues paused by the endpoint.
+func (dispatcher *Dispatcher) pauseHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		queueName, user := r.URL.Query().Get("queue"), requestUser(r)
+		if err := dispatcher.setQueuesPausedBy(queueName, user); err != nil {
+			http.Error(w, fmt.Sprintf("failed to pause the dispatching, err: %v", err), http.StatusInternalServerError)
+			return
+		}
+		klog.Infof("Dispatching of queue <%s> is paused by %s by the admin endpoint, empty means all the queues.", queueName, user)
+		dispatcher.auditPause(audit.ActionPause, queueName, user)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	dispatcher.writePauseStatus(w)
+}
+
+// resumeHandler resumes the dispatching by `POST /dispatcher/resume?queue=<name>`, all the queues paused by the
+// endpoint will be resumed if the queue is not set.
+func (dispatcher *Dispatcher) resumeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	queueName, user := r.URL.Query().Get("queue"), requestUser(r)
+	if err := dispatcher.setQueuesPausedBy(queueName, ""); err != nil {
+		http.Error(w, fmt.Sprintf("failed to resume the dispatching, err: %v", err), http.StatusInternalServerError)
+		return
+	}
+	klog.Infof("Dispatching of queue <%s> is resumed by %s by the admin endpoint, empty means all the queues.", queueName, user)
+	dispatcher.auditPause(audit.ActionResume, queueName, user)
+	dispatcher.writePauseStatus(w)
+}
+
+// setQueuesPausedBy sets the user who paused the queue on it in all the control planes, an empty user resumes it.
+// All the queues are set when the queue name is empty, the ones already in the state are skipped.
+func (dispatcher *Dispatcher) setQueuesPausedBy(queueName, user string) error {
+	for _, cp := range dispatcher.controlPlanes {
+		if queueName != "" {
+			if err := cp.cache.SetQueuePausedBy(queueName, user); err != nil {
+				return fmt.Errorf("control plane <%s>: %v", cp.name, err)
+			}
+			continue
+		}
+		for name, queue := range cp.cache.Snapshot().QueueInfos {
+			if pausedBy(queue) == user {
+				continue
+			}
+			if err := cp.cache.SetQueuePausedBy(name, user); err != nil {
+				return fmt.Errorf("control plane <%s>: %v", cp.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// auditPause writes the pause or the resume of the queue by the admin endpoint to the audit log.
+func (dispatcher *Dispatcher) auditPause(action, queueName, user string) {
+	verb := "paused"
+	if action == audit.ActionResume {
+		verb = "resumed"
+	}
+	message := fmt.Sprintf("The dispatching of queue %s is %s by %s.", queueName, verb, user)
+	if queueName == "" {
+		message = fmt.Sprintf("The dispatching of all the queues is %s by %s.", verb, user)
+	}
+	dispatcher.auditLogger.Log(&audit.Event{
+		Time:    time.Now(),
+		Action:  action,
+		Queue:   queueName,
+		Reason:  api.DispatchPausedReason,
+		Message: message,
+		User:    user,
+	})
+}
+
+// writePauseStatus writes the queues paused by the endpoint in any control plane, they're read from the caches so
+// the pauses just set may take a moment to show up.
+func (dispatcher *Dispatcher) writePauseStatus(w http.ResponseWriter) {
+	dispatcher.mutex.Lock()
+	status := pauseStatus{Global: dispatcher.configuration != nil && dispatcher.configuration.Paused}
+	dispatcher.mutex.Unlock()
+
+	queues := sets.New[string]()
+	for _, cp := range dispatcher.controlPlanes {
+		for name, queue := range cp.cache.Snapshot().QueueInfos {
+			if pausedBy(queue) != "" {
+				queues.Insert(name)
+			}
+		}
+	}
+	status.Queues = sets.List(queues)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		klog.Errorf("Failed to write the pause status, err: %v", err)
+	}
+}
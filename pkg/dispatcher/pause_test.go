@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func TestPauseHandlers(t *testing.T) {
+	snapshot := &cache.DispatcherCacheSnapshot{QueueInfos: map[string]*schedulingapi.QueueInfo{}}
+	for _, name := range []string{"q1", "q2"} {
+		snapshot.QueueInfos[name] = &schedulingapi.QueueInfo{
+			UID:   schedulingapi.QueueID(name),
+			Name:  name,
+			Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: name}},
+		}
+	}
+	dispatcher := &Dispatcher{controlPlanes: []*controlPlane{{name: defaultControlPlane, cache: &fakeCache{snapshot: snapshot}}}}
+
+	testCases := []struct {
+		Name         string
+		handler      http.HandlerFunc
+		method       string
+		url          string
+		expectCode   int
+		expectPaused []string
+	}{
+		{
+			Name:         "Pause the queue",
+			handler:      dispatcher.pauseHandler,
+			method:       http.MethodPost,
+			url:          "/dispatcher/pause?queue=q1",
+			expectCode:   http.StatusOK,
+			expectPaused: []string{"q1"},
+		},
+		{
+			Name:       "Pause the queue not found",
+			handler:    dispatcher.pauseHandler,
+			method:     http.MethodPost,
+			url:        "/dispatcher/pause?queue=q3",
+			expectCode: http.StatusInternalServerError,
+		},
+		{
+			Name:         "Pause all the queues",
+			handler:      dispatcher.pauseHandler,
+			method:       http.MethodPost,
+			url:          "/dispatcher/pause",
+			expectCode:   http.StatusOK,
+			expectPaused: []string{"q1", "q2"},
+		},
+		{
+			Name:         "Resume the queue",
+			handler:      dispatcher.resumeHandler,
+			method:       http.MethodPost,
+			url:          "/dispatcher/resume?queue=q1",
+			expectCode:   http.StatusOK,
+			expectPaused: []string{"q2"},
+		},
+		{
+			Name:       "Resume with invalid method",
+			handler:    dispatcher.resumeHandler,
+			method:     http.MethodGet,
+			url:        "/dispatcher/resume?queue=q2",
+			expectCode: http.StatusMethodNotAllowed,
+		},
+		{
+			Name:         "Get the paused queues",
+			handler:      dispatcher.pauseHandler,
+			method:       http.MethodGet,
+			url:          "/dispatcher/pause",
+			expectCode:   http.StatusOK,
+			expectPaused: []string{"q2"},
+		},
+		{
+			Name:         "Resume all the queues",
+			handler:      dispatcher.resumeHandler,
+			method:       http.MethodPost,
+			url:          "/dispatcher/resume",
+			expectCode:   http.StatusOK,
+			expectPaused: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(tc.method, tc.url, nil)
+		// The user is set by the authenticator of the debug endpoints.
+		tc.handler(recorder, request.WithContext(context.WithValue(request.Context(), userContextKey{}, "admin")))
+		if recorder.Code != tc.expectCode {
+			t.Errorf("Test case %s failed, got code: %d expect: %d", tc.Name, recorder.Code, tc.expectCode)
+		}
+		if recorder.Code != http.StatusOK {
+			continue
+		}
+		status := pauseStatus{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+			t.Errorf("Test case %s failed, unexpected err: %v", tc.Name, err)
+		}
+		if !reflect.DeepEqual(status.Queues, tc.expectPaused) {
+			t.Errorf("Test case %s failed, got paused queues: %v expect: %v", tc.Name, status.Queues, tc.expectPaused)
+		}
+		for name, queue := range snapshot.QueueInfos {
+			if paused, expectPaused := dispatcher.isQueuePaused(queue, false), slices.Contains(tc.expectPaused, name); paused != expectPaused {
+				t.Errorf("Test case %s failed, got queue %s paused: %v expect: %v", tc.Name, name, paused, expectPaused)
+			}
+			if paused := pausedBy(queue); paused != "" && paused != "admin" {
+				t.Errorf("Test case %s failed, got queue %s paused by: %s expect: admin", tc.Name, name, paused)
+			}
+		}
+	}
+}
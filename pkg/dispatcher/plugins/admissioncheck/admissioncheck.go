@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissioncheck
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "admissioncheck"
+
+	// AdmissionCheckPendingReason is the reason when the admission checks of the workload are not ready yet.
+	AdmissionCheckPendingReason = "AdmissionCheckPending"
+	// AdmissionCheckRejectedReason is the reason when one of the admission checks rejects the workload.
+	AdmissionCheckRejectedReason = "AdmissionCheckRejected"
+
+	// checksKey is the argument of the default admission checks of the queues, separated by `,`.
+	checksKey = "admissioncheck.checks"
+)
+
+// admissionCheckPlugin holds the ResourceBindings until the external admission check controllers, like the budget
+// approval or the security scan, set the conditions of their checks True on the ResourceBindings. The checks are
+// the condition types, a condition False with the reason `Rejected` rejects the workload until it's set again.
+type admissionCheckPlugin struct {
+	ssn    *framework.Session
+	checks []string
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	ap := &admissionCheckPlugin{}
+	checks := ""
+	arguments.GetString(&checks, checksKey)
+	ap.checks = parseChecks(checks)
+	return ap
+}
+
+func (ap *admissionCheckPlugin) Name() string {
+	return PluginName
+}
+
+func (ap *admissionCheckPlugin) OnSessionOpen(ssn *framework.Session) {
+	ap.ssn = ssn
+	ssn.AddDispatchableFn(ap.Name(), ap.dispatchableFn)
+}
+
+func (ap *admissionCheckPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (ap *admissionCheckPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	for _, check := range ap.queueChecks(ap.ssn.GetResourceBindingInfoQueue(rbi)) {
+		if blocker := checkBlocker(rbi.ResourceBinding.Status.Conditions, check); blocker != nil {
+			klog.V(4).Infof("AdmissionCheck plugin: ResourceBinding <%s/%s> waits for the admission check <%s>.",
+				rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, check)
+			return blocker
+		}
+	}
+	return nil
+}
+
+// queueChecks returns the admission checks of the queue, the annotation of the queue takes precedence.
+func (ap *admissionCheckPlugin) queueChecks(queueName string) []string {
+	queue, found := ap.ssn.Snapshot.QueueInfos[queueName]
+	if !found || queue.Queue == nil {
+		return ap.checks
+	}
+	value, found := queue.Queue.Annotations[api.AdmissionChecksAnnotationKey]
+	if !found {
+		return ap.checks
+	}
+	return parseChecks(value)
+}
+
+// checkBlocker returns the blocker if the condition of the admission check is not True.
+func checkBlocker(conditions []metav1.Condition, check string) *api.DispatchBlocker {
+	condition := meta.FindStatusCondition(conditions, check)
+	if condition == nil {
+		return &api.DispatchBlocker{
+			Reason:  AdmissionCheckPendingReason,
+			Message: fmt.Sprintf("The admission check %s is not ready", check),
+		}
+	}
+	if condition.Status == metav1.ConditionTrue {
+		return nil
+	}
+	blocker := &api.DispatchBlocker{
+		Reason:  AdmissionCheckPendingReason,
+		Message: fmt.Sprintf("The admission check %s is not ready", check),
+	}
+	if condition.Status == metav1.ConditionFalse && condition.Reason == api.RejectedAdmissionCheckReason {
+		blocker.Reason = AdmissionCheckRejectedReason
+		blocker.Message = fmt.Sprintf("The admission check %s rejects the workload", check)
+	}
+	if condition.Message != "" {
+		blocker.Message += ": " + condition.Message
+	}
+	return blocker
+}
+
+// parseChecks parses the admission checks separated by `,`.
+func parseChecks(value string) []string {
+	var checks []string
+	for _, check := range strings.Split(value, ",") {
+		if check = strings.TrimSpace(check); check != "" {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
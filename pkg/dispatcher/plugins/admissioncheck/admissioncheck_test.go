@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissioncheck
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func TestCheckBlocker(t *testing.T) {
+	conditions := []metav1.Condition{
+		{Type: "BudgetApproved", Status: metav1.ConditionTrue},
+		{Type: "SecurityScanned", Status: metav1.ConditionFalse, Reason: "Scanning"},
+		{Type: "CapacityReserved", Status: metav1.ConditionFalse, Reason: api.RejectedAdmissionCheckReason, Message: "no capacity"},
+	}
+
+	testCases := []struct {
+		Name   string
+		check  string
+		expect string
+	}{
+		{
+			Name:   "Condition is True",
+			check:  "BudgetApproved",
+			expect: "",
+		},
+		{
+			Name:   "Condition is not set",
+			check:  "LicenseChecked",
+			expect: AdmissionCheckPendingReason,
+		},
+		{
+			Name:   "Condition is False",
+			check:  "SecurityScanned",
+			expect: AdmissionCheckPendingReason,
+		},
+		{
+			Name:   "Condition is Rejected",
+			check:  "CapacityReserved",
+			expect: AdmissionCheckRejectedReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		got := ""
+		if blocker := checkBlocker(conditions, tc.check); blocker != nil {
+			got = blocker.Reason
+		}
+		if got != tc.expect {
+			t.Errorf("Test case %s failed, got: %q expect: %q", tc.Name, got, tc.expect)
+		}
+	}
+}
@@ -17,17 +17,63 @@ limitations under the License.
 package capacity
 
 import (
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
-	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
 	"volcano.sh/volcano-global/pkg/dispatcher/framework"
 )
 
-const PluginName = "capacity"
+const (
+	PluginName = "capacity"
 
-type capacityPlugin struct{}
+	// QueueCapabilityExceededReason is the reason when the capability of the queue would be exceeded.
+	QueueCapabilityExceededReason = "QueueCapabilityExceeded"
+	// InsufficientIdleResourceReason is the reason when the queue would borrow beyond its deserved resources,
+	// but there are not enough idle resources in the federation.
+	InsufficientIdleResourceReason = "InsufficientIdleResource"
+	// WaitingForReclaimReason is the reason when the queue is within its deserved resources,
+	// but the resources are borrowed by the other queues.
+	WaitingForReclaimReason = "WaitingForReclaim"
+	// NoPlacementClusterReason is the reason when no ready cluster matches the placement of the workload.
+	NoPlacementClusterReason = "NoPlacementCluster"
+	// InsufficientPlacementResourceReason is the reason when the clusters which the workload can land on
+	// don't have enough free resources.
+	InsufficientPlacementResourceReason = "InsufficientPlacementResource"
+)
 
-func New() framework.Plugin {
+// queueAttr is the elastic quota of a queue in the session, a resource which is not in the capability is unlimited,
+// and a resource which is not in the deserved is deserved zero, all of its usage is borrowed.
+type queueAttr struct {
+	name string
+	// guarantee is reserved for the queue, the other queues can't borrow it.
+	guarantee corev1.ResourceList
+	// deserved is the resources the queue can use without borrowing, it's between the guarantee and the capability.
+	deserved corev1.ResourceList
+	// capability is the real capability of the queue, limited by the total resources and the guarantees of the others.
+	capability corev1.ResourceList
+	// allocated is the resources of the dispatched but not completed ResourceBindings in the queue.
+	allocated corev1.ResourceList
+	// reclaimable is false when the resources borrowed by the queue can't be reclaimed.
+	reclaimable bool
+}
+
+// capacityPlugin implements the elastic quota of the queues, a queue can use the idle resources beyond its deserved
+// resources, which will be given back when the other queues need them.
+type capacityPlugin struct {
+	ssn *framework.Session
+	// total is the allocatable resources of the ready member clusters.
+	total      corev1.ResourceList
+	allocated  corev1.ResourceList
+	queueAttrs map[string]*queueAttr
+}
+
+func New(_ framework.Arguments) framework.Plugin {
 	return &capacityPlugin{}
 }
 
@@ -36,26 +82,347 @@ func (cp *capacityPlugin) Name() string {
 }
 
 func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
+	cp.ssn = ssn
+	cp.total = ssn.Snapshot.TotalResources()
+	cp.allocated = corev1.ResourceList{}
+	cp.queueAttrs = buildQueueAttrs(ssn.Snapshot.QueueInfos, cp.total)
+
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() && !rbi.IsCompleted() {
+			cp.allocate(rbi)
+		}
+	}
+
 	// Register the Queue order func
 	ssn.AddQueueInfoOrderFn(cp.Name(), cp.queueOrderFunc)
+	ssn.AddDispatchableFn(cp.Name(), cp.dispatchableFn)
+	ssn.AddReclaimableFn(cp.Name(), cp.reclaimableFn)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			cp.allocate(event.ResourceBindingInfo)
+		},
+		EvictFunc: func(event *framework.Event) {
+			cp.deallocate(event.ResourceBindingInfo)
+		},
+	})
 }
 
 func (cp *capacityPlugin) OnSessionClose(_ *framework.Session) {}
 
+func buildQueueAttrs(queues map[string]*schedulingapi.QueueInfo, total corev1.ResourceList) map[string]*queueAttr {
+	totalGuarantee := corev1.ResourceList{}
+	for _, queue := range queues {
+		if queue.Queue != nil {
+			addResources(totalGuarantee, queue.Queue.Spec.Guarantee.Resource)
+		}
+	}
+
+	queueAttrs := make(map[string]*queueAttr, len(queues))
+	for name, queue := range queues {
+		attr := &queueAttr{
+			name:       name,
+			guarantee:  corev1.ResourceList{},
+			deserved:   corev1.ResourceList{},
+			capability: corev1.ResourceList{},
+			allocated:  corev1.ResourceList{},
+			// The queue is reclaimable by default, like the volcano-scheduler.
+			reclaimable: true,
+		}
+		if queue.Queue == nil {
+			queueAttrs[name] = attr
+			continue
+		}
+		if queue.Queue.Spec.Reclaimable != nil {
+			attr.reclaimable = *queue.Queue.Spec.Reclaimable
+		}
+		attr.guarantee = queue.Queue.Spec.Guarantee.Resource.DeepCopy()
+		if attr.guarantee == nil {
+			attr.guarantee = corev1.ResourceList{}
+		}
+
+		// The real capability can't exceed the total resources except the guarantees of the other queues.
+		attr.capability = queue.Queue.Spec.Capability.DeepCopy()
+		if attr.capability == nil {
+			attr.capability = corev1.ResourceList{}
+		}
+		for resourceName, quantity := range total {
+			limit := quantity.DeepCopy()
+			limit.Sub(totalGuarantee[resourceName])
+			limit.Add(attr.guarantee[resourceName])
+			if capability, found := attr.capability[resourceName]; !found || limit.Cmp(capability) < 0 {
+				attr.capability[resourceName] = limit
+			}
+		}
+
+		// The deserved is at least the guarantee, and at most the capability.
+		for resourceName, quantity := range queue.Queue.Spec.Deserved {
+			attr.deserved[resourceName] = quantity.DeepCopy()
+		}
+		for resourceName, quantity := range attr.guarantee {
+			if deserved, found := attr.deserved[resourceName]; !found || deserved.Cmp(quantity) < 0 {
+				attr.deserved[resourceName] = quantity.DeepCopy()
+			}
+		}
+		for resourceName, quantity := range attr.deserved {
+			if capability, found := attr.capability[resourceName]; found && quantity.Cmp(capability) > 0 {
+				attr.deserved[resourceName] = capability.DeepCopy()
+			}
+		}
+		queueAttrs[name] = attr
+	}
+	return queueAttrs
+}
+
+func (cp *capacityPlugin) allocate(rbi *api.ResourceBindingInfo) {
+	addResources(cp.allocated, rbi.MinResources)
+	if attr, found := cp.queueAttrs[cp.ssn.GetResourceBindingInfoQueue(rbi)]; found {
+		addResources(attr.allocated, rbi.MinResources)
+	}
+}
+
+func (cp *capacityPlugin) deallocate(rbi *api.ResourceBindingInfo) {
+	subResources(cp.allocated, rbi.MinResources)
+	if attr, found := cp.queueAttrs[cp.ssn.GetResourceBindingInfoQueue(rbi)]; found {
+		subResources(attr.allocated, rbi.MinResources)
+	}
+}
+
+// share is the max ratio of the allocated to the deserved resources of the queue.
+func (attr *queueAttr) share() float64 {
+	share := 0.0
+	for resourceName, deserved := range attr.deserved {
+		allocated := attr.allocated[resourceName]
+		if deserved.IsZero() {
+			if !allocated.IsZero() {
+				share = math.Max(share, 1)
+			}
+			continue
+		}
+		share = math.Max(share, allocated.AsApproximateFloat64()/deserved.AsApproximateFloat64())
+	}
+	return share
+}
+
+// queueOrderFunc orders the queues by the priority, then the queue with the lower share first,
+// so the queues under their deserved resources are dispatched before the borrowing ones.
 func (cp *capacityPlugin) queueOrderFunc(l, r interface{}) int {
-	lv := l.(*scheduling.Queue)
-	rv := r.(*scheduling.Queue)
+	lv := l.(*schedulingapi.QueueInfo)
+	rv := r.(*schedulingapi.QueueInfo)
+	lpriority, rpriority := queuePriority(lv), queuePriority(rv)
 
-	klog.V(4).Infof("Capacity plugin QueueOrder: <%s/%s> Queue priority %d, <%s/%s> Queue priority %d",
-		lv.Namespace, lv.Name, lv.Spec.Priority, rv.Namespace, rv.Name, rv.Spec.Priority)
+	klog.V(4).Infof("Capacity plugin QueueOrder: <%s> Queue priority %d, <%s> Queue priority %d",
+		lv.Name, lpriority, rv.Name, rpriority)
 
-	if lv.Spec.Priority == rv.Spec.Priority {
-		return 0
+	if lpriority != rpriority {
+		if lpriority > rpriority {
+			return -1
+		}
+		return 1
 	}
 
-	if lv.Spec.Priority > rv.Spec.Priority {
+	lattr, lfound := cp.queueAttrs[lv.Name]
+	rattr, rfound := cp.queueAttrs[rv.Name]
+	if !lfound || !rfound {
+		return 0
+	}
+	lshare, rshare := lattr.share(), rattr.share()
+	if lshare == rshare {
+		return 0
+	}
+	if lshare < rshare {
 		return -1
 	}
-
 	return 1
 }
+
+// queuePriority returns the priority of the queue, it's zero when the Queue object is not set, like buildQueueAttrs.
+func queuePriority(queue *schedulingapi.QueueInfo) int32 {
+	if queue.Queue == nil {
+		return 0
+	}
+	return queue.Queue.Spec.Priority
+}
+
+func (cp *capacityPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	attr, found := cp.queueAttrs[cp.ssn.GetResourceBindingInfoQueue(rbi)]
+	if !found {
+		return nil
+	}
+
+	for resourceName, request := range rbi.MinResources {
+		allocated := attr.allocated[resourceName].DeepCopy()
+		allocated.Add(request)
+		if capability, found := attr.capability[resourceName]; found && allocated.Cmp(capability) > 0 {
+			klog.V(4).Infof("Capacity plugin: ResourceBinding <%s/%s> exceeds the capability of Queue <%s> on %s, request %s, capability %s.",
+				rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, attr.name, resourceName, request.String(), capability.String())
+			return &api.DispatchBlocker{
+				Reason:  QueueCapabilityExceededReason,
+				Message: fmt.Sprintf("The capability of queue %s would be exceeded on %s, capability: %s", attr.name, resourceName, capability.String()),
+			}
+		}
+
+		idle, known := cp.idle(attr, resourceName)
+		if !known || request.Cmp(idle) <= 0 {
+			continue
+		}
+		// The queue is entitled to its deserved resources, the borrowed ones should be given back to it.
+		if deserved, found := attr.deserved[resourceName]; found && allocated.Cmp(deserved) <= 0 {
+			return &api.DispatchBlocker{
+				Reclaimable: true,
+				Reason:      WaitingForReclaimReason,
+				Message: fmt.Sprintf("Queue %s is within its deserved %s: %s, but the idle %s is %s, waiting for the borrowed resources to be reclaimed",
+					attr.name, resourceName, deserved.String(), resourceName, idle.String()),
+			}
+		}
+		return &api.DispatchBlocker{
+			Reason: InsufficientIdleResourceReason,
+			Message: fmt.Sprintf("Queue %s would use %s beyond its deserved resources, but the idle %s is %s",
+				attr.name, resourceName, resourceName, idle.String()),
+		}
+	}
+	return cp.placementBlocker(rbi)
+}
+
+// placementBlocker checks the request against the free resources of the clusters which the workload can land on, when
+// its placement excludes some of the clusters. The free resources are reported by the clusters, so the workloads
+// dispatched in the session are not counted yet.
+func (cp *capacityPlugin) placementBlocker(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	clusters, excluded := cp.ssn.Snapshot.PlacementClusters(rbi.ResourceBinding.Spec.Placement)
+	if !excluded {
+		return nil
+	}
+	if len(clusters) == 0 {
+		return &api.DispatchBlocker{
+			Reason:  NoPlacementClusterReason,
+			Message: "No ready cluster matches the cluster affinity and tolerations of the workload",
+		}
+	}
+
+	free := cp.ssn.Snapshot.FreeResources(clusters)
+	for resourceName, request := range rbi.MinResources {
+		quantity, found := free[resourceName]
+		if !found || request.Cmp(quantity) <= 0 {
+			continue
+		}
+		klog.V(4).Infof("Capacity plugin: ResourceBinding <%s/%s> requests %s %s, but its %d placement clusters have %s free.",
+			rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, request.String(), resourceName, len(clusters), quantity.String())
+		return &api.DispatchBlocker{
+			Reason: InsufficientPlacementResourceReason,
+			Message: fmt.Sprintf("The %d clusters matching the placement of the workload have %s %s free, but it requests %s",
+				len(clusters), quantity.String(), resourceName, request.String()),
+		}
+	}
+	return nil
+}
+
+// idle is the resources which can be used by the queue, the unused guarantees of the other queues are excluded.
+// It returns false when the total resources are unknown, like no cluster reports its resource summary.
+func (cp *capacityPlugin) idle(attr *queueAttr, resourceName corev1.ResourceName) (resource.Quantity, bool) {
+	total, found := cp.total[resourceName]
+	if !found {
+		return resource.Quantity{}, false
+	}
+
+	idle := total.DeepCopy()
+	idle.Sub(cp.allocated[resourceName])
+	for _, other := range cp.queueAttrs {
+		if other == attr {
+			continue
+		}
+		unused := other.guarantee[resourceName].DeepCopy()
+		unused.Sub(other.allocated[resourceName])
+		if unused.Sign() > 0 {
+			idle.Sub(unused)
+		}
+	}
+	return idle, true
+}
+
+// reclaimableFn selects the victims from the queues which borrowed the resources the reclaimer is short of,
+// a queue will not be reclaimed below its deserved resources.
+func (cp *capacityPlugin) reclaimableFn(reclaimer *api.ResourceBindingInfo, candidates []*api.ResourceBindingInfo) []*api.ResourceBindingInfo {
+	attr, found := cp.queueAttrs[cp.ssn.GetResourceBindingInfoQueue(reclaimer)]
+	if !found {
+		return nil
+	}
+
+	shortage := corev1.ResourceList{}
+	for resourceName, request := range reclaimer.MinResources {
+		idle, known := cp.idle(attr, resourceName)
+		if !known || request.Cmp(idle) <= 0 {
+			continue
+		}
+		request = request.DeepCopy()
+		request.Sub(idle)
+		shortage[resourceName] = request
+	}
+	if len(shortage) == 0 {
+		return nil
+	}
+
+	// released[queue] is the resources of the victims selected from the queue.
+	released := map[string]corev1.ResourceList{}
+	victims := []*api.ResourceBindingInfo{}
+	for _, candidate := range candidates {
+		victimAttr, found := cp.queueAttrs[cp.ssn.GetResourceBindingInfoQueue(candidate)]
+		if !found || victimAttr == attr || !victimAttr.reclaimable {
+			continue
+		}
+		if released[victimAttr.name] == nil {
+			released[victimAttr.name] = corev1.ResourceList{}
+		}
+		if !victimAttr.borrowed(released[victimAttr.name], candidate.MinResources, shortage) {
+			continue
+		}
+
+		addResources(released[victimAttr.name], candidate.MinResources)
+		victims = append(victims, candidate)
+		satisfied := true
+		for resourceName, quantity := range shortage {
+			quantity.Sub(candidate.MinResources[resourceName])
+			shortage[resourceName] = quantity
+			satisfied = satisfied && quantity.Sign() <= 0
+		}
+		if satisfied {
+			return victims
+		}
+	}
+	return nil
+}
+
+// borrowed checks whether the resources of the victim are borrowed by the queue, it's true when the victim releases
+// some resources in the shortage, and the queue keeps its deserved resources after the victim and the released ones
+// are suspended.
+func (attr *queueAttr) borrowed(released, victim, shortage corev1.ResourceList) bool {
+	helpful := false
+	for resourceName, quantity := range victim {
+		// The missing deserved is zero.
+		deserved := attr.deserved[resourceName]
+		remaining := attr.allocated[resourceName].DeepCopy()
+		remaining.Sub(released[resourceName])
+		remaining.Sub(quantity)
+		if remaining.Cmp(deserved) < 0 {
+			return false
+		}
+		if need, found := shortage[resourceName]; found && need.Sign() > 0 && quantity.Sign() > 0 {
+			helpful = true
+		}
+	}
+	return helpful
+}
+
+func addResources(to, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		value := to[name]
+		value.Add(quantity)
+		to[name] = value
+	}
+}
+
+func subResources(from, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		value := from[name]
+		value.Sub(quantity)
+		from[name] = value
+	}
+}
@@ -0,0 +1,418 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"context"
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// fakeCache returns the snapshot only, the other operations do nothing.
+type fakeCache struct {
+	snapshot *cache.DispatcherCacheSnapshot
+}
+
+func (fc *fakeCache) Run(_ <-chan struct{}) {}
+
+func (fc *fakeCache) Snapshot() *cache.DispatcherCacheSnapshot {
+	return fc.snapshot
+}
+
+func (fc *fakeCache) LookupResourceBinding(_ types.NamespacedName) (string, api.DispatchStatus, bool) {
+	return "", 0, false
+}
+
+func (fc *fakeCache) UnSuspendResourceBinding(_ types.NamespacedName) {}
+
+func (fc *fakeCache) UnSuspendResourceBindingToCluster(_ types.NamespacedName, _ string) {}
+
+func (fc *fakeCache) SuspendResourceBinding(_ types.NamespacedName) {}
+
+func (fc *fakeCache) SuspendResourceBindingOnFailure(_ types.NamespacedName, _ []string) {}
+
+func (fc *fakeCache) FailResourceBinding(_ types.NamespacedName) {}
+
+func (fc *fakeCache) UpdateResourceBindingCondition(_ types.NamespacedName, _ metav1.Condition) {}
+
+func (fc *fakeCache) AnnotateResourceBinding(_ types.NamespacedName, _, _ string) {}
+
+func (fc *fakeCache) ExpireWorkload(_ types.NamespacedName, _ string) {}
+
+func (fc *fakeCache) CheckConsistency(_ context.Context, _ bool) (*api.ConsistencyReport, error) {
+	return &api.ConsistencyReport{}, nil
+}
+
+func (fc *fakeCache) CollectGarbage() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) WakeUp() <-chan struct{} {
+	return nil
+}
+
+func (fc *fakeCache) WorkQueueDepths() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) TakeCoalescedStatusUpdates() int {
+	return 0
+}
+
+func (fc *fakeCache) TakeEventErrors() map[string]int {
+	return nil
+}
+
+func (fc *fakeCache) Resync(_ string) int {
+	return 0
+}
+
+func (fc *fakeCache) SetDefaultQueue(_ string) {}
+
+func (fc *fakeCache) SetMinResourcesSource(_ string) {}
+
+func (fc *fakeCache) SetQueuePausedBy(_, _ string) error { return nil }
+
+func cpu(value string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+}
+
+func newCluster(name string, ready bool, allocatable corev1.ResourceList) *clusterv1alpha1.Cluster {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: clusterv1alpha1.ClusterStatus{
+			Conditions:      []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: status}},
+			ResourceSummary: &clusterv1alpha1.ResourceSummary{Allocatable: allocatable},
+		},
+	}
+}
+
+func newQueue(name string, guarantee, deserved, capability corev1.ResourceList) *schedulingapi.QueueInfo {
+	return &schedulingapi.QueueInfo{
+		Name: name,
+		Queue: &scheduling.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: scheduling.QueueSpec{
+				Guarantee:  scheduling.Guarantee{Resource: guarantee},
+				Deserved:   deserved,
+				Capability: capability,
+			},
+		},
+	}
+}
+
+func newRBI(queue string, status api.DispatchStatus, minResources corev1.ResourceList) *api.ResourceBindingInfo {
+	return &api.ResourceBindingInfo{
+		ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb"}},
+		Queue:           queue,
+		DispatchStatus:  status,
+		MinResources:    minResources,
+	}
+}
+
+func TestDispatchable(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		allocated    map[string]corev1.ResourceList
+		rbi          *api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name: "Within the deserved resources",
+			rbi:  newRBI("q1", api.Pending, cpu("3")),
+		},
+		{
+			Name:         "Exceed the capability",
+			allocated:    map[string]corev1.ResourceList{"q1": cpu("7")},
+			rbi:          newRBI("q1", api.Pending, cpu("2")),
+			expectReason: QueueCapabilityExceededReason,
+		},
+		{
+			Name:      "Borrow the idle resources",
+			allocated: map[string]corev1.ResourceList{"q2": cpu("6")},
+			rbi:       newRBI("q2", api.Pending, cpu("1")),
+		},
+		{
+			Name:         "Can't borrow the unused guarantee of the others",
+			allocated:    map[string]corev1.ResourceList{"q2": cpu("6"), "q3": cpu("1")},
+			rbi:          newRBI("q2", api.Pending, cpu("2")),
+			expectReason: InsufficientIdleResourceReason,
+		},
+		{
+			Name:         "Wait for the borrowed resources to be reclaimed",
+			allocated:    map[string]corev1.ResourceList{"q2": cpu("8")},
+			rbi:          newRBI("q1", api.Pending, cpu("3")),
+			expectReason: WaitingForReclaimReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue: "q1",
+			QueueInfos: map[string]*schedulingapi.QueueInfo{
+				"q1": newQueue("q1", cpu("2"), cpu("4"), cpu("8")),
+				"q2": newQueue("q2", nil, cpu("6"), nil),
+				"q3": newQueue("q3", nil, nil, nil),
+			},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+			Clusters: map[string]*clusterv1alpha1.Cluster{
+				"ready":     newCluster("ready", true, cpu("10")),
+				"not-ready": newCluster("not-ready", false, cpu("10")),
+			},
+		}
+		for queue, allocated := range tc.allocated {
+			snapshot.ResourceBindingInfos[types.UID(queue)] = newRBI(queue, api.Dispatched, allocated)
+		}
+		// The completed workloads release their resources.
+		snapshot.ResourceBindingInfos["completed"] = newRBI("q1", api.Dispatched, cpu("10"))
+		snapshot.ResourceBindingInfos["completed"].PodGroup = &schedulingv1beta1.PodGroup{
+			Status: schedulingv1beta1.PodGroupStatus{Phase: schedulingv1beta1.PodGroupCompleted},
+		}
+
+		cp := New(nil).(*capacityPlugin)
+		cp.OnSessionOpen(framework.OpenSession(&fakeCache{snapshot: snapshot}, nil))
+		reason := ""
+		if blocker := cp.dispatchableFn(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
+
+func TestScalarResourceDispatchable(t *testing.T) {
+	gpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(value)}
+	}
+	testCases := []struct {
+		Name         string
+		allocated    map[string]corev1.ResourceList
+		rbi          *api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name: "Within the deserved gpus",
+			rbi:  newRBI("q1", api.Pending, gpu("4")),
+		},
+		{
+			Name:         "Exceed the gpu capability",
+			allocated:    map[string]corev1.ResourceList{"q1": gpu("8")},
+			rbi:          newRBI("q1", api.Pending, gpu("3")),
+			expectReason: QueueCapabilityExceededReason,
+		},
+		{
+			Name:         "Insufficient idle gpus",
+			allocated:    map[string]corev1.ResourceList{"q1": gpu("8"), "q2": gpu("7")},
+			rbi:          newRBI("q1", api.Pending, gpu("2")),
+			expectReason: InsufficientIdleResourceReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		// The queues and the clusters account the gpus only.
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue: "q1",
+			QueueInfos: map[string]*schedulingapi.QueueInfo{
+				"q1": newQueue("q1", nil, gpu("8"), gpu("10")),
+				"q2": newQueue("q2", nil, gpu("8"), nil),
+			},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+			Clusters: map[string]*clusterv1alpha1.Cluster{
+				"gpu": newCluster("gpu", true, gpu("16")),
+			},
+		}
+		for queue, allocated := range tc.allocated {
+			snapshot.ResourceBindingInfos[types.UID(queue)] = newRBI(queue, api.Dispatched, allocated)
+		}
+
+		cp := New(nil).(*capacityPlugin)
+		cp.OnSessionOpen(framework.OpenSession(&fakeCache{snapshot: snapshot}, nil))
+		reason := ""
+		if blocker := cp.dispatchableFn(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
+
+func TestQueueOrder(t *testing.T) {
+	q1 := newQueue("q1", nil, cpu("4"), nil)
+	q2 := newQueue("q2", nil, cpu("4"), nil)
+	cp := &capacityPlugin{queueAttrs: map[string]*queueAttr{
+		"q1": {name: "q1", deserved: cpu("4"), allocated: cpu("3")},
+		"q2": {name: "q2", deserved: cpu("4"), allocated: cpu("1")},
+	}}
+
+	if result := cp.queueOrderFunc(q1, q2); result != 1 {
+		t.Errorf("Test case lower share first failed, got: %d expect: %d", result, 1)
+	}
+	q1.Queue.Spec.Priority = 1
+	if result := cp.queueOrderFunc(q1, q2); result != -1 {
+		t.Errorf("Test case higher priority first failed, got: %d expect: %d", result, -1)
+	}
+	q2.Queue = nil
+	if result := cp.queueOrderFunc(q1, q2); result != -1 {
+		t.Errorf("Test case queue without object failed, got: %d expect: %d", result, -1)
+	}
+}
+
+func TestPlacementBlocker(t *testing.T) {
+	gpuCluster := newCluster("gpu", true, cpu("4"))
+	gpuCluster.Labels = map[string]string{"pool": "gpu"}
+	gpuCluster.Status.ResourceSummary.Allocated = cpu("1")
+	taintedCluster := newCluster("tainted", true, cpu("10"))
+	taintedCluster.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "true", Effect: corev1.TaintEffectNoSchedule}}
+	snapshot := &cache.DispatcherCacheSnapshot{
+		DefaultQueue:         "q1",
+		QueueInfos:           map[string]*schedulingapi.QueueInfo{"q1": newQueue("q1", nil, nil, nil)},
+		ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+		Clusters: map[string]*clusterv1alpha1.Cluster{
+			"gpu":     gpuCluster,
+			"cpu":     newCluster("cpu", true, cpu("10")),
+			"tainted": taintedCluster,
+		},
+	}
+	withPlacement := func(rbi *api.ResourceBindingInfo, placement *policyv1alpha1.Placement) *api.ResourceBindingInfo {
+		rbi.ResourceBinding.Spec.Placement = placement
+		return rbi
+	}
+
+	testCases := []struct {
+		Name         string
+		rbi          *api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name: "Fit in the untainted clusters",
+			rbi:  newRBI("q1", api.Pending, cpu("13")),
+		},
+		{
+			Name:         "Can't land on the tainted cluster without the toleration",
+			rbi:          newRBI("q1", api.Pending, cpu("14")),
+			expectReason: InsufficientPlacementResourceReason,
+		},
+		{
+			Name: "Fit in the free resources of the affinity clusters",
+			rbi: withPlacement(newRBI("q1", api.Pending, cpu("3")), &policyv1alpha1.Placement{
+				ClusterAffinity: &policyv1alpha1.ClusterAffinity{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}}},
+			}),
+		},
+		{
+			Name: "Exceed the free resources of the affinity clusters",
+			rbi: withPlacement(newRBI("q1", api.Pending, cpu("4")), &policyv1alpha1.Placement{
+				ClusterAffinity: &policyv1alpha1.ClusterAffinity{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}}},
+			}),
+			expectReason: InsufficientPlacementResourceReason,
+		},
+		{
+			Name: "Land on the tainted cluster by the toleration",
+			rbi: withPlacement(newRBI("q1", api.Pending, cpu("10")), &policyv1alpha1.Placement{
+				ClusterAffinities: []policyv1alpha1.ClusterAffinityTerm{
+					{AffinityName: "missing", ClusterAffinity: policyv1alpha1.ClusterAffinity{ClusterNames: []string{"missing"}}},
+					{AffinityName: "tainted", ClusterAffinity: policyv1alpha1.ClusterAffinity{ClusterNames: []string{"tainted"}}},
+				},
+				ClusterTolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+			}),
+		},
+		{
+			Name: "No cluster matches the placement",
+			rbi: withPlacement(newRBI("q1", api.Pending, cpu("1")), &policyv1alpha1.Placement{
+				ClusterAffinity: &policyv1alpha1.ClusterAffinity{ClusterNames: []string{"missing"}},
+			}),
+			expectReason: NoPlacementClusterReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		cp := New(nil).(*capacityPlugin)
+		cp.OnSessionOpen(framework.OpenSession(&fakeCache{snapshot: snapshot}, nil))
+		reason := ""
+		if blocker := cp.placementBlocker(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
+
+func TestQueueUsages(t *testing.T) {
+	snapshot := &cache.DispatcherCacheSnapshot{
+		DefaultQueue: "q1",
+		QueueInfos: map[string]*schedulingapi.QueueInfo{
+			"q1": newQueue("q1", nil, cpu("4"), nil),
+			"q2": newQueue("q2", nil, cpu("4"), nil),
+			"q3": newQueue("q3", nil, cpu("6"), nil),
+		},
+		ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{
+			"rb1": newRBI("", api.Dispatched, cpu("8")),
+			"rb2": newRBI("q2", api.Dispatched, cpu("2")),
+			"rb3": newRBI("q3", api.Pending, cpu("2")),
+		},
+		Clusters: map[string]*clusterv1alpha1.Cluster{
+			"c1": newCluster("c1", true, cpu("20")),
+		},
+	}
+
+	testCases := []struct {
+		Name           string
+		queue          string
+		expectUsed     string
+		expectBorrowed string
+		expectLent     string
+	}{
+		{Name: "Borrowing queue", queue: "q1", expectUsed: "8", expectBorrowed: "4", expectLent: "0"},
+		{Name: "Lending queue", queue: "q2", expectUsed: "2", expectBorrowed: "0", expectLent: "1"},
+		{Name: "Idle queue", queue: "q3", expectUsed: "0", expectBorrowed: "0", expectLent: "3"},
+	}
+
+	usages := QueueUsages(snapshot)
+	for _, tc := range testCases {
+		for _, usage := range usages {
+			if usage.Name != tc.queue {
+				continue
+			}
+			used, borrowed, lent := usage.Used[corev1.ResourceCPU], usage.Borrowed[corev1.ResourceCPU], usage.Lent[corev1.ResourceCPU]
+			if used.Cmp(resource.MustParse(tc.expectUsed)) != 0 || borrowed.Cmp(resource.MustParse(tc.expectBorrowed)) != 0 ||
+				lent.Cmp(resource.MustParse(tc.expectLent)) != 0 {
+				t.Errorf("Test case %s failed, got used: %s borrowed: %s lent: %s expect used: %s borrowed: %s lent: %s", tc.Name,
+					used.String(), borrowed.String(), lent.String(), tc.expectUsed, tc.expectBorrowed, tc.expectLent)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"math"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+// QueueUsages computes the deserved, used, borrowed and lent resources of the queues in the snapshot by the same
+// elastic quota as the plugin, sorted by the name. A resource not in the deserved ones is borrowed entirely.
+func QueueUsages(snapshot *cache.DispatcherCacheSnapshot) []api.QueueUsage {
+	queueAttrs := buildQueueAttrs(snapshot.QueueInfos, snapshot.TotalResources())
+	for _, rbi := range snapshot.ResourceBindingInfos {
+		if !rbi.DispatchStatus.IsDispatched() || rbi.IsCompleted() {
+			continue
+		}
+		queueName := rbi.Queue
+		if queueName == "" {
+			queueName = snapshot.DefaultQueue
+		}
+		if attr, found := queueAttrs[queueName]; found {
+			addResources(attr.allocated, rbi.MinResources)
+		}
+	}
+
+	usages := make([]api.QueueUsage, 0, len(queueAttrs))
+	// totalBorrowed and totalUnused are the sums of the borrowed and the unused deserved resources of all the queues.
+	totalBorrowed, totalUnused := corev1.ResourceList{}, corev1.ResourceList{}
+	unused := make(map[string]corev1.ResourceList, len(queueAttrs))
+	for name, attr := range queueAttrs {
+		usage := api.QueueUsage{
+			Name:     name,
+			Deserved: attr.deserved.DeepCopy(),
+			Used:     attr.allocated.DeepCopy(),
+			Borrowed: corev1.ResourceList{},
+			Lent:     corev1.ResourceList{},
+		}
+		for resourceName, used := range attr.allocated {
+			borrowed := used.DeepCopy()
+			borrowed.Sub(attr.deserved[resourceName])
+			if borrowed.Sign() > 0 {
+				usage.Borrowed[resourceName] = borrowed
+			}
+		}
+		unused[name] = corev1.ResourceList{}
+		for resourceName, deserved := range attr.deserved {
+			remaining := deserved.DeepCopy()
+			remaining.Sub(attr.allocated[resourceName])
+			if remaining.Sign() > 0 {
+				unused[name][resourceName] = remaining
+			}
+		}
+		addResources(totalBorrowed, usage.Borrowed)
+		addResources(totalUnused, unused[name])
+		usages = append(usages, usage)
+	}
+
+	for i := range usages {
+		for resourceName, remaining := range unused[usages[i].Name] {
+			borrowed := totalBorrowed[resourceName]
+			if borrowed.Sign() <= 0 {
+				continue
+			}
+			total := totalUnused[resourceName]
+			usages[i].Lent[resourceName] = scaleQuantity(remaining, math.Min(1, borrowed.AsApproximateFloat64()/total.AsApproximateFloat64()))
+		}
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+	return usages
+}
+
+// scaleQuantity returns the quantity multiplied by the ratio in [0, 1].
+func scaleQuantity(quantity resource.Quantity, ratio float64) resource.Quantity {
+	if ratio >= 1 {
+		return quantity.DeepCopy()
+	}
+	// The milli value of the large quantities like the memory in bytes may overflow.
+	if quantity.Value() < 1<<40 {
+		return *resource.NewMilliQuantity(int64(float64(quantity.MilliValue())*ratio), quantity.Format)
+	}
+	return *resource.NewQuantity(int64(float64(quantity.Value())*ratio), quantity.Format)
+}
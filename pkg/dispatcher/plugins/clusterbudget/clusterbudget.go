@@ -0,0 +1,282 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterbudget
+
+import (
+	"fmt"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	karmadautil "github.com/karmada-io/karmada/pkg/util"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "clusterbudget"
+
+	// ClusterBudgetExceededReason is the reason when the dispatch budget of the target clusters is used up in the window.
+	ClusterBudgetExceededReason = "ClusterBudgetExceeded"
+
+	// windowKey is the argument of the time window of the budgets, one hour by default.
+	windowKey = "clusterbudget.window"
+	// budgetsKey is the argument of the budgets, the first budget selecting a cluster takes effect.
+	budgetsKey = "clusterbudget.budgets"
+
+	defaultWindow = time.Hour
+)
+
+// budget limits the resources dispatched toward each of the selected clusters per window, the resources not listed
+// are not limited. It selects all the clusters when neither the cluster affinity nor the taint key is set.
+type budget struct {
+	// ClusterAffinity selects the clusters by the names, the labels or the fields like the zone and the region.
+	ClusterAffinity *policyv1alpha1.ClusterAffinity `json:"clusterAffinity,omitempty"`
+	// TaintKey selects the clusters having a taint with the key.
+	TaintKey string `json:"taintKey,omitempty"`
+	// Resources is the resources which can be dispatched toward each selected cluster per window.
+	Resources corev1.ResourceList `json:"resources"`
+}
+
+// clusterBudgetPlugin limits the resources the dispatcher releases toward the member clusters per time window,
+// to protect the small clusters like the edge ones from bursts of workloads.
+type clusterBudgetPlugin struct {
+	window  time.Duration
+	budgets []*budget
+
+	snapshot *cache.DispatcherCacheSnapshot
+	// budgetOf[cluster] is the budget of the cluster, the clusters without budgets are not limited.
+	budgetOf map[string]*budget
+	// released[cluster] is the resources dispatched toward the cluster in the current window.
+	released map[string]corev1.ResourceList
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	cp := &clusterBudgetPlugin{window: defaultWindow}
+	window := ""
+	arguments.GetString(&window, windowKey)
+	if window != "" {
+		d, err := time.ParseDuration(window)
+		if err != nil || d <= 0 {
+			klog.Errorf("Invalid argument %s: %q, use the default window %v, err: %v", windowKey, window, defaultWindow, err)
+		} else {
+			cp.window = d
+		}
+	}
+	if argv, found := arguments[budgetsKey]; found {
+		if err := parseArgument(argv, &cp.budgets); err != nil {
+			klog.Errorf("Failed to parse the argument %s, err: %v", budgetsKey, err)
+		}
+	}
+	return cp
+}
+
+// parseArgument converts the structured argument decoded from the configuration into the object, by the json tags
+// which the karmada types have.
+func parseArgument(argv interface{}, object interface{}) error {
+	data, err := yaml.Marshal(argv)
+	if err != nil {
+		return err
+	}
+	return k8syaml.Unmarshal(data, object)
+}
+
+func (cp *clusterBudgetPlugin) Name() string {
+	return PluginName
+}
+
+func (cp *clusterBudgetPlugin) OnSessionOpen(ssn *framework.Session) {
+	cp.open(ssn.Snapshot, time.Now())
+
+	ssn.AddDispatchableFn(cp.Name(), cp.dispatchableFn)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			cp.release(event.ResourceBindingInfo)
+		},
+		UnreserveFunc: func(event *framework.Event) {
+			cp.unrelease(event.ResourceBindingInfo)
+		},
+	})
+}
+
+func (cp *clusterBudgetPlugin) OnSessionClose(_ *framework.Session) {}
+
+// open selects the budgets of the clusters, and counts the resources dispatched in the window before now.
+func (cp *clusterBudgetPlugin) open(snapshot *cache.DispatcherCacheSnapshot, now time.Time) {
+	cp.snapshot = snapshot
+	cp.budgetOf = map[string]*budget{}
+	cp.released = map[string]corev1.ResourceList{}
+	for name, cluster := range snapshot.Clusters {
+		for _, b := range cp.budgets {
+			if b.selects(cluster) {
+				cp.budgetOf[name] = b
+				break
+			}
+		}
+	}
+	if len(cp.budgetOf) == 0 {
+		return
+	}
+
+	for _, rbi := range snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() && !rbi.UnSuspendTime.IsZero() && now.Sub(rbi.UnSuspendTime) < cp.window {
+			cp.release(rbi)
+		}
+	}
+}
+
+func (b *budget) selects(cluster *clusterv1alpha1.Cluster) bool {
+	if b.ClusterAffinity != nil && !karmadautil.ClusterMatches(cluster, *b.ClusterAffinity) {
+		return false
+	}
+	if b.TaintKey == "" {
+		return true
+	}
+	for _, taint := range cluster.Spec.Taints {
+		if taint.Key == b.TaintKey {
+			return true
+		}
+	}
+	return false
+}
+
+// release counts the resources of the ResourceBinding toward its target clusters. The ResourceBinding which isn't
+// scheduled yet is counted when the karmada-scheduler has scheduled it in a later session.
+func (cp *clusterBudgetPlugin) release(rbi *api.ResourceBindingInfo) {
+	for cluster, request := range clusterRequests(rbi) {
+		if cp.budgetOf[cluster] == nil {
+			continue
+		}
+		if cp.released[cluster] == nil {
+			cp.released[cluster] = corev1.ResourceList{}
+		}
+		for name, quantity := range request {
+			value := cp.released[cluster][name]
+			value.Add(quantity)
+			cp.released[cluster][name] = value
+		}
+	}
+}
+
+// unrelease takes back the resources of the ResourceBinding counted by release, it's not released in the end.
+func (cp *clusterBudgetPlugin) unrelease(rbi *api.ResourceBindingInfo) {
+	for cluster, request := range clusterRequests(rbi) {
+		if cp.released[cluster] == nil {
+			continue
+		}
+		for name, quantity := range request {
+			value := cp.released[cluster][name]
+			value.Sub(quantity)
+			if value.Sign() <= 0 {
+				delete(cp.released[cluster], name)
+				continue
+			}
+			cp.released[cluster][name] = value
+		}
+	}
+}
+
+// clusterRequests returns the resources the ResourceBinding requests from each of its target clusters, by the
+// replicas scheduled to the cluster. The whole workload is counted when the requirements of the replicas are unknown.
+func clusterRequests(rbi *api.ResourceBindingInfo) map[string]corev1.ResourceList {
+	spec := &rbi.ResourceBinding.Spec
+	requests := make(map[string]corev1.ResourceList, len(spec.Clusters))
+	for _, target := range spec.Clusters {
+		if spec.ReplicaRequirements == nil || len(spec.ReplicaRequirements.ResourceRequest) == 0 || target.Replicas <= 0 {
+			requests[target.Name] = rbi.MinResources
+			continue
+		}
+		request := corev1.ResourceList{}
+		for name, quantity := range spec.ReplicaRequirements.ResourceRequest {
+			value := quantity.DeepCopy()
+			value.Mul(int64(target.Replicas))
+			request[name] = value
+		}
+		requests[target.Name] = request
+	}
+	return requests
+}
+
+// exceeds returns the resource on which the request would exceed the budget of the cluster in the window. A request
+// bigger than the whole budget is allowed when nothing has been dispatched toward the cluster in the window,
+// otherwise it would wait forever.
+func (cp *clusterBudgetPlugin) exceeds(cluster string, request corev1.ResourceList) (corev1.ResourceName, bool) {
+	b := cp.budgetOf[cluster]
+	if b == nil || len(cp.released[cluster]) == 0 {
+		return "", false
+	}
+	for name, limit := range b.Resources {
+		quantity, found := request[name]
+		if !found {
+			continue
+		}
+		used := cp.released[cluster][name].DeepCopy()
+		used.Add(quantity)
+		if used.Cmp(limit) > 0 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (cp *clusterBudgetPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	if len(cp.budgetOf) == 0 {
+		return nil
+	}
+
+	// The scheduled ResourceBinding is released toward all of its target clusters, each of them must have the budget.
+	if requests := clusterRequests(rbi); len(requests) > 0 {
+		for cluster, request := range requests {
+			name, exceeded := cp.exceeds(cluster, request)
+			if !exceeded {
+				continue
+			}
+			klog.V(4).Infof("Clusterbudget plugin: ResourceBinding <%s/%s> exceeds the dispatch budget of cluster <%s> on %s.",
+				rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, cluster, name)
+			return &api.DispatchBlocker{
+				Reason: ClusterBudgetExceededReason,
+				Message: fmt.Sprintf("The dispatch budget of cluster %s in the %v window would be exceeded on %s, limited: %s",
+					cluster, cp.window, name, cp.budgetOf[cluster].Resources.Name(name, "").String()),
+			}
+		}
+		return nil
+	}
+
+	// The ResourceBinding which isn't scheduled yet can land on any cluster matching its placement,
+	// it waits only when none of them has the budget.
+	clusters, _ := cp.snapshot.PlacementClusters(rbi.ResourceBinding.Spec.Placement)
+	if len(clusters) == 0 {
+		return nil
+	}
+	for _, cluster := range clusters {
+		if _, exceeded := cp.exceeds(cluster.Name, rbi.MinResources); !exceeded {
+			return nil
+		}
+	}
+	klog.V(4).Infof("Clusterbudget plugin: ResourceBinding <%s/%s> exceeds the dispatch budgets of all its %d placement clusters.",
+		rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, len(clusters))
+	return &api.DispatchBlocker{
+		Reason:  ClusterBudgetExceededReason,
+		Message: fmt.Sprintf("The dispatch budgets of all the %d clusters matching the placement in the %v window would be exceeded", len(clusters), cp.window),
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterbudget
+
+import (
+	"testing"
+	"time"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+)
+
+const pluginConf = `
+name: clusterbudget
+arguments:
+  clusterbudget.window: 30m
+  clusterbudget.budgets:
+  - clusterAffinity:
+      fieldSelector:
+        matchExpressions:
+        - key: zone
+          operator: In
+          values: [edge]
+    resources:
+      cpu: "4"
+  - taintKey: volcano.sh/small
+    resources:
+      cpu: "2"
+`
+
+func newCluster(name, zone string, taints ...corev1.Taint) *clusterv1alpha1.Cluster {
+	return &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       clusterv1alpha1.ClusterSpec{Zones: []string{zone}, Taints: taints},
+		Status: clusterv1alpha1.ClusterStatus{
+			Conditions: []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: metav1.ConditionTrue}},
+		},
+	}
+}
+
+// newRBI builds a ResourceBindingInfo requesting one cpu per replica, it's scheduled when the targets are given.
+func newRBI(uid string, replicas int32, unSuspendTime time.Time, targets ...string) *api.ResourceBindingInfo {
+	rb := &workv1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: uid, UID: types.UID(uid)},
+		Spec: workv1alpha2.ResourceBindingSpec{
+			Replicas:            replicas,
+			ReplicaRequirements: &workv1alpha2.ReplicaRequirements{ResourceRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+		},
+	}
+	for _, target := range targets {
+		rb.Spec.Clusters = append(rb.Spec.Clusters, workv1alpha2.TargetCluster{Name: target, Replicas: replicas})
+	}
+	rbi := &api.ResourceBindingInfo{
+		ResourceBinding: rb,
+		DispatchStatus:  api.Pending,
+		MinResources:    corev1.ResourceList{corev1.ResourceCPU: *resource.NewQuantity(int64(replicas), resource.DecimalSI)},
+	}
+	if !unSuspendTime.IsZero() {
+		rbi.DispatchStatus = api.Dispatched
+		rbi.UnSuspendTime = unSuspendTime
+	}
+	return rbi
+}
+
+func withPlacement(rbi *api.ResourceBindingInfo, clusterNames ...string) *api.ResourceBindingInfo {
+	rbi.ResourceBinding.Spec.Placement = &policyv1alpha1.Placement{
+		ClusterAffinity: &policyv1alpha1.ClusterAffinity{ClusterNames: clusterNames},
+	}
+	return rbi
+}
+
+func TestDispatchable(t *testing.T) {
+	option := conf.PluginOption{}
+	if err := yaml.Unmarshal([]byte(pluginConf), &option); err != nil {
+		t.Fatalf("Failed to unmarshal the plugin configuration: %v", err)
+	}
+	now := time.Now()
+	clusters := map[string]*clusterv1alpha1.Cluster{
+		"edge-1": newCluster("edge-1", "edge"),
+		"edge-2": newCluster("edge-2", "edge"),
+		"small":  newCluster("small", "core", corev1.Taint{Key: "volcano.sh/small", Effect: corev1.TaintEffectPreferNoSchedule}),
+		"core":   newCluster("core", "core"),
+	}
+
+	testCases := []struct {
+		Name         string
+		dispatched   []*api.ResourceBindingInfo
+		rbi          *api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name:       "Within the budget of the zone",
+			dispatched: []*api.ResourceBindingInfo{newRBI("d1", 2, now.Add(-10*time.Minute), "edge-1")},
+			rbi:        newRBI("rb", 2, time.Time{}, "edge-1"),
+		},
+		{
+			Name:         "Exceed the budget of the zone",
+			dispatched:   []*api.ResourceBindingInfo{newRBI("d1", 3, now.Add(-10*time.Minute), "edge-1")},
+			rbi:          newRBI("rb", 2, time.Time{}, "edge-1"),
+			expectReason: ClusterBudgetExceededReason,
+		},
+		{
+			Name:       "Each cluster of the zone has its own budget",
+			dispatched: []*api.ResourceBindingInfo{newRBI("d1", 3, now.Add(-10*time.Minute), "edge-1")},
+			rbi:        newRBI("rb", 2, time.Time{}, "edge-2"),
+		},
+		{
+			Name:       "Dispatched before the window are not counted",
+			dispatched: []*api.ResourceBindingInfo{newRBI("d1", 3, now.Add(-time.Hour), "edge-1")},
+			rbi:        newRBI("rb", 2, time.Time{}, "edge-1"),
+		},
+		{
+			Name:         "Exceed the budget of the tainted cluster",
+			dispatched:   []*api.ResourceBindingInfo{newRBI("d1", 1, now.Add(-time.Minute), "small")},
+			rbi:          newRBI("rb", 2, time.Time{}, "small"),
+			expectReason: ClusterBudgetExceededReason,
+		},
+		{
+			Name: "Bigger than the budget but nothing dispatched in the window",
+			rbi:  newRBI("rb", 8, time.Time{}, "edge-1"),
+		},
+		{
+			Name:       "Cluster without budget is not limited",
+			dispatched: []*api.ResourceBindingInfo{newRBI("d1", 3, now.Add(-time.Minute), "core")},
+			rbi:        newRBI("rb", 20, time.Time{}, "core"),
+		},
+		{
+			Name: "Not scheduled and all the placement clusters exceed the budget",
+			dispatched: []*api.ResourceBindingInfo{
+				newRBI("d1", 3, now.Add(-time.Minute), "edge-1"),
+				newRBI("d2", 4, now.Add(-time.Minute), "edge-2"),
+			},
+			rbi:          withPlacement(newRBI("rb", 2, time.Time{}), "edge-1", "edge-2"),
+			expectReason: ClusterBudgetExceededReason,
+		},
+		{
+			Name:       "Not scheduled and one placement cluster has the budget",
+			dispatched: []*api.ResourceBindingInfo{newRBI("d1", 3, now.Add(-time.Minute), "edge-1")},
+			rbi:        withPlacement(newRBI("rb", 2, time.Time{}), "edge-1", "edge-2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		snapshot := &cache.DispatcherCacheSnapshot{
+			Clusters:             clusters,
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+		}
+		for _, rbi := range tc.dispatched {
+			snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+		}
+		cp := New(option.Arguments).(*clusterBudgetPlugin)
+		cp.open(snapshot, now)
+
+		reason := ""
+		if blocker := cp.dispatchableFn(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"strconv"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "cost"
+
+	// defaultCostKey is the argument of the cost of the clusters without the cost annotation, 1 by default.
+	defaultCostKey = "cost.defaultCost"
+)
+
+// costPlugin orders the ResourceBindings by the relative cost of the clusters they land on, the cost of the member
+// clusters is set by the `volcano.sh/cluster-cost` annotation. The scheduled ResourceBinding costs the average of its
+// target clusters by the replicas, the others cost the cheapest ready cluster of their placement which can fit them,
+// so the workloads fitting the cheaper clusters are dispatched first. The cost is exposed in the audit log.
+type costPlugin struct {
+	defaultCost float64
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	cp := &costPlugin{defaultCost: 1}
+	arguments.GetFloat64(&cp.defaultCost, defaultCostKey)
+	return cp
+}
+
+func (cp *costPlugin) Name() string {
+	return PluginName
+}
+
+func (cp *costPlugin) OnSessionOpen(ssn *framework.Session) {
+	costs := map[string]float64{}
+	for name, cluster := range ssn.Snapshot.Clusters {
+		costs[name] = cp.clusterCost(cluster)
+	}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() {
+			continue
+		}
+		if cost, found := workloadCost(ssn.Snapshot, costs, rbi); found {
+			rbi.ClusterCost = &cost
+		}
+	}
+
+	ssn.AddResourceBindingInfoOrderFn(cp.Name(), cp.resourceBindingInfoOrderFunc)
+}
+
+func (cp *costPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (cp *costPlugin) resourceBindingInfoOrderFunc(l, r interface{}) int {
+	lv := l.(*api.ResourceBindingInfo)
+	rv := r.(*api.ResourceBindingInfo)
+
+	// The ones without the cost are dispatched after the others.
+	switch {
+	case lv.ClusterCost == nil && rv.ClusterCost == nil:
+		return 0
+	case lv.ClusterCost == nil:
+		return 1
+	case rv.ClusterCost == nil:
+		return -1
+	case *lv.ClusterCost < *rv.ClusterCost:
+		return -1
+	case *lv.ClusterCost > *rv.ClusterCost:
+		return 1
+	}
+	return 0
+}
+
+// clusterCost returns the cost of the cluster by its annotation, the default cost is used if it's not set or invalid.
+func (cp *costPlugin) clusterCost(cluster *clusterv1alpha1.Cluster) float64 {
+	value, found := cluster.Annotations[api.ClusterCostAnnotationKey]
+	if !found {
+		return cp.defaultCost
+	}
+	cost, err := strconv.ParseFloat(value, 64)
+	if err != nil || cost < 0 {
+		klog.Errorf("Invalid annotation %s of Cluster <%s>: %q, use the default cost %v.", api.ClusterCostAnnotationKey, cluster.Name, value, cp.defaultCost)
+		return cp.defaultCost
+	}
+	return cost
+}
+
+// workloadCost returns the cost of the clusters the workload lands on, it's not found when no cluster can be chosen.
+func workloadCost(snapshot *cache.DispatcherCacheSnapshot, costs map[string]float64, rbi *api.ResourceBindingInfo) (float64, bool) {
+	// The scheduled one lands on its target clusters.
+	if targets := rbi.ResourceBinding.Spec.Clusters; len(targets) > 0 {
+		total, replicas := 0.0, int32(0)
+		for _, target := range targets {
+			cost, found := costs[target.Name]
+			if !found {
+				continue
+			}
+			total += cost * float64(target.Replicas)
+			replicas += target.Replicas
+		}
+		if replicas > 0 {
+			return total / float64(replicas), true
+		}
+	}
+
+	// The others may land on any cluster of the placement, prefer the cheapest one which can fit it.
+	clusters, _ := snapshot.PlacementClusters(rbi.ResourceBinding.Spec.Placement)
+	cheapest, found := 0.0, false
+	for _, cluster := range clusters {
+		if !fits(snapshot, cluster, rbi.MinResources) {
+			continue
+		}
+		if cost := costs[cluster.Name]; !found || cost < cheapest {
+			cheapest, found = cost, true
+		}
+	}
+	return cheapest, found
+}
+
+// fits checks whether the free resources of the cluster can fit the request, the cluster whose capacity is unknown
+// is considered fitting.
+func fits(snapshot *cache.DispatcherCacheSnapshot, cluster *clusterv1alpha1.Cluster, request corev1.ResourceList) bool {
+	capacity, found := snapshot.ClusterCapacity(cluster)
+	if !found {
+		return true
+	}
+	free := capacity.Free()
+	for name, quantity := range request {
+		if value := free[name]; value.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func TestWorkloadCost(t *testing.T) {
+	cpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+	newCluster := func(name, allocatable string) *clusterv1alpha1.Cluster {
+		return &clusterv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: clusterv1alpha1.ClusterStatus{
+				Conditions:      []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: metav1.ConditionTrue}},
+				ResourceSummary: &clusterv1alpha1.ResourceSummary{Allocatable: cpu(allocatable)},
+			},
+		}
+	}
+	snapshot := &cache.DispatcherCacheSnapshot{
+		Clusters: map[string]*clusterv1alpha1.Cluster{
+			"cheap":     newCluster("cheap", "4"),
+			"expensive": newCluster("expensive", "16"),
+		},
+	}
+	costs := map[string]float64{"cheap": 1, "expensive": 3}
+
+	testCases := []struct {
+		Name        string
+		clusters    []workv1alpha2.TargetCluster
+		placement   *policyv1alpha1.Placement
+		request     corev1.ResourceList
+		expectCost  float64
+		expectFound bool
+	}{
+		{
+			Name:        "Scheduled to the clusters",
+			clusters:    []workv1alpha2.TargetCluster{{Name: "cheap", Replicas: 1}, {Name: "expensive", Replicas: 3}},
+			request:     cpu("8"),
+			expectCost:  2.5,
+			expectFound: true,
+		},
+		{
+			Name:        "Fit the cheap cluster",
+			request:     cpu("2"),
+			expectCost:  1,
+			expectFound: true,
+		},
+		{
+			Name:        "Fit the expensive cluster only",
+			request:     cpu("8"),
+			expectCost:  3,
+			expectFound: true,
+		},
+		{
+			Name:    "Fit no cluster",
+			request: cpu("32"),
+		},
+		{
+			Name:    "Placement excludes the fitting cluster",
+			request: cpu("8"),
+			placement: &policyv1alpha1.Placement{ClusterAffinity: &policyv1alpha1.ClusterAffinity{
+				ClusterNames: []string{"cheap"},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{Spec: workv1alpha2.ResourceBindingSpec{
+				Clusters:  tc.clusters,
+				Placement: tc.placement,
+			}},
+			MinResources: tc.request,
+		}
+		cost, found := workloadCost(snapshot, costs, rbi)
+		if cost != tc.expectCost || found != tc.expectFound {
+			t.Errorf("Test case %s failed, got: %v %v expect: %v %v", tc.Name, cost, found, tc.expectCost, tc.expectFound)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadline
+
+import (
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const PluginName = "deadline"
+
+// deadlinePlugin orders the ResourceBindings by Earliest-Deadline-First, the deadline is set by the
+// `volcano.sh/deadline` annotation, and the ones without a deadline are dispatched after the others.
+// It's an alternative to the priority plugin, the order of the two plugins is undefined when both of them are enabled.
+type deadlinePlugin struct{}
+
+func New(_ framework.Arguments) framework.Plugin {
+	return &deadlinePlugin{}
+}
+
+func (dp *deadlinePlugin) Name() string {
+	return PluginName
+}
+
+func (dp *deadlinePlugin) OnSessionOpen(ssn *framework.Session) {
+	// Register the ResourceBinding order func
+	ssn.AddResourceBindingInfoOrderFn(dp.Name(), dp.resourceBindingInfoOrderFunc)
+}
+
+func (dp *deadlinePlugin) OnSessionClose(_ *framework.Session) {}
+
+func (dp *deadlinePlugin) resourceBindingInfoOrderFunc(l, r interface{}) int {
+	lv := l.(*api.ResourceBindingInfo)
+	rv := r.(*api.ResourceBindingInfo)
+	lDeadline, lFound := lv.Deadline()
+	rDeadline, rFound := rv.Deadline()
+
+	klog.V(4).Infof("Deadline plugin ResourceBindingOrder: <%s/%s> ResourceBinding deadline %v, <%s/%s> ResourceBinding deadline %v",
+		lv.ResourceBinding.Namespace, lv.ResourceBinding.Name, lDeadline,
+		rv.ResourceBinding.Namespace, rv.ResourceBinding.Name, rDeadline)
+
+	if !lFound && !rFound {
+		return 0
+	}
+	if lFound != rFound {
+		if lFound {
+			return -1
+		}
+		return 1
+	}
+
+	if lDeadline.Equal(rDeadline) {
+		return 0
+	}
+	if lDeadline.Before(rDeadline) {
+		return -1
+	}
+	return 1
+}
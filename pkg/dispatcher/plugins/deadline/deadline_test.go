@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadline
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func newRBI(rbDeadline, podGroupDeadline string) *api.ResourceBindingInfo {
+	rbi := &api.ResourceBindingInfo{
+		ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb"}},
+		PodGroup:        &schedulingv1beta1.PodGroup{},
+	}
+	if rbDeadline != "" {
+		rbi.ResourceBinding.Annotations = map[string]string{api.DeadlineAnnotationKey: rbDeadline}
+	}
+	if podGroupDeadline != "" {
+		rbi.PodGroup.Annotations = map[string]string{api.DeadlineAnnotationKey: podGroupDeadline}
+	}
+	return rbi
+}
+
+func TestResourceBindingInfoOrder(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		l, r        *api.ResourceBindingInfo
+		expectOrder int
+	}{
+		{
+			Name: "Both without deadline",
+			l:    newRBI("", ""),
+			r:    newRBI("", ""),
+		},
+		{
+			Name:        "Earlier deadline first",
+			l:           newRBI("2024-12-31T08:00:00Z", ""),
+			r:           newRBI("2024-12-31T10:00:00+01:00", ""),
+			expectOrder: -1,
+		},
+		{
+			Name:        "Deadline of the PodGroup",
+			l:           newRBI("", "2024-12-31T10:00:00Z"),
+			r:           newRBI("", "2024-12-31T08:00:00Z"),
+			expectOrder: 1,
+		},
+		{
+			Name:        "With deadline first",
+			l:           newRBI("", ""),
+			r:           newRBI("2024-12-31T08:00:00Z", ""),
+			expectOrder: 1,
+		},
+		{
+			Name:        "Invalid deadline is ignored",
+			l:           newRBI("tomorrow", ""),
+			r:           newRBI("2024-12-31T08:00:00Z", ""),
+			expectOrder: 1,
+		},
+	}
+
+	dp := &deadlinePlugin{}
+	for _, tc := range testCases {
+		if result := dp.resourceBindingInfoOrderFunc(tc.l, tc.r); result != tc.expectOrder {
+			t.Errorf("Test case %s failed, got: %d expect: %d", tc.Name, result, tc.expectOrder)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "dependency"
+
+	// DependencyNotFoundReason is the reason when the workload depended on is not managed by the dispatcher.
+	DependencyNotFoundReason = "DependencyNotFound"
+	// DependencyNotReadyReason is the reason when the workload depended on is not running or succeeded yet.
+	DependencyNotReadyReason = "DependencyNotReady"
+	// DependencyCycleReason is the reason when the dependencies of the workloads form a cycle.
+	DependencyCycleReason = "DependencyCycle"
+)
+
+// dependencyPlugin holds the ResourceBindings until the workloads they depend on are running or succeeded,
+// the dependencies are declared by the `volcano.sh/dispatch-depends-on` annotation and form a DAG of the jobs.
+type dependencyPlugin struct {
+	// workloads[namespace/name] is the ResourceBindingInfos of the workload, the kinds are not distinguished.
+	workloads map[types.NamespacedName][]*api.ResourceBindingInfo
+}
+
+func New(_ framework.Arguments) framework.Plugin {
+	return &dependencyPlugin{}
+}
+
+func (dp *dependencyPlugin) Name() string {
+	return PluginName
+}
+
+func (dp *dependencyPlugin) OnSessionOpen(ssn *framework.Session) {
+	dp.workloads = map[types.NamespacedName][]*api.ResourceBindingInfo{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		resource := rbi.ResourceBinding.Spec.Resource
+		key := types.NamespacedName{Namespace: resource.Namespace, Name: resource.Name}
+		dp.workloads[key] = append(dp.workloads[key], rbi)
+	}
+
+	ssn.AddDispatchableFn(dp.Name(), dp.dispatchableFn)
+}
+
+func (dp *dependencyPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (dp *dependencyPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	dependencies := parseDependencies(rbi)
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	if dp.inCycle(rbi) {
+		klog.Errorf("Dependency plugin: the dependencies of ResourceBinding <%s/%s> form a cycle, it will never be dispatched.",
+			rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name)
+		return &api.DispatchBlocker{
+			Reason:  DependencyCycleReason,
+			Message: fmt.Sprintf("The dependencies %s form a cycle", rbi.ResourceBinding.Annotations[api.DispatchDependsOnAnnotationKey]),
+		}
+	}
+
+	for _, dependency := range dependencies {
+		rbis, found := dp.workloads[dependency]
+		if !found {
+			return &api.DispatchBlocker{
+				Reason:  DependencyNotFoundReason,
+				Message: fmt.Sprintf("The workload %s depended on is not found", dependency),
+			}
+		}
+		for _, dependencyRBI := range rbis {
+			if !dependencyRBI.DispatchStatus.IsDispatched() || !dependencyRBI.IsRunning() {
+				klog.V(4).Infof("Dependency plugin: ResourceBinding <%s/%s> waits for the workload <%s>.",
+					rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, dependency)
+				return &api.DispatchBlocker{
+					Reason:  DependencyNotReadyReason,
+					Message: fmt.Sprintf("The workload %s depended on is not running or succeeded", dependency),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// inCycle checks whether the ResourceBindingInfo can reach itself by following the dependencies.
+func (dp *dependencyPlugin) inCycle(rbi *api.ResourceBindingInfo) bool {
+	visited := map[types.UID]bool{}
+	stack := parseDependencies(rbi)
+	for len(stack) > 0 {
+		dependency := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dependencyRBI := range dp.workloads[dependency] {
+			if dependencyRBI.ResourceBinding.UID == rbi.ResourceBinding.UID {
+				return true
+			}
+			if visited[dependencyRBI.ResourceBinding.UID] {
+				continue
+			}
+			visited[dependencyRBI.ResourceBinding.UID] = true
+			stack = append(stack, parseDependencies(dependencyRBI)...)
+		}
+	}
+	return false
+}
+
+// parseDependencies parses the workloads from the `volcano.sh/dispatch-depends-on` annotation, the namespace
+// of the ResourceBinding is used if a workload doesn't set one.
+func parseDependencies(rbi *api.ResourceBindingInfo) []types.NamespacedName {
+	value := rbi.ResourceBinding.Annotations[api.DispatchDependsOnAnnotationKey]
+	if value == "" {
+		return nil
+	}
+
+	var dependencies []types.NamespacedName
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		namespace, name, found := strings.Cut(item, "/")
+		if !found {
+			namespace, name = rbi.ResourceBinding.Namespace, item
+		}
+		dependencies = append(dependencies, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	return dependencies
+}
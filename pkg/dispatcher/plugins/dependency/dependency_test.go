@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func newRBI(name, dependsOn string, status api.DispatchStatus, health workv1alpha2.ResourceHealth) *api.ResourceBindingInfo {
+	rb := &workv1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name + "-job", UID: types.UID(name)},
+		Spec: workv1alpha2.ResourceBindingSpec{
+			Resource: workv1alpha2.ObjectReference{Kind: "Job", Namespace: "ns", Name: name},
+		},
+		Status: workv1alpha2.ResourceBindingStatus{
+			AggregatedStatus: []workv1alpha2.AggregatedStatusItem{{ClusterName: "member1", Health: health}},
+		},
+	}
+	if dependsOn != "" {
+		rb.Annotations = map[string]string{api.DispatchDependsOnAnnotationKey: dependsOn}
+	}
+	return &api.ResourceBindingInfo{ResourceBinding: rb, DispatchStatus: status}
+}
+
+func TestDispatchable(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		rbis         []*api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name: "No dependency",
+			rbis: []*api.ResourceBindingInfo{newRBI("a", "", api.Pending, "")},
+		},
+		{
+			Name: "Dependency is running",
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "ns/b, c", api.Pending, ""),
+				newRBI("b", "", api.Dispatched, workv1alpha2.ResourceHealthy),
+				newRBI("c", "", api.Dispatched, workv1alpha2.ResourceHealthy),
+			},
+		},
+		{
+			Name: "Dependency is not running",
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "ns/b", api.Pending, ""),
+				newRBI("b", "", api.Dispatched, workv1alpha2.ResourceUnhealthy),
+			},
+			expectReason: DependencyNotReadyReason,
+		},
+		{
+			Name:         "Dependency is not found",
+			rbis:         []*api.ResourceBindingInfo{newRBI("a", "ns/b", api.Pending, "")},
+			expectReason: DependencyNotFoundReason,
+		},
+		{
+			Name: "Dependencies form a cycle",
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "ns/b", api.Pending, ""),
+				newRBI("b", "ns/c", api.Pending, ""),
+				newRBI("c", "ns/a", api.Pending, ""),
+			},
+			expectReason: DependencyCycleReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		dp := &dependencyPlugin{workloads: map[types.NamespacedName][]*api.ResourceBindingInfo{}}
+		for _, rbi := range tc.rbis {
+			resource := rbi.ResourceBinding.Spec.Resource
+			key := types.NamespacedName{Namespace: resource.Namespace, Name: resource.Name}
+			dp.workloads[key] = append(dp.workloads[key], rbi)
+		}
+
+		reason := ""
+		if blocker := dp.dispatchableFn(tc.rbis[0]); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatchwindow
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "dispatchwindow"
+
+	// OutsideDispatchWindowReason is the reason when the queue is outside its dispatch windows.
+	OutsideDispatchWindowReason = "OutsideDispatchWindow"
+	// InvalidDispatchWindowReason is the reason when the dispatch windows of the queue can't be parsed.
+	InvalidDispatchWindowReason = "InvalidDispatchWindow"
+
+	// timezoneKey is the argument of the timezone used by the windows, the local timezone is used by default.
+	timezoneKey = "dispatchwindow.timezone"
+)
+
+// dispatchWindowPlugin holds the ResourceBindings of the queues which are outside their dispatch windows.
+type dispatchWindowPlugin struct {
+	location *time.Location
+	// outsideWindows[queue] is the dispatch windows of the queue which is outside them now.
+	outsideWindows map[string]string
+	// invalidWindows[queue] is the error of parsing the dispatch windows of the queue.
+	invalidWindows map[string]error
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	location := time.Local
+	timezone := ""
+	arguments.GetString(&timezone, timezoneKey)
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			klog.Errorf("Failed to load timezone %s, use the local timezone, err: %v", timezone, err)
+		} else {
+			location = loc
+		}
+	}
+	return &dispatchWindowPlugin{location: location}
+}
+
+func (dp *dispatchWindowPlugin) Name() string {
+	return PluginName
+}
+
+func (dp *dispatchWindowPlugin) OnSessionOpen(ssn *framework.Session) {
+	now := time.Now().In(dp.location)
+	dp.outsideWindows = map[string]string{}
+	dp.invalidWindows = map[string]error{}
+	for name, queue := range ssn.Snapshot.QueueInfos {
+		if queue.Queue == nil || queue.Queue.Annotations[api.DispatchWindowAnnotationKey] == "" {
+			continue
+		}
+		value := queue.Queue.Annotations[api.DispatchWindowAnnotationKey]
+		windows, err := parseWindows(value)
+		if err != nil {
+			// Hold the queue until the annotation is fixed, instead of dispatching it at any time.
+			klog.Errorf("Failed to parse the dispatch windows of Queue <%s>, hold it, err: %v", name, err)
+			dp.invalidWindows[name] = err
+			continue
+		}
+		if !inWindows(windows, now) {
+			dp.outsideWindows[name] = value
+		}
+	}
+
+	ssn.AddDispatchableFn(dp.Name(), func(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		if err, found := dp.invalidWindows[queueName]; found {
+			return &api.DispatchBlocker{
+				Reason:  InvalidDispatchWindowReason,
+				Message: fmt.Sprintf("Queue %s has the invalid dispatch windows: %v", queueName, err),
+			}
+		}
+		value, found := dp.outsideWindows[queueName]
+		if !found {
+			return nil
+		}
+		return &api.DispatchBlocker{
+			Reason:  OutsideDispatchWindowReason,
+			Message: fmt.Sprintf("Queue %s only dispatches in the windows %q", queueName, value),
+		}
+	})
+}
+
+func (dp *dispatchWindowPlugin) OnSessionClose(_ *framework.Session) {}
+
+func inWindows(windows []window, t time.Time) bool {
+	for _, w := range windows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatchwindow
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWindowDuration limits how far we look back for the start of a window.
+const maxWindowDuration = 7 * 24 * time.Hour
+
+// window is a dispatch window, it starts at the time matched by the schedule and lasts for the duration.
+type window struct {
+	schedule *schedule
+	duration time.Duration
+}
+
+// parseWindows parses the windows separated by `;`, a window can be:
+//   - a daily time range like `20:00-06:00`.
+//   - a cron expression with the duration like `0 20 * * 1-5 10h`, which starts at 20:00 on weekdays and lasts 10 hours.
+func parseWindows(value string) ([]window, error) {
+	var windows []window
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		var w window
+		var err error
+		if strings.Contains(item, "-") && !strings.Contains(item, " ") {
+			w, err = parseTimeRange(item)
+		} else {
+			w, err = parseCronWindow(item)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid dispatch window %q: %v", item, err)
+		}
+		windows = append(windows, w)
+	}
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("no dispatch window found in %q", value)
+	}
+	return windows, nil
+}
+
+// ValidateWindows checks the syntax of the windows in the `volcano.sh/dispatch-window` annotation, the plugin
+// holds the queues with the invalid ones, so they're never dispatched until the annotation is fixed.
+func ValidateWindows(value string) error {
+	_, err := parseWindows(value)
+	return err
+}
+
+func parseTimeRange(value string) (window, error) {
+	parts := strings.SplitN(value, "-", 2)
+	start, err := time.Parse("15:04", parts[0])
+	if err != nil {
+		return window{}, err
+	}
+	end, err := time.Parse("15:04", parts[1])
+	if err != nil {
+		return window{}, err
+	}
+
+	duration := end.Sub(start)
+	// The window crosses midnight, like `20:00-06:00`.
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+	s, err := parseSchedule(fmt.Sprintf("%d %d * * *", start.Minute(), start.Hour()))
+	if err != nil {
+		return window{}, err
+	}
+	return window{schedule: s, duration: duration}, nil
+}
+
+func parseCronWindow(value string) (window, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 6 {
+		return window{}, fmt.Errorf("expect 5 cron fields and a duration, got %d fields", len(fields))
+	}
+	duration, err := time.ParseDuration(fields[5])
+	if err != nil {
+		return window{}, err
+	}
+	if duration <= 0 || duration > maxWindowDuration {
+		return window{}, fmt.Errorf("duration should be in (0, %v]", maxWindowDuration)
+	}
+	s, err := parseSchedule(strings.Join(fields[:5], " "))
+	if err != nil {
+		return window{}, err
+	}
+	return window{schedule: s, duration: duration}, nil
+}
+
+// contains checks whether the time is in the window, by finding a start time in (t - duration, t].
+func (w window) contains(t time.Time) bool {
+	t = t.Truncate(time.Minute)
+	start, found := w.schedule.latest(t, t.Add(-w.duration))
+	return found && t.Sub(start) < w.duration
+}
+
+// schedule is a standard cron expression with five fields: minute, hour, day of month, month and day of week.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	// When both day of month and day of week are restricted, matching either of them is enough.
+	domStar, dowStar bool
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	// Sunday can be 0 or 7, the 7 is folded into 0 after parsing.
+	dowBounds = bounds{0, 7}
+)
+
+func parseSchedule(expr string) (*schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expect 5 fields in cron expression %q", expr)
+	}
+
+	s := &schedule{domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// parseField parses a cron field like `*`, `*/2`, `1-5`, `1,3,5`, `0-30/10` or `5/10` into a bitset, a single value
+// with the step starts at the value and ends at the max.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		rangeAndStep := strings.SplitN(part, "/", 2)
+		if len(rangeAndStep) == 2 {
+			var err error
+			if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			part = rangeAndStep[0]
+		}
+
+		start, end := b.min, b.max
+		if part != "*" {
+			startAndEnd := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(startAndEnd[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			end = start
+			if len(rangeAndStep) == 2 {
+				end = b.max
+			}
+			if len(startAndEnd) == 2 {
+				if end, err = strconv.Atoi(startAndEnd[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			}
+		}
+		if start < b.min || end > b.max || start > end {
+			return 0, fmt.Errorf("%q is out of range [%d, %d]", part, b.min, b.max)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func (s *schedule) match(t time.Time) bool {
+	return s.minute&(1<<uint(t.Minute())) != 0 && s.hour&(1<<uint(t.Hour())) != 0 && s.matchDay(t)
+}
+
+func (s *schedule) matchDay(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// latest returns the latest time matched by the schedule in [earliest, t], it walks back by the days, and picks the
+// latest hour and minute of the first matched day directly.
+func (s *schedule) latest(t, earliest time.Time) (time.Time, bool) {
+	firstDay := time.Date(earliest.Year(), earliest.Month(), earliest.Day(), 0, 0, 0, 0, earliest.Location())
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for today := true; !day.Before(firstDay); day, today = day.AddDate(0, 0, -1), false {
+		if !s.matchDay(day) {
+			continue
+		}
+		maxHour := 23
+		if today {
+			maxHour = t.Hour()
+		}
+		for hour := highestBit(s.hour, maxHour); hour >= 0; hour = highestBit(s.hour, hour-1) {
+			maxMinute := 59
+			if today && hour == t.Hour() {
+				maxMinute = t.Minute()
+			}
+			minute := highestBit(s.minute, maxMinute)
+			if minute < 0 {
+				continue
+			}
+			start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+			// The earlier matches are even further.
+			return start, !start.Before(earliest)
+		}
+	}
+	return time.Time{}, false
+}
+
+// highestBit returns the highest bit set in the bitset which is not greater than max, -1 if none.
+func highestBit(bitset uint64, max int) int {
+	if max < 0 {
+		return -1
+	}
+	if max < 63 {
+		bitset &= 1<<uint(max+1) - 1
+	}
+	return bits.Len64(bitset) - 1
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatchwindow
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInWindows(t *testing.T) {
+	// 2024-12-02 is a Monday.
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, 12, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		Name     string
+		windows  string
+		now      time.Time
+		expectIn bool
+	}{
+		{Name: "Time range crosses midnight, in the evening", windows: "20:00-06:00", now: monday(21, 30), expectIn: true},
+		{Name: "Time range crosses midnight, in the morning", windows: "20:00-06:00", now: monday(5, 59), expectIn: true},
+		{Name: "Time range crosses midnight, outside", windows: "20:00-06:00", now: monday(6, 0), expectIn: false},
+		{Name: "Cron on weekdays", windows: "0 9 * * 1-5 8h", now: monday(16, 59), expectIn: true},
+		{Name: "Cron on weekends", windows: "0 9 * * 0,6 8h", now: monday(10, 0), expectIn: false},
+		{Name: "Cron crosses the day", windows: "30 22 * * 0 4h", now: monday(2, 29), expectIn: true},
+		{Name: "Multiple windows", windows: "01:00-02:00; 12:00-13:00", now: monday(12, 15), expectIn: true},
+		{Name: "Step in cron", windows: "*/30 * * * * 10m", now: monday(12, 35), expectIn: true},
+		{Name: "Step in cron, outside", windows: "*/30 * * * * 10m", now: monday(12, 45), expectIn: false},
+		{Name: "Sunday as 7 in the range", windows: "0 22 * * 1-7 4h", now: monday(1, 0), expectIn: true},
+		{Name: "Sunday as 7 in the range, outside", windows: "0 22 * * 1-7 4h", now: monday(21, 0), expectIn: false},
+		{Name: "Step of 7 on the days of week", windows: "0 12 * * */7 1h", now: monday(12, 30), expectIn: false},
+		{Name: "Single value with the step", windows: "5/20 * * * * 5m", now: monday(12, 47), expectIn: true},
+		{Name: "Single value with the step, outside", windows: "5/20 * * * * 5m", now: monday(12, 50), expectIn: false},
+		{Name: "Window lasts for days", windows: "0 8 * * 6 72h", now: monday(7, 59), expectIn: true},
+		{Name: "Window lasts for days, outside", windows: "0 8 * * 5 72h", now: monday(8, 0), expectIn: false},
+	}
+
+	for _, tc := range testCases {
+		windows, err := parseWindows(tc.windows)
+		if err != nil {
+			t.Errorf("Test case %s failed, err: %v", tc.Name, err)
+			continue
+		}
+		if in := inWindows(windows, tc.now); in != tc.expectIn {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, in, tc.expectIn)
+		}
+	}
+}
+
+func TestParseWindowsInvalid(t *testing.T) {
+	for _, value := range []string{"", "25:00-06:00", "0 20 * * *", "0 24 * * * 1h", "0 20 * * * 8d", "a b c d e 1h", "0 20 * * 8 1h", "0 20 * * 1/0 1h"} {
+		if _, err := parseWindows(value); err == nil {
+			t.Errorf("Test case %q failed, expect an error", value)
+		}
+	}
+}
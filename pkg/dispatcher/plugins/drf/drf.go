@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drf
+
+import (
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const PluginName = "drf"
+
+// drfPlugin orders the ResourceBindings by the Dominant Resource Fairness of their namespaces, the tenant with
+// the lower dominant share is dispatched first. It's an alternative to the priority plugin, the one configured
+// first takes precedence when both of them are enabled.
+type drfPlugin struct {
+	// total is the resources the shares are computed against.
+	total corev1.ResourceList
+	// allocated[namespace] is the resources of the dispatched but not completed ResourceBindings of the namespace.
+	allocated map[string]corev1.ResourceList
+}
+
+func New(_ framework.Arguments) framework.Plugin {
+	return &drfPlugin{}
+}
+
+func (dp *drfPlugin) Name() string {
+	return PluginName
+}
+
+func (dp *drfPlugin) OnSessionOpen(ssn *framework.Session) {
+	dp.allocated = map[string]corev1.ResourceList{}
+	allocatedTotal := corev1.ResourceList{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() && !rbi.IsCompleted() {
+			dp.allocate(rbi)
+			addResources(allocatedTotal, rbi.MinResources)
+		}
+	}
+
+	// The shares are computed against the total resources of the clusters, the resources which no cluster reports
+	// are computed against the allocated ones of all the namespaces.
+	dp.total = ssn.Snapshot.TotalResources()
+	for name, quantity := range allocatedTotal {
+		if _, found := dp.total[name]; !found {
+			dp.total[name] = quantity
+		}
+	}
+
+	// Register the ResourceBinding order func
+	ssn.AddResourceBindingInfoOrderFn(dp.Name(), dp.resourceBindingInfoOrderFunc)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			dp.allocate(event.ResourceBindingInfo)
+		},
+		EvictFunc: func(event *framework.Event) {
+			dp.deallocate(event.ResourceBindingInfo)
+		},
+	})
+}
+
+func (dp *drfPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (dp *drfPlugin) allocate(rbi *api.ResourceBindingInfo) {
+	namespace := rbi.ResourceBinding.Namespace
+	if dp.allocated[namespace] == nil {
+		dp.allocated[namespace] = corev1.ResourceList{}
+	}
+	addResources(dp.allocated[namespace], rbi.MinResources)
+}
+
+func (dp *drfPlugin) deallocate(rbi *api.ResourceBindingInfo) {
+	allocated := dp.allocated[rbi.ResourceBinding.Namespace]
+	for name, quantity := range rbi.MinResources {
+		value := allocated[name]
+		value.Sub(quantity)
+		allocated[name] = value
+	}
+}
+
+// dominantShare is the max share of the resources allocated by the namespace.
+func (dp *drfPlugin) dominantShare(namespace string) float64 {
+	share := 0.0
+	for name, quantity := range dp.allocated[namespace] {
+		total, found := dp.total[name]
+		if !found || total.IsZero() {
+			continue
+		}
+		share = math.Max(share, quantity.AsApproximateFloat64()/total.AsApproximateFloat64())
+	}
+	return share
+}
+
+func (dp *drfPlugin) resourceBindingInfoOrderFunc(l, r interface{}) int {
+	lv := l.(*api.ResourceBindingInfo)
+	rv := r.(*api.ResourceBindingInfo)
+	if lv.ResourceBinding.Namespace == rv.ResourceBinding.Namespace {
+		return 0
+	}
+
+	lshare := dp.dominantShare(lv.ResourceBinding.Namespace)
+	rshare := dp.dominantShare(rv.ResourceBinding.Namespace)
+	klog.V(4).Infof("DRF plugin ResourceBindingOrder: <%s/%s> namespace share %f, <%s/%s> namespace share %f",
+		lv.ResourceBinding.Namespace, lv.ResourceBinding.Name, lshare,
+		rv.ResourceBinding.Namespace, rv.ResourceBinding.Name, rshare)
+
+	if lshare == rshare {
+		return 0
+	}
+	if lshare < rshare {
+		return -1
+	}
+	return 1
+}
+
+func addResources(to, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		value := to[name]
+		value.Add(quantity)
+		to[name] = value
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drf
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func resources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func newRBI(namespace string, status api.DispatchStatus, minResources corev1.ResourceList) *api.ResourceBindingInfo {
+	return &api.ResourceBindingInfo{
+		ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "rb"}},
+		DispatchStatus:  status,
+		MinResources:    minResources,
+	}
+}
+
+func TestResourceBindingInfoOrder(t *testing.T) {
+	cluster := &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member1"},
+		Status: clusterv1alpha1.ClusterStatus{
+			Conditions:      []metav1.Condition{{Type: clusterv1alpha1.ClusterConditionReady, Status: metav1.ConditionTrue}},
+			ResourceSummary: &clusterv1alpha1.ResourceSummary{Allocatable: resources("10", "100Gi")},
+		},
+	}
+
+	testCases := []struct {
+		Name        string
+		dispatched  []*api.ResourceBindingInfo
+		l, r        *api.ResourceBindingInfo
+		expectOrder int
+	}{
+		{
+			Name: "Same dominant share",
+			l:    newRBI("ns1", api.Pending, resources("1", "1Gi")),
+			r:    newRBI("ns2", api.Pending, resources("1", "1Gi")),
+		},
+		{
+			Name: "Lower dominant share first",
+			dispatched: []*api.ResourceBindingInfo{
+				newRBI("ns1", api.Dispatched, resources("2", "10Gi")),
+				newRBI("ns2", api.Dispatched, resources("4", "1Gi")),
+			},
+			l:           newRBI("ns1", api.Pending, resources("1", "1Gi")),
+			r:           newRBI("ns2", api.Pending, resources("1", "1Gi")),
+			expectOrder: -1,
+		},
+		{
+			Name: "Dominant share of the different resources",
+			dispatched: []*api.ResourceBindingInfo{
+				newRBI("ns1", api.Dispatched, resources("1", "50Gi")),
+				newRBI("ns2", api.Dispatched, resources("4", "1Gi")),
+			},
+			l:           newRBI("ns1", api.Pending, resources("1", "1Gi")),
+			r:           newRBI("ns2", api.Pending, resources("1", "1Gi")),
+			expectOrder: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		snapshot := &cache.DispatcherCacheSnapshot{
+			Clusters: map[string]*clusterv1alpha1.Cluster{cluster.Name: cluster},
+		}
+		dp := &drfPlugin{allocated: map[string]corev1.ResourceList{}, total: snapshot.TotalResources()}
+		for _, rbi := range tc.dispatched {
+			dp.allocate(rbi)
+		}
+
+		if result := dp.resourceBindingInfoOrderFunc(tc.l, tc.r); result != tc.expectOrder {
+			t.Errorf("Test case %s failed, got: %d expect: %d", tc.Name, result, tc.expectOrder)
+		}
+	}
+}
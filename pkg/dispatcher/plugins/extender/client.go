@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// filterMethod is the method of the PolicyExtender service defined in extender.proto.
+const filterMethod = "/volcano.global.dispatcher.extender.v1.PolicyExtender/Filter"
+
+// candidate is a suspended ResourceBinding waiting to be dispatched in the round.
+type candidate struct {
+	Namespace         string              `json:"namespace"`
+	Name              string              `json:"name"`
+	APIVersion        string              `json:"apiVersion"`
+	Kind              string              `json:"kind"`
+	Queue             string              `json:"queue"`
+	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	Priority          int32               `json:"priority"`
+	MinResources      corev1.ResourceList `json:"minResources,omitempty"`
+	Labels            map[string]string   `json:"labels,omitempty"`
+}
+
+type filterRequest struct {
+	Candidates []candidate `json:"candidates"`
+}
+
+// filterResponse is the decision of the extender, both are keyed by `<namespace>/<name>` of the candidates.
+type filterResponse struct {
+	// Vetoes are the messages of the candidates which must not be dispatched in the round.
+	Vetoes map[string]string `json:"vetoes,omitempty"`
+	// Scores reorder the candidates in their queues, the higher score is dispatched first.
+	Scores map[string]float64 `json:"scores,omitempty"`
+}
+
+var (
+	connectionsMutex sync.Mutex
+	// connections are shared by the sessions, the plugin is created in each session.
+	connections = map[string]*grpc.ClientConn{}
+
+	// dial creates the connection to the extender, it's replaced in the tests.
+	dial = func(address string, useTLS bool) (*grpc.ClientConn, error) {
+		creds := insecure.NewCredentials()
+		if useTLS {
+			creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+		return grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	}
+)
+
+func connectionOf(address string, useTLS bool) (*grpc.ClientConn, error) {
+	connectionsMutex.Lock()
+	defer connectionsMutex.Unlock()
+	if conn, found := connections[address]; found {
+		return conn, nil
+	}
+	conn, err := dial(address, useTLS)
+	if err != nil {
+		return nil, err
+	}
+	connections[address] = conn
+	return conn, nil
+}
+
+// filter calls the extender with the candidates, the messages are google.protobuf.Struct converted from the json
+// of the request and the response, so the extender can be implemented in any language without the generated code.
+func filter(ctx context.Context, conn *grpc.ClientConn, request *filterRequest) (*filterResponse, error) {
+	in, err := toStruct(request)
+	if err != nil {
+		return nil, err
+	}
+	out := &structpb.Struct{}
+	if err = conn.Invoke(ctx, filterMethod, in, out); err != nil {
+		return nil, err
+	}
+
+	data, err := out.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	response := &filterResponse{}
+	if err = json.Unmarshal(data, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func toStruct(object interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err = s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "extender"
+
+	// ExtenderVetoedReason is the reason when the extender vetoes the dispatching of the workload.
+	ExtenderVetoedReason = "ExtenderVetoed"
+	// ExtenderUnavailableReason is the reason when the extender can't be called and it's not ignorable.
+	ExtenderUnavailableReason = "ExtenderUnavailable"
+
+	// addressKey is the argument of the gRPC address of the extender, like `extender.default.svc:9090`.
+	addressKey = "extender.address"
+	// timeoutKey is the argument of the timeout of calling the extender, like `3s`.
+	timeoutKey = "extender.timeout"
+	// ignorableKey is the argument whether the workloads are dispatched without the extender when it fails.
+	ignorableKey = "extender.ignorable"
+	// tlsKey is the argument whether the extender is connected by TLS.
+	tlsKey = "extender.tls"
+
+	defaultTimeout = 3 * time.Second
+)
+
+// extenderPlugin calls the external gRPC policy extender once in each round with all the candidates,
+// so the organizations can plug in their own admission logic without forking the dispatcher.
+// The extender can veto the candidates and reorder them in their queues by the scores.
+type extenderPlugin struct {
+	address   string
+	timeout   time.Duration
+	ignorable bool
+	useTLS    bool
+
+	// response is the decision of the extender in this session.
+	response *filterResponse
+	// err is the error of calling the extender in this session, the candidates are blocked by it if not ignorable.
+	err error
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	ep := &extenderPlugin{timeout: defaultTimeout}
+	arguments.GetString(&ep.address, addressKey)
+	arguments.GetBool(&ep.ignorable, ignorableKey)
+	arguments.GetBool(&ep.useTLS, tlsKey)
+	timeout := ""
+	arguments.GetString(&timeout, timeoutKey)
+	if timeout != "" {
+		if duration, err := time.ParseDuration(timeout); err != nil || duration <= 0 {
+			klog.Errorf("Invalid argument %s %q, use the default timeout %v.", timeoutKey, timeout, defaultTimeout)
+		} else {
+			ep.timeout = duration
+		}
+	}
+	return ep
+}
+
+func (ep *extenderPlugin) Name() string {
+	return PluginName
+}
+
+func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
+	if ep.address == "" {
+		klog.Errorf("Extender plugin: argument %s is not set, skip calling the extender.", addressKey)
+		return
+	}
+
+	request := &filterRequest{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() || rbi.PodGroup == nil {
+			continue
+		}
+		rb := rbi.ResourceBinding
+		request.Candidates = append(request.Candidates, candidate{
+			Namespace:         rb.Namespace,
+			Name:              rb.Name,
+			APIVersion:        rb.Spec.Resource.APIVersion,
+			Kind:              rb.Spec.Resource.Kind,
+			Queue:             ssn.GetResourceBindingInfoQueue(rbi),
+			PriorityClassName: rbi.PriorityClassName,
+			Priority:          rbi.Priority,
+			MinResources:      rbi.MinResources,
+			Labels:            rb.Labels,
+		})
+	}
+	if len(request.Candidates) == 0 {
+		return
+	}
+
+	ep.response, ep.err = ep.call(request)
+	if ep.err != nil {
+		klog.Errorf("Extender plugin: failed to call the extender <%s> with %d candidates, ignorable: %v, err: %v",
+			ep.address, len(request.Candidates), ep.ignorable, ep.err)
+		if ep.ignorable {
+			ep.err = nil
+		}
+	}
+
+	ssn.AddDispatchableFn(ep.Name(), ep.dispatchableFn)
+	ssn.AddResourceBindingInfoOrderFn(ep.Name(), ep.resourceBindingInfoOrderFunc)
+}
+
+func (ep *extenderPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (ep *extenderPlugin) call(request *filterRequest) (*filterResponse, error) {
+	conn, err := connectionOf(ep.address, ep.useTLS)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ep.timeout)
+	defer cancel()
+	return filter(ctx, conn, request)
+}
+
+func keyOf(rbi *api.ResourceBindingInfo) string {
+	return rbi.ResourceBinding.Namespace + "/" + rbi.ResourceBinding.Name
+}
+
+func (ep *extenderPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	if ep.err != nil {
+		return &api.DispatchBlocker{
+			Reason:  ExtenderUnavailableReason,
+			Message: fmt.Sprintf("Failed to call the policy extender: %v.", ep.err),
+		}
+	}
+	if ep.response == nil {
+		return nil
+	}
+	if message, found := ep.response.Vetoes[keyOf(rbi)]; found {
+		klog.V(4).Infof("Extender plugin: ResourceBinding <%s> is vetoed by the extender: %s", keyOf(rbi), message)
+		if message == "" {
+			message = "The dispatching is vetoed by the policy extender."
+		}
+		return &api.DispatchBlocker{Reason: ExtenderVetoedReason, Message: message}
+	}
+	return nil
+}
+
+// resourceBindingInfoOrderFunc orders the candidates by the scores of the extender, the higher score first,
+// the ones without a score are ordered by the other plugins.
+func (ep *extenderPlugin) resourceBindingInfoOrderFunc(l, r interface{}) int {
+	if ep.response == nil {
+		return 0
+	}
+	lScore, lFound := ep.response.Scores[keyOf(l.(*api.ResourceBindingInfo))]
+	rScore, rFound := ep.response.Scores[keyOf(r.(*api.ResourceBindingInfo))]
+	if !lFound || !rFound || lScore == rScore {
+		return 0
+	}
+	if lScore > rScore {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// fakeExtender vetoes the candidates of the `blocked` namespace, and scores the others by their priorities.
+func fakeExtender(_ interface{}, ctx context.Context, decode func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := &structpb.Struct{}
+	if err := decode(in); err != nil {
+		return nil, err
+	}
+	vetoes, scores := map[string]interface{}{}, map[string]interface{}{}
+	for _, value := range in.AsMap()["candidates"].([]interface{}) {
+		c := value.(map[string]interface{})
+		key := c["namespace"].(string) + "/" + c["name"].(string)
+		if c["namespace"] == "blocked" {
+			vetoes[key] = "The namespace is blocked."
+			continue
+		}
+		scores[key] = c["priority"]
+	}
+	return structpb.NewStruct(map[string]interface{}{"vetoes": vetoes, "scores": scores})
+}
+
+func startFakeExtender(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "volcano.global.dispatcher.extender.v1.PolicyExtender",
+		HandlerType: (*interface{})(nil),
+		Methods:     []grpc.MethodDesc{{MethodName: "Filter", Handler: fakeExtender}},
+	}, struct{}{})
+	go func() { _ = server.Serve(listener) }()
+
+	dial = func(_ string, _ bool) (*grpc.ClientConn, error) {
+		return grpc.NewClient("passthrough:///bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }))
+	}
+	t.Cleanup(func() {
+		server.Stop()
+		connections = map[string]*grpc.ClientConn{}
+	})
+}
+
+func newRBI(namespace, name string, priority int32) *api.ResourceBindingInfo {
+	return &api.ResourceBindingInfo{
+		ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}},
+		Priority:        priority,
+	}
+}
+
+func TestExtender(t *testing.T) {
+	startFakeExtender(t)
+
+	ep := New(map[string]interface{}{addressKey: "bufnet"}).(*extenderPlugin)
+	low, high, blocked := newRBI("ns", "low", 1), newRBI("ns", "high", 10), newRBI("blocked", "rb", 100)
+	request := &filterRequest{}
+	for _, rbi := range []*api.ResourceBindingInfo{low, high, blocked} {
+		request.Candidates = append(request.Candidates, candidate{
+			Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name, Priority: rbi.Priority})
+	}
+	if ep.response, ep.err = ep.call(request); ep.err != nil {
+		t.Fatalf("Failed to call the extender: %v", ep.err)
+	}
+
+	testCases := []struct {
+		Name         string
+		rbi          *api.ResourceBindingInfo
+		expectReason string
+	}{
+		{Name: "Candidate is allowed", rbi: low},
+		{Name: "Candidate is vetoed", rbi: blocked, expectReason: ExtenderVetoedReason},
+	}
+	for _, tc := range testCases {
+		reason := ""
+		if blocker := ep.dispatchableFn(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+	if result := ep.resourceBindingInfoOrderFunc(high, low); result != -1 {
+		t.Errorf("Test case higher score first failed, got: %d expect: -1", result)
+	}
+	if result := ep.resourceBindingInfoOrderFunc(high, newRBI("ns", "unknown", 0)); result != 0 {
+		t.Errorf("Test case without score failed, got: %d expect: 0", result)
+	}
+
+	// The extender fails, the candidates are blocked unless it's ignorable.
+	ep.response, ep.err = nil, errors.New("unavailable")
+	if blocker := ep.dispatchableFn(low); blocker == nil || blocker.Reason != ExtenderUnavailableReason {
+		t.Errorf("Test case extender unavailable failed, got: %v expect: %s", blocker, ExtenderUnavailableReason)
+	}
+}
@@ -18,12 +18,40 @@ package plugins
 
 import (
 	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/admissioncheck"
 	"volcano.sh/volcano-global/pkg/dispatcher/plugins/capacity"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/clusterbudget"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/cost"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/deadline"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/dependency"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/dispatchwindow"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/drf"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/extender"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/fastpath"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/inflight"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/jobarray"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/nsfair"
 	"volcano.sh/volcano-global/pkg/dispatcher/plugins/priority"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/quota"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/tenant"
 )
 
 // Register the plugins to plugin manager.
 func init() {
 	framework.PluginManagerInstance.RegisterPluginBuilder(priority.PluginName, priority.New)
 	framework.PluginManagerInstance.RegisterPluginBuilder(capacity.PluginName, capacity.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(quota.PluginName, quota.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(dispatchwindow.PluginName, dispatchwindow.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(inflight.PluginName, inflight.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(drf.PluginName, drf.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(dependency.PluginName, dependency.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(deadline.PluginName, deadline.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(tenant.PluginName, tenant.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(extender.PluginName, extender.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(nsfair.PluginName, nsfair.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(clusterbudget.PluginName, clusterbudget.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(admissioncheck.PluginName, admissioncheck.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(cost.PluginName, cost.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(jobarray.PluginName, jobarray.New)
+	framework.PluginManagerInstance.RegisterPluginBuilder(fastpath.PluginName, fastpath.New)
 }
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fastpath
+
+import (
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "fastpath"
+
+	// smallKey is the argument of the thresholds of the small workloads, like `{cpu: "1", memory: 2Gi}`.
+	smallKey = "fastpath.small"
+	// largeKey is the argument of the thresholds of the large workloads, like `{cpu: "16", memory: 64Gi}`.
+	largeKey = "fastpath.large"
+	// shareKey is the argument of the share of the capability of each queue the small workloads can take by the
+	// fast path, zero or negative disables the fast path.
+	shareKey = "fastpath.share"
+
+	defaultShare = 0.2
+)
+
+// fastPathPlugin classifies the workloads as small, medium or large by their requested resources, and lets the small
+// ones of each queue go ahead of the others, so the interactive jobs don't wait behind the batch backlog. The small
+// ones only go ahead while the dispatched small ones of the queue use less than the share of its capability, so
+// the batch work is never starved. Configured after the priority plugin, the small ones only go ahead of the ones
+// with the same priority.
+type fastPathPlugin struct {
+	ssn        *framework.Session
+	thresholds api.SizeThresholds
+	share      float64
+	// total is the resources of the clusters, the capability of the queues without one.
+	total corev1.ResourceList
+	// smallAllocated[queue] is the resources of the dispatched but not completed small workloads of the queue.
+	smallAllocated map[string]corev1.ResourceList
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	fp := &fastPathPlugin{
+		thresholds: api.SizeThresholds{
+			Small: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			Large: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("16"), corev1.ResourceMemory: resource.MustParse("64Gi")},
+		},
+		share: defaultShare,
+	}
+	for key, thresholds := range map[string]*corev1.ResourceList{smallKey: &fp.thresholds.Small, largeKey: &fp.thresholds.Large} {
+		argv, found := arguments[key]
+		if !found {
+			continue
+		}
+		parsed := corev1.ResourceList{}
+		if err := parseArgument(argv, &parsed); err != nil {
+			klog.Errorf("Failed to parse the argument %s, use the default thresholds, err: %v", key, err)
+			continue
+		}
+		*thresholds = parsed
+	}
+	arguments.GetFloat64(&fp.share, shareKey)
+	return fp
+}
+
+// parseArgument converts the structured argument decoded from the configuration into the object.
+func parseArgument(argv interface{}, object interface{}) error {
+	data, err := yaml.Marshal(argv)
+	if err != nil {
+		return err
+	}
+	return k8syaml.Unmarshal(data, object)
+}
+
+func (fp *fastPathPlugin) Name() string {
+	return PluginName
+}
+
+func (fp *fastPathPlugin) OnSessionOpen(ssn *framework.Session) {
+	fp.ssn = ssn
+	fp.total = ssn.Snapshot.TotalResources()
+	fp.smallAllocated = map[string]corev1.ResourceList{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() && !rbi.IsCompleted() {
+			fp.allocate(rbi)
+		}
+	}
+
+	ssn.AddResourceBindingInfoOrderFn(fp.Name(), fp.resourceBindingInfoOrderFn)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			fp.allocate(event.ResourceBindingInfo)
+		},
+		EvictFunc: func(event *framework.Event) {
+			fp.deallocate(event.ResourceBindingInfo)
+		},
+	})
+}
+
+func (fp *fastPathPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (fp *fastPathPlugin) allocate(rbi *api.ResourceBindingInfo) {
+	if fp.thresholds.Classify(rbi.MinResources) != api.SizeSmall {
+		return
+	}
+	queueName := fp.ssn.GetResourceBindingInfoQueue(rbi)
+	if fp.smallAllocated[queueName] == nil {
+		fp.smallAllocated[queueName] = corev1.ResourceList{}
+	}
+	for name, quantity := range rbi.MinResources {
+		value := fp.smallAllocated[queueName][name]
+		value.Add(quantity)
+		fp.smallAllocated[queueName][name] = value
+	}
+}
+
+func (fp *fastPathPlugin) deallocate(rbi *api.ResourceBindingInfo) {
+	if fp.thresholds.Classify(rbi.MinResources) != api.SizeSmall {
+		return
+	}
+	allocated := fp.smallAllocated[fp.ssn.GetResourceBindingInfoQueue(rbi)]
+	for name, quantity := range rbi.MinResources {
+		value := allocated[name]
+		value.Sub(quantity)
+		allocated[name] = value
+	}
+}
+
+// isFastPathOpen checks whether the dispatched small workloads of the queue use less than the share of its
+// capability of each resource, the total resources of the clusters are the capability of the queue without one.
+func (fp *fastPathPlugin) isFastPathOpen(queueName string) bool {
+	if fp.share <= 0 {
+		return false
+	}
+	capability := fp.total
+	if queue, found := fp.ssn.Snapshot.QueueInfos[queueName]; found && queue.Queue != nil && len(queue.Queue.Spec.Capability) != 0 {
+		capability = queue.Queue.Spec.Capability
+	}
+	for name, quantity := range fp.smallAllocated[queueName] {
+		limit, found := capability[name]
+		if !found {
+			continue
+		}
+		if quantity.AsApproximateFloat64() >= limit.AsApproximateFloat64()*fp.share {
+			return false
+		}
+	}
+	return true
+}
+
+// resourceBindingInfoOrderFn orders the small workloads ahead of the others of the same queue while the fast path
+// of the queue is open, the others are left to the other plugins.
+func (fp *fastPathPlugin) resourceBindingInfoOrderFn(l, r interface{}) int {
+	lv := l.(*api.ResourceBindingInfo)
+	rv := r.(*api.ResourceBindingInfo)
+
+	lSmall := fp.thresholds.Classify(lv.MinResources) == api.SizeSmall
+	rSmall := fp.thresholds.Classify(rv.MinResources) == api.SizeSmall
+	if lSmall == rSmall {
+		return 0
+	}
+	queueName := fp.ssn.GetResourceBindingInfoQueue(lv)
+	if queueName != fp.ssn.GetResourceBindingInfoQueue(rv) || !fp.isFastPathOpen(queueName) {
+		return 0
+	}
+
+	klog.V(5).Infof("FastPath plugin ResourceBindingOrder: <%s/%s> small %v, <%s/%s> small %v, fast path of queue <%s> is open.",
+		lv.ResourceBinding.Namespace, lv.ResourceBinding.Name, lSmall, rv.ResourceBinding.Namespace, rv.ResourceBinding.Name, rSmall, queueName)
+	if lSmall {
+		return -1
+	}
+	return 1
+}
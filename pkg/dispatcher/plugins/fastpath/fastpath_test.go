@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fastpath
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func cpu(value string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+}
+
+func newRBI(name, queue, cpuRequest string, status api.DispatchStatus) *api.ResourceBindingInfo {
+	return &api.ResourceBindingInfo{
+		ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}},
+		Queue:           queue,
+		MinResources:    cpu(cpuRequest),
+		DispatchStatus:  status,
+	}
+}
+
+func TestClassify(t *testing.T) {
+	fp := New(framework.Arguments{}).(*fastPathPlugin)
+
+	testCases := []struct {
+		Name      string
+		resources corev1.ResourceList
+		expect    api.SizeClass
+	}{
+		{
+			Name:      "Small",
+			resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			expect:    api.SizeSmall,
+		},
+		{
+			Name:      "Medium by the memory",
+			resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+			expect:    api.SizeMedium,
+		},
+		{
+			Name:      "Large by the cpu",
+			resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("16"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			expect:    api.SizeLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := fp.thresholds.Classify(tc.resources); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, got, tc.expect)
+		}
+	}
+}
+
+func TestResourceBindingInfoOrderFn(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		dispatched []*api.ResourceBindingInfo
+		l, r       *api.ResourceBindingInfo
+		expect     int
+	}{
+		{
+			Name:   "Small goes ahead",
+			l:      newRBI("small", "q1", "1", api.Pending),
+			r:      newRBI("large", "q1", "32", api.Pending),
+			expect: -1,
+		},
+		{
+			Name:   "Large goes behind",
+			l:      newRBI("large", "q1", "32", api.Pending),
+			r:      newRBI("small", "q1", "1", api.Pending),
+			expect: 1,
+		},
+		{
+			Name:   "Both small",
+			l:      newRBI("small1", "q1", "1", api.Pending),
+			r:      newRBI("small2", "q1", "1", api.Pending),
+			expect: 0,
+		},
+		{
+			Name:       "Fast path is closed by the share",
+			dispatched: []*api.ResourceBindingInfo{newRBI("d1", "q1", "1", api.Dispatched), newRBI("d2", "q1", "1", api.Dispatched)},
+			l:          newRBI("small", "q1", "1", api.Pending),
+			r:          newRBI("large", "q1", "32", api.Pending),
+			expect:     0,
+		},
+		{
+			Name:       "Fast path of the other queue is open",
+			dispatched: []*api.ResourceBindingInfo{newRBI("d1", "q1", "1", api.Dispatched), newRBI("d2", "q1", "1", api.Dispatched)},
+			l:          newRBI("small", "q2", "1", api.Pending),
+			r:          newRBI("large", "q2", "32", api.Pending),
+			expect:     -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		newQueue := func(name string) *schedulingapi.QueueInfo {
+			return &schedulingapi.QueueInfo{Name: name, Queue: &scheduling.Queue{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       scheduling.QueueSpec{Capability: cpu("10")},
+			}}
+		}
+		ssn := &framework.Session{Snapshot: &cache.DispatcherCacheSnapshot{
+			QueueInfos: map[string]*schedulingapi.QueueInfo{"q1": newQueue("q1"), "q2": newQueue("q2")},
+		}}
+		fp := New(framework.Arguments{}).(*fastPathPlugin)
+		fp.ssn = ssn
+		fp.smallAllocated = map[string]corev1.ResourceList{}
+		for _, rbi := range tc.dispatched {
+			fp.allocate(rbi)
+		}
+
+		if got := fp.resourceBindingInfoOrderFn(tc.l, tc.r); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %d expect: %d", tc.Name, got, tc.expect)
+		}
+	}
+}
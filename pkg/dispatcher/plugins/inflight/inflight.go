@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflight
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "inflight"
+
+	// MaxInFlightExceededReason is the reason when the queue has too many workloads in flight.
+	MaxInFlightExceededReason = "MaxInFlightExceeded"
+
+	// PriorityClassMaxInFlightExceededReason is the reason when the PriorityClass has too many workloads in flight.
+	PriorityClassMaxInFlightExceededReason = "PriorityClassMaxInFlightExceeded"
+
+	// DispatchRateLimitedReason is the reason when the queue has dispatched too many workloads in the period.
+	DispatchRateLimitedReason = "DispatchRateLimited"
+
+	// maxInFlightKey is the argument of the default limit of the queues, zero or negative means no limit.
+	maxInFlightKey = "inflight.maxInFlight"
+)
+
+// inFlightPlugin limits the workloads which are dispatched but not running yet in each queue,
+// to avoid flooding the member clusters with the pending pods which then fight for resources.
+// The workloads of each PriorityClass annotated with the limit are limited across the queues as well,
+// to protect the infrastructure shared by them. The queues annotated with the dispatch rate limit are limited by
+// the workloads dispatched in the period as well, so a burst of them is spread over the rounds.
+type inFlightPlugin struct {
+	ssn         *framework.Session
+	maxInFlight int
+	// inFlight[queue] is the number of the workloads dispatched but not running in the queue.
+	inFlight map[string]int
+	// priorityClassInFlight[priorityClass] is the number of the workloads dispatched but not running of the PriorityClass.
+	priorityClassInFlight map[string]int
+	// unSuspendTimes[queue] are the times when the workloads of the queue were unsuspended before the session.
+	unSuspendTimes map[string][]time.Time
+	// dispatched[queue] is the number of the workloads of the queue dispatched in the session.
+	dispatched map[string]int
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	ip := &inFlightPlugin{}
+	arguments.GetInt(&ip.maxInFlight, maxInFlightKey)
+	return ip
+}
+
+func (ip *inFlightPlugin) Name() string {
+	return PluginName
+}
+
+func (ip *inFlightPlugin) OnSessionOpen(ssn *framework.Session) {
+	ip.ssn = ssn
+	ip.inFlight = map[string]int{}
+	ip.priorityClassInFlight = map[string]int{}
+	ip.unSuspendTimes = map[string][]time.Time{}
+	ip.dispatched = map[string]int{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if isInFlight(rbi) {
+			ip.inFlight[ssn.GetResourceBindingInfoQueue(rbi)]++
+			ip.priorityClassInFlight[rbi.PriorityClassName]++
+		}
+		if !rbi.UnSuspendTime.IsZero() {
+			queueName := ssn.GetResourceBindingInfoQueue(rbi)
+			ip.unSuspendTimes[queueName] = append(ip.unSuspendTimes[queueName], rbi.UnSuspendTime)
+		}
+	}
+
+	ssn.AddDispatchableFn(ip.Name(), ip.dispatchableFn)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			ip.inFlight[ssn.GetResourceBindingInfoQueue(event.ResourceBindingInfo)]++
+			ip.priorityClassInFlight[event.ResourceBindingInfo.PriorityClassName]++
+			ip.dispatched[ssn.GetResourceBindingInfoQueue(event.ResourceBindingInfo)]++
+		},
+		UnreserveFunc: func(event *framework.Event) {
+			ip.inFlight[ssn.GetResourceBindingInfoQueue(event.ResourceBindingInfo)]--
+			ip.priorityClassInFlight[event.ResourceBindingInfo.PriorityClassName]--
+			ip.dispatched[ssn.GetResourceBindingInfoQueue(event.ResourceBindingInfo)]--
+		},
+	})
+}
+
+func (ip *inFlightPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (ip *inFlightPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	if blocker := ip.priorityClassDispatchable(rbi); blocker != nil {
+		return blocker
+	}
+
+	queueName := ip.ssn.GetResourceBindingInfoQueue(rbi)
+	if blocker := ip.rateDispatchable(queueName, time.Now()); blocker != nil {
+		return blocker
+	}
+	limit := ip.queueMaxInFlight(queueName)
+	if limit <= 0 || ip.inFlight[queueName] < limit {
+		return nil
+	}
+
+	klog.V(4).Infof("InFlight plugin: Queue <%s> has %d workloads in flight, limit %d, ResourceBinding <%s/%s> should wait.",
+		queueName, ip.inFlight[queueName], limit, rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name)
+	return &api.DispatchBlocker{
+		Reason:  MaxInFlightExceededReason,
+		Message: fmt.Sprintf("Queue %s has %d workloads dispatched but not running, limited: %d", queueName, ip.inFlight[queueName], limit),
+	}
+}
+
+// priorityClassDispatchable blocks the workload when its PriorityClass has too many workloads in flight.
+func (ip *inFlightPlugin) priorityClassDispatchable(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	name := rbi.PriorityClassName
+	limit := ip.priorityClassMaxInFlight(name)
+	if limit <= 0 || ip.priorityClassInFlight[name] < limit {
+		return nil
+	}
+
+	klog.V(4).Infof("InFlight plugin: PriorityClass <%s> has %d workloads in flight, limit %d, ResourceBinding <%s/%s> should wait.",
+		name, ip.priorityClassInFlight[name], limit, rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name)
+	return &api.DispatchBlocker{
+		Reason: PriorityClassMaxInFlightExceededReason,
+		Message: fmt.Sprintf("PriorityClass %s has %d workloads dispatched but not running, limited: %d",
+			name, ip.priorityClassInFlight[name], limit),
+	}
+}
+
+// rateDispatchable blocks the workloads of the queue when it has dispatched as many workloads as its dispatch rate
+// limit in the period, counting the ones dispatched in the session.
+func (ip *inFlightPlugin) rateDispatchable(queueName string, now time.Time) *api.DispatchBlocker {
+	queue, found := ip.ssn.Snapshot.QueueInfos[queueName]
+	if !found || queue.Queue == nil || queue.Queue.Annotations[api.DispatchRateLimitAnnotationKey] == "" {
+		return nil
+	}
+	value := queue.Queue.Annotations[api.DispatchRateLimitAnnotationKey]
+	limit, period, err := parseDispatchRateLimit(value)
+	if err != nil {
+		klog.Errorf("Failed to parse the annotation %s of Queue <%s>, no limit, err: %v", api.DispatchRateLimitAnnotationKey, queueName, err)
+		return nil
+	}
+
+	dispatched := ip.dispatched[queueName]
+	for _, unSuspendTime := range ip.unSuspendTimes[queueName] {
+		if now.Sub(unSuspendTime) < period {
+			dispatched++
+		}
+	}
+	if dispatched < limit {
+		return nil
+	}
+	klog.V(4).Infof("InFlight plugin: Queue <%s> dispatched %d workloads in the last %s, limit %d, the rest should wait.",
+		queueName, dispatched, period, limit)
+	return &api.DispatchBlocker{
+		Reason:  DispatchRateLimitedReason,
+		Message: fmt.Sprintf("Queue %s dispatched %d workloads in the last %s, limited: %s", queueName, dispatched, period, value),
+	}
+}
+
+// parseDispatchRateLimit parses the dispatch rate limit like `10/1m` into the number of the workloads and the period.
+func parseDispatchRateLimit(value string) (int, time.Duration, error) {
+	count, duration, found := strings.Cut(value, "/")
+	if !found {
+		return 0, 0, fmt.Errorf("expect <count>/<period>, got %q", value)
+	}
+	limit, err := strconv.Atoi(count)
+	if err != nil || limit <= 0 {
+		return 0, 0, fmt.Errorf("invalid count %q", count)
+	}
+	period, err := time.ParseDuration(duration)
+	if err != nil || period <= 0 {
+		return 0, 0, fmt.Errorf("invalid period %q", duration)
+	}
+	return limit, period, nil
+}
+
+// priorityClassMaxInFlight returns the limit of the PriorityClass by its annotation, zero means no limit.
+func (ip *inFlightPlugin) priorityClassMaxInFlight(name string) int {
+	priorityClass, found := ip.ssn.Snapshot.PriorityClasses[name]
+	if name == "" || !found {
+		return 0
+	}
+	value, found := priorityClass.Annotations[api.MaxInFlightAnnotationKey]
+	if !found {
+		return 0
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		klog.Errorf("Failed to parse the annotation %s of PriorityClass <%s>, no limit, err: %v", api.MaxInFlightAnnotationKey, name, err)
+		return 0
+	}
+	return limit
+}
+
+// queueMaxInFlight returns the limit of the queue, the annotation of the queue takes precedence.
+func (ip *inFlightPlugin) queueMaxInFlight(queueName string) int {
+	queue, found := ip.ssn.Snapshot.QueueInfos[queueName]
+	if !found || queue.Queue == nil {
+		return ip.maxInFlight
+	}
+	value, found := queue.Queue.Annotations[api.MaxInFlightAnnotationKey]
+	if !found {
+		return ip.maxInFlight
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		klog.Errorf("Failed to parse the annotation %s of Queue <%s>, use the default limit, err: %v", api.MaxInFlightAnnotationKey, queueName, err)
+		return ip.maxInFlight
+	}
+	return limit
+}
+
+// isInFlight checks whether the workload is dispatched but not running yet.
+func isInFlight(rbi *api.ResourceBindingInfo) bool {
+	return rbi.DispatchStatus.IsDispatched() && !rbi.IsRunning()
+}
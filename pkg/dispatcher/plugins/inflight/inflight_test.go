@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflight
+
+import (
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func TestIsInFlight(t *testing.T) {
+	podGroup := func(phase schedulingv1beta1.PodGroupPhase) *schedulingv1beta1.PodGroup {
+		return &schedulingv1beta1.PodGroup{Status: schedulingv1beta1.PodGroupStatus{Phase: phase}}
+	}
+	rbWithHealth := func(health ...workv1alpha2.ResourceHealth) *workv1alpha2.ResourceBinding {
+		rb := &workv1alpha2.ResourceBinding{}
+		for _, h := range health {
+			rb.Status.AggregatedStatus = append(rb.Status.AggregatedStatus, workv1alpha2.AggregatedStatusItem{Health: h})
+		}
+		return rb
+	}
+
+	testCases := []struct {
+		Name   string
+		rbi    *api.ResourceBindingInfo
+		expect bool
+	}{
+		{
+			Name:   "Suspended",
+			rbi:    &api.ResourceBindingInfo{ResourceBinding: rbWithHealth(), DispatchStatus: api.Pending},
+			expect: false,
+		},
+		{
+			Name:   "UnSuspending without status",
+			rbi:    &api.ResourceBindingInfo{ResourceBinding: rbWithHealth(), DispatchStatus: api.Admitted},
+			expect: true,
+		},
+		{
+			Name:   "PodGroup is pending",
+			rbi:    &api.ResourceBindingInfo{ResourceBinding: rbWithHealth(), PodGroup: podGroup(schedulingv1beta1.PodGroupPending), DispatchStatus: api.Dispatched},
+			expect: true,
+		},
+		{
+			Name:   "PodGroup is running",
+			rbi:    &api.ResourceBindingInfo{ResourceBinding: rbWithHealth(), PodGroup: podGroup(schedulingv1beta1.PodGroupRunning), DispatchStatus: api.Dispatched},
+			expect: false,
+		},
+		{
+			Name:   "Unhealthy in one of the clusters",
+			rbi:    &api.ResourceBindingInfo{ResourceBinding: rbWithHealth(workv1alpha2.ResourceHealthy, workv1alpha2.ResourceUnknown), DispatchStatus: api.Dispatched},
+			expect: true,
+		},
+		{
+			Name:   "Healthy in all the clusters",
+			rbi:    &api.ResourceBindingInfo{ResourceBinding: rbWithHealth(workv1alpha2.ResourceHealthy, workv1alpha2.ResourceHealthy), DispatchStatus: api.Dispatched},
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := isInFlight(tc.rbi); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expect)
+		}
+	}
+}
+
+func TestPriorityClassDispatchable(t *testing.T) {
+	newRBI := func(priorityClassName string, status api.DispatchStatus) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding:   &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb"}},
+			PriorityClassName: priorityClassName,
+			DispatchStatus:    status,
+		}
+	}
+	newPriorityClass := func(name, limit string) *schedulingv1.PriorityClass {
+		pc := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if limit != "" {
+			pc.Annotations = map[string]string{api.MaxInFlightAnnotationKey: limit}
+		}
+		return pc
+	}
+	priorityClasses := map[string]*schedulingv1.PriorityClass{
+		"critical": newPriorityClass("critical", "2"),
+		"invalid":  newPriorityClass("invalid", "two"),
+		"normal":   newPriorityClass("normal", ""),
+	}
+
+	testCases := []struct {
+		Name         string
+		rbi          *api.ResourceBindingInfo
+		others       []*api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name:   "PriorityClass under the limit",
+			rbi:    newRBI("critical", api.Pending),
+			others: []*api.ResourceBindingInfo{newRBI("critical", api.Dispatched), newRBI("normal", api.Dispatched)},
+		},
+		{
+			Name:         "PriorityClass reaches the limit",
+			rbi:          newRBI("critical", api.Pending),
+			others:       []*api.ResourceBindingInfo{newRBI("critical", api.Dispatched), newRBI("critical", api.Admitted)},
+			expectReason: PriorityClassMaxInFlightExceededReason,
+		},
+		{
+			Name:   "PriorityClass without the limit",
+			rbi:    newRBI("normal", api.Pending),
+			others: []*api.ResourceBindingInfo{newRBI("normal", api.Dispatched), newRBI("normal", api.Dispatched)},
+		},
+		{
+			Name:   "PriorityClass with the invalid limit",
+			rbi:    newRBI("invalid", api.Pending),
+			others: []*api.ResourceBindingInfo{newRBI("invalid", api.Dispatched)},
+		},
+		{
+			Name: "Workload without PriorityClass",
+			rbi:  newRBI("", api.Pending),
+		},
+	}
+
+	for _, tc := range testCases {
+		ip := &inFlightPlugin{
+			ssn:                   &framework.Session{Snapshot: &cache.DispatcherCacheSnapshot{PriorityClasses: priorityClasses}},
+			priorityClassInFlight: map[string]int{},
+		}
+		for _, rbi := range tc.others {
+			if isInFlight(rbi) {
+				ip.priorityClassInFlight[rbi.PriorityClassName]++
+			}
+		}
+
+		reason := ""
+		if blocker := ip.priorityClassDispatchable(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
+
+func TestRateDispatchable(t *testing.T) {
+	now := time.Now()
+	newQueue := func(limit string) *schedulingapi.QueueInfo {
+		return &schedulingapi.QueueInfo{Name: "q", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q",
+			Annotations: map[string]string{api.DispatchRateLimitAnnotationKey: limit}}}}
+	}
+
+	testCases := []struct {
+		Name           string
+		limit          string
+		unSuspendTimes []time.Time
+		dispatched     int
+		expectReason   string
+	}{
+		{
+			Name:           "Queue under the limit",
+			limit:          "2/1m",
+			unSuspendTimes: []time.Time{now.Add(-10 * time.Second), now.Add(-2 * time.Minute)},
+		},
+		{
+			Name:           "Queue reaches the limit by the ones dispatched in the session",
+			limit:          "2/1m",
+			unSuspendTimes: []time.Time{now.Add(-10 * time.Second)},
+			dispatched:     1,
+			expectReason:   DispatchRateLimitedReason,
+		},
+		{
+			Name:           "Queue with the invalid limit",
+			limit:          "2",
+			unSuspendTimes: []time.Time{now, now, now},
+		},
+		{
+			Name:           "Queue without the limit",
+			unSuspendTimes: []time.Time{now, now, now},
+		},
+	}
+
+	for _, tc := range testCases {
+		ip := &inFlightPlugin{
+			ssn:            &framework.Session{Snapshot: &cache.DispatcherCacheSnapshot{QueueInfos: map[string]*schedulingapi.QueueInfo{"q": newQueue(tc.limit)}}},
+			unSuspendTimes: map[string][]time.Time{"q": tc.unSuspendTimes},
+			dispatched:     map[string]int{"q": tc.dispatched},
+		}
+
+		reason := ""
+		if blocker := ip.rateDispatchable("q", now); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
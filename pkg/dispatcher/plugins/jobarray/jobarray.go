@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobarray
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "jobarray"
+
+	// JobArrayChunkFullReason is the reason when the job array has a full chunk of members in flight.
+	JobArrayChunkFullReason = "JobArrayChunkFull"
+
+	// chunkSizeKey is the argument of the default chunk size of the job arrays, zero or negative means no limit.
+	chunkSizeKey = "jobarray.chunkSize"
+
+	defaultChunkSize = 10
+)
+
+// jobArrayPlugin releases the members of the job arrays, which are declared by the `volcano.sh/job-array` annotation,
+// in chunks. At most a chunk of the members of an array are dispatched but not completed, the next members are
+// released as their predecessors complete, instead of all of them at once or one by one.
+type jobArrayPlugin struct {
+	ssn       *framework.Session
+	chunkSize int
+	// inFlight[namespace/array] is the number of the members of the array dispatched but not completed.
+	inFlight map[types.NamespacedName]int
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	jp := &jobArrayPlugin{chunkSize: defaultChunkSize}
+	arguments.GetInt(&jp.chunkSize, chunkSizeKey)
+	return jp
+}
+
+func (jp *jobArrayPlugin) Name() string {
+	return PluginName
+}
+
+func (jp *jobArrayPlugin) OnSessionOpen(ssn *framework.Session) {
+	jp.ssn = ssn
+	jp.inFlight = map[types.NamespacedName]int{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if array, found := jobArray(rbi); found && isInFlight(rbi) {
+			jp.inFlight[array]++
+		}
+	}
+
+	ssn.AddResourceBindingInfoOrderFn(jp.Name(), jp.resourceBindingInfoOrderFn)
+	ssn.AddDispatchableFn(jp.Name(), jp.dispatchableFn)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			if array, found := jobArray(event.ResourceBindingInfo); found {
+				jp.inFlight[array]++
+			}
+		},
+		EvictFunc: func(event *framework.Event) {
+			if array, found := jobArray(event.ResourceBindingInfo); found {
+				jp.inFlight[array]--
+			}
+		},
+	})
+}
+
+func (jp *jobArrayPlugin) OnSessionClose(_ *framework.Session) {}
+
+// resourceBindingInfoOrderFn orders the members of the same job array by the time they are created, so the chunks
+// are released in the order of the array. The ResourceBindingInfos not in the same array are left to the other plugins.
+func (jp *jobArrayPlugin) resourceBindingInfoOrderFn(l, r interface{}) int {
+	lv := l.(*api.ResourceBindingInfo)
+	rv := r.(*api.ResourceBindingInfo)
+
+	lArray, lFound := jobArray(lv)
+	rArray, rFound := jobArray(rv)
+	if !lFound || !rFound || lArray != rArray {
+		return 0
+	}
+
+	lTime, rTime := lv.ResourceBinding.CreationTimestamp, rv.ResourceBinding.CreationTimestamp
+	switch {
+	case lTime.Before(&rTime):
+		return -1
+	case rTime.Before(&lTime):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (jp *jobArrayPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	array, found := jobArray(rbi)
+	if !found {
+		return nil
+	}
+	limit := jp.arrayChunkSize(rbi)
+	if limit <= 0 || jp.inFlight[array] < limit {
+		return nil
+	}
+
+	klog.V(4).Infof("JobArray plugin: Job array <%s> has %d members in flight, chunk size %d, ResourceBinding <%s/%s> should wait.",
+		array, jp.inFlight[array], limit, rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name)
+	return &api.DispatchBlocker{
+		Reason:  JobArrayChunkFullReason,
+		Message: fmt.Sprintf("Job array %s has %d members dispatched but not completed, chunk size: %d", array.Name, jp.inFlight[array], limit),
+	}
+}
+
+// arrayChunkSize returns the chunk size of the job array of the ResourceBindingInfo, the annotation of the
+// ResourceBinding takes precedence.
+func (jp *jobArrayPlugin) arrayChunkSize(rbi *api.ResourceBindingInfo) int {
+	value, found := rbi.ResourceBinding.Annotations[api.JobArrayChunkSizeAnnotationKey]
+	if !found {
+		return jp.chunkSize
+	}
+	chunkSize, err := strconv.Atoi(value)
+	if err != nil {
+		klog.Errorf("Failed to parse the annotation %s of ResourceBinding <%s/%s>, use the default chunk size, err: %v",
+			api.JobArrayChunkSizeAnnotationKey, rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, err)
+		return jp.chunkSize
+	}
+	return chunkSize
+}
+
+// jobArray returns the job array of the ResourceBindingInfo, the arrays are scoped by the namespaces.
+func jobArray(rbi *api.ResourceBindingInfo) (types.NamespacedName, bool) {
+	name := rbi.ResourceBinding.Annotations[api.JobArrayAnnotationKey]
+	if name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: name}, true
+}
+
+// isInFlight checks whether the member is dispatched but not completed, the deleted members are not in flight either.
+func isInFlight(rbi *api.ResourceBindingInfo) bool {
+	return rbi.DispatchStatus.IsDispatched() && !rbi.IsCompleted()
+}
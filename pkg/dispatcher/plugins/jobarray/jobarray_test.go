@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobarray
+
+import (
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+func newRBI(name string, annotations map[string]string, status api.DispatchStatus, phase schedulingv1beta1.PodGroupPhase) *api.ResourceBindingInfo {
+	rbi := &api.ResourceBindingInfo{
+		ResourceBinding: &workv1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Annotations: annotations},
+		},
+		DispatchStatus: status,
+	}
+	if phase != "" {
+		rbi.PodGroup = &schedulingv1beta1.PodGroup{Status: schedulingv1beta1.PodGroupStatus{Phase: phase}}
+	}
+	return rbi
+}
+
+func TestDispatchable(t *testing.T) {
+	member := map[string]string{api.JobArrayAnnotationKey: "array"}
+	chunkOfOne := map[string]string{api.JobArrayAnnotationKey: "array", api.JobArrayChunkSizeAnnotationKey: "1"}
+
+	testCases := []struct {
+		Name         string
+		rbi          *api.ResourceBindingInfo
+		others       []*api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name:   "Not in a job array",
+			rbi:    newRBI("a", nil, api.Pending, ""),
+			others: []*api.ResourceBindingInfo{newRBI("b", nil, api.Dispatched, "")},
+		},
+		{
+			Name:   "Chunk is not full",
+			rbi:    newRBI("a", member, api.Pending, ""),
+			others: []*api.ResourceBindingInfo{newRBI("b", member, api.Dispatched, schedulingv1beta1.PodGroupRunning)},
+		},
+		{
+			Name:         "Chunk is full",
+			rbi:          newRBI("a", chunkOfOne, api.Pending, ""),
+			others:       []*api.ResourceBindingInfo{newRBI("b", chunkOfOne, api.Dispatched, schedulingv1beta1.PodGroupRunning)},
+			expectReason: JobArrayChunkFullReason,
+		},
+		{
+			Name:   "Predecessor is completed",
+			rbi:    newRBI("a", chunkOfOne, api.Pending, ""),
+			others: []*api.ResourceBindingInfo{newRBI("b", chunkOfOne, api.Dispatched, schedulingv1beta1.PodGroupCompleted)},
+		},
+		{
+			Name:   "Predecessor is in another job array",
+			rbi:    newRBI("a", chunkOfOne, api.Pending, ""),
+			others: []*api.ResourceBindingInfo{newRBI("b", map[string]string{api.JobArrayAnnotationKey: "other"}, api.Dispatched, "")},
+		},
+	}
+
+	for _, tc := range testCases {
+		jp := &jobArrayPlugin{chunkSize: defaultChunkSize, inFlight: map[types.NamespacedName]int{}}
+		for _, rbi := range tc.others {
+			if array, found := jobArray(rbi); found && isInFlight(rbi) {
+				jp.inFlight[array]++
+			}
+		}
+
+		reason := ""
+		if blocker := jp.dispatchableFn(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
+
+func TestResourceBindingInfoOrderFn(t *testing.T) {
+	withCreation := func(rbi *api.ResourceBindingInfo, offset time.Duration) *api.ResourceBindingInfo {
+		rbi.ResourceBinding.CreationTimestamp = metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(offset))
+		return rbi
+	}
+	member := map[string]string{api.JobArrayAnnotationKey: "array"}
+
+	testCases := []struct {
+		Name   string
+		l, r   *api.ResourceBindingInfo
+		expect int
+	}{
+		{
+			Name:   "Earlier member first",
+			l:      withCreation(newRBI("a", member, api.Pending, ""), 0),
+			r:      withCreation(newRBI("b", member, api.Pending, ""), time.Second),
+			expect: -1,
+		},
+		{
+			Name:   "Later member last",
+			l:      withCreation(newRBI("a", member, api.Pending, ""), time.Second),
+			r:      withCreation(newRBI("b", member, api.Pending, ""), 0),
+			expect: 1,
+		},
+		{
+			Name:   "Different job arrays",
+			l:      withCreation(newRBI("a", member, api.Pending, ""), time.Second),
+			r:      withCreation(newRBI("b", map[string]string{api.JobArrayAnnotationKey: "other"}, api.Pending, ""), 0),
+			expect: 0,
+		},
+	}
+
+	jp := &jobArrayPlugin{}
+	for _, tc := range testCases {
+		if got := jp.resourceBindingInfoOrderFn(tc.l, tc.r); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %d expect: %d", tc.Name, got, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nsfair
+
+import (
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const PluginName = "nsfair"
+
+const (
+	// roundRobin makes the namespaces take turns equally.
+	roundRobin = "round-robin"
+	// weighted makes the turns of the namespaces in proportion to their weights.
+	weighted = "weighted"
+)
+
+// nsFairPlugin shares the queues opted in by the `volcano.sh/namespace-fairness` annotation between the namespaces,
+// so the large namespaces can't starve the small ones. The workloads of each namespace are ranked by their priority
+// and creation time, the n-th workload of a namespace with the dispatched workloads d and the weight w has the key
+// (d + n) / w, the lower key is dispatched first. The keys are computed when the session opens, so the order is stable
+// while dispatching, and the workloads with the same key are ordered by the other plugins.
+// It should be configured before the priority plugin, the one configured first takes precedence.
+type nsFairPlugin struct {
+	// keys[uid] is the key of the suspended ResourceBindingInfo in the fair queues.
+	keys map[types.UID]float64
+}
+
+func New(_ framework.Arguments) framework.Plugin {
+	return &nsFairPlugin{}
+}
+
+func (np *nsFairPlugin) Name() string {
+	return PluginName
+}
+
+func (np *nsFairPlugin) OnSessionOpen(ssn *framework.Session) {
+	np.keys = fairnessKeys(ssn.Snapshot, ssn.GetResourceBindingInfoQueue)
+	ssn.AddResourceBindingInfoOrderFn(np.Name(), np.resourceBindingInfoOrderFunc)
+}
+
+func (np *nsFairPlugin) OnSessionClose(_ *framework.Session) {}
+
+// fairnessKeys computes the keys of the suspended ResourceBindingInfos in the fair queues.
+func fairnessKeys(snapshot *cache.DispatcherCacheSnapshot, queueOf func(*api.ResourceBindingInfo) string) map[types.UID]float64 {
+	// dispatched[queue][namespace] and suspended[queue][namespace] are the ResourceBindingInfos of the namespace in the queue.
+	dispatched := map[string]map[string]int{}
+	suspended := map[string]map[string][]*api.ResourceBindingInfo{}
+	modes := map[string]string{}
+	for _, rbi := range snapshot.ResourceBindingInfos {
+		queueName := queueOf(rbi)
+		mode, found := modes[queueName]
+		if !found {
+			mode = queueFairness(snapshot, queueName)
+			modes[queueName] = mode
+		}
+		if mode == "" {
+			continue
+		}
+
+		namespace := rbi.ResourceBinding.Namespace
+		if rbi.DispatchStatus.IsDispatched() {
+			if rbi.IsCompleted() {
+				continue
+			}
+			if dispatched[queueName] == nil {
+				dispatched[queueName] = map[string]int{}
+			}
+			dispatched[queueName][namespace]++
+			continue
+		}
+		if suspended[queueName] == nil {
+			suspended[queueName] = map[string][]*api.ResourceBindingInfo{}
+		}
+		suspended[queueName][namespace] = append(suspended[queueName][namespace], rbi)
+	}
+
+	keys := map[types.UID]float64{}
+	for queueName, namespaces := range suspended {
+		for namespace, rbis := range namespaces {
+			weight := 1
+			if modes[queueName] == weighted {
+				weight = namespaceWeight(snapshot, namespace)
+			}
+			sort.Slice(rbis, func(i, j int) bool {
+				return rankLess(rbis[i], rbis[j])
+			})
+			for rank, rbi := range rbis {
+				keys[rbi.ResourceBinding.UID] = float64(dispatched[queueName][namespace]+rank+1) / float64(weight)
+			}
+		}
+	}
+	return keys
+}
+
+// queueFairness returns the fairness of the queue, it's empty if the queue isn't shared fairly.
+func queueFairness(snapshot *cache.DispatcherCacheSnapshot, queueName string) string {
+	queue, found := snapshot.QueueInfos[queueName]
+	if !found || queue.Queue == nil {
+		return ""
+	}
+	switch value := queue.Queue.Annotations[api.NamespaceFairnessAnnotationKey]; value {
+	case "", roundRobin, weighted:
+		return value
+	default:
+		klog.Errorf("Invalid annotation %s=%q of Queue <%s>, it should be %s or %s, order its workloads strictly.",
+			api.NamespaceFairnessAnnotationKey, value, queueName, roundRobin, weighted)
+		return ""
+	}
+}
+
+// namespaceWeight returns the weight of the namespace, it's 1 if the namespace didn't set a valid one.
+func namespaceWeight(snapshot *cache.DispatcherCacheSnapshot, namespace string) int {
+	ns, found := snapshot.Namespaces[namespace]
+	if !found {
+		return 1
+	}
+	value, found := ns.Annotations[api.DispatchWeightAnnotationKey]
+	if !found {
+		return 1
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight <= 0 {
+		klog.Errorf("Invalid annotation %s=%q of Namespace <%s>, it should be a positive integer, use 1.",
+			api.DispatchWeightAnnotationKey, value, namespace)
+		return 1
+	}
+	return weight
+}
+
+// rankLess ranks the workloads of a namespace by the higher priority, then the earlier creation.
+func rankLess(l, r *api.ResourceBindingInfo) bool {
+	if l.Priority != r.Priority {
+		return l.Priority > r.Priority
+	}
+	lrb, rrb := l.ResourceBinding, r.ResourceBinding
+	if !lrb.CreationTimestamp.Equal(&rrb.CreationTimestamp) {
+		return lrb.CreationTimestamp.Before(&rrb.CreationTimestamp)
+	}
+	return lrb.UID < rrb.UID
+}
+
+func (np *nsFairPlugin) resourceBindingInfoOrderFunc(l, r interface{}) int {
+	lv := l.(*api.ResourceBindingInfo)
+	rv := r.(*api.ResourceBindingInfo)
+
+	lkey, lfound := np.keys[lv.ResourceBinding.UID]
+	rkey, rfound := np.keys[rv.ResourceBinding.UID]
+	if !lfound || !rfound || lkey == rkey {
+		return 0
+	}
+	klog.V(4).Infof("NsFair plugin ResourceBindingOrder: <%s/%s> key %f, <%s/%s> key %f",
+		lv.ResourceBinding.Namespace, lv.ResourceBinding.Name, lkey,
+		rv.ResourceBinding.Namespace, rv.ResourceBinding.Name, rkey)
+	if lkey < rkey {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nsfair
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func newRBI(namespace, name string, priority int32, status api.DispatchStatus) *api.ResourceBindingInfo {
+	return &api.ResourceBindingInfo{
+		ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			UID:       types.UID(namespace + "/" + name),
+		}},
+		Priority:       priority,
+		DispatchStatus: status,
+	}
+}
+
+func TestResourceBindingInfoOrder(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		fairness    string
+		rbis        []*api.ResourceBindingInfo
+		expectOrder []string
+	}{
+		{
+			Name: "Strict order without the annotation",
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "a1", 0, api.Pending),
+				newRBI("a", "a2", 0, api.Pending),
+				newRBI("b", "b1", 0, api.Pending),
+			},
+			expectOrder: []string{"a/a1", "a/a2", "b/b1"},
+		},
+		{
+			Name:     "Round robin across the namespaces",
+			fairness: roundRobin,
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "a1", 0, api.Pending),
+				newRBI("a", "a2", 0, api.Pending),
+				newRBI("a", "a3", 10, api.Pending),
+				newRBI("b", "b1", 0, api.Pending),
+				newRBI("b", "b2", 0, api.Pending),
+			},
+			expectOrder: []string{"a/a3", "b/b1", "a/a1", "b/b2", "a/a2"},
+		},
+		{
+			Name:     "Round robin counts the dispatched workloads",
+			fairness: roundRobin,
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "a0", 0, api.Dispatched),
+				newRBI("a", "a1", 0, api.Pending),
+				newRBI("a", "a2", 0, api.Pending),
+				newRBI("b", "b1", 0, api.Pending),
+				newRBI("b", "b2", 0, api.Pending),
+			},
+			expectOrder: []string{"b/b1", "a/a1", "b/b2", "a/a2"},
+		},
+		{
+			Name:     "Weighted by the namespace annotation",
+			fairness: weighted,
+			rbis: []*api.ResourceBindingInfo{
+				newRBI("a", "a1", 0, api.Pending),
+				newRBI("a", "a2", 0, api.Pending),
+				newRBI("a", "a3", 0, api.Pending),
+				newRBI("a", "a4", 0, api.Pending),
+				newRBI("b", "b1", 0, api.Pending),
+				newRBI("b", "b2", 0, api.Pending),
+			},
+			expectOrder: []string{"a/a1", "a/a2", "b/b1", "a/a3", "a/a4", "b/b2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		queue := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "queue"}}
+		if tc.fairness != "" {
+			queue.Annotations = map[string]string{api.NamespaceFairnessAnnotationKey: tc.fairness}
+		}
+		snapshot := &cache.DispatcherCacheSnapshot{
+			QueueInfos:           map[string]*schedulingapi.QueueInfo{"queue": {Name: "queue", Queue: queue}},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{},
+			Namespaces: map[string]*corev1.Namespace{
+				"a": {ObjectMeta: metav1.ObjectMeta{Name: "a", Annotations: map[string]string{api.DispatchWeightAnnotationKey: "2"}}},
+			},
+		}
+		var pending []*api.ResourceBindingInfo
+		for _, rbi := range tc.rbis {
+			snapshot.ResourceBindingInfos[rbi.ResourceBinding.UID] = rbi
+			if !rbi.DispatchStatus.IsDispatched() {
+				pending = append(pending, rbi)
+			}
+		}
+		np := &nsFairPlugin{keys: fairnessKeys(snapshot, func(*api.ResourceBindingInfo) string { return "queue" })}
+
+		// The workloads with the same key are ordered by the other plugins, by the UID here.
+		sort.Slice(pending, func(i, j int) bool {
+			if result := np.resourceBindingInfoOrderFunc(pending[i], pending[j]); result != 0 {
+				return result < 0
+			}
+			return pending[i].ResourceBinding.UID < pending[j].ResourceBinding.UID
+		})
+		var order []string
+		for _, rbi := range pending {
+			order = append(order, string(rbi.ResourceBinding.UID))
+		}
+		if !reflect.DeepEqual(order, tc.expectOrder) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, order, tc.expectOrder)
+		}
+	}
+}
@@ -23,12 +23,29 @@ import (
 	"volcano.sh/volcano-global/pkg/dispatcher/framework"
 )
 
-const PluginName = "priority"
+const (
+	PluginName = "priority"
+
+	// retryBoostKey is the argument of the priority added to the workload for each time it waits in the queue again
+	// after it was dispatched, like it's preempted or failed over, 0 by default which means disabled.
+	retryBoostKey = "priority.retryBoost"
+	// maxRetryBoostKey is the argument of the max priority added for the retries, 0 by default which means unlimited.
+	maxRetryBoostKey = "priority.maxRetryBoost"
+)
 
-type priorityPlugin struct{}
+// priorityPlugin orders the ResourceBindings by their priorities, the retried ones are boosted by the retryBoost for
+// each retry, so they don't go to the back of the line of their priority. The boost only affects the order, the
+// preemption still compares the priorities of the PriorityClasses.
+type priorityPlugin struct {
+	retryBoost    int
+	maxRetryBoost int
+}
 
-func New() framework.Plugin {
-	return &priorityPlugin{}
+func New(arguments framework.Arguments) framework.Plugin {
+	pp := &priorityPlugin{}
+	arguments.GetInt(&pp.retryBoost, retryBoostKey)
+	arguments.GetInt(&pp.maxRetryBoost, maxRetryBoostKey)
+	return pp
 }
 
 func (pp *priorityPlugin) Name() string {
@@ -46,17 +63,31 @@ func (pp *priorityPlugin) resourceBindingInfoOrderFunc(l, r interface{}) int {
 	lv := l.(*api.ResourceBindingInfo)
 	rv := r.(*api.ResourceBindingInfo)
 
+	lp, rp := pp.effectivePriority(lv), pp.effectivePriority(rv)
 	klog.V(4).Infof("Priority plugin ResourceBindingOrder: <%s/%s> ResourceBinding priority %d, <%s/%s> ResourceBInding priority %d",
-		lv.ResourceBinding.Namespace, lv.ResourceBinding.Name, lv.Priority,
-		rv.ResourceBinding.Namespace, rv.ResourceBinding.Name, rv.Priority)
+		lv.ResourceBinding.Namespace, lv.ResourceBinding.Name, lp,
+		rv.ResourceBinding.Namespace, rv.ResourceBinding.Name, rp)
 
-	if lv.Priority == rv.Priority {
+	if lp == rp {
 		return 0
 	}
 
-	if lv.Priority > rv.Priority {
+	if lp > rp {
 		return -1
 	}
 
 	return 1
 }
+
+// effectivePriority returns the priority of the ResourceBindingInfo boosted by its retries, it's computed in int64
+// so the boost can't overflow.
+func (pp *priorityPlugin) effectivePriority(rbi *api.ResourceBindingInfo) int64 {
+	if pp.retryBoost <= 0 {
+		return int64(rbi.Priority)
+	}
+	boost := int64(pp.retryBoost) * int64(rbi.Retries())
+	if pp.maxRetryBoost > 0 {
+		boost = min(boost, int64(pp.maxRetryBoost))
+	}
+	return int64(rbi.Priority) + boost
+}
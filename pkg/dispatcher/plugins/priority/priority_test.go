@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func TestResourceBindingInfoOrderFunc(t *testing.T) {
+	newRbi := func(name string, priority, preemptions, failures int32) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}},
+			Priority:        priority,
+			Preemptions:     preemptions,
+			Failures:        failures,
+		}
+	}
+
+	testCases := []struct {
+		Name      string
+		Arguments framework.Arguments
+		L, R      *api.ResourceBindingInfo
+		Expect    int
+	}{
+		{
+			Name:   "Higher priority first",
+			L:      newRbi("l", 10, 0, 0),
+			R:      newRbi("r", 5, 0, 0),
+			Expect: -1,
+		},
+		{
+			Name:   "Retries are not boosted by default",
+			L:      newRbi("l", 5, 3, 0),
+			R:      newRbi("r", 10, 0, 0),
+			Expect: 1,
+		},
+		{
+			Name:      "Preempted and failed over ones are boosted by each retry",
+			Arguments: framework.Arguments{retryBoostKey: 3},
+			L:         newRbi("l", 5, 1, 1),
+			R:         newRbi("r", 10, 0, 0),
+			Expect:    -1,
+		},
+		{
+			Name:      "Boost is limited by the max boost",
+			Arguments: framework.Arguments{retryBoostKey: 3, maxRetryBoostKey: 5},
+			L:         newRbi("l", 5, 1, 1),
+			R:         newRbi("r", 10, 0, 0),
+			Expect:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		pp := New(tc.Arguments).(*priorityPlugin)
+		if got := pp.resourceBindingInfoOrderFunc(tc.L, tc.R); got != tc.Expect {
+			t.Errorf("Test case %s failed, got: %d expect: %d", tc.Name, got, tc.Expect)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "quota"
+
+	// QuotaExceededReason is the reason when the FederatedResourceQuota of the namespace would be exceeded.
+	QuotaExceededReason = "QuotaExceeded"
+)
+
+// quotaPlugin refuses to dispatch the ResourceBindings whose namespace's FederatedResourceQuota would be exceeded,
+// so the workloads will not be released only for them to be rejected by Karmada.
+type quotaPlugin struct {
+	ssn *framework.Session
+	// allocated[namespace] is the resources dispatched but not counted by the FederatedResourceQuota yet.
+	allocated map[string]corev1.ResourceList
+}
+
+func New(_ framework.Arguments) framework.Plugin {
+	return &quotaPlugin{
+		allocated: map[string]corev1.ResourceList{},
+	}
+}
+
+func (qp *quotaPlugin) Name() string {
+	return PluginName
+}
+
+func (qp *quotaPlugin) OnSessionOpen(ssn *framework.Session) {
+	qp.ssn = ssn
+
+	// The ResourceBindings which are admitted or dispatching were dispatched in the last rounds, but
+	// the FederatedResourceQuota may not count them yet, treat them as allocated.
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus == api.Admitted || rbi.DispatchStatus == api.Dispatching {
+			qp.allocate(rbi)
+		}
+	}
+
+	ssn.AddDispatchableFn(qp.Name(), qp.dispatchableFn)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			qp.allocate(event.ResourceBindingInfo)
+		},
+		UnreserveFunc: func(event *framework.Event) {
+			qp.deallocate(event.ResourceBindingInfo)
+		},
+	})
+}
+
+func (qp *quotaPlugin) OnSessionClose(_ *framework.Session) {}
+
+func (qp *quotaPlugin) allocate(rbi *api.ResourceBindingInfo) {
+	namespace := rbi.ResourceBinding.Namespace
+	if qp.allocated[namespace] == nil {
+		qp.allocated[namespace] = corev1.ResourceList{}
+	}
+	for name, quantity := range rbi.MinResources {
+		value := qp.allocated[namespace][name]
+		value.Add(quantity)
+		qp.allocated[namespace][name] = value
+	}
+}
+
+func (qp *quotaPlugin) deallocate(rbi *api.ResourceBindingInfo) {
+	namespace := rbi.ResourceBinding.Namespace
+	if qp.allocated[namespace] == nil {
+		return
+	}
+	for name, quantity := range rbi.MinResources {
+		value := qp.allocated[namespace][name]
+		value.Sub(quantity)
+		qp.allocated[namespace][name] = value
+	}
+}
+
+func (qp *quotaPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	namespace := rbi.ResourceBinding.Namespace
+	for _, frq := range qp.ssn.Snapshot.FederatedResourceQuotas[namespace] {
+		for quotaName, limit := range frq.Spec.Overall {
+			resourceName, ok := requestResourceName(quotaName)
+			if !ok {
+				continue
+			}
+			request, found := rbi.MinResources[resourceName]
+			if !found {
+				continue
+			}
+
+			used := frq.Status.OverallUsed[quotaName].DeepCopy()
+			used.Add(qp.allocated[namespace][resourceName])
+			used.Add(request)
+			if used.Cmp(limit) > 0 {
+				klog.V(4).Infof("Quota plugin: ResourceBinding <%s/%s> exceeds FederatedResourceQuota <%s/%s> %s, request %s, limit %s.",
+					namespace, rbi.ResourceBinding.Name, frq.Namespace, frq.Name, quotaName, request.String(), limit.String())
+				return &api.DispatchBlocker{
+					Reason: QuotaExceededReason,
+					Message: fmt.Sprintf("FederatedResourceQuota %s would be exceeded on %s, limited: %s",
+						frq.Name, quotaName, limit.String()),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// requestResourceName converts the quota resource name to the request resource name,
+// like `requests.cpu` to `cpu`, the limits and object counts are not supported.
+func requestResourceName(quotaName corev1.ResourceName) (corev1.ResourceName, bool) {
+	name := string(quotaName)
+	if strings.HasPrefix(name, corev1.DefaultResourceRequestsPrefix) {
+		return corev1.ResourceName(strings.TrimPrefix(name, corev1.DefaultResourceRequestsPrefix)), true
+	}
+	if strings.HasPrefix(name, "limits.") || strings.HasPrefix(name, "count/") || quotaName == corev1.ResourcePods {
+		return "", false
+	}
+	return quotaName, true
+}
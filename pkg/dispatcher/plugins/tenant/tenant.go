@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	PluginName = "tenant"
+
+	// TenantMaxRunningJobsExceededReason is the reason when the tenant has too many dispatched workloads.
+	TenantMaxRunningJobsExceededReason = "TenantMaxRunningJobsExceeded"
+	// TenantMaxResourcesExceededReason is the reason when the resources of the tenant would exceed its ceiling.
+	TenantMaxResourcesExceededReason = "TenantMaxResourcesExceeded"
+
+	// labelKey is the argument of the label on the ResourceBinding or the PodGroup which tells the tenant,
+	// the namespace is the tenant when it's empty or the workload doesn't have the label.
+	labelKey = "tenant.label"
+	// defaultCeilingKey is the argument of the ceiling of the tenants which don't have their own.
+	defaultCeilingKey = "tenant.default"
+	// ceilingsKey is the argument of the ceilings of the tenants by the tenant name.
+	ceilingsKey = "tenant.ceilings"
+)
+
+// ceiling limits the workloads dispatched but not completed of a tenant.
+type ceiling struct {
+	// MaxRunningJobs is the maximum number of the workloads, zero or negative means no limit.
+	MaxRunningJobs int `yaml:"maxRunningJobs"`
+	// MaxResources is the maximum resources of the workloads, the resources not listed are not limited.
+	MaxResources map[corev1.ResourceName]string `yaml:"maxResources"`
+
+	maxResources corev1.ResourceList
+}
+
+// tenantPlugin enforces the ceilings of the tenants at dispatch time, independently of the queue capacity,
+// to stop one tenant consuming the whole federation through multiple queues.
+type tenantPlugin struct {
+	label          string
+	defaultCeiling *ceiling
+	ceilings       map[string]*ceiling
+
+	// running[tenant] is the number of the workloads dispatched but not completed of the tenant.
+	running map[string]int
+	// allocated[tenant] is the resources of the workloads dispatched but not completed of the tenant.
+	allocated map[string]corev1.ResourceList
+}
+
+func New(arguments framework.Arguments) framework.Plugin {
+	tp := &tenantPlugin{ceilings: map[string]*ceiling{}}
+	arguments.GetString(&tp.label, labelKey)
+	if argv, found := arguments[defaultCeilingKey]; found {
+		if err := parseArgument(argv, &tp.defaultCeiling); err != nil {
+			klog.Errorf("Failed to parse the argument %s, err: %v", defaultCeilingKey, err)
+		}
+	}
+	if argv, found := arguments[ceilingsKey]; found {
+		if err := parseArgument(argv, &tp.ceilings); err != nil {
+			klog.Errorf("Failed to parse the argument %s, err: %v", ceilingsKey, err)
+		}
+	}
+
+	for name, c := range tp.ceilings {
+		if err := c.parseMaxResources(); err != nil {
+			klog.Errorf("Failed to parse the ceiling of tenant <%s>, ignore it, err: %v", name, err)
+			delete(tp.ceilings, name)
+		}
+	}
+	if tp.defaultCeiling != nil {
+		if err := tp.defaultCeiling.parseMaxResources(); err != nil {
+			klog.Errorf("Failed to parse the default ceiling of the tenants, ignore it, err: %v", err)
+			tp.defaultCeiling = nil
+		}
+	}
+	return tp
+}
+
+// parseArgument converts the structured argument decoded from the configuration into the object.
+func parseArgument(argv interface{}, object interface{}) error {
+	data, err := yaml.Marshal(argv)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, object)
+}
+
+func (c *ceiling) parseMaxResources() error {
+	c.maxResources = corev1.ResourceList{}
+	for name, value := range c.MaxResources {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid quantity %q of %s: %v", value, name, err)
+		}
+		c.maxResources[name] = quantity
+	}
+	return nil
+}
+
+func (tp *tenantPlugin) Name() string {
+	return PluginName
+}
+
+func (tp *tenantPlugin) OnSessionOpen(ssn *framework.Session) {
+	tp.running = map[string]int{}
+	tp.allocated = map[string]corev1.ResourceList{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() && !rbi.IsCompleted() {
+			tp.allocate(rbi)
+		}
+	}
+
+	ssn.AddDispatchableFn(tp.Name(), tp.dispatchableFn)
+	ssn.AddEventHandler(&framework.EventHandler{
+		DispatchFunc: func(event *framework.Event) {
+			tp.allocate(event.ResourceBindingInfo)
+		},
+		EvictFunc: func(event *framework.Event) {
+			tp.deallocate(event.ResourceBindingInfo)
+		},
+	})
+}
+
+func (tp *tenantPlugin) OnSessionClose(_ *framework.Session) {}
+
+// tenantOf returns the tenant of the ResourceBindingInfo, the label of the ResourceBinding takes precedence.
+func (tp *tenantPlugin) tenantOf(rbi *api.ResourceBindingInfo) string {
+	if tp.label != "" {
+		if tenant, found := rbi.ResourceBinding.Labels[tp.label]; found {
+			return tenant
+		}
+		if rbi.PodGroup != nil {
+			if tenant, found := rbi.PodGroup.Labels[tp.label]; found {
+				return tenant
+			}
+		}
+	}
+	return rbi.ResourceBinding.Namespace
+}
+
+func (tp *tenantPlugin) ceilingOf(tenant string) *ceiling {
+	if c, found := tp.ceilings[tenant]; found {
+		return c
+	}
+	return tp.defaultCeiling
+}
+
+func (tp *tenantPlugin) allocate(rbi *api.ResourceBindingInfo) {
+	tenant := tp.tenantOf(rbi)
+	tp.running[tenant]++
+	if tp.allocated[tenant] == nil {
+		tp.allocated[tenant] = corev1.ResourceList{}
+	}
+	for name, quantity := range rbi.MinResources {
+		value := tp.allocated[tenant][name]
+		value.Add(quantity)
+		tp.allocated[tenant][name] = value
+	}
+}
+
+func (tp *tenantPlugin) deallocate(rbi *api.ResourceBindingInfo) {
+	tenant := tp.tenantOf(rbi)
+	tp.running[tenant]--
+	for name, quantity := range rbi.MinResources {
+		value := tp.allocated[tenant][name]
+		value.Sub(quantity)
+		tp.allocated[tenant][name] = value
+	}
+}
+
+func (tp *tenantPlugin) dispatchableFn(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+	tenant := tp.tenantOf(rbi)
+	c := tp.ceilingOf(tenant)
+	if c == nil {
+		return nil
+	}
+
+	if c.MaxRunningJobs > 0 && tp.running[tenant] >= c.MaxRunningJobs {
+		klog.V(4).Infof("Tenant plugin: Tenant <%s> has %d workloads dispatched, limit %d, ResourceBinding <%s/%s> should wait.",
+			tenant, tp.running[tenant], c.MaxRunningJobs, rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name)
+		return &api.DispatchBlocker{
+			Reason:  TenantMaxRunningJobsExceededReason,
+			Message: fmt.Sprintf("Tenant %s has %d workloads dispatched, limited: %d", tenant, tp.running[tenant], c.MaxRunningJobs),
+		}
+	}
+
+	for name, limit := range c.maxResources {
+		request, found := rbi.MinResources[name]
+		if !found {
+			continue
+		}
+		used := tp.allocated[tenant][name].DeepCopy()
+		used.Add(request)
+		if used.Cmp(limit) > 0 {
+			klog.V(4).Infof("Tenant plugin: ResourceBinding <%s/%s> exceeds the ceiling of tenant <%s> on %s, request %s, limit %s.",
+				rbi.ResourceBinding.Namespace, rbi.ResourceBinding.Name, tenant, name, request.String(), limit.String())
+			return &api.DispatchBlocker{
+				Reason:  TenantMaxResourcesExceededReason,
+				Message: fmt.Sprintf("The ceiling of tenant %s would be exceeded on %s, limited: %s", tenant, name, limit.String()),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+)
+
+const pluginConf = `
+name: tenant
+arguments:
+  tenant.label: volcano.sh/tenant
+  tenant.default:
+    maxRunningJobs: 2
+  tenant.ceilings:
+    team-a:
+      maxResources:
+        cpu: "4"
+`
+
+func newRBI(namespace, tenant, cpu string) *api.ResourceBindingInfo {
+	rb := &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "rb"}}
+	if tenant != "" {
+		rb.Labels = map[string]string{"volcano.sh/tenant": tenant}
+	}
+	return &api.ResourceBindingInfo{
+		ResourceBinding: rb,
+		DispatchStatus:  api.Dispatched,
+		MinResources:    corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+	}
+}
+
+func TestDispatchable(t *testing.T) {
+	option := conf.PluginOption{}
+	if err := yaml.Unmarshal([]byte(pluginConf), &option); err != nil {
+		t.Fatalf("Failed to unmarshal the plugin configuration: %v", err)
+	}
+
+	testCases := []struct {
+		Name         string
+		dispatched   []*api.ResourceBindingInfo
+		rbi          *api.ResourceBindingInfo
+		expectReason string
+	}{
+		{
+			Name:       "Within the default ceiling",
+			dispatched: []*api.ResourceBindingInfo{newRBI("ns1", "", "1")},
+			rbi:        newRBI("ns1", "", "1"),
+		},
+		{
+			Name:         "Exceed the default max running jobs",
+			dispatched:   []*api.ResourceBindingInfo{newRBI("ns1", "", "1"), newRBI("ns1", "", "1")},
+			rbi:          newRBI("ns1", "", "1"),
+			expectReason: TenantMaxRunningJobsExceededReason,
+		},
+		{
+			Name:       "Namespaces are different tenants",
+			dispatched: []*api.ResourceBindingInfo{newRBI("ns1", "", "1"), newRBI("ns1", "", "1")},
+			rbi:        newRBI("ns2", "", "1"),
+		},
+		{
+			Name:         "Exceed the max resources of the labeled tenant across namespaces",
+			dispatched:   []*api.ResourceBindingInfo{newRBI("ns1", "team-a", "2"), newRBI("ns2", "team-a", "1")},
+			rbi:          newRBI("ns3", "team-a", "2"),
+			expectReason: TenantMaxResourcesExceededReason,
+		},
+		{
+			Name:       "Own ceiling overrides the default one",
+			dispatched: []*api.ResourceBindingInfo{newRBI("ns1", "team-a", "1"), newRBI("ns2", "team-a", "1")},
+			rbi:        newRBI("ns3", "team-a", "2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tp := New(option.Arguments).(*tenantPlugin)
+		tp.running = map[string]int{}
+		tp.allocated = map[string]corev1.ResourceList{}
+		for _, rbi := range tc.dispatched {
+			tp.allocate(rbi)
+		}
+
+		reason := ""
+		if blocker := tp.dispatchableFn(tc.rbi); blocker != nil {
+			reason = blocker.Reason
+		}
+		if reason != tc.expectReason {
+			t.Errorf("Test case %s failed, got: %s expect: %s", tc.Name, reason, tc.expectReason)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+)
+
+// registerProfilingHandlers serves the pprof endpoints under /debug/pprof/ of the mux, they're authenticated
+// like the other debug endpoints since the profiles and the command line may leak the internals.
+func (dispatcher *Dispatcher) registerProfilingHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/pprof/", dispatcher.debugAuthenticator.wrap(pprof.Index))
+	mux.HandleFunc("/debug/pprof/cmdline", dispatcher.debugAuthenticator.wrap(pprof.Cmdline))
+	mux.HandleFunc("/debug/pprof/profile", dispatcher.debugAuthenticator.wrap(pprof.Profile))
+	mux.HandleFunc("/debug/pprof/symbol", dispatcher.debugAuthenticator.wrap(pprof.Symbol))
+	mux.HandleFunc("/debug/pprof/trace", dispatcher.debugAuthenticator.wrap(pprof.Trace))
+}
+
+// selfReport records the depths of the work queues of the caches by the metrics, and logs the heap in use and the
+// number of the goroutines, the growing ones in a long-running dispatcher tell the leaks. The heap and the goroutines
+// are exported by the Go collector of the metrics as well, like go_memstats_heap_inuse_bytes and go_goroutines.
+func (dispatcher *Dispatcher) selfReport() {
+	for _, cp := range dispatcher.controlPlanes {
+		depths := cp.cache.WorkQueueDepths()
+		metrics.UpdateCacheWorkQueueDepths(cp.name, depths)
+		klog.V(3).Infof("Work queue depths of control plane <%s>: %v", cp.name, depths)
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	klog.V(2).Infof("Dispatcher uses <%d> bytes of heap in <%d> goroutines.", memStats.HeapInuse, runtime.NumGoroutine())
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	karmadascheme "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	volcanoscheme "volcano.sh/apis/pkg/client/clientset/versioned/scheme"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/audit"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+// ReplayOptions are the inputs of replaying a dispatching round offline.
+type ReplayOptions struct {
+	// Configuration is the configuration of the dispatcher when the round was made, the default one is used when
+	// it's nil.
+	Configuration *conf.DispatcherConfiguration
+	// Objects are the cache dump, the Queues, PodGroups, PriorityClasses, ResourceBindings, Clusters, workloads
+	// and so on when the round was made.
+	Objects []*unstructured.Unstructured
+	// Events are the audit log, only the ones of the control plane and the cycle are compared.
+	Events       []*audit.Event
+	ControlPlane string
+	// Cycle seeds the tie-breaker like the replayed round, it's the earliest cycle in the events when it's zero.
+	Cycle uint64
+}
+
+// ReplayDiff is a ResourceBinding decided differently by the replayed round and the audit log, one of them is
+// nil when the ResourceBinding is only decided by the other.
+type ReplayDiff struct {
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Audited   *audit.Event  `json:"audited,omitempty"`
+	Replayed  *api.Decision `json:"replayed,omitempty"`
+}
+
+// ReplayResult is the decisions of the replayed round in order and their differences from the audit log.
+type ReplayResult struct {
+	Cycle     uint64         `json:"cycle"`
+	Decisions []api.Decision `json:"decisions"`
+	Diffs     []ReplayDiff   `json:"diffs,omitempty"`
+}
+
+// ReadCacheDump reads the objects of the cache dump, it's a stream of YAML or JSON documents like the output of
+// `kubectl get -o yaml`, the Lists are expanded to their items.
+func ReadCacheDump(reader io.Reader) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("failed to decode the cache dump: %v", err)
+		}
+		if len(object.Object) == 0 {
+			continue
+		}
+		if !object.IsList() {
+			objects = append(objects, object)
+			continue
+		}
+		if err := object.EachListItem(func(item runtime.Object) error {
+			objects = append(objects, item.(*unstructured.Unstructured))
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to expand the %s in the cache dump: %v", object.GetKind(), err)
+		}
+	}
+}
+
+// Replay rebuilds the cache from the dump and executes the actions and the plugins of the configuration in a
+// round without touching any cluster, then compares its decisions with the audited ones of the cycle. The member
+// clusters are not reachable offline, so the replicas are always feasible, and the manual releases and the two-phase
+// verification are not replayed.
+func Replay(options ReplayOptions) (*ReplayResult, error) {
+	configuration, err := offlineConfiguration(options.Configuration)
+	if err != nil {
+		return nil, err
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	dc, _, err := runOfflineCache(configuration, options.Objects, stopCh)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*audit.Event, 0, len(options.Events))
+	for _, event := range options.Events {
+		if options.ControlPlane == "" || event.ControlPlane == options.ControlPlane {
+			events = append(events, event)
+		}
+	}
+	cycle := options.Cycle
+	if cycle == 0 {
+		for _, event := range events {
+			if event.Cycle > 0 && (cycle == 0 || event.Cycle < cycle) {
+				cycle = event.Cycle
+			}
+		}
+	}
+	audited := map[types.NamespacedName]*audit.Event{}
+	for _, event := range events {
+		if event.Cycle != cycle {
+			continue
+		}
+		switch event.Action {
+		case audit.ActionSuspend, audit.ActionUnsuspend, audit.ActionPreempt:
+			audited[types.NamespacedName{Namespace: event.Namespace, Name: event.Name}] = event
+		}
+	}
+
+	ssn := dispatcherframework.OpenSession(dc, configuration.Plugins)
+	ssn.SetTieBreaker(configuration.TieBreaker, cycle)
+	ssn.SetPreemptionMatrix(configuration.PreemptionMatrix)
+	ops := &offlineOperations{ssn: ssn, configuration: configuration, cycle: cycle}
+	ssn.SetOperations(ops)
+	for _, name := range strings.Split(configuration.Actions, ",") {
+		if action, found := dispatcherframework.GetAction(strings.TrimSpace(name)); found {
+			action.Execute(ssn)
+		}
+	}
+	ssn.CloseSession()
+	ssn.Snapshot.Release()
+
+	return &ReplayResult{Cycle: cycle, Decisions: ops.decisions, Diffs: diffDecisions(audited, ops.decisions)}, nil
+}
+
+// offlineConfiguration returns the configuration of the offline rounds, the default one when it's nil, and
+// registers its workloads like applyDispatcherConf.
+func offlineConfiguration(configuration *conf.DispatcherConfiguration) (*conf.DispatcherConfiguration, error) {
+	if configuration == nil {
+		var err error
+		if configuration, err = UnmarshalDispatcherConf(DefaultDispatcherConf); err != nil {
+			return nil, err
+		}
+	}
+	workloadGVKs := make([]schema.GroupVersionKind, 0, len(configuration.Workloads))
+	for _, workload := range configuration.Workloads {
+		workloadGVKs = append(workloadGVKs, schema.GroupVersionKind{Group: workload.Group, Version: workload.Version, Kind: workload.Kind})
+	}
+	utils.DefaultWorkloadRegistry.SetExtraWorkloads(workloadGVKs)
+	return configuration, nil
+}
+
+// runOfflineCache runs the cache backed by the fake clients serving the objects until the stopCh is closed, the
+// configuration is applied to it like applyDispatcherConf.
+func runOfflineCache(configuration *conf.DispatcherConfiguration, objects []*unstructured.Unstructured,
+	stopCh <-chan struct{}) (*cache.DispatcherCache, *cache.FakeClients, error) {
+	builder := cache.NewFakeDispatcherCacheBuilder()
+	for _, object := range objects {
+		if err := addDumpedObject(builder, object); err != nil {
+			return nil, nil, err
+		}
+	}
+	dc, clients := builder.Build()
+	defaultQueueName := defaultQueue
+	if configuration.QueueDefaults.Name != "" {
+		defaultQueueName = configuration.QueueDefaults.Name
+	}
+	dc.SetDefaultQueue(defaultQueueName)
+	dc.SetMinResourcesSource(configuration.MinResourcesSource)
+	dc.Run(stopCh)
+	return dc, clients, nil
+}
+
+// addDumpedObject adds the object to the client serving it, the workloads are served by the dynamic client as they
+// are, the others are converted to the typed objects of their schemes.
+func addDumpedObject(builder *cache.FakeDispatcherCacheBuilder, object *unstructured.Unstructured) error {
+	gvk := object.GroupVersionKind()
+	if utils.DefaultWorkloadRegistry.IsWorkloadGVK(gvk) {
+		builder.WithWorkloads(object)
+		return nil
+	}
+
+	scheme, add := kubescheme.Scheme, builder.WithKubeObjects
+	switch {
+	case gvk.Group == "scheduling.volcano.sh":
+		scheme, add = volcanoscheme.Scheme, builder.WithVolcanoObjects
+	case strings.HasSuffix(gvk.Group, "karmada.io"):
+		scheme, add = karmadascheme.Scheme, builder.WithKarmadaObjects
+	}
+	typed, err := scheme.New(gvk)
+	if err != nil {
+		return fmt.Errorf("unknown kind %s of %s/%s in the cache dump: %v", gvk, object.GetNamespace(), object.GetName(), err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, typed); err != nil {
+		return fmt.Errorf("failed to convert %s %s/%s in the cache dump: %v", gvk.Kind, object.GetNamespace(), object.GetName(), err)
+	}
+	add(typed)
+	return nil
+}
+
+// diffDecisions compares the last decision of each ResourceBinding in the replayed round with the audited one.
+// The audit log skips the blockers which don't change, so a replayed blocker without the audited one is not a
+// difference.
+func diffDecisions(audited map[types.NamespacedName]*audit.Event, decisions []api.Decision) []ReplayDiff {
+	replayed := map[types.NamespacedName]*api.Decision{}
+	for i := range decisions {
+		replayed[types.NamespacedName{Namespace: decisions[i].Namespace, Name: decisions[i].Name}] = &decisions[i]
+	}
+
+	var diffs []ReplayDiff
+	for key, decision := range replayed {
+		event, found := audited[key]
+		if !found && !decision.Dispatched && !decision.Evicted {
+			continue
+		}
+		if found && replayedAction(decision) == event.Action && decision.Plugin == event.Plugin && decision.Reason == event.Reason {
+			continue
+		}
+		diffs = append(diffs, ReplayDiff{Namespace: key.Namespace, Name: key.Name, Audited: event, Replayed: decision})
+	}
+	for key, event := range audited {
+		if _, found := replayed[key]; !found {
+			diffs = append(diffs, ReplayDiff{Namespace: key.Namespace, Name: key.Name, Audited: event})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Namespace != diffs[j].Namespace {
+			return diffs[i].Namespace < diffs[j].Namespace
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+func replayedAction(decision *api.Decision) string {
+	switch {
+	case decision.Dispatched:
+		return audit.ActionUnsuspend
+	case decision.Evicted:
+		return audit.ActionPreempt
+	default:
+		return audit.ActionSuspend
+	}
+}
+
+// offlineOperations implements the operations of a round executed offline by the replay and the simulation, the
+// decisions are recorded in the session only.
+type offlineOperations struct {
+	ssn           *dispatcherframework.Session
+	configuration *conf.DispatcherConfiguration
+	cycle         uint64
+
+	// allocated is the number of the ResourceBindings dispatched in the round, a fresh token bucket accepts its
+	// burst in a round at most.
+	allocated int
+	decisions []api.Decision
+}
+
+var _ dispatcherframework.Operations = &offlineOperations{}
+
+func (ops *offlineOperations) OwnsQueue(_ string) bool {
+	return true
+}
+
+func (ops *offlineOperations) IsQueuePaused(queue *schedulingapi.QueueInfo) bool {
+	return ops.configuration.Paused || pausedBy(queue) != "" ||
+		(queue.Queue != nil && queue.Queue.Annotations[api.QueuePausedAnnotationKey] == "true")
+}
+
+func (ops *offlineOperations) QueuePausedBy(queue *schedulingapi.QueueInfo) string {
+	return pausedBy(queue)
+}
+
+func (ops *offlineOperations) HeldBy(rbi *api.ResourceBindingInfo) (string, bool) {
+	user := rbi.ResourceBinding.Annotations[api.DispatchHoldAnnotationKey]
+	return user, user != ""
+}
+
+func (ops *offlineOperations) Enqueue() {}
+
+func (ops *offlineOperations) Dispatchable(_ *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo) (*api.DispatchBlocker, string) {
+	return ops.ssn.Dispatchable(rbi), ""
+}
+
+func (ops *offlineOperations) Block(queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo, blocker *api.DispatchBlocker) {
+	ops.record(api.Decision{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name, Queue: queue.Name,
+		Plugin: blocker.Plugin, Reason: blocker.Reason, Message: blocker.Message})
+}
+
+func (ops *offlineOperations) Hold(queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo, user string) {
+	ops.record(api.Decision{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name, Queue: queue.Name,
+		Reason: api.DispatchHeldReason, Message: fmt.Sprintf("The ResourceBinding is held by %s manually.", user), User: user})
+}
+
+func (ops *offlineOperations) Allocate(queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo, _ string) bool {
+	if rateLimit := ops.configuration.RateLimit; rateLimit.QPS > 0 && ops.allocated >= max(rateLimit.Burst, 1) {
+		return false
+	}
+	ops.allocated++
+	ops.ssn.Dispatch(rbi)
+	rbi.SetDispatchStatus(api.Admitted, time.Now())
+	ops.record(api.Decision{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name, Queue: queue.Name,
+		Dispatched: true, Reason: api.DispatchedReason, Message: "The ResourceBinding is dispatched by volcano-global dispatcher."})
+	return true
+}
+
+func (ops *offlineOperations) Commit() {}
+
+func (ops *offlineOperations) Evict(victim, preemptor *api.ResourceBindingInfo, reason string) {
+	ops.ssn.Evict(victim)
+	ops.record(api.Decision{Namespace: victim.ResourceBinding.Namespace, Name: victim.ResourceBinding.Name,
+		Queue: ops.ssn.GetResourceBindingInfoQueue(victim), Evicted: true, Reason: reason,
+		Message: fmt.Sprintf("The ResourceBinding is suspended again for ResourceBinding %s/%s.",
+			preemptor.ResourceBinding.Namespace, preemptor.ResourceBinding.Name)})
+}
+
+func (ops *offlineOperations) record(decision api.Decision) {
+	decision.Time = time.Now()
+	decision.Cycle = ops.cycle
+	ops.decisions = append(ops.decisions, decision)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/audit"
+)
+
+const testCacheDump = `
+apiVersion: scheduling.volcano.sh/v1beta1
+kind: Queue
+metadata:
+  name: default
+status:
+  state: Open
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: work.karmada.io/v1alpha2
+  kind: ResourceBinding
+  metadata:
+    namespace: ns
+    name: rb-a
+    uid: rb-a
+    creationTimestamp: "2026-01-01T00:00:00Z"
+    annotations:
+      scheduling.volcano.sh/queue-name: default
+  spec:
+    suspend: true
+    resource:
+      apiVersion: apps/v1
+      kind: Deployment
+      namespace: ns
+      name: rb-a
+      uid: deploy-a
+- apiVersion: work.karmada.io/v1alpha2
+  kind: ResourceBinding
+  metadata:
+    namespace: ns
+    name: rb-b
+    uid: rb-b
+    creationTimestamp: "2026-01-01T00:01:00Z"
+    annotations:
+      scheduling.volcano.sh/queue-name: default
+      volcano.sh/dispatch-hold: alice
+  spec:
+    suspend: true
+    resource:
+      apiVersion: apps/v1
+      kind: Deployment
+      namespace: ns
+      name: rb-b
+      uid: deploy-b
+---
+{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"namespace": "ns", "name": "rb-a", "uid": "deploy-a"}}
+---
+{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"namespace": "ns", "name": "rb-b", "uid": "deploy-b"}}
+---
+apiVersion: scheduling.volcano.sh/v1beta1
+kind: PodGroup
+metadata:
+  namespace: ns
+  name: pg-a
+  ownerReferences:
+  - {apiVersion: apps/v1, kind: Deployment, name: rb-a, uid: deploy-a}
+spec:
+  queue: default
+---
+apiVersion: scheduling.volcano.sh/v1beta1
+kind: PodGroup
+metadata:
+  namespace: ns
+  name: pg-b
+  ownerReferences:
+  - {apiVersion: apps/v1, kind: Deployment, name: rb-b, uid: deploy-b}
+spec:
+  queue: default
+`
+
+func TestReplay(t *testing.T) {
+	objects, err := ReadCacheDump(strings.NewReader(testCacheDump))
+	if err != nil || len(objects) != 7 {
+		t.Fatalf("Test case read cache dump failed, got objects: %d err: %v expect: 7", len(objects), err)
+	}
+
+	testCases := []struct {
+		Name            string
+		events          []*audit.Event
+		controlPlane    string
+		expectCycle     uint64
+		expectDecisions map[string]string
+		expectDiffs     []string
+	}{
+		{
+			Name: "Replayed decisions match the audit log",
+			events: []*audit.Event{
+				{ControlPlane: "cp", Action: audit.ActionUnsuspend, Namespace: "ns", Name: "rb-a", Reason: api.DispatchedReason, Cycle: 3},
+				{ControlPlane: "cp", Action: audit.ActionSuspend, Namespace: "ns", Name: "rb-b", Reason: api.DispatchHeldReason, Cycle: 3},
+				{ControlPlane: "cp", Action: audit.ActionSuspend, Namespace: "ns", Name: "rb-a", Reason: "Unknown", Cycle: 4},
+			},
+			expectCycle:     3,
+			expectDecisions: map[string]string{"rb-a": api.DispatchedReason, "rb-b": api.DispatchHeldReason},
+		},
+		{
+			Name: "Replayed decisions differ from the audit log",
+			events: []*audit.Event{
+				{ControlPlane: "cp", Action: audit.ActionSuspend, Namespace: "ns", Name: "rb-a", Reason: "Unknown", Cycle: 5},
+				{ControlPlane: "other", Action: audit.ActionUnsuspend, Namespace: "ns", Name: "rb-b", Reason: api.DispatchedReason, Cycle: 1},
+			},
+			controlPlane:    "cp",
+			expectCycle:     5,
+			expectDecisions: map[string]string{"rb-a": api.DispatchedReason, "rb-b": api.DispatchHeldReason},
+			expectDiffs:     []string{"rb-a"},
+		},
+		{
+			Name:            "No audit log",
+			expectDecisions: map[string]string{"rb-a": api.DispatchedReason, "rb-b": api.DispatchHeldReason},
+			expectDiffs:     []string{"rb-a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		result, err := Replay(ReplayOptions{Objects: objects, Events: tc.events, ControlPlane: tc.controlPlane})
+		if err != nil {
+			t.Errorf("Test case %s failed, got err: %v", tc.Name, err)
+			continue
+		}
+		decisions := map[string]string{}
+		for _, decision := range result.Decisions {
+			decisions[decision.Name] = decision.Reason
+		}
+		var diffs []string
+		for _, diff := range result.Diffs {
+			diffs = append(diffs, diff.Name)
+		}
+		if result.Cycle != tc.expectCycle || !reflect.DeepEqual(decisions, tc.expectDecisions) || !reflect.DeepEqual(diffs, tc.expectDiffs) {
+			t.Errorf("Test case %s failed, got cycle: %d decisions: %v diffs: %v expect: %d %v %v",
+				tc.Name, result.Cycle, decisions, diffs, tc.expectCycle, tc.expectDecisions, tc.expectDiffs)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// reservation is a ResourceBindingInfo selected by the two-phase dispatching, its capacity is reserved in the session.
+type reservation struct {
+	queue *schedulingapi.QueueInfo
+	rbi   *api.ResourceBindingInfo
+	// speculativeCluster is the elastic cluster the ResourceBindingInfo is released toward, it's empty when
+	// it's released as scheduled.
+	speculativeCluster string
+}
+
+// verifyReservations is the second phase of the two-phase dispatching. Each reserved ResourceBindingInfo is checked
+// again by the plugins with all the other reservations of the round in place, so the ones selected earlier in the
+// round don't rely on the capacity reserved later. The ones passing are released, the rest leave their capacity
+// and wait in the queue. It returns the number of the released ones.
+func (dispatcher *Dispatcher) verifyReservations(cp *controlPlane, ssn *dispatcherframework.Session,
+	reserved []reservation, queueAllocated map[string]corev1.ResourceList) int {
+	logger := cp.logger()
+	released := 0
+	for _, r := range reserved {
+		key := types.NamespacedName{Namespace: r.rbi.ResourceBinding.Namespace, Name: r.rbi.ResourceBinding.Name}
+		// Take back its own reservation, the plugins would count it twice otherwise.
+		ssn.Unreserve(r.rbi)
+		if blocker := ssn.Dispatchable(r.rbi); blocker != nil {
+			logger.Info(3, r.queue.Name, key, "ResourceBinding is rejected by the verification of the reservations",
+				"plugin", blocker.Plugin, "reason", blocker.Reason, "message", blocker.Message)
+			cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+				Type:    api.DispatchedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  blocker.Reason,
+				Message: blocker.Message,
+			})
+			dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+				Queue: r.queue.Name, Plugin: blocker.Plugin, Reason: blocker.Reason, Message: blocker.Message}, r.rbi, r.queue, queueAllocated[r.queue.Name])
+			continue
+		}
+		ssn.Dispatch(r.rbi)
+		dispatcher.release(cp, r.queue, r.rbi, r.speculativeCluster, queueAllocated)
+		released++
+	}
+	return released
+}
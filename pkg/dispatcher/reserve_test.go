@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+func TestVerifyReservations(t *testing.T) {
+	newRBI := func(name string) *api.ResourceBindingInfo {
+		return &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, UID: types.UID(name)}},
+			Queue:           "default",
+			DispatchStatus:  api.Pending,
+		}
+	}
+
+	testCases := []struct {
+		Name string
+		// conflicts[a] is the ResourceBinding which can't be released with a.
+		conflicts      map[string]string
+		expectReleased []types.NamespacedName
+		expectReserved map[string]bool
+		expectCount    int
+	}{
+		{
+			Name:           "No conflicts",
+			expectReleased: []types.NamespacedName{{Namespace: "ns", Name: "a"}, {Namespace: "ns", Name: "b"}},
+			expectReserved: map[string]bool{"a": true, "b": true},
+			expectCount:    2,
+		},
+		{
+			Name:           "Reserved later conflicts with the earlier one",
+			conflicts:      map[string]string{"a": "b"},
+			expectReleased: []types.NamespacedName{{Namespace: "ns", Name: "b"}},
+			expectReserved: map[string]bool{"a": false, "b": true},
+			expectCount:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		queue := &schedulingapi.QueueInfo{Name: "default"}
+		a, b := newRBI("a"), newRBI("b")
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue:         "default",
+			QueueInfos:           map[string]*schedulingapi.QueueInfo{"default": queue},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{"a": a, "b": b},
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10)}
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		reserved := map[string]bool{}
+		ssn.AddDispatchableFn("conflict", func(rbi *api.ResourceBindingInfo) *api.DispatchBlocker {
+			if conflict, found := tc.conflicts[rbi.ResourceBinding.Name]; found && reserved[conflict] {
+				return &api.DispatchBlocker{Reason: "Conflict"}
+			}
+			return nil
+		})
+		ssn.AddEventHandler(&dispatcherframework.EventHandler{
+			DispatchFunc: func(event *dispatcherframework.Event) {
+				reserved[event.ResourceBindingInfo.ResourceBinding.Name] = true
+			},
+			UnreserveFunc: func(event *dispatcherframework.Event) {
+				reserved[event.ResourceBindingInfo.ResourceBinding.Name] = false
+			},
+		})
+		ssn.Dispatch(a)
+		ssn.Dispatch(b)
+		released := dispatcher.verifyReservations(cp, ssn, []reservation{{queue: queue, rbi: a}, {queue: queue, rbi: b}}, map[string]corev1.ResourceList{})
+		ssn.CloseSession()
+
+		if released != tc.expectCount {
+			t.Errorf("Test case %s failed, got released: %d expect: %d", tc.Name, released, tc.expectCount)
+		}
+		if !reflect.DeepEqual(fc.unsuspended, tc.expectReleased) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, fc.unsuspended, tc.expectReleased)
+		}
+		if !reflect.DeepEqual(reserved, tc.expectReserved) {
+			t.Errorf("Test case %s failed, got reserved: %v expect: %v", tc.Name, reserved, tc.expectReserved)
+		}
+	}
+}
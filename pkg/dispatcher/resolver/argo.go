@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func init() {
+	RegisterResolver(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"}, &argoWorkflowResolver{})
+}
+
+// argoWorkflowSpec is the part of the Argo WorkflowSpec used to compute the resources,
+// we parse it by ourselves to avoid depending on Argo.
+type argoWorkflowSpec struct {
+	Entrypoint string         `json:"entrypoint,omitempty"`
+	Templates  []argoTemplate `json:"templates,omitempty"`
+}
+
+type argoTemplate struct {
+	Name           string               `json:"name,omitempty"`
+	Container      *corev1.Container    `json:"container,omitempty"`
+	Script         *corev1.Container    `json:"script,omitempty"`
+	InitContainers []corev1.Container   `json:"initContainers,omitempty"`
+	Steps          [][]argoWorkflowStep `json:"steps,omitempty"`
+	DAG            *argoDAGTemplate     `json:"dag,omitempty"`
+}
+
+type argoWorkflowStep struct {
+	Name      string        `json:"name,omitempty"`
+	Template  string        `json:"template,omitempty"`
+	WithItems []interface{} `json:"withItems,omitempty"`
+}
+
+type argoDAGTemplate struct {
+	Tasks []argoDAGTask `json:"tasks,omitempty"`
+}
+
+type argoDAGTask struct {
+	Name         string        `json:"name,omitempty"`
+	Template     string        `json:"template,omitempty"`
+	Dependencies []string      `json:"dependencies,omitempty"`
+	Depends      string        `json:"depends,omitempty"`
+	WithItems    []interface{} `json:"withItems,omitempty"`
+}
+
+// argoWorkflowResolver resolves the Workflow by its entrypoint template. The gang is the largest parallel step set,
+// so the Workflow can run all its steps once admitted, and the first parallel step set is the first stage.
+// The templates referenced from the other WorkflowTemplates and the recursive templates request nothing.
+type argoWorkflowResolver struct{}
+
+func (ar *argoWorkflowResolver) MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	return ar.resolve(rb, workload, false)
+}
+
+func (ar *argoWorkflowResolver) FirstStageResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	return ar.resolve(rb, workload, true)
+}
+
+func (ar *argoWorkflowResolver) resolve(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured, firstStage bool) (corev1.ResourceList, error) {
+	if workload == nil {
+		return replicasResources(rb), nil
+	}
+
+	object, _, err := unstructured.NestedMap(workload.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the spec of Workflow, err: %v", err)
+	}
+	spec := &argoWorkflowSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object, spec); err != nil {
+		return nil, fmt.Errorf("failed to convert the spec of Workflow, err: %v", err)
+	}
+
+	tr := &argoTemplateResolver{
+		templates: make(map[string]*argoTemplate, len(spec.Templates)),
+		visiting:  map[string]bool{},
+	}
+	for i := range spec.Templates {
+		tr.templates[spec.Templates[i].Name] = &spec.Templates[i]
+	}
+	if _, found := tr.templates[spec.Entrypoint]; !found {
+		// The entrypoint may come from the workflowTemplateRef, we can't resolve it.
+		return replicasResources(rb), nil
+	}
+	if firstStage {
+		return tr.firstStage(spec.Entrypoint), nil
+	}
+	return tr.peak(spec.Entrypoint), nil
+}
+
+type argoTemplateResolver struct {
+	templates map[string]*argoTemplate
+	// visiting records the templates being resolved, to stop at the recursive templates.
+	visiting map[string]bool
+}
+
+// peak returns the resources of the largest parallel step set of the template.
+func (tr *argoTemplateResolver) peak(name string) corev1.ResourceList {
+	return tr.resolveTemplate(name, false)
+}
+
+// firstStage returns the resources of the first parallel step set of the template.
+func (tr *argoTemplateResolver) firstStage(name string) corev1.ResourceList {
+	return tr.resolveTemplate(name, true)
+}
+
+func (tr *argoTemplateResolver) resolveTemplate(name string, firstStage bool) corev1.ResourceList {
+	template, found := tr.templates[name]
+	if !found || tr.visiting[name] {
+		return corev1.ResourceList{}
+	}
+	tr.visiting[name] = true
+	defer delete(tr.visiting, name)
+
+	switch {
+	case template.Container != nil || template.Script != nil:
+		spec := &corev1.PodSpec{InitContainers: template.InitContainers}
+		if template.Container != nil {
+			spec.Containers = append(spec.Containers, *template.Container)
+		}
+		if template.Script != nil {
+			spec.Containers = append(spec.Containers, *template.Script)
+		}
+		return podRequests(spec)
+	case len(template.Steps) > 0:
+		if firstStage {
+			return tr.stepSetResources(template.Steps[0], true)
+		}
+		resources := corev1.ResourceList{}
+		for _, steps := range template.Steps {
+			maxResourceList(resources, tr.stepSetResources(steps, false))
+		}
+		return resources
+	case template.DAG != nil:
+		levels := dagLevels(template.DAG.Tasks)
+		if firstStage {
+			return tr.taskSetResources(levels[0], true)
+		}
+		resources := corev1.ResourceList{}
+		for _, tasks := range levels {
+			maxResourceList(resources, tr.taskSetResources(tasks, false))
+		}
+		return resources
+	default:
+		// The resource, suspend and http templates don't create pods.
+		return corev1.ResourceList{}
+	}
+}
+
+// stepSetResources sums the resources of the steps running in parallel, the step with items runs once per item.
+func (tr *argoTemplateResolver) stepSetResources(steps []argoWorkflowStep, firstStage bool) corev1.ResourceList {
+	resources := corev1.ResourceList{}
+	for _, step := range steps {
+		addResourceList(resources, multiplyResourceList(tr.resolveTemplate(step.Template, firstStage), fanOut(step.WithItems)))
+	}
+	return resources
+}
+
+func (tr *argoTemplateResolver) taskSetResources(tasks []argoDAGTask, firstStage bool) corev1.ResourceList {
+	resources := corev1.ResourceList{}
+	for _, task := range tasks {
+		addResourceList(resources, multiplyResourceList(tr.resolveTemplate(task.Template, firstStage), fanOut(task.WithItems)))
+	}
+	return resources
+}
+
+// fanOut returns how many times the step runs, the items from the withParam are unknown before running so count once.
+func fanOut(items []interface{}) int32 {
+	return max(int32(len(items)), 1)
+}
+
+// dagLevels groups the tasks by their depth in the DAG, the tasks in the same level may run in parallel.
+// The tasks depending on the unknown tasks or in a cycle are put in the last level.
+func dagLevels(tasks []argoDAGTask) [][]argoDAGTask {
+	taskIndex := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		taskIndex[task.Name] = i
+	}
+	depths := make([]int, len(tasks))
+	for i := range depths {
+		depths[i] = -1
+	}
+
+	var depthOf func(i int, visiting map[int]bool) int
+	depthOf = func(i int, visiting map[int]bool) int {
+		if depths[i] >= 0 {
+			return depths[i]
+		}
+		if visiting[i] {
+			return len(tasks)
+		}
+		visiting[i] = true
+		depth := 0
+		for _, dependency := range tasks[i].dependencyNames() {
+			j, found := taskIndex[dependency]
+			if !found {
+				depth = max(depth, len(tasks))
+				continue
+			}
+			depth = max(depth, depthOf(j, visiting)+1)
+		}
+		depths[i] = min(depth, len(tasks))
+		return depths[i]
+	}
+
+	levels := make([][]argoDAGTask, len(tasks)+1)
+	for i, task := range tasks {
+		depth := depthOf(i, map[int]bool{})
+		levels[depth] = append(levels[depth], task)
+	}
+	result := make([][]argoDAGTask, 0, len(levels))
+	for _, level := range levels {
+		if len(level) > 0 {
+			result = append(result, level)
+		}
+	}
+	if len(result) == 0 {
+		result = append(result, nil)
+	}
+	return result
+}
+
+// dependencyNames returns the tasks which the task depends on, from the dependencies or the depends expression
+// like `A && (B.Succeeded || C.Failed)`.
+func (task *argoDAGTask) dependencyNames() []string {
+	if task.Depends == "" {
+		return task.Dependencies
+	}
+	var names []string
+	fields := strings.FieldsFunc(task.Depends, func(r rune) bool {
+		return r == ' ' || r == '&' || r == '|' || r == '(' || r == ')' || r == '!'
+	})
+	for _, field := range fields {
+		// Remove the result suffix of the task, like `.Succeeded`.
+		name, _, _ := strings.Cut(field, ".")
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	RegisterResolver(appsv1.SchemeGroupVersion.WithKind("Deployment"), &deploymentResolver{})
+}
+
+// deploymentResolver treats all the replicas of the Deployment as the gang.
+type deploymentResolver struct{}
+
+func (dr *deploymentResolver) MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	if workload == nil {
+		return replicasResources(rb), nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(workload.Object, deployment); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured to Deployment, err: %v", err)
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return multiplyResourceList(podRequests(&deployment.Spec.Template.Spec), replicas), nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"sync"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// WorkloadResourceResolver computes the minimum resources of a workload's gang,
+// the dispatcher uses it to know how much capacity a ResourceBinding needs before unsuspending it.
+type WorkloadResourceResolver interface {
+	// MinResources returns the minimum resources of the gang. The workload may be nil when it can't be found,
+	// the resolver should fall back to the ResourceBinding's ReplicaRequirements in that case.
+	MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error)
+}
+
+// StagedWorkloadResourceResolver is implemented by the resolvers of the workloads running in stages, like the steps
+// of the Argo Workflow, the queues can admit them by the resources of the first stage.
+type StagedWorkloadResourceResolver interface {
+	WorkloadResourceResolver
+	// FirstStageResources returns the resources of the first stage of the workload, the workload may be nil like MinResources.
+	FirstStageResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error)
+}
+
+var (
+	mutex     sync.RWMutex
+	resolvers = map[schema.GroupVersionKind]WorkloadResourceResolver{}
+)
+
+// RegisterResolver register the resolver for the workload.
+func RegisterResolver(gvk schema.GroupVersionKind, resolver WorkloadResourceResolver) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	resolvers[gvk] = resolver
+}
+
+// GetResolver returns the resolver of the workload, the replicas resolver will be returned if not registered.
+func GetResolver(gvk schema.GroupVersionKind) WorkloadResourceResolver {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	if resolver, found := resolvers[gvk]; found {
+		return resolver
+	}
+	return &replicasResolver{}
+}
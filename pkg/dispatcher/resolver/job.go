@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	RegisterResolver(batchv1.SchemeGroupVersion.WithKind("Job"), &jobResolver{})
+}
+
+// jobResolver treats the pods running in parallel as the gang, it is min(parallelism, completions).
+type jobResolver struct{}
+
+func (jr *jobResolver) MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	if workload == nil {
+		return replicasResources(rb), nil
+	}
+
+	job := &batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(workload.Object, job); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured to Job, err: %v", err)
+	}
+
+	parallelism := int32(1)
+	if job.Spec.Parallelism != nil {
+		parallelism = *job.Spec.Parallelism
+	}
+	if job.Spec.Completions != nil && *job.Spec.Completions < parallelism {
+		parallelism = *job.Spec.Completions
+	}
+	return multiplyResourceList(podRequests(&job.Spec.Template.Spec), parallelism), nil
+}
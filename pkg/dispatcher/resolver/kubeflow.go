@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const kubeflowGroup = "kubeflow.org"
+
+func init() {
+	RegisterResolver(schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "TFJob"},
+		&kubeflowResolver{replicaSpecsField: "tfReplicaSpecs"})
+	RegisterResolver(schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "PyTorchJob"},
+		&kubeflowResolver{replicaSpecsField: "pytorchReplicaSpecs"})
+	RegisterResolver(schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "MXJob"},
+		&kubeflowResolver{replicaSpecsField: "mxReplicaSpecs"})
+	RegisterResolver(schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "XGBoostJob"},
+		&kubeflowResolver{replicaSpecsField: "xgbReplicaSpecs"})
+}
+
+// kubeflowRoleOrder is the order of the roles counted in the gang when the minAvailable is less than the total
+// replicas, the coordinating roles go first because the workers can't start without them. The roles not listed
+// go after them in lexical order.
+var kubeflowRoleOrder = []string{"Chief", "Master", "Launcher", "Scheduler", "Server", "PS", "Worker", "Evaluator", "Tuner"}
+
+// kubeflowReplicaSpec is the common part of the Kubeflow training operator's ReplicaSpec,
+// we parse it by ourselves to avoid depending on the training operator.
+type kubeflowReplicaSpec struct {
+	Replicas *int32                 `json:"replicas,omitempty"`
+	Template corev1.PodTemplateSpec `json:"template,omitempty"`
+}
+
+// kubeflowSchedulingPolicy is the part of `spec.runPolicy.schedulingPolicy` used by the gang scheduling.
+type kubeflowSchedulingPolicy struct {
+	MinAvailable *int32              `json:"minAvailable,omitempty"`
+	MinResources corev1.ResourceList `json:"minResources,omitempty"`
+}
+
+// kubeflowResolver computes the gang resources the same as the PodGroup created by the training operator, it's
+// the minResources of the schedulingPolicy if set, otherwise the first minAvailable pods of the roles in order,
+// and all the replicas of all the roles by default.
+type kubeflowResolver struct {
+	// The field name of the replica specs under `spec`, like `tfReplicaSpecs`.
+	replicaSpecsField string
+}
+
+func (kr *kubeflowResolver) MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	if workload == nil {
+		return replicasResources(rb), nil
+	}
+
+	replicaSpecs, found, err := unstructured.NestedMap(workload.Object, "spec", kr.replicaSpecsField)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spec.%s of %s, err: %v", kr.replicaSpecsField, workload.GetKind(), err)
+	}
+	if !found {
+		return replicasResources(rb), nil
+	}
+
+	schedulingPolicy := &kubeflowSchedulingPolicy{}
+	if object, found, _ := unstructured.NestedMap(workload.Object, "spec", "runPolicy", "schedulingPolicy"); found {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object, schedulingPolicy); err != nil {
+			return nil, fmt.Errorf("failed to convert spec.runPolicy.schedulingPolicy of %s, err: %v", workload.GetKind(), err)
+		}
+	}
+	if len(schedulingPolicy.MinResources) > 0 {
+		return schedulingPolicy.MinResources, nil
+	}
+
+	roles := make([]string, 0, len(replicaSpecs))
+	parsedSpecs := make(map[string]*kubeflowReplicaSpec, len(replicaSpecs))
+	for role, object := range replicaSpecs {
+		replicaSpecObject, ok := object.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid replica spec of role %s in %s", role, workload.GetKind())
+		}
+		replicaSpec := &kubeflowReplicaSpec{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(replicaSpecObject, replicaSpec); err != nil {
+			return nil, fmt.Errorf("failed to convert replica spec of role %s in %s, err: %v", role, workload.GetKind(), err)
+		}
+		roles = append(roles, role)
+		parsedSpecs[role] = replicaSpec
+	}
+	sortKubeflowRoles(roles)
+
+	// The minAvailable is the total replicas of all the roles by default.
+	minAvailable := int32(math.MaxInt32)
+	if schedulingPolicy.MinAvailable != nil && *schedulingPolicy.MinAvailable > 0 {
+		minAvailable = *schedulingPolicy.MinAvailable
+	}
+	minResources := corev1.ResourceList{}
+	for _, role := range roles {
+		if minAvailable <= 0 {
+			break
+		}
+		replicas := int32(1)
+		if parsedSpecs[role].Replicas != nil {
+			replicas = *parsedSpecs[role].Replicas
+		}
+		replicas = min(replicas, minAvailable)
+		addResourceList(minResources, multiplyResourceList(podRequests(&parsedSpecs[role].Template.Spec), replicas))
+		minAvailable -= replicas
+	}
+	return minResources, nil
+}
+
+// sortKubeflowRoles sorts the roles by the kubeflowRoleOrder, the roles not listed go last in lexical order.
+func sortKubeflowRoles(roles []string) {
+	rank := func(role string) int {
+		for i, ordered := range kubeflowRoleOrder {
+			if strings.EqualFold(role, ordered) {
+				return i
+			}
+		}
+		return len(kubeflowRoleOrder)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		if rank(roles[i]) != rank(roles[j]) {
+			return rank(roles[i]) < rank(roles[j])
+		}
+		return roles[i] < roles[j]
+	})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const rayGroup = "ray.io"
+
+func init() {
+	RegisterResolver(schema.GroupVersionKind{Group: rayGroup, Version: "v1", Kind: "RayCluster"},
+		&rayResolver{clusterSpecFields: []string{"spec"}})
+	RegisterResolver(schema.GroupVersionKind{Group: rayGroup, Version: "v1", Kind: "RayJob"},
+		&rayResolver{clusterSpecFields: []string{"spec", "rayClusterSpec"}})
+}
+
+// rayClusterSpec is the part of the KubeRay RayClusterSpec used to compute the resources,
+// we parse it by ourselves to avoid depending on KubeRay.
+type rayClusterSpec struct {
+	HeadGroupSpec struct {
+		Template corev1.PodTemplateSpec `json:"template,omitempty"`
+	} `json:"headGroupSpec,omitempty"`
+	WorkerGroupSpecs []rayWorkerGroupSpec `json:"workerGroupSpecs,omitempty"`
+}
+
+type rayWorkerGroupSpec struct {
+	Replicas    *int32                 `json:"replicas,omitempty"`
+	MinReplicas *int32                 `json:"minReplicas,omitempty"`
+	MaxReplicas *int32                 `json:"maxReplicas,omitempty"`
+	NumOfHosts  int32                  `json:"numOfHosts,omitempty"`
+	Template    corev1.PodTemplateSpec `json:"template,omitempty"`
+}
+
+// rayResolver treats the head and the desired workers of all the worker groups as the gang,
+// the same as the PodGroup created by KubeRay for the volcano batch scheduler.
+type rayResolver struct {
+	// The fields path of the RayClusterSpec, like `spec.rayClusterSpec` of the RayJob.
+	clusterSpecFields []string
+}
+
+func (rr *rayResolver) MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	if workload == nil {
+		return replicasResources(rb), nil
+	}
+
+	object, found, err := unstructured.NestedMap(workload.Object, rr.clusterSpecFields...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the RayClusterSpec of %s, err: %v", workload.GetKind(), err)
+	}
+	if !found {
+		// The RayJob may use an existing RayCluster by the clusterSelector, it doesn't need more resources.
+		return replicasResources(rb), nil
+	}
+	spec := &rayClusterSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object, spec); err != nil {
+		return nil, fmt.Errorf("failed to convert the RayClusterSpec of %s, err: %v", workload.GetKind(), err)
+	}
+
+	minResources := podRequests(&spec.HeadGroupSpec.Template.Spec)
+	for _, group := range spec.WorkerGroupSpecs {
+		addResourceList(minResources, multiplyResourceList(podRequests(&group.Template.Spec), group.desiredPods()))
+	}
+	return minResources, nil
+}
+
+// desiredPods returns the replicas clamped by the minReplicas and the maxReplicas, multiplied by the hosts of a replica.
+func (group *rayWorkerGroupSpec) desiredPods() int32 {
+	replicas := int32(0)
+	if group.MinReplicas != nil {
+		replicas = *group.MinReplicas
+	}
+	if group.Replicas != nil {
+		replicas = max(replicas, *group.Replicas)
+	}
+	if group.MaxReplicas != nil {
+		replicas = min(replicas, *group.MaxReplicas)
+	}
+	return replicas * max(group.NumOfHosts, 1)
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// replicasResolver computes the resources by the ResourceBinding only, it is `replicas * replicaRequirements`.
+// The ReplicaRequirements are interpreted by Karmada, so it works for all the workloads but doesn't know the gang.
+type replicasResolver struct{}
+
+func (rr *replicasResolver) MinResources(rb *workv1alpha2.ResourceBinding, _ *unstructured.Unstructured) (corev1.ResourceList, error) {
+	return replicasResources(rb), nil
+}
+
+func replicasResources(rb *workv1alpha2.ResourceBinding) corev1.ResourceList {
+	if rb.Spec.ReplicaRequirements == nil {
+		return corev1.ResourceList{}
+	}
+	return multiplyResourceList(rb.Spec.ReplicaRequirements.ResourceRequest, rb.Spec.Replicas)
+}
@@ -0,0 +1,445 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+func buildPodTemplate(cpu, memory string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func toUnstructured(t *testing.T, obj interface{}) *unstructured.Unstructured {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("Failed to convert object to unstructured, err: %v", err)
+	}
+	return &unstructured.Unstructured{Object: object}
+}
+
+func TestMinResources(t *testing.T) {
+	rb := &workv1alpha2.ResourceBinding{
+		Spec: workv1alpha2.ResourceBindingSpec{
+			Replicas: 3,
+			ReplicaRequirements: &workv1alpha2.ReplicaRequirements{
+				ResourceRequest: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name         string
+		gvk          schema.GroupVersionKind
+		workload     interface{}
+		expectCPU    string
+		expectMemory string
+	}{
+		{
+			Name:      "Fall back to ReplicaRequirements without workload",
+			gvk:       appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			expectCPU: "1500m",
+		},
+		{
+			Name: "Deployment",
+			gvk:  appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			workload: &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: utils.ToPointer(int32(2)),
+					Template: buildPodTemplate("1", "1Gi"),
+				},
+			},
+			expectCPU:    "2",
+			expectMemory: "2Gi",
+		},
+		{
+			Name: "VolcanoJob with minAvailable",
+			gvk:  batchv1alpha1.SchemeGroupVersion.WithKind("Job"),
+			workload: &batchv1alpha1.Job{
+				Spec: batchv1alpha1.JobSpec{
+					MinAvailable: 3,
+					Tasks: []batchv1alpha1.TaskSpec{
+						{Name: "ps", Replicas: 1, Template: buildPodTemplate("2", "2Gi")},
+						{Name: "worker", Replicas: 4, Template: buildPodTemplate("1", "1Gi")},
+					},
+				},
+			},
+			expectCPU:    "4",
+			expectMemory: "4Gi",
+		},
+		{
+			Name: "VolcanoJob with the minAvailable of the tasks",
+			gvk:  batchv1alpha1.SchemeGroupVersion.WithKind("Job"),
+			workload: &batchv1alpha1.Job{
+				Spec: batchv1alpha1.JobSpec{
+					Tasks: []batchv1alpha1.TaskSpec{
+						{Name: "ps", Replicas: 1, Template: buildPodTemplate("2", "2Gi")},
+						{Name: "worker", Replicas: 4, MinAvailable: utils.ToPointer(int32(2)), Template: buildPodTemplate("1", "1Gi")},
+					},
+				},
+			},
+			expectCPU:    "4",
+			expectMemory: "4Gi",
+		},
+		{
+			Name: "VolcanoJob filled up by the rest replicas of the tasks",
+			gvk:  batchv1alpha1.SchemeGroupVersion.WithKind("Job"),
+			workload: &batchv1alpha1.Job{
+				Spec: batchv1alpha1.JobSpec{
+					MinAvailable: 4,
+					Tasks: []batchv1alpha1.TaskSpec{
+						{Name: "ps", Replicas: 1, Template: buildPodTemplate("2", "2Gi")},
+						{Name: "worker", Replicas: 4, MinAvailable: utils.ToPointer(int32(2)), Template: buildPodTemplate("1", "1Gi")},
+					},
+				},
+			},
+			expectCPU:    "5",
+			expectMemory: "5Gi",
+		},
+		{
+			Name: "TFJob",
+			gvk:  schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "TFJob"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"tfReplicaSpecs": map[string]interface{}{
+						"PS":     map[string]interface{}{"replicas": int64(1), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+						"Worker": map[string]interface{}{"replicas": int64(2), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("2", "1Gi"))).Object},
+					},
+				},
+			},
+			expectCPU:    "5",
+			expectMemory: "3Gi",
+		},
+		{
+			Name: "PyTorchJob with minAvailable counts the Master first",
+			gvk:  schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "PyTorchJob"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"runPolicy": map[string]interface{}{
+						"schedulingPolicy": map[string]interface{}{"minAvailable": int64(2)},
+					},
+					"pytorchReplicaSpecs": map[string]interface{}{
+						"Worker": map[string]interface{}{"replicas": int64(3), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+						"Master": map[string]interface{}{"replicas": int64(1), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("2", "2Gi"))).Object},
+					},
+				},
+			},
+			expectCPU:    "3",
+			expectMemory: "3Gi",
+		},
+		{
+			Name: "XGBoostJob with minResources",
+			gvk:  schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "XGBoostJob"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"runPolicy": map[string]interface{}{
+						"schedulingPolicy": map[string]interface{}{"minResources": map[string]interface{}{"cpu": "10"}},
+					},
+					"xgbReplicaSpecs": map[string]interface{}{
+						"Master": map[string]interface{}{"replicas": int64(1), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+					},
+				},
+			},
+			expectCPU: "10",
+		},
+		{
+			Name: "MXJob",
+			gvk:  schema.GroupVersionKind{Group: kubeflowGroup, Version: "v1", Kind: "MXJob"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"mxReplicaSpecs": map[string]interface{}{
+						"Scheduler": map[string]interface{}{"template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+						"Server":    map[string]interface{}{"replicas": int64(1), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+						"Worker":    map[string]interface{}{"replicas": int64(2), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("2", "1Gi"))).Object},
+					},
+				},
+			},
+			expectCPU:    "6",
+			expectMemory: "4Gi",
+		},
+		{
+			Name: "RayCluster counts the head and the desired workers",
+			gvk:  schema.GroupVersionKind{Group: rayGroup, Version: "v1", Kind: "RayCluster"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"headGroupSpec": map[string]interface{}{"template": toUnstructured(t, utils.ToPointer(buildPodTemplate("2", "4Gi"))).Object},
+					"workerGroupSpecs": []interface{}{
+						map[string]interface{}{"replicas": int64(1), "minReplicas": int64(2), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+						map[string]interface{}{"replicas": int64(5), "maxReplicas": int64(3), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+					},
+				},
+			},
+			expectCPU:    "7",
+			expectMemory: "9Gi",
+		},
+		{
+			Name: "RayJob",
+			gvk:  schema.GroupVersionKind{Group: rayGroup, Version: "v1", Kind: "RayJob"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"rayClusterSpec": map[string]interface{}{
+						"headGroupSpec": map[string]interface{}{"template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+						"workerGroupSpecs": []interface{}{
+							map[string]interface{}{"replicas": int64(2), "numOfHosts": int64(2), "template": toUnstructured(t, utils.ToPointer(buildPodTemplate("1", "1Gi"))).Object},
+						},
+					},
+				},
+			},
+			expectCPU:    "5",
+			expectMemory: "5Gi",
+		},
+		{
+			Name: "SparkApplication",
+			gvk:  schema.GroupVersionKind{Group: sparkGroup, Version: "v1beta2", Kind: "SparkApplication"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"type":     "Scala",
+					"driver":   map[string]interface{}{"cores": int64(1), "memory": "1g", "memoryOverhead": "1g"},
+					"executor": map[string]interface{}{"instances": int64(2), "coreRequest": "500m", "memory": "5g"},
+				},
+			},
+			// The overhead of the executor is 10% of the memory, 384MiB at least.
+			expectCPU:    "2",
+			expectMemory: "13Gi",
+		},
+		{
+			Name: "SparkApplication with dynamic allocation",
+			gvk:  schema.GroupVersionKind{Group: sparkGroup, Version: "v1beta2", Kind: "SparkApplication"},
+			workload: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"type":              "Python",
+					"driver":            map[string]interface{}{"cores": int64(1), "memory": "1280m"},
+					"executor":          map[string]interface{}{"instances": int64(10), "cores": int64(2), "memory": "1280m"},
+					"dynamicAllocation": map[string]interface{}{"enabled": true, "minExecutors": int64(1)},
+				},
+			},
+			// The overhead of the non-JVM applications is 40% of the memory, 384MiB at least.
+			expectCPU:    "3",
+			expectMemory: "3584Mi",
+		},
+		{
+			Name:         "Workflow with steps is resolved by the largest parallel step set",
+			gvk:          schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"},
+			workload:     buildStepsWorkflow(t),
+			expectCPU:    "4",
+			expectMemory: "4Gi",
+		},
+		{
+			Name:         "Workflow with DAG is resolved by the largest level",
+			gvk:          schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"},
+			workload:     buildDAGWorkflow(t),
+			expectCPU:    "3",
+			expectMemory: "3Gi",
+		},
+	}
+
+	for _, tc := range testCases {
+		var workload *unstructured.Unstructured
+		switch obj := tc.workload.(type) {
+		case nil:
+		case map[string]interface{}:
+			workload = &unstructured.Unstructured{Object: obj}
+		default:
+			workload = toUnstructured(t, obj)
+		}
+
+		minResources, err := GetResolver(tc.gvk).MinResources(rb, workload)
+		if err != nil {
+			t.Errorf("Test case %s failed, unexpected error: %v", tc.Name, err)
+			continue
+		}
+		if cpu := minResources[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse(tc.expectCPU)) != 0 {
+			t.Errorf("Test case %s failed, got cpu: %s, expect: %s", tc.Name, cpu.String(), tc.expectCPU)
+		}
+		if tc.expectMemory != "" {
+			if memory := minResources[corev1.ResourceMemory]; memory.Cmp(resource.MustParse(tc.expectMemory)) != 0 {
+				t.Errorf("Test case %s failed, got memory: %s, expect: %s", tc.Name, memory.String(), tc.expectMemory)
+			}
+		}
+	}
+}
+
+func buildArgoContainerTemplate(t *testing.T, name, cpu, memory string) map[string]interface{} {
+	template := buildPodTemplate(cpu, memory)
+	return map[string]interface{}{
+		"name":      name,
+		"container": toUnstructured(t, &template.Spec.Containers[0]).Object,
+	}
+}
+
+// buildStepsWorkflow builds the Workflow running `prepare` first, then `train` for 3 items and the nested `evaluate`
+// in parallel, the nested `evaluate` runs `score` and the recursive `main`.
+func buildStepsWorkflow(t *testing.T) map[string]interface{} {
+	return map[string]interface{}{
+		"spec": map[string]interface{}{
+			"entrypoint": "main",
+			"templates": []interface{}{
+				map[string]interface{}{
+					"name": "main",
+					"steps": []interface{}{
+						[]interface{}{map[string]interface{}{"name": "prepare", "template": "prepare"}},
+						[]interface{}{
+							map[string]interface{}{"name": "train", "template": "train", "withItems": []interface{}{"a", "b", "c"}},
+							map[string]interface{}{"name": "evaluate", "template": "evaluate"},
+						},
+					},
+				},
+				map[string]interface{}{
+					"name": "evaluate",
+					"steps": []interface{}{
+						[]interface{}{
+							map[string]interface{}{"name": "score", "template": "score"},
+							map[string]interface{}{"name": "again", "template": "main"},
+						},
+					},
+				},
+				buildArgoContainerTemplate(t, "prepare", "2", "2Gi"),
+				buildArgoContainerTemplate(t, "train", "1", "1Gi"),
+				buildArgoContainerTemplate(t, "score", "1", "1Gi"),
+			},
+		},
+	}
+}
+
+// buildDAGWorkflow builds the Workflow running `a` and `b` first, then `c`, `d` and `e` depending on them.
+func buildDAGWorkflow(t *testing.T) map[string]interface{} {
+	return map[string]interface{}{
+		"spec": map[string]interface{}{
+			"entrypoint": "main",
+			"templates": []interface{}{
+				map[string]interface{}{
+					"name": "main",
+					"dag": map[string]interface{}{
+						"tasks": []interface{}{
+							map[string]interface{}{"name": "c", "template": "small", "dependencies": []interface{}{"a"}},
+							map[string]interface{}{"name": "a", "template": "large"},
+							map[string]interface{}{"name": "b", "template": "small"},
+							map[string]interface{}{"name": "d", "template": "small", "depends": "a && (b.Succeeded || b.Failed)"},
+							map[string]interface{}{"name": "e", "template": "small", "depends": "b"},
+						},
+					},
+				},
+				buildArgoContainerTemplate(t, "large", "2", "2Gi"),
+				buildArgoContainerTemplate(t, "small", "1", "1Gi"),
+			},
+		},
+	}
+}
+
+func TestFirstStageResources(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		workload  map[string]interface{}
+		expectCPU string
+	}{
+		{
+			Name:      "Workflow with steps",
+			workload:  buildStepsWorkflow(t),
+			expectCPU: "2",
+		},
+		{
+			Name:      "Workflow with DAG",
+			workload:  buildDAGWorkflow(t),
+			expectCPU: "3",
+		},
+	}
+
+	stagedResolver, ok := GetResolver(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"}).(StagedWorkloadResourceResolver)
+	if !ok {
+		t.Fatalf("The resolver of Workflow should be a StagedWorkloadResourceResolver")
+	}
+	for _, tc := range testCases {
+		resources, err := stagedResolver.FirstStageResources(&workv1alpha2.ResourceBinding{}, &unstructured.Unstructured{Object: tc.workload})
+		if err != nil {
+			t.Errorf("Test case %s failed, unexpected error: %v", tc.Name, err)
+			continue
+		}
+		if cpu := resources[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse(tc.expectCPU)) != 0 {
+			t.Errorf("Test case %s failed, got cpu: %s, expect: %s", tc.Name, cpu.String(), tc.expectCPU)
+		}
+	}
+}
+
+func TestExtendedResources(t *testing.T) {
+	gpu := corev1.ResourceName("nvidia.com/gpu")
+	rdma := corev1.ResourceName("rdma/hca")
+	hugepages := corev1.ResourceName("hugepages-1Gi")
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("4"),
+						hugepages:          resource.MustParse("512Gi"),
+					},
+					// The extended resources are set in the limits only.
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("8"),
+						gpu:                resource.MustParse("8"),
+						rdma:               resource.MustParse("1"),
+					},
+				},
+			}},
+		},
+	}
+	workload := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: utils.ToPointer(int32(20000)),
+			Template: template,
+		},
+	}
+
+	minResources, err := GetResolver(appsv1.SchemeGroupVersion.WithKind("Deployment")).MinResources(&workv1alpha2.ResourceBinding{}, toUnstructured(t, workload))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect := map[corev1.ResourceName]string{
+		corev1.ResourceCPU: "80000",
+		gpu:                "160000",
+		rdma:               "20000",
+		hugepages:          "10000Ti",
+	}
+	for name, value := range expect {
+		if quantity := minResources[name]; quantity.Cmp(resource.MustParse(value)) != 0 {
+			t.Errorf("Test case %s failed, got: %s, expect: %s", name, quantity.String(), value)
+		}
+	}
+}
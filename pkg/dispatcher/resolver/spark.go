@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	sparkGroup = "sparkoperator.k8s.io"
+
+	// The defaults of the Spark on Kubernetes, the memory overhead is a factor of the memory, 384MiB at least.
+	defaultSparkMemory               = "1g"
+	defaultSparkMemoryOverhead       = 0.1
+	defaultSparkNonJVMMemoryOverhead = 0.4
+	minSparkMemoryOverhead           = 384 * 1024 * 1024
+)
+
+func init() {
+	RegisterResolver(schema.GroupVersionKind{Group: sparkGroup, Version: "v1beta2", Kind: "SparkApplication"}, &sparkResolver{})
+}
+
+// sparkApplicationSpec is the part of the SparkApplicationSpec used to compute the resources,
+// we parse it by ourselves to avoid depending on the Spark operator.
+type sparkApplicationSpec struct {
+	Type                 string   `json:"type,omitempty"`
+	MemoryOverheadFactor *string  `json:"memoryOverheadFactor,omitempty"`
+	Driver               sparkPod `json:"driver,omitempty"`
+	Executor             sparkPod `json:"executor,omitempty"`
+	DynamicAllocation    *struct {
+		Enabled          bool   `json:"enabled,omitempty"`
+		InitialExecutors *int32 `json:"initialExecutors,omitempty"`
+		MinExecutors     *int32 `json:"minExecutors,omitempty"`
+	} `json:"dynamicAllocation,omitempty"`
+}
+
+type sparkPod struct {
+	Instances      *int32  `json:"instances,omitempty"`
+	Cores          *int32  `json:"cores,omitempty"`
+	CoreRequest    *string `json:"coreRequest,omitempty"`
+	Memory         *string `json:"memory,omitempty"`
+	MemoryOverhead *string `json:"memoryOverhead,omitempty"`
+}
+
+// sparkResolver treats the driver and the executors started with the application as the gang,
+// the resources are computed by the Spark settings instead of the pod templates.
+type sparkResolver struct{}
+
+func (sr *sparkResolver) MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	if workload == nil {
+		return replicasResources(rb), nil
+	}
+
+	object, found, err := unstructured.NestedMap(workload.Object, "spec")
+	if err != nil || !found {
+		return nil, fmt.Errorf("failed to get the spec of %s, err: %v", workload.GetKind(), err)
+	}
+	spec := &sparkApplicationSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object, spec); err != nil {
+		return nil, fmt.Errorf("failed to convert the spec of %s, err: %v", workload.GetKind(), err)
+	}
+
+	overheadFactor := defaultSparkMemoryOverhead
+	if spec.Type == "Python" || spec.Type == "R" {
+		overheadFactor = defaultSparkNonJVMMemoryOverhead
+	}
+	if spec.MemoryOverheadFactor != nil {
+		if overheadFactor, err = strconv.ParseFloat(*spec.MemoryOverheadFactor, 64); err != nil {
+			return nil, fmt.Errorf("invalid memoryOverheadFactor %q, err: %v", *spec.MemoryOverheadFactor, err)
+		}
+	}
+
+	minResources, err := spec.Driver.requests(overheadFactor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid driver of %s, err: %v", workload.GetKind(), err)
+	}
+	executorRequests, err := spec.Executor.requests(overheadFactor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid executor of %s, err: %v", workload.GetKind(), err)
+	}
+	addResourceList(minResources, multiplyResourceList(executorRequests, spec.executors()))
+	return minResources, nil
+}
+
+// executors returns the number of the executors started with the application, the dynamic allocation
+// starts with the initialExecutors, or the minExecutors if not set.
+func (spec *sparkApplicationSpec) executors() int32 {
+	if spec.DynamicAllocation != nil && spec.DynamicAllocation.Enabled {
+		switch {
+		case spec.DynamicAllocation.InitialExecutors != nil:
+			return *spec.DynamicAllocation.InitialExecutors
+		case spec.DynamicAllocation.MinExecutors != nil:
+			return *spec.DynamicAllocation.MinExecutors
+		}
+	}
+	if spec.Executor.Instances != nil {
+		return *spec.Executor.Instances
+	}
+	return 1
+}
+
+// requests returns the resources requested by the pod, the cpu is the coreRequest or the cores,
+// the memory is the memory plus its overhead.
+func (pod *sparkPod) requests(overheadFactor float64) (corev1.ResourceList, error) {
+	cpu := *resource.NewQuantity(1, resource.DecimalSI)
+	switch {
+	case pod.CoreRequest != nil:
+		quantity, err := resource.ParseQuantity(*pod.CoreRequest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coreRequest %q: %v", *pod.CoreRequest, err)
+		}
+		cpu = quantity
+	case pod.Cores != nil:
+		cpu = *resource.NewQuantity(int64(*pod.Cores), resource.DecimalSI)
+	}
+
+	memory := defaultSparkMemory
+	if pod.Memory != nil {
+		memory = *pod.Memory
+	}
+	memoryBytes, err := parseSparkMemory(memory)
+	if err != nil {
+		return nil, err
+	}
+	overheadBytes := max(int64(float64(memoryBytes)*overheadFactor), minSparkMemoryOverhead)
+	if pod.MemoryOverhead != nil {
+		if overheadBytes, err = parseSparkMemory(*pod.MemoryOverhead); err != nil {
+			return nil, err
+		}
+	}
+
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    cpu,
+		corev1.ResourceMemory: *resource.NewQuantity(memoryBytes+overheadBytes, resource.BinarySI),
+	}, nil
+}
+
+// sparkMemoryUnits are the binary units of the JVM memory format, the longer suffixes go first.
+var sparkMemoryUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30}, {"tb", 1 << 40},
+	{"k", 1 << 10}, {"m", 1 << 20}, {"g", 1 << 30}, {"t", 1 << 40}, {"b", 1},
+}
+
+// parseSparkMemory parses the memory in the JVM format like `512m` or `2g` into bytes, the suffixes are binary
+// units and the value without a suffix is in MiB, the same as the Spark memory settings.
+func parseSparkMemory(memory string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(memory))
+	multiplier := int64(1 << 20)
+	for _, unit := range sparkMemoryUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, multiplier = strings.TrimSuffix(value, unit.suffix), unit.multiplier
+			break
+		}
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid memory %q", memory)
+	}
+	return number * multiplier, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ReplicaRequests returns the resources requested by a replica of the workload with the pod template at
+// `spec.template`, like the Deployment and the Job, it returns false for the other workloads.
+func ReplicaRequests(workload *unstructured.Unstructured) (corev1.ResourceList, bool) {
+	template, found, err := unstructured.NestedMap(workload.Object, "spec", "template", "spec")
+	if err != nil || !found {
+		return nil, false
+	}
+	spec := &corev1.PodSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, spec); err != nil {
+		return nil, false
+	}
+	return podRequests(spec), true
+}
+
+// podRequests returns the resources requested by a pod, it is the max of
+// the sum of the containers and each init container, plus the overhead.
+func podRequests(spec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for i := range spec.Containers {
+		addResourceList(requests, containerRequests(&spec.Containers[i]))
+	}
+	for i := range spec.InitContainers {
+		maxResourceList(requests, containerRequests(&spec.InitContainers[i]))
+	}
+	addResourceList(requests, spec.Overhead)
+	return requests
+}
+
+// containerRequests returns the resources requested by a container, the limit is the request of a resource which
+// doesn't set the request, like the apiserver defaults it, the extended resources like `nvidia.com/gpu` are often
+// set in the limits only.
+func containerRequests(container *corev1.Container) corev1.ResourceList {
+	if len(container.Resources.Limits) == 0 {
+		return container.Resources.Requests
+	}
+	requests := container.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = corev1.ResourceList{}
+	}
+	for name, quantity := range container.Resources.Limits {
+		if _, found := requests[name]; !found {
+			requests[name] = quantity.DeepCopy()
+		}
+	}
+	return requests
+}
+
+// addResourceList adds the resources in new to list.
+func addResourceList(list, new corev1.ResourceList) {
+	for name, quantity := range new {
+		if value, ok := list[name]; !ok {
+			list[name] = quantity.DeepCopy()
+		} else {
+			value.Add(quantity)
+			list[name] = value
+		}
+	}
+}
+
+// maxResourceList sets list to the greater of list/newList for every resource in newList.
+func maxResourceList(list, newList corev1.ResourceList) {
+	for name, quantity := range newList {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// multiplyResourceList returns the list multiplied by the count.
+func multiplyResourceList(list corev1.ResourceList, count int32) corev1.ResourceList {
+	result := corev1.ResourceList{}
+	for name, quantity := range list {
+		// Multiply the quantity itself, the milli value of the large resources like the hugepages would overflow.
+		value := quantity.DeepCopy()
+		value.Mul(int64(count))
+		result[name] = value
+	}
+	return result
+}
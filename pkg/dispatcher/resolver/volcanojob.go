@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func init() {
+	RegisterResolver(batchv1alpha1.SchemeGroupVersion.WithKind("Job"), &volcanoJobResolver{})
+}
+
+// volcanoJobResolver computes the gang resources the same as the minResources of the PodGroup created by the volcano
+// job controller, so the capacity of the whole gang is checked before the VolcanoJob is dispatched.
+type volcanoJobResolver struct{}
+
+func (vr *volcanoJobResolver) MinResources(rb *workv1alpha2.ResourceBinding, workload *unstructured.Unstructured) (corev1.ResourceList, error) {
+	if workload == nil {
+		return replicasResources(rb), nil
+	}
+
+	job := &batchv1alpha1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(workload.Object, job); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured to volcano Job, err: %v", err)
+	}
+
+	totalMinAvailable := int32(0)
+	for _, task := range job.Spec.Tasks {
+		totalMinAvailable += taskMinAvailable(task)
+	}
+	// If the minAvailable is not set, the minAvailable of all the tasks are required, like the volcano webhook defaults.
+	minAvailable := job.Spec.MinAvailable
+	if minAvailable <= 0 {
+		minAvailable = totalMinAvailable
+	}
+
+	minResources := corev1.ResourceList{}
+	if minAvailable < totalMinAvailable {
+		// The gang is the first minAvailable pods of the tasks in order.
+		for _, task := range job.Spec.Tasks {
+			if minAvailable <= 0 {
+				break
+			}
+			replicas := min(task.Replicas, minAvailable)
+			addResourceList(minResources, multiplyResourceList(podRequests(&task.Template.Spec), replicas))
+			minAvailable -= replicas
+		}
+		return minResources, nil
+	}
+
+	// The gang is the minAvailable pods of each task, then filled up to the minAvailable of the job by the rest replicas.
+	for _, task := range job.Spec.Tasks {
+		addResourceList(minResources, multiplyResourceList(podRequests(&task.Template.Spec), taskMinAvailable(task)))
+	}
+	left := minAvailable - totalMinAvailable
+	for _, task := range job.Spec.Tasks {
+		if left <= 0 {
+			break
+		}
+		replicas := min(task.Replicas-taskMinAvailable(task), left)
+		if replicas <= 0 {
+			continue
+		}
+		addResourceList(minResources, multiplyResourceList(podRequests(&task.Template.Spec), replicas))
+		left -= replicas
+	}
+	return minResources, nil
+}
+
+// taskMinAvailable returns the minAvailable of the task, it's defaulted to the replicas by the volcano webhook.
+func taskMinAvailable(task batchv1alpha1.TaskSpec) int32 {
+	if task.MinAvailable != nil {
+		return min(*task.MinAvailable, task.Replicas)
+	}
+	return task.Replicas
+}
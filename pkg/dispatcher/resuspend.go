@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+	"volcano.sh/volcano-global/pkg/features"
+)
+
+// resuspendGrown suspends the dispatched ResourceBindings again when their workloads grow, like scaled up,
+// and their queues opt in by the `volcano.sh/resuspend-on-growth` annotation, so they go through the admission
+// again in the next rounds and the growth is subject to the queue capacity.
+func (dispatcher *Dispatcher) resuspendGrown(cp *controlPlane, ssn *dispatcherframework.Session) {
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus != api.Dispatched || rbi.IsCompleted() || !rbi.IsGrown() ||
+			!dispatcher.ownsResourceBinding(ssn, rbi) {
+			continue
+		}
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		queue := ssn.Snapshot.QueueInfos[queueName]
+		if !resuspendsOnGrowth(ssn, rbi) {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+		message := fmt.Sprintf("The workload grows after it was dispatched, wait for queue %s to admit it again.", queueName)
+		cp.logger().Info(3, queueName, key, "Resuspend ResourceBinding", "message", message)
+
+		ssn.Evict(rbi)
+		cp.cache.SuspendResourceBinding(key)
+		cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+			Type:    api.DispatchedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  api.WorkloadGrownReason,
+			Message: message,
+		})
+		dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+			Queue: queueName, Evicted: true, Reason: api.WorkloadGrownReason, Message: message}, rbi, queue, nil)
+	}
+}
+
+// resuspendsOnGrowth checks whether the queue of the ResourceBindingInfo opts in to suspending the grown workloads
+// again by the `volcano.sh/resuspend-on-growth` annotation.
+func resuspendsOnGrowth(ssn *dispatcherframework.Session, rbi *api.ResourceBindingInfo) bool {
+	queue, found := ssn.Snapshot.QueueInfos[ssn.GetResourceBindingInfoQueue(rbi)]
+	return found && queue.Queue != nil && queue.Queue.Annotations[api.ResuspendOnGrowthAnnotationKey] == "true"
+}
+
+// resuspendRescheduled suspends the dispatched ResourceBindings again when Karmada reschedules them to the different
+// clusters, like after the failures of the clusters, and the RedispatchOnReschedule feature gate is enabled, so they
+// go through the admission again in the next rounds and the queue accounting reflects the new placement.
+// The ones released toward the elastic clusters speculatively are rescheduled by design, they're skipped.
+func (dispatcher *Dispatcher) resuspendRescheduled(cp *controlPlane, ssn *dispatcherframework.Session) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.RedispatchOnReschedule) {
+		return
+	}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus != api.Dispatched || rbi.IsCompleted() || rbi.IsSpeculative() || !rbi.IsRescheduled() ||
+			!dispatcher.ownsResourceBinding(ssn, rbi) {
+			continue
+		}
+		// It's suspended again by resuspendGrown in the round already.
+		if rbi.IsGrown() && resuspendsOnGrowth(ssn, rbi) {
+			continue
+		}
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+
+		key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+		message := fmt.Sprintf("Karmada reschedules the workload from clusters [%s] to [%s] after it was dispatched, "+
+			"wait for queue %s to admit it again.", strings.Join(rbi.AdmittedClusters, ","),
+			strings.Join(rbi.ScheduledClusters(), ","), queueName)
+		cp.logger().Info(3, queueName, key, "Resuspend rescheduled ResourceBinding", "message", message)
+
+		ssn.Evict(rbi)
+		cp.cache.SuspendResourceBinding(key)
+		cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+			Type:    api.DispatchedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  api.RescheduledReason,
+			Message: message,
+		})
+		dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+			Queue: queueName, Evicted: true, Reason: api.RescheduledReason, Message: message},
+			rbi, ssn.Snapshot.QueueInfos[queueName], nil)
+	}
+}
+
+const (
+	// unadmittedPolicyAccount counts the unadmitted ResourceBindings into the usage of their queues as they are.
+	unadmittedPolicyAccount = "account"
+	// unadmittedPolicyResuspend suspends the unadmitted ResourceBindings, so they wait for their queues to admit them.
+	unadmittedPolicyResuspend = "resuspend"
+)
+
+// handleUnadmitted handles the ResourceBindings created unsuspended and never admitted by their queues, like the
+// suspension webhook is installed with the `Ignore` failurePolicy and skipped. They're counted into the usage of
+// their queues and the metrics by default, and suspended when the UnadmittedPolicy is `resuspend`.
+func (dispatcher *Dispatcher) handleUnadmitted(cp *controlPlane, ssn *dispatcherframework.Session, policy string) {
+	counts := map[string]int{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if !rbi.Unadmitted || rbi.DispatchStatus != api.Dispatched || rbi.IsCompleted() ||
+			!dispatcher.ownsResourceBinding(ssn, rbi) {
+			continue
+		}
+		// It's suspended again by resuspendGrown, resuspendRescheduled or resuspendOnClusterFailure in the round already.
+		if resuspendedInRound(ssn, rbi) || len(notReadyClusters(ssn, rbi)) > 0 {
+			continue
+		}
+		queueName := ssn.GetResourceBindingInfoQueue(rbi)
+		key := types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}
+		if policy != unadmittedPolicyResuspend {
+			counts[queueName]++
+			cp.logger().Info(4, queueName, key, "ResourceBinding runs without the admission of its queue")
+			continue
+		}
+
+		message := fmt.Sprintf("The workload was created without being suspended, wait for queue %s to admit it.", queueName)
+		cp.logger().Info(3, queueName, key, "Resuspend unadmitted ResourceBinding", "message", message)
+
+		ssn.Evict(rbi)
+		cp.cache.SuspendResourceBinding(key)
+		cp.cache.UpdateResourceBindingCondition(key, metav1.Condition{
+			Type:    api.DispatchedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  api.UnadmittedReason,
+			Message: message,
+		})
+		dispatcher.recordDecision(cp, api.Decision{Time: time.Now(), Namespace: key.Namespace, Name: key.Name,
+			Queue: queueName, Evicted: true, Reason: api.UnadmittedReason, Message: message},
+			rbi, ssn.Snapshot.QueueInfos[queueName], nil)
+	}
+	metrics.UpdateUnadmittedResourceBindings(cp.name, counts)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"reflect"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/features"
+)
+
+func TestResuspendGrown(t *testing.T) {
+	cpu := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)}
+	}
+	newQueue := func(name string, resuspend bool) *schedulingapi.QueueInfo {
+		queue := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if resuspend {
+			queue.Annotations = map[string]string{api.ResuspendOnGrowthAnnotationKey: "true"}
+		}
+		return &schedulingapi.QueueInfo{Name: name, Queue: queue}
+	}
+
+	testCases := []struct {
+		Name            string
+		queue           string
+		status          api.DispatchStatus
+		admitted        corev1.ResourceList
+		expectSuspended []types.NamespacedName
+	}{
+		{
+			Name:            "Grown workload in the opted in queue",
+			queue:           "resuspend",
+			status:          api.Dispatched,
+			admitted:        cpu("2"),
+			expectSuspended: []types.NamespacedName{{Namespace: "ns", Name: "rb"}},
+		},
+		{
+			Name:     "Grown workload in the other queue",
+			queue:    "default",
+			status:   api.Dispatched,
+			admitted: cpu("2"),
+		},
+		{
+			Name:     "Not grown workload",
+			queue:    "resuspend",
+			status:   api.Dispatched,
+			admitted: cpu("4"),
+		},
+		{
+			Name:   "Suspended workload",
+			queue:  "resuspend",
+			status: api.Pending,
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding:   &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb", UID: "rb"}},
+			Queue:             tc.queue,
+			DispatchStatus:    tc.status,
+			MinResources:      cpu("4"),
+			AdmittedResources: tc.admitted,
+		}
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue: "default",
+			QueueInfos: map[string]*schedulingapi.QueueInfo{
+				"default":   newQueue("default", false),
+				"resuspend": newQueue("resuspend", true),
+			},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{rbi.ResourceBinding.UID: rbi},
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10)}
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		dispatcher.resuspendGrown(cp, ssn)
+		ssn.CloseSession()
+
+		if !reflect.DeepEqual(fc.suspended, tc.expectSuspended) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, fc.suspended, tc.expectSuspended)
+		}
+	}
+}
+
+func TestResuspendRescheduled(t *testing.T) {
+	targetClusters := func(names ...string) []workv1alpha2.TargetCluster {
+		clusters := make([]workv1alpha2.TargetCluster, 0, len(names))
+		for _, name := range names {
+			clusters = append(clusters, workv1alpha2.TargetCluster{Name: name, Replicas: 1})
+		}
+		return clusters
+	}
+
+	testCases := []struct {
+		Name            string
+		enabled         bool
+		status          api.DispatchStatus
+		admitted        []string
+		scheduled       []workv1alpha2.TargetCluster
+		speculative     string
+		expectSuspended []types.NamespacedName
+	}{
+		{
+			Name:            "Rescheduled to the other clusters",
+			enabled:         true,
+			status:          api.Dispatched,
+			admitted:        []string{"member1", "member2"},
+			scheduled:       targetClusters("member2", "member3"),
+			expectSuspended: []types.NamespacedName{{Namespace: "ns", Name: "rb"}},
+		},
+		{
+			Name:      "Scheduled to the same clusters in the other order",
+			enabled:   true,
+			status:    api.Dispatched,
+			admitted:  []string{"member1", "member2"},
+			scheduled: targetClusters("member2", "member1"),
+		},
+		{
+			Name:      "Feature gate disabled",
+			status:    api.Dispatched,
+			admitted:  []string{"member1"},
+			scheduled: targetClusters("member2"),
+		},
+		{
+			Name:      "Not scheduled since it was dispatched",
+			enabled:   true,
+			status:    api.Dispatched,
+			scheduled: targetClusters("member2"),
+		},
+		{
+			Name:        "Released speculatively",
+			enabled:     true,
+			status:      api.Dispatched,
+			admitted:    []string{"elastic"},
+			scheduled:   targetClusters("member1"),
+			speculative: "elastic",
+		},
+	}
+
+	for _, tc := range testCases {
+		featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.RedispatchOnReschedule, tc.enabled)
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb", UID: "rb"},
+				Spec:       workv1alpha2.ResourceBindingSpec{Clusters: tc.scheduled},
+			},
+			Queue:              "default",
+			DispatchStatus:     tc.status,
+			AdmittedClusters:   tc.admitted,
+			SpeculativeCluster: tc.speculative,
+		}
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue:         "default",
+			QueueInfos:           map[string]*schedulingapi.QueueInfo{"default": {Name: "default"}},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{rbi.ResourceBinding.UID: rbi},
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10)}
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		dispatcher.resuspendRescheduled(cp, ssn)
+		ssn.CloseSession()
+
+		if !reflect.DeepEqual(fc.suspended, tc.expectSuspended) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, fc.suspended, tc.expectSuspended)
+		}
+	}
+}
+
+func TestHandleUnadmitted(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		policy          string
+		unadmitted      bool
+		status          api.DispatchStatus
+		expectSuspended []types.NamespacedName
+	}{
+		{
+			Name:            "Resuspend the unadmitted workload",
+			policy:          unadmittedPolicyResuspend,
+			unadmitted:      true,
+			status:          api.Dispatched,
+			expectSuspended: []types.NamespacedName{{Namespace: "ns", Name: "rb"}},
+		},
+		{
+			Name:       "Account the unadmitted workload",
+			policy:     unadmittedPolicyAccount,
+			unadmitted: true,
+			status:     api.Dispatched,
+		},
+		{
+			Name:       "Account by default",
+			unadmitted: true,
+			status:     api.Dispatched,
+		},
+		{
+			Name:   "Admitted workload",
+			policy: unadmittedPolicyResuspend,
+			status: api.Dispatched,
+		},
+	}
+
+	for _, tc := range testCases {
+		rbi := &api.ResourceBindingInfo{
+			ResourceBinding: &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb", UID: "rb"}},
+			Queue:           "default",
+			DispatchStatus:  tc.status,
+			Unadmitted:      tc.unadmitted,
+		}
+		snapshot := &cache.DispatcherCacheSnapshot{
+			DefaultQueue:         "default",
+			QueueInfos:           map[string]*schedulingapi.QueueInfo{"default": {Name: "default"}},
+			ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{rbi.ResourceBinding.UID: rbi},
+		}
+
+		fc := &fakeCache{snapshot: snapshot}
+		dispatcher := &Dispatcher{}
+		cp := &controlPlane{name: defaultControlPlane, cache: fc, decisions: newDecisionRecorder(10)}
+		ssn := dispatcherframework.OpenSession(fc, nil)
+		dispatcher.handleUnadmitted(cp, ssn, tc.policy)
+		ssn.CloseSession()
+
+		if !reflect.DeepEqual(fc.suspended, tc.expectSuspended) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, fc.suspended, tc.expectSuspended)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+	"volcano.sh/volcano-global/pkg/dispatcher/metrics"
+)
+
+const (
+	// shardGroupLabelKey is the label of the shard Leases, its value is the group of the dispatcher replicas.
+	shardGroupLabelKey = "volcano.sh/dispatcher-shard-group"
+	// shardVirtualNodes is the number of the virtual nodes of each member on the hash ring,
+	// so the queues are divided evenly and only the ones of the changed member move.
+	shardVirtualNodes = 128
+
+	defaultShardLeaseNamespace = "volcano-global"
+	defaultShardLeaseDuration  = 15 * time.Second
+)
+
+// shardMembership divides the queues among the dispatcher replicas of the same group by consistent hashing, so each
+// queue is admitted by one replica against its whole capacity and the replicas never overcommit it together.
+// Each replica renews its own Lease, and the replicas whose Leases are not expired are the members of the ring.
+// When the members change, a queue moved to this replica is not owned until the others observe the change,
+// so a queue is never dispatched by two replicas at the same time.
+type shardMembership struct {
+	kubeClient    kubernetes.Interface
+	namespace     string
+	group         string
+	identity      string
+	leaseDuration time.Duration
+
+	mutex sync.RWMutex
+	// ring is the hash ring of the current members, it is nil before this replica joins.
+	ring *hashRing
+	// previousRing is the hash ring before the last change of the members.
+	previousRing *hashRing
+	// settledTime is when all the members have observed the last change of the members.
+	settledTime time.Time
+	// renewTime is the last time the Lease of this replica was renewed, it owns nothing after its Lease expires.
+	renewTime time.Time
+}
+
+func newShardMembership(kubeClient kubernetes.Interface, namespace, group, identity string, leaseDuration time.Duration) *shardMembership {
+	return &shardMembership{
+		kubeClient:    kubeClient,
+		namespace:     namespace,
+		group:         group,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+	}
+}
+
+// renewPeriod is the period of renewing the Lease and observing the members, the members see a change within it.
+func (sm *shardMembership) renewPeriod() time.Duration {
+	return sm.leaseDuration / 3
+}
+
+func (sm *shardMembership) leaseName() string {
+	return fmt.Sprintf("%s-%s", sm.group, sm.identity)
+}
+
+// run renews the Lease of this replica until the stopCh is closed, then releases it so the others take over quickly.
+func (sm *shardMembership) run(stopCh <-chan struct{}) {
+	wait.Until(func() { sm.sync(time.Now()) }, sm.renewPeriod(), stopCh)
+
+	err := sm.kubeClient.CoordinationV1().Leases(sm.namespace).Delete(context.TODO(), sm.leaseName(), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to release the shard Lease <%s/%s>, err: %v", sm.namespace, sm.leaseName(), err)
+	}
+}
+
+// sync renews the Lease of this replica, then rebuilds the hash ring by the Leases which are not expired.
+func (sm *shardMembership) sync(now time.Time) {
+	if err := sm.renew(now); err != nil {
+		klog.Errorf("Failed to renew the shard Lease <%s/%s>, err: %v", sm.namespace, sm.leaseName(), err)
+		return
+	}
+	leases, err := sm.kubeClient.CoordinationV1().Leases(sm.namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", shardGroupLabelKey, sm.group),
+	})
+	if err != nil {
+		klog.Errorf("Failed to list the shard Leases of group <%s>, err: %v", sm.group, err)
+		return
+	}
+
+	members := []string{sm.identity}
+	for _, lease := range leases.Items {
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == sm.identity || isLeaseExpired(&lease, now) {
+			continue
+		}
+		members = append(members, *lease.Spec.HolderIdentity)
+	}
+	sm.setMembers(members, now)
+}
+
+func (sm *shardMembership) renew(now time.Time) error {
+	leases := sm.kubeClient.CoordinationV1().Leases(sm.namespace)
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       ptr.To(sm.identity),
+		LeaseDurationSeconds: ptr.To(int32(sm.leaseDuration.Seconds())),
+		RenewTime:            &metav1.MicroTime{Time: now},
+	}
+	lease, err := leases.Get(context.TODO(), sm.leaseName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		spec.AcquireTime = &metav1.MicroTime{Time: now}
+		_, err = leases.Create(context.TODO(), &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: sm.namespace,
+				Name:      sm.leaseName(),
+				Labels:    map[string]string{shardGroupLabelKey: sm.group},
+			},
+			Spec: spec,
+		}, metav1.CreateOptions{})
+	case err == nil:
+		spec.AcquireTime = lease.Spec.AcquireTime
+		lease.Spec = spec
+		_, err = leases.Update(context.TODO(), lease, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	sm.mutex.Lock()
+	sm.renewTime = now
+	sm.mutex.Unlock()
+	return nil
+}
+
+// setMembers rebuilds the hash ring when the members change, the change is settled after a renew period,
+// when all the members have observed it.
+func (sm *shardMembership) setMembers(members []string, now time.Time) {
+	ring := newHashRing(members)
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	if sm.ring != nil && sm.ring.equal(ring) {
+		return
+	}
+	klog.V(2).Infof("The members of dispatcher shard group <%s> change to %v.", sm.group, ring.members)
+	sm.previousRing = sm.ring
+	sm.ring = ring
+	sm.settledTime = now.Add(sm.renewPeriod() + time.Second)
+	metrics.UpdateShardMembers(sm.group, len(ring.members))
+}
+
+// owns checks whether the queue is dispatched by this replica. It owns nothing before it joins or after its
+// Lease expires, and owns the queues moved to it only after the change of the members is settled.
+func (sm *shardMembership) owns(queue string, now time.Time) bool {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	if sm.ring == nil || now.Sub(sm.renewTime) > sm.leaseDuration {
+		return false
+	}
+	if sm.ring.owner(queue) != sm.identity {
+		return false
+	}
+	return !now.Before(sm.settledTime) || (sm.previousRing != nil && sm.previousRing.owner(queue) == sm.identity)
+}
+
+func isLeaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return now.After(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
+
+// hashRing is the consistent hash ring of the members, each member has shardVirtualNodes points on it,
+// and a key is owned by the member of the first point at or after the hash of the key.
+type hashRing struct {
+	members []string
+	points  []uint32
+	owners  map[uint32]string
+}
+
+func newHashRing(members []string) *hashRing {
+	ring := &hashRing{owners: map[uint32]string{}}
+	ring.members = append(ring.members, members...)
+	sort.Strings(ring.members)
+	for _, member := range ring.members {
+		for i := 0; i < shardVirtualNodes; i++ {
+			point := hashKey(member + "#" + strconv.Itoa(i))
+			// The collided point is kept by the lexically smaller member, so all the replicas build the same ring.
+			if _, found := ring.owners[point]; found {
+				continue
+			}
+			ring.owners[point] = member
+			ring.points = append(ring.points, point)
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool { return ring.points[i] < ring.points[j] })
+	return ring
+}
+
+func (ring *hashRing) owner(key string) string {
+	if len(ring.points) == 0 {
+		return ""
+	}
+	hash := hashKey(key)
+	index := sort.Search(len(ring.points), func(i int) bool { return ring.points[i] >= hash })
+	if index == len(ring.points) {
+		index = 0
+	}
+	return ring.owners[ring.points[index]]
+}
+
+func (ring *hashRing) equal(other *hashRing) bool {
+	if len(ring.members) != len(other.members) {
+		return false
+	}
+	for i := range ring.members {
+		if ring.members[i] != other.members[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hashKey(key string) uint32 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return hash.Sum32()
+}
+
+// ownsQueue checks whether the ResourceBindings of the queue are dispatched by this replica,
+// all the queues are owned when the sharding is disabled.
+func (dispatcher *Dispatcher) ownsQueue(queue string) bool {
+	return dispatcher.shard == nil || dispatcher.shard.owns(queue, time.Now())
+}
+
+// ownsResourceBinding checks whether the ResourceBinding is dispatched by this replica by its queue in the session.
+func (dispatcher *Dispatcher) ownsResourceBinding(ssn *dispatcherframework.Session, rbi *api.ResourceBindingInfo) bool {
+	return dispatcher.ownsQueue(ssn.GetResourceBindingInfoQueue(rbi))
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHashRing(t *testing.T) {
+	ring := newHashRing([]string{"b", "a", "c"})
+	if !ring.equal(newHashRing([]string{"c", "b", "a"})) {
+		t.Errorf("Test case same members failed, got different rings: %v", ring.members)
+	}
+
+	// Only the queues moved to the new member change their owners.
+	grown := newHashRing([]string{"a", "b", "c", "d"})
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		queue := fmt.Sprintf("queue-%d", i)
+		before, after := ring.owner(queue), grown.owner(queue)
+		if before != after && after != "d" {
+			t.Errorf("Test case add member failed, queue %s moved from %s to %s", queue, before, after)
+		}
+		counts[after]++
+	}
+	for _, member := range grown.members {
+		if counts[member] < 100 {
+			t.Errorf("Test case balance failed, member %s owns %d of 1000 queues", member, counts[member])
+		}
+	}
+
+	if owner := newHashRing(nil).owner("q"); owner != "" {
+		t.Errorf("Test case empty ring failed, got: %s expect: empty", owner)
+	}
+}
+
+func TestShardMembership(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	now := time.Now()
+	a := newShardMembership(kubeClient, "volcano-global", "group", "a", 15*time.Second)
+	b := newShardMembership(kubeClient, "volcano-global", "group", "b", 15*time.Second)
+
+	if a.owns("q", now) {
+		t.Errorf("Test case not joined failed, got owned expect not owned")
+	}
+	a.sync(now)
+	if a.owns("q", now) {
+		t.Errorf("Test case joined but not settled failed, got owned expect not owned")
+	}
+	now = now.Add(a.renewPeriod() + time.Second)
+	a.sync(now)
+	if !a.owns("q", now) {
+		t.Errorf("Test case single member failed, got not owned expect owned")
+	}
+
+	// The member b joins, the queues kept by a are still owned while the change is settling.
+	b.sync(now)
+	a.sync(now)
+	settled := now.Add(a.renewPeriod() + time.Second)
+	for i := 0; i < 100; i++ {
+		queue := fmt.Sprintf("queue-%d", i)
+		if b.owns(queue, now) {
+			t.Errorf("Test case settling failed, queue %s is owned by the new member", queue)
+		}
+		if a.ring.owner(queue) == "a" && !a.owns(queue, now) {
+			t.Errorf("Test case settling failed, queue %s kept by a is not owned", queue)
+		}
+		if owners := btoi(a.owns(queue, settled)) + btoi(b.owns(queue, settled)); owners != 1 {
+			t.Errorf("Test case settled failed, queue %s is owned by %d members", queue, owners)
+		}
+	}
+
+	// The Lease of b expires, a takes over all the queues.
+	now = now.Add(time.Minute)
+	a.sync(now)
+	now = now.Add(a.renewPeriod() + time.Second)
+	for i := 0; i < 100; i++ {
+		queue := fmt.Sprintf("queue-%d", i)
+		if !a.owns(queue, now) || b.owns(queue, now) {
+			t.Errorf("Test case member expired failed, queue %s is not taken over", queue)
+		}
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
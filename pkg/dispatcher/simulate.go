@@ -0,0 +1,599 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+const (
+	defaultSimulationPeriod      = time.Second
+	defaultSimulationMaxDuration = 24 * time.Hour
+	// simulationSyncTimeout limits the wait for the cache to observe the changes of a simulated step.
+	simulationSyncTimeout = 30 * time.Second
+)
+
+// SimulatedWorkload is a workload arriving in the simulation, its replicas run for the duration once dispatched.
+type SimulatedWorkload struct {
+	// Arrival is the time the workload is created since the simulation starts.
+	Arrival           metav1.Duration `json:"arrival"`
+	Namespace         string          `json:"namespace"`
+	Name              string          `json:"name"`
+	Queue             string          `json:"queue"`
+	PriorityClassName string          `json:"priorityClassName,omitempty"`
+	Replicas          int32           `json:"replicas"`
+	// CPU and Memory are the requests of each replica.
+	CPU      resource.Quantity `json:"cpu"`
+	Memory   resource.Quantity `json:"memory"`
+	Duration metav1.Duration   `json:"duration"`
+}
+
+// WorkloadGenerator generates the workloads of a queue arriving by the Poisson process, the intervals between
+// them follow the exponential distribution with the mean Interval.
+type WorkloadGenerator struct {
+	Namespace         string            `json:"namespace"`
+	Queue             string            `json:"queue"`
+	PriorityClassName string            `json:"priorityClassName,omitempty"`
+	Count             int               `json:"count"`
+	Start             metav1.Duration   `json:"start"`
+	Interval          metav1.Duration   `json:"interval"`
+	Replicas          int32             `json:"replicas"`
+	CPU               resource.Quantity `json:"cpu"`
+	Memory            resource.Quantity `json:"memory"`
+	Duration          metav1.Duration   `json:"duration"`
+}
+
+// SimulationTrace is the arrivals of the simulation, the workloads listed explicitly and the generated ones.
+type SimulationTrace struct {
+	Workloads  []SimulatedWorkload `json:"workloads,omitempty"`
+	Generators []WorkloadGenerator `json:"generators,omitempty"`
+}
+
+// simulationCSVColumns are the columns of the CSV trace, the header is required and the priorityClassName can be empty.
+var simulationCSVColumns = []string{"arrival", "namespace", "name", "queue", "priorityClassName", "replicas", "cpu", "memory", "duration"}
+
+// ReadSimulationTrace reads the trace in the format, which is `yaml` for the SimulationTrace or `csv` for the
+// workloads with the simulationCSVColumns.
+func ReadSimulationTrace(reader io.Reader, format string) (*SimulationTrace, error) {
+	switch format {
+	case "yaml":
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		trace := &SimulationTrace{}
+		if err := yaml.UnmarshalStrict(data, trace); err != nil {
+			return nil, fmt.Errorf("failed to decode the trace: %v", err)
+		}
+		return trace, nil
+	case "csv":
+		records, err := csv.NewReader(reader).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the trace: %v", err)
+		}
+		if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(simulationCSVColumns, ",") {
+			return nil, fmt.Errorf("expect the header of the trace to be %q", strings.Join(simulationCSVColumns, ","))
+		}
+		trace := &SimulationTrace{}
+		for i, record := range records[1:] {
+			workload, err := parseSimulatedWorkload(record)
+			if err != nil {
+				return nil, fmt.Errorf("invalid workload at line %d of the trace: %v", i+2, err)
+			}
+			trace.Workloads = append(trace.Workloads, workload)
+		}
+		return trace, nil
+	default:
+		return nil, fmt.Errorf("unknown trace format %q, expect yaml or csv", format)
+	}
+}
+
+func parseSimulatedWorkload(record []string) (SimulatedWorkload, error) {
+	workload := SimulatedWorkload{Namespace: record[1], Name: record[2], Queue: record[3], PriorityClassName: record[4]}
+	arrival, err := time.ParseDuration(record[0])
+	if err != nil {
+		return workload, err
+	}
+	replicas, err := strconv.ParseInt(record[5], 10, 32)
+	if err != nil {
+		return workload, err
+	}
+	if workload.CPU, err = resource.ParseQuantity(record[6]); err != nil {
+		return workload, err
+	}
+	if workload.Memory, err = resource.ParseQuantity(record[7]); err != nil {
+		return workload, err
+	}
+	duration, err := time.ParseDuration(record[8])
+	if err != nil {
+		return workload, err
+	}
+	workload.Arrival, workload.Replicas, workload.Duration = metav1.Duration{Duration: arrival}, int32(replicas), metav1.Duration{Duration: duration}
+	return workload, nil
+}
+
+// Expand returns the workloads of the trace and the generated ones in the order of their arrivals, the same seed
+// generates the same workloads.
+func (trace *SimulationTrace) Expand(seed int64) []SimulatedWorkload {
+	random := rand.New(rand.NewSource(seed))
+	workloads := append([]SimulatedWorkload{}, trace.Workloads...)
+	for i, generator := range trace.Generators {
+		arrival := generator.Start.Duration
+		for j := 0; j < generator.Count; j++ {
+			workloads = append(workloads, SimulatedWorkload{
+				Arrival:           metav1.Duration{Duration: arrival},
+				Namespace:         generator.Namespace,
+				Name:              fmt.Sprintf("%s-%d-%d", generator.Queue, i, j),
+				Queue:             generator.Queue,
+				PriorityClassName: generator.PriorityClassName,
+				Replicas:          generator.Replicas,
+				CPU:               generator.CPU,
+				Memory:            generator.Memory,
+				Duration:          generator.Duration,
+			})
+			arrival += time.Duration(random.ExpFloat64() * float64(generator.Interval.Duration))
+		}
+	}
+	sort.SliceStable(workloads, func(i, j int) bool {
+		return workloads[i].Arrival.Duration < workloads[j].Arrival.Duration
+	})
+	return workloads
+}
+
+// SimulationOptions are the inputs of the simulation.
+type SimulationOptions struct {
+	// Configuration is the configuration of the simulated dispatcher, the default one is used when it's nil.
+	Configuration *conf.DispatcherConfiguration
+	// Objects are the Queues, the PriorityClasses and the other objects existing when the simulation starts.
+	Objects   []*unstructured.Unstructured
+	Workloads []SimulatedWorkload
+	// Period is the interval of the rounds while the released workloads keep the others waiting, like the rate limit.
+	Period time.Duration
+	// MaxDuration stops the simulation, the workloads not dispatched by then are reported as pending.
+	MaxDuration time.Duration
+}
+
+// QueueStatistics are the statistics of the workloads of a queue in the simulation.
+type QueueStatistics struct {
+	Queue      string `json:"queue"`
+	Weight     int32  `json:"weight"`
+	Workloads  int    `json:"workloads"`
+	Dispatched int    `json:"dispatched"`
+	Preempted  int    `json:"preempted"`
+	// The wait times are from the arrivals to the first dispatching of the dispatched workloads.
+	WaitMean time.Duration `json:"waitMean"`
+	WaitP50  time.Duration `json:"waitP50"`
+	WaitP95  time.Duration `json:"waitP95"`
+	WaitMax  time.Duration `json:"waitMax"`
+	// CPUShare is the share of the queue in the cpu time of the running workloads, and WeightShare is its share
+	// in the weights of the queues with workloads.
+	CPUShare    float64 `json:"cpuShare"`
+	WeightShare float64 `json:"weightShare"`
+}
+
+// SimulationReport is the result of the simulation.
+type SimulationReport struct {
+	Rounds int `json:"rounds"`
+	// Makespan is the time from the start to the last workload finishes, or the time the simulation stops.
+	Makespan time.Duration      `json:"makespan"`
+	Pending  int                `json:"pending"`
+	Queues   []*QueueStatistics `json:"queues"`
+	// Fairness is the Jain's fairness index of the cpu shares of the queues normalized by their weight shares,
+	// 1 means the cpu time is shared by the weights exactly.
+	Fairness float64 `json:"fairness"`
+}
+
+// simulatedState is the state of a workload in the simulation.
+type simulatedState struct {
+	workload *SimulatedWorkload
+	// running is true from the workload is dispatched until it's preempted or finishes.
+	running  bool
+	finished bool
+	// finish is the time the running workload finishes.
+	finish time.Duration
+	// wait is the time from the arrival to the first dispatching, it's negative before that.
+	wait      time.Duration
+	preempted int
+}
+
+// Simulate runs the real actions and plugins of the configuration over the cache backed by the fake clients, the
+// workloads are created when they arrive and deleted when they finish in the simulated time. The rounds are run
+// when the workloads arrive or finish, and in the period while the released workloads keep the others waiting.
+// The member clusters are not simulated, so the replicas are always feasible, and the plugins depending on the
+// wall clock, like the dispatch windows, see the time the simulation runs instead.
+func Simulate(options SimulationOptions) (*SimulationReport, error) {
+	configuration, err := offlineConfiguration(options.Configuration)
+	if err != nil {
+		return nil, err
+	}
+	period, maxDuration := options.Period, options.MaxDuration
+	if period <= 0 {
+		period = defaultSimulationPeriod
+	}
+	if maxDuration <= 0 {
+		maxDuration = defaultSimulationMaxDuration
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	dc, clients, err := runOfflineCache(configuration, options.Objects, stopCh)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	states := make([]*simulatedState, 0, len(options.Workloads))
+	stateByKey := map[types.NamespacedName]*simulatedState{}
+	cpuTime := map[string]float64{}
+	report := &SimulationReport{}
+	var now time.Duration
+	for {
+		// Create the arrived workloads and delete the finished ones, then wait for the cache to observe them.
+		for len(states) < len(options.Workloads) && options.Workloads[len(states)].Arrival.Duration <= now {
+			workload := &options.Workloads[len(states)]
+			if err := createSimulatedWorkload(clients, workload, start); err != nil {
+				return nil, err
+			}
+			state := &simulatedState{workload: workload, wait: -1}
+			states = append(states, state)
+			stateByKey[types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name}] = state
+		}
+		for _, state := range states {
+			if state.running && state.finish <= now {
+				if err := deleteSimulatedWorkload(clients, state.workload); err != nil {
+					return nil, err
+				}
+				state.running, state.finished = false, true
+			}
+		}
+		if err := waitSimulatedStates(dc, stateByKey); err != nil {
+			return nil, err
+		}
+
+		report.Rounds++
+		released := false
+		for _, decision := range simulateRound(dc, configuration, uint64(report.Rounds)) {
+			key := types.NamespacedName{Namespace: decision.Namespace, Name: decision.Name}
+			state, found := stateByKey[key]
+			if !found || state.finished {
+				continue
+			}
+			switch {
+			case decision.Dispatched:
+				dc.UnSuspendResourceBinding(key)
+				if err := setSimulatedPodGroupPhase(clients, state.workload, schedulingv1beta1.PodGroupRunning); err != nil {
+					return nil, err
+				}
+				state.running, state.finish = true, now+state.workload.Duration.Duration
+				if state.wait < 0 {
+					state.wait = now - state.workload.Arrival.Duration
+				}
+				released = true
+			case decision.Evicted:
+				dc.SuspendResourceBinding(key)
+				if err := setSimulatedPodGroupPhase(clients, state.workload, schedulingv1beta1.PodGroupInqueue); err != nil {
+					return nil, err
+				}
+				state.running = false
+				state.preempted++
+			}
+		}
+
+		// The next round is run when a workload arrives or finishes, or after the period when the released
+		// workloads may keep the others waiting, like the rate limit does.
+		next, waiting := time.Duration(math.MaxInt64), false
+		if len(states) < len(options.Workloads) {
+			next = options.Workloads[len(states)].Arrival.Duration
+		}
+		for _, state := range states {
+			if state.running {
+				next = min(next, state.finish)
+			} else if !state.finished {
+				waiting = true
+			}
+		}
+		if released && waiting {
+			next = min(next, now+period)
+		}
+		if next == time.Duration(math.MaxInt64) {
+			break
+		}
+		next = min(next, maxDuration)
+		accumulateCPUTime(cpuTime, states, next-now)
+		if now = next; now >= maxDuration {
+			break
+		}
+	}
+
+	report.Makespan = now
+	report.Pending = len(options.Workloads)
+	for _, state := range states {
+		if state.wait >= 0 {
+			report.Pending--
+		}
+	}
+	report.Queues, report.Fairness = simulationStatistics(dc, states, options.Workloads, cpuTime)
+	return report, nil
+}
+
+// simulateRound runs the actions in a round like runOnce and returns its decisions.
+func simulateRound(dc *cache.DispatcherCache, configuration *conf.DispatcherConfiguration, cycle uint64) []api.Decision {
+	ssn := dispatcherframework.OpenSession(dc, configuration.Plugins)
+	ssn.SetTieBreaker(configuration.TieBreaker, cycle)
+	ssn.SetPreemptionMatrix(configuration.PreemptionMatrix)
+	ops := &offlineOperations{ssn: ssn, configuration: configuration, cycle: cycle}
+	ssn.SetOperations(ops)
+	for _, name := range strings.Split(configuration.Actions, ",") {
+		if action, found := dispatcherframework.GetAction(strings.TrimSpace(name)); found {
+			action.Execute(ssn)
+		}
+	}
+	ssn.CloseSession()
+	ssn.Snapshot.Release()
+	return ops.decisions
+}
+
+// createSimulatedWorkload creates the Deployment of the workload, its PodGroup and its suspended ResourceBinding
+// like the karmada and volcano controllers do, the ResourceBinding is created at the arrival in the simulated time.
+func createSimulatedWorkload(clients *cache.FakeClients, workload *SimulatedWorkload, start time.Time) error {
+	requests := corev1.ResourceList{corev1.ResourceCPU: workload.CPU, corev1.ResourceMemory: workload.Memory}
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: workload.Namespace,
+			Name:      workload.Name,
+			UID:       types.UID("deployment/" + workload.Namespace + "/" + workload.Name),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &workload.Replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				PriorityClassName: workload.PriorityClassName,
+				Containers:        []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Requests: requests}}},
+			}},
+		},
+	}
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment)
+	if err != nil {
+		return err
+	}
+	gvr := appsv1.SchemeGroupVersion.WithResource("deployments")
+	if _, err := clients.DynamicClient.Resource(gvr).Namespace(workload.Namespace).Create(context.TODO(),
+		&unstructured.Unstructured{Object: object}, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create the Deployment of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+
+	minResources := corev1.ResourceList{}
+	for name, quantity := range requests {
+		total := quantity.DeepCopy()
+		total.Mul(int64(workload.Replicas))
+		minResources[name] = total
+	}
+	podGroup := &schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       workload.Namespace,
+			Name:            workload.Name,
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: workload.Name, UID: deployment.UID}},
+		},
+		Spec: schedulingv1beta1.PodGroupSpec{
+			MinMember:         workload.Replicas,
+			Queue:             workload.Queue,
+			PriorityClassName: workload.PriorityClassName,
+			MinResources:      &minResources,
+		},
+		Status: schedulingv1beta1.PodGroupStatus{Phase: schedulingv1beta1.PodGroupPending},
+	}
+	if _, err := clients.VolcanoClient.SchedulingV1beta1().PodGroups(workload.Namespace).Create(context.TODO(),
+		podGroup, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create the PodGroup of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+
+	rb := &workv1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         workload.Namespace,
+			Name:              workload.Name,
+			UID:               types.UID(workload.Namespace + "/" + workload.Name),
+			CreationTimestamp: metav1.NewTime(start.Add(workload.Arrival.Duration)),
+			Annotations:       map[string]string{schedulingv1beta1.QueueNameAnnotationKey: workload.Queue},
+		},
+		Spec: workv1alpha2.ResourceBindingSpec{
+			Resource: workv1alpha2.ObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Namespace:  workload.Namespace,
+				Name:       workload.Name,
+				UID:        deployment.UID,
+			},
+			Replicas: workload.Replicas,
+			ReplicaRequirements: &workv1alpha2.ReplicaRequirements{
+				ResourceRequest:   requests,
+				PriorityClassName: workload.PriorityClassName,
+			},
+			Suspend: true,
+		},
+	}
+	if _, err := clients.KarmadaClient.WorkV1alpha2().ResourceBindings(workload.Namespace).Create(context.TODO(),
+		rb, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create the ResourceBinding of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+	return nil
+}
+
+// deleteSimulatedWorkload deletes the objects of the finished workload.
+func deleteSimulatedWorkload(clients *cache.FakeClients, workload *SimulatedWorkload) error {
+	if err := clients.KarmadaClient.WorkV1alpha2().ResourceBindings(workload.Namespace).Delete(context.TODO(),
+		workload.Name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete the ResourceBinding of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+	if err := clients.VolcanoClient.SchedulingV1beta1().PodGroups(workload.Namespace).Delete(context.TODO(),
+		workload.Name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete the PodGroup of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+	gvr := appsv1.SchemeGroupVersion.WithResource("deployments")
+	if err := clients.DynamicClient.Resource(gvr).Namespace(workload.Namespace).Delete(context.TODO(),
+		workload.Name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete the Deployment of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+	return nil
+}
+
+// setSimulatedPodGroupPhase sets the phase of the PodGroup of the workload like the volcano scheduler does when its
+// pods are scheduled in the member clusters.
+func setSimulatedPodGroupPhase(clients *cache.FakeClients, workload *SimulatedWorkload, phase schedulingv1beta1.PodGroupPhase) error {
+	podGroups := clients.VolcanoClient.SchedulingV1beta1().PodGroups(workload.Namespace)
+	podGroup, err := podGroups.Get(context.TODO(), workload.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get the PodGroup of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+	podGroup.Status.Phase = phase
+	if _, err := podGroups.UpdateStatus(context.TODO(), podGroup, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update the PodGroup of the workload %s/%s: %v", workload.Namespace, workload.Name, err)
+	}
+	return nil
+}
+
+// waitSimulatedStates waits for the cache to observe the ResourceBindings of the workloads not finished, and the
+// PodGroups of the running ones.
+func waitSimulatedStates(dc *cache.DispatcherCache, states map[types.NamespacedName]*simulatedState) error {
+	return wait.PollUntilContextTimeout(context.TODO(), 5*time.Millisecond, simulationSyncTimeout, true, func(_ context.Context) (bool, error) {
+		snapshot := dc.Snapshot()
+		defer snapshot.Release()
+		observed := 0
+		for _, rbi := range snapshot.ResourceBindingInfos {
+			state, found := states[types.NamespacedName{Namespace: rbi.ResourceBinding.Namespace, Name: rbi.ResourceBinding.Name}]
+			if !found || state.finished || state.running != rbi.IsRunning() {
+				return false, nil
+			}
+			observed++
+		}
+		for _, state := range states {
+			if !state.finished {
+				observed--
+			}
+		}
+		return observed == 0, nil
+	})
+}
+
+// accumulateCPUTime adds the cpu time of the running workloads in the elapsed time to their queues.
+func accumulateCPUTime(cpuTime map[string]float64, states []*simulatedState, elapsed time.Duration) {
+	for _, state := range states {
+		if state.running {
+			cpuTime[state.workload.Queue] += state.workload.CPU.AsApproximateFloat64() * float64(state.workload.Replicas) * elapsed.Seconds()
+		}
+	}
+}
+
+// simulationStatistics returns the statistics of the queues in the order of their names and the fairness of them.
+func simulationStatistics(dc *cache.DispatcherCache, states []*simulatedState, workloads []SimulatedWorkload,
+	cpuTime map[string]float64) ([]*QueueStatistics, float64) {
+	snapshot := dc.Snapshot()
+	defer snapshot.Release()
+
+	queues := map[string]*QueueStatistics{}
+	waits := map[string][]time.Duration{}
+	for i := range workloads {
+		statistics, found := queues[workloads[i].Queue]
+		if !found {
+			statistics = &QueueStatistics{Queue: workloads[i].Queue, Weight: 1}
+			if queue, found := snapshot.QueueInfos[workloads[i].Queue]; found {
+				statistics.Weight = queue.Weight
+			}
+			queues[workloads[i].Queue] = statistics
+		}
+		statistics.Workloads++
+	}
+	for _, state := range states {
+		statistics := queues[state.workload.Queue]
+		statistics.Preempted += state.preempted
+		if state.wait >= 0 {
+			statistics.Dispatched++
+			waits[statistics.Queue] = append(waits[statistics.Queue], state.wait)
+		}
+	}
+
+	var totalCPUTime, totalWeight float64
+	for _, statistics := range queues {
+		totalCPUTime += cpuTime[statistics.Queue]
+		totalWeight += float64(statistics.Weight)
+	}
+	result := make([]*QueueStatistics, 0, len(queues))
+	var sum, sumOfSquares float64
+	for _, statistics := range queues {
+		queueWaits := waits[statistics.Queue]
+		if len(queueWaits) > 0 {
+			sort.Slice(queueWaits, func(i, j int) bool { return queueWaits[i] < queueWaits[j] })
+			var total time.Duration
+			for _, wait := range queueWaits {
+				total += wait
+			}
+			statistics.WaitMean = total / time.Duration(len(queueWaits))
+			statistics.WaitP50 = percentile(queueWaits, 0.5)
+			statistics.WaitP95 = percentile(queueWaits, 0.95)
+			statistics.WaitMax = queueWaits[len(queueWaits)-1]
+		}
+		if totalCPUTime > 0 {
+			statistics.CPUShare = cpuTime[statistics.Queue] / totalCPUTime
+		}
+		if totalWeight > 0 {
+			statistics.WeightShare = float64(statistics.Weight) / totalWeight
+		}
+		if statistics.WeightShare > 0 {
+			normalized := statistics.CPUShare / statistics.WeightShare
+			sum += normalized
+			sumOfSquares += normalized * normalized
+		}
+		result = append(result, statistics)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Queue < result[j].Queue })
+
+	fairness := 1.0
+	if sumOfSquares > 0 {
+		fairness = sum * sum / (float64(len(result)) * sumOfSquares)
+	}
+	return result, fairness
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSimulationQueues = `
+apiVersion: scheduling.volcano.sh/v1beta1
+kind: Queue
+metadata:
+  name: queue-a
+spec:
+  weight: 2
+  capability:
+    cpu: "4"
+status:
+  state: Open
+---
+apiVersion: scheduling.volcano.sh/v1beta1
+kind: Queue
+metadata:
+  name: queue-b
+spec:
+  weight: 1
+  capability:
+    cpu: "4"
+status:
+  state: Open
+`
+
+func TestReadSimulationTrace(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Format        string
+		Trace         string
+		ExpectNames   []string
+		ExpectedError bool
+	}{
+		{
+			Name:   "YAML trace with the generated workloads",
+			Format: "yaml",
+			Trace: `
+workloads:
+- {arrival: 30s, namespace: ns, name: late, queue: queue-a, replicas: 1, cpu: "1", memory: 1Gi, duration: 1m}
+generators:
+- {namespace: ns, queue: queue-b, count: 2, start: 10s, interval: 1h, replicas: 1, cpu: "1", memory: 1Gi, duration: 1m}
+`,
+			ExpectNames: []string{"queue-b-0-0", "late", "queue-b-0-1"},
+		},
+		{
+			Name:   "CSV trace",
+			Format: "csv",
+			Trace: `arrival,namespace,name,queue,priorityClassName,replicas,cpu,memory,duration
+1m,ns,second,queue-a,,2,500m,1Gi,10m
+0s,ns,first,queue-b,high,1,1,2Gi,5m
+`,
+			ExpectNames: []string{"first", "second"},
+		},
+		{
+			Name:          "CSV trace without the header",
+			Format:        "csv",
+			Trace:         "0s,ns,first,queue-b,high,1,1,2Gi,5m\n",
+			ExpectedError: true,
+		},
+		{
+			Name:          "Unknown format",
+			Format:        "json",
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		trace, err := ReadSimulationTrace(strings.NewReader(tc.Trace), tc.Format)
+		if (err != nil) != tc.ExpectedError {
+			t.Errorf("Test case %s failed, got error: %v, expect error: %v", tc.Name, err, tc.ExpectedError)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		var names []string
+		for _, workload := range trace.Expand(1) {
+			names = append(names, workload.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tc.ExpectNames, ",") {
+			t.Errorf("Test case %s failed, got: %v, expect: %v", tc.Name, names, tc.ExpectNames)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	objects, err := ReadCacheDump(strings.NewReader(testSimulationQueues))
+	if err != nil {
+		t.Fatalf("Failed to read the queues: %v", err)
+	}
+	trace, err := ReadSimulationTrace(strings.NewReader(`arrival,namespace,name,queue,priorityClassName,replicas,cpu,memory,duration
+0s,ns,a-1,queue-a,,2,2,1Gi,10s
+1s,ns,a-2,queue-a,,1,4,1Gi,10s
+0s,ns,b-1,queue-b,,1,4,1Gi,20s
+`), "csv")
+	if err != nil {
+		t.Fatalf("Failed to read the trace: %v", err)
+	}
+
+	report, err := Simulate(SimulationOptions{Objects: objects, Workloads: trace.Expand(0)})
+	if err != nil {
+		t.Fatalf("Failed to simulate: %v", err)
+	}
+	// a-2 waits for a-1 to finish by the capability of queue-a.
+	if report.Pending != 0 || report.Makespan != 20*time.Second || len(report.Queues) != 2 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	a, b := report.Queues[0], report.Queues[1]
+	if a.Queue != "queue-a" || a.Dispatched != 2 || a.WaitMax != 9*time.Second || a.WaitP50 != 0 {
+		t.Errorf("Unexpected statistics of queue-a: %+v", a)
+	}
+	if b.Queue != "queue-b" || b.Dispatched != 1 || b.WaitMax != 0 || b.Weight != 1 {
+		t.Errorf("Unexpected statistics of queue-b: %+v", b)
+	}
+	// Both queues run 80 cpu-seconds, so queue-a gets less than its weight.
+	if a.CPUShare != 0.5 || report.Fairness >= 1 {
+		t.Errorf("Unexpected shares, queue-a: %v, fairness: %v", a.CPUShare, report.Fairness)
+	}
+}
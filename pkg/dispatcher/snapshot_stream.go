@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+const (
+	// snapshotStreamService is the service defined in snapshot_stream.proto.
+	snapshotStreamService = "volcano.global.dispatcher.snapshot.v1.SnapshotStream"
+	snapshotStreamMethod  = "/" + snapshotStreamService + "/Watch"
+)
+
+// newSnapshotStreamServer creates the gRPC server streaming the snapshots of the control planes read-only, so the
+// external optimizers and UIs can follow the queues without listing the apiserver. The streams are authenticated
+// like the debug endpoints, the user needs the `get` permission to the non-resource url of the method.
+func (dispatcher *Dispatcher) newSnapshotStreamServer() *grpc.Server {
+	var options []grpc.ServerOption
+	if dispatcher.debugAuthenticator != nil {
+		options = append(options, grpc.StreamInterceptor(dispatcher.debugAuthenticator.streamInterceptor))
+	}
+	server := grpc.NewServer(options...)
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: snapshotStreamService,
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Watch",
+			Handler:       func(_ interface{}, stream grpc.ServerStream) error { return dispatcher.watchSnapshot(stream) },
+			ServerStreams: true,
+		}},
+	}, struct{}{})
+	return server
+}
+
+// serveSnapshotStream serves the snapshot stream on the address until it fails.
+func (dispatcher *Dispatcher) serveSnapshotStream(address string) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		klog.Fatalf("Failed to listen on %s for the snapshot stream, err: %v", address, err)
+	}
+	klog.Fatalf("Snapshot stream server failed %s", dispatcher.newSnapshotStreamServer().Serve(listener))
+}
+
+// watchSnapshot sends the state of the control plane requested by `{"controlPlane": "<name>"}` at once, and again
+// after each dispatching round until the stream is closed. The rounds finished while the stream is slow are merged
+// into one update, the decisions made in them are all carried by it.
+func (dispatcher *Dispatcher) watchSnapshot(stream grpc.ServerStream) error {
+	request := &structpb.Struct{}
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+	name, _ := request.AsMap()["controlPlane"].(string)
+	cp, found := dispatcher.controlPlaneByName(name)
+	if !found {
+		return status.Errorf(codes.NotFound, "control plane %q not found", name)
+	}
+
+	sent := false
+	var sentCycle uint64
+	for {
+		dispatched, cycle := cp.dispatchedSignal()
+		if !sent || cycle != sentCycle {
+			message, err := toStruct(dispatcher.snapshotUpdate(cp, cycle, sent, sentCycle))
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err = stream.SendMsg(message); err != nil {
+				return err
+			}
+			sent, sentCycle = true, cycle
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-dispatched:
+		}
+	}
+}
+
+// snapshotUpdate builds the update of the control plane after the cycle, the decisions made after the sent cycle are
+// carried only when an update was sent before.
+func (dispatcher *Dispatcher) snapshotUpdate(cp *controlPlane, cycle uint64, sent bool, sentCycle uint64) *api.SnapshotUpdate {
+	snapshot := cp.cache.Snapshot()
+	update := &api.SnapshotUpdate{
+		Version:          api.SnapshotStreamVersion,
+		ControlPlane:     cp.name,
+		Cycle:            cycle,
+		Time:             time.Now(),
+		Queues:           debugQueues(snapshot),
+		ResourceBindings: debugResourceBindings(snapshot, "", ""),
+	}
+	for _, decision := range cp.decisions.list() {
+		if !sent || decision.Cycle > sentCycle {
+			update.Decisions = append(update.Decisions, decision)
+		}
+	}
+	return update
+}
+
+// streamInterceptor authenticates and authorizes the streams by the bearer token in the `authorization` metadata.
+func (da *debugAuthenticator) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	token := ""
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok && len(md.Get("authorization")) > 0 {
+		token = strings.TrimSpace(strings.TrimPrefix(md.Get("authorization")[0], "Bearer "))
+	}
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "bearer token is required")
+	}
+	if _, code, err := da.review(stream.Context(), token, "get", info.FullMethod); err != nil {
+		klog.V(3).Infof("Stream %s is refused, err: %v", info.FullMethod, err)
+		switch code {
+		case http.StatusUnauthorized:
+			return status.Error(codes.Unauthenticated, err.Error())
+		case http.StatusForbidden:
+			return status.Error(codes.PermissionDenied, err.Error())
+		default:
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	return handler(srv, stream)
+}
+
+func toStruct(object interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err = s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func TestWatchSnapshot(t *testing.T) {
+	rb := &workv1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb", UID: "rb"}}
+	snapshot := &cache.DispatcherCacheSnapshot{
+		DefaultQueue: "default",
+		QueueInfos: map[string]*schedulingapi.QueueInfo{"default": {Name: "default",
+			Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default"}}}},
+		ResourceBindingInfos: map[types.UID]*api.ResourceBindingInfo{rb.UID: {ResourceBinding: rb}},
+	}
+	cp := &controlPlane{name: defaultControlPlane, cache: &fakeCache{snapshot: snapshot}, decisions: newDecisionRecorder(10)}
+	cp.decisions.record(api.Decision{Namespace: "ns", Name: "rb", Reason: "Blocked", Cycle: 0})
+	dispatcher := &Dispatcher{controlPlanes: []*controlPlane{cp}}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := dispatcher.newSnapshotStreamServer()
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }))
+	if err != nil {
+		t.Fatalf("Failed to dial the snapshot stream: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	watch := func(controlPlane string) (grpc.ClientStream, error) {
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, snapshotStreamMethod)
+		if err != nil {
+			return nil, err
+		}
+		request, _ := structpb.NewStruct(map[string]interface{}{"controlPlane": controlPlane})
+		if err = stream.SendMsg(request); err != nil {
+			return nil, err
+		}
+		return stream, stream.CloseSend()
+	}
+	receive := func(stream grpc.ClientStream) (*api.SnapshotUpdate, error) {
+		message := &structpb.Struct{}
+		if err := stream.RecvMsg(message); err != nil {
+			return nil, err
+		}
+		data, _ := message.MarshalJSON()
+		update := &api.SnapshotUpdate{}
+		return update, json.Unmarshal(data, update)
+	}
+
+	stream, err := watch("")
+	if err != nil {
+		t.Fatalf("Failed to watch the snapshot stream: %v", err)
+	}
+	update, err := receive(stream)
+	if err != nil {
+		t.Fatalf("Failed to receive the first update: %v", err)
+	}
+	if update.Version != api.SnapshotStreamVersion || update.ControlPlane != defaultControlPlane || update.Cycle != 0 ||
+		len(update.Queues) != 1 || len(update.ResourceBindings) != 1 || len(update.Decisions) != 1 {
+		t.Errorf("Test case %s failed, got: %+v expect: %s", "First update", update, "the current state with all the decisions")
+	}
+
+	// The next round is finished, its decisions are sent only.
+	cp.cycle = 1
+	cp.decisions.record(api.Decision{Namespace: "ns", Name: "rb", Reason: "Dispatched", Dispatched: true, Cycle: 1})
+	cp.markDispatched(time.Now())
+	if update, err = receive(stream); err != nil {
+		t.Fatalf("Failed to receive the update of the round: %v", err)
+	}
+	reasons := []string{}
+	for _, decision := range update.Decisions {
+		reasons = append(reasons, decision.Reason)
+	}
+	if update.Cycle != 1 || !reflect.DeepEqual(reasons, []string{"Dispatched"}) {
+		t.Errorf("Test case %s failed, got: cycle %d decisions %v expect: cycle %d decisions %v",
+			"Update of the round", update.Cycle, reasons, 1, []string{"Dispatched"})
+	}
+
+	if stream, err = watch("unknown"); err == nil {
+		_, err = receive(stream)
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Test case %s failed, got: %v expect: %v", "Unknown control plane", status.Code(err), codes.NotFound)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"strconv"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+// defaultMaxSpeculativeReleases is the default number of the workloads of a queue which can be released toward the
+// elastic cluster speculatively but not running yet.
+const defaultMaxSpeculativeReleases = 1
+
+// speculativeReleases counts the ResourceBindings released toward the elastic clusters speculatively but not running
+// yet in each queue.
+func speculativeReleases(ssn *dispatcherframework.Session) map[string]int {
+	released := map[string]int{}
+	for _, rbi := range ssn.Snapshot.ResourceBindingInfos {
+		if rbi.DispatchStatus.IsDispatched() && rbi.IsSpeculative() && !rbi.IsRunning() {
+			released[ssn.GetResourceBindingInfoQueue(rbi)]++
+		}
+	}
+	return released
+}
+
+// speculativeCluster returns the elastic cluster of the queue which the ResourceBinding no cluster has room for can be
+// released toward, the cluster must be ready and allowed by the placement of the ResourceBinding, and the queue must
+// not reach its limit of the speculative releases.
+func speculativeCluster(snapshot *cache.DispatcherCacheSnapshot, queue *schedulingapi.QueueInfo, rbi *api.ResourceBindingInfo, released int) (string, bool) {
+	if queue.Queue == nil {
+		return "", false
+	}
+	clusterName := queue.Queue.Annotations[api.ElasticClusterAnnotationKey]
+	if clusterName == "" {
+		return "", false
+	}
+
+	limit := defaultMaxSpeculativeReleases
+	if value, found := queue.Queue.Annotations[api.MaxSpeculativeReleasesAnnotationKey]; found {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			klog.Errorf("Failed to parse the annotation %s of Queue <%s>, use the default limit, err: %v",
+				api.MaxSpeculativeReleasesAnnotationKey, queue.Name, err)
+		} else {
+			limit = parsed
+		}
+	}
+	if released >= limit {
+		return "", false
+	}
+
+	cluster, found := snapshot.Clusters[clusterName]
+	if !found || !meta.IsStatusConditionTrue(cluster.Status.Conditions, clusterv1alpha1.ClusterConditionReady) {
+		klog.V(4).Infof("The elastic cluster <%s> of Queue <%s> is not ready.", clusterName, queue.Name)
+		return "", false
+	}
+	if !cache.PlacementMatches(rbi.ResourceBinding.Spec.Placement, cluster) {
+		return "", false
+	}
+	return clusterName, true
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/karmada-io/karmada/pkg/apis/cluster/v1alpha1"
+	policyv1alpha1 "github.com/karmada-io/karmada/pkg/apis/policy/v1alpha1"
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/cache"
+)
+
+func TestSpeculativeCluster(t *testing.T) {
+	newCluster := func(name string, ready bool) *clusterv1alpha1.Cluster {
+		status := metav1.ConditionFalse
+		if ready {
+			status = metav1.ConditionTrue
+		}
+		return &clusterv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: clusterv1alpha1.ClusterStatus{Conditions: []metav1.Condition{
+				{Type: clusterv1alpha1.ClusterConditionReady, Status: status},
+			}},
+		}
+	}
+	snapshot := &cache.DispatcherCacheSnapshot{
+		Clusters: map[string]*clusterv1alpha1.Cluster{
+			"elastic":  newCluster("elastic", true),
+			"notready": newCluster("notready", false),
+		},
+	}
+
+	testCases := []struct {
+		Name          string
+		annotations   map[string]string
+		placement     *policyv1alpha1.Placement
+		released      int
+		expectCluster string
+	}{
+		{
+			Name: "No elastic cluster",
+		},
+		{
+			Name:          "Release toward the elastic cluster",
+			annotations:   map[string]string{api.ElasticClusterAnnotationKey: "elastic"},
+			expectCluster: "elastic",
+		},
+		{
+			Name:        "Reach the default limit",
+			annotations: map[string]string{api.ElasticClusterAnnotationKey: "elastic"},
+			released:    1,
+		},
+		{
+			Name: "Under the limit of the queue",
+			annotations: map[string]string{api.ElasticClusterAnnotationKey: "elastic",
+				api.MaxSpeculativeReleasesAnnotationKey: "3"},
+			released:      2,
+			expectCluster: "elastic",
+		},
+		{
+			Name:        "Elastic cluster is not ready",
+			annotations: map[string]string{api.ElasticClusterAnnotationKey: "notready"},
+		},
+		{
+			Name:        "Placement excludes the elastic cluster",
+			annotations: map[string]string{api.ElasticClusterAnnotationKey: "elastic"},
+			placement: &policyv1alpha1.Placement{ClusterAffinity: &policyv1alpha1.ClusterAffinity{
+				ClusterNames: []string{"member1"},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		queue := &schedulingapi.QueueInfo{Name: "q", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q", Annotations: tc.annotations}}}
+		rbi := &api.ResourceBindingInfo{ResourceBinding: &workv1alpha2.ResourceBinding{
+			Spec: workv1alpha2.ResourceBindingSpec{Placement: tc.placement},
+		}}
+		cluster, _ := speculativeCluster(snapshot, queue, rbi, tc.released)
+		if cluster != tc.expectCluster {
+			t.Errorf("Test case %s failed, got: %q expect: %q", tc.Name, cluster, tc.expectCluster)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/conf"
+	dispatcherframework "volcano.sh/volcano-global/pkg/dispatcher/framework"
+)
+
+var DefaultDispatcherConf = `
+actions: "enqueue, allocate"
+plugins:
+- name: priority
+- name: capacity
+- name: quota
+- name: dispatchwindow
+- name: inflight
+- name: dependency
+`
+
+// UnmarshalDispatcherConf parses the configuration and validates the actions and plugins.
+func UnmarshalDispatcherConf(confStr string) (*conf.DispatcherConfiguration, error) {
+	dispatcherConf := &conf.DispatcherConfiguration{}
+	if err := yaml.Unmarshal([]byte(confStr), dispatcherConf); err != nil {
+		return nil, err
+	}
+
+	for _, actionName := range strings.Split(dispatcherConf.Actions, ",") {
+		if _, found := dispatcherframework.GetAction(strings.TrimSpace(actionName)); !found {
+			return nil, fmt.Errorf("failed to find Action %s", actionName)
+		}
+	}
+
+	pluginBuilders := dispatcherframework.PluginManagerInstance.GetPluginBuilders()
+	for _, plugin := range dispatcherConf.Plugins {
+		if _, found := pluginBuilders[plugin.Name]; !found {
+			return nil, fmt.Errorf("failed to find Plugin %s", plugin.Name)
+		}
+	}
+
+	for _, workload := range dispatcherConf.Workloads {
+		if workload.Version == "" || workload.Kind == "" {
+			return nil, fmt.Errorf("workload %s/%s/%s must set version and kind", workload.Group, workload.Version, workload.Kind)
+		}
+	}
+
+	switch dispatcherConf.TieBreaker.Policy {
+	case "", dispatcherframework.TieBreakerUID, dispatcherframework.TieBreakerWeightedRandom:
+	default:
+		return nil, fmt.Errorf("unknown tie breaker policy %s, expect %s or %s", dispatcherConf.TieBreaker.Policy,
+			dispatcherframework.TieBreakerUID, dispatcherframework.TieBreakerWeightedRandom)
+	}
+	for namespace, weight := range dispatcherConf.TieBreaker.NamespaceWeights {
+		if weight <= 0 {
+			return nil, fmt.Errorf("tie breaker weight of namespace %s must be positive", namespace)
+		}
+	}
+
+	switch dispatcherConf.UnadmittedPolicy {
+	case "", unadmittedPolicyAccount, unadmittedPolicyResuspend:
+	default:
+		return nil, fmt.Errorf("unknown unadmitted policy %s, expect %s or %s", dispatcherConf.UnadmittedPolicy,
+			unadmittedPolicyAccount, unadmittedPolicyResuspend)
+	}
+
+	return dispatcherConf, nil
+}
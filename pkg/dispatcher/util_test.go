@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"testing"
+)
+
+func TestUnmarshalDispatcherConf(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		conf         string
+		expectErr    bool
+		expectQPS    float32
+		expectQueue  string
+		expectPlugin int
+	}{
+		{
+			Name:         "Default configuration",
+			conf:         DefaultDispatcherConf,
+			expectPlugin: 6,
+		},
+		{
+			Name: "Rate limit and queue defaults",
+			conf: `
+actions: "allocate"
+plugins:
+- name: priority
+rateLimit:
+  qps: 10
+  burst: 20
+queueDefaults:
+  name: batch
+`,
+			expectQPS:    10,
+			expectQueue:  "batch",
+			expectPlugin: 1,
+		},
+		{
+			Name: "Volcano scheduler actions",
+			conf: `
+actions: "enqueue, allocate, preempt, reclaim"
+plugins:
+- name: priority
+`,
+			expectPlugin: 1,
+		},
+		{
+			Name:      "Unknown action",
+			conf:      `actions: "allocate, unknown"`,
+			expectErr: true,
+		},
+		{
+			Name: "Unknown plugin",
+			conf: `
+actions: "allocate"
+plugins:
+- name: unknown
+`,
+			expectErr: true,
+		},
+		{
+			Name: "Weighted random tie breaker",
+			conf: `
+actions: "allocate"
+plugins:
+- name: priority
+tieBreaker:
+  policy: weightedRandom
+  seed: 42
+  namespaceWeights:
+    ns1: 2
+`,
+			expectPlugin: 1,
+		},
+		{
+			Name: "Unknown tie breaker",
+			conf: `
+actions: "allocate"
+tieBreaker:
+  policy: random
+`,
+			expectErr: true,
+		},
+		{
+			Name: "Negative tie breaker weight",
+			conf: `
+actions: "allocate"
+tieBreaker:
+  policy: weightedRandom
+  namespaceWeights:
+    ns1: -1
+`,
+			expectErr: true,
+		},
+		{
+			Name: "Unknown unadmitted policy",
+			conf: `
+actions: "allocate"
+unadmittedPolicy: delete
+`,
+			expectErr: true,
+		},
+		{
+			Name: "Workload without kind",
+			conf: `
+actions: "allocate"
+workloads:
+- group: apps
+  version: v1
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		configuration, err := UnmarshalDispatcherConf(tc.conf)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("Test case %s failed, expect error but got nil", tc.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test case %s failed, unexpected error: %v", tc.Name, err)
+			continue
+		}
+		if configuration.RateLimit.QPS != tc.expectQPS || configuration.QueueDefaults.Name != tc.expectQueue ||
+			len(configuration.Plugins) != tc.expectPlugin {
+			t.Errorf("Test case %s failed, got: %+v", tc.Name, configuration)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+)
+
+// runLoop runs the dispatching rounds of the control plane every dispatch period like wait.Until, and it starts
+// a round early when the cache wakes it up, like the capability or the weight of a queue, or the taints of a cluster
+// is changed, so the suspended ResourceBindings are evaluated again without waiting for the next period.
+func (dispatcher *Dispatcher) runLoop(cp *controlPlane, stopCh <-chan struct{}) {
+	wakeUp := cp.cache.WakeUp()
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		func() {
+			defer runtime.HandleCrash()
+			dispatcher.runOnce(cp)
+		}()
+
+		timer := time.NewTimer(dispatcher.dispatchPeriod)
+		select {
+		case <-stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-wakeUp:
+			timer.Stop()
+			klog.V(3).Infof("Dispatcher of control plane <%s> is woken up, start a round after the edits settle.", cp.name)
+			if !debounce(wakeUp, dispatcher.wakeUpDebounce, dispatcher.dispatchPeriod, stopCh) {
+				return
+			}
+		}
+	}
+}
+
+// debounce waits until the wake-up is not signaled again in the quiet period, so the rapid successive edits trigger
+// one round only, but it waits for the max wait at most in case the edits never settle. It returns false when stopped.
+func debounce(wakeUp <-chan struct{}, quiet, maxWait time.Duration, stopCh <-chan struct{}) bool {
+	if quiet <= 0 {
+		return true
+	}
+	deadline := time.NewTimer(maxWait)
+	defer deadline.Stop()
+	for {
+		timer := time.NewTimer(quiet)
+		select {
+		case <-stopCh:
+			timer.Stop()
+			return false
+		case <-deadline.C:
+			timer.Stop()
+			return true
+		case <-timer.C:
+			return true
+		case <-wakeUp:
+			timer.Stop()
+		}
+	}
+}
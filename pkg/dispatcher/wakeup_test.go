@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		edits        int
+		quiet        time.Duration
+		maxWait      time.Duration
+		stop         bool
+		expectResult bool
+		expectAtMost time.Duration
+	}{
+		{Name: "Settle after the quiet period", edits: 3, quiet: 20 * time.Millisecond, maxWait: time.Minute, expectResult: true, expectAtMost: time.Second},
+		{Name: "No debounce", edits: 3, maxWait: time.Minute, expectResult: true, expectAtMost: 10 * time.Millisecond},
+		{Name: "Edits never settle", edits: 1000, quiet: time.Minute, maxWait: 50 * time.Millisecond, expectResult: true, expectAtMost: time.Second},
+		{Name: "Stopped", quiet: time.Minute, maxWait: time.Minute, stop: true, expectResult: false, expectAtMost: time.Second},
+	}
+
+	for _, tc := range testCases {
+		wakeUp := make(chan struct{}, 1)
+		stopCh := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < tc.edits; i++ {
+				select {
+				case wakeUp <- struct{}{}:
+				case <-stopCh:
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+		if tc.stop {
+			close(stopCh)
+		}
+
+		start := time.Now()
+		result := debounce(wakeUp, tc.quiet, tc.maxWait, stopCh)
+		elapsed := time.Since(start)
+		if !tc.stop {
+			close(stopCh)
+		}
+		<-done
+		if result != tc.expectResult || elapsed > tc.expectAtMost {
+			t.Errorf("Test case %s failed, got: %v in %v expect: %v in %v at most", tc.Name, result, elapsed, tc.expectResult, tc.expectAtMost)
+		}
+	}
+}
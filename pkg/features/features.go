@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"k8s.io/apimachinery/pkg/util/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// ForceSuspensionAPI always suspends the ResourceBindings by `spec.suspension.dispatching`,
+	// instead of detecting whether the Karmada ResourceBinding API supports `spec.suspend`.
+	ForceSuspensionAPI featuregate.Feature = "ForceSuspensionAPI"
+
+	// RedispatchOnReschedule suspends the dispatched ResourceBindings again when Karmada reschedules them to the
+	// different clusters, like after the failures of the clusters, so the queues admit them again for the new placement.
+	RedispatchOnReschedule featuregate.Feature = "RedispatchOnReschedule"
+
+	// WorkloadSuspension holds the workloads themselves, like the Jobs by `spec.suspend` and the Deployments by
+	// `spec.paused`, when the older Karmada ResourceBinding API supports neither `spec.suspend` nor `spec.suspension`.
+	WorkloadSuspension featuregate.Feature = "WorkloadSuspension"
+)
+
+func init() {
+	runtime.Must(utilfeature.DefaultMutableFeatureGate.Add(defaultVolcanoGlobalFeatureGates))
+}
+
+var defaultVolcanoGlobalFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	ForceSuspensionAPI:     {Default: false, PreRelease: featuregate.Alpha},
+	RedispatchOnReschedule: {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadSuspension:     {Default: false, PreRelease: featuregate.Alpha},
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+)
+
+// DispatchAnnotationKey is the annotation to opt out of the dispatcher. The workload or the ResourceBinding
+// with `volcano.sh/dispatch: "false"` will not be suspended by the webhook and will be ignored by the dispatcher.
+const DispatchAnnotationKey = "volcano.sh/dispatch"
+
+// IgnoreKey is the label or the annotation to opt out of the dispatcher, like DispatchAnnotationKey. The workload or
+// the ResourceBinding with `volcano-global.volcano.sh/ignore: "true"` is dispatched by Karmada directly, it's useful
+// for the system components and the urgent deployments.
+const IgnoreKey = "volcano-global.volcano.sh/ignore"
+
+// IsDispatchDisabled checks whether the object opted out of the dispatcher by the labels or the annotations.
+func IsDispatchDisabled(obj metav1.Object) bool {
+	return obj.GetAnnotations()[DispatchAnnotationKey] == "false" ||
+		obj.GetAnnotations()[IgnoreKey] == "true" || obj.GetLabels()[IgnoreKey] == "true"
+}
+
+// ValidatePreemptableAnnotation checks the `volcano.sh/preemptable` annotation is either "true" or "false",
+// the dispatcher takes any other value as preemptable, so a typo would expose the workloads to the reclaiming.
+func ValidatePreemptableAnnotation(annotations map[string]string) error {
+	value, found := annotations[api.PreemptableAnnotationKey]
+	if !found || value == "true" || value == "false" {
+		return nil
+	}
+	return fmt.Errorf("invalid annotation %s: `%s`, expect `true` or `false`", api.PreemptableAnnotationKey, value)
+}
+
+// ValidateExpiryAnnotations checks the `volcano.sh/suspended-ttl` and the `volcano.sh/expiry-policy` annotations
+// of the Queue, the dispatcher never expires the ResourceBindings of the queue with the invalid ones.
+func ValidateExpiryAnnotations(annotations map[string]string) error {
+	_, _, err := api.ParseExpiry(annotations)
+	return err
+}
+
+// ValidatePodGroupQueue checks the queue of the PodGroup of the workload matches the queue annotation of its
+// ResourceBinding, otherwise the workload is queued by the federation and gang scheduled by the member clusters
+// in the different queues. The queue of the PodGroup takes precedence in the dispatcher.
+func ValidatePodGroupQueue(rb *workv1alpha2.ResourceBinding, podGroup *schedulingv1beta1.PodGroup) error {
+	queueName := rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey]
+	if podGroup == nil || podGroup.Spec.Queue == "" || queueName == "" || podGroup.Spec.Queue == queueName {
+		return nil
+	}
+	return fmt.Errorf("queue `%s` of %s <%s/%s> doesn't match queue `%s` of its PodGroup <%s/%s>", queueName,
+		rb.Spec.Resource.Kind, rb.Spec.Resource.Namespace, rb.Spec.Resource.Name, podGroup.Spec.Queue, podGroup.Namespace, podGroup.Name)
+}
+
+// GetWorkload gets the workload referenced by the ResourceBinding by the dynamic client.
+func GetWorkload(dynamicClient dynamic.Interface, restMapper meta.RESTMapper, ref workv1alpha2.ObjectReference) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := restMapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicClient.Resource(mapping.Resource).Namespace(ref.Namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+}
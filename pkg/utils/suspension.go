@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"gomodules.xyz/jsonpatch/v2"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano-global/pkg/features"
+)
+
+// SuspendMode is the field used to suspend the ResourceBindings.
+type SuspendMode string
+
+const (
+	// SuspendModeSuspend suspends the scheduling by `spec.suspend`, it's only supported by the forked Karmada.
+	SuspendModeSuspend SuspendMode = "suspend"
+	// SuspendModeSuspension suspends the dispatching by `spec.suspension.dispatching`, it's supported by the newer Karmada.
+	SuspendModeSuspension SuspendMode = "suspension"
+	// SuspendModeWorkload holds the workloads themselves by their own fields, like `spec.suspend` of the Jobs and
+	// `spec.paused` of the Deployments, for the older Karmada supporting neither of the fields above. Their
+	// ResourceBindings are suspended by the WorkloadHeldAnnotationKey annotation until the dispatcher releases them.
+	SuspendModeWorkload SuspendMode = "workload"
+)
+
+// WorkloadHeldAnnotationKey marks the workload held by the webhook in the SuspendModeWorkload, and its
+// ResourceBinding suspended until the dispatcher releases the workload.
+const WorkloadHeldAnnotationKey = "volcano.sh/workload-held"
+
+// workloadHoldPaths are the fields to hold the workloads of the kinds in the SuspendModeWorkload, the other kinds
+// can't be held, their ResourceBindings are not suspended in the mode.
+var workloadHoldPaths = map[schema.GroupKind]string{
+	{Group: "batch", Kind: "Job"}:       "/spec/suspend",
+	{Group: "apps", Kind: "Deployment"}: "/spec/paused",
+}
+
+// errSuspensionUnsupported is returned when the ResourceBinding CRD supports neither of the suspend fields.
+var errSuspensionUnsupported = errors.New("neither spec.suspend nor spec.suspension is supported")
+
+var resourceBindingCRDName = fmt.Sprintf("%s.%s", workv1alpha2.ResourcePluralResourceBinding, workv1alpha2.GroupVersion.Group)
+
+// DetectSuspendMode detects which suspend field is supported by the ResourceBinding CRD,
+// the SuspendModeSuspension will be used if the ForceSuspensionAPI feature gate is enabled, and the
+// SuspendModeWorkload will be used if neither field is supported and the WorkloadSuspension feature gate is enabled.
+func DetectSuspendMode(config *rest.Config) SuspendMode {
+	if utilfeature.DefaultFeatureGate.Enabled(features.ForceSuspensionAPI) {
+		klog.V(2).Infof("Feature gate %s is enabled, suspend the ResourceBindings by spec.suspension.", features.ForceSuspensionAPI)
+		return SuspendModeSuspension
+	}
+
+	mode, err := detectSuspendMode(config)
+	if errors.Is(err, errSuspensionUnsupported) && utilfeature.DefaultFeatureGate.Enabled(features.WorkloadSuspension) {
+		klog.V(2).Infof("CRD <%s> supports no suspend field, hold the workloads themselves, err: %v", resourceBindingCRDName, err)
+		return SuspendModeWorkload
+	}
+	if err != nil {
+		klog.Errorf("Failed to detect the suspend mode from CRD <%s>, fall back to spec.suspend, err: %v", resourceBindingCRDName, err)
+		return SuspendModeSuspend
+	}
+	klog.V(2).Infof("Detected suspend mode <%s> from CRD <%s>.", mode, resourceBindingCRDName)
+	return mode
+}
+
+func detectSuspendMode(config *rest.Config) (SuspendMode, error) {
+	client, err := apiextensionsclientset.NewForConfig(config)
+	if err != nil {
+		return "", err
+	}
+	crd, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), resourceBindingCRDName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Name != workv1alpha2.GroupVersion.Version || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		spec, found := version.Schema.OpenAPIV3Schema.Properties["spec"]
+		if !found {
+			return "", fmt.Errorf("spec not found in the schema of version %s", version.Name)
+		}
+		if _, found = spec.Properties["suspend"]; found {
+			return SuspendModeSuspend, nil
+		}
+		if _, found = spec.Properties["suspension"]; found {
+			return SuspendModeSuspension, nil
+		}
+		return "", fmt.Errorf("%w by version %s", errSuspensionUnsupported, version.Name)
+	}
+	return "", fmt.Errorf("version %s not found", workv1alpha2.GroupVersion.Version)
+}
+
+// IsResourceBindingSuspended returns whether the ResourceBinding is suspended by any of the suspend fields,
+// or its workload is held in the SuspendModeWorkload.
+func IsResourceBindingSuspended(rb *workv1alpha2.ResourceBinding) bool {
+	if rb.Spec.Suspend || IsWorkloadHeld(rb) {
+		return true
+	}
+	return rb.Spec.Suspension != nil && rb.Spec.Suspension.Dispatching != nil && *rb.Spec.Suspension.Dispatching
+}
+
+// IsResourceBindingApplied returns whether Karmada has applied the workload of the ResourceBinding to all of its
+// target member clusters.
+func IsResourceBindingApplied(rb *workv1alpha2.ResourceBinding) bool {
+	return meta.IsStatusConditionTrue(rb.Status.Conditions, workv1alpha2.FullyApplied)
+}
+
+// BuildSuspendPatch builds the json patch to suspend or unsuspend the ResourceBinding by the mode.
+func BuildSuspendPatch(rb *workv1alpha2.ResourceBinding, mode SuspendMode, suspend bool) []jsonpatch.Operation {
+	if mode == SuspendModeSuspend {
+		return []jsonpatch.Operation{
+			{Operation: "replace", Path: "/spec/suspend", Value: suspend},
+		}
+	}
+	if mode == SuspendModeWorkload {
+		return buildHeldAnnotationPatch(rb, suspend)
+	}
+
+	if suspend {
+		if rb.Spec.Suspension == nil {
+			return []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/suspension", Value: map[string]interface{}{"dispatching": true}},
+			}
+		}
+		return []jsonpatch.Operation{
+			{Operation: "add", Path: "/spec/suspension/dispatching", Value: true},
+		}
+	}
+
+	// The dispatching only accepts true, so remove it to unsuspend.
+	if rb.Spec.Suspension == nil || rb.Spec.Suspension.Dispatching == nil {
+		return nil
+	}
+	return []jsonpatch.Operation{
+		{Operation: "remove", Path: "/spec/suspension/dispatching"},
+	}
+}
+
+// BuildUnSuspendApplyPatch builds the server-side apply patch to unsuspend the ResourceBinding by the mode. It returns
+// nil for the SuspendModeSuspension, the dispatching only accepts true and can't be removed by applying, use the
+// json patch built by BuildSuspendPatch instead.
+func BuildUnSuspendApplyPatch(rb *workv1alpha2.ResourceBinding, mode SuspendMode) []byte {
+	if mode != SuspendModeSuspend {
+		return nil
+	}
+	patch, _ := json.Marshal(map[string]interface{}{
+		"apiVersion": workv1alpha2.GroupVersion.String(),
+		"kind":       workv1alpha2.ResourceKindResourceBinding,
+		"metadata": map[string]interface{}{
+			"namespace": rb.Namespace,
+			"name":      rb.Name,
+		},
+		"spec": map[string]interface{}{
+			"suspend": false,
+		},
+	})
+	return patch
+}
+
+// IsWorkloadHeld returns whether the workload, or the workload of the ResourceBinding, is held in the
+// SuspendModeWorkload.
+func IsWorkloadHeld(obj metav1.Object) bool {
+	return obj.GetAnnotations()[WorkloadHeldAnnotationKey] == "true"
+}
+
+// WorkloadHoldPath returns the field to hold the workloads of the kind, false if they can't be held.
+func WorkloadHoldPath(gk schema.GroupKind) (string, bool) {
+	path, found := workloadHoldPaths[gk]
+	return path, found
+}
+
+// BuildWorkloadHoldPatch builds the json patch to hold or release the workload by the field in the
+// SuspendModeWorkload, the workload is annotated by WorkloadHeldAnnotationKey while it's held.
+func BuildWorkloadHoldPatch(workload metav1.Object, path string, hold bool) []jsonpatch.Operation {
+	patch := []jsonpatch.Operation{{Operation: "add", Path: path, Value: hold}}
+	return append(patch, buildHeldAnnotationPatch(workload, hold)...)
+}
+
+// buildHeldAnnotationPatch adds or removes the WorkloadHeldAnnotationKey annotation of the object.
+func buildHeldAnnotationPatch(obj metav1.Object, held bool) []jsonpatch.Operation {
+	annotationPath := "/metadata/annotations/" + strings.ReplaceAll(WorkloadHeldAnnotationKey, "/", "~1")
+	if !held {
+		if _, found := obj.GetAnnotations()[WorkloadHeldAnnotationKey]; !found {
+			return nil
+		}
+		return []jsonpatch.Operation{{Operation: "remove", Path: annotationPath}}
+	}
+	if obj.GetAnnotations() == nil {
+		return []jsonpatch.Operation{{Operation: "add", Path: "/metadata/annotations",
+			Value: map[string]string{WorkloadHeldAnnotationKey: "true"}}}
+	}
+	return []jsonpatch.Operation{{Operation: "add", Path: annotationPath, Value: "true"}}
+}
@@ -18,9 +18,13 @@ package utils
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
@@ -28,36 +32,119 @@ import (
 
 // todo: we can do like kueue/pkg/controller/jobframework/interface.go, the workloads implement the interface so that we didnt need to know what kind of the resource.
 
-// This map contains the workload that we can handle now, the controllers will create PodGroup for them.
-var workloadGVKMap = map[schema.GroupVersionKind]struct{}{
-	{
-		Group:   batchv1alpha1.SchemeGroupVersion.Group,
-		Version: batchv1alpha1.SchemeGroupVersion.Version,
-		Kind:    "Job",
-	}: {},
-	{
-		Group:   appsv1.SchemeGroupVersion.Group,
-		Version: appsv1.SchemeGroupVersion.Version,
-		Kind:    "Deployment",
-	}: {},
-	{
-		Group:   corev1.SchemeGroupVersion.Group,
-		Version: corev1.SchemeGroupVersion.Version,
-		Kind:    "Pod",
-	}: {},
+// The workloads that we can handle by default, the controllers will create PodGroup for them.
+var builtInWorkloadGVKs = []schema.GroupVersionKind{
+	batchv1alpha1.SchemeGroupVersion.WithKind("Job"),
+	appsv1.SchemeGroupVersion.WithKind("Deployment"),
+	appsv1.SchemeGroupVersion.WithKind("StatefulSet"),
+	batchv1.SchemeGroupVersion.WithKind("Job"),
+	corev1.SchemeGroupVersion.WithKind("Pod"),
+	{Group: "kubeflow.org", Version: "v1", Kind: "TFJob"},
+	{Group: "kubeflow.org", Version: "v1", Kind: "PyTorchJob"},
+	{Group: "kubeflow.org", Version: "v1", Kind: "MXJob"},
+	{Group: "kubeflow.org", Version: "v1", Kind: "XGBoostJob"},
+	{Group: "ray.io", Version: "v1", Kind: "RayJob"},
+	{Group: "ray.io", Version: "v1", Kind: "RayCluster"},
+	{Group: "sparkoperator.k8s.io", Version: "v1beta2", Kind: "SparkApplication"},
+	{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"},
+}
+
+// DefaultWorkloadRegistry is the registry used by the webhooks and the dispatcher.
+var DefaultWorkloadRegistry = NewWorkloadRegistry(builtInWorkloadGVKs...)
+
+// WorkloadRegistry records the GroupVersionKinds which should be treated as workloads,
+// only the ResourceBindings of the workloads will be suspended and dispatched.
+type WorkloadRegistry struct {
+	mutex sync.RWMutex
+	// builtIn contains the workloads registered in code, they can't be removed.
+	builtIn map[schema.GroupVersionKind]struct{}
+	// extra contains the workloads from configuration, they will be replaced when the configuration changed.
+	extra map[schema.GroupVersionKind]struct{}
+}
+
+func NewWorkloadRegistry(gvks ...schema.GroupVersionKind) *WorkloadRegistry {
+	registry := &WorkloadRegistry{
+		builtIn: map[schema.GroupVersionKind]struct{}{},
+		extra:   map[schema.GroupVersionKind]struct{}{},
+	}
+	registry.Register(gvks...)
+	return registry
+}
+
+// Register add the workloads to the built-in workloads.
+func (wr *WorkloadRegistry) Register(gvks ...schema.GroupVersionKind) {
+	wr.mutex.Lock()
+	defer wr.mutex.Unlock()
+
+	for _, gvk := range gvks {
+		wr.builtIn[gvk] = struct{}{}
+	}
+}
+
+// SetExtraWorkloads replace the workloads which come from configuration, like custom CRDs.
+func (wr *WorkloadRegistry) SetExtraWorkloads(gvks []schema.GroupVersionKind) {
+	extra := make(map[schema.GroupVersionKind]struct{}, len(gvks))
+	for _, gvk := range gvks {
+		extra[gvk] = struct{}{}
+	}
+
+	wr.mutex.Lock()
+	defer wr.mutex.Unlock()
+	wr.extra = extra
 }
 
 // IsWorkload Return if the object reference is a workload.
-func IsWorkload(ref workv1alpha2.ObjectReference) (bool, error) {
+func (wr *WorkloadRegistry) IsWorkload(ref workv1alpha2.ObjectReference) (bool, error) {
 	gv, err := schema.ParseGroupVersion(ref.APIVersion)
 	if err != nil {
 		return false, fmt.Errorf("failed to parse APIVersion, err: %v", err)
 	}
 
-	_, exists := workloadGVKMap[schema.GroupVersionKind{
-		Group:   gv.Group,
-		Version: gv.Version,
-		Kind:    ref.Kind,
-	}]
-	return exists, nil
+	return wr.IsWorkloadGVK(gv.WithKind(ref.Kind)), nil
+}
+
+// IsWorkloadGVK Return if the GroupVersionKind is a workload.
+func (wr *WorkloadRegistry) IsWorkloadGVK(gvk schema.GroupVersionKind) bool {
+	wr.mutex.RLock()
+	defer wr.mutex.RUnlock()
+
+	if _, exists := wr.builtIn[gvk]; exists {
+		return true
+	}
+	_, exists := wr.extra[gvk]
+	return exists
+}
+
+// List returns all the workloads in the registry, sorted by string.
+func (wr *WorkloadRegistry) List() []schema.GroupVersionKind {
+	wr.mutex.RLock()
+	defer wr.mutex.RUnlock()
+
+	gvks := make([]schema.GroupVersionKind, 0, len(wr.builtIn)+len(wr.extra))
+	for gvk := range wr.builtIn {
+		gvks = append(gvks, gvk)
+	}
+	for gvk := range wr.extra {
+		if _, exists := wr.builtIn[gvk]; !exists {
+			gvks = append(gvks, gvk)
+		}
+	}
+	sort.Slice(gvks, func(i, j int) bool {
+		return gvks[i].String() < gvks[j].String()
+	})
+	return gvks
+}
+
+// ParseWorkloadGVK parse the workload in `Kind.version.group` format, like `TFJob.v1.kubeflow.org` or `Pod.v1`.
+func ParseWorkloadGVK(arg string) (schema.GroupVersionKind, error) {
+	parts := strings.SplitN(arg, ".", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("workload %q should be in Kind.version.group format", arg)
+	}
+
+	gvk := schema.GroupVersionKind{Kind: parts[0], Version: parts[1]}
+	if len(parts) == 3 {
+		gvk.Group = parts[2]
+	}
+	return gvk, nil
 }
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestWorkloadRegistry(t *testing.T) {
+	registry := NewWorkloadRegistry(builtInWorkloadGVKs...)
+	registry.SetExtraWorkloads([]schema.GroupVersionKind{
+		{Group: "example.com", Version: "v1", Kind: "Training"},
+	})
+
+	testCases := []struct {
+		Name         string
+		ref          workv1alpha2.ObjectReference
+		expectResult bool
+		expectErr    bool
+	}{
+		{
+			Name:         "Built-in Deployment",
+			ref:          workv1alpha2.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment"},
+			expectResult: true,
+		},
+		{
+			Name:         "Built-in VolcanoJob",
+			ref:          workv1alpha2.ObjectReference{APIVersion: "batch.volcano.sh/v1alpha1", Kind: "Job"},
+			expectResult: true,
+		},
+		{
+			Name:         "Extra workload from configuration",
+			ref:          workv1alpha2.ObjectReference{APIVersion: "example.com/v1", Kind: "Training"},
+			expectResult: true,
+		},
+		{
+			Name: "Not a workload",
+			ref:  workv1alpha2.ObjectReference{APIVersion: "v1", Kind: "ConfigMap"},
+		},
+		{
+			Name:      "Invalid APIVersion",
+			ref:       workv1alpha2.ObjectReference{APIVersion: "a/b/c", Kind: "Deployment"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		result, err := registry.IsWorkload(tc.ref)
+		if (err != nil) != tc.expectErr || result != tc.expectResult {
+			t.Errorf("Test case %s failed, got: %v, err: %v, expect: %v", tc.Name, result, err, tc.expectResult)
+		}
+	}
+
+	// The extra workloads should be replaced, and the built-in workloads should be kept.
+	registry.SetExtraWorkloads(nil)
+	if registry.IsWorkloadGVK(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Training"}) {
+		t.Errorf("Extra workload should be removed after SetExtraWorkloads(nil)")
+	}
+	if !registry.IsWorkloadGVK(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}) {
+		t.Errorf("Built-in workload should be kept after SetExtraWorkloads(nil)")
+	}
+}
+
+func TestParseWorkloadGVK(t *testing.T) {
+	testCases := []struct {
+		arg       string
+		expectGVK schema.GroupVersionKind
+		expectErr bool
+	}{
+		{arg: "TFJob.v1.kubeflow.org", expectGVK: schema.GroupVersionKind{Group: "kubeflow.org", Version: "v1", Kind: "TFJob"}},
+		{arg: "Pod.v1", expectGVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}},
+		{arg: "Pod", expectErr: true},
+		{arg: ".v1", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		gvk, err := ParseWorkloadGVK(tc.arg)
+		if (err != nil) != tc.expectErr || gvk != tc.expectGVK {
+			t.Errorf("Test case %s failed, got: %v, err: %v, expect: %v", tc.arg, gvk, err, tc.expectGVK)
+		}
+	}
+}
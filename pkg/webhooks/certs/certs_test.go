@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGenerateSelfSignedCertificates(t *testing.T) {
+	certs, err := GenerateSelfSignedCertificates("volcano-global-webhook", "volcano-global", time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to generate certificates, err: %v", err)
+	}
+	if _, err = tls.X509KeyPair(certs.Cert, certs.Key); err != nil {
+		t.Errorf("Serving certificate doesn't match the key, err: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(certs.CACert) {
+		t.Fatalf("Failed to parse the CA certificate")
+	}
+	block, _ := pem.Decode(certs.Cert)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse the serving certificate, err: %v", err)
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{
+		DNSName: "volcano-global-webhook.volcano-global.svc",
+		Roots:   roots,
+	}); err != nil {
+		t.Errorf("Failed to verify the serving certificate, err: %v", err)
+	}
+}
+
+func TestManagerBootstrap(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	// The certificates expire in 2 hours, and should be rotated 1 hour before it.
+	manager := NewManager(kubeClient, "volcano-global", "volcano-global-webhook", "certs", 2*time.Hour, time.Hour)
+	first, err := manager.Bootstrap()
+	if err != nil {
+		t.Fatalf("Failed to bootstrap certificates, err: %v", err)
+	}
+	second, err := manager.Bootstrap()
+	if err != nil {
+		t.Fatalf("Failed to bootstrap certificates, err: %v", err)
+	}
+	if string(first.Cert) != string(second.Cert) {
+		t.Errorf("Certificates should be reused when they are valid")
+	}
+
+	// Now the certificates need to be rotated, the previous CA should be kept in the bundle.
+	manager.rotateBefore = 3 * time.Hour
+	rotated, err := manager.Bootstrap()
+	if err != nil {
+		t.Fatalf("Failed to rotate certificates, err: %v", err)
+	}
+	if string(rotated.Cert) == string(first.Cert) {
+		t.Errorf("Certificates should be rotated")
+	}
+	if count := strings.Count(string(rotated.CACert), "BEGIN CERTIFICATE"); count != 2 {
+		t.Errorf("CA bundle should contain the new and the previous CA, got %d", count)
+	}
+}
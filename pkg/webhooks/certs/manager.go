@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	caCertKey = "ca.crt"
+	certKey   = corev1.TLSCertKey
+	keyKey    = corev1.TLSPrivateKeyKey
+
+	checkPeriod = time.Hour
+)
+
+// Manager bootstraps the self-signed certificates of the webhook server and rotates them before expiry.
+// The certificates are saved in a Secret, so the replicas and the restarted webhook server share them.
+type Manager struct {
+	kubeClient   kubernetes.Interface
+	namespace    string
+	serviceName  string
+	secretName   string
+	validity     time.Duration
+	rotateBefore time.Duration
+}
+
+// NewManager creates a certificate Manager for the webhook service.
+func NewManager(kubeClient kubernetes.Interface, namespace, serviceName, secretName string, validity, rotateBefore time.Duration) *Manager {
+	return &Manager{
+		kubeClient:   kubeClient,
+		namespace:    namespace,
+		serviceName:  serviceName,
+		secretName:   secretName,
+		validity:     validity,
+		rotateBefore: rotateBefore,
+	}
+}
+
+// Bootstrap returns the certificates in the Secret, they will be generated when the Secret doesn't exist
+// or the certificates need to be rotated.
+func (m *Manager) Bootstrap() (*Certificates, error) {
+	secret, err := m.kubeClient.CoreV1().Secrets(m.namespace).Get(context.TODO(), m.secretName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get Secret <%s/%s>: %v", m.namespace, m.secretName, err)
+		}
+		return m.createSecret()
+	}
+
+	certs := &Certificates{
+		CACert: secret.Data[caCertKey],
+		Cert:   secret.Data[certKey],
+		Key:    secret.Data[keyKey],
+	}
+	if !m.needRotate(certs) {
+		return certs, nil
+	}
+	return m.rotate(secret)
+}
+
+// WatchExpiry checks the certificates periodically, when they need to be rotated, the certificates
+// in the Secret will be rotated and the onRotated will be called, the webhook server should restart
+// to serve with the new certificates.
+func (m *Manager) WatchExpiry(certs *Certificates, stopCh <-chan struct{}, onRotated func()) {
+	ticker := time.NewTicker(checkPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !m.needRotate(certs) {
+				continue
+			}
+			if _, err := m.Bootstrap(); err != nil {
+				klog.Errorf("Failed to rotate the webhook certificates, err: %v", err)
+				continue
+			}
+			onRotated()
+			return
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+func (m *Manager) needRotate(certs *Certificates) bool {
+	if len(certs.CACert) == 0 || len(certs.Cert) == 0 || len(certs.Key) == 0 {
+		return true
+	}
+	notAfter, err := certs.NotAfter()
+	if err != nil {
+		klog.Errorf("Failed to get the expiration time of the webhook certificate, err: %v", err)
+		return true
+	}
+	return time.Now().Add(m.rotateBefore).After(notAfter)
+}
+
+func (m *Manager) createSecret() (*Certificates, error) {
+	certs, err := GenerateSelfSignedCertificates(m.serviceName, m.namespace, m.validity)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.secretName,
+			Namespace: m.namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			caCertKey: certs.CACert,
+			certKey:   certs.Cert,
+			keyKey:    certs.Key,
+		},
+	}
+	if _, err = m.kubeClient.CoreV1().Secrets(m.namespace).Create(context.TODO(), secret, metav1.CreateOptions{}); err != nil {
+		// Another replica created it at the same time, use its certificates.
+		if apierrors.IsAlreadyExists(err) {
+			return m.Bootstrap()
+		}
+		return nil, fmt.Errorf("failed to create Secret <%s/%s>: %v", m.namespace, m.secretName, err)
+	}
+
+	klog.Infof("Generated the self-signed webhook certificates into Secret <%s/%s>.", m.namespace, m.secretName)
+	return certs, nil
+}
+
+func (m *Manager) rotate(secret *corev1.Secret) (*Certificates, error) {
+	certs, err := GenerateSelfSignedCertificates(m.serviceName, m.namespace, m.validity)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the previous CA in the bundle, the other replicas still serve with the previous certificate until they restart.
+	certs.CACert = appendCertificate(certs.CACert, firstCertificate(secret.Data[caCertKey]))
+
+	secret = secret.DeepCopy()
+	secret.Data = map[string][]byte{
+		caCertKey: certs.CACert,
+		certKey:   certs.Cert,
+		keyKey:    certs.Key,
+	}
+	if _, err = m.kubeClient.CoreV1().Secrets(m.namespace).Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+		// Another replica rotated it at the same time, use its certificates.
+		if apierrors.IsConflict(err) {
+			return m.Bootstrap()
+		}
+		return nil, fmt.Errorf("failed to update Secret <%s/%s>: %v", m.namespace, m.secretName, err)
+	}
+
+	klog.Infof("Rotated the self-signed webhook certificates in Secret <%s/%s>.", m.namespace, m.secretName)
+	return certs, nil
+}
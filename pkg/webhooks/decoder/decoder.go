@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
 func init() {
@@ -42,6 +43,7 @@ var codecs = serializer.NewCodecFactory(scheme)
 func addToScheme(scheme *runtime.Scheme) {
 	utilruntime.Must(corev1.AddToScheme(scheme))
 	utilruntime.Must(admissionv1.AddToScheme(scheme))
+	utilruntime.Must(schedulingv1beta1.AddToScheme(scheme))
 }
 
 var ResourceBindingGVR = metav1.GroupVersionResource{
@@ -50,6 +52,12 @@ var ResourceBindingGVR = metav1.GroupVersionResource{
 	Resource: workv1alpha2.ResourcePluralResourceBinding,
 }
 
+var QueueGVR = metav1.GroupVersionResource{
+	Group:    schedulingv1beta1.SchemeGroupVersion.Group,
+	Version:  schedulingv1beta1.SchemeGroupVersion.Version,
+	Resource: "queues",
+}
+
 // DecodeResourceBinding decode the ResourceBinding use deserializer from the raw object.
 func DecodeResourceBinding(object runtime.RawExtension, gvr metav1.GroupVersionResource) (*workv1alpha2.ResourceBinding, error) {
 	if gvr != ResourceBindingGVR {
@@ -65,3 +73,19 @@ func DecodeResourceBinding(object runtime.RawExtension, gvr metav1.GroupVersionR
 	klog.V(5).Infof("The ResourceBinding struct is %+v", resourceBinding)
 	return resourceBinding, nil
 }
+
+// DecodeQueue decode the Queue use deserializer from the raw object.
+func DecodeQueue(object runtime.RawExtension, gvr metav1.GroupVersionResource) (*schedulingv1beta1.Queue, error) {
+	if gvr != QueueGVR {
+		return nil, fmt.Errorf("expect resource to be %s", QueueGVR)
+	}
+
+	deserializer := codecs.UniversalDeserializer()
+	queue := &schedulingv1beta1.Queue{}
+	if _, _, err := deserializer.Decode(object.Raw, nil, queue); err != nil {
+		return nil, err
+	}
+
+	klog.V(5).Infof("The Queue struct is %+v", queue)
+	return queue, nil
+}
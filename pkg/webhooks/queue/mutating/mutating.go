@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	registrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/router"
+	"volcano.sh/volcano/pkg/webhooks/util"
+
+	"volcano.sh/volcano-global/pkg/utils"
+	"volcano.sh/volcano-global/pkg/webhooks/decoder"
+)
+
+// Init the Queue mutate admissionWebhook, it will set the weight of the queue to the default one when it's not set,
+// the volcano webhook defaulting it is not installed on the Karmada control plane.
+func init() {
+	router.RegisterAdmission(service)
+}
+
+var config = &router.AdmissionServiceConfig{}
+
+var service = &router.AdmissionService{
+	Path: "/queues/mutate",
+	Func: Queues,
+	MutatingConfig: &registrationv1.MutatingWebhookConfiguration{
+		Webhooks: []registrationv1.MutatingWebhook{{
+			Name: "mutatequeues.volcano.sh",
+			Rules: []registrationv1.RuleWithOperations{
+				{
+					Operations: []registrationv1.OperationType{registrationv1.Create, registrationv1.Update},
+					Rule: registrationv1.Rule{
+						APIGroups:   []string{schedulingv1beta1.SchemeGroupVersion.Group},
+						APIVersions: []string{schedulingv1beta1.SchemeGroupVersion.Version},
+						Resources:   []string{"queues"},
+					},
+				},
+			},
+		}},
+	},
+	Config: config,
+}
+
+// defaultWeight is the weight of the queue which didn't set one, it's the same as the volcano one.
+const defaultWeight = 1
+
+func Queues(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request == nil || (ar.Request.Operation != admissionv1.Create && ar.Request.Operation != admissionv1.Update) {
+		// This error should not be happened; We have set the rule for CREATE and UPDATE operations only.
+		return util.ToAdmissionResponse(fmt.Errorf("expect operation to be '%s' or '%s'", admissionv1.Create, admissionv1.Update))
+	}
+	klog.V(3).Infof("Mutating %s operation for Queue <%s>.", ar.Request.Operation, ar.Request.Name)
+
+	queue, err := decoder.DecodeQueue(ar.Request.Object, ar.Request.Resource)
+	if err != nil {
+		return util.ToAdmissionResponse(err)
+	}
+
+	response := &admissionv1.AdmissionResponse{Allowed: true}
+	patches := buildDefaultWeightPatch(queue)
+	if len(patches) == 0 {
+		return response
+	}
+	response.Patch, err = json.Marshal(patches)
+	if err != nil {
+		return util.ToAdmissionResponse(err)
+	}
+	response.PatchType = utils.ToPointer(admissionv1.PatchTypeJSONPatch)
+	return response
+}
+
+// buildDefaultWeightPatch sets the weight of the queue when it's not set, the negative one is left to be rejected
+// by the validating webhook.
+func buildDefaultWeightPatch(queue *schedulingv1beta1.Queue) []jsonpatch.Operation {
+	if queue.Spec.Weight != 0 {
+		return nil
+	}
+	klog.V(3).Infof("Queue <%s> didn't set the weight, set it to %d.", queue.Name, defaultWeight)
+	return []jsonpatch.Operation{{
+		Operation: "add",
+		Path:      "/spec/weight",
+		Value:     defaultWeight,
+	}}
+}
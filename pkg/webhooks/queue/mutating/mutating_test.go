@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/webhooks/decoder"
+)
+
+func TestQueues(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		weight      int32
+		expectPatch string
+	}{
+		{Name: "Weight not set", weight: 0, expectPatch: `[{"op":"add","path":"/spec/weight","value":1}]`},
+		{Name: "Weight set", weight: 3},
+		{Name: "Negative weight is left to the validating webhook", weight: -1},
+	}
+
+	for _, tc := range testCases {
+		raw, err := json.Marshal(&schedulingv1beta1.Queue{
+			TypeMeta:   metav1.TypeMeta{APIVersion: schedulingv1beta1.SchemeGroupVersion.String(), Kind: "Queue"},
+			ObjectMeta: metav1.ObjectMeta{Name: "q1"},
+			Spec:       schedulingv1beta1.QueueSpec{Weight: tc.weight},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		response := Queues(admissionv1.AdmissionReview{Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Name:      "q1",
+			Resource:  decoder.QueueGVR,
+			Object:    runtime.RawExtension{Raw: raw},
+		}})
+		if !response.Allowed || string(response.Patch) != tc.expectPatch {
+			t.Errorf("Test case %s failed, got allowed: %v patch: %s expect patch: %s", tc.Name, response.Allowed, response.Patch, tc.expectPatch)
+		}
+	}
+}
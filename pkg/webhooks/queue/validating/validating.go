@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	karmadaclientset "github.com/karmada-io/karmada/pkg/generated/clientset/versioned"
+	admissionv1 "k8s.io/api/admission/v1"
+	registrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/router"
+	"volcano.sh/volcano/pkg/webhooks/util"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/dispatcher/plugins/dispatchwindow"
+	"volcano.sh/volcano-global/pkg/utils"
+	"volcano.sh/volcano-global/pkg/webhooks/decoder"
+)
+
+// Init the Queue validate admissionWebhook, it will reject the deletion of the queue which is still referenced
+// by the suspended workload ResourceBindings, otherwise they will be suspended forever.
+// It also rejects the queue with an invalid `volcano.sh/preemptable`, `volcano.sh/suspended-ttl` or
+// `volcano.sh/expiry-policy` annotation, and the queue which misbehaves at dispatch time silently, like the one
+// without a positive weight, with a negative capability, inconsistent hierarchy annotations or invalid dispatch
+// windows.
+func init() {
+	router.RegisterAdmission(service)
+}
+
+var config = &router.AdmissionServiceConfig{}
+
+var service = &router.AdmissionService{
+	Path: "/queues/validate",
+	Func: Queues,
+	ValidatingConfig: &registrationv1.ValidatingWebhookConfiguration{
+		Webhooks: []registrationv1.ValidatingWebhook{{
+			Name: "validatequeues.volcano.sh",
+			Rules: []registrationv1.RuleWithOperations{
+				{
+					Operations: []registrationv1.OperationType{registrationv1.Create, registrationv1.Update, registrationv1.Delete},
+					Rule: registrationv1.Rule{
+						APIGroups:   []string{schedulingv1beta1.SchemeGroupVersion.Group},
+						APIVersions: []string{schedulingv1beta1.SchemeGroupVersion.Version},
+						Resources:   []string{"queues"},
+					},
+				},
+			},
+		}},
+	},
+	Config: config,
+}
+
+// maxReportedResourceBindings limits the ResourceBindings listed in the rejection message, the listing stops once
+// they're found.
+const maxReportedResourceBindings = 5
+
+// resourceBindingPageSize is the number of the ResourceBindings listed in a page, so the deletion of a queue doesn't
+// load all the ResourceBindings of the federation at once.
+const resourceBindingPageSize = 500
+
+// karmadaClient is used to list the ResourceBindings which reference the queue.
+var karmadaClient karmadaclientset.Interface
+
+// SetKarmadaClient set the client used to list the ResourceBindings.
+func SetKarmadaClient(client karmadaclientset.Interface) {
+	karmadaClient = client
+}
+
+func Queues(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request == nil {
+		return util.ToAdmissionResponse(fmt.Errorf("expect the admission request"))
+	}
+	klog.V(3).Infof("Validating %s operation for Queue <%s>.", ar.Request.Operation, ar.Request.Name)
+
+	switch ar.Request.Operation {
+	case admissionv1.Create, admissionv1.Update:
+		queue, err := decoder.DecodeQueue(ar.Request.Object, ar.Request.Resource)
+		if err != nil {
+			return util.ToAdmissionResponse(err)
+		}
+		if err = utils.ValidatePreemptableAnnotation(queue.Annotations); err != nil {
+			klog.V(3).Infof("Reject Queue <%s>, err: %v", queue.Name, err)
+			return util.ToAdmissionResponse(err)
+		}
+		if err = utils.ValidateExpiryAnnotations(queue.Annotations); err != nil {
+			klog.V(3).Infof("Reject Queue <%s>, err: %v", queue.Name, err)
+			return util.ToAdmissionResponse(err)
+		}
+		if err = validateQueueSpec(queue); err != nil {
+			klog.V(3).Infof("Reject Queue <%s>, err: %v", queue.Name, err)
+			return util.ToAdmissionResponse(err)
+		}
+	case admissionv1.Delete:
+		if err := validateQueueDeletion(ar.Request.Name); err != nil {
+			klog.V(3).Infof("Reject deleting Queue <%s>, err: %v", ar.Request.Name, err)
+			return util.ToAdmissionResponse(err)
+		}
+	default:
+		// This error should not be happened; We have set the rule for CREATE, UPDATE and DELETE operations only.
+		return util.ToAdmissionResponse(fmt.Errorf("expect operation to be '%s', '%s' or '%s'",
+			admissionv1.Create, admissionv1.Update, admissionv1.Delete))
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// validateQueueDeletion checks there is no suspended workload ResourceBinding waiting in the queue.
+func validateQueueDeletion(queueName string) error {
+	if karmadaClient == nil {
+		return nil
+	}
+
+	var waiting []string
+	options := metav1.ListOptions{Limit: resourceBindingPageSize}
+	for {
+		rbList, err := karmadaClient.WorkV1alpha2().ResourceBindings(metav1.NamespaceAll).List(context.TODO(), options)
+		if err != nil {
+			return fmt.Errorf("unable to list the ResourceBindings of queue `%s`: %v", queueName, err)
+		}
+		for i := range rbList.Items {
+			rb := &rbList.Items[i]
+			if rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey] != queueName || !utils.IsResourceBindingSuspended(rb) {
+				continue
+			}
+			isWorkload, err := utils.DefaultWorkloadRegistry.IsWorkload(rb.Spec.Resource)
+			if err != nil || !isWorkload {
+				continue
+			}
+			if waiting = append(waiting, rb.Namespace+"/"+rb.Name); len(waiting) == maxReportedResourceBindings {
+				break
+			}
+		}
+		if len(waiting) == maxReportedResourceBindings || rbList.Continue == "" {
+			break
+		}
+		options.Continue = rbList.Continue
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("queue `%s` still has suspended ResourceBindings waiting to be dispatched, like <%s>, "+
+		"move them to another queue or delete them first", queueName, strings.Join(waiting, ", "))
+}
+
+// validateQueueSpec checks the fields and the annotations of the queue used by the dispatcher, the invalid ones are
+// ignored or misread at dispatch time without any error, so the users are told at admission instead.
+func validateQueueSpec(queue *schedulingv1beta1.Queue) error {
+	// The weight is defaulted to 1 by the mutating webhook, a queue without a positive weight never gets a share.
+	if queue.Spec.Weight <= 0 {
+		return fmt.Errorf("invalid weight %d of queue `%s`, expect a positive one", queue.Spec.Weight, queue.Name)
+	}
+	if err := validateCapability(queue); err != nil {
+		return err
+	}
+	if err := validateHierarchyAnnotations(queue.Annotations); err != nil {
+		return err
+	}
+	if value, found := queue.Annotations[api.DispatchWindowAnnotationKey]; found {
+		if err := dispatchwindow.ValidateWindows(value); err != nil {
+			return fmt.Errorf("invalid annotation %s: %v", api.DispatchWindowAnnotationKey, err)
+		}
+	}
+	return nil
+}
+
+// validateCapability checks the capability, the deserved and the guarantee resources of the queue are not negative,
+// and the deserved and the guarantee ones don't exceed the capability.
+func validateCapability(queue *schedulingv1beta1.Queue) error {
+	resources := map[string]corev1.ResourceList{
+		"capability": queue.Spec.Capability,
+		"deserved":   queue.Spec.Deserved,
+		"guarantee":  queue.Spec.Guarantee.Resource,
+	}
+	for _, field := range []string{"capability", "deserved", "guarantee"} {
+		for name, quantity := range resources[field] {
+			if quantity.Sign() < 0 {
+				return fmt.Errorf("invalid %s %s of queue `%s`: `%s`, expect a non-negative quantity",
+					field, name, queue.Name, quantity.String())
+			}
+			if field == "capability" {
+				continue
+			}
+			if capability, found := queue.Spec.Capability[name]; found && quantity.Cmp(capability) > 0 {
+				return fmt.Errorf("%s %s of queue `%s` is `%s`, it exceeds the capability `%s`",
+					field, name, queue.Name, quantity.String(), capability.String())
+			}
+		}
+	}
+	return nil
+}
+
+// validateHierarchyAnnotations checks the `volcano.sh/hierarchy` and the `volcano.sh/hierarchy-weights` annotations
+// are set together, like `root/eng/prod` and `1/2/8`, and each level of the hierarchy has a positive weight.
+func validateHierarchyAnnotations(annotations map[string]string) error {
+	hierarchy, hierarchyFound := annotations[schedulingv1beta1.KubeHierarchyAnnotationKey]
+	weights, weightsFound := annotations[schedulingv1beta1.KubeHierarchyWeightAnnotationKey]
+	if !hierarchyFound && !weightsFound {
+		return nil
+	}
+	if hierarchyFound != weightsFound {
+		return fmt.Errorf("annotations %s and %s must be set together", schedulingv1beta1.KubeHierarchyAnnotationKey,
+			schedulingv1beta1.KubeHierarchyWeightAnnotationKey)
+	}
+
+	levels, levelWeights := strings.Split(hierarchy, "/"), strings.Split(weights, "/")
+	if len(levels) != len(levelWeights) {
+		return fmt.Errorf("annotation %s `%s` has %d levels, but annotation %s `%s` has %d weights",
+			schedulingv1beta1.KubeHierarchyAnnotationKey, hierarchy, len(levels),
+			schedulingv1beta1.KubeHierarchyWeightAnnotationKey, weights, len(levelWeights))
+	}
+	for i, level := range levels {
+		if level == "" {
+			return fmt.Errorf("invalid annotation %s: `%s`, expect no empty level", schedulingv1beta1.KubeHierarchyAnnotationKey, hierarchy)
+		}
+		if weight, err := strconv.ParseFloat(levelWeights[i], 64); err != nil || weight <= 0 {
+			return fmt.Errorf("invalid annotation %s: `%s`, expect a positive weight for level `%s`",
+				schedulingv1beta1.KubeHierarchyWeightAnnotationKey, weights, level)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	karmadafake "github.com/karmada-io/karmada/pkg/generated/clientset/versioned/fake"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/webhooks/decoder"
+)
+
+func TestValidateQueueDeletion(t *testing.T) {
+	newResourceBinding := func(name, queueName string, suspend bool) *workv1alpha2.ResourceBinding {
+		return &workv1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: queueName},
+			},
+			Spec: workv1alpha2.ResourceBindingSpec{
+				Resource: workv1alpha2.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: name},
+				Suspend:  suspend,
+			},
+		}
+	}
+	SetKarmadaClient(karmadafake.NewSimpleClientset(
+		newResourceBinding("waiting", "busy", true),
+		newResourceBinding("dispatched", "idle", false),
+	))
+	defer SetKarmadaClient(nil)
+
+	testCases := []struct {
+		Name        string
+		queueName   string
+		expectError bool
+	}{
+		{Name: "Queue has suspended ResourceBindings", queueName: "busy", expectError: true},
+		{Name: "Queue only has dispatched ResourceBindings", queueName: "idle", expectError: false},
+		{Name: "Queue has no ResourceBindings", queueName: "empty", expectError: false},
+	}
+
+	for _, tc := range testCases {
+		err := validateQueueDeletion(tc.queueName)
+		if (err != nil) != tc.expectError {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectError)
+		}
+	}
+}
+
+func TestValidateQueueDeletionReported(t *testing.T) {
+	var objects []runtime.Object
+	for i := 0; i < 2*maxReportedResourceBindings; i++ {
+		objects = append(objects, &workv1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        fmt.Sprintf("waiting-%d", i),
+				Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "busy"},
+			},
+			Spec: workv1alpha2.ResourceBindingSpec{
+				Resource: workv1alpha2.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default"},
+				Suspend:  true,
+			},
+		})
+	}
+	SetKarmadaClient(karmadafake.NewSimpleClientset(objects...))
+	defer SetKarmadaClient(nil)
+
+	// The listing stops once the reported ResourceBindings are found.
+	err := validateQueueDeletion("busy")
+	if err == nil || strings.Count(err.Error(), "default/waiting-") != maxReportedResourceBindings {
+		t.Errorf("Test case Report the first suspended ResourceBindings failed, got err: %v expect %d ResourceBindings",
+			err, maxReportedResourceBindings)
+	}
+}
+
+func TestValidatePreemptableAnnotation(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		annotations map[string]string
+		expectAllow bool
+	}{
+		{Name: "Annotation not set", annotations: nil, expectAllow: true},
+		{Name: "Non-preemptable queue", annotations: map[string]string{api.PreemptableAnnotationKey: "false"}, expectAllow: true},
+		{Name: "Preemptable queue", annotations: map[string]string{api.PreemptableAnnotationKey: "true"}, expectAllow: true},
+		{Name: "Invalid annotation", annotations: map[string]string{api.PreemptableAnnotationKey: "no"}, expectAllow: false},
+		{Name: "Suspended TTL", annotations: map[string]string{api.SuspendedTTLAnnotationKey: "24h", api.ExpiryPolicyAnnotationKey: api.ExpiryPolicyDelete}, expectAllow: true},
+		{Name: "Invalid suspended TTL", annotations: map[string]string{api.SuspendedTTLAnnotationKey: "-1h"}, expectAllow: false},
+		{Name: "Invalid expiry policy", annotations: map[string]string{api.SuspendedTTLAnnotationKey: "1h", api.ExpiryPolicyAnnotationKey: "Evict"}, expectAllow: false},
+	}
+
+	for _, tc := range testCases {
+		raw, err := json.Marshal(&schedulingv1beta1.Queue{
+			TypeMeta:   metav1.TypeMeta{APIVersion: schedulingv1beta1.SchemeGroupVersion.String(), Kind: "Queue"},
+			ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations},
+			Spec:       schedulingv1beta1.QueueSpec{Weight: 1},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		response := Queues(admissionv1.AdmissionReview{Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Name:      "q1",
+			Resource:  decoder.QueueGVR,
+			Object:    runtime.RawExtension{Raw: raw},
+		}})
+		if response.Allowed != tc.expectAllow {
+			t.Errorf("Test case %s failed, got allowed: %v expect allowed: %v", tc.Name, response.Allowed, tc.expectAllow)
+		}
+	}
+}
+
+func TestValidateQueueSpec(t *testing.T) {
+	resources := func(cpu string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}
+	}
+	testCases := []struct {
+		Name        string
+		spec        schedulingv1beta1.QueueSpec
+		annotations map[string]string
+		expectError bool
+	}{
+		{Name: "Valid queue", spec: schedulingv1beta1.QueueSpec{Weight: 1, Capability: resources("10"), Deserved: resources("5"),
+			Guarantee: schedulingv1beta1.Guarantee{Resource: resources("2")}}},
+		{Name: "Weight not set", spec: schedulingv1beta1.QueueSpec{}, expectError: true},
+		{Name: "Negative weight", spec: schedulingv1beta1.QueueSpec{Weight: -1}, expectError: true},
+		{Name: "Negative capability", spec: schedulingv1beta1.QueueSpec{Weight: 1, Capability: resources("-1")}, expectError: true},
+		{Name: "Deserved exceeds capability", spec: schedulingv1beta1.QueueSpec{Weight: 1, Capability: resources("4"), Deserved: resources("5")}, expectError: true},
+		{Name: "Guarantee exceeds capability", spec: schedulingv1beta1.QueueSpec{Weight: 1, Capability: resources("4"),
+			Guarantee: schedulingv1beta1.Guarantee{Resource: resources("5")}}, expectError: true},
+		{Name: "Valid hierarchy", spec: schedulingv1beta1.QueueSpec{Weight: 1}, annotations: map[string]string{
+			schedulingv1beta1.KubeHierarchyAnnotationKey: "root/eng/prod", schedulingv1beta1.KubeHierarchyWeightAnnotationKey: "1/2/8"}},
+		{Name: "Hierarchy without weights", spec: schedulingv1beta1.QueueSpec{Weight: 1}, annotations: map[string]string{
+			schedulingv1beta1.KubeHierarchyAnnotationKey: "root/eng"}, expectError: true},
+		{Name: "Hierarchy and weights mismatch", spec: schedulingv1beta1.QueueSpec{Weight: 1}, annotations: map[string]string{
+			schedulingv1beta1.KubeHierarchyAnnotationKey: "root/eng/prod", schedulingv1beta1.KubeHierarchyWeightAnnotationKey: "1/2"}, expectError: true},
+		{Name: "Invalid hierarchy weight", spec: schedulingv1beta1.QueueSpec{Weight: 1}, annotations: map[string]string{
+			schedulingv1beta1.KubeHierarchyAnnotationKey: "root/eng", schedulingv1beta1.KubeHierarchyWeightAnnotationKey: "1/0"}, expectError: true},
+		{Name: "Valid dispatch windows", spec: schedulingv1beta1.QueueSpec{Weight: 1}, annotations: map[string]string{
+			api.DispatchWindowAnnotationKey: "20:00-06:00; 0 20 * * 1-5 10h"}},
+		{Name: "Invalid dispatch windows", spec: schedulingv1beta1.QueueSpec{Weight: 1}, annotations: map[string]string{
+			api.DispatchWindowAnnotationKey: "25:00-06:00"}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		queue := &schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations}, Spec: tc.spec}
+		err := validateQueueSpec(queue)
+		if (err != nil) != tc.expectError {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectError)
+		}
+	}
+}
@@ -17,14 +17,20 @@ limitations under the License.
 package mutating
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	registrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/util"
 
@@ -32,11 +38,15 @@ import (
 	"volcano.sh/volcano-global/pkg/webhooks/decoder"
 )
 
-// Init the ResourceBinding mutate admissionWebhook, it will set the `suspend` to true when create a ResourceBinding.
+// Init the ResourceBinding mutate admissionWebhook, it will set the `suspend` to true when create a ResourceBinding,
+// and set the queue annotation if the ResourceBinding didn't set one. In the SuspendModeWorkload, the ResourceBinding
+// is suspended by the `volcano.sh/workload-held` annotation when its workload is held by the workload webhook.
 func init() {
 	router.RegisterAdmission(service)
 }
 
+var config = &router.AdmissionServiceConfig{}
+
 var service = &router.AdmissionService{
 	Path: "/resourcebindings/mutate",
 	Func: ResourceBindings,
@@ -55,6 +65,35 @@ var service = &router.AdmissionService{
 			},
 		}},
 	},
+	Config: config,
+}
+
+// suspendMode is the field used to suspend the ResourceBindings, it is detected when the webhook manager starts.
+var suspendMode = utils.SuspendModeSuspend
+
+// SetSuspendMode set the field used to suspend the ResourceBindings.
+func SetSuspendMode(mode utils.SuspendMode) {
+	suspendMode = mode
+}
+
+// dynamicClient and restMapper are used to get the workload, to check whether it opted out of the dispatcher.
+var (
+	dynamicClient dynamic.Interface
+	restMapper    meta.RESTMapper
+)
+
+// SetWorkloadClient set the clients used to get the workload of the ResourceBinding.
+func SetWorkloadClient(client dynamic.Interface, mapper meta.RESTMapper) {
+	dynamicClient = client
+	restMapper = mapper
+}
+
+// defaultQueue is the queue of the workloads which didn't set a queue, and their namespace didn't set one either.
+var defaultQueue = "default"
+
+// SetDefaultQueue set the default queue name of the workloads.
+func SetDefaultQueue(queueName string) {
+	defaultQueue = queueName
 }
 
 func ResourceBindings(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -71,13 +110,13 @@ func ResourceBindings(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResp
 	}
 
 	response := &admissionv1.AdmissionResponse{Allowed: true}
-	if rb.Spec.Suspend == true {
+	if utils.IsResourceBindingSuspended(rb) {
 		// This should not be happened, the `suspend` will update to True by this webhook when create the resourceBinding.
 		return response
 	}
 
 	// Check if its workload, skip suspend if not.
-	isWorkload, err := utils.IsWorkload(rb.Spec.Resource)
+	isWorkload, err := utils.DefaultWorkloadRegistry.IsWorkload(rb.Spec.Resource)
 	if err != nil {
 		klog.Errorf("Failed to check ResourceBinding <%s/%s> if workload, stop suspend, err: %v",
 			rb.Namespace, rb.Name, err)
@@ -89,10 +128,32 @@ func ResourceBindings(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResp
 		return response
 	}
 
-	// Create the patch, update the suspend field.
-	response.Patch, err = json.Marshal([]jsonpatch.Operation{
-		{Operation: "replace", Path: "/spec/suspend", Value: true},
-	})
+	// Check if the ResourceBinding or its workload opted out of the dispatcher, skip suspend if so.
+	if utils.IsDispatchDisabled(rb) {
+		klog.V(3).Infof("ResourceBinding <%s/%s> opted out of the dispatcher, skip suspend it.", rb.Namespace, rb.Name)
+		return response
+	}
+	if isWorkloadDispatchDisabled(rb) {
+		klog.V(3).Infof("The workload of ResourceBinding <%s/%s> opted out of the dispatcher, skip suspend it.", rb.Namespace, rb.Name)
+		// Copy the annotation to the ResourceBinding, so the dispatcher can ignore it too.
+		response.Patch, err = json.Marshal(buildAnnotationPatch(rb, utils.DispatchAnnotationKey, "false"))
+		if err != nil {
+			return util.ToAdmissionResponse(err)
+		}
+		response.PatchType = utils.ToPointer(admissionv1.PatchTypeJSONPatch)
+		return response
+	}
+
+	// The ResourceBinding can't be suspended in the SuspendModeWorkload unless its workload is held.
+	if suspendMode == utils.SuspendModeWorkload && !isWorkloadHeld(rb) {
+		klog.V(3).Infof("The workload of ResourceBinding <%s/%s> is not held, skip suspend it.", rb.Namespace, rb.Name)
+		return response
+	}
+
+	// Create the patch, update the suspend field, and set the default queue if the queue annotation is missing.
+	patches := utils.BuildSuspendPatch(rb, suspendMode, true)
+	patches = append(patches, buildDefaultQueuePatch(rb)...)
+	response.Patch, err = json.Marshal(patches)
 	if err != nil {
 		return util.ToAdmissionResponse(err)
 	}
@@ -100,3 +161,70 @@ func ResourceBindings(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResp
 	response.PatchType = utils.ToPointer(admissionv1.PatchTypeJSONPatch)
 	return response
 }
+
+// buildDefaultQueuePatch sets the queue annotation of the ResourceBinding when it's missing, the queue annotation
+// of the namespace takes precedence over the default queue. So the workloads without a queue can be dispatched.
+func buildDefaultQueuePatch(rb *workv1alpha2.ResourceBinding) []jsonpatch.Operation {
+	if rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey] != "" {
+		return nil
+	}
+
+	queueName := defaultQueue
+	if config.KubeClient != nil && rb.Namespace != "" {
+		namespace, err := config.KubeClient.CoreV1().Namespaces().Get(context.TODO(), rb.Namespace, metav1.GetOptions{})
+		if err != nil {
+			klog.Errorf("Failed to get Namespace <%s> of ResourceBinding <%s/%s>, use the default queue <%s>, err: %v",
+				rb.Namespace, rb.Namespace, rb.Name, defaultQueue, err)
+		} else if namespace.Annotations[schedulingv1beta1.QueueNameAnnotationKey] != "" {
+			queueName = namespace.Annotations[schedulingv1beta1.QueueNameAnnotationKey]
+		}
+	}
+	klog.V(3).Infof("ResourceBinding <%s/%s> didn't set the queue, set it to <%s>.", rb.Namespace, rb.Name, queueName)
+
+	return buildAnnotationPatch(rb, schedulingv1beta1.QueueNameAnnotationKey, queueName)
+}
+
+// buildAnnotationPatch adds the annotation to the ResourceBinding.
+func buildAnnotationPatch(rb *workv1alpha2.ResourceBinding, key, value string) []jsonpatch.Operation {
+	if rb.Annotations == nil {
+		return []jsonpatch.Operation{{
+			Operation: "add",
+			Path:      "/metadata/annotations",
+			Value:     map[string]string{key: value},
+		}}
+	}
+	return []jsonpatch.Operation{{
+		Operation: "add",
+		Path:      "/metadata/annotations/" + strings.ReplaceAll(key, "/", "~1"),
+		Value:     value,
+	}}
+}
+
+// isWorkloadDispatchDisabled checks whether the workload of the ResourceBinding opted out of the dispatcher.
+func isWorkloadDispatchDisabled(rb *workv1alpha2.ResourceBinding) bool {
+	if dynamicClient == nil || restMapper == nil {
+		return false
+	}
+	workload, err := utils.GetWorkload(dynamicClient, restMapper, rb.Spec.Resource)
+	if err != nil {
+		klog.Errorf("Failed to get the workload of ResourceBinding <%s/%s>, suspend it by default, err: %v",
+			rb.Namespace, rb.Name, err)
+		return false
+	}
+	return utils.IsDispatchDisabled(workload)
+}
+
+// isWorkloadHeld checks whether the workload of the ResourceBinding is held by the workload webhook in the
+// SuspendModeWorkload.
+func isWorkloadHeld(rb *workv1alpha2.ResourceBinding) bool {
+	if dynamicClient == nil || restMapper == nil {
+		return false
+	}
+	workload, err := utils.GetWorkload(dynamicClient, restMapper, rb.Spec.Resource)
+	if err != nil {
+		klog.Errorf("Failed to get the workload of ResourceBinding <%s/%s>, don't suspend it, err: %v",
+			rb.Namespace, rb.Name, err)
+		return false
+	}
+	return utils.IsWorkloadHeld(workload)
+}
@@ -25,9 +25,15 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/json"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
 	"volcano.sh/volcano-global/pkg/utils"
 	"volcano.sh/volcano-global/pkg/webhooks/decoder"
@@ -65,8 +71,41 @@ func TestResourceBindings(t *testing.T) {
 		t.Errorf("Failed to marshal not suspended ResourceBinding json, err: %v", err)
 	}
 
+	optedOutRBJson, err := json.Marshal(v1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rb-opted-out",
+			Annotations: map[string]string{utils.DispatchAnnotationKey: "false"},
+		},
+		Spec: v1alpha2.ResourceBindingSpec{
+			Resource: v1alpha2.ObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Failed to marshal opted out ResourceBinding json, err: %v", err)
+	}
+
+	ignoredRBJson, err := json.Marshal(v1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "rb-ignored",
+			Labels: map[string]string{utils.IgnoreKey: "true"},
+		},
+		Spec: v1alpha2.ResourceBindingSpec{
+			Resource: v1alpha2.ObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Failed to marshal ignored ResourceBinding json, err: %v", err)
+	}
+
 	normalResponsePatch, err := json.Marshal([]jsonpatch.Operation{
 		{Operation: "replace", Path: "/spec/suspend", Value: true},
+		{Operation: "add", Path: "/metadata/annotations", Value: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "default"}},
 	})
 	if err != nil {
 		t.Errorf("Failed to marshal normal response patch json, err: %v", err)
@@ -123,7 +162,7 @@ func TestResourceBindings(t *testing.T) {
 			expectResponse: admissionv1.AdmissionResponse{
 				Allowed: false,
 				Result: &metav1.Status{
-					Message: fmt.Sprintf("expect operation to be '%s'", admissionv1.Create),
+					Message: fmt.Sprintf("expect operation is '%s'", admissionv1.Create),
 				},
 			},
 		},
@@ -140,6 +179,32 @@ func TestResourceBindings(t *testing.T) {
 				Allowed: true,
 			},
 		},
+		{
+			Name: "Opted out of the dispatcher, should not have patch",
+			review: admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource:  decoder.ResourceBindingGVR,
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: optedOutRBJson},
+				},
+			},
+			expectResponse: admissionv1.AdmissionResponse{
+				Allowed: true,
+			},
+		},
+		{
+			Name: "Ignored by the label, should not have patch",
+			review: admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource:  decoder.ResourceBindingGVR,
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: ignoredRBJson},
+				},
+			},
+			expectResponse: admissionv1.AdmissionResponse{
+				Allowed: true,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -149,3 +214,135 @@ func TestResourceBindings(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceBindingsWithSuspensionMode(t *testing.T) {
+	SetSuspendMode(utils.SuspendModeSuspension)
+	defer SetSuspendMode(utils.SuspendModeSuspend)
+
+	rbJson, err := json.Marshal(v1alpha2.ResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "rb",
+		},
+		Spec: v1alpha2.ResourceBindingSpec{
+			Resource: v1alpha2.ObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Failed to marshal ResourceBinding json, err: %v", err)
+	}
+	expectPatch, err := json.Marshal([]jsonpatch.Operation{
+		{Operation: "add", Path: "/spec/suspension", Value: map[string]interface{}{"dispatching": true}},
+		{Operation: "add", Path: "/metadata/annotations", Value: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "default"}},
+	})
+	if err != nil {
+		t.Errorf("Failed to marshal expect patch json, err: %v", err)
+	}
+
+	response := ResourceBindings(admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Resource:  decoder.ResourceBindingGVR,
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: rbJson},
+		},
+	})
+	if !reflect.DeepEqual(response.Patch, expectPatch) {
+		t.Errorf("Test case suspension mode failed, got patch: %s expect: %s", response.Patch, expectPatch)
+	}
+}
+
+func TestBuildDefaultQueuePatch(t *testing.T) {
+	config.KubeClient = fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ns-with-queue",
+			Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "ns-queue"},
+		},
+	})
+	defer func() { config.KubeClient = nil }()
+
+	testCases := []struct {
+		Name        string
+		rb          *v1alpha2.ResourceBinding
+		expectPatch []jsonpatch.Operation
+	}{
+		{
+			Name: "Queue annotation exists, should not have patch",
+			rb: &v1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns-with-queue",
+				Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "q1"},
+			}},
+		},
+		{
+			Name: "Use the queue of namespace",
+			rb: &v1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns-with-queue",
+				Annotations: map[string]string{"foo": "bar"},
+			}},
+			expectPatch: []jsonpatch.Operation{
+				{Operation: "add", Path: "/metadata/annotations/scheduling.volcano.sh~1queue-name", Value: "ns-queue"},
+			},
+		},
+		{
+			Name: "Namespace not found, use the default queue",
+			rb:   &v1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-not-found"}},
+			expectPatch: []jsonpatch.Operation{
+				{Operation: "add", Path: "/metadata/annotations", Value: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "default"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		patch := buildDefaultQueuePatch(tc.rb)
+		if !reflect.DeepEqual(patch, tc.expectPatch) {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, patch, tc.expectPatch)
+		}
+	}
+}
+
+func TestIsWorkloadDispatchDisabled(t *testing.T) {
+	newDeployment := func(name string, labels map[string]string) *unstructured.Unstructured {
+		deployment := &unstructured.Unstructured{}
+		deployment.SetAPIVersion("apps/v1")
+		deployment.SetKind("Deployment")
+		deployment.SetNamespace("ns")
+		deployment.SetName(name)
+		deployment.SetLabels(labels)
+		return deployment
+	}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	SetWorkloadClient(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newDeployment("ignored", map[string]string{utils.IgnoreKey: "true"}),
+		newDeployment("normal", nil),
+	), restMapper)
+	defer SetWorkloadClient(nil, nil)
+
+	testCases := []struct {
+		Name     string
+		workload string
+		expect   bool
+	}{
+		{Name: "Workload ignored by the label", workload: "ignored", expect: true},
+		{Name: "Normal workload", workload: "normal", expect: false},
+		{Name: "Workload not found", workload: "missing", expect: false},
+	}
+
+	for _, tc := range testCases {
+		rb := &v1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: tc.workload},
+			Spec: v1alpha2.ResourceBindingSpec{
+				Resource: v1alpha2.ObjectReference{
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+					Kind:       "Deployment",
+					Namespace:  "ns",
+					Name:       tc.workload,
+				},
+			},
+		}
+		if got := isWorkloadDispatchDisabled(rb); got != tc.expect {
+			t.Errorf("Test case %s failed, got: %v expect: %v", tc.Name, got, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// The name of the MutatingWebhookConfiguration follows the volcano webhook manager.
+const admissionPrefix = "volcano-admission-service"
+
+// ApplyWebhookSelectors sets the namespaceSelector and objectSelector of the ResourceBinding mutating webhook,
+// so the operators can scope which namespaces and ResourceBindings will be suspended.
+// The objectSelector matches the labels of the ResourceBinding instead of the workload.
+func ApplyWebhookSelectors(kubeClient kubernetes.Interface, namespaceSelector, objectSelector *metav1.LabelSelector) error {
+	webhookConfigurationName := admissionPrefix + strings.ReplaceAll(service.Path, "/", "-")
+	webhookName := service.MutatingConfig.Webhooks[0].Name
+
+	return wait.PollUntilContextTimeout(context.TODO(), time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		webhookConfiguration, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, webhookConfigurationName, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				klog.Errorf("MutatingWebhookConfiguration <%s> not found, wait for it.", webhookConfigurationName)
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get MutatingWebhookConfiguration <%s>: %v", webhookConfigurationName, err)
+		}
+
+		changed := false
+		for index := range webhookConfiguration.Webhooks {
+			webhook := &webhookConfiguration.Webhooks[index]
+			if webhook.Name != webhookName {
+				continue
+			}
+			if !reflect.DeepEqual(webhook.NamespaceSelector, namespaceSelector) || !reflect.DeepEqual(webhook.ObjectSelector, objectSelector) {
+				webhook.NamespaceSelector = namespaceSelector
+				webhook.ObjectSelector = objectSelector
+				changed = true
+			}
+		}
+		if !changed {
+			return true, nil
+		}
+
+		if _, err = kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(ctx, webhookConfiguration, metav1.UpdateOptions{}); err != nil {
+			if apierrors.IsConflict(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to update MutatingWebhookConfiguration <%s>: %v", webhookConfigurationName, err)
+		}
+		klog.V(2).Infof("Applied the namespaceSelector and objectSelector to MutatingWebhookConfiguration <%s>.", webhookConfigurationName)
+		return true, nil
+	})
+}
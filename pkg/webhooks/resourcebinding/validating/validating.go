@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+
+	workv1alpha2 "github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	admissionv1 "k8s.io/api/admission/v1"
+	registrationv1 "k8s.io/api/admissionregistration/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/router"
+	"volcano.sh/volcano/pkg/webhooks/util"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/utils"
+	"volcano.sh/volcano-global/pkg/webhooks/decoder"
+)
+
+// Init the ResourceBinding validate admissionWebhook, it will reject the workload ResourceBinding
+// whose queue doesn't exist or isn't open, otherwise it will be suspended forever, or doesn't match the queue of
+// the PodGroup of its workload.
+// The invalid `volcano.sh/preemptable` annotation is rejected for all the ResourceBindings, and so is the
+// `volcano.sh/priority-override` annotation set by the users not allowed to `override-priority` the ResourceBindings.
+func init() {
+	router.RegisterAdmission(service)
+}
+
+var config = &router.AdmissionServiceConfig{}
+
+var service = &router.AdmissionService{
+	Path: "/resourcebindings/validate",
+	Func: ResourceBindings,
+	ValidatingConfig: &registrationv1.ValidatingWebhookConfiguration{
+		Webhooks: []registrationv1.ValidatingWebhook{{
+			Name: "validateresourcebindings.volcano.sh",
+			Rules: []registrationv1.RuleWithOperations{
+				{
+					Operations: []registrationv1.OperationType{registrationv1.Create, registrationv1.Update},
+					Rule: registrationv1.Rule{
+						APIGroups:   []string{workv1alpha2.GroupVersion.Group},
+						APIVersions: []string{workv1alpha2.GroupVersion.Version},
+						Resources:   []string{workv1alpha2.ResourcePluralResourceBinding},
+					},
+				},
+			},
+		}},
+	},
+	Config: config,
+}
+
+func ResourceBindings(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request == nil || (ar.Request.Operation != admissionv1.Create && ar.Request.Operation != admissionv1.Update) {
+		// This error should not be happened; We have set the rule for CREATE and UPDATE operations only.
+		return util.ToAdmissionResponse(fmt.Errorf("expect operation to be '%s' or '%s'", admissionv1.Create, admissionv1.Update))
+	}
+	klog.V(3).Infof("Validating %s operation for ResourceBinding <%s/%s>.",
+		ar.Request.Operation, ar.Request.Namespace, ar.Request.Name)
+
+	rb, err := decoder.DecodeResourceBinding(ar.Request.Object, ar.Request.Resource)
+	if err != nil {
+		return util.ToAdmissionResponse(err)
+	}
+
+	if err = utils.ValidatePreemptableAnnotation(rb.Annotations); err != nil {
+		klog.V(3).Infof("Reject ResourceBinding <%s/%s>, err: %v", rb.Namespace, rb.Name, err)
+		return util.ToAdmissionResponse(err)
+	}
+	if err = validatePriorityOverride(ar, rb); err != nil {
+		klog.V(3).Infof("Reject ResourceBinding <%s/%s>, err: %v", rb.Namespace, rb.Name, err)
+		return util.ToAdmissionResponse(err)
+	}
+
+	response := &admissionv1.AdmissionResponse{Allowed: true}
+	// Only the suspended workload ResourceBinding waits for the dispatcher, the others don't care about the queue.
+	if !utils.IsResourceBindingSuspended(rb) {
+		return response
+	}
+	isWorkload, err := utils.DefaultWorkloadRegistry.IsWorkload(rb.Spec.Resource)
+	if err != nil {
+		return util.ToAdmissionResponse(err)
+	}
+	if !isWorkload {
+		return response
+	}
+
+	// The queue was validated when it is set, the ResourceBinding can still be updated when the queue is closed later.
+	if ar.Request.Operation == admissionv1.Update {
+		oldRB, err := decoder.DecodeResourceBinding(ar.Request.OldObject, ar.Request.Resource)
+		if err != nil {
+			return util.ToAdmissionResponse(err)
+		}
+		if oldRB.Annotations[schedulingv1beta1.QueueNameAnnotationKey] == rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey] {
+			return response
+		}
+	}
+
+	if err = validateQueue(rb); err != nil {
+		klog.V(3).Infof("Reject ResourceBinding <%s/%s>, err: %v", rb.Namespace, rb.Name, err)
+		return util.ToAdmissionResponse(err)
+	}
+	if err = validatePodGroupQueue(rb); err != nil {
+		klog.V(3).Infof("Reject ResourceBinding <%s/%s>, err: %v", rb.Namespace, rb.Name, err)
+		return util.ToAdmissionResponse(err)
+	}
+	return response
+}
+
+// validateQueue checks the queue in the annotation of the ResourceBinding exists and is open.
+func validateQueue(rb *workv1alpha2.ResourceBinding) error {
+	queueName := rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey]
+	// The dispatcher will use the default queue.
+	if queueName == "" || config.VolcanoClient == nil {
+		return nil
+	}
+
+	queue, err := config.VolcanoClient.SchedulingV1beta1().Queues().Get(context.TODO(), queueName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to find queue `%s` of %s <%s/%s>: %v",
+			queueName, rb.Spec.Resource.Kind, rb.Spec.Resource.Namespace, rb.Spec.Resource.Name, err)
+	}
+	if queue.Status.State != schedulingv1beta1.QueueStateOpen {
+		return fmt.Errorf("can only submit %s <%s/%s> to queue with state `Open`, queue `%s` status is `%s`",
+			rb.Spec.Resource.Kind, rb.Spec.Resource.Namespace, rb.Spec.Resource.Name, queue.Name, queue.Status.State)
+	}
+	return nil
+}
+
+// validatePodGroupQueue checks the queue in the annotation of the ResourceBinding matches the queue of the PodGroup
+// of its workload, the PodGroup may be created later, it's checked by the dispatcher then.
+// The PodGroup is got by the names its controllers give it, instead of listing all the PodGroups of the namespace.
+func validatePodGroupQueue(rb *workv1alpha2.ResourceBinding) error {
+	if rb.Annotations[schedulingv1beta1.QueueNameAnnotationKey] == "" || rb.Spec.Resource.UID == "" || config.VolcanoClient == nil {
+		return nil
+	}
+
+	ref := rb.Spec.Resource
+	// The PodGroups created for the pods and the Jobs are named by the prefix, and the ones of the Volcano Jobs
+	// are named by the Jobs.
+	podGroupNames := []string{vcbatch.PodgroupNamePrefix + string(ref.UID), ref.Name + "-" + string(ref.UID)}
+	for _, podGroupName := range podGroupNames {
+		podGroup, err := config.VolcanoClient.SchedulingV1beta1().PodGroups(ref.Namespace).Get(context.TODO(), podGroupName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to get PodGroup <%s/%s> of %s <%s/%s>: %v",
+				ref.Namespace, podGroupName, ref.Kind, ref.Namespace, ref.Name, err)
+		}
+		for _, ownerRef := range podGroup.OwnerReferences {
+			if ownerRef.UID == ref.UID {
+				return utils.ValidatePodGroupQueue(rb, podGroup)
+			}
+		}
+	}
+	return nil
+}
+
+// validatePriorityOverride checks the priority override annotation of the ResourceBinding is valid, and the user who
+// adds or changes it is allowed to `override-priority` the ResourceBindings by the SubjectAccessReview.
+func validatePriorityOverride(ar admissionv1.AdmissionReview, rb *workv1alpha2.ResourceBinding) error {
+	_, found, err := api.ParsePriorityOverride(rb.Annotations)
+	if err != nil || !found {
+		return err
+	}
+	if ar.Request.Operation == admissionv1.Update {
+		oldRB, err := decoder.DecodeResourceBinding(ar.Request.OldObject, ar.Request.Resource)
+		if err != nil {
+			return err
+		}
+		if oldValue, oldFound := oldRB.Annotations[api.PriorityOverrideAnnotationKey]; oldFound &&
+			oldValue == rb.Annotations[api.PriorityOverrideAnnotationKey] {
+			return nil
+		}
+	}
+	if config.KubeClient == nil {
+		return fmt.Errorf("unable to authorize annotation %s of ResourceBinding <%s/%s>",
+			api.PriorityOverrideAnnotationKey, rb.Namespace, rb.Name)
+	}
+
+	userInfo := ar.Request.UserInfo
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review, err := config.KubeClient.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: rb.Namespace,
+				Verb:      api.OverridePriorityVerb,
+				Group:     workv1alpha2.GroupVersion.Group,
+				Resource:  workv1alpha2.ResourcePluralResourceBinding,
+				Name:      rb.Name,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to authorize annotation %s of ResourceBinding <%s/%s>: %v",
+			api.PriorityOverrideAnnotationKey, rb.Namespace, rb.Name, err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("user `%s` is not allowed to %s ResourceBinding <%s/%s> by annotation %s",
+			userInfo.Username, api.OverridePriorityVerb, rb.Namespace, rb.Name, api.PriorityOverrideAnnotationKey)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/karmada-io/karmada/pkg/apis/work/v1alpha2"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	"volcano.sh/volcano-global/pkg/dispatcher/api"
+	"volcano.sh/volcano-global/pkg/webhooks/decoder"
+)
+
+func TestValidateQueue(t *testing.T) {
+	config.VolcanoClient = fake.NewSimpleClientset(
+		&schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "open"},
+			Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+		},
+		&schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "closed"},
+			Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateClosed},
+		},
+	)
+	defer func() { config.VolcanoClient = nil }()
+
+	testCases := []struct {
+		Name        string
+		queueName   string
+		expectError bool
+	}{
+		{Name: "Queue is open", queueName: "open", expectError: false},
+		{Name: "Queue is closed", queueName: "closed", expectError: true},
+		{Name: "Queue not found", queueName: "not-found", expectError: true},
+		{Name: "Queue not set", queueName: "", expectError: false},
+	}
+
+	for _, tc := range testCases {
+		rb := &v1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{
+			Name:        "rb",
+			Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: tc.queueName},
+		}}
+		err := validateQueue(rb)
+		if (err != nil) != tc.expectError {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectError)
+		}
+	}
+}
+
+func TestValidatePodGroupQueue(t *testing.T) {
+	config.VolcanoClient = fake.NewSimpleClientset(
+		&schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "podgroup-deploy1", OwnerReferences: []metav1.OwnerReference{{UID: "deploy1"}}},
+			Spec:       schedulingv1beta1.PodGroupSpec{Queue: "q1"},
+		},
+		&schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "podgroup-deploy2", OwnerReferences: []metav1.OwnerReference{{UID: "deploy2"}}},
+		},
+		&schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "deploy-job1", OwnerReferences: []metav1.OwnerReference{{UID: "job1"}}},
+			Spec:       schedulingv1beta1.PodGroupSpec{Queue: "q1"},
+		},
+		&schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "podgroup-deploy5", OwnerReferences: []metav1.OwnerReference{{UID: "other"}}},
+			Spec:       schedulingv1beta1.PodGroupSpec{Queue: "q1"},
+		},
+	)
+	defer func() { config.VolcanoClient = nil }()
+
+	testCases := []struct {
+		Name        string
+		workloadUID types.UID
+		queueName   string
+		expectError bool
+	}{
+		{Name: "Queue matches the PodGroup", workloadUID: "deploy1", queueName: "q1", expectError: false},
+		{Name: "Queue doesn't match the PodGroup", workloadUID: "deploy1", queueName: "q2", expectError: true},
+		{Name: "PodGroup without queue", workloadUID: "deploy2", queueName: "q2", expectError: false},
+		{Name: "PodGroup not created yet", workloadUID: "deploy3", queueName: "q2", expectError: false},
+		{Name: "Queue doesn't match the PodGroup of the Volcano Job", workloadUID: "job1", queueName: "q2", expectError: true},
+		{Name: "PodGroup owned by another workload", workloadUID: "deploy5", queueName: "q2", expectError: false},
+	}
+
+	for _, tc := range testCases {
+		rb := &v1alpha2.ResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns",
+				Name:        "rb",
+				Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: tc.queueName},
+			},
+			Spec: v1alpha2.ResourceBindingSpec{Resource: v1alpha2.ObjectReference{
+				Kind: "Deployment", Namespace: "ns", Name: "deploy", UID: tc.workloadUID,
+			}},
+		}
+		err := validatePodGroupQueue(rb)
+		if (err != nil) != tc.expectError {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectError)
+		}
+	}
+}
+
+func TestValidatePriorityOverride(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.User == "admin" && review.Spec.ResourceAttributes.Verb == api.OverridePriorityVerb
+		return true, review, nil
+	})
+	config.KubeClient = kubeClient
+	defer func() { config.KubeClient = nil }()
+
+	testCases := []struct {
+		Name        string
+		user        string
+		operation   admissionv1.Operation
+		oldOverride string
+		override    string
+		expectError bool
+	}{
+		{Name: "Admin sets the override", user: "admin", operation: admissionv1.Create, override: "1000", expectError: false},
+		{Name: "User sets the override", user: "user", operation: admissionv1.Create, override: "1000", expectError: true},
+		{Name: "Invalid override", user: "admin", operation: admissionv1.Create, override: "urgent", expectError: true},
+		{Name: "User keeps the override", user: "user", operation: admissionv1.Update, oldOverride: "1000", override: "1000", expectError: false},
+		{Name: "User changes the override", user: "user", operation: admissionv1.Update, oldOverride: "1000", override: "2000", expectError: true},
+		{Name: "Override not set", user: "user", operation: admissionv1.Create, expectError: false},
+	}
+
+	rawResourceBinding := func(override string) runtime.RawExtension {
+		rb := &v1alpha2.ResourceBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "rb"}}
+		if override != "" {
+			rb.Annotations = map[string]string{api.PriorityOverrideAnnotationKey: override}
+		}
+		raw, _ := json.Marshal(rb)
+		return runtime.RawExtension{Raw: raw}
+	}
+
+	for _, tc := range testCases {
+		ar := admissionv1.AdmissionReview{Request: &admissionv1.AdmissionRequest{
+			Operation: tc.operation,
+			Resource:  decoder.ResourceBindingGVR,
+			UserInfo:  authenticationv1.UserInfo{Username: tc.user},
+			Object:    rawResourceBinding(tc.override),
+			OldObject: rawResourceBinding(tc.oldOverride),
+		}}
+		rb, err := decoder.DecodeResourceBinding(ar.Request.Object, ar.Request.Resource)
+		if err != nil {
+			t.Errorf("Test case %s failed, got err: %v", tc.Name, err)
+			continue
+		}
+		err = validatePriorityOverride(ar, rb)
+		if (err != nil) != tc.expectError {
+			t.Errorf("Test case %s failed, got err: %v expect error: %v", tc.Name, err, tc.expectError)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	registrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/klog/v2"
+	"volcano.sh/volcano/pkg/webhooks/router"
+	"volcano.sh/volcano/pkg/webhooks/util"
+
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+var config = &router.AdmissionServiceConfig{}
+
+var service = &router.AdmissionService{
+	Path: "/workloads/mutate",
+	Func: Workloads,
+	MutatingConfig: &registrationv1.MutatingWebhookConfiguration{
+		Webhooks: []registrationv1.MutatingWebhook{{
+			Name: "mutateworkloads.volcano.sh",
+			Rules: []registrationv1.RuleWithOperations{
+				{
+					Operations: []registrationv1.OperationType{registrationv1.Create},
+					Rule: registrationv1.Rule{
+						APIGroups:   []string{batchv1.GroupName},
+						APIVersions: []string{batchv1.SchemeGroupVersion.Version},
+						Resources:   []string{"jobs"},
+					},
+				},
+				{
+					Operations: []registrationv1.OperationType{registrationv1.Create},
+					Rule: registrationv1.Rule{
+						APIGroups:   []string{appsv1.GroupName},
+						APIVersions: []string{appsv1.SchemeGroupVersion.Version},
+						Resources:   []string{"deployments"},
+					},
+				},
+			},
+		}},
+	},
+	Config: config,
+}
+
+// suspendMode is the field used to suspend the ResourceBindings, the workloads are held only in the
+// SuspendModeWorkload.
+var suspendMode = utils.SuspendModeSuspend
+
+// SetSuspendMode set the field used to suspend the ResourceBindings.
+func SetSuspendMode(mode utils.SuspendMode) {
+	suspendMode = mode
+}
+
+// Register registers the workload mutate admissionWebhook, it will hold the Jobs by `spec.suspend` and the
+// Deployments by `spec.paused` when they're created, for the older Karmada which can't suspend the ResourceBindings.
+// The dispatcher releases them when they're admitted. It's only registered in the SuspendModeWorkload, so the
+// webhook configuration isn't created for the clusters which suspend the ResourceBindings.
+func Register() error {
+	return router.RegisterAdmission(service)
+}
+
+func Workloads(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request == nil || ar.Request.Operation != admissionv1.Create {
+		// This error should not be happened; We have set the rule for CREATE operation only.
+		return util.ToAdmissionResponse(fmt.Errorf("expect operation to be '%s'", admissionv1.Create))
+	}
+	klog.V(3).Infof("Mutating %s operation for %s <%s/%s>.",
+		ar.Request.Operation, ar.Request.Kind.Kind, ar.Request.Namespace, ar.Request.Name)
+
+	response := &admissionv1.AdmissionResponse{Allowed: true}
+	if suspendMode != utils.SuspendModeWorkload {
+		return response
+	}
+
+	gvk := schema.GroupVersionKind{Group: ar.Request.Kind.Group, Version: ar.Request.Kind.Version, Kind: ar.Request.Kind.Kind}
+	path, found := utils.WorkloadHoldPath(gvk.GroupKind())
+	if !found || !utils.DefaultWorkloadRegistry.IsWorkloadGVK(gvk) {
+		return response
+	}
+
+	workload := &unstructured.Unstructured{}
+	if err := json.Unmarshal(ar.Request.Object.Raw, &workload.Object); err != nil {
+		return util.ToAdmissionResponse(err)
+	}
+	if utils.IsDispatchDisabled(workload) {
+		klog.V(3).Infof("%s <%s/%s> opted out of the dispatcher, skip holding it.", gvk.Kind, ar.Request.Namespace, workload.GetName())
+		return response
+	}
+	// The workload held by the user is left to the user, the dispatcher never releases it.
+	if held, _, _ := unstructured.NestedBool(workload.Object, fieldsOf(path)...); held {
+		klog.V(3).Infof("%s <%s/%s> is held by the user, skip holding it.", gvk.Kind, ar.Request.Namespace, workload.GetName())
+		return response
+	}
+
+	patch, err := json.Marshal(utils.BuildWorkloadHoldPatch(workload, path, true))
+	if err != nil {
+		return util.ToAdmissionResponse(err)
+	}
+	response.Patch = patch
+	response.PatchType = utils.ToPointer(admissionv1.PatchTypeJSONPatch)
+	return response
+}
+
+// fieldsOf splits the json patch path of the field, like `/spec/suspend`, into the fields of the unstructured object.
+func fieldsOf(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
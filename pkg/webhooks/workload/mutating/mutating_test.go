@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"reflect"
+	"testing"
+
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"volcano.sh/volcano-global/pkg/utils"
+)
+
+func TestWorkloads(t *testing.T) {
+	jobKind := metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	deploymentKind := metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	statefulSetKind := metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
+
+	testCases := []struct {
+		Name        string
+		suspendMode utils.SuspendMode
+		kind        metav1.GroupVersionKind
+		workload    map[string]interface{}
+		expectPatch []jsonpatch.Operation
+	}{
+		{
+			Name:        "Hold the Job",
+			suspendMode: utils.SuspendModeWorkload,
+			kind:        jobKind,
+			workload:    map[string]interface{}{"metadata": map[string]interface{}{"name": "job"}},
+			expectPatch: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/suspend", Value: true},
+				{Operation: "add", Path: "/metadata/annotations", Value: map[string]interface{}{utils.WorkloadHeldAnnotationKey: "true"}},
+			},
+		},
+		{
+			Name:        "Hold the Deployment",
+			suspendMode: utils.SuspendModeWorkload,
+			kind:        deploymentKind,
+			workload: map[string]interface{}{"metadata": map[string]interface{}{"name": "deploy",
+				"annotations": map[string]interface{}{"foo": "bar"}}},
+			expectPatch: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/paused", Value: true},
+				{Operation: "add", Path: "/metadata/annotations/volcano.sh~1workload-held", Value: "true"},
+			},
+		},
+		{
+			Name:        "Not in the workload mode",
+			suspendMode: utils.SuspendModeSuspend,
+			kind:        jobKind,
+			workload:    map[string]interface{}{"metadata": map[string]interface{}{"name": "job"}},
+		},
+		{
+			Name:        "Kind can't be held",
+			suspendMode: utils.SuspendModeWorkload,
+			kind:        statefulSetKind,
+			workload:    map[string]interface{}{"metadata": map[string]interface{}{"name": "sts"}},
+		},
+		{
+			Name:        "Held by the user",
+			suspendMode: utils.SuspendModeWorkload,
+			kind:        jobKind,
+			workload: map[string]interface{}{"metadata": map[string]interface{}{"name": "job"},
+				"spec": map[string]interface{}{"suspend": true}},
+		},
+		{
+			Name:        "Opted out of the dispatcher",
+			suspendMode: utils.SuspendModeWorkload,
+			kind:        jobKind,
+			workload: map[string]interface{}{"metadata": map[string]interface{}{"name": "job",
+				"annotations": map[string]interface{}{utils.DispatchAnnotationKey: "false"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		SetSuspendMode(tc.suspendMode)
+		raw, err := json.Marshal(tc.workload)
+		if err != nil {
+			t.Errorf("Test case %s failed, marshal workload err: %v", tc.Name, err)
+			continue
+		}
+		response := Workloads(admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Kind:      tc.kind,
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+		if !response.Allowed {
+			t.Errorf("Test case %s failed, got rejected: %v", tc.Name, response.Result)
+			continue
+		}
+
+		var gotPatch []jsonpatch.Operation
+		if response.Patch != nil {
+			if err := json.Unmarshal(response.Patch, &gotPatch); err != nil {
+				t.Errorf("Test case %s failed, unmarshal patch err: %v", tc.Name, err)
+				continue
+			}
+		}
+		if !reflect.DeepEqual(gotPatch, tc.expectPatch) {
+			t.Errorf("Test case %s failed, got patch: %+v expect: %+v", tc.Name, gotPatch, tc.expectPatch)
+		}
+	}
+	SetSuspendMode(utils.SuspendModeSuspend)
+}